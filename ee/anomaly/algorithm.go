@@ -0,0 +1,52 @@
+package anomaly
+
+import (
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// Algorithm is the algorithm used to predict the values of a series and score the deviations from the predictions
+type Algorithm struct{ valuer.String }
+
+var (
+	// AlgorithmStandard predicts the values with the moving average of the past period adjusted by the seasonal
+	// growth, and scores the deviations with the z-score against the current season
+	AlgorithmStandard = Algorithm{valuer.NewString("standard")}
+	// AlgorithmHoltWinters predicts the values with additive triple exponential smoothing
+	AlgorithmHoltWinters = Algorithm{valuer.NewString("holt_winters")}
+	// AlgorithmMAD predicts the values with the median of the past seasons at the same time of the season, and
+	// scores the deviations with the median absolute deviation, which ignores outliers like holidays
+	AlgorithmMAD = Algorithm{valuer.NewString("mad")}
+	// AlgorithmSTL predicts the values with the trend and the seasonal components of a robust seasonal-trend
+	// decomposition, weekly seasonality is decomposed into daily and weekly components
+	AlgorithmSTL = Algorithm{valuer.NewString("stl")}
+)
+
+func (a Algorithm) IsValid() bool {
+	switch a {
+	case AlgorithmStandard, AlgorithmHoltWinters, AlgorithmMAD, AlgorithmSTL:
+		return true
+	default:
+		return false
+	}
+}
+
+// Period returns the length of the seasonality in milliseconds
+func (s Seasonality) Period() uint64 {
+	switch s {
+	case SeasonalityHourly:
+		return oneHourOffset
+	case SeasonalityWeekly:
+		return oneWeekOffset
+	default:
+		return oneDayOffset
+	}
+}
+
+// Periods returns the lengths of the seasonalities in milliseconds which overlap in the seasonality, from the
+// shortest to the longest
+func (s Seasonality) Periods() []uint64 {
+	if s == SeasonalityWeekly {
+		return []uint64{oneDayOffset, oneWeekOffset}
+	}
+	return []uint64{s.Period()}
+}
@@ -0,0 +1,222 @@
+package anomaly
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/querier"
+	"github.com/SigNoz/signoz/pkg/querybuilder"
+	"github.com/SigNoz/signoz/pkg/valuer"
+
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+)
+
+// historySeasons is the number of past seasons the models are fitted to
+var historySeasons = uint64(4)
+
+// ForecastProvider detects the anomalies with a model of the algorithm, which is fitted to the past seasons of every
+// series and predicts the values of the current period.
+type ForecastProvider struct {
+	algorithm Algorithm
+	querier   querier.Querier
+	logger    *slog.Logger
+}
+
+var _ Provider = (*ForecastProvider)(nil)
+
+func NewForecastProvider(algorithm Algorithm, querier querier.Querier, logger *slog.Logger) *ForecastProvider {
+	return &ForecastProvider{
+		algorithm: algorithm,
+		querier:   querier,
+		logger:    logger,
+	}
+}
+
+func (p *ForecastProvider) GetAnomalies(ctx context.Context, orgID valuer.UUID, req *AnomaliesRequest) (*AnomaliesResponse, error) {
+	if !req.Seasonality.IsValid() {
+		req.Seasonality = SeasonalityDaily
+	}
+
+	start := req.Params.Start
+	historyStart, step := HistoryWindow(req.Seasonality, start)
+
+	currentPeriodQuery := qbtypes.QueryRangeRequest{
+		Start:          start,
+		End:            req.Params.End,
+		RequestType:    qbtypes.RequestTypeTimeSeries,
+		CompositeQuery: req.Params.CompositeQuery,
+		NoCache:        false,
+	}
+
+	historyQuery := qbtypes.QueryRangeRequest{
+		Start:          historyStart,
+		End:            start,
+		RequestType:    qbtypes.RequestTypeTimeSeries,
+		CompositeQuery: withStepInterval(req.Params.CompositeQuery, step),
+		NoCache:        false,
+	}
+
+	p.logger.InfoContext(ctx, "fetching results for current period", "anomaly_algorithm", p.algorithm.StringValue(), "anomaly_current_period_query", currentPeriodQuery)
+	currentPeriodResp, err := p.querier.QueryRange(ctx, orgID, &currentPeriodQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	p.logger.InfoContext(ctx, "fetching results for history", "anomaly_algorithm", p.algorithm.StringValue(), "anomaly_history_query", historyQuery)
+	historyResp, err := p.querier.QueryRange(ctx, orgID, &historyQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	historyResults := make(map[string]*qbtypes.TimeSeriesData)
+	for _, result := range toTimeSeriesData(historyResp) {
+		historyResults[result.QueryName] = result
+	}
+
+	results := toTimeSeriesData(currentPeriodResp)
+	for _, result := range results {
+		if !req.scores(result.QueryName) {
+			continue
+		}
+
+		zScoreThreshold := zScoreThresholdFor(req.Params.FuncsForQuery(result.QueryName))
+
+		historyResult, ok := historyResults[result.QueryName]
+		if !ok || len(result.Aggregations) == 0 || len(historyResult.Aggregations) == 0 {
+			continue
+		}
+
+		aggOfInterest := result.Aggregations[0]
+		historySeries := make(map[string]*qbtypes.TimeSeries)
+		for _, series := range historyResult.Aggregations[0].Series {
+			historySeries[qbtypes.GetUniqueSeriesKey(series.Labels)] = series
+		}
+
+		for _, series := range aggOfInterest.Series {
+			history, ok := historySeries[qbtypes.GetUniqueSeriesKey(series.Labels)]
+			if !ok {
+				p.logger.InfoContext(ctx, "no history for series, skipping", "anomaly_labels", series.Labels)
+				continue
+			}
+
+			samples := make([]Sample, 0, len(history.Values))
+			for _, value := range history.Values {
+				samples = append(samples, Sample{Timestamp: value.Timestamp, Value: value.Value})
+			}
+
+			model, err := FitModel(p.algorithm, samples, req.Seasonality, step*1000)
+			if err != nil {
+				p.logger.InfoContext(ctx, "failed to fit the model for series, skipping", "error", err, "anomaly_labels", series.Labels)
+				continue
+			}
+
+			predictedSeries := &qbtypes.TimeSeries{Labels: series.Labels, Values: make([]*qbtypes.TimeSeriesValue, 0, len(series.Values))}
+			upperBoundSeries := &qbtypes.TimeSeries{Labels: series.Labels, Values: make([]*qbtypes.TimeSeriesValue, 0, len(series.Values))}
+			lowerBoundSeries := &qbtypes.TimeSeries{Labels: series.Labels, Values: make([]*qbtypes.TimeSeriesValue, 0, len(series.Values))}
+			anomalyScoreSeries := &qbtypes.TimeSeries{Labels: series.Labels, Values: make([]*qbtypes.TimeSeriesValue, 0, len(series.Values))}
+
+			for _, curr := range series.Values {
+				predicted := model.Predict(curr.Timestamp)
+				deviation := model.Deviation(curr.Timestamp)
+
+				predictedSeries.Values = append(predictedSeries.Values, &qbtypes.TimeSeriesValue{Timestamp: curr.Timestamp, Value: predicted})
+				upperBoundSeries.Values = append(upperBoundSeries.Values, &qbtypes.TimeSeriesValue{Timestamp: curr.Timestamp, Value: predicted + zScoreThreshold*deviation})
+				lowerBoundSeries.Values = append(lowerBoundSeries.Values, &qbtypes.TimeSeriesValue{Timestamp: curr.Timestamp, Value: math.Max(predicted-zScoreThreshold*deviation, 0)})
+				anomalyScoreSeries.Values = append(anomalyScoreSeries.Values, &qbtypes.TimeSeriesValue{Timestamp: curr.Timestamp, Value: Score(curr.Value, predicted, deviation)})
+			}
+
+			aggOfInterest.PredictedSeries = append(aggOfInterest.PredictedSeries, predictedSeries)
+			aggOfInterest.UpperBoundSeries = append(aggOfInterest.UpperBoundSeries, upperBoundSeries)
+			aggOfInterest.LowerBoundSeries = append(aggOfInterest.LowerBoundSeries, lowerBoundSeries)
+			aggOfInterest.AnomalyScores = append(aggOfInterest.AnomalyScores, anomalyScoreSeries)
+		}
+	}
+
+	resp := &AnomaliesResponse{
+		Results: results,
+	}
+	if currentPeriodResp != nil {
+		resp.Meta = currentPeriodResp.Meta
+		resp.Warning = currentPeriodResp.Warning
+	}
+
+	return resp, nil
+}
+
+// HistoryWindow returns the start of the history the models are fitted to for the period starting at start, and the
+// step in seconds of the history. The step is the smallest step allowed for the history which divides the shortest
+// period of the seasonality.
+func HistoryWindow(seasonality Seasonality, start uint64) (uint64, uint64) {
+	historyStart := start - historySeasons*seasonality.Period()
+
+	shortest := seasonality.Periods()[0] / 1000
+	minStep := querybuilder.MinAllowedStepIntervalForMetric(historyStart, start)
+	for step := (minStep + 59) / 60 * 60; step < shortest; step += 60 {
+		if shortest%step == 0 {
+			return historyStart, step
+		}
+	}
+	return historyStart, shortest
+}
+
+// withStepInterval returns a copy of the composite query with the step interval of the builder queries and trace
+// operators set to the step
+func withStepInterval(compositeQuery qbtypes.CompositeQuery, step uint64) qbtypes.CompositeQuery {
+	stepInterval := qbtypes.Step{Duration: time.Duration(step) * time.Second}
+
+	queries := make([]qbtypes.QueryEnvelope, len(compositeQuery.Queries))
+	for idx, query := range compositeQuery.Queries {
+		switch spec := query.Spec.(type) {
+		case qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]:
+			spec.StepInterval = stepInterval
+			query.Spec = spec
+		case qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]:
+			spec.StepInterval = stepInterval
+			query.Spec = spec
+		case qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]:
+			spec.StepInterval = stepInterval
+			query.Spec = spec
+		case qbtypes.QueryBuilderTraceOperator:
+			spec.StepInterval = stepInterval
+			query.Spec = spec
+		}
+		queries[idx] = query
+	}
+
+	compositeQuery.Queries = queries
+	return compositeQuery
+}
+
+func toTimeSeriesData(resp *qbtypes.QueryRangeResponse) []*qbtypes.TimeSeriesData {
+	tsData := []*qbtypes.TimeSeriesData{}
+	if resp == nil {
+		return tsData
+	}
+
+	for _, item := range resp.Data.Results {
+		if resultData, ok := item.(*qbtypes.TimeSeriesData); ok {
+			tsData = append(tsData, resultData)
+		}
+	}
+	return tsData
+}
+
+// zScoreThresholdFor returns the z_score_threshold argument of the anomaly function, which defaults to 3
+func zScoreThresholdFor(funcs []qbtypes.Function) float64 {
+	for _, f := range funcs {
+		if f.Name != qbtypes.FunctionNameAnomaly {
+			continue
+		}
+		for _, arg := range f.Args {
+			if arg.Name != "z_score_threshold" {
+				continue
+			}
+			if value, ok := arg.Value.(float64); ok {
+				return value
+			}
+		}
+	}
+	return 3
+}
@@ -0,0 +1,71 @@
+package anomaly
+
+import (
+	"github.com/SigNoz/signoz/pkg/errors"
+)
+
+// the smoothing factors of the level, trend and seasonal components
+var (
+	holtWintersAlpha = 0.3
+	holtWintersBeta  = 0.05
+	holtWintersGamma = 0.2
+)
+
+// holtWintersModel is an additive triple exponential smoothing model
+type holtWintersModel struct {
+	g         *grid
+	period    int
+	level     float64
+	trend     float64
+	seasonal  []float64 // by phase
+	deviation float64
+}
+
+var _ Model = (*holtWintersModel)(nil)
+
+// fitHoltWinters fits the model to the history, the first season initializes the components and the following
+// seasons update them. The expected deviation is the scaled median absolute deviation of the one step ahead
+// prediction errors, so that the outliers in the history don't widen it.
+func fitHoltWinters(g *grid, period int) (*holtWintersModel, error) {
+	n := len(g.values)
+	if period < 2 || n < 2*period {
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "holt-winters requires at least two seasons of %d steps, got %d steps", period, n)
+	}
+
+	m := &holtWintersModel{g: g, period: period, seasonal: make([]float64, period)}
+
+	firstSeason := mean(g.values[:period])
+	m.level = firstSeason
+	m.trend = (mean(g.values[period:2*period]) - firstSeason) / float64(period)
+	for idx := 0; idx < period; idx++ {
+		m.seasonal[g.phase(idx, period)] = g.values[idx] - firstSeason
+	}
+
+	errs := make([]float64, 0, n-period)
+	for idx := period; idx < n; idx++ {
+		phase := g.phase(idx, period)
+		value := g.values[idx]
+
+		predicted := m.level + m.trend + m.seasonal[phase]
+		errs = append(errs, value-predicted)
+
+		level := holtWintersAlpha*(value-m.seasonal[phase]) + (1-holtWintersAlpha)*(m.level+m.trend)
+		m.trend = holtWintersBeta*(level-m.level) + (1-holtWintersBeta)*m.trend
+		m.seasonal[phase] = holtWintersGamma*(value-level) + (1-holtWintersGamma)*m.seasonal[phase]
+		m.level = level
+	}
+	m.deviation = madScale * mad(errs, median(errs))
+
+	return m, nil
+}
+
+func (m *holtWintersModel) Predict(timestamp int64) float64 {
+	idx := m.g.index(timestamp)
+	// the components are at the last value of the history
+	ahead := max(idx-(len(m.g.values)-1), 1)
+	return m.level + float64(ahead)*m.trend + m.seasonal[m.g.phase(idx, m.period)]
+}
+
+func (m *holtWintersModel) Deviation(int64) float64 {
+	return m.deviation
+}
@@ -0,0 +1,64 @@
+package anomaly
+
+import (
+	"github.com/SigNoz/signoz/pkg/errors"
+)
+
+// madNeighbours is the number of steps before and after the phase whose values are included in the phase, so that
+// there are enough values when the history has a few seasons
+var madNeighbours = 2
+
+// madModel predicts the median of the values at the same phase in the past seasons
+type madModel struct {
+	g          *grid
+	period     int
+	medians    []float64 // by phase
+	deviations []float64 // by phase
+}
+
+var _ Model = (*madModel)(nil)
+
+// fitMAD fits the model to the history. The expected deviation is the scaled median absolute deviation of the values
+// at the phase, or of all the values from their phase medians when the values at the phase don't deviate.
+func fitMAD(g *grid, period int) (*madModel, error) {
+	if period < 1 || len(g.values) < period {
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "mad requires at least one season of %d steps, got %d steps", period, len(g.values))
+	}
+
+	m := &madModel{g: g, period: period, medians: make([]float64, period), deviations: make([]float64, period)}
+
+	phases := make([][]float64, period)
+	for idx, value := range g.values {
+		phase := g.phase(idx, period)
+		for offset := -madNeighbours; offset <= madNeighbours; offset++ {
+			neighbour := ((phase+offset)%period + period) % period
+			phases[neighbour] = append(phases[neighbour], value)
+		}
+	}
+
+	for phase, values := range phases {
+		m.medians[phase] = median(values)
+		m.deviations[phase] = madScale * mad(values, m.medians[phase])
+	}
+
+	residuals := make([]float64, len(g.values))
+	for idx, value := range g.values {
+		residuals[idx] = value - m.medians[g.phase(idx, period)]
+	}
+	overall := madScale * mad(residuals, median(residuals))
+	for phase := range m.deviations {
+		if m.deviations[phase] == 0 {
+			m.deviations[phase] = overall
+		}
+	}
+
+	return m, nil
+}
+
+func (m *madModel) Predict(timestamp int64) float64 {
+	return m.medians[m.g.phase(m.g.index(timestamp), m.period)]
+}
+
+func (m *madModel) Deviation(timestamp int64) float64 {
+	return m.deviations[m.g.phase(m.g.index(timestamp), m.period)]
+}
@@ -0,0 +1,179 @@
+package anomaly
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+)
+
+// madScale scales the median absolute deviation to the standard deviation of normally distributed values
+const madScale = 1.4826
+
+// Sample is the value of a series at a timestamp in milliseconds
+type Sample struct {
+	Timestamp int64
+	Value     float64
+}
+
+// Model predicts the values of a series, it is fitted to the history of the series.
+type Model interface {
+	// Predict returns the predicted value at the timestamp
+	Predict(timestamp int64) float64
+	// Deviation returns the expected deviation of the value at the timestamp from the prediction, the anomaly score
+	// of a value is its deviation from the prediction in units of the expected deviation
+	Deviation(timestamp int64) float64
+}
+
+// FitModel fits the model of the algorithm to the history of a series. The history is resampled to the step in
+// milliseconds, which must divide the periods of the seasonality.
+func FitModel(algorithm Algorithm, history []Sample, seasonality Seasonality, step uint64) (Model, error) {
+	g, err := newGrid(history, int64(step))
+	if err != nil {
+		return nil, err
+	}
+
+	periods := make([]int, 0, len(seasonality.Periods()))
+	for _, period := range seasonality.Periods() {
+		if period%step != 0 {
+			return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "step %d doesn't divide the %s seasonality", step, seasonality.StringValue())
+		}
+		periods = append(periods, int(period/step))
+	}
+
+	switch algorithm {
+	case AlgorithmHoltWinters:
+		return fitHoltWinters(g, periods[len(periods)-1])
+	case AlgorithmMAD:
+		return fitMAD(g, periods[len(periods)-1])
+	case AlgorithmSTL:
+		return fitSTL(g, periods)
+	}
+
+	return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "unsupported anomaly algorithm %s", algorithm.StringValue())
+}
+
+// Score returns the anomaly score of the value for the prediction and the expected deviation
+func Score(value, predicted, deviation float64) float64 {
+	if deviation == 0 || math.IsNaN(deviation) {
+		return 0
+	}
+	return (value - predicted) / deviation
+}
+
+// grid is a series resampled to a regular step. The steps are aligned to the epoch so that the positions in the
+// seasons are the same for every series.
+type grid struct {
+	// first is the number of steps since the epoch of the first value
+	first  int64
+	step   int64
+	values []float64
+}
+
+func newGrid(samples []Sample, step int64) (*grid, error) {
+	if step <= 0 {
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "step must be positive, got %d", step)
+	}
+
+	sorted := make([]Sample, 0, len(samples))
+	for _, sample := range samples {
+		if !math.IsNaN(sample.Value) && !math.IsInf(sample.Value, 0) {
+			sorted = append(sorted, sample)
+		}
+	}
+	if len(sorted) == 0 {
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "no history to fit the model")
+	}
+	slices.SortFunc(sorted, func(a, b Sample) int {
+		return cmp.Compare(a.Timestamp, b.Timestamp)
+	})
+
+	g := &grid{first: sorted[0].Timestamp / step, step: step}
+	last := sorted[len(sorted)-1].Timestamp / step
+
+	sums := make([]float64, last-g.first+1)
+	counts := make([]int, len(sums))
+	for _, sample := range sorted {
+		idx := g.index(sample.Timestamp)
+		sums[idx] += sample.Value
+		counts[idx]++
+	}
+
+	// average the values in every step, and interpolate the missing steps
+	g.values = make([]float64, len(sums))
+	prev := -1
+	for idx := range sums {
+		if counts[idx] == 0 {
+			continue
+		}
+		g.values[idx] = sums[idx] / float64(counts[idx])
+		if prev == -1 {
+			for fill := 0; fill < idx; fill++ {
+				g.values[fill] = g.values[idx]
+			}
+		} else {
+			for fill := prev + 1; fill < idx; fill++ {
+				frac := float64(fill-prev) / float64(idx-prev)
+				g.values[fill] = g.values[prev] + frac*(g.values[idx]-g.values[prev])
+			}
+		}
+		prev = idx
+	}
+
+	return g, nil
+}
+
+// index returns the position of the timestamp in the grid, which is past the values for future timestamps
+func (g *grid) index(timestamp int64) int {
+	return int(timestamp/g.step - g.first)
+}
+
+// phase returns the position in the season of the given length for the position in the grid
+func (g *grid) phase(idx int, period int) int {
+	return int(((g.first+int64(idx))%int64(period) + int64(period)) % int64(period))
+}
+
+// seasonIndex returns the position of the latest value at the same phase as the position, for the season of the
+// given length
+func (g *grid) seasonIndex(idx int, period int) int {
+	n := len(g.values)
+	if idx < n {
+		return max(idx, 0)
+	}
+	seasons := (idx - (n - 1) + period - 1) / period
+	return idx - seasons*period
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// mad returns the median absolute deviation of the values from the center
+func mad(values []float64, center float64) float64 {
+	deviations := make([]float64, len(values))
+	for idx, value := range values {
+		deviations[idx] = math.Abs(value - center)
+	}
+	return median(deviations)
+}
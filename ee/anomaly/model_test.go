@@ -0,0 +1,85 @@
+package anomaly
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFitModel(t *testing.T) {
+	step := uint64(3600 * 1000)
+	hour := int64(step)
+	day := 24 * hour
+	start := int64(1700006400000) // aligned to the day
+
+	// daily seasonality with a small weekly one, and an outlier in the history
+	noise := rand.New(rand.NewSource(1))
+	value := func(ts int64) float64 {
+		v := 100 + 50*math.Sin(2*math.Pi*float64(ts%day)/float64(day))
+		if (ts/day)%7 == 5 {
+			v += 10
+		}
+		return v
+	}
+
+	var history []Sample
+	for ts := start - 28*day; ts < start; ts += hour {
+		v := value(ts) + noise.NormFloat64()
+		if ts >= start-10*day && ts < start-10*day+6*hour {
+			v *= 5
+		}
+		history = append(history, Sample{Timestamp: ts, Value: v})
+	}
+
+	for _, algorithm := range []Algorithm{AlgorithmHoltWinters, AlgorithmMAD, AlgorithmSTL} {
+		t.Run(algorithm.StringValue(), func(t *testing.T) {
+			model, err := FitModel(algorithm, history, SeasonalityWeekly, step)
+			require.NoError(t, err)
+
+			for ts := start; ts < start+6*hour; ts += hour {
+				predicted := model.Predict(ts)
+				deviation := model.Deviation(ts)
+
+				assert.InDelta(t, value(ts), predicted, 15, "prediction at %d", ts)
+				assert.Less(t, math.Abs(Score(value(ts), predicted, deviation)), 3.0, "score of the expected value at %d", ts)
+				assert.Greater(t, Score(3*value(ts), predicted, deviation), 3.0, "score of the spike at %d", ts)
+			}
+		})
+	}
+}
+
+func TestFitModel_NotEnoughHistory(t *testing.T) {
+	step := uint64(3600 * 1000)
+	history := []Sample{{Timestamp: 1700006400000, Value: 1}, {Timestamp: 1700010000000, Value: 2}}
+
+	for _, algorithm := range []Algorithm{AlgorithmHoltWinters, AlgorithmMAD, AlgorithmSTL} {
+		t.Run(algorithm.StringValue(), func(t *testing.T) {
+			_, err := FitModel(algorithm, history, SeasonalityDaily, step)
+			require.Error(t, err)
+		})
+	}
+
+	_, err := FitModel(AlgorithmStandard, history, SeasonalityDaily, step)
+	require.Error(t, err)
+}
+
+func TestNewGrid(t *testing.T) {
+	g, err := newGrid([]Sample{
+		{Timestamp: 3000, Value: 4},
+		{Timestamp: 1000, Value: 1},
+		{Timestamp: 1500, Value: 3},
+		{Timestamp: 6000, Value: math.NaN()},
+		{Timestamp: 6000, Value: 10},
+	}, 1000)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(1), g.first)
+	assert.Equal(t, []float64{2, 3, 4, 6, 8, 10}, g.values)
+	assert.Equal(t, 7, g.index(8000))
+	assert.Equal(t, 1, g.phase(6, 3))
+	assert.Equal(t, 3, g.seasonIndex(6, 3))
+	assert.Equal(t, 4, g.seasonIndex(7, 3))
+}
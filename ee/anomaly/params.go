@@ -1,8 +1,11 @@
 package anomaly
 
 import (
+	"slices"
 	"time"
 
+	"github.com/SigNoz/govaluate"
+	"github.com/SigNoz/signoz/pkg/errors"
 	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
 	"github.com/SigNoz/signoz/pkg/valuer"
 )
@@ -34,10 +37,94 @@ func (s Seasonality) IsValid() bool {
 type AnomaliesRequest struct {
 	Params      qbtypes.QueryRangeRequest
 	Seasonality Seasonality
+	// Queries are the names of the queries, formulas and trace operators whose results are scored, every time
+	// series result is scored when empty
+	Queries []string
+}
+
+// scores returns whether the result of the query is scored
+func (r *AnomaliesRequest) scores(queryName string) bool {
+	return len(r.Queries) == 0 || slices.Contains(r.Queries, queryName)
+}
+
+// WithQueries returns the request with only the queries for which keep returns true and the queries they depend on,
+// the formula variables, the operands of the trace operators and joins and the queries referenced with `IN @name`
+func WithQueries(req qbtypes.QueryRangeRequest, keep func(name string) bool) (qbtypes.QueryRangeRequest, error) {
+	names := make([]string, len(req.CompositeQuery.Queries))
+	deps := make(map[string][]string, len(req.CompositeQuery.Queries))
+	for idx, query := range req.CompositeQuery.Queries {
+		switch spec := query.Spec.(type) {
+		case qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]:
+			names[idx] = spec.Name
+			if spec.Filter != nil {
+				deps[spec.Name] = qbtypes.SubQueryRefs(spec.Filter.Expression)
+			}
+		case qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]:
+			names[idx] = spec.Name
+			if spec.Filter != nil {
+				deps[spec.Name] = qbtypes.SubQueryRefs(spec.Filter.Expression)
+			}
+		case qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]:
+			names[idx] = spec.Name
+			if spec.Filter != nil {
+				deps[spec.Name] = qbtypes.SubQueryRefs(spec.Filter.Expression)
+			}
+		case qbtypes.QueryBuilderFormula:
+			names[idx] = spec.Name
+			expression, err := govaluate.NewEvaluableExpressionWithFunctions(spec.Expression, qbtypes.EvalFuncs())
+			if err != nil {
+				return qbtypes.QueryRangeRequest{}, errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid expression of formula %s: %v", spec.Name, err)
+			}
+			deps[spec.Name] = expression.Vars()
+		case qbtypes.QueryBuilderTraceOperator:
+			names[idx] = spec.Name
+			if err := spec.ParseExpression(); err != nil {
+				return qbtypes.QueryRangeRequest{}, err
+			}
+			deps[spec.Name] = spec.CollectReferencedQueries(spec.ParsedExpression)
+		case qbtypes.QueryBuilderJoin:
+			names[idx] = spec.Name
+			deps[spec.Name] = []string{spec.Left.Name, spec.Right.Name}
+		case qbtypes.PromQuery:
+			names[idx] = spec.Name
+		case qbtypes.ClickHouseQuery:
+			names[idx] = spec.Name
+		}
+	}
+
+	kept := make(map[string]bool)
+	var visit func(name string)
+	visit = func(name string) {
+		if kept[name] {
+			return
+		}
+		kept[name] = true
+		for _, dep := range deps[name] {
+			visit(dep)
+		}
+	}
+	for _, name := range names {
+		if keep(name) {
+			visit(name)
+		}
+	}
+
+	queries := make([]qbtypes.QueryEnvelope, 0, len(kept))
+	for idx, query := range req.CompositeQuery.Queries {
+		if kept[names[idx]] {
+			queries = append(queries, query)
+		}
+	}
+
+	req.CompositeQuery.Queries = queries
+	return req, nil
 }
 
 type AnomaliesResponse struct {
 	Results []*qbtypes.TimeSeriesData
+	// Meta and Warning are of the query range of the current period
+	Meta    qbtypes.ExecStats
+	Warning *qbtypes.QueryWarnData
 }
 
 // anomalyParams is the params for anomaly detection
@@ -215,6 +302,8 @@ func prepareAnomalyQueryParams(req qbtypes.QueryRangeRequest, seasonality Season
 
 type anomalyQueryResults struct {
 	CurrentPeriodResults []*qbtypes.TimeSeriesData
+	CurrentPeriodMeta    qbtypes.ExecStats
+	CurrentPeriodWarning *qbtypes.QueryWarnData
 	PastPeriodResults    []*qbtypes.TimeSeriesData
 	CurrentSeasonResults []*qbtypes.TimeSeriesData
 	PastSeasonResults    []*qbtypes.TimeSeriesData
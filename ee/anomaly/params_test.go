@@ -0,0 +1,80 @@
+package anomaly
+
+import (
+	"testing"
+
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithQueries(t *testing.T) {
+	metricQuery := func(name string, filter string) qbtypes.QueryEnvelope {
+		return qbtypes.QueryEnvelope{
+			Type: qbtypes.QueryTypeBuilder,
+			Spec: qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]{
+				Name:   name,
+				Signal: telemetrytypes.SignalMetrics,
+				Filter: &qbtypes.Filter{Expression: filter},
+			},
+		}
+	}
+
+	req := qbtypes.QueryRangeRequest{
+		CompositeQuery: qbtypes.CompositeQuery{
+			Queries: []qbtypes.QueryEnvelope{
+				metricQuery("A", ""),
+				metricQuery("B", "service.name IN @C"),
+				metricQuery("C", ""),
+				metricQuery("D", ""),
+				{Type: qbtypes.QueryTypeFormula, Spec: qbtypes.QueryBuilderFormula{Name: "F1", Expression: "A / B"}},
+				{Type: qbtypes.QueryTypeJoin, Spec: qbtypes.QueryBuilderJoin{Name: "J", Left: qbtypes.QueryRef{Name: "A"}, Right: qbtypes.QueryRef{Name: "D"}}},
+			},
+		},
+	}
+
+	names := func(req qbtypes.QueryRangeRequest) []string {
+		names := []string{}
+		for _, query := range req.CompositeQuery.Queries {
+			switch spec := query.Spec.(type) {
+			case qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]:
+				names = append(names, spec.Name)
+			case qbtypes.QueryBuilderFormula:
+				names = append(names, spec.Name)
+			case qbtypes.QueryBuilderJoin:
+				names = append(names, spec.Name)
+			}
+		}
+		return names
+	}
+
+	cases := []struct {
+		name     string
+		keep     []string
+		expected []string
+	}{
+		{name: "Query", keep: []string{"D"}, expected: []string{"D"}},
+		{name: "SubQuery", keep: []string{"B"}, expected: []string{"B", "C"}},
+		{name: "Formula", keep: []string{"F1"}, expected: []string{"A", "B", "C", "F1"}},
+		{name: "Join", keep: []string{"J"}, expected: []string{"A", "D", "J"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := WithQueries(req, func(name string) bool {
+				for _, keep := range c.keep {
+					if keep == name {
+						return true
+					}
+				}
+				return false
+			})
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, names(got))
+		})
+	}
+
+	// the request itself is left as is
+	assert.Len(t, req.CompositeQuery.Queries, 6)
+}
@@ -0,0 +1,95 @@
+package anomaly
+
+import (
+	"log/slog"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/querier"
+)
+
+// ProviderFactory creates the provider of an algorithm for the seasonality
+type ProviderFactory func(seasonality Seasonality, querier querier.Querier, logger *slog.Logger) Provider
+
+// Registry holds the providers of the algorithms, so that every anomaly rule and query can select one. A single
+// registry is shared by the rules and the queries.
+type Registry struct {
+	querier   querier.Querier
+	logger    *slog.Logger
+	factories map[Algorithm]ProviderFactory
+}
+
+// NewRegistry creates a registry with the providers of the standard, holt-winters, mad and stl algorithms.
+func NewRegistry(q querier.Querier, logger *slog.Logger) *Registry {
+	registry := &Registry{
+		querier:   q,
+		logger:    logger,
+		factories: make(map[Algorithm]ProviderFactory),
+	}
+
+	registry.Register(AlgorithmStandard, newSeasonalProvider)
+	for _, algorithm := range []Algorithm{AlgorithmHoltWinters, AlgorithmMAD, AlgorithmSTL} {
+		registry.Register(algorithm, func(_ Seasonality, q querier.Querier, logger *slog.Logger) Provider {
+			return NewForecastProvider(algorithm, q, logger)
+		})
+	}
+
+	return registry
+}
+
+// Register registers the provider factory of the algorithm, replacing the existing one.
+func (r *Registry) Register(algorithm Algorithm, factory ProviderFactory) {
+	r.factories[algorithm] = factory
+}
+
+// Validate returns an error if no provider is registered for the algorithm, the standard algorithm is used when the
+// algorithm is empty.
+func (r *Registry) Validate(algorithm Algorithm) error {
+	_, err := r.factory(algorithm)
+	return err
+}
+
+// Provider returns the provider of the algorithm for the seasonality, the standard algorithm is used when the
+// algorithm is empty.
+func (r *Registry) Provider(algorithm Algorithm, seasonality Seasonality) (Provider, error) {
+	factory, err := r.factory(algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	return factory(seasonality, r.querier, r.logger), nil
+}
+
+func (r *Registry) factory(algorithm Algorithm) (ProviderFactory, error) {
+	if algorithm.IsZero() {
+		algorithm = AlgorithmStandard
+	}
+
+	factory, ok := r.factories[algorithm]
+	if !ok {
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "unsupported anomaly algorithm %s", algorithm.StringValue()).WithAdditional(
+			"Supported algorithms are: standard, holt_winters, mad, stl",
+		)
+	}
+
+	return factory, nil
+}
+
+func newSeasonalProvider(seasonality Seasonality, q querier.Querier, logger *slog.Logger) Provider {
+	switch seasonality {
+	case SeasonalityHourly:
+		return NewHourlyProvider(
+			WithQuerier[*HourlyProvider](q),
+			WithLogger[*HourlyProvider](logger),
+		)
+	case SeasonalityWeekly:
+		return NewWeeklyProvider(
+			WithQuerier[*WeeklyProvider](q),
+			WithLogger[*WeeklyProvider](logger),
+		)
+	default:
+		return NewDailyProvider(
+			WithQuerier[*DailyProvider](q),
+			WithLogger[*DailyProvider](logger),
+		)
+	}
+}
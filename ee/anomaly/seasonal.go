@@ -104,14 +104,20 @@ func (p *BaseSeasonalProvider) getResults(ctx context.Context, orgID valuer.UUID
 		return nil, err
 	}
 
-	return &anomalyQueryResults{
+	results := &anomalyQueryResults{
 		CurrentPeriodResults: p.toTSResults(ctx, currentPeriodResults),
 		PastPeriodResults:    p.toTSResults(ctx, pastPeriodResults),
 		CurrentSeasonResults: p.toTSResults(ctx, currentSeasonResults),
 		PastSeasonResults:    p.toTSResults(ctx, pastSeasonResults),
 		Past2SeasonResults:   p.toTSResults(ctx, past2SeasonResults),
 		Past3SeasonResults:   p.toTSResults(ctx, past3SeasonResults),
-	}, nil
+	}
+	if currentPeriodResults != nil {
+		results.CurrentPeriodMeta = currentPeriodResults.Meta
+		results.CurrentPeriodWarning = currentPeriodResults.Warning
+	}
+
+	return results, nil
 }
 
 // getMatchingSeries gets the matching series from the query result
@@ -348,27 +354,12 @@ func (p *BaseSeasonalProvider) getAnomalies(ctx context.Context, orgID valuer.UU
 	}
 
 	for _, result := range currentPeriodResults {
-		funcs := req.Params.FuncsForQuery(result.QueryName)
-
-		var zScoreThreshold float64
-		for _, f := range funcs {
-			if f.Name == qbtypes.FunctionNameAnomaly {
-				for _, arg := range f.Args {
-					if arg.Name != "z_score_threshold" {
-						continue
-					}
-					value, ok := arg.Value.(float64)
-					if ok {
-						zScoreThreshold = value
-					} else {
-						p.logger.InfoContext(ctx, "z_score_threshold not provided, defaulting")
-						zScoreThreshold = 3
-					}
-					break
-				}
-			}
+		if !req.scores(result.QueryName) {
+			continue
 		}
 
+		zScoreThreshold := zScoreThresholdFor(req.Params.FuncsForQuery(result.QueryName))
+
 		pastPeriodResult, ok := pastPeriodResults[result.QueryName]
 		if !ok {
 			continue
@@ -461,5 +452,7 @@ func (p *BaseSeasonalProvider) getAnomalies(ctx context.Context, orgID valuer.UU
 
 	return &AnomaliesResponse{
 		Results: results,
+		Meta:    anomalyQueryResults.CurrentPeriodMeta,
+		Warning: anomalyQueryResults.CurrentPeriodWarning,
 	}, nil
 }
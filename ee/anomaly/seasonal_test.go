@@ -0,0 +1,107 @@
+package anomaly
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+)
+
+type fakeQuerier struct{}
+
+func (fakeQuerier) QueryRange(_ context.Context, _ valuer.UUID, req *qbtypes.QueryRangeRequest) (*qbtypes.QueryRangeResponse, error) {
+	results := []any{}
+	for _, name := range []string{"A", "F1"} {
+		series := &qbtypes.TimeSeries{Labels: []*qbtypes.Label{}}
+		for ts := req.Start; ts < req.End; ts += 60000 {
+			series.Values = append(series.Values, &qbtypes.TimeSeriesValue{Timestamp: int64(ts), Value: 10})
+		}
+		results = append(results, &qbtypes.TimeSeriesData{
+			QueryName:    name,
+			Aggregations: []*qbtypes.AggregationBucket{{Series: []*qbtypes.TimeSeries{series}}},
+		})
+	}
+
+	return &qbtypes.QueryRangeResponse{
+		Type: qbtypes.RequestTypeTimeSeries,
+		Data: qbtypes.QueryData{Results: results},
+		Meta: qbtypes.ExecStats{StepIntervals: map[string]uint64{"A": 60, "F1": 60}},
+	}, nil
+}
+
+func (fakeQuerier) QueryRawStream(context.Context, valuer.UUID, *qbtypes.QueryRangeRequest, *qbtypes.RawStream) {
+}
+
+func (fakeQuerier) QueryRangeStream(context.Context, valuer.UUID, *qbtypes.QueryRangeRequest, qbtypes.StreamWriter) error {
+	return nil
+}
+
+func (fakeQuerier) SetWatermark(context.Context, valuer.UUID, *qbtypes.PostableWatermark) error {
+	return nil
+}
+
+func (fakeQuerier) ListWatermarks(context.Context, valuer.UUID) (qbtypes.GettableWatermarks, error) {
+	return nil, nil
+}
+
+func (fakeQuerier) PurgeCache(context.Context, valuer.UUID, string, *qbtypes.PostableCachePurge) error {
+	return nil
+}
+
+func TestProviders_ScoreRequestedQueries(t *testing.T) {
+	logger := slog.New(slog.DiscardHandler)
+	registry := NewRegistry(fakeQuerier{}, logger)
+
+	for _, algorithm := range []Algorithm{AlgorithmStandard, AlgorithmMAD} {
+		t.Run(algorithm.StringValue(), func(t *testing.T) {
+			provider, err := registry.Provider(algorithm, SeasonalityHourly)
+			require.NoError(t, err)
+
+			resp, err := provider.GetAnomalies(context.Background(), valuer.GenerateUUID(), &AnomaliesRequest{
+				Params: qbtypes.QueryRangeRequest{
+					Start:       1700006400000,
+					End:         1700006400000 + 30*60000,
+					RequestType: qbtypes.RequestTypeTimeSeries,
+				},
+				Seasonality: SeasonalityHourly,
+				Queries:     []string{"F1"},
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, map[string]uint64{"A": 60, "F1": 60}, resp.Meta.StepIntervals)
+			require.Len(t, resp.Results, 2)
+			for _, result := range resp.Results {
+				bucket := result.Aggregations[0]
+				if result.QueryName == "F1" {
+					require.Len(t, bucket.AnomalyScores, 1)
+					require.Len(t, bucket.UpperBoundSeries, 1)
+					require.Len(t, bucket.LowerBoundSeries, 1)
+					assert.Len(t, bucket.AnomalyScores[0].Values, 30)
+				} else {
+					assert.Empty(t, bucket.AnomalyScores)
+					assert.Empty(t, bucket.UpperBoundSeries)
+				}
+			}
+		})
+	}
+}
+
+func TestRegistry_Provider(t *testing.T) {
+	registry := NewRegistry(fakeQuerier{}, slog.New(slog.DiscardHandler))
+
+	provider, err := registry.Provider(Algorithm{}, SeasonalityWeekly)
+	require.NoError(t, err)
+	assert.IsType(t, &WeeklyProvider{}, provider)
+
+	provider, err = registry.Provider(AlgorithmSTL, SeasonalityWeekly)
+	require.NoError(t, err)
+	assert.IsType(t, &ForecastProvider{}, provider)
+
+	_, err = registry.Provider(Algorithm{valuer.NewString("prophet")}, SeasonalityWeekly)
+	require.Error(t, err)
+}
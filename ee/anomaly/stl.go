@@ -0,0 +1,306 @@
+package anomaly
+
+import (
+	"math"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+)
+
+var (
+	// stlSeasonalSpan is the span of the loess smoothing of the cycle subseries, in seasons
+	stlSeasonalSpan = 7
+	// stlInnerIterations is the number of passes which update the seasonal and trend components
+	stlInnerIterations = 2
+	// stlOuterIterations is the number of passes which update the robustness weights, so that the outliers don't
+	// distort the components
+	stlOuterIterations = 3
+	// mstlIterations is the number of passes over the seasonal components of the overlapping seasonalities
+	mstlIterations = 2
+)
+
+// stlModel predicts the trend at the end of the history plus the seasonal components at the same phase in the
+// latest seasons
+type stlModel struct {
+	g         *grid
+	periods   []int
+	seasonals [][]float64
+	trend     []float64
+	deviation float64
+}
+
+var _ Model = (*stlModel)(nil)
+
+// fitSTL decomposes the history into a seasonal component for each of the periods which fit twice in the history,
+// and the trend. The expected deviation is the scaled median absolute deviation of the errors of the predictions of
+// the history.
+func fitSTL(g *grid, periods []int) (*stlModel, error) {
+	n := len(g.values)
+
+	m := &stlModel{g: g}
+	for _, period := range periods {
+		if period >= 2 && n >= 2*period {
+			m.periods = append(m.periods, period)
+		}
+	}
+	if len(m.periods) == 0 {
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "stl requires at least two seasons of %d steps, got %d steps", periods[0], n)
+	}
+
+	// with overlapping seasonalities, every seasonal component is decomposed from the values without the other
+	// seasonal components
+	m.seasonals = make([][]float64, len(m.periods))
+	for idx := range m.seasonals {
+		m.seasonals[idx] = make([]float64, n)
+	}
+	deseasonalized := append([]float64(nil), g.values...)
+	iterations := 1
+	if len(m.periods) > 1 {
+		iterations = mstlIterations
+	}
+	for iteration := 0; iteration < iterations; iteration++ {
+		for idx, period := range m.periods {
+			for i := range deseasonalized {
+				deseasonalized[i] += m.seasonals[idx][i]
+			}
+			m.seasonals[idx], m.trend = stl(deseasonalized, period)
+			for i := range deseasonalized {
+				deseasonalized[i] -= m.seasonals[idx][i]
+			}
+		}
+	}
+
+	// the values are predicted with the seasonal components of the latest season, so the errors are of the values
+	// from the components of the previous season
+	longest := m.periods[len(m.periods)-1]
+	errs := make([]float64, 0, n-longest)
+	for i := longest; i < n; i++ {
+		predicted := m.trend[i]
+		for idx, period := range m.periods {
+			predicted += m.seasonals[idx][i-period]
+		}
+		errs = append(errs, g.values[i]-predicted)
+	}
+	m.deviation = madScale * mad(errs, median(errs))
+
+	return m, nil
+}
+
+func (m *stlModel) Predict(timestamp int64) float64 {
+	idx := m.g.index(timestamp)
+	n := len(m.trend)
+
+	// the trend is extended flat after the history
+	predicted := m.trend[min(max(idx, 0), n-1)]
+	for i, period := range m.periods {
+		predicted += m.seasonals[i][m.g.seasonIndex(idx, period)]
+	}
+	return predicted
+}
+
+func (m *stlModel) Deviation(int64) float64 {
+	return m.deviation
+}
+
+// stl is the seasonal-trend decomposition using loess of Cleveland et al., it returns the seasonal and trend
+// components of the values for the period.
+func stl(values []float64, period int) ([]float64, []float64) {
+	n := len(values)
+	lowPassSpan := nextOdd(period)
+	trendSpan := nextOdd(int(math.Ceil(1.5 * float64(period) / (1 - 1.5/float64(stlSeasonalSpan)))))
+
+	seasonal := make([]float64, n)
+	trend := make([]float64, n)
+
+	// the initial weights are of the deviations from the medians of the cycle subseries, so that the outliers don't
+	// distort the first pass
+	residuals := make([]float64, n)
+	for phase := 0; phase < period; phase++ {
+		var subseries []float64
+		for i := phase; i < n; i += period {
+			subseries = append(subseries, values[i])
+		}
+		phaseMedian := median(subseries)
+		for i := phase; i < n; i += period {
+			residuals[i] = values[i] - phaseMedian
+		}
+	}
+	weights := bisquare(values, residuals)
+
+	detrended := make([]float64, n)
+	deseasonalized := make([]float64, n)
+	for outer := 0; outer < stlOuterIterations; outer++ {
+		for inner := 0; inner < stlInnerIterations; inner++ {
+			for i := range values {
+				detrended[i] = values[i] - trend[i]
+			}
+
+			// smooth every cycle subseries, extended by one season before and after the values
+			cycle := make([]float64, n+2*period)
+			for phase := 0; phase < period; phase++ {
+				var subseries, subweights []float64
+				for i := phase; i < n; i += period {
+					subseries = append(subseries, detrended[i])
+					subweights = append(subweights, weights[i])
+				}
+				for pos := phase; pos < len(cycle); pos += period {
+					cycle[pos] = loess(subseries, subweights, stlSeasonalSpan, float64(pos/period-1))
+				}
+			}
+
+			// the low pass of the smoothed cycle subseries is the trend left in them
+			lowPass := movingAverage(movingAverage(movingAverage(cycle, period), period), 3)
+			lowPass = loessSmooth(lowPass, nil, lowPassSpan)
+
+			for i := range values {
+				seasonal[i] = cycle[period+i] - lowPass[i]
+				deseasonalized[i] = values[i] - seasonal[i]
+			}
+			trend = loessSmooth(deseasonalized, weights, trendSpan)
+		}
+
+		// bisquare weights of the remainder
+		for i := range values {
+			residuals[i] = values[i] - seasonal[i] - trend[i]
+		}
+		weights = bisquare(values, residuals)
+	}
+
+	return seasonal, trend
+}
+
+// bisquare returns the robustness weights of the residuals of the values. The residuals below a thousandth of the
+// level of the values are treated as exact, so that the weights don't vanish for the series without noise.
+func bisquare(values []float64, residuals []float64) []float64 {
+	absolute := make([]float64, len(residuals))
+	level := make([]float64, len(values))
+	for i := range residuals {
+		absolute[i] = math.Abs(residuals[i])
+		level[i] = math.Abs(values[i])
+	}
+
+	weights := make([]float64, len(residuals))
+	h := 6 * math.Max(median(absolute), 1e-3*mean(level))
+	for i := range weights {
+		if h == 0 {
+			weights[i] = 1
+			continue
+		}
+		u := absolute[i] / h
+		if u < 1 {
+			weights[i] = (1 - u*u) * (1 - u*u)
+		}
+	}
+	return weights
+}
+
+// loessSmooth evaluates the loess of the values at every position, the loess is evaluated at every jump positions
+// and interpolated in between
+func loessSmooth(values []float64, weights []float64, span int) []float64 {
+	n := len(values)
+	smoothed := make([]float64, n)
+	if n == 0 {
+		return smoothed
+	}
+
+	jump := max(span/10, 1)
+	prev := 0
+	smoothed[0] = loess(values, weights, span, 0)
+	for i := jump; ; i += jump {
+		if i > n-1 {
+			i = n - 1
+		}
+		smoothed[i] = loess(values, weights, span, float64(i))
+		for fill := prev + 1; fill < i; fill++ {
+			frac := float64(fill-prev) / float64(i-prev)
+			smoothed[fill] = smoothed[prev] + frac*(smoothed[i]-smoothed[prev])
+		}
+		prev = i
+		if i == n-1 {
+			break
+		}
+	}
+
+	return smoothed
+}
+
+// loess returns the locally weighted linear regression of the values at the position, over the span nearest values
+// with tricube weights. The weights scale the tricube weights, nil weights are all 1.
+func loess(values []float64, weights []float64, span int, at float64) float64 {
+	n := len(values)
+	if n == 0 {
+		return 0
+	}
+
+	lo, hi := 0, n-1
+	if span < n {
+		lo = min(max(int(math.Round(at))-span/2, 0), n-span)
+		hi = lo + span - 1
+	}
+	maxDistance := math.Max(at-float64(lo), float64(hi)-at)
+	if span > n {
+		maxDistance += float64(span-n) / 2
+	}
+	if maxDistance <= 0 {
+		maxDistance = 1
+	}
+
+	var sw, swx, swy, swxx, swxy float64
+	for i := lo; i <= hi; i++ {
+		distance := math.Abs(float64(i)-at) / maxDistance
+		if distance >= 1 {
+			continue
+		}
+		w := math.Pow(1-distance*distance*distance, 3)
+		if weights != nil {
+			w *= weights[i]
+		}
+		x := float64(i)
+		sw += w
+		swx += w * x
+		swy += w * values[i]
+		swxx += w * x * x
+		swxy += w * x * values[i]
+	}
+
+	if sw <= 0 {
+		// all the values in the span are outliers
+		if weights != nil {
+			return loess(values, nil, span, at)
+		}
+		return values[min(max(int(math.Round(at)), 0), n-1)]
+	}
+
+	meanX, meanY := swx/sw, swy/sw
+	variance := swxx/sw - meanX*meanX
+	if variance <= 1e-9 {
+		return meanY
+	}
+	slope := (swxy/sw - meanX*meanY) / variance
+	return meanY + slope*(at-meanX)
+}
+
+// movingAverage returns the averages of every window of values
+func movingAverage(values []float64, window int) []float64 {
+	if len(values) < window {
+		return nil
+	}
+
+	averages := make([]float64, len(values)-window+1)
+	var sum float64
+	for i := 0; i < window; i++ {
+		sum += values[i]
+	}
+	averages[0] = sum / float64(window)
+	for i := window; i < len(values); i++ {
+		sum += values[i] - values[i-window]
+		averages[i-window+1] = sum / float64(window)
+	}
+	return averages
+}
+
+func nextOdd(value int) int {
+	if value%2 == 0 {
+		return value + 1
+	}
+	return value
+}
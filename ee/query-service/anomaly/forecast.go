@@ -0,0 +1,168 @@
+package anomaly
+
+import (
+	"context"
+	"math"
+
+	anomalyV2 "github.com/SigNoz/signoz/ee/anomaly"
+	querierV2 "github.com/SigNoz/signoz/pkg/query-service/app/querier/v2"
+	"github.com/SigNoz/signoz/pkg/query-service/app/queryBuilder"
+	v3 "github.com/SigNoz/signoz/pkg/query-service/model/v3"
+	"github.com/SigNoz/signoz/pkg/query-service/postprocess"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"go.uber.org/zap"
+)
+
+// ForecastProvider detects the anomalies with a model of the algorithm, which is fitted to the past seasons of every
+// series and predicts the values of the current period.
+type ForecastProvider struct {
+	BaseSeasonalProvider
+	algorithm anomalyV2.Algorithm
+}
+
+var _ BaseProvider = (*ForecastProvider)(nil)
+
+func (fp *ForecastProvider) GetBaseSeasonalProvider() *BaseSeasonalProvider {
+	return &fp.BaseSeasonalProvider
+}
+
+// NewForecastProvider uses the same generic option type
+func NewForecastProvider(algorithm anomalyV2.Algorithm, opts ...GenericProviderOption[*ForecastProvider]) *ForecastProvider {
+	fp := &ForecastProvider{
+		BaseSeasonalProvider: BaseSeasonalProvider{},
+		algorithm:            algorithm,
+	}
+
+	for _, opt := range opts {
+		opt(fp)
+	}
+
+	fp.querierV2 = querierV2.NewQuerier(querierV2.QuerierOptions{
+		Reader:       fp.reader,
+		Cache:        fp.cache,
+		KeyGenerator: queryBuilder.NewKeyGenerator(),
+		FluxInterval: fp.fluxInterval,
+	})
+
+	return fp
+}
+
+func (p *ForecastProvider) GetAnomalies(ctx context.Context, orgID valuer.UUID, req *GetAnomaliesRequest) (*GetAnomaliesResponse, error) {
+	if !req.Seasonality.IsValid() {
+		req.Seasonality = SeasonalityDaily
+	}
+	seasonality := anomalyV2.Seasonality{String: valuer.NewString(req.Seasonality.String())}
+
+	start := req.Params.Start
+	historyStart, step := anomalyV2.HistoryWindow(seasonality, uint64(start))
+
+	currentPeriodQuery := &v3.QueryRangeParamsV3{
+		Start:          start,
+		End:            req.Params.End,
+		CompositeQuery: req.Params.CompositeQuery.Clone(),
+		Variables:      make(map[string]interface{}, 0),
+		NoCache:        false,
+	}
+	updateStepInterval(currentPeriodQuery)
+
+	historyQuery := &v3.QueryRangeParamsV3{
+		Start:          int64(historyStart),
+		End:            start,
+		Step:           int64(step),
+		CompositeQuery: req.Params.CompositeQuery.Clone(),
+		Variables:      make(map[string]interface{}, 0),
+		NoCache:        false,
+	}
+	for _, q := range historyQuery.CompositeQuery.BuilderQueries {
+		q.StepInterval = int64(step)
+	}
+
+	zap.L().Info("fetching results for current period", zap.String("algorithm", p.algorithm.StringValue()), zap.Any("currentPeriodQuery", currentPeriodQuery))
+	currentPeriodResults, _, err := p.querierV2.QueryRange(ctx, orgID, currentPeriodQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	currentPeriodResults, err = postprocess.PostProcessResult(currentPeriodResults, currentPeriodQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	zap.L().Info("fetching results for history", zap.String("algorithm", p.algorithm.StringValue()), zap.Any("historyQuery", historyQuery))
+	historyResults, _, err := p.querierV2.QueryRange(ctx, orgID, historyQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	historyResults, err = postprocess.PostProcessResult(historyResults, historyQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	historyResultsMap := make(map[string]*v3.Result)
+	for _, result := range historyResults {
+		historyResultsMap[result.QueryName] = result
+	}
+
+	for _, result := range currentPeriodResults {
+		zScoreThreshold := 3.0
+		if builderQuery, ok := req.Params.CompositeQuery.BuilderQueries[result.QueryName]; ok {
+			for _, f := range builderQuery.Functions {
+				if f.Name == v3.FunctionNameAnomaly {
+					if value, ok := f.NamedArgs["z_score_threshold"].(float64); ok {
+						zScoreThreshold = value
+					}
+					break
+				}
+			}
+		}
+
+		historyResult, ok := historyResultsMap[result.QueryName]
+		if !ok {
+			continue
+		}
+
+		for _, series := range result.Series {
+			historySeries := p.getMatchingSeries(historyResult, series)
+			if historySeries == nil {
+				zap.L().Info("no history for series, skipping", zap.Any("labels", series.Labels))
+				continue
+			}
+
+			samples := make([]anomalyV2.Sample, 0, len(historySeries.Points))
+			for _, point := range historySeries.Points {
+				samples = append(samples, anomalyV2.Sample{Timestamp: point.Timestamp, Value: point.Value})
+			}
+
+			model, err := anomalyV2.FitModel(p.algorithm, samples, seasonality, step*1000)
+			if err != nil {
+				zap.L().Info("failed to fit the model for series, skipping", zap.Error(err), zap.Any("labels", series.Labels))
+				continue
+			}
+
+			predictedSeries := &v3.Series{Labels: series.Labels, LabelsArray: series.LabelsArray, Points: []v3.Point{}}
+			upperBoundSeries := &v3.Series{Labels: series.Labels, LabelsArray: series.LabelsArray, Points: []v3.Point{}}
+			lowerBoundSeries := &v3.Series{Labels: series.Labels, LabelsArray: series.LabelsArray, Points: []v3.Point{}}
+			anomalyScoreSeries := &v3.Series{Labels: series.Labels, LabelsArray: series.LabelsArray, Points: []v3.Point{}}
+
+			for _, curr := range series.Points {
+				predicted := model.Predict(curr.Timestamp)
+				deviation := model.Deviation(curr.Timestamp)
+
+				predictedSeries.Points = append(predictedSeries.Points, v3.Point{Timestamp: curr.Timestamp, Value: predicted})
+				upperBoundSeries.Points = append(upperBoundSeries.Points, v3.Point{Timestamp: curr.Timestamp, Value: predicted + zScoreThreshold*deviation})
+				lowerBoundSeries.Points = append(lowerBoundSeries.Points, v3.Point{Timestamp: curr.Timestamp, Value: math.Max(predicted-zScoreThreshold*deviation, 0)})
+				anomalyScoreSeries.Points = append(anomalyScoreSeries.Points, v3.Point{Timestamp: curr.Timestamp, Value: anomalyV2.Score(curr.Value, predicted, deviation)})
+			}
+
+			result.PredictedSeries = append(result.PredictedSeries, predictedSeries)
+			result.UpperBoundSeries = append(result.UpperBoundSeries, upperBoundSeries)
+			result.LowerBoundSeries = append(result.LowerBoundSeries, lowerBoundSeries)
+			result.AnomalyScores = append(result.AnomalyScores, anomalyScoreSeries)
+		}
+	}
+
+	return &GetAnomaliesResponse{
+		Results: currentPeriodResults,
+	}, nil
+}
@@ -3,9 +3,47 @@ package anomaly
 import (
 	"context"
 
+	anomalyV2 "github.com/SigNoz/signoz/ee/anomaly"
+	"github.com/SigNoz/signoz/pkg/cache"
+	"github.com/SigNoz/signoz/pkg/query-service/app/queryBuilder"
+	"github.com/SigNoz/signoz/pkg/query-service/interfaces"
 	"github.com/SigNoz/signoz/pkg/valuer"
 )
 
 type Provider interface {
 	GetAnomalies(ctx context.Context, orgID valuer.UUID, req *GetAnomaliesRequest) (*GetAnomaliesResponse, error)
 }
+
+// NewProvider returns the provider of the algorithm for the seasonality, the standard algorithm is used when the
+// algorithm is empty. The algorithm is validated with the registry of the algorithms.
+func NewProvider(algorithm anomalyV2.Algorithm, seasonality Seasonality, reader interfaces.Reader, c cache.Cache) Provider {
+	if !algorithm.IsZero() && algorithm != anomalyV2.AlgorithmStandard {
+		return NewForecastProvider(
+			algorithm,
+			WithCache[*ForecastProvider](c),
+			WithKeyGenerator[*ForecastProvider](queryBuilder.NewKeyGenerator()),
+			WithReader[*ForecastProvider](reader),
+		)
+	}
+
+	switch seasonality {
+	case SeasonalityHourly:
+		return NewHourlyProvider(
+			WithCache[*HourlyProvider](c),
+			WithKeyGenerator[*HourlyProvider](queryBuilder.NewKeyGenerator()),
+			WithReader[*HourlyProvider](reader),
+		)
+	case SeasonalityWeekly:
+		return NewWeeklyProvider(
+			WithCache[*WeeklyProvider](c),
+			WithKeyGenerator[*WeeklyProvider](queryBuilder.NewKeyGenerator()),
+			WithReader[*WeeklyProvider](reader),
+		)
+	default:
+		return NewDailyProvider(
+			WithCache[*DailyProvider](c),
+			WithKeyGenerator[*DailyProvider](queryBuilder.NewKeyGenerator()),
+			WithReader[*DailyProvider](reader),
+		)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"net/http/httputil"
 	"time"
 
+	"github.com/SigNoz/signoz/ee/anomaly"
 	"github.com/SigNoz/signoz/ee/licensing/httplicensing"
 	"github.com/SigNoz/signoz/ee/query-service/integrations/gateway"
 	"github.com/SigNoz/signoz/ee/query-service/usage"
@@ -37,6 +38,8 @@ type APIHandlerOptions struct {
 	// Querier Influx Interval
 	FluxInterval time.Duration
 	GlobalConfig global.Config
+	// AnomalyRegistry holds the providers of the anomaly algorithms
+	AnomalyRegistry *anomaly.Registry
 }
 
 type APIHandler struct {
@@ -6,15 +6,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"runtime/debug"
+	"slices"
 
 	anomalyV2 "github.com/SigNoz/signoz/ee/anomaly"
 	"github.com/SigNoz/signoz/ee/query-service/anomaly"
 	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/http/render"
 	baseapp "github.com/SigNoz/signoz/pkg/query-service/app"
-	"github.com/SigNoz/signoz/pkg/query-service/app/queryBuilder"
 	"github.com/SigNoz/signoz/pkg/query-service/model"
 	v3 "github.com/SigNoz/signoz/pkg/query-service/model/v3"
 	"github.com/SigNoz/signoz/pkg/types/authtypes"
@@ -83,10 +84,14 @@ func (aH *APIHandler) queryRangeV4(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 
-		// get the threshold, and seasonality from the anomaly query
+		// get the threshold, seasonality and algorithm from the anomaly query
 		var seasonality anomaly.Seasonality
+		var algorithm anomalyV2.Algorithm
 		for _, fn := range anomalyQuery.Functions {
 			if fn.Name == v3.FunctionNameAnomaly {
+				if algorithmStr, ok := fn.NamedArgs["algorithm"].(string); ok {
+					algorithm = anomalyV2.Algorithm{String: valuer.NewString(algorithmStr)}
+				}
 				seasonalityStr, ok := fn.NamedArgs["seasonality"].(string)
 				if !ok {
 					seasonalityStr = "daily"
@@ -101,33 +106,11 @@ func (aH *APIHandler) queryRangeV4(w http.ResponseWriter, r *http.Request) {
 				break
 			}
 		}
-		var provider anomaly.Provider
-		switch seasonality {
-		case anomaly.SeasonalityWeekly:
-			provider = anomaly.NewWeeklyProvider(
-				anomaly.WithCache[*anomaly.WeeklyProvider](aH.Signoz.Cache),
-				anomaly.WithKeyGenerator[*anomaly.WeeklyProvider](queryBuilder.NewKeyGenerator()),
-				anomaly.WithReader[*anomaly.WeeklyProvider](aH.opts.DataConnector),
-			)
-		case anomaly.SeasonalityDaily:
-			provider = anomaly.NewDailyProvider(
-				anomaly.WithCache[*anomaly.DailyProvider](aH.Signoz.Cache),
-				anomaly.WithKeyGenerator[*anomaly.DailyProvider](queryBuilder.NewKeyGenerator()),
-				anomaly.WithReader[*anomaly.DailyProvider](aH.opts.DataConnector),
-			)
-		case anomaly.SeasonalityHourly:
-			provider = anomaly.NewHourlyProvider(
-				anomaly.WithCache[*anomaly.HourlyProvider](aH.Signoz.Cache),
-				anomaly.WithKeyGenerator[*anomaly.HourlyProvider](queryBuilder.NewKeyGenerator()),
-				anomaly.WithReader[*anomaly.HourlyProvider](aH.opts.DataConnector),
-			)
-		default:
-			provider = anomaly.NewDailyProvider(
-				anomaly.WithCache[*anomaly.DailyProvider](aH.Signoz.Cache),
-				anomaly.WithKeyGenerator[*anomaly.DailyProvider](queryBuilder.NewKeyGenerator()),
-				anomaly.WithReader[*anomaly.DailyProvider](aH.opts.DataConnector),
-			)
+		if err := aH.opts.AnomalyRegistry.Validate(algorithm); err != nil {
+			RespondError(w, &model.ApiError{Typ: model.ErrorBadData, Err: err}, nil)
+			return
 		}
+		provider := anomaly.NewProvider(algorithm, seasonality, aH.opts.DataConnector, aH.Signoz.Cache)
 		anomalies, err := provider.GetAnomalies(r.Context(), orgID, &anomaly.GetAnomaliesRequest{Params: queryRangeParams})
 		if err != nil {
 			RespondError(w, &model.ApiError{Typ: model.ErrorInternal, Err: err}, nil)
@@ -144,19 +127,15 @@ func (aH *APIHandler) queryRangeV4(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func extractSeasonality(anomalyQuery *qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]) anomalyV2.Seasonality {
-	for _, fn := range anomalyQuery.Functions {
-		if fn.Name == qbtypes.FunctionNameAnomaly {
-			for _, arg := range fn.Args {
-				if arg.Name == "seasonality" {
-					if seasonalityStr, ok := arg.Value.(string); ok {
-						switch seasonalityStr {
-						case "weekly":
-							return anomalyV2.SeasonalityWeekly
-						case "hourly":
-							return anomalyV2.SeasonalityHourly
-						}
-					}
+func extractSeasonality(fn qbtypes.Function) anomalyV2.Seasonality {
+	for _, arg := range fn.Args {
+		if arg.Name == "seasonality" {
+			if seasonalityStr, ok := arg.Value.(string); ok {
+				switch seasonalityStr {
+				case "weekly":
+					return anomalyV2.SeasonalityWeekly
+				case "hourly":
+					return anomalyV2.SeasonalityHourly
 				}
 			}
 		}
@@ -164,31 +143,108 @@ func extractSeasonality(anomalyQuery *qbtypes.QueryBuilderQuery[qbtypes.MetricAg
 	return anomalyV2.SeasonalityDaily // default
 }
 
-func createAnomalyProvider(aH *APIHandler, seasonality anomalyV2.Seasonality) anomalyV2.Provider {
-	switch seasonality {
-	case anomalyV2.SeasonalityWeekly:
-		return anomalyV2.NewWeeklyProvider(
-			anomalyV2.WithQuerier[*anomalyV2.WeeklyProvider](aH.Signoz.Querier),
-			anomalyV2.WithLogger[*anomalyV2.WeeklyProvider](aH.Signoz.Instrumentation.Logger()),
-		)
-	case anomalyV2.SeasonalityHourly:
-		return anomalyV2.NewHourlyProvider(
-			anomalyV2.WithQuerier[*anomalyV2.HourlyProvider](aH.Signoz.Querier),
-			anomalyV2.WithLogger[*anomalyV2.HourlyProvider](aH.Signoz.Instrumentation.Logger()),
-		)
-	default:
-		return anomalyV2.NewDailyProvider(
-			anomalyV2.WithQuerier[*anomalyV2.DailyProvider](aH.Signoz.Querier),
-			anomalyV2.WithLogger[*anomalyV2.DailyProvider](aH.Signoz.Instrumentation.Logger()),
-		)
+func extractAlgorithm(fn qbtypes.Function) anomalyV2.Algorithm {
+	for _, arg := range fn.Args {
+		if arg.Name == "algorithm" {
+			if algorithmStr, ok := arg.Value.(string); ok {
+				return anomalyV2.Algorithm{String: valuer.NewString(algorithmStr)}
+			}
+		}
 	}
+	return anomalyV2.AlgorithmStandard // default
 }
 
-func (aH *APIHandler) handleAnomalyQuery(ctx context.Context, orgID valuer.UUID, anomalyQuery *qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation], queryRangeRequest qbtypes.QueryRangeRequest) (*anomalyV2.AnomaliesResponse, error) {
-	seasonality := extractSeasonality(anomalyQuery)
-	provider := createAnomalyProvider(aH, seasonality)
+// handleAnomalyQuery runs the request with the anomaly providers of the anomaly functions, the queries whose anomaly
+// functions have the same seasonality and algorithm are scored together
+func (aH *APIHandler) handleAnomalyQuery(ctx context.Context, orgID valuer.UUID, anomalyFuncs map[string]qbtypes.Function, queryRangeRequest qbtypes.QueryRangeRequest) (*qbtypes.QueryRangeResponse, error) {
+	if queryRangeRequest.RequestType != qbtypes.RequestTypeTimeSeries {
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "anomaly function is only supported for %s requests", qbtypes.RequestTypeTimeSeries.StringValue())
+	}
+
+	type anomalyGroup struct {
+		seasonality anomalyV2.Seasonality
+		algorithm   anomalyV2.Algorithm
+		queries     []string
+	}
+
+	names := slices.Sorted(maps.Keys(anomalyFuncs))
+	groups := []*anomalyGroup{}
+	for _, name := range names {
+		seasonality := extractSeasonality(anomalyFuncs[name])
+		algorithm := extractAlgorithm(anomalyFuncs[name])
+
+		idx := slices.IndexFunc(groups, func(group *anomalyGroup) bool {
+			return group.seasonality == seasonality && group.algorithm == algorithm
+		})
+		if idx == -1 {
+			groups = append(groups, &anomalyGroup{seasonality: seasonality, algorithm: algorithm})
+			idx = len(groups) - 1
+		}
+		groups[idx].queries = append(groups[idx].queries, name)
+	}
+
+	var resp *qbtypes.QueryRangeResponse
+	var results []*qbtypes.TimeSeriesData
+	for _, group := range groups {
+		provider, err := aH.opts.AnomalyRegistry.Provider(group.algorithm, group.seasonality)
+		if err != nil {
+			return nil, err
+		}
+
+		// the first group also runs the queries without the anomaly function, the others only run their queries
+		first := resp == nil
+		params, err := anomalyV2.WithQueries(queryRangeRequest, func(name string) bool {
+			if slices.Contains(group.queries, name) {
+				return true
+			}
+			_, ok := anomalyFuncs[name]
+			return first && !ok
+		})
+		if err != nil {
+			return nil, err
+		}
 
-	return provider.GetAnomalies(ctx, orgID, &anomalyV2.AnomaliesRequest{Params: queryRangeRequest})
+		anomalies, err := provider.GetAnomalies(ctx, orgID, &anomalyV2.AnomaliesRequest{
+			Params:      params,
+			Seasonality: group.seasonality,
+			Queries:     group.queries,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// the results of the queries without the anomaly function are of the first group
+		if resp == nil {
+			resp = &qbtypes.QueryRangeResponse{
+				Type:    queryRangeRequest.RequestType,
+				Meta:    anomalies.Meta,
+				Warning: anomalies.Warning,
+			}
+			results = anomalies.Results
+			continue
+		}
+
+		for _, result := range anomalies.Results {
+			if !slices.Contains(group.queries, result.QueryName) {
+				continue
+			}
+			idx := slices.IndexFunc(results, func(item *qbtypes.TimeSeriesData) bool {
+				return item.QueryName == result.QueryName
+			})
+			if idx == -1 {
+				results = append(results, result)
+			} else {
+				results[idx] = result
+			}
+		}
+	}
+
+	resp.Data.Results = make([]any, 0, len(results))
+	for _, result := range results {
+		resp.Data.Results = append(resp.Data.Results, result)
+	}
+
+	return resp, nil
 }
 
 func (aH *APIHandler) queryRangeV5(rw http.ResponseWriter, req *http.Request) {
@@ -245,35 +301,18 @@ func (aH *APIHandler) queryRangeV5(rw http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	if anomalyQuery, ok := queryRangeRequest.IsAnomalyRequest(); ok {
-		anomalies, err := aH.handleAnomalyQuery(ctx, orgID, anomalyQuery, queryRangeRequest)
+	if anomalyFuncs := queryRangeRequest.AnomalyFunctions(); len(anomalyFuncs) > 0 {
+		resp, err := aH.handleAnomalyQuery(ctx, orgID, anomalyFuncs, queryRangeRequest)
+		if errors.Ast(err, errors.TypeInvalidInput) {
+			render.Error(rw, err)
+			return
+		}
 		if err != nil {
 			render.Error(rw, errors.NewInternalf(errors.CodeInternal, "failed to get anomalies: %v", err))
 			return
 		}
 
-		results := []any{}
-		for _, item := range anomalies.Results {
-			results = append(results, item)
-		}
-
-		// Build step intervals from the anomaly query
-		stepIntervals := make(map[string]uint64)
-		if anomalyQuery.StepInterval.Duration > 0 {
-			stepIntervals[anomalyQuery.Name] = uint64(anomalyQuery.StepInterval.Duration.Seconds())
-		}
-
-		finalResp := &qbtypes.QueryRangeResponse{
-			Type: queryRangeRequest.RequestType,
-			Data: qbtypes.QueryData{
-				Results: results,
-			},
-			Meta: qbtypes.ExecStats{
-				StepIntervals: stepIntervals,
-			},
-		}
-
-		render.Success(rw, http.StatusOK, finalResp)
+		render.Success(rw, http.StatusOK, resp)
 		return
 	} else {
 		// regular query range request, let the querier handle it
@@ -11,6 +11,7 @@ import (
 	"github.com/SigNoz/signoz/pkg/cache/memorycache"
 	"github.com/SigNoz/signoz/pkg/factory"
 	"github.com/SigNoz/signoz/pkg/queryparser"
+	"github.com/SigNoz/signoz/pkg/ruler/rulestore/clickhouserulestore"
 	"github.com/SigNoz/signoz/pkg/ruler/rulestore/sqlrulestore"
 	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux"
@@ -18,6 +19,7 @@ import (
 
 	"github.com/gorilla/handlers"
 
+	"github.com/SigNoz/signoz/ee/anomaly"
 	"github.com/SigNoz/signoz/ee/query-service/app/api"
 	"github.com/SigNoz/signoz/ee/query-service/integrations/gateway"
 	"github.com/SigNoz/signoz/ee/query-service/rules"
@@ -28,6 +30,7 @@ import (
 	"github.com/SigNoz/signoz/pkg/modules/organization"
 	"github.com/SigNoz/signoz/pkg/prometheus"
 	"github.com/SigNoz/signoz/pkg/querier"
+	"github.com/SigNoz/signoz/pkg/sharder"
 	"github.com/SigNoz/signoz/pkg/signoz"
 	"github.com/SigNoz/signoz/pkg/sqlstore"
 	"github.com/SigNoz/signoz/pkg/telemetrystore"
@@ -46,6 +49,7 @@ import (
 	baseconst "github.com/SigNoz/signoz/pkg/query-service/constants"
 	"github.com/SigNoz/signoz/pkg/query-service/healthcheck"
 	baseint "github.com/SigNoz/signoz/pkg/query-service/interfaces"
+	basemodel "github.com/SigNoz/signoz/pkg/query-service/model"
 	baserules "github.com/SigNoz/signoz/pkg/query-service/rules"
 	"github.com/SigNoz/signoz/pkg/query-service/utils"
 	"go.uber.org/zap"
@@ -99,6 +103,8 @@ func NewServer(config signoz.Config, signoz *signoz.SigNoz) (*Server, error) {
 		nil,
 	)
 
+	anomalyRegistry := anomaly.NewRegistry(signoz.Querier, signoz.Instrumentation.Logger())
+
 	rm, err := makeRulesManager(
 		reader,
 		signoz.Cache,
@@ -111,12 +117,16 @@ func NewServer(config signoz.Config, signoz *signoz.SigNoz) (*Server, error) {
 		signoz.Querier,
 		signoz.Instrumentation.ToProviderSettings(),
 		signoz.QueryParser,
+		signoz.Sharder,
+		anomalyRegistry,
 	)
 
 	if err != nil {
 		return nil, err
 	}
 
+	signoz.Modules.SLO.SetRuleManager(rm)
+
 	// initiate opamp
 	opAmpModel.Init(signoz.SQLStore, signoz.Instrumentation.Logger(), signoz.Modules.OrgGetter)
 
@@ -173,6 +183,7 @@ func NewServer(config signoz.Config, signoz *signoz.SigNoz) (*Server, error) {
 		Gateway:                       gatewayProxy,
 		GatewayUrl:                    config.Gateway.URL.String(),
 		GlobalConfig:                  config.Global,
+		AnomalyRegistry:               anomalyRegistry,
 	}
 
 	apiHandler, err := api.NewAPIHandler(apiOpts, signoz)
@@ -357,29 +368,45 @@ func (s *Server) Stop(ctx context.Context) error {
 	return nil
 }
 
-func makeRulesManager(ch baseint.Reader, cache cache.Cache, alertmanager alertmanager.Alertmanager, sqlstore sqlstore.SQLStore, telemetryStore telemetrystore.TelemetryStore, metadataStore telemetrytypes.MetadataStore, prometheus prometheus.Prometheus, orgGetter organization.Getter, querier querier.Querier, providerSettings factory.ProviderSettings, queryParser queryparser.QueryParser) (*baserules.Manager, error) {
+func makeRulesManager(ch baseint.Reader, cache cache.Cache, alertmanager alertmanager.Alertmanager, sqlstore sqlstore.SQLStore, telemetryStore telemetrystore.TelemetryStore, metadataStore telemetrytypes.MetadataStore, prometheus prometheus.Prometheus, orgGetter organization.Getter, querier querier.Querier, providerSettings factory.ProviderSettings, queryParser queryparser.QueryParser, sharder sharder.Sharder, anomalyRegistry *anomaly.Registry) (*baserules.Manager, error) {
 	ruleStore := sqlrulestore.NewRuleStore(sqlstore, queryParser, providerSettings)
 	maintenanceStore := sqlrulestore.NewMaintenanceStore(sqlstore)
+	absentGroupStore := sqlrulestore.NewAbsentGroupStore(sqlstore)
+	// the anomaly rules share the registry of the anomaly algorithms
+	prepareTaskFunc := func(opts baserules.PrepareTaskOptions) (baserules.Task, error) {
+		return rules.PrepareTaskFunc(opts, anomalyRegistry)
+	}
+	prepareTestRuleFunc := func(opts baserules.PrepareTestRuleOptions) (int, *basemodel.ApiError) {
+		return rules.TestNotification(opts, anomalyRegistry)
+	}
+	prepareBacktestRuleFunc := func(opts baserules.PrepareTestRuleOptions) (baserules.Rule, error) {
+		return rules.BacktestRule(opts, anomalyRegistry)
+	}
+
 	// create manager opts
 	managerOpts := &baserules.ManagerOptions{
-		TelemetryStore:      telemetryStore,
-		MetadataStore:       metadataStore,
-		Prometheus:          prometheus,
-		Context:             context.Background(),
-		Logger:              zap.L(),
-		Reader:              ch,
-		Querier:             querier,
-		SLogger:             providerSettings.Logger,
-		Cache:               cache,
-		EvalDelay:           baseconst.GetEvalDelay(),
-		PrepareTaskFunc:     rules.PrepareTaskFunc,
-		PrepareTestRuleFunc: rules.TestNotification,
-		Alertmanager:        alertmanager,
-		OrgGetter:           orgGetter,
-		RuleStore:           ruleStore,
-		MaintenanceStore:    maintenanceStore,
-		SqlStore:            sqlstore,
-		QueryParser:         queryParser,
+		TelemetryStore:          telemetryStore,
+		MetadataStore:           metadataStore,
+		Prometheus:              prometheus,
+		Context:                 context.Background(),
+		Logger:                  zap.L(),
+		Reader:                  ch,
+		Querier:                 querier,
+		SLogger:                 providerSettings.Logger,
+		Cache:                   cache,
+		EvalDelay:               baseconst.GetEvalDelay(),
+		PrepareTaskFunc:         prepareTaskFunc,
+		PrepareTestRuleFunc:     prepareTestRuleFunc,
+		PrepareBacktestRuleFunc: prepareBacktestRuleFunc,
+		Alertmanager:            alertmanager,
+		OrgGetter:               orgGetter,
+		RuleStore:               ruleStore,
+		MaintenanceStore:        maintenanceStore,
+		AbsentGroupStore:        absentGroupStore,
+		SqlStore:                sqlstore,
+		QueryParser:             queryParser,
+		RecordingStore:          clickhouserulestore.NewRecordingStore(telemetryStore),
+		Sharder:                 sharder,
 	}
 
 	// create Manager
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	anomalyV2 "github.com/SigNoz/signoz/ee/anomaly"
 	"github.com/SigNoz/signoz/ee/query-service/anomaly"
 	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
 	"github.com/SigNoz/signoz/pkg/query-service/app/clickhouseReader"
@@ -126,6 +127,7 @@ func TestAnomalyRule_NoData_AlertOnAbsent(t *testing.T) {
 				nil,
 				logger,
 				nil,
+				anomalyV2.NewRegistry(nil, logger),
 			)
 			require.NoError(t, err)
 
@@ -249,7 +251,7 @@ func TestAnomalyRule_NoData_AbsentFor(t *testing.T) {
 			options := clickhouseReader.NewOptions("primaryNamespace")
 			reader := clickhouseReader.NewReader(nil, telemetryStore, nil, "", time.Second, nil, nil, options)
 
-			rule, err := NewAnomalyRule("test-anomaly-rule", valuer.GenerateUUID(), &postableRule, reader, nil, logger, nil)
+			rule, err := NewAnomalyRule("test-anomaly-rule", valuer.GenerateUUID(), &postableRule, reader, nil, logger, nil, anomalyV2.NewRegistry(nil, logger))
 			require.NoError(t, err)
 
 			rule.provider = &mockAnomalyProvider{
@@ -6,6 +6,7 @@ import (
 
 	"time"
 
+	anomalyV2 "github.com/SigNoz/signoz/ee/anomaly"
 	"github.com/SigNoz/signoz/pkg/errors"
 	basemodel "github.com/SigNoz/signoz/pkg/query-service/model"
 	baserules "github.com/SigNoz/signoz/pkg/query-service/rules"
@@ -16,7 +17,7 @@ import (
 	"go.uber.org/zap"
 )
 
-func PrepareTaskFunc(opts baserules.PrepareTaskOptions) (baserules.Task, error) {
+func PrepareTaskFunc(opts baserules.PrepareTaskOptions, registry *anomalyV2.Registry) (baserules.Task, error) {
 
 	rules := make([]baserules.Rule, 0)
 	var task baserules.Task
@@ -39,6 +40,7 @@ func PrepareTaskFunc(opts baserules.PrepareTaskOptions) (baserules.Task, error)
 			baserules.WithSQLStore(opts.SQLStore),
 			baserules.WithQueryParser(opts.ManagerOpts.QueryParser),
 			baserules.WithMetadataStore(opts.ManagerOpts.MetadataStore),
+			baserules.WithAbsentGroupStore(opts.ManagerOpts.AbsentGroupStore),
 		)
 
 		if err != nil {
@@ -63,6 +65,7 @@ func PrepareTaskFunc(opts baserules.PrepareTaskOptions) (baserules.Task, error)
 			baserules.WithSQLStore(opts.SQLStore),
 			baserules.WithQueryParser(opts.ManagerOpts.QueryParser),
 			baserules.WithMetadataStore(opts.ManagerOpts.MetadataStore),
+			baserules.WithAbsentGroupStore(opts.ManagerOpts.AbsentGroupStore),
 		)
 
 		if err != nil {
@@ -84,10 +87,12 @@ func PrepareTaskFunc(opts baserules.PrepareTaskOptions) (baserules.Task, error)
 			opts.Querier,
 			opts.SLogger,
 			opts.Cache,
+			registry,
 			baserules.WithEvalDelay(opts.ManagerOpts.EvalDelay),
 			baserules.WithSQLStore(opts.SQLStore),
 			baserules.WithQueryParser(opts.ManagerOpts.QueryParser),
 			baserules.WithMetadataStore(opts.ManagerOpts.MetadataStore),
+			baserules.WithAbsentGroupStore(opts.ManagerOpts.AbsentGroupStore),
 		)
 		if err != nil {
 			return task, err
@@ -98,8 +103,72 @@ func PrepareTaskFunc(opts baserules.PrepareTaskOptions) (baserules.Task, error)
 		// create anomaly rule task for evaluation
 		task = newTask(baserules.TaskTypeCh, opts.TaskName, evaluation.GetFrequency().Duration(), rules, opts.ManagerOpts, opts.NotifyFunc, opts.MaintenanceStore, opts.OrgID)
 
+	} else if opts.Rule.RuleType == ruletypes.RuleTypeComposite {
+		// create composite rule
+		cr, err := baserules.NewCompositeRule(
+			ruleId,
+			opts.OrgID,
+			opts.Rule,
+			opts.RuleState,
+			opts.Reader,
+			opts.SLogger,
+			baserules.WithSQLStore(opts.SQLStore),
+		)
+		if err != nil {
+			return task, err
+		}
+
+		rules = append(rules, cr)
+
+		// create composite rule task, the manager evaluates it after the rules it depends on
+		task = newTask(baserules.TaskTypeComposite, opts.TaskName, evaluation.GetFrequency().Duration(), rules, opts.ManagerOpts, opts.NotifyFunc, opts.MaintenanceStore, opts.OrgID)
+
+	} else if opts.Rule.RuleType == ruletypes.RuleTypeBurnRate {
+		// create burn rate rule
+		br, err := baserules.NewBurnRateRule(
+			ruleId,
+			opts.OrgID,
+			opts.Rule,
+			opts.Querier,
+			opts.Reader,
+			opts.SLogger,
+			baserules.WithEvalDelay(opts.ManagerOpts.EvalDelay),
+			baserules.WithSQLStore(opts.SQLStore),
+		)
+		if err != nil {
+			return task, err
+		}
+
+		rules = append(rules, br)
+
+		// create ch rule task for evaluation
+		task = newTask(baserules.TaskTypeCh, opts.TaskName, evaluation.GetFrequency().Duration(), rules, opts.ManagerOpts, opts.NotifyFunc, opts.MaintenanceStore, opts.OrgID)
+
+	} else if opts.Rule.RuleType == ruletypes.RuleTypeRecording {
+		// create recording rule
+		rr, err := baserules.NewRecordingRule(
+			ruleId,
+			opts.OrgID,
+			opts.Rule,
+			opts.Querier,
+			opts.ManagerOpts.RecordingStore,
+			opts.ManagerOpts.Sharder,
+			opts.Reader,
+			opts.SLogger,
+			baserules.WithEvalDelay(opts.ManagerOpts.EvalDelay),
+			baserules.WithSQLStore(opts.SQLStore),
+		)
+		if err != nil {
+			return task, err
+		}
+
+		rules = append(rules, rr)
+
+		// create ch rule task for evaluation
+		task = newTask(baserules.TaskTypeCh, opts.TaskName, evaluation.GetFrequency().Duration(), rules, opts.ManagerOpts, opts.NotifyFunc, opts.MaintenanceStore, opts.OrgID)
+
 	} else {
-		return nil, fmt.Errorf("unsupported rule type %s. Supported types: %s, %s", opts.Rule.RuleType, ruletypes.RuleTypeProm, ruletypes.RuleTypeThreshold)
+		return nil, fmt.Errorf("unsupported rule type %s. Supported types: %s, %s, %s, %s, %s, %s", opts.Rule.RuleType, ruletypes.RuleTypeProm, ruletypes.RuleTypeThreshold, ruletypes.RuleTypeAnomaly, ruletypes.RuleTypeComposite, ruletypes.RuleTypeBurnRate, ruletypes.RuleTypeRecording)
 	}
 
 	return task, nil
@@ -107,7 +176,7 @@ func PrepareTaskFunc(opts baserules.PrepareTaskOptions) (baserules.Task, error)
 
 // TestNotification prepares a dummy rule for given rule parameters and
 // sends a test notification. returns alert count and error (if any)
-func TestNotification(opts baserules.PrepareTestRuleOptions) (int, *basemodel.ApiError) {
+func TestNotification(opts baserules.PrepareTestRuleOptions, registry *anomalyV2.Registry) (int, *basemodel.ApiError) {
 
 	ctx := context.Background()
 
@@ -186,6 +255,7 @@ func TestNotification(opts baserules.PrepareTestRuleOptions) (int, *basemodel.Ap
 			opts.Querier,
 			opts.SLogger,
 			opts.Cache,
+			registry,
 			baserules.WithSendAlways(),
 			baserules.WithSendUnmatched(),
 			baserules.WithSQLStore(opts.SQLStore),
@@ -213,10 +283,33 @@ func TestNotification(opts baserules.PrepareTestRuleOptions) (int, *basemodel.Ap
 	return alertsFound, nil
 }
 
+// BacktestRule prepares the rule which is replayed by a backtest, the anomaly
+// rules are prepared here and the other ones by the default backtest.
+func BacktestRule(opts baserules.PrepareTestRuleOptions, registry *anomalyV2.Registry) (baserules.Rule, error) {
+	if opts.Rule == nil || opts.Rule.RuleType != ruletypes.RuleTypeAnomaly {
+		return baserules.DefaultBacktestRule(opts)
+	}
+
+	return NewAnomalyRule(
+		valuer.GenerateUUID().StringValue(),
+		opts.OrgID,
+		opts.Rule,
+		opts.Reader,
+		opts.Querier,
+		opts.SLogger,
+		opts.Cache,
+		registry,
+		append(baserules.BacktestRuleOptions(opts), baserules.WithEvalDelay(opts.ManagerOpts.EvalDelay))...,
+	)
+}
+
 // newTask returns an appropriate group for the rule type
 func newTask(taskType baserules.TaskType, name string, frequency time.Duration, rules []baserules.Rule, opts *baserules.ManagerOptions, notify baserules.NotifyFunc, maintenanceStore ruletypes.MaintenanceStore, orgID valuer.UUID) baserules.Task {
 	if taskType == baserules.TaskTypeCh {
 		return baserules.NewRuleTask(name, "", frequency, rules, opts, notify, maintenanceStore, orgID)
 	}
+	if taskType == baserules.TaskTypeComposite {
+		return baserules.NewCompositeRuleTask(name, "", frequency, rules, opts, notify, maintenanceStore, orgID)
+	}
 	return baserules.NewPromRuleTask(name, "", frequency, rules, opts, notify, maintenanceStore, orgID)
 }
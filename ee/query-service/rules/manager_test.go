@@ -8,11 +8,13 @@ import (
 	"testing"
 	"time"
 
+	anomalyV2 "github.com/SigNoz/signoz/ee/anomaly"
 	"github.com/SigNoz/signoz/pkg/alertmanager"
 	alertmanagermock "github.com/SigNoz/signoz/pkg/alertmanager/alertmanagertest"
 	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
 	"github.com/SigNoz/signoz/pkg/prometheus"
 	"github.com/SigNoz/signoz/pkg/prometheus/prometheustest"
+	"github.com/SigNoz/signoz/pkg/query-service/model"
 	"github.com/SigNoz/signoz/pkg/query-service/rules"
 	"github.com/SigNoz/signoz/pkg/sqlstore"
 	"github.com/SigNoz/signoz/pkg/sqlstore/sqlstoretest"
@@ -59,7 +61,10 @@ func TestManager_TestNotification_SendUnmatched_ThresholdRule(t *testing.T) {
 					}
 				},
 				ManagerOptionsHook: func(opts *rules.ManagerOptions) {
-					opts.PrepareTestRuleFunc = TestNotification
+					registry := anomalyV2.NewRegistry(opts.Querier, opts.SLogger)
+					opts.PrepareTestRuleFunc = func(o rules.PrepareTestRuleOptions) (int, *model.ApiError) {
+						return TestNotification(o, registry)
+					}
 				},
 				SqlStoreHook: func(store sqlstore.SQLStore) {
 					mockStore := store.(*sqlstoretest.Provider)
@@ -249,7 +254,10 @@ func TestManager_TestNotification_SendUnmatched_PromRule(t *testing.T) {
 					if promProvider != nil {
 						opts.Prometheus = promProvider
 					}
-					opts.PrepareTestRuleFunc = TestNotification
+					registry := anomalyV2.NewRegistry(opts.Querier, opts.SLogger)
+					opts.PrepareTestRuleFunc = func(o rules.PrepareTestRuleOptions) (int, *model.ApiError) {
+						return TestNotification(o, registry)
+					}
 				},
 			})
 
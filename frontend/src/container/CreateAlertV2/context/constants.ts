@@ -158,6 +158,9 @@ export const ANOMALY_TIME_DURATION_OPTIONS = [
 
 export const ANOMALY_ALGORITHM_OPTIONS = [
 	{ value: Algorithm.STANDARD, label: 'Standard' },
+	{ value: Algorithm.HOLT_WINTERS, label: 'Holt-Winters' },
+	{ value: Algorithm.MAD, label: 'Median absolute deviation' },
+	{ value: Algorithm.STL, label: 'Seasonal-trend decomposition' },
 ];
 
 export const ANOMALY_SEASONALITY_OPTIONS = [
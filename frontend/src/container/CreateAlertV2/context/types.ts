@@ -124,6 +124,9 @@ export enum TimeDuration {
 
 export enum Algorithm {
 	STANDARD = 'standard',
+	HOLT_WINTERS = 'holt_winters',
+	MAD = 'mad',
+	STL = 'stl',
 }
 
 export enum Seasonality {
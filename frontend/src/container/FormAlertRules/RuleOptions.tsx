@@ -210,6 +210,9 @@ function RuleOptions({
 			onChange={onChangeAlgorithm}
 		>
 			<Select.Option value="standard">Standard</Select.Option>
+			<Select.Option value="holt_winters">Holt-Winters</Select.Option>
+			<Select.Option value="mad">Median absolute deviation</Select.Option>
+			<Select.Option value="stl">Seasonal-trend decomposition</Select.Option>
 		</InlineSelect>
 	);
 
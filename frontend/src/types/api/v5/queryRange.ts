@@ -277,6 +277,7 @@ export interface QueryBuilderJoin {
 	having?: Having;
 	order?: OrderBy[];
 	limit?: number;
+	offset?: number;
 	secondaryAggregations?: SecondaryAggregation[];
 	functions?: QueryFunction[];
 }
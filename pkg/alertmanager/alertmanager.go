@@ -30,6 +30,49 @@ type Alertmanager interface {
 	// TestAlert sends an alert to a list of receivers.
 	TestAlert(ctx context.Context, orgID string, ruleID string, receiversMap map[*alertmanagertypes.PostableAlert][]string) error
 
+	// ListSilences lists the silences of the organization, including the expired silences which are still retained.
+	ListSilences(context.Context, string) (alertmanagertypes.GettableSilences, error)
+
+	// GetSilence gets a silence of the organization.
+	GetSilence(context.Context, string, string) (*alertmanagertypes.GettableSilence, error)
+
+	// SetSilence creates a silence for the organization, or updates it when it has an id. The user setting the silence
+	// is recorded as its creator, or as the user who last updated it. It returns the id of the silence.
+	SetSilence(ctx context.Context, orgID string, setBy string, silence *alertmanagertypes.PostableSilence) (string, error)
+
+	// ExpireSilence expires a silence of the organization.
+	ExpireSilence(context.Context, string, string) error
+
+	// PreviewSilence lists the active alerts of the organization which would be muted by the silence.
+	PreviewSilence(context.Context, string, *alertmanagertypes.PostableSilence) (alertmanagertypes.GettableAlerts, error)
+
+	// ListAcknowledgements lists the acknowledged and the assigned alert groups of the organization.
+	ListAcknowledgements(context.Context, string) (alertmanagertypes.GettableAcknowledgements, error)
+
+	// Acknowledge acknowledges the group of a firing alert of the organization and records it in the alert history.
+	Acknowledge(ctx context.Context, orgID string, acknowledgedBy string, acknowledgement *alertmanagertypes.PostableAcknowledgement) (*alertmanagertypes.GettableAcknowledgement, error)
+
+	// Unacknowledge withdraws the acknowledgement of the group of an alert of the organization and records it in the alert history.
+	Unacknowledge(ctx context.Context, orgID string, acknowledgement *alertmanagertypes.PostableAcknowledgement) error
+
+	// Assign assigns the group of a firing alert of the organization to the assignee of the acknowledgement.
+	Assign(ctx context.Context, orgID string, assignedBy string, acknowledgement *alertmanagertypes.PostableAcknowledgement) (*alertmanagertypes.GettableAcknowledgement, error)
+
+	// ListWebhookDeliveries lists the latest deliveries of the signoz webhooks of the organization.
+	ListWebhookDeliveries(ctx context.Context, orgID string, params *alertmanagertypes.ListWebhookDeliveriesParams) (alertmanagertypes.GettableWebhookDeliveries, error)
+
+	// ResendWebhookDelivery queues a failed delivery of a signoz webhook of the organization to be re-sent.
+	ResendWebhookDelivery(ctx context.Context, orgID string, id valuer.UUID) (*alertmanagertypes.GettableWebhookDelivery, error)
+
+	// ListDigests lists the digests of the channels of the organization.
+	ListDigests(ctx context.Context, orgID string) (alertmanagertypes.GettableDigests, error)
+
+	// SetDigest creates the digest of a channel of the organization or updates the existing one.
+	SetDigest(ctx context.Context, orgID string, updatedBy string, digest *alertmanagertypes.PostableDigest) (*alertmanagertypes.GettableDigest, error)
+
+	// DeleteDigest deletes a digest of the organization, the alerts of its channel are notified again.
+	DeleteDigest(ctx context.Context, orgID string, id valuer.UUID) error
+
 	// ListChannels lists all channels for the organization.
 	ListChannels(context.Context, string) ([]*alertmanagertypes.Channel, error)
 
@@ -71,6 +114,13 @@ type Alertmanager interface {
 	DeleteAllRoutePoliciesByRuleId(ctx context.Context, ruleId string) error
 	UpdateAllRoutePoliciesByRuleId(ctx context.Context, ruleId string, routes []*alertmanagertypes.PostableRoutePolicy) error
 
+	// Escalation Policy CRUD
+	CreateEscalationPolicy(ctx context.Context, policy *alertmanagertypes.PostableEscalationPolicy) (*alertmanagertypes.GettableEscalationPolicy, error)
+	GetEscalationPolicyByID(ctx context.Context, policyID string) (*alertmanagertypes.GettableEscalationPolicy, error)
+	GetAllEscalationPolicies(ctx context.Context) ([]*alertmanagertypes.GettableEscalationPolicy, error)
+	UpdateEscalationPolicyByID(ctx context.Context, policyID string, policy *alertmanagertypes.PostableEscalationPolicy) (*alertmanagertypes.GettableEscalationPolicy, error)
+	DeleteEscalationPolicyByID(ctx context.Context, policyID string) error
+
 	CreateInhibitRules(ctx context.Context, orgID valuer.UUID, rules []amConfig.InhibitRule) error
 	DeleteAllInhibitRulesByRuleId(ctx context.Context, orgID valuer.UUID, ruleId string) error
 
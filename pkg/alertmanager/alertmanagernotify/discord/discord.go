@@ -0,0 +1,166 @@
+package discord
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	commoncfg "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+)
+
+const (
+	// https://discord.com/developers/docs/resources/message#embed-object-embed-limits
+	maxTitleLenRunes       = 256
+	maxDescriptionLenRunes = 4096
+	maxContentLenRunes     = 2000
+)
+
+const (
+	colorRed   = 0x992D22
+	colorGreen = 0x2ECC71
+	colorGrey  = 0x95A5A6
+)
+
+type Notifier struct {
+	conf         *config.DiscordConfig
+	titleLink    string
+	tmpl         *template.Template
+	logger       *slog.Logger
+	client       *http.Client
+	retrier      *notify.Retrier
+	postJSONFunc func(ctx context.Context, client *http.Client, url string, body io.Reader) (*http.Response, error)
+}
+
+// https://discord.com/developers/docs/resources/webhook#execute-webhook
+type webhook struct {
+	Content   string  `json:"content"`
+	Embeds    []embed `json:"embeds"`
+	Username  string  `json:"username,omitempty"`
+	AvatarURL string  `json:"avatar_url,omitempty"`
+}
+
+type embed struct {
+	Title       string `json:"title"`
+	URL         string `json:"url,omitempty"`
+	Description string `json:"description"`
+	Color       int    `json:"color"`
+}
+
+// New returns a new notifier that uses the Discord webhooks, with the title of its embed linking to titleLink.
+func New(c *config.DiscordConfig, t *template.Template, titleLink string, l *slog.Logger, httpOpts ...commoncfg.HTTPClientOption) (*Notifier, error) {
+	client, err := commoncfg.NewClientFromConfig(*c.HTTPConfig, "discord", httpOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Notifier{
+		conf:         c,
+		titleLink:    titleLink,
+		tmpl:         t,
+		logger:       l,
+		client:       client,
+		retrier:      &notify.Retrier{RetryCodes: []int{http.StatusTooManyRequests}},
+		postJSONFunc: notify.PostJSON,
+	}, nil
+}
+
+func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	key, err := notify.ExtractGroupKey(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	n.logger.DebugContext(ctx, "extracted group key", "key", key)
+
+	data := notify.GetTemplateData(ctx, n.tmpl, as, n.logger)
+	tmpl := notify.TmplText(n.tmpl, data, &err)
+
+	title, truncated := notify.TruncateInRunes(tmpl(n.conf.Title), maxTitleLenRunes)
+	if truncated {
+		n.logger.WarnContext(ctx, "truncated title", "key", key, "max_runes", maxTitleLenRunes)
+	}
+
+	description, truncated := notify.TruncateInRunes(tmpl(n.conf.Message), maxDescriptionLenRunes)
+	if truncated {
+		n.logger.WarnContext(ctx, "truncated message", "key", key, "max_runes", maxDescriptionLenRunes)
+	}
+
+	content, truncated := notify.TruncateInRunes(tmpl(n.conf.Content), maxContentLenRunes)
+	if truncated {
+		n.logger.WarnContext(ctx, "truncated content", "key", key, "max_runes", maxContentLenRunes)
+	}
+
+	titleLink := tmpl(n.titleLink)
+	if err != nil {
+		return false, err
+	}
+
+	color := colorGrey
+	switch types.Alerts(as...).Status() {
+	case model.AlertFiring:
+		color = colorRed
+	case model.AlertResolved:
+		color = colorGreen
+	}
+
+	var webhookURL string
+	if n.conf.WebhookURL != nil {
+		webhookURL = n.conf.WebhookURL.String()
+	} else {
+		content, err := os.ReadFile(n.conf.WebhookURLFile)
+		if err != nil {
+			return false, errors.WrapInternalf(err, errors.CodeInternal, "read webhook_url_file")
+		}
+		webhookURL = strings.TrimSpace(string(content))
+	}
+
+	w := webhook{
+		Content:  content,
+		Username: n.conf.Username,
+		Embeds: []embed{{
+			Title:       title,
+			URL:         titleLink,
+			Description: description,
+			Color:       color,
+		}},
+	}
+
+	if n.conf.AvatarURL != "" {
+		if _, err := url.Parse(n.conf.AvatarURL); err == nil {
+			w.AvatarURL = n.conf.AvatarURL
+		} else {
+			n.logger.WarnContext(ctx, "bad avatar url", "key", key)
+		}
+	}
+
+	var payload bytes.Buffer
+	if err = json.NewEncoder(&payload).Encode(w); err != nil {
+		return false, err
+	}
+
+	resp, err := n.postJSONFunc(ctx, n.client, webhookURL, &payload) //nolint:bodyclose
+	if err != nil {
+		return true, notify.RedactURL(err)
+	}
+	defer notify.Drain(resp) //drain is used to close the body of the response hence the nolint directive
+
+	shouldRetry, err := n.retrier.Check(resp.StatusCode, resp.Body)
+	if err != nil {
+		return shouldRetry, notify.NewErrorWithReason(notify.GetFailureReasonFromStatusCode(resp.StatusCode), err)
+	}
+
+	return shouldRetry, err
+}
@@ -0,0 +1,106 @@
+package discord
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	commoncfg "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/promslog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	test "github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/alertmanagernotifytest"
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func TestDiscordRetry(t *testing.T) {
+	u, err := url.Parse("https://discord.com/api/webhooks/1/token")
+	require.NoError(t, err)
+
+	notifier, err := New(
+		&config.DiscordConfig{
+			WebhookURL: &config.SecretURL{URL: u},
+			HTTPConfig: &commoncfg.HTTPClientConfig{},
+		},
+		test.CreateTmpl(t),
+		`{{ template "discord.default.titleLink" . }}`,
+		promslog.NewNopLogger(),
+	)
+	require.NoError(t, err)
+
+	for statusCode, expected := range test.RetryTests(append(test.DefaultRetryCodes(), http.StatusTooManyRequests)) {
+		actual, _ := notifier.retrier.Check(statusCode, nil)
+		require.Equal(t, expected, actual, "retry - error on status %d", statusCode)
+	}
+}
+
+func TestDiscordTemplating(t *testing.T) {
+	var out webhook
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&out))
+	}))
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	notifier, err := New(
+		&config.DiscordConfig{
+			WebhookURL: &config.SecretURL{URL: u},
+			HTTPConfig: &commoncfg.HTTPClientConfig{},
+			Title:      config.DefaultDiscordConfig.Title,
+			Message:    config.DefaultDiscordConfig.Message,
+		},
+		test.CreateTmpl(t),
+		`{{ template "discord.default.titleLink" . }}`,
+		promslog.NewNopLogger(),
+	)
+	require.NoError(t, err)
+
+	retry, err := notifier.Notify(notify.WithGroupKey(context.Background(), "1"), &types.Alert{
+		Alert: model.Alert{
+			Labels:      model.LabelSet{"alertname": "HighLatency", "ruleId": "rule-1", "threshold.name": "critical"},
+			Annotations: model.LabelSet{"summary": "latency is high", "related_logs": "http://am/logs/logs-explorer?q=1"},
+			StartsAt:    time.Now(),
+			EndsAt:      time.Now().Add(time.Hour),
+		},
+	})
+	require.NoError(t, err)
+	assert.False(t, retry)
+
+	require.Len(t, out.Embeds, 1)
+	assert.Equal(t, "http://am/alerts/edit?ruleId=rule-1", out.Embeds[0].URL)
+	assert.Equal(t, colorRed, out.Embeds[0].Color)
+	assert.Contains(t, out.Embeds[0].Title, "[FIRING:1]")
+	assert.Contains(t, out.Embeds[0].Description, "**HighLatency** · threshold critical")
+	assert.Contains(t, out.Embeds[0].Description, "[Related logs](http://am/logs/logs-explorer?q=1)")
+}
+
+func TestDiscordRedactedURL(t *testing.T) {
+	ctx, u, fn := test.GetContextWithCancelingURL()
+	defer fn()
+
+	secret := "secret"
+	u.Path = "/api/webhooks/1/" + secret
+
+	notifier, err := New(
+		&config.DiscordConfig{
+			WebhookURL: &config.SecretURL{URL: u},
+			HTTPConfig: &commoncfg.HTTPClientConfig{},
+		},
+		test.CreateTmpl(t),
+		`{{ template "discord.default.titleLink" . }}`,
+		promslog.NewNopLogger(),
+	)
+	require.NoError(t, err)
+
+	test.AssertNotifyLeaksNoSecret(ctx, t, notifier, secret)
+}
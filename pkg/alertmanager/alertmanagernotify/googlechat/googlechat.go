@@ -0,0 +1,102 @@
+package googlechat
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+
+	commoncfg "github.com/prometheus/common/config"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// https://developers.google.com/workspace/chat/format-messages
+const maxTextLenRunes = 4096
+
+type Notifier struct {
+	conf         *config.WebhookConfig
+	tmpl         *template.Template
+	logger       *slog.Logger
+	client       *http.Client
+	retrier      *notify.Retrier
+	postJSONFunc func(ctx context.Context, client *http.Client, url string, body io.Reader) (*http.Response, error)
+}
+
+// https://developers.google.com/workspace/chat/api/reference/rest/v1/spaces.messages
+type message struct {
+	Text string `json:"text"`
+}
+
+// New returns a new notifier that uses the Google Chat space webhooks, for a webhook config marked as googlechat. The
+// notifications of an alert group are replied in the same thread.
+func New(c *config.WebhookConfig, t *template.Template, l *slog.Logger, httpOpts ...commoncfg.HTTPClientOption) (*Notifier, error) {
+	client, err := commoncfg.NewClientFromConfig(*c.HTTPConfig, "googlechat", httpOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Notifier{
+		conf:         c,
+		tmpl:         t,
+		logger:       l,
+		client:       client,
+		retrier:      &notify.Retrier{RetryCodes: []int{http.StatusTooManyRequests}},
+		postJSONFunc: notify.PostJSON,
+	}, nil
+}
+
+func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	key, err := notify.ExtractGroupKey(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	n.logger.DebugContext(ctx, "extracted group key", "key", key)
+
+	data := notify.GetTemplateData(ctx, n.tmpl, as, n.logger)
+	tmpl := notify.TmplText(n.tmpl, data, &err)
+
+	text, truncated := notify.TruncateInRunes(tmpl(`{{ template "googlechat.default.text" . }}`), maxTextLenRunes)
+	if err != nil {
+		return false, err
+	}
+	if truncated {
+		n.logger.WarnContext(ctx, "truncated text", "key", key, "max_runes", maxTextLenRunes)
+	}
+
+	var payload bytes.Buffer
+	if err = json.NewEncoder(&payload).Encode(message{Text: text}); err != nil {
+		return false, err
+	}
+
+	// the fragment only marks the webhook config as googlechat
+	u := *n.conf.URL.URL
+	u.Fragment = ""
+
+	threadKey := sha256.Sum256([]byte(key.String()))
+	query := u.Query()
+	query.Set("threadKey", hex.EncodeToString(threadKey[:]))
+	query.Set("messageReplyOption", "REPLY_MESSAGE_FALLBACK_TO_NEW_THREAD")
+	u.RawQuery = query.Encode()
+
+	resp, err := n.postJSONFunc(ctx, n.client, u.String(), &payload) //nolint:bodyclose
+	if err != nil {
+		return true, notify.RedactURL(err)
+	}
+	defer notify.Drain(resp) //drain is used to close the body of the response hence the nolint directive
+
+	shouldRetry, err := n.retrier.Check(resp.StatusCode, resp.Body)
+	if err != nil {
+		return shouldRetry, notify.NewErrorWithReason(notify.GetFailureReasonFromStatusCode(resp.StatusCode), err)
+	}
+
+	return shouldRetry, err
+}
@@ -0,0 +1,115 @@
+package googlechat
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	commoncfg "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/promslog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	test "github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/alertmanagernotifytest"
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func TestGoogleChatRetry(t *testing.T) {
+	u, err := url.Parse("https://chat.googleapis.com/v1/spaces/AAA/messages?key=k&token=t#googlechat")
+	require.NoError(t, err)
+
+	notifier, err := New(
+		&config.WebhookConfig{
+			URL:        &config.SecretURL{URL: u},
+			HTTPConfig: &commoncfg.HTTPClientConfig{},
+		},
+		test.CreateTmpl(t),
+		promslog.NewNopLogger(),
+	)
+	require.NoError(t, err)
+
+	for statusCode, expected := range test.RetryTests(append(test.DefaultRetryCodes(), http.StatusTooManyRequests)) {
+		actual, _ := notifier.retrier.Check(statusCode, nil)
+		require.Equal(t, expected, actual, "retry - error on status %d", statusCode)
+	}
+}
+
+func TestGoogleChatTemplating(t *testing.T) {
+	var (
+		query url.Values
+		out   message
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&out))
+	}))
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL + "/v1/spaces/AAA/messages?key=k&token=t#googlechat")
+	require.NoError(t, err)
+
+	notifier, err := New(
+		&config.WebhookConfig{
+			URL:        &config.SecretURL{URL: u},
+			HTTPConfig: &commoncfg.HTTPClientConfig{},
+		},
+		test.CreateTmpl(t),
+		promslog.NewNopLogger(),
+	)
+	require.NoError(t, err)
+
+	alert := &types.Alert{
+		Alert: model.Alert{
+			Labels:      model.LabelSet{"alertname": "HighLatency", "ruleId": "rule-1"},
+			Annotations: model.LabelSet{"summary": "latency is high", "related_traces": "http://am/traces-explorer?q=1"},
+			StartsAt:    time.Now(),
+			EndsAt:      time.Now().Add(time.Hour),
+		},
+	}
+
+	retry, err := notifier.Notify(notify.WithGroupKey(context.Background(), "1"), alert)
+	require.NoError(t, err)
+	assert.False(t, retry)
+
+	assert.Equal(t, "k", query.Get("key"))
+	assert.Equal(t, "t", query.Get("token"))
+	assert.Equal(t, "REPLY_MESSAGE_FALLBACK_TO_NEW_THREAD", query.Get("messageReplyOption"))
+	assert.Contains(t, out.Text, "*<http://am/alerts/edit?ruleId=rule-1|[FIRING:1]")
+	assert.Contains(t, out.Text, "<http://am/traces-explorer?q=1|Related traces>")
+
+	threadKey := query.Get("threadKey")
+	require.NotEmpty(t, threadKey)
+
+	_, err = notifier.Notify(notify.WithGroupKey(context.Background(), "1"), alert)
+	require.NoError(t, err)
+	assert.Equal(t, threadKey, query.Get("threadKey"))
+}
+
+func TestGoogleChatRedactedURL(t *testing.T) {
+	ctx, u, fn := test.GetContextWithCancelingURL()
+	defer fn()
+
+	secret := "secret"
+	u.Path = "/v1/spaces/AAA/messages"
+	u.RawQuery = "key=k&token=" + secret
+	u.Fragment = "googlechat"
+
+	notifier, err := New(
+		&config.WebhookConfig{
+			URL:        &config.SecretURL{URL: u},
+			HTTPConfig: &commoncfg.HTTPClientConfig{},
+		},
+		test.CreateTmpl(t),
+		promslog.NewNopLogger(),
+	)
+	require.NoError(t, err)
+
+	test.AssertNotifyLeaksNoSecret(ctx, t, notifier, secret)
+}
@@ -0,0 +1,91 @@
+package alertmanagernotify
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/contextlinks"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
+)
+
+const (
+	annotationRelatedLogs   = "related_logs"
+	annotationRelatedTraces = "related_traces"
+
+	// relatedLinksLookback is how long before the start of an alert its related logs and traces are looked for.
+	relatedLinksLookback = 5 * time.Minute
+)
+
+// nonTelemetryLabels are the labels set by the ruler, which are not attributes of the telemetry of an alert.
+var nonTelemetryLabels = []string{
+	"alertname",
+	"nodata",
+	"testalert",
+	ruletypes.LabelRuleId,
+	"ruleSource",
+	ruletypes.LabelBurnRateWindow,
+}
+
+// relatedLinksNotifier adds the links to the logs and traces related to an alert to its annotations before notifying
+// it, unless its rule already added them.
+type relatedLinksNotifier struct {
+	notifier notify.Notifier
+	tmpl     *template.Template
+}
+
+func (n *relatedLinksNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	if n.tmpl.ExternalURL == nil {
+		return n.notifier.Notify(ctx, as...)
+	}
+
+	return n.notifier.Notify(ctx, withRelatedLinks(n.tmpl.ExternalURL.String(), time.Now(), as...)...)
+}
+
+// withRelatedLinks returns the alerts with the links to the logs and traces filtered by their labels around the time
+// they were firing. The alerts are copied, as they are shared by the integrations of a receiver.
+func withRelatedLinks(externalURL string, now time.Time, as ...*types.Alert) []*types.Alert {
+	alerts := make([]*types.Alert, 0, len(as))
+	for _, alert := range as {
+		_, hasLogs := alert.Annotations[annotationRelatedLogs]
+		_, hasTraces := alert.Annotations[annotationRelatedTraces]
+		if hasLogs || hasTraces {
+			alerts = append(alerts, alert)
+			continue
+		}
+
+		labels := make(map[string]string, len(alert.Labels))
+		for name, value := range alert.Labels {
+			if slices.Contains(nonTelemetryLabels, string(name)) {
+				continue
+			}
+			labels[string(name)] = string(value)
+		}
+
+		whereClause := contextlinks.PrepareFilterExpression(labels, "", nil)
+		if whereClause == "" {
+			alerts = append(alerts, alert)
+			continue
+		}
+
+		start := alert.StartsAt.Add(-relatedLinksLookback)
+		end := now
+		if alert.ResolvedAt(now) {
+			end = alert.EndsAt
+		}
+
+		withLinks := *alert
+		withLinks.Annotations = alert.Annotations.Clone()
+		withLinks.Annotations[annotationRelatedLogs] = model.LabelValue(fmt.Sprintf("%s/logs/logs-explorer?%s", externalURL, contextlinks.PrepareLinksToLogsV5(start, end, whereClause)))
+		withLinks.Annotations[annotationRelatedTraces] = model.LabelValue(fmt.Sprintf("%s/traces-explorer?%s", externalURL, contextlinks.PrepareLinksToTracesV5(start, end, whereClause)))
+
+		alerts = append(alerts, &withLinks)
+	}
+
+	return alerts
+}
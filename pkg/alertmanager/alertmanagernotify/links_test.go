@@ -0,0 +1,67 @@
+package alertmanagernotify
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRelatedLinks(t *testing.T) {
+	now := time.Now()
+
+	testCases := []struct {
+		name          string
+		alert         *types.Alert
+		expectedLinks bool
+	}{
+		{
+			name: "WithTelemetryLabels",
+			alert: &types.Alert{Alert: model.Alert{
+				Labels:   model.LabelSet{"alertname": "HighLatency", "ruleId": "rule-1", "service.name": "frontend"},
+				StartsAt: now.Add(-time.Hour),
+			}},
+			expectedLinks: true,
+		},
+		{
+			name: "WithoutTelemetryLabels",
+			alert: &types.Alert{Alert: model.Alert{
+				Labels:   model.LabelSet{"alertname": "HighLatency", "ruleId": "rule-1", "threshold.name": "critical", "severity": "critical"},
+				StartsAt: now.Add(-time.Hour),
+			}},
+			expectedLinks: false,
+		},
+		{
+			name: "WithLinksOfRule",
+			alert: &types.Alert{Alert: model.Alert{
+				Labels:      model.LabelSet{"alertname": "HighLatency", "service.name": "frontend"},
+				Annotations: model.LabelSet{"related_logs": "http://am/logs/logs-explorer?q=1"},
+				StartsAt:    now.Add(-time.Hour),
+			}},
+			expectedLinks: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			alerts := withRelatedLinks("http://am", now, tc.alert)
+			require.Len(t, alerts, 1)
+
+			// the alert shared with the other integrations is left untouched
+			assert.NotContains(t, tc.alert.Annotations, model.LabelName(annotationRelatedTraces))
+
+			if !tc.expectedLinks {
+				assert.Same(t, tc.alert, alerts[0])
+				return
+			}
+
+			assert.Contains(t, string(alerts[0].Annotations[annotationRelatedLogs]), "http://am/logs/logs-explorer?compositeQuery=")
+			assert.Contains(t, string(alerts[0].Annotations[annotationRelatedLogs]), "service.name")
+			assert.NotContains(t, string(alerts[0].Annotations[annotationRelatedLogs]), "ruleId")
+			assert.Contains(t, string(alerts[0].Annotations[annotationRelatedTraces]), "http://am/traces-explorer?compositeQuery=")
+		})
+	}
+}
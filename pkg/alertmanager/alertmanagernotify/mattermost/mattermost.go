@@ -0,0 +1,126 @@
+package mattermost
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+
+	commoncfg "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// https://developers.mattermost.com/integrate/reference/message-attachments/
+const maxTextLenRunes = 16383
+
+const (
+	colorRed   = "#992D22"
+	colorGreen = "#2ECC71"
+	colorGrey  = "#95A5A6"
+)
+
+type Notifier struct {
+	conf         *config.WebhookConfig
+	tmpl         *template.Template
+	logger       *slog.Logger
+	client       *http.Client
+	retrier      *notify.Retrier
+	postJSONFunc func(ctx context.Context, client *http.Client, url string, body io.Reader) (*http.Response, error)
+}
+
+// https://developers.mattermost.com/integrate/webhooks/incoming/#parameters
+type webhook struct {
+	Attachments []attachment `json:"attachments"`
+}
+
+type attachment struct {
+	Fallback  string `json:"fallback"`
+	Color     string `json:"color"`
+	Title     string `json:"title"`
+	TitleLink string `json:"title_link,omitempty"`
+	Text      string `json:"text"`
+}
+
+// New returns a new notifier that uses the Mattermost incoming webhooks, for a webhook config marked as mattermost.
+func New(c *config.WebhookConfig, t *template.Template, l *slog.Logger, httpOpts ...commoncfg.HTTPClientOption) (*Notifier, error) {
+	client, err := commoncfg.NewClientFromConfig(*c.HTTPConfig, "mattermost", httpOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Notifier{
+		conf:         c,
+		tmpl:         t,
+		logger:       l,
+		client:       client,
+		retrier:      &notify.Retrier{RetryCodes: []int{http.StatusTooManyRequests}},
+		postJSONFunc: notify.PostJSON,
+	}, nil
+}
+
+func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	key, err := notify.ExtractGroupKey(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	n.logger.DebugContext(ctx, "extracted group key", "key", key)
+
+	data := notify.GetTemplateData(ctx, n.tmpl, as, n.logger)
+	tmpl := notify.TmplText(n.tmpl, data, &err)
+
+	title := tmpl(`{{ template "mattermost.default.title" . }}`)
+	titleLink := tmpl(`{{ template "mattermost.default.titleLink" . }}`)
+	text, truncated := notify.TruncateInRunes(tmpl(`{{ template "mattermost.default.text" . }}`), maxTextLenRunes)
+	if err != nil {
+		return false, err
+	}
+	if truncated {
+		n.logger.WarnContext(ctx, "truncated text", "key", key, "max_runes", maxTextLenRunes)
+	}
+
+	color := colorGrey
+	switch types.Alerts(as...).Status() {
+	case model.AlertFiring:
+		color = colorRed
+	case model.AlertResolved:
+		color = colorGreen
+	}
+
+	var payload bytes.Buffer
+	if err = json.NewEncoder(&payload).Encode(webhook{
+		Attachments: []attachment{{
+			Fallback:  title,
+			Color:     color,
+			Title:     title,
+			TitleLink: titleLink,
+			Text:      text,
+		}},
+	}); err != nil {
+		return false, err
+	}
+
+	// the fragment only marks the webhook config as mattermost
+	u := *n.conf.URL.URL
+	u.Fragment = ""
+
+	resp, err := n.postJSONFunc(ctx, n.client, u.String(), &payload) //nolint:bodyclose
+	if err != nil {
+		return true, notify.RedactURL(err)
+	}
+	defer notify.Drain(resp) //drain is used to close the body of the response hence the nolint directive
+
+	shouldRetry, err := n.retrier.Check(resp.StatusCode, resp.Body)
+	if err != nil {
+		return shouldRetry, notify.NewErrorWithReason(notify.GetFailureReasonFromStatusCode(resp.StatusCode), err)
+	}
+
+	return shouldRetry, err
+}
@@ -0,0 +1,102 @@
+package mattermost
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	commoncfg "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/promslog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	test "github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/alertmanagernotifytest"
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func TestMattermostRetry(t *testing.T) {
+	u, err := url.Parse("https://mattermost.signoz.io/hooks/xyz#mattermost")
+	require.NoError(t, err)
+
+	notifier, err := New(
+		&config.WebhookConfig{
+			URL:        &config.SecretURL{URL: u},
+			HTTPConfig: &commoncfg.HTTPClientConfig{},
+		},
+		test.CreateTmpl(t),
+		promslog.NewNopLogger(),
+	)
+	require.NoError(t, err)
+
+	for statusCode, expected := range test.RetryTests(append(test.DefaultRetryCodes(), http.StatusTooManyRequests)) {
+		actual, _ := notifier.retrier.Check(statusCode, nil)
+		require.Equal(t, expected, actual, "retry - error on status %d", statusCode)
+	}
+}
+
+func TestMattermostTemplating(t *testing.T) {
+	var out webhook
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&out))
+	}))
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL + "/hooks/xyz#mattermost")
+	require.NoError(t, err)
+
+	notifier, err := New(
+		&config.WebhookConfig{
+			URL:        &config.SecretURL{URL: u},
+			HTTPConfig: &commoncfg.HTTPClientConfig{},
+		},
+		test.CreateTmpl(t),
+		promslog.NewNopLogger(),
+	)
+	require.NoError(t, err)
+
+	retry, err := notifier.Notify(notify.WithGroupKey(context.Background(), "1"), &types.Alert{
+		Alert: model.Alert{
+			Labels:      model.LabelSet{"alertname": "HighLatency", "ruleId": "rule-1"},
+			Annotations: model.LabelSet{"summary": "latency is high", "related_logs": "http://am/logs/logs-explorer?q=1"},
+			StartsAt:    time.Now().Add(-time.Hour),
+			EndsAt:      time.Now().Add(-time.Minute),
+		},
+	})
+	require.NoError(t, err)
+	assert.False(t, retry)
+
+	require.Len(t, out.Attachments, 1)
+	assert.Equal(t, colorGreen, out.Attachments[0].Color)
+	assert.Equal(t, "http://am/alerts/edit?ruleId=rule-1", out.Attachments[0].TitleLink)
+	assert.Contains(t, out.Attachments[0].Title, "[RESOLVED]")
+	assert.Contains(t, out.Attachments[0].Text, "**Resolved**")
+	assert.Contains(t, out.Attachments[0].Text, "[Related logs](http://am/logs/logs-explorer?q=1)")
+}
+
+func TestMattermostRedactedURL(t *testing.T) {
+	ctx, u, fn := test.GetContextWithCancelingURL()
+	defer fn()
+
+	secret := "secret"
+	u.Path = "/hooks/" + secret
+	u.Fragment = "mattermost"
+
+	notifier, err := New(
+		&config.WebhookConfig{
+			URL:        &config.SecretURL{URL: u},
+			HTTPConfig: &commoncfg.HTTPClientConfig{},
+		},
+		test.CreateTmpl(t),
+		promslog.NewNopLogger(),
+	)
+	require.NoError(t, err)
+
+	test.AssertNotifyLeaksNoSecret(ctx, t, notifier, secret)
+}
@@ -0,0 +1,149 @@
+package oncall
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/emailing"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/emailtypes"
+	"github.com/SigNoz/signoz/pkg/types/oncalltypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	commoncfg "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/notify/webhook"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// Notifier notifies the participant on call of a schedule at send time, on their webhook with the payload of the
+// upstream webhook notifier and on their email with pkg/emailing.
+type Notifier struct {
+	conf       *config.WebhookConfig
+	orgID      string
+	scheduleID valuer.UUID
+	store      oncalltypes.Store
+	emailing   emailing.Emailing
+	tmpl       *template.Template
+	logger     *slog.Logger
+	httpOpts   []commoncfg.HTTPClientOption
+}
+
+// New returns a new notifier for a webhook config targeting an on-call schedule.
+func New(c *config.WebhookConfig, orgID string, scheduleID valuer.UUID, store oncalltypes.Store, emailing emailing.Emailing, t *template.Template, l *slog.Logger, httpOpts ...commoncfg.HTTPClientOption) (*Notifier, error) {
+	if store == nil || emailing == nil {
+		return nil, errors.Newf(errors.TypeUnsupported, errors.CodeUnsupported, "on-call channels are not supported")
+	}
+
+	return &Notifier{
+		conf:       c,
+		orgID:      orgID,
+		scheduleID: scheduleID,
+		store:      store,
+		emailing:   emailing,
+		tmpl:       t,
+		logger:     l,
+		httpOpts:   httpOpts,
+	}, nil
+}
+
+func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	orgID, err := valuer.NewUUID(n.orgID)
+	if err != nil {
+		return false, err
+	}
+
+	storable, err := n.store.Get(ctx, orgID, n.scheduleID)
+	if err != nil {
+		return !errors.Ast(err, errors.TypeNotFound), err
+	}
+
+	schedule, err := oncalltypes.NewScheduleFromStorableSchedule(storable)
+	if err != nil {
+		return false, err
+	}
+
+	onCall := schedule.OnCall(time.Now())
+	if onCall.Participant == nil {
+		return false, errors.Newf(errors.TypeNotFound, oncalltypes.ErrCodeNobodyOnCall, "nobody is on call of schedule %s", schedule.Name)
+	}
+
+	n.logger.DebugContext(ctx, "resolved the participant on call", "schedule", schedule.Name, "participant", onCall.Participant.Name, "rotation", onCall.Rotation, "override", onCall.OverrideID)
+
+	var (
+		retry bool
+		errs  []error
+	)
+	if onCall.Participant.WebhookURL != "" {
+		if shouldRetry, err := n.notifyWebhook(ctx, onCall.Participant, as...); err != nil {
+			retry = retry || shouldRetry
+			errs = append(errs, err)
+		}
+	}
+
+	if onCall.Participant.Email != "" {
+		if err := n.notifyEmail(ctx, schedule, onCall.Participant, as...); err != nil {
+			retry = true
+			errs = append(errs, err)
+		}
+	}
+
+	return retry, errors.Join(errs...)
+}
+
+func (n *Notifier) notifyWebhook(ctx context.Context, participant *oncalltypes.Participant, as ...*types.Alert) (bool, error) {
+	u, err := url.Parse(participant.WebhookURL)
+	if err != nil {
+		return false, err
+	}
+
+	conf := *n.conf
+	conf.URL = &config.SecretURL{URL: u}
+
+	notifier, err := webhook.New(&conf, n.tmpl, n.logger, n.httpOpts...)
+	if err != nil {
+		return false, err
+	}
+
+	return notifier.Notify(ctx, as...)
+}
+
+func (n *Notifier) notifyEmail(ctx context.Context, schedule *oncalltypes.Schedule, participant *oncalltypes.Participant, as ...*types.Alert) error {
+	data := notify.GetTemplateData(ctx, n.tmpl, as, n.logger)
+
+	subject, err := n.tmpl.ExecuteTextString(`{{ template "__subject" . }}`, data)
+	if err != nil {
+		return err
+	}
+
+	alerts := make([]map[string]any, 0, len(data.Alerts))
+	for _, alert := range data.Alerts {
+		alerts = append(alerts, map[string]any{
+			"Name":        alert.Labels[model.AlertNameLabel],
+			"Status":      alert.Status,
+			"StartsAt":    alert.StartsAt.Format(time.RFC1123),
+			"Summary":     alert.Annotations["summary"],
+			"Description": alert.Annotations["description"],
+			"Labels":      map[string]string(alert.Labels),
+		})
+	}
+
+	name := participant.Name
+	if name == "" {
+		name = participant.Email
+	}
+
+	return n.emailing.SendHTML(ctx, participant.Email, subject, emailtypes.TemplateNameOnCallAlert, map[string]any{
+		"Name":     name,
+		"Schedule": schedule.Name,
+		"Receiver": data.Receiver,
+		"Status":   data.Status,
+		"Alerts":   alerts,
+		"Link":     data.ExternalURL,
+	})
+}
@@ -0,0 +1,128 @@
+package oncall
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	test "github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/alertmanagernotifytest"
+	"github.com/SigNoz/signoz/pkg/emailing/emailingtest"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/emailtypes"
+	"github.com/SigNoz/signoz/pkg/types/oncalltypes"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	commoncfg "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/promslog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+)
+
+type store struct {
+	oncalltypes.Store
+	schedule *oncalltypes.StorableSchedule
+}
+
+func (store *store) Get(ctx context.Context, orgID valuer.UUID, id valuer.UUID) (*oncalltypes.StorableSchedule, error) {
+	if store.schedule == nil || store.schedule.ID != id {
+		return nil, errors.Newf(errors.TypeNotFound, oncalltypes.ErrCodeScheduleNotFound, "oncall schedule with id %s doesn't exist", id)
+	}
+
+	return store.schedule, nil
+}
+
+func newTestStore(t *testing.T, orgID valuer.UUID, participant oncalltypes.Participant, start time.Time) *store {
+	schedule, err := oncalltypes.NewSchedule(orgID, "admin@signoz.io", &oncalltypes.PostableSchedule{
+		Name:     "payments",
+		Timezone: "UTC",
+		Rotations: []oncalltypes.Rotation{{
+			Name:         "primary",
+			Participants: []oncalltypes.Participant{participant},
+			Recurrence: ruletypes.Recurrence{
+				StartTime:  start,
+				RepeatType: ruletypes.RepeatTypeDaily,
+			},
+		}},
+	})
+	require.NoError(t, err)
+
+	storable, err := oncalltypes.NewStorableScheduleFromSchedule(schedule)
+	require.NoError(t, err)
+
+	return &store{schedule: storable}
+}
+
+func newTestAlert() *types.Alert {
+	return &types.Alert{
+		Alert: model.Alert{
+			Labels:      model.LabelSet{model.AlertNameLabel: "HighLatency", "ruleId": "rule-1"},
+			Annotations: model.LabelSet{"summary": "latency is high"},
+			StartsAt:    time.Now(),
+			EndsAt:      time.Now().Add(time.Hour),
+		},
+	}
+}
+
+func TestNotifier_Notify(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		requests++
+		rw.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	orgID := valuer.GenerateUUID()
+	store := newTestStore(t, orgID, oncalltypes.Participant{Name: "alice", Email: "alice@signoz.io", WebhookURL: server.URL}, time.Now().Add(-time.Hour))
+	emailing := emailingtest.New()
+
+	notifier, err := New(&config.WebhookConfig{HTTPConfig: &commoncfg.HTTPClientConfig{}}, orgID.StringValue(), store.schedule.ID, store, emailing, test.CreateTmpl(t), promslog.NewNopLogger())
+	require.NoError(t, err)
+
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	retry, err := notifier.Notify(ctx, newTestAlert())
+	require.NoError(t, err)
+	assert.False(t, retry)
+
+	assert.Equal(t, 1, requests)
+	assert.Equal(t, 1, emailing.SentEmailCountByTo["alice@signoz.io"])
+	assert.Equal(t, 1, emailing.SentEmailCountByTemplateName[emailtypes.TemplateNameOnCallAlert])
+}
+
+func TestNotifier_Notify_NobodyOnCall(t *testing.T) {
+	orgID := valuer.GenerateUUID()
+	store := newTestStore(t, orgID, oncalltypes.Participant{Name: "alice", Email: "alice@signoz.io"}, time.Now().Add(time.Hour))
+
+	notifier, err := New(&config.WebhookConfig{HTTPConfig: &commoncfg.HTTPClientConfig{}}, orgID.StringValue(), store.schedule.ID, store, emailingtest.New(), test.CreateTmpl(t), promslog.NewNopLogger())
+	require.NoError(t, err)
+
+	retry, err := notifier.Notify(notify.WithGroupKey(context.Background(), "1"), newTestAlert())
+	require.Error(t, err)
+	assert.False(t, retry)
+	assert.True(t, errors.Ast(err, errors.TypeNotFound))
+}
+
+func TestNotifier_Notify_ScheduleNotFound(t *testing.T) {
+	orgID := valuer.GenerateUUID()
+	store := newTestStore(t, orgID, oncalltypes.Participant{Name: "alice", Email: "alice@signoz.io"}, time.Now())
+
+	u, err := url.Parse(oncalltypes.NewChannelURL(valuer.GenerateUUID()))
+	require.NoError(t, err)
+
+	scheduleID, ok := oncalltypes.ScheduleIDFromChannelURL(u)
+	require.True(t, ok)
+
+	notifier, err := New(&config.WebhookConfig{HTTPConfig: &commoncfg.HTTPClientConfig{}}, orgID.StringValue(), scheduleID, store, emailingtest.New(), test.CreateTmpl(t), promslog.NewNopLogger())
+	require.NoError(t, err)
+
+	retry, err := notifier.Notify(notify.WithGroupKey(context.Background(), "1"), newTestAlert())
+	require.Error(t, err)
+	assert.False(t, retry)
+}
@@ -3,49 +3,135 @@ package alertmanagernotify
 import (
 	"log/slog"
 
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/discord"
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/googlechat"
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/mattermost"
 	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/msteamsv2"
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/oncall"
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/signozwebhook"
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/telegram"
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/zulip"
+	"github.com/SigNoz/signoz/pkg/emailing"
 	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+	"github.com/SigNoz/signoz/pkg/types/oncalltypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/alertmanager/config/receiver"
 	"github.com/prometheus/alertmanager/notify"
 	"github.com/prometheus/alertmanager/template"
 	"github.com/prometheus/alertmanager/types"
 )
 
-func NewReceiverIntegrations(nc alertmanagertypes.Receiver, tmpl *template.Template, logger *slog.Logger) ([]notify.Integration, error) {
-	upstreamIntegrations, err := receiver.BuildReceiverIntegrations(nc, tmpl, logger)
-	if err != nil {
-		return nil, err
-	}
+// NewReceiverIntegrationsFunc returns the function building the integrations of the receivers of an org. The webhook
+// configs targeting an on-call schedule notify the participant on call of the schedule instead of their url, and the
+// ones marked as a chat integration notify it natively. The chat integrations link to the related logs and traces of
+// the alerts. The ones marked as signoz are signed, and their deliveries are recorded and retried by the queue.
+func NewReceiverIntegrationsFunc(orgID string, onCallStore oncalltypes.Store, webhookDeliveryStore alertmanagertypes.WebhookDeliveryStore, webhookDeliveryQueue *signozwebhook.Queue, emailing emailing.Emailing) alertmanagertypes.ReceiverIntegrationsFunc {
+	return func(nc alertmanagertypes.Receiver, tmpl *template.Template, logger *slog.Logger) ([]notify.Integration, error) {
+		upstreamIntegrations, err := receiver.BuildReceiverIntegrations(nc, tmpl, logger)
+		if err != nil {
+			return nil, err
+		}
 
-	var (
-		errs         types.MultiError
-		integrations []notify.Integration
-		add          = func(name string, i int, rs notify.ResolvedSender, f func(l *slog.Logger) (notify.Notifier, error)) {
-			n, err := f(logger.With("integration", name))
-			if err != nil {
-				errs.Add(err)
-				return
+		var (
+			errs         types.MultiError
+			integrations []notify.Integration
+			add          = func(name string, i int, rs notify.ResolvedSender, f func(l *slog.Logger) (notify.Notifier, error)) {
+				n, err := f(logger.With("integration", name))
+				if err != nil {
+					errs.Add(err)
+					return
+				}
+				integrations = append(integrations, notify.NewIntegration(n, rs, name, i, nc.Name))
+			}
+			addWithRelatedLinks = func(name string, i int, rs notify.ResolvedSender, f func(l *slog.Logger) (notify.Notifier, error)) {
+				add(name, i, rs, func(l *slog.Logger) (notify.Notifier, error) {
+					n, err := f(l)
+					if err != nil {
+						return nil, err
+					}
+
+					return &relatedLinksNotifier{notifier: n, tmpl: tmpl}, nil
+				})
+			}
+		)
+
+		for _, integration := range upstreamIntegrations {
+			// skip upstream msteamsv2, discord and telegram integrations
+			if integration.Name() == "msteamsv2" || integration.Name() == "discord" || integration.Name() == "telegram" {
+				continue
+			}
+
+			// skip upstream webhook integrations targeting an on-call schedule or a chat integration
+			if integration.Name() == "webhook" {
+				c := nc.WebhookConfigs[integration.Index()]
+				if _, ok := scheduleIDOf(c); ok || alertmanagertypes.WebhookKindOf(c) != "" {
+					continue
+				}
 			}
-			integrations = append(integrations, notify.NewIntegration(n, rs, name, i, nc.Name))
-		}
-	)
 
-	for _, integration := range upstreamIntegrations {
-		// skip upstream msteamsv2 integration
-		if integration.Name() != "msteamsv2" {
 			integrations = append(integrations, integration)
 		}
-	}
 
-	for i, c := range nc.MSTeamsV2Configs {
-		add("msteamsv2", i, c, func(l *slog.Logger) (notify.Notifier, error) {
-			return msteamsv2.New(c, tmpl, `{{ template "msteamsv2.default.titleLink" . }}`, l)
-		})
+		for i, c := range nc.MSTeamsV2Configs {
+			add("msteamsv2", i, c, func(l *slog.Logger) (notify.Notifier, error) {
+				return msteamsv2.New(c, tmpl, `{{ template "msteamsv2.default.titleLink" . }}`, l)
+			})
+		}
+
+		for i, c := range nc.DiscordConfigs {
+			addWithRelatedLinks("discord", i, c, func(l *slog.Logger) (notify.Notifier, error) {
+				return discord.New(c, tmpl, `{{ template "discord.default.titleLink" . }}`, l)
+			})
+		}
+
+		for i, c := range nc.TelegramConfigs {
+			addWithRelatedLinks("telegram", i, c, func(l *slog.Logger) (notify.Notifier, error) {
+				return telegram.New(c, tmpl, l)
+			})
+		}
+
+		for i, c := range nc.WebhookConfigs {
+			if scheduleID, ok := scheduleIDOf(c); ok {
+				add("oncall", i, c, func(l *slog.Logger) (notify.Notifier, error) {
+					return oncall.New(c, orgID, scheduleID, onCallStore, emailing, tmpl, l)
+				})
+				continue
+			}
+
+			switch kind := alertmanagertypes.WebhookKindOf(c); kind {
+			case alertmanagertypes.WebhookKindMattermost:
+				addWithRelatedLinks(kind, i, c, func(l *slog.Logger) (notify.Notifier, error) {
+					return mattermost.New(c, tmpl, l)
+				})
+			case alertmanagertypes.WebhookKindGoogleChat:
+				addWithRelatedLinks(kind, i, c, func(l *slog.Logger) (notify.Notifier, error) {
+					return googlechat.New(c, tmpl, l)
+				})
+			case alertmanagertypes.WebhookKindZulip:
+				addWithRelatedLinks(kind, i, c, func(l *slog.Logger) (notify.Notifier, error) {
+					return zulip.New(c, tmpl, l)
+				})
+			case alertmanagertypes.WebhookKindSigNoz:
+				add(kind, i, c, func(l *slog.Logger) (notify.Notifier, error) {
+					return signozwebhook.New(c, orgID, i, webhookDeliveryStore, webhookDeliveryQueue, tmpl, l)
+				})
+			}
+		}
+
+		if errs.Len() > 0 {
+			return nil, &errs
+		}
+
+		return integrations, nil
 	}
+}
 
-	if errs.Len() > 0 {
-		return nil, &errs
+// scheduleIDOf returns the id of the on-call schedule targeted by a webhook config, if any.
+func scheduleIDOf(c *config.WebhookConfig) (valuer.UUID, bool) {
+	if c.URL == nil {
+		return valuer.UUID{}, false
 	}
 
-	return integrations, nil
+	return oncalltypes.ScheduleIDFromChannelURL(c.URL.URL)
 }
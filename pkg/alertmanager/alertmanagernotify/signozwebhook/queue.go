@@ -0,0 +1,113 @@
+package signozwebhook
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+)
+
+const (
+	queueSize    = 1024
+	queueWorkers = 4
+)
+
+type queuedDelivery struct {
+	notifier *Notifier
+	delivery *alertmanagertypes.StorableWebhookDelivery
+}
+
+// Queue delivers the payloads of the signoz webhooks in the background, so that the retries of a delivery never hold
+// the notification of a group.
+type Queue struct {
+	deliveries chan *queuedDelivery
+	logger     *slog.Logger
+
+	// stopped is guarded by mtx so that nothing is enqueued once the workers are draining the queue.
+	mtx     sync.RWMutex
+	stopped bool
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// NewQueue returns a queue, the deliveries queued before it is started are delivered once it is.
+func NewQueue(logger *slog.Logger) *Queue {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Queue{
+		deliveries: make(chan *queuedDelivery, queueSize),
+		logger:     logger,
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+}
+
+// Start starts the workers of the queue.
+func (queue *Queue) Start() {
+	for i := 0; i < queueWorkers; i++ {
+		queue.wg.Add(1)
+		go queue.work()
+	}
+}
+
+// Stop interrupts the deliveries and waits for the workers to return. The deliveries left in the queue are recorded as
+// failed, they can be re-sent.
+func (queue *Queue) Stop() {
+	queue.mtx.Lock()
+	queue.stopped = true
+	queue.mtx.Unlock()
+
+	queue.cancel()
+	queue.wg.Wait()
+}
+
+func (queue *Queue) enqueue(notifier *Notifier, delivery *alertmanagertypes.StorableWebhookDelivery) error {
+	queue.mtx.RLock()
+	defer queue.mtx.RUnlock()
+
+	if queue.stopped {
+		return errors.Newf(errors.TypeUnsupported, errors.CodeUnsupported, "webhook delivery queue is stopped")
+	}
+
+	select {
+	case queue.deliveries <- &queuedDelivery{notifier: notifier, delivery: delivery}:
+		return nil
+	default:
+		return errors.Newf(errors.TypeUnsupported, errors.CodeUnsupported, "webhook delivery queue is full")
+	}
+}
+
+func (queue *Queue) work() {
+	defer queue.wg.Done()
+
+	for {
+		select {
+		case queued := <-queue.deliveries:
+			queue.deliver(queued)
+		case <-queue.ctx.Done():
+			for {
+				select {
+				case queued := <-queue.deliveries:
+					queue.deliver(queued)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (queue *Queue) deliver(queued *queuedDelivery) {
+	deliveryErr, err := queued.notifier.deliver(queue.ctx, queued.delivery)
+	if err != nil {
+		queue.logger.ErrorContext(queue.ctx, "failed to record webhook delivery", "delivery_id", queued.delivery.ID.StringValue(), "error", err)
+		return
+	}
+
+	if deliveryErr != nil {
+		queue.logger.WarnContext(queue.ctx, "failed to deliver webhook", "delivery_id", queued.delivery.ID.StringValue(), "receiver", queued.delivery.Receiver, "error", deliveryErr)
+	}
+}
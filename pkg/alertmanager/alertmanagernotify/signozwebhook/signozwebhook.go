@@ -0,0 +1,278 @@
+package signozwebhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+	commoncfg "github.com/prometheus/common/config"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/notify/webhook"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+)
+
+const (
+	HeaderDeliveryID = "X-SigNoz-Delivery-Id"
+	HeaderTimestamp  = "X-SigNoz-Timestamp"
+	// HeaderSignature is the hex encoded HMAC-SHA256 of the timestamp and the payload joined by a dot, keyed by the
+	// secret of the webhook and prefixed by sha256=.
+	HeaderSignature = "X-SigNoz-Signature"
+
+	initialBackoff = time.Second
+	maxBackoff     = 30 * time.Second
+)
+
+type Notifier struct {
+	conf        *config.WebhookConfig
+	webhook     *alertmanagertypes.SigNozWebhook
+	orgID       string
+	integration int
+	store       alertmanagertypes.WebhookDeliveryStore
+	queue       *Queue
+	tmpl        *template.Template
+	logger      *slog.Logger
+	client      *http.Client
+	retrier     *notify.Retrier
+	backoff     func(attempt int) time.Duration
+}
+
+// New returns a new notifier for a webhook config marked as signoz. The payloads are the ones of the upstream webhook,
+// signed with the secret of the webhook. A delivery is recorded in the store and attempted in the background by the
+// queue, up to the max attempts of the webhook, backing off between the attempts.
+func New(c *config.WebhookConfig, orgID string, integration int, store alertmanagertypes.WebhookDeliveryStore, queue *Queue, t *template.Template, l *slog.Logger, httpOpts ...commoncfg.HTTPClientOption) (*Notifier, error) {
+	signozWebhook, err := alertmanagertypes.NewSigNozWebhook(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// the authorization holds the secret, which must never be sent
+	httpConfig := *c.HTTPConfig
+	httpConfig.Authorization = nil
+
+	client, err := commoncfg.NewClientFromConfig(httpConfig, "signoz_webhook", httpOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Notifier{
+		conf:        c,
+		webhook:     signozWebhook,
+		orgID:       orgID,
+		integration: integration,
+		store:       store,
+		queue:       queue,
+		tmpl:        t,
+		logger:      l,
+		client:      client,
+		retrier:     &notify.Retrier{RetryCodes: []int{http.StatusTooManyRequests}},
+		backoff:     backoff,
+	}, nil
+}
+
+// Notify records the delivery of the alerts to the webhook and queues it. As the delivery is retried by the queue, it
+// is never retried by the notification pipeline.
+func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	key, err := notify.ExtractGroupKey(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	n.logger.DebugContext(ctx, "extracted group key", "key", key)
+
+	receiver, _ := notify.ReceiverName(ctx)
+
+	alerts, numTruncated := as, uint64(0)
+	if n.conf.MaxAlerts != 0 && uint64(len(as)) > n.conf.MaxAlerts {
+		alerts, numTruncated = as[:n.conf.MaxAlerts], uint64(len(as))-n.conf.MaxAlerts
+	}
+
+	payload, err := json.Marshal(&webhook.Message{
+		Data:            notify.GetTemplateData(ctx, n.tmpl, alerts, n.logger),
+		Version:         "4",
+		GroupKey:        key.String(),
+		TruncatedAlerts: numTruncated,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	delivery := alertmanagertypes.NewStorableWebhookDelivery(n.orgID, receiver, n.integration, n.conf.URL.URL, key.String(), payload, time.Now())
+	if err := n.store.Create(ctx, delivery); err != nil {
+		return true, err
+	}
+
+	return false, n.enqueue(ctx, delivery)
+}
+
+// Redeliver queues a failed delivery to deliver its payload again, the new attempts are recorded in the delivery.
+func (n *Notifier) Redeliver(ctx context.Context, delivery *alertmanagertypes.StorableWebhookDelivery) error {
+	if delivery.Status != alertmanagertypes.WebhookDeliveryStatusFailed {
+		return errors.Newf(errors.TypeInvalidInput, alertmanagertypes.ErrCodeAlertmanagerWebhookDeliveryInvalid, "cannot re-send webhook delivery %s with status %s, only failed deliveries can be re-sent", delivery.ID.StringValue(), delivery.Status.StringValue())
+	}
+
+	if err := delivery.RecordAttempts(nil, alertmanagertypes.WebhookDeliveryStatusPending, time.Now()); err != nil {
+		return err
+	}
+
+	if err := n.store.Update(ctx, delivery); err != nil {
+		return err
+	}
+
+	return n.enqueue(ctx, delivery)
+}
+
+// enqueue queues a pending delivery, which is recorded as failed if it can not be queued.
+func (n *Notifier) enqueue(ctx context.Context, delivery *alertmanagertypes.StorableWebhookDelivery) error {
+	// the queue owns a copy of the delivery, which is updated while it is delivered
+	queued := *delivery
+	enqueueErr := n.queue.enqueue(n, &queued)
+	if enqueueErr == nil {
+		return nil
+	}
+
+	if err := delivery.RecordAttempts(nil, alertmanagertypes.WebhookDeliveryStatusFailed, time.Now()); err != nil {
+		return err
+	}
+
+	if err := n.store.Update(ctx, delivery); err != nil {
+		return err
+	}
+
+	return enqueueErr
+}
+
+// deliver attempts to deliver the payload and records the attempts, it returns the error of the last attempt of a
+// failed delivery, and an error if the delivery could not be recorded.
+func (n *Notifier) deliver(ctx context.Context, delivery *alertmanagertypes.StorableWebhookDelivery) (deliveryErr error, err error) {
+	next, err := delivery.NextAttempt()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		recorded []*alertmanagertypes.WebhookDeliveryAttempt
+		status   = alertmanagertypes.WebhookDeliveryStatusFailed
+		lastErr  error
+	)
+attempts:
+	for i := 0; i < n.webhook.MaxAttempts; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				lastErr = errors.Join(lastErr, context.Cause(ctx))
+				break attempts
+			case <-time.After(n.backoff(i)):
+			}
+		}
+
+		attempt, retry, err := n.attempt(ctx, delivery, next+i)
+		recorded = append(recorded, attempt)
+		if err == nil {
+			status = alertmanagertypes.WebhookDeliveryStatusSucceeded
+			lastErr = nil
+			break
+		}
+
+		lastErr = err
+		if !retry {
+			break
+		}
+	}
+
+	if err := delivery.RecordAttempts(recorded, status, time.Now()); err != nil {
+		return nil, err
+	}
+
+	// the delivery is recorded even if the queue was stopped
+	if err := n.store.Update(context.WithoutCancel(ctx), delivery); err != nil {
+		return nil, err
+	}
+
+	return lastErr, nil
+}
+
+// attempt posts the payload of a delivery once, and returns whether a failed attempt should be retried.
+func (n *Notifier) attempt(ctx context.Context, delivery *alertmanagertypes.StorableWebhookDelivery, number int) (*alertmanagertypes.WebhookDeliveryAttempt, bool, error) {
+	if n.conf.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, n.conf.Timeout)
+		defer cancel()
+	}
+
+	// the fragment only holds the parameters of the webhook
+	u := *n.conf.URL.URL
+	u.Fragment = ""
+
+	attemptedAt := time.Now()
+	attempt := &alertmanagertypes.WebhookDeliveryAttempt{Attempt: number, AttemptedAt: attemptedAt}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), strings.NewReader(delivery.Payload))
+	if err != nil {
+		err = notify.RedactURL(err)
+		attempt.Error = err.Error()
+		return attempt, false, err
+	}
+
+	timestamp := strconv.FormatInt(attemptedAt.Unix(), 10)
+	req.Header.Set("User-Agent", notify.UserAgentHeader)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderDeliveryID, delivery.ID.StringValue())
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, signature(n.webhook.Secret, timestamp, []byte(delivery.Payload)))
+
+	resp, err := n.client.Do(req) //nolint:bodyclose
+	attempt.LatencyMs = time.Since(attemptedAt).Milliseconds()
+	if err != nil {
+		err = notify.RedactURL(err)
+		attempt.Error = err.Error()
+		return attempt, true, err
+	}
+	defer notify.Drain(resp) //drain is used to close the body of the response hence the nolint directive
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, alertmanagertypes.MaxWebhookResponseBytes))
+	attempt.StatusCode = resp.StatusCode
+	attempt.Response = strings.ToValidUTF8(string(body), "")
+
+	shouldRetry, err := n.retrier.Check(resp.StatusCode, bytes.NewReader(body))
+	if err != nil {
+		err = notify.NewErrorWithReason(notify.GetFailureReasonFromStatusCode(resp.StatusCode), err)
+		attempt.Error = err.Error()
+		return attempt, shouldRetry, err
+	}
+
+	return attempt, false, nil
+}
+
+// signature signs a payload sent at a timestamp, the timestamp is signed to prevent replaying the payload.
+func signature(secret string, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// backoff returns the exponential backoff before an attempt, starting from the second one.
+func backoff(attempt int) time.Duration {
+	d := initialBackoff << (attempt - 1)
+	if d <= 0 || d > maxBackoff {
+		return maxBackoff
+	}
+
+	return d
+}
@@ -0,0 +1,257 @@
+package signozwebhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	commoncfg "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/promslog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	test "github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/alertmanagernotifytest"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes/alertmanagertypestest"
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/notify/webhook"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func newNotifier(t *testing.T, rawURL string, store alertmanagertypes.WebhookDeliveryStore) *Notifier {
+	t.Helper()
+
+	u, err := url.Parse(rawURL)
+	require.NoError(t, err)
+
+	queue := NewQueue(promslog.NewNopLogger())
+	queue.Start()
+	t.Cleanup(queue.Stop)
+
+	notifier, err := New(
+		&config.WebhookConfig{
+			URL: &config.SecretURL{URL: u},
+			HTTPConfig: &commoncfg.HTTPClientConfig{
+				Authorization: &commoncfg.Authorization{Type: alertmanagertypes.WebhookSignatureAuthorizationType, Credentials: "s3cr3t"},
+			},
+		},
+		"org-1",
+		0,
+		store,
+		queue,
+		test.CreateTmpl(t),
+		promslog.NewNopLogger(),
+	)
+	require.NoError(t, err)
+	notifier.backoff = func(int) time.Duration { return time.Millisecond }
+
+	return notifier
+}
+
+func notifyAlert(ctx context.Context, notifier *Notifier) (bool, error) {
+	ctx = notify.WithGroupKey(ctx, "1")
+	ctx = notify.WithReceiverName(ctx, "automation")
+
+	return notifier.Notify(ctx, &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": "HighLatency", "ruleId": "rule-1"},
+			StartsAt: time.Now(),
+			EndsAt:   time.Now().Add(time.Hour),
+		},
+	})
+}
+
+// listDeliveries lists the deliveries once none of them is pending anymore.
+func listDeliveries(t *testing.T, store alertmanagertypes.WebhookDeliveryStore) alertmanagertypes.GettableWebhookDeliveries {
+	t.Helper()
+
+	var deliveries alertmanagertypes.GettableWebhookDeliveries
+	require.Eventually(t, func() bool {
+		storables, err := store.List(context.Background(), "org-1", &alertmanagertypes.ListWebhookDeliveriesParams{})
+		require.NoError(t, err)
+
+		deliveries, err = alertmanagertypes.NewGettableWebhookDeliveriesFromStorableWebhookDeliveries(storables)
+		require.NoError(t, err)
+
+		for _, delivery := range deliveries {
+			if delivery.Status == alertmanagertypes.WebhookDeliveryStatusPending {
+				return false
+			}
+		}
+
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+
+	return deliveries
+}
+
+func TestSigNozWebhookSignature(t *testing.T) {
+	var (
+		body   []byte
+		header http.Header
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		body, err = io.ReadAll(r.Body)
+		require.NoError(t, err)
+		header = r.Header
+		_, _ = w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer srv.Close()
+
+	store := alertmanagertypestest.NewWebhookDeliveryStore()
+	notifier := newNotifier(t, srv.URL+"/alerts?team=core#signoz", store)
+
+	retry, err := notifyAlert(context.Background(), notifier)
+	require.NoError(t, err)
+	assert.False(t, retry)
+
+	deliveries := listDeliveries(t, store)
+	require.Len(t, deliveries, 1)
+
+	// the secret only keys the signature
+	assert.Empty(t, header.Get("Authorization"))
+	assert.Equal(t, signature("s3cr3t", header.Get(HeaderTimestamp), body), header.Get(HeaderSignature))
+	assert.NotEqual(t, signature("other", header.Get(HeaderTimestamp), body), header.Get(HeaderSignature))
+
+	var message webhook.Message
+	require.NoError(t, json.Unmarshal(body, &message))
+	assert.Equal(t, "4", message.Version)
+	assert.Equal(t, "automation", message.Receiver)
+	require.Len(t, message.Alerts, 1)
+
+	assert.Equal(t, header.Get(HeaderDeliveryID), deliveries[0].ID.StringValue())
+	assert.Equal(t, alertmanagertypes.WebhookDeliveryStatusSucceeded, deliveries[0].Status)
+	assert.Equal(t, "automation", deliveries[0].Receiver)
+	assert.Equal(t, srv.URL+"/alerts", deliveries[0].URL)
+	require.Len(t, deliveries[0].Attempts, 1)
+	assert.Equal(t, http.StatusOK, deliveries[0].Attempts[0].StatusCode)
+	assert.Equal(t, `{"status":"ok"}`, deliveries[0].Attempts[0].Response)
+}
+
+func TestSigNozWebhookRetry(t *testing.T) {
+	testCases := []struct {
+		name             string
+		statusCode       int
+		expectedAttempts int
+	}{
+		{
+			name:             "ServerError",
+			statusCode:       http.StatusBadGateway,
+			expectedAttempts: 4,
+		},
+		{
+			name:             "RateLimited",
+			statusCode:       http.StatusTooManyRequests,
+			expectedAttempts: 4,
+		},
+		{
+			name:             "ClientError",
+			statusCode:       http.StatusBadRequest,
+			expectedAttempts: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			requests := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				w.WriteHeader(tc.statusCode)
+			}))
+			defer srv.Close()
+
+			store := alertmanagertypestest.NewWebhookDeliveryStore()
+			notifier := newNotifier(t, srv.URL+"#signoz?max_attempts=4", store)
+
+			// the delivery is retried by the queue
+			retry, err := notifyAlert(context.Background(), notifier)
+			require.NoError(t, err)
+			assert.False(t, retry)
+
+			deliveries := listDeliveries(t, store)
+			assert.Equal(t, tc.expectedAttempts, requests)
+			require.Len(t, deliveries, 1)
+			assert.Equal(t, alertmanagertypes.WebhookDeliveryStatusFailed, deliveries[0].Status)
+			require.Len(t, deliveries[0].Attempts, tc.expectedAttempts)
+			for i, attempt := range deliveries[0].Attempts {
+				assert.Equal(t, i+1, attempt.Attempt)
+				assert.Equal(t, tc.statusCode, attempt.StatusCode)
+				assert.NotEmpty(t, attempt.Error)
+			}
+		})
+	}
+}
+
+func TestSigNozWebhookRedeliver(t *testing.T) {
+	fail := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	store := alertmanagertypestest.NewWebhookDeliveryStore()
+	notifier := newNotifier(t, srv.URL+"#signoz?max_attempts=2", store)
+
+	_, err := notifyAlert(context.Background(), notifier)
+	require.NoError(t, err)
+
+	deliveries := listDeliveries(t, store)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, alertmanagertypes.WebhookDeliveryStatusFailed, deliveries[0].Status)
+
+	storable, err := store.Get(context.Background(), "org-1", deliveries[0].ID)
+	require.NoError(t, err)
+
+	fail = false
+	require.NoError(t, notifier.Redeliver(context.Background(), storable))
+	assert.Equal(t, alertmanagertypes.WebhookDeliveryStatusPending, storable.Status)
+
+	deliveries = listDeliveries(t, store)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, alertmanagertypes.WebhookDeliveryStatusSucceeded, deliveries[0].Status)
+	require.Len(t, deliveries[0].Attempts, 3)
+	assert.Equal(t, 3, deliveries[0].Attempts[2].Attempt)
+	assert.Equal(t, http.StatusOK, deliveries[0].Attempts[2].StatusCode)
+
+	// a succeeded delivery cannot be re-sent
+	storable, err = store.Get(context.Background(), "org-1", deliveries[0].ID)
+	require.NoError(t, err)
+	require.Error(t, notifier.Redeliver(context.Background(), storable))
+}
+
+func TestSigNozWebhookRedactedURL(t *testing.T) {
+	// nothing listens on the url of a closed server
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.Close()
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	secret := "secret"
+	u.RawQuery = "token=" + secret
+	u.Fragment = "signoz?max_attempts=1"
+
+	store := alertmanagertypestest.NewWebhookDeliveryStore()
+	notifier := newNotifier(t, u.String(), store)
+
+	_, err = notifyAlert(context.Background(), notifier)
+	require.NoError(t, err)
+
+	deliveries := listDeliveries(t, store)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, alertmanagertypes.WebhookDeliveryStatusFailed, deliveries[0].Status)
+	assert.NotContains(t, deliveries[0].URL, secret)
+	require.Len(t, deliveries[0].Attempts, 1)
+	assert.NotEmpty(t, deliveries[0].Attempts[0].Error)
+	assert.NotContains(t, deliveries[0].Attempts[0].Error, secret)
+}
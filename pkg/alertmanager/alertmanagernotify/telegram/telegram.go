@@ -0,0 +1,136 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	commoncfg "github.com/prometheus/common/config"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// https://core.telegram.org/bots/api#sendmessage
+const maxMessageLenRunes = 4096
+
+type Notifier struct {
+	conf         *config.TelegramConfig
+	tmpl         *template.Template
+	logger       *slog.Logger
+	client       *http.Client
+	retrier      *notify.Retrier
+	postJSONFunc func(ctx context.Context, client *http.Client, url string, body io.Reader) (*http.Response, error)
+}
+
+// https://core.telegram.org/bots/api#sendmessage
+type message struct {
+	ChatID                int64  `json:"chat_id"`
+	MessageThreadID       int    `json:"message_thread_id,omitempty"`
+	Text                  string `json:"text"`
+	ParseMode             string `json:"parse_mode,omitempty"`
+	DisableNotification   bool   `json:"disable_notification,omitempty"`
+	DisableWebPagePreview bool   `json:"disable_web_page_preview"`
+}
+
+// New returns a new notifier that uses the sendMessage method of the Telegram Bot API.
+func New(c *config.TelegramConfig, t *template.Template, l *slog.Logger, httpOpts ...commoncfg.HTTPClientOption) (*Notifier, error) {
+	client, err := commoncfg.NewClientFromConfig(*c.HTTPConfig, "telegram", httpOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Notifier{
+		conf:         c,
+		tmpl:         t,
+		logger:       l,
+		client:       client,
+		retrier:      &notify.Retrier{RetryCodes: []int{http.StatusTooManyRequests}},
+		postJSONFunc: notify.PostJSON,
+	}, nil
+}
+
+func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	key, err := notify.ExtractGroupKey(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	n.logger.DebugContext(ctx, "extracted group key", "key", key)
+
+	data := notify.GetTemplateData(ctx, n.tmpl, as, n.logger)
+	tmpl := notify.TmplText(n.tmpl, data, &err)
+	if n.conf.ParseMode == "HTML" {
+		tmpl = notify.TmplHTML(n.tmpl, data, &err)
+	}
+
+	text, truncated := notify.TruncateInRunes(tmpl(n.conf.Message), maxMessageLenRunes)
+	if err != nil {
+		return false, err
+	}
+	if truncated {
+		n.logger.WarnContext(ctx, "truncated message", "key", key, "max_runes", maxMessageLenRunes)
+	}
+
+	token, err := n.botToken()
+	if err != nil {
+		return false, err
+	}
+
+	apiURL := "https://api.telegram.org"
+	if n.conf.APIUrl != nil && n.conf.APIUrl.URL != nil {
+		apiURL = n.conf.APIUrl.String()
+	}
+
+	sendMessageURL, err := url.JoinPath(apiURL, "bot"+token, "sendMessage")
+	if err != nil {
+		return false, errors.WrapInvalidInputf(err, errors.CodeInvalidInput, "invalid telegram api_url")
+	}
+
+	var payload bytes.Buffer
+	if err = json.NewEncoder(&payload).Encode(message{
+		ChatID:                n.conf.ChatID,
+		MessageThreadID:       n.conf.MessageThreadID,
+		Text:                  text,
+		ParseMode:             n.conf.ParseMode,
+		DisableNotification:   n.conf.DisableNotifications,
+		DisableWebPagePreview: true,
+	}); err != nil {
+		return false, err
+	}
+
+	resp, err := n.postJSONFunc(ctx, n.client, sendMessageURL, &payload) //nolint:bodyclose
+	if err != nil {
+		return true, notify.RedactURL(err)
+	}
+	defer notify.Drain(resp) //drain is used to close the body of the response hence the nolint directive
+
+	shouldRetry, err := n.retrier.Check(resp.StatusCode, resp.Body)
+	if err != nil {
+		return shouldRetry, notify.NewErrorWithReason(notify.GetFailureReasonFromStatusCode(resp.StatusCode), err)
+	}
+
+	return shouldRetry, err
+}
+
+func (n *Notifier) botToken() (string, error) {
+	if n.conf.BotTokenFile != "" {
+		content, err := os.ReadFile(n.conf.BotTokenFile)
+		if err != nil {
+			return "", errors.WrapInternalf(err, errors.CodeInternal, "read bot_token_file")
+		}
+
+		return strings.TrimSpace(string(content)), nil
+	}
+
+	return string(n.conf.BotToken), nil
+}
@@ -0,0 +1,111 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	commoncfg "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/promslog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	test "github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/alertmanagernotifytest"
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func TestTelegramRetry(t *testing.T) {
+	notifier, err := New(
+		&config.TelegramConfig{
+			HTTPConfig: &commoncfg.HTTPClientConfig{},
+		},
+		test.CreateTmpl(t),
+		promslog.NewNopLogger(),
+	)
+	require.NoError(t, err)
+
+	for statusCode, expected := range test.RetryTests(append(test.DefaultRetryCodes(), http.StatusTooManyRequests)) {
+		actual, _ := notifier.retrier.Check(statusCode, nil)
+		require.Equal(t, expected, actual, "retry - error on status %d", statusCode)
+	}
+}
+
+func TestTelegramTemplating(t *testing.T) {
+	var (
+		path string
+		out  message
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&out))
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	notifier, err := New(
+		&config.TelegramConfig{
+			HTTPConfig:      &commoncfg.HTTPClientConfig{},
+			APIUrl:          &config.URL{URL: u},
+			BotToken:        "123:token",
+			ChatID:          -1001,
+			MessageThreadID: 7,
+			Message:         config.DefaultTelegramConfig.Message,
+			ParseMode:       config.DefaultTelegramConfig.ParseMode,
+		},
+		test.CreateTmpl(t),
+		promslog.NewNopLogger(),
+	)
+	require.NoError(t, err)
+
+	retry, err := notifier.Notify(notify.WithGroupKey(context.Background(), "1"), &types.Alert{
+		Alert: model.Alert{
+			Labels:      model.LabelSet{"alertname": "HighLatency", "ruleId": "rule-1", "threshold.name": "critical"},
+			Annotations: model.LabelSet{"summary": "latency is > 5s", "related_traces": "http://am/traces-explorer?q=1"},
+			StartsAt:    time.Now(),
+			EndsAt:      time.Now().Add(time.Hour),
+		},
+	})
+	require.NoError(t, err)
+	assert.False(t, retry)
+
+	assert.Equal(t, "/bot123:token/sendMessage", path)
+	assert.Equal(t, int64(-1001), out.ChatID)
+	assert.Equal(t, 7, out.MessageThreadID)
+	assert.Equal(t, "HTML", out.ParseMode)
+	assert.Contains(t, out.Text, `<a href="http://am/alerts/edit?ruleId=rule-1">`)
+	assert.Contains(t, out.Text, "<b>HighLatency</b> · threshold critical")
+	assert.Contains(t, out.Text, "latency is &gt; 5s")
+	assert.Contains(t, out.Text, `<a href="http://am/traces-explorer?q=1">Related traces</a>`)
+}
+
+func TestTelegramRedactedToken(t *testing.T) {
+	ctx, u, fn := test.GetContextWithCancelingURL()
+	defer fn()
+
+	secret := "secret"
+	notifier, err := New(
+		&config.TelegramConfig{
+			HTTPConfig: &commoncfg.HTTPClientConfig{},
+			APIUrl:     &config.URL{URL: u},
+			BotToken:   config.Secret(secret),
+			ChatID:     1,
+			Message:    config.DefaultTelegramConfig.Message,
+			ParseMode:  config.DefaultTelegramConfig.ParseMode,
+		},
+		test.CreateTmpl(t),
+		promslog.NewNopLogger(),
+	)
+	require.NoError(t, err)
+
+	test.AssertNotifyLeaksNoSecret(ctx, t, notifier, secret)
+}
@@ -0,0 +1,115 @@
+package zulip
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	commoncfg "github.com/prometheus/common/config"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// https://zulip.com/api/send-message
+const (
+	maxTopicLenRunes   = 60
+	maxContentLenRunes = 10000
+)
+
+type Notifier struct {
+	conf         *config.WebhookConfig
+	tmpl         *template.Template
+	logger       *slog.Logger
+	client       *http.Client
+	retrier      *notify.Retrier
+	postFormFunc func(ctx context.Context, client *http.Client, url string, body io.Reader) (*http.Response, error)
+}
+
+// New returns a new notifier that uses the send message api of Zulip, for a webhook config marked as zulip. The stream
+// and the topic of the messages are the stream and topic query parameters of the url of the config, the topic
+// defaulting to the name of the alert. The bot is authenticated with the basic auth of the http config.
+func New(c *config.WebhookConfig, t *template.Template, l *slog.Logger, httpOpts ...commoncfg.HTTPClientOption) (*Notifier, error) {
+	client, err := commoncfg.NewClientFromConfig(*c.HTTPConfig, "zulip", httpOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Notifier{
+		conf:         c,
+		tmpl:         t,
+		logger:       l,
+		client:       client,
+		retrier:      &notify.Retrier{RetryCodes: []int{http.StatusTooManyRequests}},
+		postFormFunc: postForm,
+	}, nil
+}
+
+func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	key, err := notify.ExtractGroupKey(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	n.logger.DebugContext(ctx, "extracted group key", "key", key)
+
+	data := notify.GetTemplateData(ctx, n.tmpl, as, n.logger)
+	tmpl := notify.TmplText(n.tmpl, data, &err)
+
+	u := *n.conf.URL.URL
+	query := u.Query()
+
+	topic := query.Get("topic")
+	if topic == "" {
+		topic = tmpl(`{{ template "zulip.default.topic" . }}`)
+	}
+	topic, _ = notify.TruncateInRunes(topic, maxTopicLenRunes)
+
+	content, truncated := notify.TruncateInRunes(tmpl(`{{ template "zulip.default.content" . }}`), maxContentLenRunes)
+	if err != nil {
+		return false, err
+	}
+	if truncated {
+		n.logger.WarnContext(ctx, "truncated content", "key", key, "max_runes", maxContentLenRunes)
+	}
+
+	form := url.Values{}
+	form.Set("type", "stream")
+	form.Set("to", query.Get("stream"))
+	form.Set("topic", topic)
+	form.Set("content", content)
+
+	// the stream and topic are sent in the form, and the fragment only marks the webhook config as zulip
+	u.RawQuery = ""
+	u.Fragment = ""
+
+	resp, err := n.postFormFunc(ctx, n.client, u.String(), strings.NewReader(form.Encode())) //nolint:bodyclose
+	if err != nil {
+		return true, notify.RedactURL(err)
+	}
+	defer notify.Drain(resp) //drain is used to close the body of the response hence the nolint directive
+
+	shouldRetry, err := n.retrier.Check(resp.StatusCode, resp.Body)
+	if err != nil {
+		return shouldRetry, notify.NewErrorWithReason(notify.GetFailureReasonFromStatusCode(resp.StatusCode), err)
+	}
+
+	return shouldRetry, err
+}
+
+func postForm(ctx context.Context, client *http.Client, url string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", notify.UserAgentHeader)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return client.Do(req)
+}
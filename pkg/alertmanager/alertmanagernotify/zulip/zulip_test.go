@@ -0,0 +1,138 @@
+package zulip
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	commoncfg "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/promslog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	test "github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/alertmanagernotifytest"
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func TestZulipRetry(t *testing.T) {
+	u, err := url.Parse("https://signoz.zulipchat.com/api/v1/messages?stream=alerts#zulip")
+	require.NoError(t, err)
+
+	notifier, err := New(
+		&config.WebhookConfig{
+			URL:        &config.SecretURL{URL: u},
+			HTTPConfig: &commoncfg.HTTPClientConfig{},
+		},
+		test.CreateTmpl(t),
+		promslog.NewNopLogger(),
+	)
+	require.NoError(t, err)
+
+	for statusCode, expected := range test.RetryTests(append(test.DefaultRetryCodes(), http.StatusTooManyRequests)) {
+		actual, _ := notifier.retrier.Check(statusCode, nil)
+		require.Equal(t, expected, actual, "retry - error on status %d", statusCode)
+	}
+}
+
+func TestZulipTemplating(t *testing.T) {
+	testCases := []struct {
+		name          string
+		rawQuery      string
+		expectedTopic string
+	}{
+		{
+			name:          "WithTopic",
+			rawQuery:      "stream=alerts&topic=production",
+			expectedTopic: "production",
+		},
+		{
+			name:          "WithoutTopic",
+			rawQuery:      "stream=alerts",
+			expectedTopic: "HighLatency",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				r    *http.Request
+				form url.Values
+			)
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				r = req
+				require.NoError(t, req.ParseForm())
+				form = req.PostForm
+				_, _ = w.Write([]byte(`{"result":"success"}`))
+			}))
+			defer srv.Close()
+
+			u, err := url.Parse(srv.URL + "/api/v1/messages?" + tc.rawQuery + "#zulip")
+			require.NoError(t, err)
+
+			notifier, err := New(
+				&config.WebhookConfig{
+					URL: &config.SecretURL{URL: u},
+					HTTPConfig: &commoncfg.HTTPClientConfig{
+						BasicAuth: &commoncfg.BasicAuth{Username: "bot@signoz.zulipchat.com", Password: "key"},
+					},
+				},
+				test.CreateTmpl(t),
+				promslog.NewNopLogger(),
+			)
+			require.NoError(t, err)
+
+			retry, err := notifier.Notify(notify.WithGroupKey(context.Background(), "1"), &types.Alert{
+				Alert: model.Alert{
+					Labels:      model.LabelSet{"alertname": "HighLatency", "ruleId": "rule-1"},
+					Annotations: model.LabelSet{"summary": "latency is high", "related_logs": "http://am/logs/logs-explorer?q=1"},
+					StartsAt:    time.Now(),
+					EndsAt:      time.Now().Add(time.Hour),
+				},
+			})
+			require.NoError(t, err)
+			assert.False(t, retry)
+
+			username, password, ok := r.BasicAuth()
+			require.True(t, ok)
+			assert.Equal(t, "bot@signoz.zulipchat.com", username)
+			assert.Equal(t, "key", password)
+			assert.Empty(t, r.URL.RawQuery)
+
+			assert.Equal(t, "stream", form.Get("type"))
+			assert.Equal(t, "alerts", form.Get("to"))
+			assert.Equal(t, tc.expectedTopic, form.Get("topic"))
+			assert.Contains(t, form.Get("content"), "](http://am/alerts/edit?ruleId=rule-1)**")
+			assert.Contains(t, form.Get("content"), "[Related logs](http://am/logs/logs-explorer?q=1)")
+		})
+	}
+}
+
+func TestZulipRedactedURL(t *testing.T) {
+	ctx, u, fn := test.GetContextWithCancelingURL()
+	defer fn()
+
+	secret := "secret"
+	u.Path = "/api/v1/messages"
+	u.RawQuery = "stream=alerts"
+	u.Fragment = "zulip"
+
+	notifier, err := New(
+		&config.WebhookConfig{
+			URL: &config.SecretURL{URL: u},
+			HTTPConfig: &commoncfg.HTTPClientConfig{
+				BasicAuth: &commoncfg.BasicAuth{Username: "bot@signoz.zulipchat.com", Password: commoncfg.Secret(secret)},
+			},
+		},
+		test.CreateTmpl(t),
+		promslog.NewNopLogger(),
+	)
+	require.NoError(t, err)
+
+	test.AssertNotifyLeaksNoSecret(ctx, t, notifier, secret)
+}
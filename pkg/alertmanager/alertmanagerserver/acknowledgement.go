@@ -0,0 +1,93 @@
+package alertmanagerserver
+
+import (
+	"context"
+	"sync"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+)
+
+var _ alertmanagertypes.AcknowledgementStore = (*acknowledgementCache)(nil)
+
+// acknowledgementCache keeps the acknowledgements of the groups in memory so that the dispatcher doesn't read the
+// store on every flush. The groups without an acknowledgement are cached as well, the entry of a group is invalidated
+// whenever its acknowledgement is set or deleted through the cache.
+type acknowledgementCache struct {
+	store alertmanagertypes.AcknowledgementStore
+
+	mtx sync.Mutex
+	// acknowledgements is keyed by the rule and the group fingerprint, a nil acknowledgement means the group is not
+	// acknowledged
+	acknowledgements map[string]*alertmanagertypes.StorableAcknowledgement
+	// version is incremented on every invalidation, so that a read racing with a write is not cached
+	version uint64
+}
+
+func newAcknowledgementCache(store alertmanagertypes.AcknowledgementStore) *acknowledgementCache {
+	return &acknowledgementCache{
+		store:            store,
+		acknowledgements: make(map[string]*alertmanagertypes.StorableAcknowledgement),
+	}
+}
+
+func (c *acknowledgementCache) key(orgID string, ruleID string, groupFingerprint string) string {
+	return orgID + "/" + ruleID + "/" + groupFingerprint
+}
+
+func (c *acknowledgementCache) Get(ctx context.Context, orgID string, ruleID string, groupFingerprint string) (*alertmanagertypes.StorableAcknowledgement, error) {
+	key := c.key(orgID, ruleID, groupFingerprint)
+
+	c.mtx.Lock()
+	acknowledgement, ok := c.acknowledgements[key]
+	version := c.version
+	c.mtx.Unlock()
+
+	if ok {
+		if acknowledgement == nil {
+			return nil, errors.Newf(errors.TypeNotFound, alertmanagertypes.ErrCodeAlertmanagerAcknowledgementNotFound, "acknowledgement of group %q not found", groupFingerprint)
+		}
+		copied := *acknowledgement
+		return &copied, nil
+	}
+
+	acknowledgement, err := c.store.Get(ctx, orgID, ruleID, groupFingerprint)
+	if err != nil && !errors.Ast(err, errors.TypeNotFound) {
+		return nil, err
+	}
+
+	c.mtx.Lock()
+	if c.version == version {
+		if acknowledgement == nil {
+			c.acknowledgements[key] = nil
+		} else {
+			copied := *acknowledgement
+			c.acknowledgements[key] = &copied
+		}
+	}
+	c.mtx.Unlock()
+
+	return acknowledgement, err
+}
+
+func (c *acknowledgementCache) List(ctx context.Context, orgID string) ([]*alertmanagertypes.StorableAcknowledgement, error) {
+	return c.store.List(ctx, orgID)
+}
+
+func (c *acknowledgementCache) Set(ctx context.Context, acknowledgement *alertmanagertypes.StorableAcknowledgement) error {
+	defer c.invalidate(acknowledgement.OrgID, acknowledgement.RuleID, acknowledgement.GroupFingerprint)
+	return c.store.Set(ctx, acknowledgement)
+}
+
+func (c *acknowledgementCache) Delete(ctx context.Context, orgID string, ruleID string, groupFingerprint string) error {
+	defer c.invalidate(orgID, ruleID, groupFingerprint)
+	return c.store.Delete(ctx, orgID, ruleID, groupFingerprint)
+}
+
+func (c *acknowledgementCache) invalidate(orgID string, ruleID string, groupFingerprint string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	delete(c.acknowledgements, c.key(orgID, ruleID, groupFingerprint))
+	c.version++
+}
@@ -0,0 +1,53 @@
+package alertmanagerserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes/alertmanagertypestest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingAcknowledgementStore struct {
+	*alertmanagertypestest.AcknowledgementStore
+	gets int
+}
+
+func (s *countingAcknowledgementStore) Get(ctx context.Context, orgID string, ruleID string, groupFingerprint string) (*alertmanagertypes.StorableAcknowledgement, error) {
+	s.gets++
+	return s.AcknowledgementStore.Get(ctx, orgID, ruleID, groupFingerprint)
+}
+
+func TestAcknowledgementCache(t *testing.T) {
+	ctx := context.Background()
+	store := &countingAcknowledgementStore{AcknowledgementStore: alertmanagertypestest.NewAcknowledgementStore()}
+	cache := newAcknowledgementCache(store)
+
+	// the groups without an acknowledgement are cached
+	for i := 0; i < 2; i++ {
+		_, err := cache.Get(ctx, "org", "rule", "fp")
+		require.True(t, errors.Ast(err, errors.TypeNotFound))
+	}
+	assert.Equal(t, 1, store.gets)
+
+	acknowledgedAt := time.Now()
+	require.NoError(t, cache.Set(ctx, &alertmanagertypes.StorableAcknowledgement{OrgID: "org", RuleID: "rule", GroupFingerprint: "fp", AcknowledgedBy: "user", AcknowledgedAt: acknowledgedAt}))
+
+	// the acknowledgement is read again once after it is set
+	for i := 0; i < 2; i++ {
+		acknowledgement, err := cache.Get(ctx, "org", "rule", "fp")
+		require.NoError(t, err)
+		assert.Equal(t, "user", acknowledgement.AcknowledgedBy)
+		acknowledgement.AcknowledgedBy = "changed"
+	}
+	assert.Equal(t, 2, store.gets)
+
+	require.NoError(t, cache.Delete(ctx, "org", "rule", "fp"))
+	_, err := cache.Get(ctx, "org", "rule", "fp")
+	require.True(t, errors.Ast(err, errors.TypeNotFound))
+	assert.Equal(t, 3, store.gets)
+}
@@ -0,0 +1,296 @@
+package alertmanagerserver
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+	"github.com/SigNoz/signoz/pkg/types/emailtypes"
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
+)
+
+// digester collects the alerts notified to the channels having a digest until their digests are sent. The collected
+// alerts are kept in memory, the ones collected since the last digest are lost on restart.
+type digester struct {
+	mtx sync.Mutex
+
+	// digests are the digests by channel
+	digests map[string]*alertmanagertypes.StorableDigest
+
+	// alerts are the collected alerts by channel and fingerprint
+	alerts map[string]map[model.Fingerprint]*types.Alert
+}
+
+func newDigester() *digester {
+	return &digester{
+		digests: map[string]*alertmanagertypes.StorableDigest{},
+		alerts:  map[string]map[model.Fingerprint]*types.Alert{},
+	}
+}
+
+// set replaces the digests, the alerts collected for the channels which no longer have a digest are dropped.
+func (d *digester) set(digests []*alertmanagertypes.StorableDigest) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	d.digests = make(map[string]*alertmanagertypes.StorableDigest, len(digests))
+	for _, digest := range digests {
+		d.digests[digest.Channel] = digest
+	}
+
+	for channel := range d.alerts {
+		if _, ok := d.digests[channel]; !ok {
+			delete(d.alerts, channel)
+		}
+	}
+}
+
+func (d *digester) has(channel string) bool {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	_, ok := d.digests[channel]
+	return ok
+}
+
+// collect collects the alerts of a channel matched by its digest, and returns the other alerts.
+func (d *digester) collect(channel string, alerts []*types.Alert) []*types.Alert {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	digest, ok := d.digests[channel]
+	if !ok {
+		return alerts
+	}
+
+	passed := []*types.Alert{}
+	for _, alert := range alerts {
+		if !digest.Digests(alert.Labels) {
+			passed = append(passed, alert)
+			continue
+		}
+
+		if _, ok := d.alerts[channel]; !ok {
+			d.alerts[channel] = map[model.Fingerprint]*types.Alert{}
+		}
+		d.alerts[channel][alert.Fingerprint()] = alert
+	}
+
+	return passed
+}
+
+// collected returns the alerts collected for a channel sorted by start time.
+func (d *digester) collected(channel string) []*types.Alert {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	alerts := make([]*types.Alert, 0, len(d.alerts[channel]))
+	for _, alert := range d.alerts[channel] {
+		alerts = append(alerts, alert)
+	}
+
+	sort.Slice(alerts, func(i, j int) bool {
+		if alerts[i].StartsAt.Equal(alerts[j].StartsAt) {
+			return alerts[i].Fingerprint() < alerts[j].Fingerprint()
+		}
+		return alerts[i].StartsAt.Before(alerts[j].StartsAt)
+	})
+
+	return alerts
+}
+
+// release stops collecting the alerts of a sent digest which are resolved at now, while the firing ones are kept for
+// the next digest. The alerts collected again since the digest was built are kept.
+func (d *digester) release(channel string, alerts []*types.Alert, now time.Time) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	for _, alert := range alerts {
+		fp := alert.Fingerprint()
+		if current, ok := d.alerts[channel][fp]; ok && current == alert && alert.ResolvedAt(now) {
+			delete(d.alerts[channel], fp)
+		}
+	}
+}
+
+// wrap returns an integration of a channel collecting the alerts matched by the digest of the channel, if any, and
+// notifying the other alerts through the given integration.
+func (d *digester) wrap(channel string, integration notify.Integration) notify.Integration {
+	n := &digestNotifier{digester: d, channel: channel, integration: integration}
+	return notify.NewIntegration(n, n, integration.Name(), integration.Index(), channel)
+}
+
+type digestNotifier struct {
+	digester    *digester
+	channel     string
+	integration notify.Integration
+}
+
+func (n *digestNotifier) Notify(ctx context.Context, alerts ...*types.Alert) (bool, error) {
+	passed := n.digester.collect(n.channel, alerts)
+
+	// the resolved alerts reach the notifier to be collected even if the integration does not send them
+	if !n.integration.SendResolved() {
+		firing := []*types.Alert{}
+		for _, alert := range passed {
+			if !alert.Resolved() {
+				firing = append(firing, alert)
+			}
+		}
+		passed = firing
+	}
+
+	if len(passed) == 0 {
+		return false, nil
+	}
+
+	return n.integration.Notify(ctx, passed...)
+}
+
+func (n *digestNotifier) SendResolved() bool {
+	return n.integration.SendResolved() || n.digester.has(n.channel)
+}
+
+// SendDigests sends the digests of the channels whose period is over at now. A digest summarizes the alerts collected
+// over the period, what fired, what resolved and what is still firing. It is notified through the integrations of the
+// channel, except the emails which are sent with the digest email template.
+func (server *Server) SendDigests(ctx context.Context, now time.Time) error {
+	if server.alertmanagerConfig == nil {
+		return nil
+	}
+
+	digests, err := server.digestStore.List(ctx, server.orgID)
+	if err != nil {
+		return err
+	}
+	server.digester.set(digests)
+
+	var errs []error
+	for _, digest := range digests {
+		if !digest.Due(now) {
+			continue
+		}
+
+		if alerts := server.digester.collected(digest.Channel); len(alerts) > 0 {
+			if err := server.sendDigest(ctx, digest, alerts, now); err != nil {
+				// the digest is sent again on the next poll, with the alerts collected until then
+				errs = append(errs, errors.WrapInternalf(err, errors.CodeInternal, "failed to send the digest of %q", digest.Channel))
+				continue
+			}
+			server.digester.release(digest.Channel, alerts, now)
+		}
+
+		digest.SentAt = now
+		if err := server.digestStore.Set(ctx, digest); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (server *Server) sendDigest(ctx context.Context, digest *alertmanagertypes.StorableDigest, alerts []*types.Alert, now time.Time) error {
+	receiver, err := server.alertmanagerConfig.GetReceiver(digest.Channel)
+	if err != nil {
+		return err
+	}
+
+	groupLabels := model.LabelSet{alertmanagertypes.DigestLabel: model.LabelValue(digest.Period.StringValue())}
+	ctx = notify.WithGroupKey(ctx, "digest-"+digest.Channel+"-"+groupLabels.Fingerprint().String()+"-"+now.Format(time.RFC3339))
+	ctx = notify.WithGroupLabels(ctx, groupLabels)
+	ctx = notify.WithReceiverName(ctx, digest.Channel)
+
+	emailConfigs := receiver.EmailConfigs
+	receiver.EmailConfigs = nil
+
+	integrations, err := server.receiverIntegrations(receiver, server.tmpl, server.logger)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, integration := range integrations {
+		if _, err := integration.Notify(ctx, alerts...); err != nil {
+			errs = append(errs, errors.WrapInternalf(err, errors.CodeInternal, "failed to notify %s", integration.String()))
+		}
+	}
+
+	if len(emailConfigs) > 0 {
+		if err := server.emailDigest(ctx, digest, emailConfigs, alerts); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// emailDigest sends the digest to the recipients of the email configs of the channel.
+func (server *Server) emailDigest(ctx context.Context, digest *alertmanagertypes.StorableDigest, emailConfigs []*config.EmailConfig, alerts []*types.Alert) error {
+	data := notify.GetTemplateData(ctx, server.tmpl, alerts, server.logger)
+
+	subject, err := server.tmpl.ExecuteTextString(`{{ template "__subject" . }}`, data)
+	if err != nil {
+		return err
+	}
+
+	loc, err := time.LoadLocation(digest.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	since := digest.Since()
+	fired, resolved, firing := []map[string]any{}, []map[string]any{}, []map[string]any{}
+	for _, alert := range data.Alerts {
+		if !alert.StartsAt.Before(since) {
+			fired = append(fired, digestEmailAlert(alert, loc))
+		}
+
+		if alert.Status == string(model.AlertResolved) {
+			resolved = append(resolved, digestEmailAlert(alert, loc))
+		} else {
+			firing = append(firing, digestEmailAlert(alert, loc))
+		}
+	}
+
+	var errs []error
+	for _, emailConfig := range emailConfigs {
+		for _, to := range strings.Split(emailConfig.To, ",") {
+			to = strings.TrimSpace(to)
+			if to == "" {
+				continue
+			}
+
+			if err := server.emailing.SendHTML(ctx, to, subject, emailtypes.TemplateNameAlertDigest, map[string]any{
+				"Receiver": digest.Channel,
+				"Period":   digest.Period.StringValue(),
+				"Since":    since.In(loc).Format(time.RFC1123),
+				"Fired":    fired,
+				"Resolved": resolved,
+				"Firing":   firing,
+				"Link":     data.ExternalURL,
+			}); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func digestEmailAlert(alert template.Alert, loc *time.Location) map[string]any {
+	return map[string]any{
+		"Name":     alert.Labels[model.AlertNameLabel],
+		"StartsAt": alert.StartsAt.In(loc).Format(time.RFC1123),
+		"EndsAt":   alert.EndsAt.In(loc).Format(time.RFC1123),
+		"Summary":  alert.Annotations["summary"],
+		"Labels":   map[string]string(alert.Labels),
+	}
+}
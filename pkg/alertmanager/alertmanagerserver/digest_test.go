@@ -0,0 +1,177 @@
+package alertmanagerserver
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/alertmanager/nfmanager/nfmanagertest"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes/alertmanagertypestest"
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/notify/webhook"
+	"github.com/prometheus/client_golang/prometheus"
+	commoncfg "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerDigests(t *testing.T) {
+	var (
+		mtx      sync.Mutex
+		messages []webhook.Message
+		failing  bool
+	)
+	webhookServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		var message webhook.Message
+		require.NoError(t, json.Unmarshal(body, &message))
+
+		mtx.Lock()
+		defer mtx.Unlock()
+		if failing {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		messages = append(messages, message)
+	}))
+	defer webhookServer.Close()
+
+	now := time.Now()
+	digest, err := alertmanagertypes.NewStorableDigest("1", "admin@signoz.io", &alertmanagertypes.PostableDigest{
+		Channel:    "low-severity",
+		Period:     alertmanagertypes.DigestPeriodHourly,
+		Severities: []string{"info"},
+	}, now.Add(-2*time.Hour))
+	require.NoError(t, err)
+
+	digestStore := alertmanagertypestest.NewDigestStore()
+	require.NoError(t, digestStore.Set(context.Background(), digest))
+
+	srvCfg := NewConfig()
+	server, err := New(context.Background(), slog.New(slog.DiscardHandler), prometheus.NewRegistry(), srvCfg, "1", alertmanagertypestest.NewStateStore(), alertmanagertypestest.NewAcknowledgementStore(), alertmanagertypestest.NewWebhookDeliveryStore(), digestStore, nfmanagertest.NewMock(), nil, nil)
+	require.NoError(t, err)
+	defer func() {
+		_ = server.Stop(context.Background())
+	}()
+
+	amConfig, err := alertmanagertypes.NewDefaultConfig(srvCfg.Global, srvCfg.Route, "1")
+	require.NoError(t, err)
+
+	webhookURL, err := url.Parse(webhookServer.URL)
+	require.NoError(t, err)
+
+	require.NoError(t, amConfig.CreateReceiver(alertmanagertypes.Receiver{
+		Name:           "low-severity",
+		WebhookConfigs: []*config.WebhookConfig{{HTTPConfig: &commoncfg.HTTPClientConfig{}, URL: &config.SecretURL{URL: webhookURL}}},
+	}))
+	require.NoError(t, server.SetConfig(context.Background(), amConfig))
+
+	receiver, err := amConfig.GetReceiver("low-severity")
+	require.NoError(t, err)
+
+	integrations, err := server.receiverIntegrations(receiver, server.tmpl, server.logger)
+	require.NoError(t, err)
+	require.Len(t, integrations, 1)
+
+	integration := server.digester.wrap("low-severity", integrations[0])
+	assert.True(t, integration.SendResolved())
+
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	ctx = notify.WithGroupLabels(ctx, model.LabelSet{"alertname": "HighLatency"})
+	ctx = notify.WithReceiverName(ctx, "low-severity")
+
+	alert := func(service string, severity string, startsAt time.Time, endsAt time.Time) *alertmanagertypes.Alert {
+		return &alertmanagertypes.Alert{
+			Alert: model.Alert{
+				Labels:   model.LabelSet{"alertname": "HighLatency", "service": model.LabelValue(service), "severity": model.LabelValue(severity)},
+				StartsAt: startsAt,
+				EndsAt:   endsAt,
+			},
+		}
+	}
+
+	// the info alerts are collected while the critical ones are notified right away
+	_, err = integration.Notify(ctx,
+		alert("checkout", "info", now.Add(-30*time.Minute), now.Add(2*time.Hour)),
+		alert("frontend", "info", now.Add(-20*time.Minute), now.Add(-time.Minute)),
+		alert("payments", "critical", now.Add(-10*time.Minute), now.Add(time.Hour)),
+	)
+	require.NoError(t, err)
+
+	mtx.Lock()
+	require.Len(t, messages, 1)
+	require.Len(t, messages[0].Alerts, 1)
+	assert.Equal(t, "payments", messages[0].Alerts[0].Labels["service"])
+	messages = nil
+	mtx.Unlock()
+
+	require.NoError(t, server.SendDigests(context.Background(), now))
+
+	mtx.Lock()
+	require.Len(t, messages, 1)
+	assert.Equal(t, "hourly", messages[0].GroupLabels[alertmanagertypes.DigestLabel])
+	require.Len(t, messages[0].Alerts, 2)
+	assert.Equal(t, "checkout", messages[0].Alerts[0].Labels["service"])
+	assert.Equal(t, "firing", messages[0].Alerts[0].Status)
+	assert.Equal(t, "frontend", messages[0].Alerts[1].Labels["service"])
+	assert.Equal(t, "resolved", messages[0].Alerts[1].Status)
+	messages = nil
+	mtx.Unlock()
+
+	stored, err := digestStore.GetByChannel(context.Background(), "1", "low-severity")
+	require.NoError(t, err)
+	assert.True(t, stored.SentAt.Equal(now))
+
+	// the period of the digest is not over yet
+	require.NoError(t, server.SendDigests(context.Background(), now.Add(time.Second)))
+	mtx.Lock()
+	assert.Empty(t, messages)
+	mtx.Unlock()
+
+	// the resolved alert is no longer collected while the firing one is still summarized
+	require.NoError(t, server.SendDigests(context.Background(), now.Add(time.Hour)))
+	mtx.Lock()
+	require.Len(t, messages, 1)
+	require.Len(t, messages[0].Alerts, 1)
+	assert.Equal(t, "checkout", messages[0].Alerts[0].Labels["service"])
+	messages = nil
+	mtx.Unlock()
+
+	// a failed digest keeps its alerts and is sent again on the next poll
+	_, err = integration.Notify(ctx, alert("search", "info", now.Add(-15*time.Minute), now.Add(-5*time.Minute)))
+	require.NoError(t, err)
+
+	mtx.Lock()
+	failing = true
+	mtx.Unlock()
+	require.Error(t, server.SendDigests(context.Background(), now.Add(2*time.Hour)))
+
+	stored, err = digestStore.GetByChannel(context.Background(), "1", "low-severity")
+	require.NoError(t, err)
+	assert.True(t, stored.SentAt.Equal(now.Add(time.Hour)))
+
+	mtx.Lock()
+	failing = false
+	mtx.Unlock()
+	require.NoError(t, server.SendDigests(context.Background(), now.Add(2*time.Hour+time.Second)))
+
+	mtx.Lock()
+	require.Len(t, messages, 1)
+	require.Len(t, messages[0].Alerts, 2)
+	assert.Equal(t, "checkout", messages[0].Alerts[0].Labels["service"])
+	assert.Equal(t, "search", messages[0].Alerts[1].Labels["service"])
+	assert.Equal(t, "resolved", messages[0].Alerts[1].Status)
+	mtx.Unlock()
+}
@@ -41,10 +41,11 @@ type Dispatcher struct {
 	ctx    context.Context
 	cancel func()
 
-	logger              *slog.Logger
-	notificationManager nfmanager.NotificationManager
-	orgID               string
-	receiverRoutes      map[string]*dispatch.Route
+	logger               *slog.Logger
+	notificationManager  nfmanager.NotificationManager
+	acknowledgementStore alertmanagertypes.AcknowledgementStore
+	orgID                string
+	receiverRoutes       map[string]*dispatch.Route
 }
 
 // We use the upstream Limits interface from Prometheus
@@ -61,6 +62,7 @@ func NewDispatcher(
 	l *slog.Logger,
 	m *DispatcherMetrics,
 	n nfmanager.NotificationManager,
+	as alertmanagertypes.AcknowledgementStore,
 	orgID string,
 ) *Dispatcher {
 	if lim == nil {
@@ -69,16 +71,17 @@ func NewDispatcher(
 	}
 
 	disp := &Dispatcher{
-		alerts:              ap,
-		stage:               s,
-		route:               r,
-		marker:              mk,
-		timeout:             to,
-		logger:              l.With("component", "signoz-dispatcher"),
-		metrics:             m,
-		limits:              lim,
-		notificationManager: n,
-		orgID:               orgID,
+		alerts:               ap,
+		stage:                s,
+		route:                r,
+		marker:               mk,
+		timeout:              to,
+		logger:               l.With("component", "signoz-dispatcher"),
+		metrics:              m,
+		limits:               lim,
+		notificationManager:  n,
+		acknowledgementStore: as,
+		orgID:                orgID,
 	}
 	return disp
 }
@@ -281,14 +284,11 @@ func (d *Dispatcher) processAlert(alert *types.Alert, route *dispatch.Route) {
 		return
 	}
 	renotifyInterval := config.Renotify.RenotifyInterval
-
-	groupLabels := getGroupLabels(alert, config.NotificationGroup, config.GroupByAll)
-
 	if alertmanagertypes.NoDataAlert(alert) {
 		renotifyInterval = config.Renotify.NoDataInterval
-		groupLabels[alertmanagertypes.NoDataLabel] = alert.Labels[alertmanagertypes.NoDataLabel] //to create new group key for no data alerts
 	}
 
+	groupLabels := getAlertGroupLabels(alert, config)
 	fp := groupLabels.Fingerprint()
 
 	d.mtx.Lock()
@@ -325,6 +325,11 @@ func (d *Dispatcher) processAlert(alert *types.Alert, route *dispatch.Route) {
 	ag.insert(alert)
 
 	go ag.run(func(ctx context.Context, alerts ...*types.Alert) bool {
+		if d.acknowledged(ctx, ruleId, fp, alerts) {
+			d.logger.DebugContext(ctx, "Notify for alerts suppressed by acknowledgement", "rule_id", ruleId, "num_alerts", len(alerts))
+			return true
+		}
+
 		_, _, err := d.stage.Exec(ctx, d.logger, alerts...)
 		if err != nil {
 			logger := d.logger.With("num_alerts", len(alerts), "err", err)
@@ -337,10 +342,70 @@ func (d *Dispatcher) processAlert(alert *types.Alert, route *dispatch.Route) {
 				logger.ErrorContext(ctx, "Notify for alerts failed")
 			}
 		}
+		if err == nil {
+			d.releaseResolved(ctx, ruleId, groupLabels, alerts)
+		}
 		return err == nil
 	})
 }
 
+// acknowledged returns true if the notification of the alerts of an acknowledged group should be suppressed, which
+// is the case as long as the rule suppresses the re-notifications of the acknowledged groups and every alert of the
+// group has been firing since before it was acknowledged. Resolved alerts and alerts which started firing after the
+// acknowledgement are notified.
+func (d *Dispatcher) acknowledged(ctx context.Context, ruleId string, fp model.Fingerprint, alerts []*types.Alert) bool {
+	if d.acknowledgementStore == nil {
+		return false
+	}
+
+	config, err := d.notificationManager.GetNotificationConfig(d.orgID, ruleId)
+	if err != nil || !config.Renotify.SuppressWhenAcknowledged {
+		return false
+	}
+
+	acknowledgement, err := d.acknowledgementStore.Get(ctx, d.orgID, ruleId, fp.String())
+	if err != nil {
+		if !errors.Ast(err, errors.TypeNotFound) {
+			d.logger.ErrorContext(ctx, "error getting the acknowledgement of the group", "rule_id", ruleId, "error", err)
+		}
+		return false
+	}
+
+	if !acknowledgement.Acknowledged() {
+		return false
+	}
+
+	for _, alert := range alerts {
+		if alert.Resolved() || alert.StartsAt.After(acknowledgement.AcknowledgedAt) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// releaseResolved releases the acknowledgement and the assignment of a group and stops its escalations once all of its
+// alerts are resolved and notified, so that the next incident of the group is neither taken nor escalated already.
+func (d *Dispatcher) releaseResolved(ctx context.Context, ruleId string, groupLabels model.LabelSet, alerts []*types.Alert) {
+	for _, alert := range alerts {
+		if !alert.Resolved() {
+			return
+		}
+	}
+
+	if err := d.notificationManager.StopEscalation(ctx, d.orgID, ruleId, groupLabels); err != nil {
+		d.logger.ErrorContext(ctx, "error stopping the escalations of the resolved group", "rule_id", ruleId, "error", err)
+	}
+
+	if d.acknowledgementStore == nil {
+		return
+	}
+
+	if err := d.acknowledgementStore.Delete(ctx, d.orgID, ruleId, groupLabels.Fingerprint().String()); err != nil {
+		d.logger.ErrorContext(ctx, "error releasing the acknowledgement of the resolved group", "rule_id", ruleId, "error", err)
+	}
+}
+
 // aggrGroup aggregates alert fingerprints into groups to which a
 // common set of routing options applies.
 // It emits notifications in the specified intervals.
@@ -560,6 +625,16 @@ func getGroupLabels(alert *types.Alert, groups map[model.LabelName]struct{}, gro
 	return groupLabels
 }
 
+// getAlertGroupLabels returns the labels of the group the alert falls in. No data alerts fall in groups of their own.
+func getAlertGroupLabels(alert *types.Alert, config *alertmanagertypes.NotificationConfig) model.LabelSet {
+	groupLabels := getGroupLabels(alert, config.NotificationGroup, config.GroupByAll)
+	if alertmanagertypes.NoDataAlert(alert) {
+		groupLabels[alertmanagertypes.NoDataLabel] = alert.Labels[alertmanagertypes.NoDataLabel] //to create new group key for no data alerts
+	}
+
+	return groupLabels
+}
+
 func (d *Dispatcher) getOrCreateRoute(receiver string) *dispatch.Route {
 	d.mtx.Lock()
 	defer d.mtx.Unlock()
@@ -11,13 +11,16 @@ import (
 	"time"
 
 	"github.com/SigNoz/signoz/pkg/alertmanager/nfmanager"
+	"github.com/SigNoz/signoz/pkg/alertmanager/nfmanager/nfescalationstore/nfescalationstoretest"
 	"github.com/SigNoz/signoz/pkg/alertmanager/nfmanager/nfmanagertest"
 	"github.com/SigNoz/signoz/pkg/alertmanager/nfmanager/nfroutingstore/nfroutingstoretest"
 	"github.com/SigNoz/signoz/pkg/alertmanager/nfmanager/rulebasednotification"
+	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/factory"
 	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
 	"github.com/SigNoz/signoz/pkg/types"
 	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes/alertmanagertypestest"
 	"github.com/SigNoz/signoz/pkg/valuer"
 
 	"github.com/prometheus/alertmanager/config"
@@ -376,7 +379,7 @@ route:
 	metrics := NewDispatcherMetrics(false, prometheus.NewRegistry())
 	store := nfroutingstoretest.NewMockSQLRouteStore()
 	store.MatchExpectationsInOrder(false)
-	nfManager, err := rulebasednotification.New(context.Background(), providerSettings, nfmanager.Config{}, store)
+	nfManager, err := rulebasednotification.New(context.Background(), providerSettings, nfmanager.Config{}, store, nfescalationstoretest.NewStore())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -428,7 +431,7 @@ route:
 
 	// Set up expectations for getting routes during matching (multiple calls expected)
 
-	dispatcher := NewDispatcher(alerts, route, recorder, marker, timeout, nil, logger, metrics, nfManager, orgId)
+	dispatcher := NewDispatcher(alerts, route, recorder, marker, timeout, nil, logger, metrics, nfManager, nil, orgId)
 	go dispatcher.Run()
 	defer dispatcher.Stop()
 	inputAlerts := []*alertmanagertypes.Alert{
@@ -649,7 +652,7 @@ route:
 	metrics := NewDispatcherMetrics(false, prometheus.NewRegistry())
 	store := nfroutingstoretest.NewMockSQLRouteStore()
 	store.MatchExpectationsInOrder(false)
-	nfManager, err := rulebasednotification.New(context.Background(), providerSettings, nfmanager.Config{}, store)
+	nfManager, err := rulebasednotification.New(context.Background(), providerSettings, nfmanager.Config{}, store, nfescalationstoretest.NewStore())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -723,7 +726,7 @@ route:
 	err = nfManager.CreateRoutePolicies(ctx, orgId, routes)
 	require.NoError(t, err)
 
-	dispatcher := NewDispatcher(alerts, route, recorder, marker, timeout, nil, logger, metrics, nfManager, orgId)
+	dispatcher := NewDispatcher(alerts, route, recorder, marker, timeout, nil, logger, metrics, nfManager, nil, orgId)
 	go dispatcher.Run()
 	defer dispatcher.Stop()
 
@@ -908,7 +911,7 @@ route:
 	metrics := NewDispatcherMetrics(false, prometheus.NewRegistry())
 	store := nfroutingstoretest.NewMockSQLRouteStore()
 	store.MatchExpectationsInOrder(false)
-	nfManager, err := rulebasednotification.New(context.Background(), providerSettings, nfmanager.Config{}, store)
+	nfManager, err := rulebasednotification.New(context.Background(), providerSettings, nfmanager.Config{}, store, nfescalationstoretest.NewStore())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -958,7 +961,7 @@ route:
 	err = nfManager.CreateRoutePolicies(ctx, orgId, routes)
 	require.NoError(t, err)
 
-	dispatcher := NewDispatcher(alerts, route, recorder, marker, timeout, nil, logger, metrics, nfManager, orgId)
+	dispatcher := NewDispatcher(alerts, route, recorder, marker, timeout, nil, logger, metrics, nfManager, nil, orgId)
 	go dispatcher.Run()
 	defer dispatcher.Stop()
 
@@ -1169,7 +1172,7 @@ func TestDispatcherRace(t *testing.T) {
 	metrics := NewDispatcherMetrics(false, prometheus.NewRegistry())
 	nfManager := nfmanagertest.NewMock()
 	// Set up default expectation that won't be called in this race test
-	dispatcher := NewDispatcher(alerts, nil, nil, marker, timeout, nil, logger, metrics, nfManager, "test-org")
+	dispatcher := NewDispatcher(alerts, nil, nil, marker, timeout, nil, logger, metrics, nfManager, nil, "test-org")
 	go dispatcher.Run()
 	dispatcher.Stop()
 }
@@ -1204,7 +1207,7 @@ route:
 	metrics := NewDispatcherMetrics(false, prometheus.NewRegistry())
 	store := nfroutingstoretest.NewMockSQLRouteStore()
 	store.MatchExpectationsInOrder(false)
-	nfManager, err := rulebasednotification.New(context.Background(), providerSettings, nfmanager.Config{}, store)
+	nfManager, err := rulebasednotification.New(context.Background(), providerSettings, nfmanager.Config{}, store, nfescalationstoretest.NewStore())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1240,7 +1243,7 @@ route:
 		require.NoError(t, err)
 	}
 
-	dispatcher := NewDispatcher(alerts, route, recorder, marker, timeout, nil, logger, metrics, nfManager, orgId)
+	dispatcher := NewDispatcher(alerts, route, recorder, marker, timeout, nil, logger, metrics, nfManager, nil, orgId)
 	go dispatcher.Run()
 	defer dispatcher.Stop()
 
@@ -1284,7 +1287,7 @@ func TestDispatcher_DoMaintenance(t *testing.T) {
 	metrics := NewDispatcherMetrics(false, r)
 	nfManager := nfmanagertest.NewMock()
 	// Set up default expectation that may be called during maintenance
-	dispatcher := NewDispatcher(alerts, route, recorder, marker, timeout, nil, promslog.NewNopLogger(), metrics, nfManager, "test-org")
+	dispatcher := NewDispatcher(alerts, route, recorder, marker, timeout, nil, promslog.NewNopLogger(), metrics, nfManager, nil, "test-org")
 	aggrGroups := make(map[*dispatch.Route]map[model.Fingerprint]*aggrGroup)
 	aggrGroups[route] = make(map[model.Fingerprint]*aggrGroup)
 
@@ -1381,11 +1384,11 @@ route:
 			metrics := NewDispatcherMetrics(false, prometheus.NewRegistry())
 			store := nfroutingstoretest.NewMockSQLRouteStore()
 			store.MatchExpectationsInOrder(false)
-			nfManager, err := rulebasednotification.New(context.Background(), providerSettings, nfmanager.Config{}, store)
+			nfManager, err := rulebasednotification.New(context.Background(), providerSettings, nfmanager.Config{}, store, nfescalationstoretest.NewStore())
 			if err != nil {
 				t.Fatal(err)
 			}
-			d := NewDispatcher(alerts, route, recorder, marker, timeout, nil, logger, metrics, nfManager, "test-org")
+			d := NewDispatcher(alerts, route, recorder, marker, timeout, nil, logger, metrics, nfManager, nil, "test-org")
 			// setup the dispatcher for tests
 			d.receiverRoutes = map[string]*dispatch.Route{}
 
@@ -1400,3 +1403,90 @@ route:
 		})
 	}
 }
+
+func TestDispatcherSuppressesAcknowledgedGroups(t *testing.T) {
+	logger := promslog.NewNopLogger()
+	marker := alertmanagertypes.NewMarker(prometheus.NewRegistry())
+	alerts, err := mem.NewAlerts(context.Background(), marker, time.Hour, nil, logger, nil)
+	require.NoError(t, err)
+	defer alerts.Close()
+
+	route := &dispatch.Route{
+		RouteOpts: dispatch.RouteOpts{
+			Receiver:      "slack",
+			GroupWait:     0,
+			GroupInterval: 50 * time.Millisecond,
+		},
+	}
+	timeout := func(d time.Duration) time.Duration { return d }
+	recorder := &recordStage{alerts: make(map[string]map[model.Fingerprint]*alertmanagertypes.Alert)}
+	metrics := NewDispatcherMetrics(false, prometheus.NewRegistry())
+
+	orgId := "test-org"
+	nfManager := nfmanagertest.NewMock()
+	nfManager.SetMockRoute(orgId, &alertmanagertypes.RoutePolicy{
+		Identifiable: types.Identifiable{ID: valuer.GenerateUUID()},
+		Name:         "ruleId-HighLatency",
+		Expression:   `ruleId == "ruleId-HighLatency"`,
+		Channels:     []string{"slack"},
+		OrgID:        orgId,
+	})
+	notifConfig := alertmanagertypes.NewNotificationConfig(nil, time.Hour, time.Hour, false)
+	notifConfig.Renotify.SuppressWhenAcknowledged = true
+	nfManager.SetMockConfig(orgId, "ruleId-HighLatency", &notifConfig)
+
+	acknowledgementStore := alertmanagertypestest.NewAcknowledgementStore()
+	acknowledgement, err := alertmanagertypes.NewStorableAcknowledgement(orgId, "ruleId-HighLatency", model.LabelSet{"ruleId": "ruleId-HighLatency"}, time.Now())
+	require.NoError(t, err)
+	acknowledgement.Acknowledge("oncall@signoz.io", "", time.Now())
+	require.NoError(t, acknowledgementStore.Set(context.Background(), acknowledgement))
+
+	dispatcher := NewDispatcher(alerts, route, recorder, marker, timeout, nil, logger, metrics, nfManager, acknowledgementStore, orgId)
+	go dispatcher.Run()
+	defer dispatcher.Stop()
+
+	// the alerts of newAlert end two minutes after the tests start, which the tests of the package can outlast
+	firingAlert := func(labels model.LabelSet, startsAt time.Time) *alertmanagertypes.Alert {
+		alert := newAlert(labels)
+		alert.StartsAt = startsAt
+		alert.EndsAt = time.Now().Add(time.Hour)
+		return alert
+	}
+
+	waitForAlerts := func(n int) {
+		for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); {
+			if len(recorder.Alerts()) >= n {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	// the alert has been firing since before the acknowledgement
+	require.NoError(t, alerts.Put(firingAlert(model.LabelSet{"ruleId": "ruleId-HighLatency", "service": "checkout"}, time.Now().Add(-time.Minute))))
+	time.Sleep(200 * time.Millisecond)
+	require.Empty(t, recorder.Alerts())
+
+	// a new alert of the group is notified along with the acknowledged one
+	require.NoError(t, alerts.Put(firingAlert(model.LabelSet{"ruleId": "ruleId-HighLatency", "service": "frontend"}, time.Now())))
+	waitForAlerts(2)
+	require.Len(t, recorder.Alerts(), 2)
+
+	// the acknowledgement is released once all the alerts of the group are resolved
+	for _, labels := range []model.LabelSet{{"ruleId": "ruleId-HighLatency", "service": "checkout"}, {"ruleId": "ruleId-HighLatency", "service": "frontend"}} {
+		resolved := firingAlert(labels, time.Now().Add(-time.Minute))
+		resolved.EndsAt = time.Now().Add(-time.Second)
+		resolved.UpdatedAt = time.Now()
+		require.NoError(t, alerts.Put(resolved))
+	}
+
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); {
+		if _, err := acknowledgementStore.Get(context.Background(), orgId, "ruleId-HighLatency", acknowledgement.GroupFingerprint); err != nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	_, err = acknowledgementStore.Get(context.Background(), orgId, "ruleId-HighLatency", acknowledgement.GroupFingerprint)
+	require.True(t, errors.Ast(err, errors.TypeNotFound))
+}
@@ -0,0 +1,86 @@
+package alertmanagerserver
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
+)
+
+// notificationTimeout bounds the retries of a notification sent outside of the dispatcher.
+const notificationTimeout = time.Minute
+
+// Notification is a notification of alerts to a channel outside of the routes of the dispatcher, such as the steps of
+// the escalations. It is built while the server is locked and sent after, to all the integrations of the channel with
+// the retries of the notification pipeline.
+type Notification struct {
+	channel      string
+	groupKey     string
+	groupLabels  model.LabelSet
+	alerts       []*types.Alert
+	integrations []notify.Integration
+	metrics      *notify.Metrics
+	logger       *slog.Logger
+}
+
+// newNotification builds the notification of the alerts to all the integrations of the channel.
+func (server *Server) newNotification(channel string, groupKey string, groupLabels model.LabelSet, alerts ...*types.Alert) (*Notification, error) {
+	receiver, err := server.alertmanagerConfig.GetReceiver(channel)
+	if err != nil {
+		return nil, err
+	}
+
+	integrations, err := server.receiverIntegrations(receiver, server.tmpl, server.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(integrations) == 0 {
+		return nil, errors.Newf(errors.TypeNotFound, errors.CodeNotFound, "no integrations found for receiver %s", channel)
+	}
+
+	return &Notification{
+		channel:      channel,
+		groupKey:     groupKey,
+		groupLabels:  groupLabels,
+		alerts:       alerts,
+		integrations: integrations,
+		metrics:      server.notificationMetrics,
+		logger:       server.logger,
+	}, nil
+}
+
+// Send notifies the alerts to all the integrations of the channel at once, each of them is retried until it succeeds
+// or the notification times out.
+func (notification *Notification) Send(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, notificationTimeout)
+	defer cancel()
+
+	firing := make([]uint64, 0, len(notification.alerts))
+	for _, alert := range notification.alerts {
+		if alert.Status() == model.AlertFiring {
+			firing = append(firing, uint64(alert.Fingerprint()))
+		}
+	}
+
+	ctx = notify.WithGroupKey(ctx, notification.groupKey)
+	ctx = notify.WithGroupLabels(ctx, notification.groupLabels)
+	ctx = notify.WithReceiverName(ctx, notification.channel)
+	ctx = notify.WithFiringAlerts(ctx, firing)
+	ctx = notify.WithResolvedAlerts(ctx, []uint64{})
+
+	stage := make(notify.FanoutStage, 0, len(notification.integrations))
+	for _, integration := range notification.integrations {
+		stage = append(stage, notify.NewRetryStage(integration, notification.channel, notification.metrics))
+	}
+
+	if _, _, err := stage.Exec(ctx, notification.logger, notification.alerts...); err != nil {
+		return errors.WrapInternalf(err, errors.CodeInternal, "failed to notify %q", notification.channel)
+	}
+
+	return nil
+}
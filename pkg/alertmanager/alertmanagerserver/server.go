@@ -2,18 +2,24 @@ package alertmanagerserver
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	v2 "github.com/prometheus/alertmanager/api/v2"
 	"github.com/prometheus/alertmanager/types"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify"
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/signozwebhook"
 	"github.com/SigNoz/signoz/pkg/alertmanager/nfmanager"
+	"github.com/SigNoz/signoz/pkg/emailing"
 	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+	"github.com/SigNoz/signoz/pkg/types/oncalltypes"
 	"github.com/prometheus/alertmanager/dispatch"
 	"github.com/prometheus/alertmanager/featurecontrol"
 	"github.com/prometheus/alertmanager/inhibit"
@@ -53,6 +59,27 @@ type Server struct {
 	// store is the backing store for the alertmanager
 	stateStore alertmanagertypes.StateStore
 
+	// acknowledgementStore is the store of the acknowledgements of the alert groups
+	acknowledgementStore alertmanagertypes.AcknowledgementStore
+
+	// webhookDeliveryStore is the store of the deliveries of the signoz webhooks
+	webhookDeliveryStore alertmanagertypes.WebhookDeliveryStore
+
+	// webhookDeliveryQueue delivers the payloads of the signoz webhooks in the background
+	webhookDeliveryQueue *signozwebhook.Queue
+
+	// digestStore is the store of the digests of the channels
+	digestStore alertmanagertypes.DigestStore
+
+	// digester collects the alerts of the channels having a digest
+	digester *digester
+
+	// emailing sends the digests to the email channels
+	emailing emailing.Emailing
+
+	// receiverIntegrations builds the integrations of the receivers of the org
+	receiverIntegrations alertmanagertypes.ReceiverIntegrationsFunc
+
 	// alertmanager primitives from upstream alertmanager
 	alerts              *mem.Alerts
 	nflog               *nflog.Log
@@ -63,28 +90,53 @@ type Server struct {
 	silences            *silence.Silences
 	timeIntervals       map[string][]timeinterval.TimeInterval
 	pipelineBuilder     *notify.PipelineBuilder
+	notificationMetrics *notify.Metrics
 	marker              *alertmanagertypes.MemMarker
 	tmpl                *template.Template
 	wg                  sync.WaitGroup
 	stopc               chan struct{}
 	notificationManager nfmanager.NotificationManager
+
+	// silencesExpiredAt is the time up to which the expired silences have been notified
+	silencesExpiredAt time.Time
 }
 
-func New(ctx context.Context, logger *slog.Logger, registry prometheus.Registerer, srvConfig Config, orgID string, stateStore alertmanagertypes.StateStore, nfManager nfmanager.NotificationManager) (*Server, error) {
+func New(ctx context.Context, logger *slog.Logger, registry prometheus.Registerer, srvConfig Config, orgID string, stateStore alertmanagertypes.StateStore, acknowledgementStore alertmanagertypes.AcknowledgementStore, webhookDeliveryStore alertmanagertypes.WebhookDeliveryStore, digestStore alertmanagertypes.DigestStore, nfManager nfmanager.NotificationManager, onCallStore oncalltypes.Store, emailing emailing.Emailing) (*Server, error) {
+	logger = logger.With("pkg", "go.signoz.io/pkg/alertmanager/alertmanagerserver")
+	webhookDeliveryQueue := signozwebhook.NewQueue(logger)
+	if acknowledgementStore != nil {
+		acknowledgementStore = newAcknowledgementCache(acknowledgementStore)
+	}
+
 	server := &Server{
-		logger:              logger.With("pkg", "go.signoz.io/pkg/alertmanager/alertmanagerserver"),
-		registry:            registry,
-		srvConfig:           srvConfig,
-		orgID:               orgID,
-		stateStore:          stateStore,
-		stopc:               make(chan struct{}),
-		notificationManager: nfManager,
+		logger:               logger,
+		registry:             registry,
+		srvConfig:            srvConfig,
+		orgID:                orgID,
+		stateStore:           stateStore,
+		acknowledgementStore: acknowledgementStore,
+		webhookDeliveryStore: webhookDeliveryStore,
+		webhookDeliveryQueue: webhookDeliveryQueue,
+		digestStore:          digestStore,
+		digester:             newDigester(),
+		emailing:             emailing,
+		receiverIntegrations: alertmanagernotify.NewReceiverIntegrationsFunc(orgID, onCallStore, webhookDeliveryStore, webhookDeliveryQueue, emailing),
+		stopc:                make(chan struct{}),
+		notificationManager:  nfManager,
+		silencesExpiredAt:    time.Now(),
 	}
 	signozRegisterer := prometheus.WrapRegistererWithPrefix("signoz_", registry)
 	signozRegisterer = prometheus.WrapRegistererWith(prometheus.Labels{"org_id": server.orgID}, signozRegisterer)
 	// initialize marker
 	server.marker = alertmanagertypes.NewMarker(signozRegisterer)
 
+	// get digests for initial state
+	digests, err := server.digestStore.List(ctx, server.orgID)
+	if err != nil {
+		return nil, err
+	}
+	server.digester.set(digests)
+
 	// get silences for initial state
 	state, err := server.stateStore.Get(ctx, server.orgID)
 	if err != nil && !errors.Ast(err, errors.TypeNotFound) {
@@ -196,8 +248,12 @@ func New(ctx context.Context, logger *slog.Logger, registry prometheus.Registere
 	}
 
 	server.pipelineBuilder = notify.NewPipelineBuilder(signozRegisterer, featurecontrol.NoopFlags{})
+	// the metrics of the notifications sent outside of the routes are apart from the ones of the pipeline
+	server.notificationMetrics = notify.NewMetrics(prometheus.WrapRegistererWithPrefix("unrouted_", signozRegisterer), featurecontrol.NoopFlags{})
 	server.dispatcherMetrics = NewDispatcherMetrics(false, signozRegisterer)
 
+	server.webhookDeliveryQueue.Start()
+
 	return server, nil
 }
 
@@ -249,10 +305,14 @@ func (server *Server) SetConfig(ctx context.Context, alertmanagerConfig *alertma
 			server.logger.InfoContext(ctx, "skipping creation of receiver not referenced by any route", "receiver", rcv.Name)
 			continue
 		}
-		integrations, err := alertmanagernotify.NewReceiverIntegrations(rcv, server.tmpl, server.logger)
+		integrations, err := server.receiverIntegrations(rcv, server.tmpl, server.logger)
 		if err != nil {
 			return err
 		}
+		// The alerts of the channels having a digest are collected rather than notified.
+		for i := range integrations {
+			integrations[i] = server.digester.wrap(rcv.Name, integrations[i])
+		}
 		// rcv.Name is guaranteed to be unique across all receivers.
 		receivers[rcv.Name] = integrations
 		integrationsNum += len(integrations)
@@ -310,6 +370,7 @@ func (server *Server) SetConfig(ctx context.Context, alertmanagerConfig *alertma
 		server.logger,
 		server.dispatcherMetrics,
 		server.notificationManager,
+		server.acknowledgementStore,
 		server.orgID,
 	)
 
@@ -325,7 +386,7 @@ func (server *Server) SetConfig(ctx context.Context, alertmanagerConfig *alertma
 
 func (server *Server) TestReceiver(ctx context.Context, receiver alertmanagertypes.Receiver) error {
 	testAlert := alertmanagertypes.NewTestAlert(receiver, time.Now(), time.Now())
-	return alertmanagertypes.TestReceiver(ctx, receiver, alertmanagernotify.NewReceiverIntegrations, server.alertmanagerConfig, server.tmpl, server.logger, testAlert.Labels, testAlert)
+	return alertmanagertypes.TestReceiver(ctx, receiver, server.receiverIntegrations, server.alertmanagerConfig, server.tmpl, server.logger, testAlert.Labels, testAlert)
 }
 
 func (server *Server) TestAlert(ctx context.Context, receiversMap map[*alertmanagertypes.PostableAlert][]string, config *alertmanagertypes.NotificationConfig) error {
@@ -403,7 +464,7 @@ func (server *Server) TestAlert(ctx context.Context, receiversMap map[*alertmana
 				err = alertmanagertypes.TestReceiver(
 					gCtx,
 					receiver,
-					alertmanagernotify.NewReceiverIntegrations,
+					server.receiverIntegrations,
 					server.alertmanagerConfig,
 					server.tmpl,
 					server.logger,
@@ -428,6 +489,413 @@ func (server *Server) TestAlert(ctx context.Context, receiversMap map[*alertmana
 	return nil
 }
 
+func (server *Server) ListSilences(ctx context.Context) (alertmanagertypes.GettableSilences, error) {
+	silences, _, err := server.silences.Query()
+	if err != nil {
+		return nil, err
+	}
+
+	return alertmanagertypes.NewGettableSilencesFromSilences(silences)
+}
+
+func (server *Server) GetSilence(ctx context.Context, id string) (*alertmanagertypes.GettableSilence, error) {
+	sil, err := server.silences.QueryOne(silence.QIDs(id))
+	if err != nil {
+		if errors.Is(err, silence.ErrNotFound) {
+			return nil, errors.Newf(errors.TypeNotFound, alertmanagertypes.ErrCodeAlertmanagerSilenceNotFound, "silence with id %s doesn't exist", id)
+		}
+		return nil, err
+	}
+
+	return alertmanagertypes.NewGettableSilenceFromSilence(sil)
+}
+
+// SetSilence creates a silence, or updates the silence with the id of the given silence. The returned id differs from
+// the id of the updated silence if the update can't be done in place, e.g. when the matchers of an active silence change.
+func (server *Server) SetSilence(ctx context.Context, sil *alertmanagertypes.Silence) (string, error) {
+	if err := server.silences.Set(sil); err != nil {
+		if errors.Is(err, silence.ErrNotFound) {
+			return "", errors.Newf(errors.TypeNotFound, alertmanagertypes.ErrCodeAlertmanagerSilenceNotFound, "silence with id %s doesn't exist", sil.Id)
+		}
+		return "", errors.Wrapf(err, errors.TypeInvalidInput, alertmanagertypes.ErrCodeAlertmanagerSilenceInvalid, "failed to set silence")
+	}
+
+	return sil.Id, nil
+}
+
+func (server *Server) ExpireSilence(ctx context.Context, id string) error {
+	if err := server.silences.Expire(id); err != nil {
+		if errors.Is(err, silence.ErrNotFound) {
+			return errors.Newf(errors.TypeNotFound, alertmanagertypes.ErrCodeAlertmanagerSilenceNotFound, "silence with id %s doesn't exist", id)
+		}
+		return errors.Wrapf(err, errors.TypeInvalidInput, alertmanagertypes.ErrCodeAlertmanagerSilenceInvalid, "failed to expire silence")
+	}
+
+	return nil
+}
+
+// PreviewSilence returns the active alerts which the given silence would mute, along with the channels they are
+// routed to.
+func (server *Server) PreviewSilence(ctx context.Context, sil *alertmanagertypes.Silence) (alertmanagertypes.GettableAlerts, error) {
+	alerts, err := server.matchingAlerts(sil, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	gettableAlerts := make(alertmanagertypes.GettableAlerts, 0, len(alerts))
+	for _, alert := range alerts {
+		channels, err := server.notificationManager.Match(ctx, server.orgID, getRuleIDFromAlert(alert), alert.Labels)
+		if err != nil {
+			return nil, err
+		}
+		gettableAlerts = append(gettableAlerts, v2.AlertToOpenAPIAlert(alert, server.marker.Status(alert.Fingerprint()), channels, nil))
+	}
+
+	sort.Slice(gettableAlerts, func(i, j int) bool {
+		return *gettableAlerts[i].Fingerprint < *gettableAlerts[j].Fingerprint
+	})
+
+	return gettableAlerts, nil
+}
+
+// NotifyExpiredSilences returns the notifications of the channels of the alerts which are still firing when the
+// silence muting them expires, which are sent by the caller. The silences which expired since the previous call are
+// notified.
+func (server *Server) NotifyExpiredSilences(ctx context.Context, now time.Time) ([]*Notification, error) {
+	if server.alertmanagerConfig == nil {
+		return nil, nil
+	}
+
+	from := server.silencesExpiredAt
+	server.silencesExpiredAt = now
+
+	silences, _, err := server.silences.Query(silence.QState(types.SilenceStateExpired))
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	var notifications []*Notification
+	for _, sil := range silences {
+		if !sil.EndsAt.After(from) || sil.EndsAt.After(now) {
+			continue
+		}
+
+		alerts, err := server.matchingAlerts(sil, now)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		firingByChannel := map[string][]*types.Alert{}
+		for _, alert := range alerts {
+			channels, err := server.notificationManager.Match(ctx, server.orgID, getRuleIDFromAlert(alert), alert.Labels)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			for _, channel := range channels {
+				firingByChannel[channel] = append(firingByChannel[channel], alert)
+			}
+		}
+
+		for channel, firing := range firingByChannel {
+			alert := alertmanagertypes.NewSilenceExpiredAlert(sil, firing, now, time.Duration(server.srvConfig.Global.ResolveTimeout))
+			groupKey := fmt.Sprintf("silence-expired-%s-%s", channel, sil.Id)
+			notification, err := server.newNotification(channel, groupKey, alert.Labels, alert)
+			if err != nil {
+				errs = append(errs, errors.WrapInternalf(err, errors.CodeInternal, "failed to notify the expiry of silence %s to %q", sil.Id, channel))
+				continue
+			}
+			notifications = append(notifications, notification)
+		}
+	}
+
+	return notifications, errors.Join(errs...)
+}
+
+// matchingAlerts returns the unresolved alerts matched by the matchers of a silence.
+func (server *Server) matchingAlerts(sil *alertmanagertypes.Silence, now time.Time) ([]*types.Alert, error) {
+	matchers, err := alertmanagertypes.NewMatchersFromSilence(sil)
+	if err != nil {
+		return nil, err
+	}
+
+	iterator := server.alerts.GetPending()
+	defer iterator.Close()
+
+	alerts := []*types.Alert{}
+	for alert := range iterator.Next() {
+		if err := iterator.Err(); err != nil {
+			return nil, err
+		}
+
+		if alert.ResolvedAt(now) || !matchers.Matches(alert.Labels) {
+			continue
+		}
+		alerts = append(alerts, alert)
+	}
+
+	return alerts, nil
+}
+
+// Escalate advances the escalations of the groups of firing alerts and returns the notifications of the channels of
+// their due steps, which are sent by the caller. The escalations of a group are stopped once someone takes the
+// incident, they are stopped by the dispatcher once the group is resolved or here once its alerts expired.
+func (server *Server) Escalate(ctx context.Context, now time.Time) ([]*Notification, error) {
+	if server.alertmanagerConfig == nil {
+		return nil, nil
+	}
+
+	groups, err := server.firingGroups(now)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	var notifications []*Notification
+	firing := map[string][]model.LabelSet{}
+	for _, group := range groups {
+		firing[group.ruleID] = append(firing[group.ruleID], group.labels)
+
+		taken, err := server.taken(ctx, group.ruleID, group.labels)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if taken {
+			if err := server.notificationManager.StopEscalation(ctx, server.orgID, group.ruleID, group.labels); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+
+		alertLabels := make([]model.LabelSet, 0, len(group.alerts))
+		for _, alert := range group.alerts {
+			alertLabels = append(alertLabels, alert.Labels)
+		}
+
+		channels, err := server.notificationManager.Escalate(ctx, server.orgID, group.ruleID, group.labels, alertLabels, now)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		for _, channel := range channels {
+			groupKey := fmt.Sprintf("escalation-%s-%s-%s-%d", channel, group.ruleID, group.labels.Fingerprint(), now.Unix())
+			notification, err := server.newNotification(channel, groupKey, group.labels, group.alerts...)
+			if err != nil {
+				errs = append(errs, errors.WrapInternalf(err, errors.CodeInternal, "failed to escalate the group of rule %s to %q", group.ruleID, channel))
+				continue
+			}
+			notifications = append(notifications, notification)
+		}
+	}
+
+	if err := server.notificationManager.StopStaleEscalations(ctx, server.orgID, firing); err != nil {
+		errs = append(errs, err)
+	}
+
+	return notifications, errors.Join(errs...)
+}
+
+// alertGroup is a group of firing alerts of a rule.
+type alertGroup struct {
+	ruleID string
+	labels model.LabelSet
+	alerts []*types.Alert
+}
+
+// firingGroups returns the groups of the unresolved alerts, the alerts are grouped by the notification settings of
+// their rule.
+func (server *Server) firingGroups(now time.Time) ([]*alertGroup, error) {
+	iterator := server.alerts.GetPending()
+	defer iterator.Close()
+
+	groups := map[string]*alertGroup{}
+	keys := []string{}
+	for alert := range iterator.Next() {
+		if err := iterator.Err(); err != nil {
+			return nil, err
+		}
+
+		if alert.ResolvedAt(now) {
+			continue
+		}
+
+		ruleID := getRuleIDFromAlert(alert)
+		config, err := server.notificationManager.GetNotificationConfig(server.orgID, ruleID)
+		if err != nil {
+			return nil, err
+		}
+
+		groupLabels := getAlertGroupLabels(alert, config)
+		key := ruleID + "/" + groupLabels.Fingerprint().String()
+		group, ok := groups[key]
+		if !ok {
+			group = &alertGroup{ruleID: ruleID, labels: groupLabels}
+			groups[key] = group
+			keys = append(keys, key)
+		}
+		group.alerts = append(group.alerts, alert)
+	}
+
+	sort.Strings(keys)
+	sorted := make([]*alertGroup, 0, len(keys))
+	for _, key := range keys {
+		sorted = append(sorted, groups[key])
+	}
+
+	return sorted, nil
+}
+
+// taken returns true if someone has taken the incident of the group.
+func (server *Server) taken(ctx context.Context, ruleID string, groupLabels model.LabelSet) (bool, error) {
+	if server.acknowledgementStore == nil {
+		return false, nil
+	}
+
+	acknowledgement, err := server.acknowledgementStore.Get(ctx, server.orgID, ruleID, groupLabels.Fingerprint().String())
+	if err != nil {
+		if errors.Ast(err, errors.TypeNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return acknowledgement.Taken(), nil
+}
+
+// Acknowledge acknowledges the group of the firing alert with the given labels. It returns the acknowledgement along
+// with the firing alerts of the group.
+func (server *Server) Acknowledge(ctx context.Context, labels model.LabelSet, acknowledgedBy string, comment string, now time.Time) (*alertmanagertypes.StorableAcknowledgement, []*types.Alert, error) {
+	acknowledgement, alerts, err := server.getAcknowledgement(ctx, labels, now)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(alerts) == 0 {
+		return nil, nil, errors.Newf(errors.TypeNotFound, alertmanagertypes.ErrCodeAlertmanagerAcknowledgementNotFound, "no firing alerts to acknowledge in the group of the alert")
+	}
+
+	acknowledgement.Acknowledge(acknowledgedBy, comment, now)
+	if err := server.acknowledgementStore.Set(ctx, acknowledgement); err != nil {
+		return nil, nil, err
+	}
+
+	return acknowledgement, alerts, nil
+}
+
+// Unacknowledge withdraws the acknowledgement of the group of the alert with the given labels. The assignment of the
+// group, if any, is kept. It returns the firing alerts of the group.
+func (server *Server) Unacknowledge(ctx context.Context, labels model.LabelSet, now time.Time) ([]*types.Alert, error) {
+	acknowledgement, alerts, err := server.getAcknowledgement(ctx, labels, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if !acknowledgement.Acknowledged() {
+		return nil, errors.Newf(errors.TypeNotFound, alertmanagertypes.ErrCodeAlertmanagerAcknowledgementNotFound, "the group of the alert is not acknowledged")
+	}
+
+	acknowledgement.Unacknowledge(now)
+	if err := server.setOrDeleteAcknowledgement(ctx, acknowledgement); err != nil {
+		return nil, err
+	}
+
+	return alerts, nil
+}
+
+// Assign assigns the group of the firing alert with the given labels, an empty assignee unassigns the group.
+func (server *Server) Assign(ctx context.Context, labels model.LabelSet, assignee string, assignedBy string, now time.Time) (*alertmanagertypes.StorableAcknowledgement, error) {
+	acknowledgement, alerts, err := server.getAcknowledgement(ctx, labels, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(alerts) == 0 && assignee != "" {
+		return nil, errors.Newf(errors.TypeNotFound, alertmanagertypes.ErrCodeAlertmanagerAcknowledgementNotFound, "no firing alerts to assign in the group of the alert")
+	}
+
+	acknowledgement.Assign(assignee, assignedBy, now)
+	if err := server.setOrDeleteAcknowledgement(ctx, acknowledgement); err != nil {
+		return nil, err
+	}
+
+	return acknowledgement, nil
+}
+
+// WebhookNotifier returns the notifier of the signoz webhook at the given index of the webhook configs of a receiver.
+func (server *Server) WebhookNotifier(_ context.Context, receiverName string, integration int) (*signozwebhook.Notifier, error) {
+	if server.alertmanagerConfig == nil {
+		return nil, errors.Newf(errors.TypeNotFound, alertmanagertypes.ErrCodeAlertmanagerChannelNotFound, "channel with name %q not found", receiverName)
+	}
+
+	receiver, err := server.alertmanagerConfig.GetReceiver(receiverName)
+	if err != nil {
+		return nil, err
+	}
+
+	if integration < 0 || integration >= len(receiver.WebhookConfigs) || alertmanagertypes.WebhookKindOf(receiver.WebhookConfigs[integration]) != alertmanagertypes.WebhookKindSigNoz {
+		return nil, errors.Newf(errors.TypeNotFound, alertmanagertypes.ErrCodeAlertmanagerChannelNotFound, "channel with name %q has no signoz webhook %d", receiverName, integration)
+	}
+
+	return signozwebhook.New(receiver.WebhookConfigs[integration], server.orgID, integration, server.webhookDeliveryStore, server.webhookDeliveryQueue, server.tmpl, server.logger.With("integration", alertmanagertypes.WebhookKindSigNoz))
+}
+
+// getAcknowledgement returns the acknowledgement of the group of the alert with the given labels, which is new if the
+// group is neither acknowledged nor assigned, along with the firing alerts of the group.
+func (server *Server) getAcknowledgement(ctx context.Context, labels model.LabelSet, now time.Time) (*alertmanagertypes.StorableAcknowledgement, []*types.Alert, error) {
+	ruleID := string(labels[model.LabelName(alertmanagertypes.DefaultGroupBy)])
+	config, err := server.notificationManager.GetNotificationConfig(server.orgID, ruleID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	groupLabels := getAlertGroupLabels(&types.Alert{Alert: model.Alert{Labels: labels}}, config)
+	fp := groupLabels.Fingerprint()
+
+	iterator := server.alerts.GetPending()
+	defer iterator.Close()
+
+	alerts := []*types.Alert{}
+	for alert := range iterator.Next() {
+		if err := iterator.Err(); err != nil {
+			return nil, nil, err
+		}
+
+		if alert.ResolvedAt(now) || getRuleIDFromAlert(alert) != ruleID || getAlertGroupLabels(alert, config).Fingerprint() != fp {
+			continue
+		}
+		alerts = append(alerts, alert)
+	}
+
+	acknowledgement, err := server.acknowledgementStore.Get(ctx, server.orgID, ruleID, fp.String())
+	if err != nil {
+		if !errors.Ast(err, errors.TypeNotFound) {
+			return nil, nil, err
+		}
+
+		acknowledgement, err = alertmanagertypes.NewStorableAcknowledgement(server.orgID, ruleID, groupLabels, now)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return acknowledgement, alerts, nil
+}
+
+// setOrDeleteAcknowledgement stores the acknowledgement, or deletes it once the group is neither acknowledged nor
+// assigned.
+func (server *Server) setOrDeleteAcknowledgement(ctx context.Context, acknowledgement *alertmanagertypes.StorableAcknowledgement) error {
+	if acknowledgement.Taken() {
+		return server.acknowledgementStore.Set(ctx, acknowledgement)
+	}
+
+	return server.acknowledgementStore.Delete(ctx, acknowledgement.OrgID, acknowledgement.RuleID, acknowledgement.GroupFingerprint)
+}
+
 func (server *Server) Hash() string {
 	if server.alertmanagerConfig == nil {
 		return ""
@@ -454,5 +922,8 @@ func (server *Server) Stop(ctx context.Context) error {
 	// Wait for all goroutines to finish.
 	server.wg.Wait()
 
+	// The dispatcher is stopped, nothing is queued anymore.
+	server.webhookDeliveryQueue.Stop()
+
 	return nil
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/prometheus/alertmanager/dispatch"
 
 	"github.com/SigNoz/signoz/pkg/alertmanager/nfmanager"
+	"github.com/SigNoz/signoz/pkg/alertmanager/nfmanager/nfescalationstore/nfescalationstoretest"
 	"github.com/SigNoz/signoz/pkg/alertmanager/nfmanager/nfroutingstore/nfroutingstoretest"
 	"github.com/SigNoz/signoz/pkg/alertmanager/nfmanager/rulebasednotification"
 	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
@@ -31,7 +32,7 @@ func TestEndToEndAlertManagerFlow(t *testing.T) {
 
 	store := nfroutingstoretest.NewMockSQLRouteStore()
 	store.MatchExpectationsInOrder(false)
-	notificationManager, err := rulebasednotification.New(ctx, providerSettings, nfmanager.Config{}, store)
+	notificationManager, err := rulebasednotification.New(ctx, providerSettings, nfmanager.Config{}, store, nfescalationstoretest.NewStore())
 	require.NoError(t, err)
 	orgID := "test-org"
 
@@ -90,7 +91,7 @@ func TestEndToEndAlertManagerFlow(t *testing.T) {
 	stateStore := alertmanagertypestest.NewStateStore()
 	registry := prometheus.NewRegistry()
 	logger := slog.New(slog.DiscardHandler)
-	server, err := New(context.Background(), logger, registry, srvCfg, orgID, stateStore, notificationManager)
+	server, err := New(context.Background(), logger, registry, srvCfg, orgID, stateStore, alertmanagertypestest.NewAcknowledgementStore(), alertmanagertypestest.NewWebhookDeliveryStore(), alertmanagertypestest.NewDigestStore(), notificationManager, nil, nil)
 	require.NoError(t, err)
 	amConfig, err := alertmanagertypes.NewDefaultConfig(srvCfg.Global, srvCfg.Route, orgID)
 	require.NoError(t, err)
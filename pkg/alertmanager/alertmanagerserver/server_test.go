@@ -7,12 +7,16 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/SigNoz/signoz/pkg/alertmanager/nfmanager/nfmanagertest"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types"
 	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
 	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes/alertmanagertypestest"
+	"github.com/SigNoz/signoz/pkg/valuer"
 	"github.com/go-openapi/strfmt"
 	"github.com/prometheus/alertmanager/api/v2/models"
 	"github.com/prometheus/alertmanager/config"
@@ -25,7 +29,7 @@ import (
 
 func TestServerSetConfigAndStop(t *testing.T) {
 	notificationManager := nfmanagertest.NewMock()
-	server, err := New(context.Background(), slog.New(slog.DiscardHandler), prometheus.NewRegistry(), NewConfig(), "1", alertmanagertypestest.NewStateStore(), notificationManager)
+	server, err := New(context.Background(), slog.New(slog.DiscardHandler), prometheus.NewRegistry(), NewConfig(), "1", alertmanagertypestest.NewStateStore(), alertmanagertypestest.NewAcknowledgementStore(), alertmanagertypestest.NewWebhookDeliveryStore(), alertmanagertypestest.NewDigestStore(), notificationManager, nil, nil)
 	require.NoError(t, err)
 
 	amConfig, err := alertmanagertypes.NewDefaultConfig(alertmanagertypes.GlobalConfig{}, alertmanagertypes.RouteConfig{GroupInterval: 1 * time.Minute, RepeatInterval: 1 * time.Minute, GroupWait: 1 * time.Minute}, "1")
@@ -37,7 +41,7 @@ func TestServerSetConfigAndStop(t *testing.T) {
 
 func TestServerTestReceiverTypeWebhook(t *testing.T) {
 	notificationManager := nfmanagertest.NewMock()
-	server, err := New(context.Background(), slog.New(slog.DiscardHandler), prometheus.NewRegistry(), NewConfig(), "1", alertmanagertypestest.NewStateStore(), notificationManager)
+	server, err := New(context.Background(), slog.New(slog.DiscardHandler), prometheus.NewRegistry(), NewConfig(), "1", alertmanagertypestest.NewStateStore(), alertmanagertypestest.NewAcknowledgementStore(), alertmanagertypestest.NewWebhookDeliveryStore(), alertmanagertypestest.NewDigestStore(), notificationManager, nil, nil)
 	require.NoError(t, err)
 
 	amConfig, err := alertmanagertypes.NewDefaultConfig(alertmanagertypes.GlobalConfig{}, alertmanagertypes.RouteConfig{GroupInterval: 1 * time.Minute, RepeatInterval: 1 * time.Minute, GroupWait: 1 * time.Minute}, "1")
@@ -85,7 +89,7 @@ func TestServerPutAlerts(t *testing.T) {
 	srvCfg := NewConfig()
 	srvCfg.Route.GroupInterval = 1 * time.Second
 	notificationManager := nfmanagertest.NewMock()
-	server, err := New(context.Background(), slog.New(slog.DiscardHandler), prometheus.NewRegistry(), srvCfg, "1", stateStore, notificationManager)
+	server, err := New(context.Background(), slog.New(slog.DiscardHandler), prometheus.NewRegistry(), srvCfg, "1", stateStore, alertmanagertypestest.NewAcknowledgementStore(), alertmanagertypestest.NewWebhookDeliveryStore(), alertmanagertypestest.NewDigestStore(), notificationManager, nil, nil)
 	require.NoError(t, err)
 
 	amConfig, err := alertmanagertypes.NewDefaultConfig(srvCfg.Global, srvCfg.Route, "1")
@@ -133,7 +137,7 @@ func TestServerTestAlert(t *testing.T) {
 	srvCfg := NewConfig()
 	srvCfg.Route.GroupInterval = 1 * time.Second
 	notificationManager := nfmanagertest.NewMock()
-	server, err := New(context.Background(), slog.New(slog.DiscardHandler), prometheus.NewRegistry(), srvCfg, "1", stateStore, notificationManager)
+	server, err := New(context.Background(), slog.New(slog.DiscardHandler), prometheus.NewRegistry(), srvCfg, "1", stateStore, alertmanagertypestest.NewAcknowledgementStore(), alertmanagertypestest.NewWebhookDeliveryStore(), alertmanagertypestest.NewDigestStore(), notificationManager, nil, nil)
 	require.NoError(t, err)
 
 	amConfig, err := alertmanagertypes.NewDefaultConfig(srvCfg.Global, srvCfg.Route, "1")
@@ -238,7 +242,7 @@ func TestServerTestAlertContinuesOnFailure(t *testing.T) {
 	srvCfg := NewConfig()
 	srvCfg.Route.GroupInterval = 1 * time.Second
 	notificationManager := nfmanagertest.NewMock()
-	server, err := New(context.Background(), slog.New(slog.DiscardHandler), prometheus.NewRegistry(), srvCfg, "1", stateStore, notificationManager)
+	server, err := New(context.Background(), slog.New(slog.DiscardHandler), prometheus.NewRegistry(), srvCfg, "1", stateStore, alertmanagertypestest.NewAcknowledgementStore(), alertmanagertypestest.NewWebhookDeliveryStore(), alertmanagertypestest.NewDigestStore(), notificationManager, nil, nil)
 	require.NoError(t, err)
 
 	amConfig, err := alertmanagertypes.NewDefaultConfig(srvCfg.Global, srvCfg.Route, "1")
@@ -313,3 +317,179 @@ func TestServerTestAlertContinuesOnFailure(t *testing.T) {
 
 	assert.Greater(t, requestCount, 0, "working-receiver should have received at least one request even though failing-receiver failed")
 }
+
+func TestServerSilences(t *testing.T) {
+	srvCfg := NewConfig()
+	notificationManager := nfmanagertest.NewMock()
+	notificationManager.SetMockRoute("1", &alertmanagertypes.RoutePolicy{
+		Identifiable: types.Identifiable{ID: valuer.GenerateUUID()},
+		Name:         "ruleId-HighLatency",
+		Expression:   `ruleId == "ruleId-HighLatency"`,
+		Channels:     []string{"receiver-1"},
+		OrgID:        "1",
+	})
+	server, err := New(context.Background(), slog.New(slog.DiscardHandler), prometheus.NewRegistry(), srvCfg, "1", alertmanagertypestest.NewStateStore(), alertmanagertypestest.NewAcknowledgementStore(), alertmanagertypestest.NewWebhookDeliveryStore(), alertmanagertypestest.NewDigestStore(), notificationManager, nil, nil)
+	require.NoError(t, err)
+
+	amConfig, err := alertmanagertypes.NewDefaultConfig(srvCfg.Global, srvCfg.Route, "1")
+	require.NoError(t, err)
+
+	webhookListener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+
+	var mtx sync.Mutex
+	requestBody := new(bytes.Buffer)
+	webhookServer := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mtx.Lock()
+			defer mtx.Unlock()
+			_, _ = requestBody.ReadFrom(r.Body)
+			w.WriteHeader(http.StatusOK)
+		}),
+	}
+	go func() {
+		_ = webhookServer.Serve(webhookListener)
+	}()
+
+	webhookURL, err := url.Parse("http://" + webhookListener.Addr().String() + "/webhook")
+	require.NoError(t, err)
+
+	require.NoError(t, amConfig.CreateReceiver(alertmanagertypes.Receiver{
+		Name:           "receiver-1",
+		WebhookConfigs: []*config.WebhookConfig{{HTTPConfig: &commoncfg.HTTPClientConfig{}, URL: &config.SecretURL{URL: webhookURL}}},
+	}))
+	require.NoError(t, server.SetConfig(context.Background(), amConfig))
+	defer func() {
+		_ = server.Stop(context.Background())
+		_ = webhookServer.Close()
+	}()
+
+	require.NoError(t, server.PutAlerts(context.Background(), alertmanagertypes.PostableAlerts{
+		{
+			StartsAt: strfmt.DateTime(time.Now().Add(-time.Hour)),
+			EndsAt:   strfmt.DateTime(time.Now().Add(time.Hour)),
+			Alert:    models.Alert{Labels: models.LabelSet{"alertname": "HighLatency", "ruleId": "ruleId-HighLatency", "service": "checkout"}},
+		},
+		{
+			StartsAt: strfmt.DateTime(time.Now().Add(-time.Hour)),
+			EndsAt:   strfmt.DateTime(time.Now().Add(time.Hour)),
+			Alert:    models.Alert{Labels: models.LabelSet{"alertname": "HighLatency", "ruleId": "ruleId-HighLatency", "service": "frontend"}},
+		},
+	}))
+
+	name, value, isEqual, isRegex := "service", "checkout", true, false
+	startsAt, endsAt, comment := strfmt.DateTime(time.Now()), strfmt.DateTime(time.Now().Add(time.Hour)), "deploying checkout"
+	postable := &alertmanagertypes.PostableSilence{
+		Silence: models.Silence{
+			Matchers: models.Matchers{{Name: &name, Value: &value, IsEqual: &isEqual, IsRegex: &isRegex}},
+			StartsAt: &startsAt,
+			EndsAt:   &endsAt,
+			Comment:  &comment,
+		},
+	}
+
+	sil, err := alertmanagertypes.NewSilenceFromPostableSilence(postable, "oncall@signoz.io", "", time.Now())
+	require.NoError(t, err)
+
+	preview, err := server.PreviewSilence(context.Background(), sil)
+	require.NoError(t, err)
+	require.Len(t, preview, 1)
+	assert.Equal(t, "checkout", preview[0].Labels["service"])
+	assert.Equal(t, "receiver-1", *preview[0].Receivers[0].Name)
+
+	id, err := server.SetSilence(context.Background(), sil)
+	require.NoError(t, err)
+
+	silences, err := server.ListSilences(context.Background())
+	require.NoError(t, err)
+	require.Len(t, silences, 1)
+	assert.Equal(t, "oncall@signoz.io", *silences[0].CreatedBy)
+	assert.Equal(t, "active", *silences[0].Status.State)
+
+	require.NoError(t, server.ExpireSilence(context.Background(), id))
+	gettable, err := server.GetSilence(context.Background(), id)
+	require.NoError(t, err)
+	assert.Equal(t, "expired", *gettable.Status.State)
+
+	_, err = server.GetSilence(context.Background(), "does-not-exist")
+	assert.True(t, errors.Ast(err, errors.TypeNotFound))
+
+	notifications, err := server.NotifyExpiredSilences(context.Background(), time.Now().Add(time.Second))
+	require.NoError(t, err)
+	for _, notification := range notifications {
+		require.NoError(t, notification.Send(context.Background()))
+	}
+	mtx.Lock()
+	assert.Contains(t, requestBody.String(), alertmanagertypes.SilenceExpiredAlertName)
+	assert.Contains(t, requestBody.String(), "deploying checkout")
+	requestBody.Reset()
+	mtx.Unlock()
+
+	// the silence is notified only once
+	notifications, err = server.NotifyExpiredSilences(context.Background(), time.Now().Add(2*time.Second))
+	require.NoError(t, err)
+	assert.Empty(t, notifications)
+}
+
+func TestServerAcknowledgements(t *testing.T) {
+	acknowledgementStore := alertmanagertypestest.NewAcknowledgementStore()
+	server, err := New(context.Background(), slog.New(slog.DiscardHandler), prometheus.NewRegistry(), NewConfig(), "1", alertmanagertypestest.NewStateStore(), acknowledgementStore, alertmanagertypestest.NewWebhookDeliveryStore(), alertmanagertypestest.NewDigestStore(), nfmanagertest.NewMock(), nil, nil)
+	require.NoError(t, err)
+	defer func() {
+		_ = server.Stop(context.Background())
+	}()
+
+	require.NoError(t, server.PutAlerts(context.Background(), alertmanagertypes.PostableAlerts{
+		{
+			StartsAt: strfmt.DateTime(time.Now().Add(-time.Hour)),
+			EndsAt:   strfmt.DateTime(time.Now().Add(time.Hour)),
+			Alert:    models.Alert{Labels: models.LabelSet{"alertname": "HighLatency", "ruleId": "ruleId-HighLatency", "service": "checkout"}},
+		},
+		{
+			StartsAt: strfmt.DateTime(time.Now().Add(-time.Hour)),
+			EndsAt:   strfmt.DateTime(time.Now().Add(time.Hour)),
+			Alert:    models.Alert{Labels: models.LabelSet{"alertname": "HighLatency", "ruleId": "ruleId-HighLatency", "service": "frontend"}},
+		},
+		{
+			StartsAt: strfmt.DateTime(time.Now().Add(-time.Hour)),
+			EndsAt:   strfmt.DateTime(time.Now().Add(time.Hour)),
+			Alert:    models.Alert{Labels: models.LabelSet{"alertname": "HighErrorRate", "ruleId": "ruleId-HighErrorRate"}},
+		},
+	}))
+
+	labels := model.LabelSet{"alertname": "HighLatency", "ruleId": "ruleId-HighLatency", "service": "checkout"}
+
+	// the group of the alert has all the alerts of the rule as the alerts are grouped by rule by default
+	acknowledgement, alerts, err := server.Acknowledge(context.Background(), labels, "oncall@signoz.io", "looking into it", time.Now())
+	require.NoError(t, err)
+	assert.Len(t, alerts, 2)
+	assert.True(t, acknowledgement.Acknowledged())
+	assert.Equal(t, `{"ruleId":"ruleId-HighLatency"}`, acknowledgement.GroupLabels)
+
+	acknowledgement, err = server.Assign(context.Background(), labels, "sre@signoz.io", "oncall@signoz.io", time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, "sre@signoz.io", acknowledgement.Assignee)
+	assert.Equal(t, "oncall@signoz.io", acknowledgement.AcknowledgedBy)
+
+	// the assignment is kept when the acknowledgement is withdrawn
+	alerts, err = server.Unacknowledge(context.Background(), labels, time.Now())
+	require.NoError(t, err)
+	assert.Len(t, alerts, 2)
+
+	stored, err := acknowledgementStore.Get(context.Background(), "1", "ruleId-HighLatency", acknowledgement.GroupFingerprint)
+	require.NoError(t, err)
+	assert.False(t, stored.Acknowledged())
+	assert.True(t, stored.Taken())
+
+	_, err = server.Unacknowledge(context.Background(), labels, time.Now())
+	assert.True(t, errors.Ast(err, errors.TypeNotFound))
+
+	// the acknowledgement is deleted once the group is neither acknowledged nor assigned
+	_, err = server.Assign(context.Background(), labels, "", "oncall@signoz.io", time.Now())
+	require.NoError(t, err)
+	_, err = acknowledgementStore.Get(context.Background(), "1", "ruleId-HighLatency", acknowledgement.GroupFingerprint)
+	assert.True(t, errors.Ast(err, errors.TypeNotFound))
+
+	_, _, err = server.Acknowledge(context.Background(), model.LabelSet{"ruleId": "ruleId-Unknown"}, "oncall@signoz.io", "", time.Now())
+	assert.True(t, errors.Ast(err, errors.TypeNotFound))
+}
@@ -0,0 +1,125 @@
+package clickhousealertmanagerstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/querybuilder/rulestatehistory"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+	"github.com/prometheus/common/model"
+)
+
+// historyWindow bounds the rule state history which is looked up for the last state changes of the alerts, the alerts
+// which have been firing for longer than the window are not recorded.
+const historyWindow = 30 * 24 * time.Hour
+
+type history struct {
+	telemetryStore telemetrystore.TelemetryStore
+}
+
+func NewHistoryStore(telemetryStore telemetrystore.TelemetryStore) alertmanagertypes.AcknowledgementHistoryStore {
+	return &history{telemetryStore: telemetryStore}
+}
+
+// lastStateChange is the last state change of an alert of a rule in the rule state history.
+type lastStateChange struct {
+	ruleName     string
+	overallState string
+	state        string
+	fingerprint  uint64
+	labels       string
+	value        float64
+}
+
+// Record implements alertmanagertypes.AcknowledgementHistoryStore. The history of an alert is keyed by the fingerprint
+// of the labels of the query result it was evaluated from, which is not known to the alertmanager. The event is
+// therefore recorded for the alerts of the rule which are firing in the history and whose labels are part of the labels
+// of one of the given alerts.
+func (store *history) Record(ctx context.Context, ruleID string, event alertmanagertypes.AcknowledgementEvent, alerts []model.LabelSet, at time.Time) error {
+	state := rulestatehistory.StateAcknowledged
+	if event == alertmanagertypes.AcknowledgementEventUnacknowledged {
+		state = rulestatehistory.StateUnacknowledged
+	}
+
+	changes, err := store.lastStateChanges(ctx, ruleID, at.Add(-historyWindow))
+	if err != nil {
+		return err
+	}
+
+	statement, err := store.telemetryStore.ClickhouseDB().PrepareBatch(ctx, fmt.Sprintf("INSERT INTO %s.%s (rule_id, rule_name, overall_state, overall_state_changed, state, state_changed, unix_milli, labels, fingerprint, value) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)", rulestatehistory.DBName, rulestatehistory.TableName))
+	if err != nil {
+		return errors.WrapInternalf(err, errors.CodeInternal, "failed to prepare the batch of the rule state history")
+	}
+	defer statement.Abort() //nolint:errcheck
+
+	for _, change := range changes {
+		if change.state != rulestatehistory.StateFiring && change.state != rulestatehistory.StateNoData {
+			continue
+		}
+
+		labels := map[string]string{}
+		if err := json.Unmarshal([]byte(change.labels), &labels); err != nil {
+			continue
+		}
+
+		if !matchesAny(labels, alerts) {
+			continue
+		}
+
+		if err := statement.Append(ruleID, change.ruleName, change.overallState, false, state, false, at.UnixMilli(), change.labels, change.fingerprint, change.value); err != nil {
+			return errors.WrapInternalf(err, errors.CodeInternal, "failed to append to the batch of the rule state history")
+		}
+	}
+
+	if statement.Rows() == 0 {
+		return nil
+	}
+
+	if err := statement.Send(); err != nil {
+		return errors.WrapInternalf(err, errors.CodeInternal, "failed to record %s in the rule state history", event.StringValue())
+	}
+
+	return nil
+}
+
+func (store *history) lastStateChanges(ctx context.Context, ruleID string, since time.Time) ([]*lastStateChange, error) {
+	rows, err := store.telemetryStore.ClickhouseDB().Query(ctx, fmt.Sprintf("SELECT rule_name, overall_state, state, fingerprint, labels, value FROM %s.%s WHERE rule_id = ? AND unix_milli >= ? AND state_changed = true ORDER BY unix_milli DESC LIMIT 1 BY fingerprint", rulestatehistory.DBName, rulestatehistory.TableName), ruleID, since.UnixMilli())
+	if err != nil {
+		return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to query the rule state history of rule %s", ruleID)
+	}
+	defer rows.Close() //nolint:errcheck
+
+	changes := []*lastStateChange{}
+	for rows.Next() {
+		change := new(lastStateChange)
+		if err := rows.Scan(&change.ruleName, &change.overallState, &change.state, &change.fingerprint, &change.labels, &change.value); err != nil {
+			return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to scan the rule state history of rule %s", ruleID)
+		}
+		changes = append(changes, change)
+	}
+
+	return changes, rows.Err()
+}
+
+// matchesAny returns true if the labels are part of the labels of one of the alerts.
+func matchesAny(labels map[string]string, alerts []model.LabelSet) bool {
+	for _, alert := range alerts {
+		matches := true
+		for name, value := range labels {
+			if string(alert[model.LabelName(name)]) != value {
+				matches = false
+				break
+			}
+		}
+
+		if matches {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,101 @@
+package sqlalertmanagerstore
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+)
+
+type acknowledgement struct {
+	sqlstore sqlstore.SQLStore
+}
+
+func NewAcknowledgementStore(sqlstore sqlstore.SQLStore) alertmanagertypes.AcknowledgementStore {
+	return &acknowledgement{sqlstore: sqlstore}
+}
+
+// Get implements alertmanagertypes.AcknowledgementStore.
+func (store *acknowledgement) Get(ctx context.Context, orgID string, ruleID string, groupFingerprint string) (*alertmanagertypes.StorableAcknowledgement, error) {
+	storableAcknowledgement := new(alertmanagertypes.StorableAcknowledgement)
+
+	err := store.
+		sqlstore.
+		BunDB().
+		NewSelect().
+		Model(storableAcknowledgement).
+		Where("org_id = ?", orgID).
+		Where("rule_id = ?", ruleID).
+		Where("group_fingerprint = ?", groupFingerprint).
+		Scan(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.Newf(errors.TypeNotFound, alertmanagertypes.ErrCodeAlertmanagerAcknowledgementNotFound, "cannot find acknowledgement of group %s of rule %s", groupFingerprint, ruleID)
+		}
+
+		return nil, err
+	}
+
+	return storableAcknowledgement, nil
+}
+
+// List implements alertmanagertypes.AcknowledgementStore.
+func (store *acknowledgement) List(ctx context.Context, orgID string) ([]*alertmanagertypes.StorableAcknowledgement, error) {
+	storableAcknowledgements := make([]*alertmanagertypes.StorableAcknowledgement, 0)
+
+	err := store.
+		sqlstore.
+		BunDB().
+		NewSelect().
+		Model(&storableAcknowledgements).
+		Where("org_id = ?", orgID).
+		Order("updated_at DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return storableAcknowledgements, nil
+}
+
+// Set implements alertmanagertypes.AcknowledgementStore.
+func (store *acknowledgement) Set(ctx context.Context, storableAcknowledgement *alertmanagertypes.StorableAcknowledgement) error {
+	_, err := store.
+		sqlstore.
+		BunDB().
+		NewInsert().
+		Model(storableAcknowledgement).
+		On("CONFLICT (org_id, rule_id, group_fingerprint) DO UPDATE").
+		Set("acknowledged_by = EXCLUDED.acknowledged_by").
+		Set("acknowledged_at = EXCLUDED.acknowledged_at").
+		Set("assignee = EXCLUDED.assignee").
+		Set("assigned_by = EXCLUDED.assigned_by").
+		Set("comment = EXCLUDED.comment").
+		Set("updated_at = EXCLUDED.updated_at").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Delete implements alertmanagertypes.AcknowledgementStore.
+func (store *acknowledgement) Delete(ctx context.Context, orgID string, ruleID string, groupFingerprint string) error {
+	_, err := store.
+		sqlstore.
+		BunDB().
+		NewDelete().
+		Model(new(alertmanagertypes.StorableAcknowledgement)).
+		Where("org_id = ?", orgID).
+		Where("rule_id = ?", ruleID).
+		Where("group_fingerprint = ?", groupFingerprint).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package sqlalertmanagerstore
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type digest struct {
+	sqlstore sqlstore.SQLStore
+}
+
+func NewDigestStore(sqlstore sqlstore.SQLStore) alertmanagertypes.DigestStore {
+	return &digest{sqlstore: sqlstore}
+}
+
+// Get implements alertmanagertypes.DigestStore.
+func (store *digest) Get(ctx context.Context, orgID string, id valuer.UUID) (*alertmanagertypes.StorableDigest, error) {
+	storableDigest := new(alertmanagertypes.StorableDigest)
+
+	err := store.
+		sqlstore.
+		BunDB().
+		NewSelect().
+		Model(storableDigest).
+		Where("org_id = ?", orgID).
+		Where("id = ?", id.StringValue()).
+		Scan(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.Newf(errors.TypeNotFound, alertmanagertypes.ErrCodeAlertmanagerDigestNotFound, "cannot find digest with id %s", id.StringValue())
+		}
+
+		return nil, err
+	}
+
+	return storableDigest, nil
+}
+
+// GetByChannel implements alertmanagertypes.DigestStore.
+func (store *digest) GetByChannel(ctx context.Context, orgID string, channel string) (*alertmanagertypes.StorableDigest, error) {
+	storableDigest := new(alertmanagertypes.StorableDigest)
+
+	err := store.
+		sqlstore.
+		BunDB().
+		NewSelect().
+		Model(storableDigest).
+		Where("org_id = ?", orgID).
+		Where("channel = ?", channel).
+		Scan(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.Newf(errors.TypeNotFound, alertmanagertypes.ErrCodeAlertmanagerDigestNotFound, "cannot find digest of channel %s", channel)
+		}
+
+		return nil, err
+	}
+
+	return storableDigest, nil
+}
+
+// List implements alertmanagertypes.DigestStore.
+func (store *digest) List(ctx context.Context, orgID string) ([]*alertmanagertypes.StorableDigest, error) {
+	storableDigests := make([]*alertmanagertypes.StorableDigest, 0)
+
+	err := store.
+		sqlstore.
+		BunDB().
+		NewSelect().
+		Model(&storableDigests).
+		Where("org_id = ?", orgID).
+		Order("channel ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return storableDigests, nil
+}
+
+// Set implements alertmanagertypes.DigestStore.
+func (store *digest) Set(ctx context.Context, storableDigest *alertmanagertypes.StorableDigest) error {
+	_, err := store.
+		sqlstore.
+		BunDB().
+		NewInsert().
+		Model(storableDigest).
+		On("CONFLICT (org_id, channel) DO UPDATE").
+		Set("period = EXCLUDED.period").
+		Set("hour = EXCLUDED.hour").
+		Set("timezone = EXCLUDED.timezone").
+		Set("severities = EXCLUDED.severities").
+		Set("sent_at = EXCLUDED.sent_at").
+		Set("updated_by = EXCLUDED.updated_by").
+		Set("updated_at = EXCLUDED.updated_at").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Delete implements alertmanagertypes.DigestStore.
+func (store *digest) Delete(ctx context.Context, orgID string, id valuer.UUID) error {
+	_, err := store.
+		sqlstore.
+		BunDB().
+		NewDelete().
+		Model(new(alertmanagertypes.StorableDigest)).
+		Where("org_id = ?", orgID).
+		Where("id = ?", id.StringValue()).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
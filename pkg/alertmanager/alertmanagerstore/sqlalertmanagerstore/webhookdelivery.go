@@ -0,0 +1,122 @@
+package sqlalertmanagerstore
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type webhookDelivery struct {
+	sqlstore sqlstore.SQLStore
+}
+
+func NewWebhookDeliveryStore(sqlstore sqlstore.SQLStore) alertmanagertypes.WebhookDeliveryStore {
+	return &webhookDelivery{sqlstore: sqlstore}
+}
+
+// Create implements alertmanagertypes.WebhookDeliveryStore.
+func (store *webhookDelivery) Create(ctx context.Context, storableWebhookDelivery *alertmanagertypes.StorableWebhookDelivery) error {
+	_, err := store.
+		sqlstore.
+		BunDB().
+		NewInsert().
+		Model(storableWebhookDelivery).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Get implements alertmanagertypes.WebhookDeliveryStore.
+func (store *webhookDelivery) Get(ctx context.Context, orgID string, id valuer.UUID) (*alertmanagertypes.StorableWebhookDelivery, error) {
+	storableWebhookDelivery := new(alertmanagertypes.StorableWebhookDelivery)
+
+	err := store.
+		sqlstore.
+		BunDB().
+		NewSelect().
+		Model(storableWebhookDelivery).
+		Where("org_id = ?", orgID).
+		Where("id = ?", id.StringValue()).
+		Scan(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.Newf(errors.TypeNotFound, alertmanagertypes.ErrCodeAlertmanagerWebhookDeliveryNotFound, "cannot find webhook delivery with id %s", id.StringValue())
+		}
+
+		return nil, err
+	}
+
+	return storableWebhookDelivery, nil
+}
+
+// List implements alertmanagertypes.WebhookDeliveryStore.
+func (store *webhookDelivery) List(ctx context.Context, orgID string, params *alertmanagertypes.ListWebhookDeliveriesParams) ([]*alertmanagertypes.StorableWebhookDelivery, error) {
+	storableWebhookDeliveries := make([]*alertmanagertypes.StorableWebhookDelivery, 0)
+
+	query := store.
+		sqlstore.
+		BunDB().
+		NewSelect().
+		Model(&storableWebhookDeliveries).
+		Where("org_id = ?", orgID)
+
+	if params.Receiver != "" {
+		query = query.Where("receiver = ?", params.Receiver)
+	}
+
+	if !params.Status.IsZero() {
+		query = query.Where("status = ?", params.Status.StringValue())
+	}
+
+	err := query.
+		Order("created_at DESC").
+		Limit(params.Limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return storableWebhookDeliveries, nil
+}
+
+// Update implements alertmanagertypes.WebhookDeliveryStore.
+func (store *webhookDelivery) Update(ctx context.Context, storableWebhookDelivery *alertmanagertypes.StorableWebhookDelivery) error {
+	_, err := store.
+		sqlstore.
+		BunDB().
+		NewUpdate().
+		Model(storableWebhookDelivery).
+		Column("status", "attempts", "updated_at").
+		Where("org_id = ?", storableWebhookDelivery.OrgID).
+		Where("id = ?", storableWebhookDelivery.ID.StringValue()).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteBefore implements alertmanagertypes.WebhookDeliveryStore.
+func (store *webhookDelivery) DeleteBefore(ctx context.Context, before time.Time) error {
+	_, err := store.
+		sqlstore.
+		BunDB().
+		NewDelete().
+		Model(new(alertmanagertypes.StorableWebhookDelivery)).
+		Where("created_at < ?", before).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
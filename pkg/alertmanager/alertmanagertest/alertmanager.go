@@ -40,6 +40,166 @@ func (_m *MockAlertmanager) EXPECT() *MockAlertmanager_Expecter {
 	return &MockAlertmanager_Expecter{mock: &_m.Mock}
 }
 
+// Acknowledge provides a mock function for the type MockAlertmanager
+func (_mock *MockAlertmanager) Acknowledge(ctx context.Context, orgID string, acknowledgedBy string, acknowledgement *alertmanagertypes.PostableAcknowledgement) (*alertmanagertypes.GettableAcknowledgement, error) {
+	ret := _mock.Called(ctx, orgID, acknowledgedBy, acknowledgement)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Acknowledge")
+	}
+
+	var r0 *alertmanagertypes.GettableAcknowledgement
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *alertmanagertypes.PostableAcknowledgement) (*alertmanagertypes.GettableAcknowledgement, error)); ok {
+		return returnFunc(ctx, orgID, acknowledgedBy, acknowledgement)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *alertmanagertypes.PostableAcknowledgement) *alertmanagertypes.GettableAcknowledgement); ok {
+		r0 = returnFunc(ctx, orgID, acknowledgedBy, acknowledgement)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*alertmanagertypes.GettableAcknowledgement)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *alertmanagertypes.PostableAcknowledgement) error); ok {
+		r1 = returnFunc(ctx, orgID, acknowledgedBy, acknowledgement)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAlertmanager_Acknowledge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Acknowledge'
+type MockAlertmanager_Acknowledge_Call struct {
+	*mock.Call
+}
+
+// Acknowledge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID string
+//   - acknowledgedBy string
+//   - acknowledgement *alertmanagertypes.PostableAcknowledgement
+func (_e *MockAlertmanager_Expecter) Acknowledge(ctx interface{}, orgID interface{}, acknowledgedBy interface{}, acknowledgement interface{}) *MockAlertmanager_Acknowledge_Call {
+	return &MockAlertmanager_Acknowledge_Call{Call: _e.mock.On("Acknowledge", ctx, orgID, acknowledgedBy, acknowledgement)}
+}
+
+func (_c *MockAlertmanager_Acknowledge_Call) Run(run func(ctx context.Context, orgID string, acknowledgedBy string, acknowledgement *alertmanagertypes.PostableAcknowledgement)) *MockAlertmanager_Acknowledge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *alertmanagertypes.PostableAcknowledgement
+		if args[3] != nil {
+			arg3 = args[3].(*alertmanagertypes.PostableAcknowledgement)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAlertmanager_Acknowledge_Call) Return(gettableAcknowledgement *alertmanagertypes.GettableAcknowledgement, err error) *MockAlertmanager_Acknowledge_Call {
+	_c.Call.Return(gettableAcknowledgement, err)
+	return _c
+}
+
+func (_c *MockAlertmanager_Acknowledge_Call) RunAndReturn(run func(ctx context.Context, orgID string, acknowledgedBy string, acknowledgement *alertmanagertypes.PostableAcknowledgement) (*alertmanagertypes.GettableAcknowledgement, error)) *MockAlertmanager_Acknowledge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Assign provides a mock function for the type MockAlertmanager
+func (_mock *MockAlertmanager) Assign(ctx context.Context, orgID string, assignedBy string, acknowledgement *alertmanagertypes.PostableAcknowledgement) (*alertmanagertypes.GettableAcknowledgement, error) {
+	ret := _mock.Called(ctx, orgID, assignedBy, acknowledgement)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Assign")
+	}
+
+	var r0 *alertmanagertypes.GettableAcknowledgement
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *alertmanagertypes.PostableAcknowledgement) (*alertmanagertypes.GettableAcknowledgement, error)); ok {
+		return returnFunc(ctx, orgID, assignedBy, acknowledgement)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *alertmanagertypes.PostableAcknowledgement) *alertmanagertypes.GettableAcknowledgement); ok {
+		r0 = returnFunc(ctx, orgID, assignedBy, acknowledgement)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*alertmanagertypes.GettableAcknowledgement)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *alertmanagertypes.PostableAcknowledgement) error); ok {
+		r1 = returnFunc(ctx, orgID, assignedBy, acknowledgement)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAlertmanager_Assign_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Assign'
+type MockAlertmanager_Assign_Call struct {
+	*mock.Call
+}
+
+// Assign is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID string
+//   - assignedBy string
+//   - acknowledgement *alertmanagertypes.PostableAcknowledgement
+func (_e *MockAlertmanager_Expecter) Assign(ctx interface{}, orgID interface{}, assignedBy interface{}, acknowledgement interface{}) *MockAlertmanager_Assign_Call {
+	return &MockAlertmanager_Assign_Call{Call: _e.mock.On("Assign", ctx, orgID, assignedBy, acknowledgement)}
+}
+
+func (_c *MockAlertmanager_Assign_Call) Run(run func(ctx context.Context, orgID string, assignedBy string, acknowledgement *alertmanagertypes.PostableAcknowledgement)) *MockAlertmanager_Assign_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *alertmanagertypes.PostableAcknowledgement
+		if args[3] != nil {
+			arg3 = args[3].(*alertmanagertypes.PostableAcknowledgement)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAlertmanager_Assign_Call) Return(gettableAcknowledgement *alertmanagertypes.GettableAcknowledgement, err error) *MockAlertmanager_Assign_Call {
+	_c.Call.Return(gettableAcknowledgement, err)
+	return _c
+}
+
+func (_c *MockAlertmanager_Assign_Call) RunAndReturn(run func(ctx context.Context, orgID string, assignedBy string, acknowledgement *alertmanagertypes.PostableAcknowledgement) (*alertmanagertypes.GettableAcknowledgement, error)) *MockAlertmanager_Assign_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Collect provides a mock function for the type MockAlertmanager
 func (_mock *MockAlertmanager) Collect(context1 context.Context, uUID valuer.UUID) (map[string]any, error) {
 	ret := _mock.Called(context1, uUID)
@@ -182,6 +342,74 @@ func (_c *MockAlertmanager_CreateChannel_Call) RunAndReturn(run func(context1 co
 	return _c
 }
 
+// CreateEscalationPolicy provides a mock function for the type MockAlertmanager
+func (_mock *MockAlertmanager) CreateEscalationPolicy(ctx context.Context, policy *alertmanagertypes.PostableEscalationPolicy) (*alertmanagertypes.GettableEscalationPolicy, error) {
+	ret := _mock.Called(ctx, policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateEscalationPolicy")
+	}
+
+	var r0 *alertmanagertypes.GettableEscalationPolicy
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *alertmanagertypes.PostableEscalationPolicy) (*alertmanagertypes.GettableEscalationPolicy, error)); ok {
+		return returnFunc(ctx, policy)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *alertmanagertypes.PostableEscalationPolicy) *alertmanagertypes.GettableEscalationPolicy); ok {
+		r0 = returnFunc(ctx, policy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*alertmanagertypes.GettableEscalationPolicy)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *alertmanagertypes.PostableEscalationPolicy) error); ok {
+		r1 = returnFunc(ctx, policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAlertmanager_CreateEscalationPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEscalationPolicy'
+type MockAlertmanager_CreateEscalationPolicy_Call struct {
+	*mock.Call
+}
+
+// CreateEscalationPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - policy *alertmanagertypes.PostableEscalationPolicy
+func (_e *MockAlertmanager_Expecter) CreateEscalationPolicy(ctx interface{}, policy interface{}) *MockAlertmanager_CreateEscalationPolicy_Call {
+	return &MockAlertmanager_CreateEscalationPolicy_Call{Call: _e.mock.On("CreateEscalationPolicy", ctx, policy)}
+}
+
+func (_c *MockAlertmanager_CreateEscalationPolicy_Call) Run(run func(ctx context.Context, policy *alertmanagertypes.PostableEscalationPolicy)) *MockAlertmanager_CreateEscalationPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *alertmanagertypes.PostableEscalationPolicy
+		if args[1] != nil {
+			arg1 = args[1].(*alertmanagertypes.PostableEscalationPolicy)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAlertmanager_CreateEscalationPolicy_Call) Return(gettableEscalationPolicy *alertmanagertypes.GettableEscalationPolicy, err error) *MockAlertmanager_CreateEscalationPolicy_Call {
+	_c.Call.Return(gettableEscalationPolicy, err)
+	return _c
+}
+
+func (_c *MockAlertmanager_CreateEscalationPolicy_Call) RunAndReturn(run func(ctx context.Context, policy *alertmanagertypes.PostableEscalationPolicy) (*alertmanagertypes.GettableEscalationPolicy, error)) *MockAlertmanager_CreateEscalationPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateInhibitRules provides a mock function for the type MockAlertmanager
 func (_mock *MockAlertmanager) CreateInhibitRules(ctx context.Context, orgID valuer.UUID, rules []config.InhibitRule) error {
 	ret := _mock.Called(ctx, orgID, rules)
@@ -564,49 +792,49 @@ func (_c *MockAlertmanager_DeleteChannelByID_Call) RunAndReturn(run func(context
 	return _c
 }
 
-// DeleteNotificationConfig provides a mock function for the type MockAlertmanager
-func (_mock *MockAlertmanager) DeleteNotificationConfig(ctx context.Context, orgID valuer.UUID, ruleId string) error {
-	ret := _mock.Called(ctx, orgID, ruleId)
+// DeleteDigest provides a mock function for the type MockAlertmanager
+func (_mock *MockAlertmanager) DeleteDigest(ctx context.Context, orgID string, id valuer.UUID) error {
+	ret := _mock.Called(ctx, orgID, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteNotificationConfig")
+		panic("no return value specified for DeleteDigest")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, valuer.UUID, string) error); ok {
-		r0 = returnFunc(ctx, orgID, ruleId)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, valuer.UUID) error); ok {
+		r0 = returnFunc(ctx, orgID, id)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockAlertmanager_DeleteNotificationConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteNotificationConfig'
-type MockAlertmanager_DeleteNotificationConfig_Call struct {
+// MockAlertmanager_DeleteDigest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteDigest'
+type MockAlertmanager_DeleteDigest_Call struct {
 	*mock.Call
 }
 
-// DeleteNotificationConfig is a helper method to define mock.On call
+// DeleteDigest is a helper method to define mock.On call
 //   - ctx context.Context
-//   - orgID valuer.UUID
-//   - ruleId string
-func (_e *MockAlertmanager_Expecter) DeleteNotificationConfig(ctx interface{}, orgID interface{}, ruleId interface{}) *MockAlertmanager_DeleteNotificationConfig_Call {
-	return &MockAlertmanager_DeleteNotificationConfig_Call{Call: _e.mock.On("DeleteNotificationConfig", ctx, orgID, ruleId)}
+//   - orgID string
+//   - id valuer.UUID
+func (_e *MockAlertmanager_Expecter) DeleteDigest(ctx interface{}, orgID interface{}, id interface{}) *MockAlertmanager_DeleteDigest_Call {
+	return &MockAlertmanager_DeleteDigest_Call{Call: _e.mock.On("DeleteDigest", ctx, orgID, id)}
 }
 
-func (_c *MockAlertmanager_DeleteNotificationConfig_Call) Run(run func(ctx context.Context, orgID valuer.UUID, ruleId string)) *MockAlertmanager_DeleteNotificationConfig_Call {
+func (_c *MockAlertmanager_DeleteDigest_Call) Run(run func(ctx context.Context, orgID string, id valuer.UUID)) *MockAlertmanager_DeleteDigest_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 valuer.UUID
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(valuer.UUID)
+			arg1 = args[1].(string)
 		}
-		var arg2 string
+		var arg2 valuer.UUID
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].(valuer.UUID)
 		}
 		run(
 			arg0,
@@ -617,46 +845,46 @@ func (_c *MockAlertmanager_DeleteNotificationConfig_Call) Run(run func(ctx conte
 	return _c
 }
 
-func (_c *MockAlertmanager_DeleteNotificationConfig_Call) Return(err error) *MockAlertmanager_DeleteNotificationConfig_Call {
+func (_c *MockAlertmanager_DeleteDigest_Call) Return(err error) *MockAlertmanager_DeleteDigest_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockAlertmanager_DeleteNotificationConfig_Call) RunAndReturn(run func(ctx context.Context, orgID valuer.UUID, ruleId string) error) *MockAlertmanager_DeleteNotificationConfig_Call {
+func (_c *MockAlertmanager_DeleteDigest_Call) RunAndReturn(run func(ctx context.Context, orgID string, id valuer.UUID) error) *MockAlertmanager_DeleteDigest_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteRoutePolicyByID provides a mock function for the type MockAlertmanager
-func (_mock *MockAlertmanager) DeleteRoutePolicyByID(ctx context.Context, routeID string) error {
-	ret := _mock.Called(ctx, routeID)
+// DeleteEscalationPolicyByID provides a mock function for the type MockAlertmanager
+func (_mock *MockAlertmanager) DeleteEscalationPolicyByID(ctx context.Context, policyID string) error {
+	ret := _mock.Called(ctx, policyID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteRoutePolicyByID")
+		panic("no return value specified for DeleteEscalationPolicyByID")
 	}
 
 	var r0 error
 	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = returnFunc(ctx, routeID)
+		r0 = returnFunc(ctx, policyID)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockAlertmanager_DeleteRoutePolicyByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRoutePolicyByID'
-type MockAlertmanager_DeleteRoutePolicyByID_Call struct {
+// MockAlertmanager_DeleteEscalationPolicyByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteEscalationPolicyByID'
+type MockAlertmanager_DeleteEscalationPolicyByID_Call struct {
 	*mock.Call
 }
 
-// DeleteRoutePolicyByID is a helper method to define mock.On call
+// DeleteEscalationPolicyByID is a helper method to define mock.On call
 //   - ctx context.Context
-//   - routeID string
-func (_e *MockAlertmanager_Expecter) DeleteRoutePolicyByID(ctx interface{}, routeID interface{}) *MockAlertmanager_DeleteRoutePolicyByID_Call {
-	return &MockAlertmanager_DeleteRoutePolicyByID_Call{Call: _e.mock.On("DeleteRoutePolicyByID", ctx, routeID)}
+//   - policyID string
+func (_e *MockAlertmanager_Expecter) DeleteEscalationPolicyByID(ctx interface{}, policyID interface{}) *MockAlertmanager_DeleteEscalationPolicyByID_Call {
+	return &MockAlertmanager_DeleteEscalationPolicyByID_Call{Call: _e.mock.On("DeleteEscalationPolicyByID", ctx, policyID)}
 }
 
-func (_c *MockAlertmanager_DeleteRoutePolicyByID_Call) Run(run func(ctx context.Context, routeID string)) *MockAlertmanager_DeleteRoutePolicyByID_Call {
+func (_c *MockAlertmanager_DeleteEscalationPolicyByID_Call) Run(run func(ctx context.Context, policyID string)) *MockAlertmanager_DeleteEscalationPolicyByID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -674,70 +902,59 @@ func (_c *MockAlertmanager_DeleteRoutePolicyByID_Call) Run(run func(ctx context.
 	return _c
 }
 
-func (_c *MockAlertmanager_DeleteRoutePolicyByID_Call) Return(err error) *MockAlertmanager_DeleteRoutePolicyByID_Call {
+func (_c *MockAlertmanager_DeleteEscalationPolicyByID_Call) Return(err error) *MockAlertmanager_DeleteEscalationPolicyByID_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockAlertmanager_DeleteRoutePolicyByID_Call) RunAndReturn(run func(ctx context.Context, routeID string) error) *MockAlertmanager_DeleteRoutePolicyByID_Call {
+func (_c *MockAlertmanager_DeleteEscalationPolicyByID_Call) RunAndReturn(run func(ctx context.Context, policyID string) error) *MockAlertmanager_DeleteEscalationPolicyByID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAlerts provides a mock function for the type MockAlertmanager
-func (_mock *MockAlertmanager) GetAlerts(context1 context.Context, s string, gettableAlertsParams alertmanagertypes.GettableAlertsParams) (alertmanagertypes.DeprecatedGettableAlerts, error) {
-	ret := _mock.Called(context1, s, gettableAlertsParams)
+// DeleteNotificationConfig provides a mock function for the type MockAlertmanager
+func (_mock *MockAlertmanager) DeleteNotificationConfig(ctx context.Context, orgID valuer.UUID, ruleId string) error {
+	ret := _mock.Called(ctx, orgID, ruleId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAlerts")
+		panic("no return value specified for DeleteNotificationConfig")
 	}
 
-	var r0 alertmanagertypes.DeprecatedGettableAlerts
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, alertmanagertypes.GettableAlertsParams) (alertmanagertypes.DeprecatedGettableAlerts, error)); ok {
-		return returnFunc(context1, s, gettableAlertsParams)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, alertmanagertypes.GettableAlertsParams) alertmanagertypes.DeprecatedGettableAlerts); ok {
-		r0 = returnFunc(context1, s, gettableAlertsParams)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(alertmanagertypes.DeprecatedGettableAlerts)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, alertmanagertypes.GettableAlertsParams) error); ok {
-		r1 = returnFunc(context1, s, gettableAlertsParams)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, valuer.UUID, string) error); ok {
+		r0 = returnFunc(ctx, orgID, ruleId)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockAlertmanager_GetAlerts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAlerts'
-type MockAlertmanager_GetAlerts_Call struct {
+// MockAlertmanager_DeleteNotificationConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteNotificationConfig'
+type MockAlertmanager_DeleteNotificationConfig_Call struct {
 	*mock.Call
 }
 
-// GetAlerts is a helper method to define mock.On call
-//   - context1 context.Context
-//   - s string
-//   - gettableAlertsParams alertmanagertypes.GettableAlertsParams
-func (_e *MockAlertmanager_Expecter) GetAlerts(context1 interface{}, s interface{}, gettableAlertsParams interface{}) *MockAlertmanager_GetAlerts_Call {
-	return &MockAlertmanager_GetAlerts_Call{Call: _e.mock.On("GetAlerts", context1, s, gettableAlertsParams)}
+// DeleteNotificationConfig is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID valuer.UUID
+//   - ruleId string
+func (_e *MockAlertmanager_Expecter) DeleteNotificationConfig(ctx interface{}, orgID interface{}, ruleId interface{}) *MockAlertmanager_DeleteNotificationConfig_Call {
+	return &MockAlertmanager_DeleteNotificationConfig_Call{Call: _e.mock.On("DeleteNotificationConfig", ctx, orgID, ruleId)}
 }
 
-func (_c *MockAlertmanager_GetAlerts_Call) Run(run func(context1 context.Context, s string, gettableAlertsParams alertmanagertypes.GettableAlertsParams)) *MockAlertmanager_GetAlerts_Call {
+func (_c *MockAlertmanager_DeleteNotificationConfig_Call) Run(run func(ctx context.Context, orgID valuer.UUID, ruleId string)) *MockAlertmanager_DeleteNotificationConfig_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 valuer.UUID
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(valuer.UUID)
 		}
-		var arg2 alertmanagertypes.GettableAlertsParams
+		var arg2 string
 		if args[2] != nil {
-			arg2 = args[2].(alertmanagertypes.GettableAlertsParams)
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
@@ -748,170 +965,834 @@ func (_c *MockAlertmanager_GetAlerts_Call) Run(run func(context1 context.Context
 	return _c
 }
 
-func (_c *MockAlertmanager_GetAlerts_Call) Return(v alertmanagertypes.DeprecatedGettableAlerts, err error) *MockAlertmanager_GetAlerts_Call {
-	_c.Call.Return(v, err)
+func (_c *MockAlertmanager_DeleteNotificationConfig_Call) Return(err error) *MockAlertmanager_DeleteNotificationConfig_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockAlertmanager_GetAlerts_Call) RunAndReturn(run func(context1 context.Context, s string, gettableAlertsParams alertmanagertypes.GettableAlertsParams) (alertmanagertypes.DeprecatedGettableAlerts, error)) *MockAlertmanager_GetAlerts_Call {
+func (_c *MockAlertmanager_DeleteNotificationConfig_Call) RunAndReturn(run func(ctx context.Context, orgID valuer.UUID, ruleId string) error) *MockAlertmanager_DeleteNotificationConfig_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAllRoutePolicies provides a mock function for the type MockAlertmanager
+// DeleteRoutePolicyByID provides a mock function for the type MockAlertmanager
+func (_mock *MockAlertmanager) DeleteRoutePolicyByID(ctx context.Context, routeID string) error {
+	ret := _mock.Called(ctx, routeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRoutePolicyByID")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, routeID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAlertmanager_DeleteRoutePolicyByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRoutePolicyByID'
+type MockAlertmanager_DeleteRoutePolicyByID_Call struct {
+	*mock.Call
+}
+
+// DeleteRoutePolicyByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - routeID string
+func (_e *MockAlertmanager_Expecter) DeleteRoutePolicyByID(ctx interface{}, routeID interface{}) *MockAlertmanager_DeleteRoutePolicyByID_Call {
+	return &MockAlertmanager_DeleteRoutePolicyByID_Call{Call: _e.mock.On("DeleteRoutePolicyByID", ctx, routeID)}
+}
+
+func (_c *MockAlertmanager_DeleteRoutePolicyByID_Call) Run(run func(ctx context.Context, routeID string)) *MockAlertmanager_DeleteRoutePolicyByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAlertmanager_DeleteRoutePolicyByID_Call) Return(err error) *MockAlertmanager_DeleteRoutePolicyByID_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAlertmanager_DeleteRoutePolicyByID_Call) RunAndReturn(run func(ctx context.Context, routeID string) error) *MockAlertmanager_DeleteRoutePolicyByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExpireSilence provides a mock function for the type MockAlertmanager
+func (_mock *MockAlertmanager) ExpireSilence(context1 context.Context, s string, s1 string) error {
+	ret := _mock.Called(context1, s, s1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExpireSilence")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(context1, s, s1)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAlertmanager_ExpireSilence_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExpireSilence'
+type MockAlertmanager_ExpireSilence_Call struct {
+	*mock.Call
+}
+
+// ExpireSilence is a helper method to define mock.On call
+//   - context1 context.Context
+//   - s string
+//   - s1 string
+func (_e *MockAlertmanager_Expecter) ExpireSilence(context1 interface{}, s interface{}, s1 interface{}) *MockAlertmanager_ExpireSilence_Call {
+	return &MockAlertmanager_ExpireSilence_Call{Call: _e.mock.On("ExpireSilence", context1, s, s1)}
+}
+
+func (_c *MockAlertmanager_ExpireSilence_Call) Run(run func(context1 context.Context, s string, s1 string)) *MockAlertmanager_ExpireSilence_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAlertmanager_ExpireSilence_Call) Return(err error) *MockAlertmanager_ExpireSilence_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAlertmanager_ExpireSilence_Call) RunAndReturn(run func(context1 context.Context, s string, s1 string) error) *MockAlertmanager_ExpireSilence_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAlerts provides a mock function for the type MockAlertmanager
+func (_mock *MockAlertmanager) GetAlerts(context1 context.Context, s string, gettableAlertsParams alertmanagertypes.GettableAlertsParams) (alertmanagertypes.DeprecatedGettableAlerts, error) {
+	ret := _mock.Called(context1, s, gettableAlertsParams)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAlerts")
+	}
+
+	var r0 alertmanagertypes.DeprecatedGettableAlerts
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, alertmanagertypes.GettableAlertsParams) (alertmanagertypes.DeprecatedGettableAlerts, error)); ok {
+		return returnFunc(context1, s, gettableAlertsParams)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, alertmanagertypes.GettableAlertsParams) alertmanagertypes.DeprecatedGettableAlerts); ok {
+		r0 = returnFunc(context1, s, gettableAlertsParams)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(alertmanagertypes.DeprecatedGettableAlerts)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, alertmanagertypes.GettableAlertsParams) error); ok {
+		r1 = returnFunc(context1, s, gettableAlertsParams)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAlertmanager_GetAlerts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAlerts'
+type MockAlertmanager_GetAlerts_Call struct {
+	*mock.Call
+}
+
+// GetAlerts is a helper method to define mock.On call
+//   - context1 context.Context
+//   - s string
+//   - gettableAlertsParams alertmanagertypes.GettableAlertsParams
+func (_e *MockAlertmanager_Expecter) GetAlerts(context1 interface{}, s interface{}, gettableAlertsParams interface{}) *MockAlertmanager_GetAlerts_Call {
+	return &MockAlertmanager_GetAlerts_Call{Call: _e.mock.On("GetAlerts", context1, s, gettableAlertsParams)}
+}
+
+func (_c *MockAlertmanager_GetAlerts_Call) Run(run func(context1 context.Context, s string, gettableAlertsParams alertmanagertypes.GettableAlertsParams)) *MockAlertmanager_GetAlerts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 alertmanagertypes.GettableAlertsParams
+		if args[2] != nil {
+			arg2 = args[2].(alertmanagertypes.GettableAlertsParams)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAlertmanager_GetAlerts_Call) Return(v alertmanagertypes.DeprecatedGettableAlerts, err error) *MockAlertmanager_GetAlerts_Call {
+	_c.Call.Return(v, err)
+	return _c
+}
+
+func (_c *MockAlertmanager_GetAlerts_Call) RunAndReturn(run func(context1 context.Context, s string, gettableAlertsParams alertmanagertypes.GettableAlertsParams) (alertmanagertypes.DeprecatedGettableAlerts, error)) *MockAlertmanager_GetAlerts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllEscalationPolicies provides a mock function for the type MockAlertmanager
+func (_mock *MockAlertmanager) GetAllEscalationPolicies(ctx context.Context) ([]*alertmanagertypes.GettableEscalationPolicy, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllEscalationPolicies")
+	}
+
+	var r0 []*alertmanagertypes.GettableEscalationPolicy
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*alertmanagertypes.GettableEscalationPolicy, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*alertmanagertypes.GettableEscalationPolicy); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*alertmanagertypes.GettableEscalationPolicy)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAlertmanager_GetAllEscalationPolicies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllEscalationPolicies'
+type MockAlertmanager_GetAllEscalationPolicies_Call struct {
+	*mock.Call
+}
+
+// GetAllEscalationPolicies is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockAlertmanager_Expecter) GetAllEscalationPolicies(ctx interface{}) *MockAlertmanager_GetAllEscalationPolicies_Call {
+	return &MockAlertmanager_GetAllEscalationPolicies_Call{Call: _e.mock.On("GetAllEscalationPolicies", ctx)}
+}
+
+func (_c *MockAlertmanager_GetAllEscalationPolicies_Call) Run(run func(ctx context.Context)) *MockAlertmanager_GetAllEscalationPolicies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAlertmanager_GetAllEscalationPolicies_Call) Return(gettableEscalationPolicys []*alertmanagertypes.GettableEscalationPolicy, err error) *MockAlertmanager_GetAllEscalationPolicies_Call {
+	_c.Call.Return(gettableEscalationPolicys, err)
+	return _c
+}
+
+func (_c *MockAlertmanager_GetAllEscalationPolicies_Call) RunAndReturn(run func(ctx context.Context) ([]*alertmanagertypes.GettableEscalationPolicy, error)) *MockAlertmanager_GetAllEscalationPolicies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllRoutePolicies provides a mock function for the type MockAlertmanager
 func (_mock *MockAlertmanager) GetAllRoutePolicies(ctx context.Context) ([]*alertmanagertypes.GettableRoutePolicy, error) {
 	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllRoutePolicies")
+		panic("no return value specified for GetAllRoutePolicies")
+	}
+
+	var r0 []*alertmanagertypes.GettableRoutePolicy
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*alertmanagertypes.GettableRoutePolicy, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*alertmanagertypes.GettableRoutePolicy); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*alertmanagertypes.GettableRoutePolicy)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAlertmanager_GetAllRoutePolicies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllRoutePolicies'
+type MockAlertmanager_GetAllRoutePolicies_Call struct {
+	*mock.Call
+}
+
+// GetAllRoutePolicies is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockAlertmanager_Expecter) GetAllRoutePolicies(ctx interface{}) *MockAlertmanager_GetAllRoutePolicies_Call {
+	return &MockAlertmanager_GetAllRoutePolicies_Call{Call: _e.mock.On("GetAllRoutePolicies", ctx)}
+}
+
+func (_c *MockAlertmanager_GetAllRoutePolicies_Call) Run(run func(ctx context.Context)) *MockAlertmanager_GetAllRoutePolicies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAlertmanager_GetAllRoutePolicies_Call) Return(gettableRoutePolicys []*alertmanagertypes.GettableRoutePolicy, err error) *MockAlertmanager_GetAllRoutePolicies_Call {
+	_c.Call.Return(gettableRoutePolicys, err)
+	return _c
+}
+
+func (_c *MockAlertmanager_GetAllRoutePolicies_Call) RunAndReturn(run func(ctx context.Context) ([]*alertmanagertypes.GettableRoutePolicy, error)) *MockAlertmanager_GetAllRoutePolicies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChannelByID provides a mock function for the type MockAlertmanager
+func (_mock *MockAlertmanager) GetChannelByID(context1 context.Context, s string, uUID valuer.UUID) (*alertmanagertypes.Channel, error) {
+	ret := _mock.Called(context1, s, uUID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChannelByID")
+	}
+
+	var r0 *alertmanagertypes.Channel
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, valuer.UUID) (*alertmanagertypes.Channel, error)); ok {
+		return returnFunc(context1, s, uUID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, valuer.UUID) *alertmanagertypes.Channel); ok {
+		r0 = returnFunc(context1, s, uUID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*alertmanagertypes.Channel)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, valuer.UUID) error); ok {
+		r1 = returnFunc(context1, s, uUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAlertmanager_GetChannelByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChannelByID'
+type MockAlertmanager_GetChannelByID_Call struct {
+	*mock.Call
+}
+
+// GetChannelByID is a helper method to define mock.On call
+//   - context1 context.Context
+//   - s string
+//   - uUID valuer.UUID
+func (_e *MockAlertmanager_Expecter) GetChannelByID(context1 interface{}, s interface{}, uUID interface{}) *MockAlertmanager_GetChannelByID_Call {
+	return &MockAlertmanager_GetChannelByID_Call{Call: _e.mock.On("GetChannelByID", context1, s, uUID)}
+}
+
+func (_c *MockAlertmanager_GetChannelByID_Call) Run(run func(context1 context.Context, s string, uUID valuer.UUID)) *MockAlertmanager_GetChannelByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 valuer.UUID
+		if args[2] != nil {
+			arg2 = args[2].(valuer.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAlertmanager_GetChannelByID_Call) Return(channel *alertmanagertypes.Channel, err error) *MockAlertmanager_GetChannelByID_Call {
+	_c.Call.Return(channel, err)
+	return _c
+}
+
+func (_c *MockAlertmanager_GetChannelByID_Call) RunAndReturn(run func(context1 context.Context, s string, uUID valuer.UUID) (*alertmanagertypes.Channel, error)) *MockAlertmanager_GetChannelByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetConfig provides a mock function for the type MockAlertmanager
+func (_mock *MockAlertmanager) GetConfig(context1 context.Context, s string) (*alertmanagertypes.Config, error) {
+	ret := _mock.Called(context1, s)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetConfig")
+	}
+
+	var r0 *alertmanagertypes.Config
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*alertmanagertypes.Config, error)); ok {
+		return returnFunc(context1, s)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *alertmanagertypes.Config); ok {
+		r0 = returnFunc(context1, s)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*alertmanagertypes.Config)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(context1, s)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAlertmanager_GetConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetConfig'
+type MockAlertmanager_GetConfig_Call struct {
+	*mock.Call
+}
+
+// GetConfig is a helper method to define mock.On call
+//   - context1 context.Context
+//   - s string
+func (_e *MockAlertmanager_Expecter) GetConfig(context1 interface{}, s interface{}) *MockAlertmanager_GetConfig_Call {
+	return &MockAlertmanager_GetConfig_Call{Call: _e.mock.On("GetConfig", context1, s)}
+}
+
+func (_c *MockAlertmanager_GetConfig_Call) Run(run func(context1 context.Context, s string)) *MockAlertmanager_GetConfig_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAlertmanager_GetConfig_Call) Return(config1 *alertmanagertypes.Config, err error) *MockAlertmanager_GetConfig_Call {
+	_c.Call.Return(config1, err)
+	return _c
+}
+
+func (_c *MockAlertmanager_GetConfig_Call) RunAndReturn(run func(context1 context.Context, s string) (*alertmanagertypes.Config, error)) *MockAlertmanager_GetConfig_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEscalationPolicyByID provides a mock function for the type MockAlertmanager
+func (_mock *MockAlertmanager) GetEscalationPolicyByID(ctx context.Context, policyID string) (*alertmanagertypes.GettableEscalationPolicy, error) {
+	ret := _mock.Called(ctx, policyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEscalationPolicyByID")
+	}
+
+	var r0 *alertmanagertypes.GettableEscalationPolicy
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*alertmanagertypes.GettableEscalationPolicy, error)); ok {
+		return returnFunc(ctx, policyID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *alertmanagertypes.GettableEscalationPolicy); ok {
+		r0 = returnFunc(ctx, policyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*alertmanagertypes.GettableEscalationPolicy)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, policyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAlertmanager_GetEscalationPolicyByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEscalationPolicyByID'
+type MockAlertmanager_GetEscalationPolicyByID_Call struct {
+	*mock.Call
+}
+
+// GetEscalationPolicyByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - policyID string
+func (_e *MockAlertmanager_Expecter) GetEscalationPolicyByID(ctx interface{}, policyID interface{}) *MockAlertmanager_GetEscalationPolicyByID_Call {
+	return &MockAlertmanager_GetEscalationPolicyByID_Call{Call: _e.mock.On("GetEscalationPolicyByID", ctx, policyID)}
+}
+
+func (_c *MockAlertmanager_GetEscalationPolicyByID_Call) Run(run func(ctx context.Context, policyID string)) *MockAlertmanager_GetEscalationPolicyByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAlertmanager_GetEscalationPolicyByID_Call) Return(gettableEscalationPolicy *alertmanagertypes.GettableEscalationPolicy, err error) *MockAlertmanager_GetEscalationPolicyByID_Call {
+	_c.Call.Return(gettableEscalationPolicy, err)
+	return _c
+}
+
+func (_c *MockAlertmanager_GetEscalationPolicyByID_Call) RunAndReturn(run func(ctx context.Context, policyID string) (*alertmanagertypes.GettableEscalationPolicy, error)) *MockAlertmanager_GetEscalationPolicyByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRoutePolicyByID provides a mock function for the type MockAlertmanager
+func (_mock *MockAlertmanager) GetRoutePolicyByID(ctx context.Context, routeID string) (*alertmanagertypes.GettableRoutePolicy, error) {
+	ret := _mock.Called(ctx, routeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRoutePolicyByID")
+	}
+
+	var r0 *alertmanagertypes.GettableRoutePolicy
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*alertmanagertypes.GettableRoutePolicy, error)); ok {
+		return returnFunc(ctx, routeID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *alertmanagertypes.GettableRoutePolicy); ok {
+		r0 = returnFunc(ctx, routeID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*alertmanagertypes.GettableRoutePolicy)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, routeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAlertmanager_GetRoutePolicyByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRoutePolicyByID'
+type MockAlertmanager_GetRoutePolicyByID_Call struct {
+	*mock.Call
+}
+
+// GetRoutePolicyByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - routeID string
+func (_e *MockAlertmanager_Expecter) GetRoutePolicyByID(ctx interface{}, routeID interface{}) *MockAlertmanager_GetRoutePolicyByID_Call {
+	return &MockAlertmanager_GetRoutePolicyByID_Call{Call: _e.mock.On("GetRoutePolicyByID", ctx, routeID)}
+}
+
+func (_c *MockAlertmanager_GetRoutePolicyByID_Call) Run(run func(ctx context.Context, routeID string)) *MockAlertmanager_GetRoutePolicyByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAlertmanager_GetRoutePolicyByID_Call) Return(gettableRoutePolicy *alertmanagertypes.GettableRoutePolicy, err error) *MockAlertmanager_GetRoutePolicyByID_Call {
+	_c.Call.Return(gettableRoutePolicy, err)
+	return _c
+}
+
+func (_c *MockAlertmanager_GetRoutePolicyByID_Call) RunAndReturn(run func(ctx context.Context, routeID string) (*alertmanagertypes.GettableRoutePolicy, error)) *MockAlertmanager_GetRoutePolicyByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSilence provides a mock function for the type MockAlertmanager
+func (_mock *MockAlertmanager) GetSilence(context1 context.Context, s string, s1 string) (*alertmanagertypes.GettableSilence, error) {
+	ret := _mock.Called(context1, s, s1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSilence")
+	}
+
+	var r0 *alertmanagertypes.GettableSilence
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*alertmanagertypes.GettableSilence, error)); ok {
+		return returnFunc(context1, s, s1)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *alertmanagertypes.GettableSilence); ok {
+		r0 = returnFunc(context1, s, s1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*alertmanagertypes.GettableSilence)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(context1, s, s1)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAlertmanager_GetSilence_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSilence'
+type MockAlertmanager_GetSilence_Call struct {
+	*mock.Call
+}
+
+// GetSilence is a helper method to define mock.On call
+//   - context1 context.Context
+//   - s string
+//   - s1 string
+func (_e *MockAlertmanager_Expecter) GetSilence(context1 interface{}, s interface{}, s1 interface{}) *MockAlertmanager_GetSilence_Call {
+	return &MockAlertmanager_GetSilence_Call{Call: _e.mock.On("GetSilence", context1, s, s1)}
+}
+
+func (_c *MockAlertmanager_GetSilence_Call) Run(run func(context1 context.Context, s string, s1 string)) *MockAlertmanager_GetSilence_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAlertmanager_GetSilence_Call) Return(gettableSilence *alertmanagertypes.GettableSilence, err error) *MockAlertmanager_GetSilence_Call {
+	_c.Call.Return(gettableSilence, err)
+	return _c
+}
+
+func (_c *MockAlertmanager_GetSilence_Call) RunAndReturn(run func(context1 context.Context, s string, s1 string) (*alertmanagertypes.GettableSilence, error)) *MockAlertmanager_GetSilence_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAcknowledgements provides a mock function for the type MockAlertmanager
+func (_mock *MockAlertmanager) ListAcknowledgements(context1 context.Context, s string) (alertmanagertypes.GettableAcknowledgements, error) {
+	ret := _mock.Called(context1, s)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAcknowledgements")
 	}
 
-	var r0 []*alertmanagertypes.GettableRoutePolicy
+	var r0 alertmanagertypes.GettableAcknowledgements
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*alertmanagertypes.GettableRoutePolicy, error)); ok {
-		return returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (alertmanagertypes.GettableAcknowledgements, error)); ok {
+		return returnFunc(context1, s)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) []*alertmanagertypes.GettableRoutePolicy); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) alertmanagertypes.GettableAcknowledgements); ok {
+		r0 = returnFunc(context1, s)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*alertmanagertypes.GettableRoutePolicy)
+			r0 = ret.Get(0).(alertmanagertypes.GettableAcknowledgements)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(context1, s)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockAlertmanager_GetAllRoutePolicies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllRoutePolicies'
-type MockAlertmanager_GetAllRoutePolicies_Call struct {
+// MockAlertmanager_ListAcknowledgements_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAcknowledgements'
+type MockAlertmanager_ListAcknowledgements_Call struct {
 	*mock.Call
 }
 
-// GetAllRoutePolicies is a helper method to define mock.On call
-//   - ctx context.Context
-func (_e *MockAlertmanager_Expecter) GetAllRoutePolicies(ctx interface{}) *MockAlertmanager_GetAllRoutePolicies_Call {
-	return &MockAlertmanager_GetAllRoutePolicies_Call{Call: _e.mock.On("GetAllRoutePolicies", ctx)}
+// ListAcknowledgements is a helper method to define mock.On call
+//   - context1 context.Context
+//   - s string
+func (_e *MockAlertmanager_Expecter) ListAcknowledgements(context1 interface{}, s interface{}) *MockAlertmanager_ListAcknowledgements_Call {
+	return &MockAlertmanager_ListAcknowledgements_Call{Call: _e.mock.On("ListAcknowledgements", context1, s)}
 }
 
-func (_c *MockAlertmanager_GetAllRoutePolicies_Call) Run(run func(ctx context.Context)) *MockAlertmanager_GetAllRoutePolicies_Call {
+func (_c *MockAlertmanager_ListAcknowledgements_Call) Run(run func(context1 context.Context, s string)) *MockAlertmanager_ListAcknowledgements_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockAlertmanager_GetAllRoutePolicies_Call) Return(gettableRoutePolicys []*alertmanagertypes.GettableRoutePolicy, err error) *MockAlertmanager_GetAllRoutePolicies_Call {
-	_c.Call.Return(gettableRoutePolicys, err)
+func (_c *MockAlertmanager_ListAcknowledgements_Call) Return(gettableAcknowledgements alertmanagertypes.GettableAcknowledgements, err error) *MockAlertmanager_ListAcknowledgements_Call {
+	_c.Call.Return(gettableAcknowledgements, err)
 	return _c
 }
 
-func (_c *MockAlertmanager_GetAllRoutePolicies_Call) RunAndReturn(run func(ctx context.Context) ([]*alertmanagertypes.GettableRoutePolicy, error)) *MockAlertmanager_GetAllRoutePolicies_Call {
+func (_c *MockAlertmanager_ListAcknowledgements_Call) RunAndReturn(run func(context1 context.Context, s string) (alertmanagertypes.GettableAcknowledgements, error)) *MockAlertmanager_ListAcknowledgements_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetChannelByID provides a mock function for the type MockAlertmanager
-func (_mock *MockAlertmanager) GetChannelByID(context1 context.Context, s string, uUID valuer.UUID) (*alertmanagertypes.Channel, error) {
-	ret := _mock.Called(context1, s, uUID)
+// ListAllChannels provides a mock function for the type MockAlertmanager
+func (_mock *MockAlertmanager) ListAllChannels(context1 context.Context) ([]*alertmanagertypes.Channel, error) {
+	ret := _mock.Called(context1)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetChannelByID")
+		panic("no return value specified for ListAllChannels")
 	}
 
-	var r0 *alertmanagertypes.Channel
+	var r0 []*alertmanagertypes.Channel
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, valuer.UUID) (*alertmanagertypes.Channel, error)); ok {
-		return returnFunc(context1, s, uUID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*alertmanagertypes.Channel, error)); ok {
+		return returnFunc(context1)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, valuer.UUID) *alertmanagertypes.Channel); ok {
-		r0 = returnFunc(context1, s, uUID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*alertmanagertypes.Channel); ok {
+		r0 = returnFunc(context1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*alertmanagertypes.Channel)
+			r0 = ret.Get(0).([]*alertmanagertypes.Channel)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, valuer.UUID) error); ok {
-		r1 = returnFunc(context1, s, uUID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(context1)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockAlertmanager_GetChannelByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChannelByID'
-type MockAlertmanager_GetChannelByID_Call struct {
+// MockAlertmanager_ListAllChannels_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAllChannels'
+type MockAlertmanager_ListAllChannels_Call struct {
 	*mock.Call
 }
 
-// GetChannelByID is a helper method to define mock.On call
+// ListAllChannels is a helper method to define mock.On call
 //   - context1 context.Context
-//   - s string
-//   - uUID valuer.UUID
-func (_e *MockAlertmanager_Expecter) GetChannelByID(context1 interface{}, s interface{}, uUID interface{}) *MockAlertmanager_GetChannelByID_Call {
-	return &MockAlertmanager_GetChannelByID_Call{Call: _e.mock.On("GetChannelByID", context1, s, uUID)}
+func (_e *MockAlertmanager_Expecter) ListAllChannels(context1 interface{}) *MockAlertmanager_ListAllChannels_Call {
+	return &MockAlertmanager_ListAllChannels_Call{Call: _e.mock.On("ListAllChannels", context1)}
 }
 
-func (_c *MockAlertmanager_GetChannelByID_Call) Run(run func(context1 context.Context, s string, uUID valuer.UUID)) *MockAlertmanager_GetChannelByID_Call {
+func (_c *MockAlertmanager_ListAllChannels_Call) Run(run func(context1 context.Context)) *MockAlertmanager_ListAllChannels_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
-		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 valuer.UUID
-		if args[2] != nil {
-			arg2 = args[2].(valuer.UUID)
-		}
 		run(
 			arg0,
-			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockAlertmanager_GetChannelByID_Call) Return(channel *alertmanagertypes.Channel, err error) *MockAlertmanager_GetChannelByID_Call {
-	_c.Call.Return(channel, err)
+func (_c *MockAlertmanager_ListAllChannels_Call) Return(channels []*alertmanagertypes.Channel, err error) *MockAlertmanager_ListAllChannels_Call {
+	_c.Call.Return(channels, err)
 	return _c
 }
 
-func (_c *MockAlertmanager_GetChannelByID_Call) RunAndReturn(run func(context1 context.Context, s string, uUID valuer.UUID) (*alertmanagertypes.Channel, error)) *MockAlertmanager_GetChannelByID_Call {
+func (_c *MockAlertmanager_ListAllChannels_Call) RunAndReturn(run func(context1 context.Context) ([]*alertmanagertypes.Channel, error)) *MockAlertmanager_ListAllChannels_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetConfig provides a mock function for the type MockAlertmanager
-func (_mock *MockAlertmanager) GetConfig(context1 context.Context, s string) (*alertmanagertypes.Config, error) {
+// ListChannels provides a mock function for the type MockAlertmanager
+func (_mock *MockAlertmanager) ListChannels(context1 context.Context, s string) ([]*alertmanagertypes.Channel, error) {
 	ret := _mock.Called(context1, s)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetConfig")
+		panic("no return value specified for ListChannels")
 	}
 
-	var r0 *alertmanagertypes.Config
+	var r0 []*alertmanagertypes.Channel
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*alertmanagertypes.Config, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*alertmanagertypes.Channel, error)); ok {
 		return returnFunc(context1, s)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *alertmanagertypes.Config); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*alertmanagertypes.Channel); ok {
 		r0 = returnFunc(context1, s)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*alertmanagertypes.Config)
+			r0 = ret.Get(0).([]*alertmanagertypes.Channel)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
@@ -922,19 +1803,19 @@ func (_mock *MockAlertmanager) GetConfig(context1 context.Context, s string) (*a
 	return r0, r1
 }
 
-// MockAlertmanager_GetConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetConfig'
-type MockAlertmanager_GetConfig_Call struct {
+// MockAlertmanager_ListChannels_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListChannels'
+type MockAlertmanager_ListChannels_Call struct {
 	*mock.Call
 }
 
-// GetConfig is a helper method to define mock.On call
+// ListChannels is a helper method to define mock.On call
 //   - context1 context.Context
 //   - s string
-func (_e *MockAlertmanager_Expecter) GetConfig(context1 interface{}, s interface{}) *MockAlertmanager_GetConfig_Call {
-	return &MockAlertmanager_GetConfig_Call{Call: _e.mock.On("GetConfig", context1, s)}
+func (_e *MockAlertmanager_Expecter) ListChannels(context1 interface{}, s interface{}) *MockAlertmanager_ListChannels_Call {
+	return &MockAlertmanager_ListChannels_Call{Call: _e.mock.On("ListChannels", context1, s)}
 }
 
-func (_c *MockAlertmanager_GetConfig_Call) Run(run func(context1 context.Context, s string)) *MockAlertmanager_GetConfig_Call {
+func (_c *MockAlertmanager_ListChannels_Call) Run(run func(context1 context.Context, s string)) *MockAlertmanager_ListChannels_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -952,57 +1833,57 @@ func (_c *MockAlertmanager_GetConfig_Call) Run(run func(context1 context.Context
 	return _c
 }
 
-func (_c *MockAlertmanager_GetConfig_Call) Return(config1 *alertmanagertypes.Config, err error) *MockAlertmanager_GetConfig_Call {
-	_c.Call.Return(config1, err)
+func (_c *MockAlertmanager_ListChannels_Call) Return(channels []*alertmanagertypes.Channel, err error) *MockAlertmanager_ListChannels_Call {
+	_c.Call.Return(channels, err)
 	return _c
 }
 
-func (_c *MockAlertmanager_GetConfig_Call) RunAndReturn(run func(context1 context.Context, s string) (*alertmanagertypes.Config, error)) *MockAlertmanager_GetConfig_Call {
+func (_c *MockAlertmanager_ListChannels_Call) RunAndReturn(run func(context1 context.Context, s string) ([]*alertmanagertypes.Channel, error)) *MockAlertmanager_ListChannels_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetRoutePolicyByID provides a mock function for the type MockAlertmanager
-func (_mock *MockAlertmanager) GetRoutePolicyByID(ctx context.Context, routeID string) (*alertmanagertypes.GettableRoutePolicy, error) {
-	ret := _mock.Called(ctx, routeID)
+// ListDigests provides a mock function for the type MockAlertmanager
+func (_mock *MockAlertmanager) ListDigests(ctx context.Context, orgID string) (alertmanagertypes.GettableDigests, error) {
+	ret := _mock.Called(ctx, orgID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetRoutePolicyByID")
+		panic("no return value specified for ListDigests")
 	}
 
-	var r0 *alertmanagertypes.GettableRoutePolicy
+	var r0 alertmanagertypes.GettableDigests
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*alertmanagertypes.GettableRoutePolicy, error)); ok {
-		return returnFunc(ctx, routeID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (alertmanagertypes.GettableDigests, error)); ok {
+		return returnFunc(ctx, orgID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *alertmanagertypes.GettableRoutePolicy); ok {
-		r0 = returnFunc(ctx, routeID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) alertmanagertypes.GettableDigests); ok {
+		r0 = returnFunc(ctx, orgID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*alertmanagertypes.GettableRoutePolicy)
+			r0 = ret.Get(0).(alertmanagertypes.GettableDigests)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, routeID)
+		r1 = returnFunc(ctx, orgID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockAlertmanager_GetRoutePolicyByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRoutePolicyByID'
-type MockAlertmanager_GetRoutePolicyByID_Call struct {
+// MockAlertmanager_ListDigests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDigests'
+type MockAlertmanager_ListDigests_Call struct {
 	*mock.Call
 }
 
-// GetRoutePolicyByID is a helper method to define mock.On call
+// ListDigests is a helper method to define mock.On call
 //   - ctx context.Context
-//   - routeID string
-func (_e *MockAlertmanager_Expecter) GetRoutePolicyByID(ctx interface{}, routeID interface{}) *MockAlertmanager_GetRoutePolicyByID_Call {
-	return &MockAlertmanager_GetRoutePolicyByID_Call{Call: _e.mock.On("GetRoutePolicyByID", ctx, routeID)}
+//   - orgID string
+func (_e *MockAlertmanager_Expecter) ListDigests(ctx interface{}, orgID interface{}) *MockAlertmanager_ListDigests_Call {
+	return &MockAlertmanager_ListDigests_Call{Call: _e.mock.On("ListDigests", ctx, orgID)}
 }
 
-func (_c *MockAlertmanager_GetRoutePolicyByID_Call) Run(run func(ctx context.Context, routeID string)) *MockAlertmanager_GetRoutePolicyByID_Call {
+func (_c *MockAlertmanager_ListDigests_Call) Run(run func(ctx context.Context, orgID string)) *MockAlertmanager_ListDigests_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1020,119 +1901,200 @@ func (_c *MockAlertmanager_GetRoutePolicyByID_Call) Run(run func(ctx context.Con
 	return _c
 }
 
-func (_c *MockAlertmanager_GetRoutePolicyByID_Call) Return(gettableRoutePolicy *alertmanagertypes.GettableRoutePolicy, err error) *MockAlertmanager_GetRoutePolicyByID_Call {
-	_c.Call.Return(gettableRoutePolicy, err)
+func (_c *MockAlertmanager_ListDigests_Call) Return(gettableDigests alertmanagertypes.GettableDigests, err error) *MockAlertmanager_ListDigests_Call {
+	_c.Call.Return(gettableDigests, err)
 	return _c
 }
 
-func (_c *MockAlertmanager_GetRoutePolicyByID_Call) RunAndReturn(run func(ctx context.Context, routeID string) (*alertmanagertypes.GettableRoutePolicy, error)) *MockAlertmanager_GetRoutePolicyByID_Call {
+func (_c *MockAlertmanager_ListDigests_Call) RunAndReturn(run func(ctx context.Context, orgID string) (alertmanagertypes.GettableDigests, error)) *MockAlertmanager_ListDigests_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListAllChannels provides a mock function for the type MockAlertmanager
-func (_mock *MockAlertmanager) ListAllChannels(context1 context.Context) ([]*alertmanagertypes.Channel, error) {
-	ret := _mock.Called(context1)
+// ListSilences provides a mock function for the type MockAlertmanager
+func (_mock *MockAlertmanager) ListSilences(context1 context.Context, s string) (alertmanagertypes.GettableSilences, error) {
+	ret := _mock.Called(context1, s)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListAllChannels")
+		panic("no return value specified for ListSilences")
 	}
 
-	var r0 []*alertmanagertypes.Channel
+	var r0 alertmanagertypes.GettableSilences
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*alertmanagertypes.Channel, error)); ok {
-		return returnFunc(context1)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (alertmanagertypes.GettableSilences, error)); ok {
+		return returnFunc(context1, s)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) []*alertmanagertypes.Channel); ok {
-		r0 = returnFunc(context1)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) alertmanagertypes.GettableSilences); ok {
+		r0 = returnFunc(context1, s)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*alertmanagertypes.Channel)
+			r0 = ret.Get(0).(alertmanagertypes.GettableSilences)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = returnFunc(context1)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(context1, s)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockAlertmanager_ListAllChannels_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAllChannels'
-type MockAlertmanager_ListAllChannels_Call struct {
+// MockAlertmanager_ListSilences_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSilences'
+type MockAlertmanager_ListSilences_Call struct {
 	*mock.Call
 }
 
-// ListAllChannels is a helper method to define mock.On call
+// ListSilences is a helper method to define mock.On call
 //   - context1 context.Context
-func (_e *MockAlertmanager_Expecter) ListAllChannels(context1 interface{}) *MockAlertmanager_ListAllChannels_Call {
-	return &MockAlertmanager_ListAllChannels_Call{Call: _e.mock.On("ListAllChannels", context1)}
+//   - s string
+func (_e *MockAlertmanager_Expecter) ListSilences(context1 interface{}, s interface{}) *MockAlertmanager_ListSilences_Call {
+	return &MockAlertmanager_ListSilences_Call{Call: _e.mock.On("ListSilences", context1, s)}
 }
 
-func (_c *MockAlertmanager_ListAllChannels_Call) Run(run func(context1 context.Context)) *MockAlertmanager_ListAllChannels_Call {
+func (_c *MockAlertmanager_ListSilences_Call) Run(run func(context1 context.Context, s string)) *MockAlertmanager_ListSilences_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockAlertmanager_ListAllChannels_Call) Return(channels []*alertmanagertypes.Channel, err error) *MockAlertmanager_ListAllChannels_Call {
-	_c.Call.Return(channels, err)
+func (_c *MockAlertmanager_ListSilences_Call) Return(gettableSilences alertmanagertypes.GettableSilences, err error) *MockAlertmanager_ListSilences_Call {
+	_c.Call.Return(gettableSilences, err)
 	return _c
 }
 
-func (_c *MockAlertmanager_ListAllChannels_Call) RunAndReturn(run func(context1 context.Context) ([]*alertmanagertypes.Channel, error)) *MockAlertmanager_ListAllChannels_Call {
+func (_c *MockAlertmanager_ListSilences_Call) RunAndReturn(run func(context1 context.Context, s string) (alertmanagertypes.GettableSilences, error)) *MockAlertmanager_ListSilences_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListChannels provides a mock function for the type MockAlertmanager
-func (_mock *MockAlertmanager) ListChannels(context1 context.Context, s string) ([]*alertmanagertypes.Channel, error) {
-	ret := _mock.Called(context1, s)
+// ListWebhookDeliveries provides a mock function for the type MockAlertmanager
+func (_mock *MockAlertmanager) ListWebhookDeliveries(ctx context.Context, orgID string, params *alertmanagertypes.ListWebhookDeliveriesParams) (alertmanagertypes.GettableWebhookDeliveries, error) {
+	ret := _mock.Called(ctx, orgID, params)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListChannels")
+		panic("no return value specified for ListWebhookDeliveries")
 	}
 
-	var r0 []*alertmanagertypes.Channel
+	var r0 alertmanagertypes.GettableWebhookDeliveries
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*alertmanagertypes.Channel, error)); ok {
-		return returnFunc(context1, s)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *alertmanagertypes.ListWebhookDeliveriesParams) (alertmanagertypes.GettableWebhookDeliveries, error)); ok {
+		return returnFunc(ctx, orgID, params)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*alertmanagertypes.Channel); ok {
-		r0 = returnFunc(context1, s)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *alertmanagertypes.ListWebhookDeliveriesParams) alertmanagertypes.GettableWebhookDeliveries); ok {
+		r0 = returnFunc(ctx, orgID, params)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*alertmanagertypes.Channel)
+			r0 = ret.Get(0).(alertmanagertypes.GettableWebhookDeliveries)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(context1, s)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *alertmanagertypes.ListWebhookDeliveriesParams) error); ok {
+		r1 = returnFunc(ctx, orgID, params)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockAlertmanager_ListChannels_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListChannels'
-type MockAlertmanager_ListChannels_Call struct {
+// MockAlertmanager_ListWebhookDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWebhookDeliveries'
+type MockAlertmanager_ListWebhookDeliveries_Call struct {
 	*mock.Call
 }
 
-// ListChannels is a helper method to define mock.On call
+// ListWebhookDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID string
+//   - params *alertmanagertypes.ListWebhookDeliveriesParams
+func (_e *MockAlertmanager_Expecter) ListWebhookDeliveries(ctx interface{}, orgID interface{}, params interface{}) *MockAlertmanager_ListWebhookDeliveries_Call {
+	return &MockAlertmanager_ListWebhookDeliveries_Call{Call: _e.mock.On("ListWebhookDeliveries", ctx, orgID, params)}
+}
+
+func (_c *MockAlertmanager_ListWebhookDeliveries_Call) Run(run func(ctx context.Context, orgID string, params *alertmanagertypes.ListWebhookDeliveriesParams)) *MockAlertmanager_ListWebhookDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *alertmanagertypes.ListWebhookDeliveriesParams
+		if args[2] != nil {
+			arg2 = args[2].(*alertmanagertypes.ListWebhookDeliveriesParams)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAlertmanager_ListWebhookDeliveries_Call) Return(gettableWebhookDeliveries alertmanagertypes.GettableWebhookDeliveries, err error) *MockAlertmanager_ListWebhookDeliveries_Call {
+	_c.Call.Return(gettableWebhookDeliveries, err)
+	return _c
+}
+
+func (_c *MockAlertmanager_ListWebhookDeliveries_Call) RunAndReturn(run func(ctx context.Context, orgID string, params *alertmanagertypes.ListWebhookDeliveriesParams) (alertmanagertypes.GettableWebhookDeliveries, error)) *MockAlertmanager_ListWebhookDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PreviewSilence provides a mock function for the type MockAlertmanager
+func (_mock *MockAlertmanager) PreviewSilence(context1 context.Context, s string, postableSilence *alertmanagertypes.PostableSilence) (alertmanagertypes.GettableAlerts, error) {
+	ret := _mock.Called(context1, s, postableSilence)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PreviewSilence")
+	}
+
+	var r0 alertmanagertypes.GettableAlerts
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *alertmanagertypes.PostableSilence) (alertmanagertypes.GettableAlerts, error)); ok {
+		return returnFunc(context1, s, postableSilence)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *alertmanagertypes.PostableSilence) alertmanagertypes.GettableAlerts); ok {
+		r0 = returnFunc(context1, s, postableSilence)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(alertmanagertypes.GettableAlerts)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *alertmanagertypes.PostableSilence) error); ok {
+		r1 = returnFunc(context1, s, postableSilence)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAlertmanager_PreviewSilence_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PreviewSilence'
+type MockAlertmanager_PreviewSilence_Call struct {
+	*mock.Call
+}
+
+// PreviewSilence is a helper method to define mock.On call
 //   - context1 context.Context
 //   - s string
-func (_e *MockAlertmanager_Expecter) ListChannels(context1 interface{}, s interface{}) *MockAlertmanager_ListChannels_Call {
-	return &MockAlertmanager_ListChannels_Call{Call: _e.mock.On("ListChannels", context1, s)}
+//   - postableSilence *alertmanagertypes.PostableSilence
+func (_e *MockAlertmanager_Expecter) PreviewSilence(context1 interface{}, s interface{}, postableSilence interface{}) *MockAlertmanager_PreviewSilence_Call {
+	return &MockAlertmanager_PreviewSilence_Call{Call: _e.mock.On("PreviewSilence", context1, s, postableSilence)}
 }
 
-func (_c *MockAlertmanager_ListChannels_Call) Run(run func(context1 context.Context, s string)) *MockAlertmanager_ListChannels_Call {
+func (_c *MockAlertmanager_PreviewSilence_Call) Run(run func(context1 context.Context, s string, postableSilence *alertmanagertypes.PostableSilence)) *MockAlertmanager_PreviewSilence_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1142,20 +2104,25 @@ func (_c *MockAlertmanager_ListChannels_Call) Run(run func(context1 context.Cont
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 *alertmanagertypes.PostableSilence
+		if args[2] != nil {
+			arg2 = args[2].(*alertmanagertypes.PostableSilence)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockAlertmanager_ListChannels_Call) Return(channels []*alertmanagertypes.Channel, err error) *MockAlertmanager_ListChannels_Call {
-	_c.Call.Return(channels, err)
+func (_c *MockAlertmanager_PreviewSilence_Call) Return(gettableAlerts alertmanagertypes.GettableAlerts, err error) *MockAlertmanager_PreviewSilence_Call {
+	_c.Call.Return(gettableAlerts, err)
 	return _c
 }
 
-func (_c *MockAlertmanager_ListChannels_Call) RunAndReturn(run func(context1 context.Context, s string) ([]*alertmanagertypes.Channel, error)) *MockAlertmanager_ListChannels_Call {
+func (_c *MockAlertmanager_PreviewSilence_Call) RunAndReturn(run func(context1 context.Context, s string, postableSilence *alertmanagertypes.PostableSilence) (alertmanagertypes.GettableAlerts, error)) *MockAlertmanager_PreviewSilence_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -1223,6 +2190,80 @@ func (_c *MockAlertmanager_PutAlerts_Call) RunAndReturn(run func(context1 contex
 	return _c
 }
 
+// ResendWebhookDelivery provides a mock function for the type MockAlertmanager
+func (_mock *MockAlertmanager) ResendWebhookDelivery(ctx context.Context, orgID string, id valuer.UUID) (*alertmanagertypes.GettableWebhookDelivery, error) {
+	ret := _mock.Called(ctx, orgID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResendWebhookDelivery")
+	}
+
+	var r0 *alertmanagertypes.GettableWebhookDelivery
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, valuer.UUID) (*alertmanagertypes.GettableWebhookDelivery, error)); ok {
+		return returnFunc(ctx, orgID, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, valuer.UUID) *alertmanagertypes.GettableWebhookDelivery); ok {
+		r0 = returnFunc(ctx, orgID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*alertmanagertypes.GettableWebhookDelivery)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, valuer.UUID) error); ok {
+		r1 = returnFunc(ctx, orgID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAlertmanager_ResendWebhookDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResendWebhookDelivery'
+type MockAlertmanager_ResendWebhookDelivery_Call struct {
+	*mock.Call
+}
+
+// ResendWebhookDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID string
+//   - id valuer.UUID
+func (_e *MockAlertmanager_Expecter) ResendWebhookDelivery(ctx interface{}, orgID interface{}, id interface{}) *MockAlertmanager_ResendWebhookDelivery_Call {
+	return &MockAlertmanager_ResendWebhookDelivery_Call{Call: _e.mock.On("ResendWebhookDelivery", ctx, orgID, id)}
+}
+
+func (_c *MockAlertmanager_ResendWebhookDelivery_Call) Run(run func(ctx context.Context, orgID string, id valuer.UUID)) *MockAlertmanager_ResendWebhookDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 valuer.UUID
+		if args[2] != nil {
+			arg2 = args[2].(valuer.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAlertmanager_ResendWebhookDelivery_Call) Return(gettableWebhookDelivery *alertmanagertypes.GettableWebhookDelivery, err error) *MockAlertmanager_ResendWebhookDelivery_Call {
+	_c.Call.Return(gettableWebhookDelivery, err)
+	return _c
+}
+
+func (_c *MockAlertmanager_ResendWebhookDelivery_Call) RunAndReturn(run func(ctx context.Context, orgID string, id valuer.UUID) (*alertmanagertypes.GettableWebhookDelivery, error)) *MockAlertmanager_ResendWebhookDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SetConfig provides a mock function for the type MockAlertmanager
 func (_mock *MockAlertmanager) SetConfig(context1 context.Context, config1 *alertmanagertypes.Config) error {
 	ret := _mock.Called(context1, config1)
@@ -1406,6 +2447,164 @@ func (_c *MockAlertmanager_SetNotificationConfig_Call) RunAndReturn(run func(ctx
 	return _c
 }
 
+// SetDigest provides a mock function for the type MockAlertmanager
+func (_mock *MockAlertmanager) SetDigest(ctx context.Context, orgID string, updatedBy string, digest *alertmanagertypes.PostableDigest) (*alertmanagertypes.GettableDigest, error) {
+	ret := _mock.Called(ctx, orgID, updatedBy, digest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDigest")
+	}
+
+	var r0 *alertmanagertypes.GettableDigest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *alertmanagertypes.PostableDigest) (*alertmanagertypes.GettableDigest, error)); ok {
+		return returnFunc(ctx, orgID, updatedBy, digest)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *alertmanagertypes.PostableDigest) *alertmanagertypes.GettableDigest); ok {
+		r0 = returnFunc(ctx, orgID, updatedBy, digest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*alertmanagertypes.GettableDigest)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *alertmanagertypes.PostableDigest) error); ok {
+		r1 = returnFunc(ctx, orgID, updatedBy, digest)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAlertmanager_SetDigest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetDigest'
+type MockAlertmanager_SetDigest_Call struct {
+	*mock.Call
+}
+
+// SetDigest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID string
+//   - updatedBy string
+//   - digest *alertmanagertypes.PostableDigest
+func (_e *MockAlertmanager_Expecter) SetDigest(ctx interface{}, orgID interface{}, updatedBy interface{}, digest interface{}) *MockAlertmanager_SetDigest_Call {
+	return &MockAlertmanager_SetDigest_Call{Call: _e.mock.On("SetDigest", ctx, orgID, updatedBy, digest)}
+}
+
+func (_c *MockAlertmanager_SetDigest_Call) Run(run func(ctx context.Context, orgID string, updatedBy string, digest *alertmanagertypes.PostableDigest)) *MockAlertmanager_SetDigest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *alertmanagertypes.PostableDigest
+		if args[3] != nil {
+			arg3 = args[3].(*alertmanagertypes.PostableDigest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAlertmanager_SetDigest_Call) Return(gettableDigest *alertmanagertypes.GettableDigest, err error) *MockAlertmanager_SetDigest_Call {
+	_c.Call.Return(gettableDigest, err)
+	return _c
+}
+
+func (_c *MockAlertmanager_SetDigest_Call) RunAndReturn(run func(ctx context.Context, orgID string, updatedBy string, digest *alertmanagertypes.PostableDigest) (*alertmanagertypes.GettableDigest, error)) *MockAlertmanager_SetDigest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetSilence provides a mock function for the type MockAlertmanager
+func (_mock *MockAlertmanager) SetSilence(ctx context.Context, orgID string, createdBy string, silence *alertmanagertypes.PostableSilence) (string, error) {
+	ret := _mock.Called(ctx, orgID, createdBy, silence)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSilence")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *alertmanagertypes.PostableSilence) (string, error)); ok {
+		return returnFunc(ctx, orgID, createdBy, silence)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *alertmanagertypes.PostableSilence) string); ok {
+		r0 = returnFunc(ctx, orgID, createdBy, silence)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *alertmanagertypes.PostableSilence) error); ok {
+		r1 = returnFunc(ctx, orgID, createdBy, silence)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAlertmanager_SetSilence_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetSilence'
+type MockAlertmanager_SetSilence_Call struct {
+	*mock.Call
+}
+
+// SetSilence is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID string
+//   - createdBy string
+//   - silence *alertmanagertypes.PostableSilence
+func (_e *MockAlertmanager_Expecter) SetSilence(ctx interface{}, orgID interface{}, createdBy interface{}, silence interface{}) *MockAlertmanager_SetSilence_Call {
+	return &MockAlertmanager_SetSilence_Call{Call: _e.mock.On("SetSilence", ctx, orgID, createdBy, silence)}
+}
+
+func (_c *MockAlertmanager_SetSilence_Call) Run(run func(ctx context.Context, orgID string, createdBy string, silence *alertmanagertypes.PostableSilence)) *MockAlertmanager_SetSilence_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *alertmanagertypes.PostableSilence
+		if args[3] != nil {
+			arg3 = args[3].(*alertmanagertypes.PostableSilence)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAlertmanager_SetSilence_Call) Return(s string, err error) *MockAlertmanager_SetSilence_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockAlertmanager_SetSilence_Call) RunAndReturn(run func(ctx context.Context, orgID string, createdBy string, silence *alertmanagertypes.PostableSilence) (string, error)) *MockAlertmanager_SetSilence_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Start provides a mock function for the type MockAlertmanager
 func (_mock *MockAlertmanager) Start(context1 context.Context) error {
 	ret := _mock.Called(context1)
@@ -1640,6 +2839,69 @@ func (_c *MockAlertmanager_TestReceiver_Call) RunAndReturn(run func(context1 con
 	return _c
 }
 
+// Unacknowledge provides a mock function for the type MockAlertmanager
+func (_mock *MockAlertmanager) Unacknowledge(ctx context.Context, orgID string, acknowledgement *alertmanagertypes.PostableAcknowledgement) error {
+	ret := _mock.Called(ctx, orgID, acknowledgement)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unacknowledge")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *alertmanagertypes.PostableAcknowledgement) error); ok {
+		r0 = returnFunc(ctx, orgID, acknowledgement)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAlertmanager_Unacknowledge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unacknowledge'
+type MockAlertmanager_Unacknowledge_Call struct {
+	*mock.Call
+}
+
+// Unacknowledge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID string
+//   - acknowledgement *alertmanagertypes.PostableAcknowledgement
+func (_e *MockAlertmanager_Expecter) Unacknowledge(ctx interface{}, orgID interface{}, acknowledgement interface{}) *MockAlertmanager_Unacknowledge_Call {
+	return &MockAlertmanager_Unacknowledge_Call{Call: _e.mock.On("Unacknowledge", ctx, orgID, acknowledgement)}
+}
+
+func (_c *MockAlertmanager_Unacknowledge_Call) Run(run func(ctx context.Context, orgID string, acknowledgement *alertmanagertypes.PostableAcknowledgement)) *MockAlertmanager_Unacknowledge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *alertmanagertypes.PostableAcknowledgement
+		if args[2] != nil {
+			arg2 = args[2].(*alertmanagertypes.PostableAcknowledgement)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAlertmanager_Unacknowledge_Call) Return(err error) *MockAlertmanager_Unacknowledge_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAlertmanager_Unacknowledge_Call) RunAndReturn(run func(ctx context.Context, orgID string, acknowledgement *alertmanagertypes.PostableAcknowledgement) error) *MockAlertmanager_Unacknowledge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateAllRoutePoliciesByRuleId provides a mock function for the type MockAlertmanager
 func (_mock *MockAlertmanager) UpdateAllRoutePoliciesByRuleId(ctx context.Context, ruleId string, routes []*alertmanagertypes.PostableRoutePolicy) error {
 	ret := _mock.Called(ctx, ruleId, routes)
@@ -1772,6 +3034,80 @@ func (_c *MockAlertmanager_UpdateChannelByReceiverAndID_Call) RunAndReturn(run f
 	return _c
 }
 
+// UpdateEscalationPolicyByID provides a mock function for the type MockAlertmanager
+func (_mock *MockAlertmanager) UpdateEscalationPolicyByID(ctx context.Context, policyID string, policy *alertmanagertypes.PostableEscalationPolicy) (*alertmanagertypes.GettableEscalationPolicy, error) {
+	ret := _mock.Called(ctx, policyID, policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateEscalationPolicyByID")
+	}
+
+	var r0 *alertmanagertypes.GettableEscalationPolicy
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *alertmanagertypes.PostableEscalationPolicy) (*alertmanagertypes.GettableEscalationPolicy, error)); ok {
+		return returnFunc(ctx, policyID, policy)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *alertmanagertypes.PostableEscalationPolicy) *alertmanagertypes.GettableEscalationPolicy); ok {
+		r0 = returnFunc(ctx, policyID, policy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*alertmanagertypes.GettableEscalationPolicy)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *alertmanagertypes.PostableEscalationPolicy) error); ok {
+		r1 = returnFunc(ctx, policyID, policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAlertmanager_UpdateEscalationPolicyByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateEscalationPolicyByID'
+type MockAlertmanager_UpdateEscalationPolicyByID_Call struct {
+	*mock.Call
+}
+
+// UpdateEscalationPolicyByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - policyID string
+//   - policy *alertmanagertypes.PostableEscalationPolicy
+func (_e *MockAlertmanager_Expecter) UpdateEscalationPolicyByID(ctx interface{}, policyID interface{}, policy interface{}) *MockAlertmanager_UpdateEscalationPolicyByID_Call {
+	return &MockAlertmanager_UpdateEscalationPolicyByID_Call{Call: _e.mock.On("UpdateEscalationPolicyByID", ctx, policyID, policy)}
+}
+
+func (_c *MockAlertmanager_UpdateEscalationPolicyByID_Call) Run(run func(ctx context.Context, policyID string, policy *alertmanagertypes.PostableEscalationPolicy)) *MockAlertmanager_UpdateEscalationPolicyByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *alertmanagertypes.PostableEscalationPolicy
+		if args[2] != nil {
+			arg2 = args[2].(*alertmanagertypes.PostableEscalationPolicy)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAlertmanager_UpdateEscalationPolicyByID_Call) Return(gettableEscalationPolicy *alertmanagertypes.GettableEscalationPolicy, err error) *MockAlertmanager_UpdateEscalationPolicyByID_Call {
+	_c.Call.Return(gettableEscalationPolicy, err)
+	return _c
+}
+
+func (_c *MockAlertmanager_UpdateEscalationPolicyByID_Call) RunAndReturn(run func(ctx context.Context, policyID string, policy *alertmanagertypes.PostableEscalationPolicy) (*alertmanagertypes.GettableEscalationPolicy, error)) *MockAlertmanager_UpdateEscalationPolicyByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateRoutePolicyByID provides a mock function for the type MockAlertmanager
 func (_mock *MockAlertmanager) UpdateRoutePolicyByID(ctx context.Context, routeID string, route *alertmanagertypes.PostableRoutePolicy) (*alertmanagertypes.GettableRoutePolicy, error) {
 	ret := _mock.Called(ctx, routeID, route)
@@ -73,6 +73,11 @@ func (api *API) TestReceiver(rw http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	if err := alertmanagertypes.ValidateReceiver(receiver); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
 	err = api.alertmanager.TestReceiver(ctx, claims.OrgID, receiver)
 	if err != nil {
 		render.Error(rw, err)
@@ -98,12 +103,14 @@ func (api *API) ListChannels(rw http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	// This ensures that the UI receives an empty array instead of null
-	if len(channels) == 0 {
-		channels = make([]*alertmanagertypes.Channel, 0)
+	// The gettable channels are never nil, which ensures that the UI receives an empty array instead of null
+	gettableChannels, err := alertmanagertypes.NewGettableChannelsFromChannels(channels)
+	if err != nil {
+		render.Error(rw, err)
+		return
 	}
 
-	render.Success(rw, http.StatusOK, channels)
+	render.Success(rw, http.StatusOK, gettableChannels)
 }
 
 func (api *API) ListAllChannels(rw http.ResponseWriter, req *http.Request) {
@@ -153,7 +160,13 @@ func (api *API) GetChannelByID(rw http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	render.Success(rw, http.StatusOK, channel)
+	gettableChannel, err := alertmanagertypes.NewGettableChannelFromChannel(channel)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, gettableChannel)
 }
 
 func (api *API) UpdateChannelByID(rw http.ResponseWriter, req *http.Request) {
@@ -197,6 +210,11 @@ func (api *API) UpdateChannelByID(rw http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	if err := alertmanagertypes.ValidateReceiver(receiver); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
 	err = api.alertmanager.UpdateChannelByReceiverAndID(ctx, claims.OrgID, receiver, id)
 	if err != nil {
 		render.Error(rw, err)
@@ -266,13 +284,24 @@ func (api *API) CreateChannel(rw http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	if err := alertmanagertypes.ValidateReceiver(receiver); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
 	channel, err := api.alertmanager.CreateChannel(ctx, claims.OrgID, receiver)
 	if err != nil {
 		render.Error(rw, err)
 		return
 	}
 
-	render.Success(rw, http.StatusCreated, channel)
+	gettableChannel, err := alertmanagertypes.NewGettableChannelFromChannel(channel)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusCreated, gettableChannel)
 }
 
 func (api *API) CreateRoutePolicy(rw http.ResponseWriter, req *http.Request) {
@@ -399,3 +428,503 @@ func (api *API) UpdateRoutePolicy(rw http.ResponseWriter, req *http.Request) {
 	}
 	render.Success(rw, http.StatusOK, result)
 }
+
+func (api *API) CreateEscalationPolicy(rw http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 30*time.Second)
+	defer cancel()
+
+	policy, err := readPostableEscalationPolicy(req)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	result, err := api.alertmanager.CreateEscalationPolicy(ctx, policy)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusCreated, result)
+}
+
+func (api *API) GetAllEscalationPolicies(rw http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 30*time.Second)
+	defer cancel()
+
+	policies, err := api.alertmanager.GetAllEscalationPolicies(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, policies)
+}
+
+func (api *API) GetEscalationPolicyByID(rw http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 30*time.Second)
+	defer cancel()
+
+	policyID := mux.Vars(req)["id"]
+	if policyID == "" {
+		render.Error(rw, errors.NewInvalidInputf(errors.CodeInvalidInput, "policy ID is required"))
+		return
+	}
+
+	policy, err := api.alertmanager.GetEscalationPolicyByID(ctx, policyID)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, policy)
+}
+
+func (api *API) UpdateEscalationPolicy(rw http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 30*time.Second)
+	defer cancel()
+
+	policyID := mux.Vars(req)["id"]
+	if policyID == "" {
+		render.Error(rw, errors.NewInvalidInputf(errors.CodeInvalidInput, "policy ID is required"))
+		return
+	}
+
+	policy, err := readPostableEscalationPolicy(req)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	result, err := api.alertmanager.UpdateEscalationPolicyByID(ctx, policyID, policy)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, result)
+}
+
+func (api *API) DeleteEscalationPolicyByID(rw http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 30*time.Second)
+	defer cancel()
+
+	policyID := mux.Vars(req)["id"]
+	if policyID == "" {
+		render.Error(rw, errors.NewInvalidInputf(errors.CodeInvalidInput, "policy ID is required"))
+		return
+	}
+
+	if err := api.alertmanager.DeleteEscalationPolicyByID(ctx, policyID); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusNoContent, nil)
+}
+
+func (api *API) ListSilences(rw http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 30*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	silences, err := api.alertmanager.ListSilences(ctx, claims.OrgID)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, silences)
+}
+
+func (api *API) GetSilence(rw http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 30*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	silence, err := api.alertmanager.GetSilence(ctx, claims.OrgID, mux.Vars(req)["id"])
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, silence)
+}
+
+func (api *API) CreateSilence(rw http.ResponseWriter, req *http.Request) {
+	api.setSilence(rw, req, "", http.StatusCreated)
+}
+
+func (api *API) UpdateSilence(rw http.ResponseWriter, req *http.Request) {
+	api.setSilence(rw, req, mux.Vars(req)["id"], http.StatusOK)
+}
+
+// setSilence creates or updates a silence, the user making the request is recorded as the creator of the silence or as
+// the user who last updated it.
+func (api *API) setSilence(rw http.ResponseWriter, req *http.Request, id string, status int) {
+	ctx, cancel := context.WithTimeout(req.Context(), 30*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+	defer req.Body.Close() //nolint:errcheck
+
+	var postable alertmanagertypes.PostableSilence
+	if err := json.Unmarshal(body, &postable); err != nil {
+		render.Error(rw, errors.Wrapf(err, errors.TypeInvalidInput, alertmanagertypes.ErrCodeAlertmanagerSilenceInvalid, "invalid silence"))
+		return
+	}
+	postable.ID = id
+
+	silenceID, err := api.alertmanager.SetSilence(ctx, claims.OrgID, claims.Email, &postable)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	silence, err := api.alertmanager.GetSilence(ctx, claims.OrgID, silenceID)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, status, silence)
+}
+
+func (api *API) ExpireSilence(rw http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 30*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	if err := api.alertmanager.ExpireSilence(ctx, claims.OrgID, mux.Vars(req)["id"]); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusNoContent, nil)
+}
+
+// PreviewSilence lists the active alerts which would be muted by the silence in the body, before creating it.
+func (api *API) PreviewSilence(rw http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 30*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+	defer req.Body.Close() //nolint:errcheck
+
+	var postable alertmanagertypes.PostableSilence
+	if err := json.Unmarshal(body, &postable); err != nil {
+		render.Error(rw, errors.Wrapf(err, errors.TypeInvalidInput, alertmanagertypes.ErrCodeAlertmanagerSilenceInvalid, "invalid silence"))
+		return
+	}
+
+	alerts, err := api.alertmanager.PreviewSilence(ctx, claims.OrgID, &postable)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, alerts)
+}
+
+func (api *API) ListAcknowledgements(rw http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 30*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	acknowledgements, err := api.alertmanager.ListAcknowledgements(ctx, claims.OrgID)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, acknowledgements)
+}
+
+// Acknowledge acknowledges the group of the alert with the labels in the body, the user making the request is
+// recorded as the one who acknowledged it.
+func (api *API) Acknowledge(rw http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 30*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	postable, err := readPostableAcknowledgement(req)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	acknowledgement, err := api.alertmanager.Acknowledge(ctx, claims.OrgID, claims.Email, postable)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, acknowledgement)
+}
+
+func (api *API) Unacknowledge(rw http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 30*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	postable, err := readPostableAcknowledgement(req)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	if err := api.alertmanager.Unacknowledge(ctx, claims.OrgID, postable); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusNoContent, nil)
+}
+
+// Assign assigns the group of the alert with the labels in the body to the assignee in the body, the user making the
+// request is recorded as the one who assigned it.
+func (api *API) Assign(rw http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 30*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	postable, err := readPostableAcknowledgement(req)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	acknowledgement, err := api.alertmanager.Assign(ctx, claims.OrgID, claims.Email, postable)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, acknowledgement)
+}
+
+// ListWebhookDeliveries lists the latest deliveries of the signoz webhooks, filtered by the receiver and status query
+// parameters.
+func (api *API) ListWebhookDeliveries(rw http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 30*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	params, err := alertmanagertypes.NewListWebhookDeliveriesParams(req.URL.Query())
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	deliveries, err := api.alertmanager.ListWebhookDeliveries(ctx, claims.OrgID, params)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, deliveries)
+}
+
+// ResendWebhookDelivery queues a failed delivery to be re-sent and returns it, pending until it is delivered.
+func (api *API) ResendWebhookDelivery(rw http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 30*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	idString, ok := mux.Vars(req)["id"]
+	if !ok {
+		render.Error(rw, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "id is required in path"))
+		return
+	}
+
+	id, err := valuer.NewUUID(idString)
+	if err != nil {
+		render.Error(rw, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "id is not a valid uuid-v7"))
+		return
+	}
+
+	delivery, err := api.alertmanager.ResendWebhookDelivery(ctx, claims.OrgID, id)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusAccepted, delivery)
+}
+
+func (api *API) ListDigests(rw http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 30*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	digests, err := api.alertmanager.ListDigests(ctx, claims.OrgID)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, digests)
+}
+
+// SetDigest creates the digest of a channel or replaces the existing one.
+func (api *API) SetDigest(rw http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 30*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+	defer req.Body.Close() //nolint:errcheck
+
+	var postable alertmanagertypes.PostableDigest
+	if err := json.Unmarshal(body, &postable); err != nil {
+		render.Error(rw, errors.Wrapf(err, errors.TypeInvalidInput, alertmanagertypes.ErrCodeAlertmanagerDigestInvalid, "invalid digest"))
+		return
+	}
+
+	digest, err := api.alertmanager.SetDigest(ctx, claims.OrgID, claims.Email, &postable)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, digest)
+}
+
+func (api *API) DeleteDigest(rw http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 30*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	idString, ok := mux.Vars(req)["id"]
+	if !ok {
+		render.Error(rw, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "id is required in path"))
+		return
+	}
+
+	id, err := valuer.NewUUID(idString)
+	if err != nil {
+		render.Error(rw, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "id is not a valid uuid-v7"))
+		return
+	}
+
+	if err := api.alertmanager.DeleteDigest(ctx, claims.OrgID, id); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusNoContent, nil)
+}
+
+func readPostableAcknowledgement(req *http.Request) (*alertmanagertypes.PostableAcknowledgement, error) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close() //nolint:errcheck
+
+	var postable alertmanagertypes.PostableAcknowledgement
+	if err := json.Unmarshal(body, &postable); err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInvalidInput, alertmanagertypes.ErrCodeAlertmanagerAcknowledgementInvalid, "invalid acknowledgement")
+	}
+
+	return &postable, nil
+}
+
+func readPostableEscalationPolicy(req *http.Request) (*alertmanagertypes.PostableEscalationPolicy, error) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	defer req.Body.Close() //nolint:errcheck
+
+	var postable alertmanagertypes.PostableEscalationPolicy
+	if err := json.Unmarshal(body, &postable); err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInvalidInput, alertmanagertypes.ErrCodeEscalationPolicyInvalid, "invalid escalation policy")
+	}
+
+	return &postable, nil
+}
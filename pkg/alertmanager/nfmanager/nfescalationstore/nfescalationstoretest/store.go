@@ -0,0 +1,150 @@
+package nfescalationstoretest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+)
+
+var _ alertmanagertypes.EscalationStore = (*Store)(nil)
+
+// Store is an in-memory escalation store.
+type Store struct {
+	policies    map[string]*alertmanagertypes.EscalationPolicy
+	escalations map[string]*alertmanagertypes.Escalation
+	mtx         sync.RWMutex
+}
+
+func NewStore() *Store {
+	return &Store{
+		policies:    make(map[string]*alertmanagertypes.EscalationPolicy),
+		escalations: make(map[string]*alertmanagertypes.Escalation),
+	}
+}
+
+func policyKey(orgID string, id string) string {
+	return orgID + "/" + id
+}
+
+func escalationKey(escalation *alertmanagertypes.Escalation) string {
+	return escalation.OrgID + "/" + escalation.RuleID + "/" + escalation.GroupFingerprint + "/" + escalation.PolicyID
+}
+
+func (s *Store) GetPolicyByID(ctx context.Context, orgID string, id string) (*alertmanagertypes.EscalationPolicy, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	policy, ok := s.policies[policyKey(orgID, id)]
+	if !ok {
+		return nil, errors.Newf(errors.TypeNotFound, alertmanagertypes.ErrCodeEscalationPolicyNotFound, "escalation policy with ID: %s does not exist", id)
+	}
+
+	copied := *policy
+	return &copied, nil
+}
+
+func (s *Store) GetAllPolicies(ctx context.Context, orgID string) ([]*alertmanagertypes.EscalationPolicy, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	policies := []*alertmanagertypes.EscalationPolicy{}
+	for _, policy := range s.policies {
+		if policy.OrgID == orgID {
+			copied := *policy
+			policies = append(policies, &copied)
+		}
+	}
+
+	return policies, nil
+}
+
+func (s *Store) CreatePolicy(ctx context.Context, policy *alertmanagertypes.EscalationPolicy) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	copied := *policy
+	s.policies[policyKey(policy.OrgID, policy.ID.StringValue())] = &copied
+	return nil
+}
+
+func (s *Store) UpdatePolicy(ctx context.Context, policy *alertmanagertypes.EscalationPolicy) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	key := policyKey(policy.OrgID, policy.ID.StringValue())
+	if _, ok := s.policies[key]; !ok {
+		return errors.Newf(errors.TypeNotFound, alertmanagertypes.ErrCodeEscalationPolicyNotFound, "escalation policy with ID: %s does not exist", policy.ID)
+	}
+
+	copied := *policy
+	s.policies[key] = &copied
+	return nil
+}
+
+func (s *Store) DeletePolicy(ctx context.Context, orgID string, id string) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	for key, escalation := range s.escalations {
+		if escalation.OrgID == orgID && escalation.PolicyID == id {
+			delete(s.escalations, key)
+		}
+	}
+
+	delete(s.policies, policyKey(orgID, id))
+	return nil
+}
+
+func (s *Store) GetAll(ctx context.Context, orgID string) ([]*alertmanagertypes.Escalation, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	escalations := []*alertmanagertypes.Escalation{}
+	for _, escalation := range s.escalations {
+		if escalation.OrgID == orgID {
+			copied := *escalation
+			escalations = append(escalations, &copied)
+		}
+	}
+
+	return escalations, nil
+}
+
+func (s *Store) GetAllByGroup(ctx context.Context, orgID string, ruleID string, groupFingerprint string) ([]*alertmanagertypes.Escalation, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	escalations := []*alertmanagertypes.Escalation{}
+	for _, escalation := range s.escalations {
+		if escalation.OrgID == orgID && escalation.RuleID == ruleID && escalation.GroupFingerprint == groupFingerprint {
+			copied := *escalation
+			escalations = append(escalations, &copied)
+		}
+	}
+
+	return escalations, nil
+}
+
+func (s *Store) Set(ctx context.Context, escalation *alertmanagertypes.Escalation) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	copied := *escalation
+	s.escalations[escalationKey(escalation)] = &copied
+	return nil
+}
+
+func (s *Store) DeleteAllByGroup(ctx context.Context, orgID string, ruleID string, groupFingerprint string) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	for key, escalation := range s.escalations {
+		if escalation.OrgID == orgID && escalation.RuleID == ruleID && escalation.GroupFingerprint == groupFingerprint {
+			delete(s.escalations, key)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package sqlescalationstore
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+)
+
+type store struct {
+	sqlstore sqlstore.SQLStore
+}
+
+func NewStore(sqlstore sqlstore.SQLStore) alertmanagertypes.EscalationStore {
+	return &store{
+		sqlstore: sqlstore,
+	}
+}
+
+func (store *store) GetPolicyByID(ctx context.Context, orgID string, id string) (*alertmanagertypes.EscalationPolicy, error) {
+	policy := new(alertmanagertypes.EscalationPolicy)
+	err := store.sqlstore.BunDBCtx(ctx).NewSelect().Model(policy).Where("id = ?", id).Where("org_id = ?", orgID).Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, store.sqlstore.WrapNotFoundErrf(err, alertmanagertypes.ErrCodeEscalationPolicyNotFound, "escalation policy with ID: %s does not exist", id)
+		}
+		return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "unable to fetch escalation policy with ID: %s", id)
+	}
+
+	return policy, nil
+}
+
+func (store *store) GetAllPolicies(ctx context.Context, orgID string) ([]*alertmanagertypes.EscalationPolicy, error) {
+	policies := make([]*alertmanagertypes.EscalationPolicy, 0)
+	err := store.sqlstore.BunDBCtx(ctx).NewSelect().Model(&policies).Where("org_id = ?", orgID).Order("name ASC").Scan(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "unable to fetch escalation policies for orgID: %s", orgID)
+	}
+
+	return policies, nil
+}
+
+func (store *store) CreatePolicy(ctx context.Context, policy *alertmanagertypes.EscalationPolicy) error {
+	_, err := store.sqlstore.BunDBCtx(ctx).NewInsert().Model(policy).Exec(ctx)
+	if err != nil {
+		return errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "error creating escalation policy with ID: %s", policy.ID)
+	}
+
+	return nil
+}
+
+func (store *store) UpdatePolicy(ctx context.Context, policy *alertmanagertypes.EscalationPolicy) error {
+	result, err := store.sqlstore.BunDBCtx(ctx).NewUpdate().Model(policy).WherePK().Where("org_id = ?", policy.OrgID).ExcludeColumn("id", "org_id", "created_at", "created_by").Exec(ctx)
+	if err != nil {
+		return errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "unable to update escalation policy with ID: %s", policy.ID)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "unable to update escalation policy with ID: %s", policy.ID)
+	}
+
+	if rows == 0 {
+		return errors.Newf(errors.TypeNotFound, alertmanagertypes.ErrCodeEscalationPolicyNotFound, "escalation policy with ID: %s does not exist", policy.ID)
+	}
+
+	return nil
+}
+
+func (store *store) DeletePolicy(ctx context.Context, orgID string, id string) error {
+	return store.sqlstore.RunInTxCtx(ctx, nil, func(ctx context.Context) error {
+		_, err := store.sqlstore.BunDBCtx(ctx).NewDelete().Model((*alertmanagertypes.Escalation)(nil)).Where("org_id = ?", orgID).Where("policy_id = ?", id).Exec(ctx)
+		if err != nil {
+			return errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "unable to delete escalations by escalation policy with ID: %s", id)
+		}
+
+		_, err = store.sqlstore.BunDBCtx(ctx).NewDelete().Model((*alertmanagertypes.EscalationPolicy)(nil)).Where("org_id = ?", orgID).Where("id = ?", id).Exec(ctx)
+		if err != nil {
+			return errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "unable to delete escalation policy with ID: %s", id)
+		}
+
+		return nil
+	})
+}
+
+func (store *store) GetAll(ctx context.Context, orgID string) ([]*alertmanagertypes.Escalation, error) {
+	escalations := make([]*alertmanagertypes.Escalation, 0)
+	err := store.sqlstore.BunDBCtx(ctx).NewSelect().Model(&escalations).Where("org_id = ?", orgID).Scan(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "unable to fetch escalations of org %s", orgID)
+	}
+
+	return escalations, nil
+}
+
+func (store *store) GetAllByGroup(ctx context.Context, orgID string, ruleID string, groupFingerprint string) ([]*alertmanagertypes.Escalation, error) {
+	escalations := make([]*alertmanagertypes.Escalation, 0)
+	err := store.sqlstore.BunDBCtx(ctx).NewSelect().Model(&escalations).Where("org_id = ?", orgID).Where("rule_id = ?", ruleID).Where("group_fingerprint = ?", groupFingerprint).Scan(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "unable to fetch escalations of group %s of rule %s", groupFingerprint, ruleID)
+	}
+
+	return escalations, nil
+}
+
+func (store *store) Set(ctx context.Context, escalation *alertmanagertypes.Escalation) error {
+	_, err := store.
+		sqlstore.
+		BunDBCtx(ctx).
+		NewInsert().
+		Model(escalation).
+		On("CONFLICT (org_id, rule_id, group_fingerprint, policy_id) DO UPDATE").
+		Set("step = EXCLUDED.step").
+		Set("repetition = EXCLUDED.repetition").
+		Set("next_at = EXCLUDED.next_at").
+		Set("notified_at = EXCLUDED.notified_at").
+		Set("updated_at = EXCLUDED.updated_at").
+		Exec(ctx)
+	if err != nil {
+		return errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "unable to set escalation of group %s of rule %s", escalation.GroupFingerprint, escalation.RuleID)
+	}
+
+	return nil
+}
+
+func (store *store) DeleteAllByGroup(ctx context.Context, orgID string, ruleID string, groupFingerprint string) error {
+	_, err := store.sqlstore.BunDBCtx(ctx).NewDelete().Model((*alertmanagertypes.Escalation)(nil)).Where("org_id = ?", orgID).Where("rule_id = ?", ruleID).Where("group_fingerprint = ?", groupFingerprint).Exec(ctx)
+	if err != nil {
+		return errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "unable to delete escalations of group %s of rule %s", groupFingerprint, ruleID)
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package nfmanagertest
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
@@ -15,6 +16,9 @@ type MockNotificationManager struct {
 	routes       map[string]*alertmanagertypes.RoutePolicy
 	routesByName map[string][]*alertmanagertypes.RoutePolicy
 	errors       map[string]error
+	policies     map[string]*alertmanagertypes.EscalationPolicy
+	escalations  map[string][]string
+	stopped      map[string]bool
 }
 
 // NewMock creates a new mock notification manager
@@ -24,6 +28,9 @@ func NewMock() *MockNotificationManager {
 		routes:       make(map[string]*alertmanagertypes.RoutePolicy),
 		routesByName: make(map[string][]*alertmanagertypes.RoutePolicy),
 		errors:       make(map[string]error),
+		policies:     make(map[string]*alertmanagertypes.EscalationPolicy),
+		escalations:  make(map[string][]string),
+		stopped:      make(map[string]bool),
 	}
 }
 
@@ -77,6 +84,9 @@ func (m *MockNotificationManager) ClearMockData() {
 	m.routes = make(map[string]*alertmanagertypes.RoutePolicy)
 	m.routesByName = make(map[string][]*alertmanagertypes.RoutePolicy)
 	m.errors = make(map[string]error)
+	m.policies = make(map[string]*alertmanagertypes.EscalationPolicy)
+	m.escalations = make(map[string][]string)
+	m.stopped = make(map[string]bool)
 }
 
 func (m *MockNotificationManager) HasConfig(orgID, ruleID string) bool {
@@ -275,6 +285,73 @@ func (m *MockNotificationManager) Match(ctx context.Context, orgID string, ruleI
 	return matchedChannels, nil
 }
 
+// Escalation Policy CRUD
+
+func (m *MockNotificationManager) CreateEscalationPolicy(ctx context.Context, orgID string, policy *alertmanagertypes.EscalationPolicy) error {
+	if policy == nil {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "escalation policy cannot be nil")
+	}
+
+	m.policies[getKey(orgID, policy.ID.StringValue())] = policy
+	return nil
+}
+
+func (m *MockNotificationManager) GetEscalationPolicyByID(ctx context.Context, orgID string, policyID string) (*alertmanagertypes.EscalationPolicy, error) {
+	policy, exists := m.policies[getKey(orgID, policyID)]
+	if !exists {
+		return nil, errors.NewNotFoundf(alertmanagertypes.ErrCodeEscalationPolicyNotFound, "escalation policy with ID %s not found", policyID)
+	}
+
+	return policy, nil
+}
+
+func (m *MockNotificationManager) GetAllEscalationPolicies(ctx context.Context, orgID string) ([]*alertmanagertypes.EscalationPolicy, error) {
+	var policies []*alertmanagertypes.EscalationPolicy
+	for _, policy := range m.policies {
+		if policy.OrgID == orgID {
+			policies = append(policies, policy)
+		}
+	}
+
+	return policies, nil
+}
+
+func (m *MockNotificationManager) UpdateEscalationPolicy(ctx context.Context, orgID string, policy *alertmanagertypes.EscalationPolicy) error {
+	if _, err := m.GetEscalationPolicyByID(ctx, orgID, policy.ID.StringValue()); err != nil {
+		return err
+	}
+
+	m.policies[getKey(orgID, policy.ID.StringValue())] = policy
+	return nil
+}
+
+func (m *MockNotificationManager) DeleteEscalationPolicy(ctx context.Context, orgID string, policyID string) error {
+	delete(m.policies, getKey(orgID, policyID))
+	return nil
+}
+
+// Escalate returns the channels set with SetMockEscalation for the rule, once.
+func (m *MockNotificationManager) Escalate(ctx context.Context, orgID string, ruleID string, groupLabels model.LabelSet, alerts []model.LabelSet, now time.Time) ([]string, error) {
+	key := getKey(orgID, ruleID)
+	if err := m.errors[key]; err != nil {
+		return nil, err
+	}
+
+	channels := m.escalations[key]
+	delete(m.escalations, key)
+	return channels, nil
+}
+
+func (m *MockNotificationManager) StopStaleEscalations(ctx context.Context, orgID string, firing map[string][]model.LabelSet) error {
+	return nil
+}
+
+func (m *MockNotificationManager) StopEscalation(ctx context.Context, orgID string, ruleID string, groupLabels model.LabelSet) error {
+	m.stopped[getKey(orgID, ruleID)] = true
+	delete(m.escalations, getKey(orgID, ruleID))
+	return nil
+}
+
 func (m *MockNotificationManager) evaluateExpr(expression string, labelSet model.LabelSet) bool {
 	ruleID, ok := labelSet["ruleId"]
 	if !ok {
@@ -322,3 +399,11 @@ func (m *MockNotificationManager) HasRoute(orgID, routeID string) bool {
 	_, exists := m.routes[routeKey]
 	return exists
 }
+
+func (m *MockNotificationManager) SetMockEscalation(orgID, ruleID string, channels []string) {
+	m.escalations[getKey(orgID, ruleID)] = channels
+}
+
+func (m *MockNotificationManager) IsEscalationStopped(orgID, ruleID string) bool {
+	return m.stopped[getKey(orgID, ruleID)]
+}
@@ -3,6 +3,7 @@ package nfmanager
 
 import (
 	"context"
+	"time"
 
 	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
 	"github.com/prometheus/common/model"
@@ -25,4 +26,20 @@ type NotificationManager interface {
 
 	// Route matching
 	Match(ctx context.Context, orgID string, ruleID string, set model.LabelSet) ([]string, error)
+
+	// Escalation Policy CRUD
+	CreateEscalationPolicy(ctx context.Context, orgID string, policy *alertmanagertypes.EscalationPolicy) error
+	GetEscalationPolicyByID(ctx context.Context, orgID string, policyID string) (*alertmanagertypes.EscalationPolicy, error)
+	GetAllEscalationPolicies(ctx context.Context, orgID string) ([]*alertmanagertypes.EscalationPolicy, error)
+	UpdateEscalationPolicy(ctx context.Context, orgID string, policy *alertmanagertypes.EscalationPolicy) error
+	DeleteEscalationPolicy(ctx context.Context, orgID string, policyID string) error
+
+	// Escalate advances the escalations of a group of firing alerts of a rule by the policies attached to the rule or
+	// to the route policies matching the alerts, and returns the channels of the steps which are due.
+	Escalate(ctx context.Context, orgID string, ruleID string, groupLabels model.LabelSet, alerts []model.LabelSet, now time.Time) ([]string, error)
+	// StopEscalation stops the escalations of a group, once the group is resolved or the incident is taken.
+	StopEscalation(ctx context.Context, orgID string, ruleID string, groupLabels model.LabelSet) error
+	// StopStaleEscalations stops the escalations of the groups which are not firing anymore, whose alerts expired
+	// without the group being resolved. The labels of the firing groups are given by rule id.
+	StopStaleEscalations(ctx context.Context, orgID string, firing map[string][]model.LabelSet) error
 }
@@ -2,8 +2,10 @@ package rulebasednotification
 
 import (
 	"context"
+	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/SigNoz/signoz/pkg/alertmanager/nfmanager"
 	"github.com/SigNoz/signoz/pkg/errors"
@@ -18,27 +20,29 @@ type provider struct {
 	settings                             factory.ScopedProviderSettings
 	orgToFingerprintToNotificationConfig map[string]map[string]alertmanagertypes.NotificationConfig
 	routeStore                           alertmanagertypes.RouteStore
+	escalationStore                      alertmanagertypes.EscalationStore
 	mutex                                sync.RWMutex
 }
 
 // NewFactory creates a new factory for the rule-based grouping strategy.
-func NewFactory(routeStore alertmanagertypes.RouteStore) factory.ProviderFactory[nfmanager.NotificationManager, nfmanager.Config] {
+func NewFactory(routeStore alertmanagertypes.RouteStore, escalationStore alertmanagertypes.EscalationStore) factory.ProviderFactory[nfmanager.NotificationManager, nfmanager.Config] {
 	return factory.NewProviderFactory(
 		factory.MustNewName("rulebased"),
 		func(ctx context.Context, settings factory.ProviderSettings, config nfmanager.Config) (nfmanager.NotificationManager, error) {
-			return New(ctx, settings, config, routeStore)
+			return New(ctx, settings, config, routeStore, escalationStore)
 		},
 	)
 }
 
 // New creates a new rule-based grouping strategy provider.
-func New(ctx context.Context, providerSettings factory.ProviderSettings, config nfmanager.Config, routeStore alertmanagertypes.RouteStore) (nfmanager.NotificationManager, error) {
+func New(ctx context.Context, providerSettings factory.ProviderSettings, config nfmanager.Config, routeStore alertmanagertypes.RouteStore, escalationStore alertmanagertypes.EscalationStore) (nfmanager.NotificationManager, error) {
 	settings := factory.NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/pkg/alertmanager/nfmanager/rulebasednotification")
 
 	return &provider{
 		settings:                             settings,
 		orgToFingerprintToNotificationConfig: make(map[string]map[string]alertmanagertypes.NotificationConfig),
 		routeStore:                           routeStore,
+		escalationStore:                      escalationStore,
 	}, nil
 }
 
@@ -60,6 +64,7 @@ func (r *provider) GetNotificationConfig(orgID string, ruleID string) (*alertman
 			if config.Renotify.NoDataInterval != 0 {
 				notificationConfig.Renotify.NoDataInterval = config.Renotify.NoDataInterval
 			}
+			notificationConfig.Renotify.SuppressWhenAcknowledged = config.Renotify.SuppressWhenAcknowledged
 			for k, v := range config.NotificationGroup {
 				notificationConfig.NotificationGroup[k] = v
 			}
@@ -173,6 +178,21 @@ func (r *provider) DeleteAllRoutePoliciesByName(ctx context.Context, orgID strin
 }
 
 func (r *provider) Match(ctx context.Context, orgID string, ruleID string, set model.LabelSet) ([]string, error) {
+	routes, err := r.matchRoutes(ctx, orgID, ruleID, set)
+	if err != nil {
+		return []string{}, err
+	}
+
+	var matchedChannels []string
+	for _, route := range routes {
+		matchedChannels = append(matchedChannels, route.Channels...)
+	}
+
+	return matchedChannels, nil
+}
+
+// matchRoutes returns the route policies matching the label set of an alert of the rule.
+func (r *provider) matchRoutes(ctx context.Context, orgID string, ruleID string, set model.LabelSet) ([]*alertmanagertypes.RoutePolicy, error) {
 	config, err := r.GetNotificationConfig(orgID, ruleID)
 	if err != nil {
 		return nil, errors.NewInternalf(errors.CodeInternal, "error getting notification configuration: %v", err)
@@ -181,33 +201,164 @@ func (r *provider) Match(ctx context.Context, orgID string, ruleID string, set m
 	if config.UsePolicy {
 		expressionRoutes, err = r.routeStore.GetAllByKind(ctx, orgID, alertmanagertypes.PolicyBasedExpression)
 		if err != nil {
-			return []string{}, errors.NewInternalf(errors.CodeInternal, "error getting route policies: %v", err)
+			return nil, errors.NewInternalf(errors.CodeInternal, "error getting route policies: %v", err)
 		}
 	} else {
 		expressionRoutes, err = r.routeStore.GetAllByName(ctx, orgID, ruleID)
 		if err != nil {
-			return []string{}, errors.NewInternalf(errors.CodeInternal, "error getting route policies: %v", err)
+			return nil, errors.NewInternalf(errors.CodeInternal, "error getting route policies: %v", err)
 		}
 	}
-	var matchedChannels []string
 	if _, ok := set[alertmanagertypes.NoDataLabel]; ok && !config.UsePolicy {
-		for _, expressionRoute := range expressionRoutes {
-			matchedChannels = append(matchedChannels, expressionRoute.Channels...)
-		}
-		return matchedChannels, nil
+		return expressionRoutes, nil
 	}
 
+	var matchedRoutes []*alertmanagertypes.RoutePolicy
 	for _, route := range expressionRoutes {
 		evaluateExpr, err := r.evaluateExpr(ctx, route.Expression, set)
 		if err != nil {
 			continue
 		}
 		if evaluateExpr {
-			matchedChannels = append(matchedChannels, route.Channels...)
+			matchedRoutes = append(matchedRoutes, route)
 		}
 	}
 
-	return matchedChannels, nil
+	return matchedRoutes, nil
+}
+
+func (r *provider) CreateEscalationPolicy(ctx context.Context, orgID string, policy *alertmanagertypes.EscalationPolicy) error {
+	if policy == nil {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "escalation policy cannot be nil")
+	}
+
+	return r.escalationStore.CreatePolicy(ctx, policy)
+}
+
+func (r *provider) GetEscalationPolicyByID(ctx context.Context, orgID string, policyID string) (*alertmanagertypes.EscalationPolicy, error) {
+	if policyID == "" {
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "policyID cannot be empty")
+	}
+
+	return r.escalationStore.GetPolicyByID(ctx, orgID, policyID)
+}
+
+func (r *provider) GetAllEscalationPolicies(ctx context.Context, orgID string) ([]*alertmanagertypes.EscalationPolicy, error) {
+	if orgID == "" {
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "orgID cannot be empty")
+	}
+
+	return r.escalationStore.GetAllPolicies(ctx, orgID)
+}
+
+func (r *provider) UpdateEscalationPolicy(ctx context.Context, orgID string, policy *alertmanagertypes.EscalationPolicy) error {
+	if policy == nil {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "escalation policy cannot be nil")
+	}
+
+	return r.escalationStore.UpdatePolicy(ctx, policy)
+}
+
+func (r *provider) DeleteEscalationPolicy(ctx context.Context, orgID string, policyID string) error {
+	if policyID == "" {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "policyID cannot be empty")
+	}
+
+	return r.escalationStore.DeletePolicy(ctx, orgID, policyID)
+}
+
+// Escalate starts the escalations of a group by the policies attached to it, and advances the existing ones. The state
+// of an escalation is stored before its channels are notified, a step which fails to be notified is not retried.
+func (r *provider) Escalate(ctx context.Context, orgID string, ruleID string, groupLabels model.LabelSet, alerts []model.LabelSet, now time.Time) ([]string, error) {
+	policies, err := r.escalationStore.GetAllPolicies(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(policies) == 0 {
+		return nil, nil
+	}
+
+	routeIDs := []string{}
+	for _, alert := range alerts {
+		routes, err := r.matchRoutes(ctx, orgID, ruleID, alert)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, route := range routes {
+			routeIDs = append(routeIDs, route.ID.StringValue())
+		}
+	}
+
+	groupFingerprint := groupLabels.Fingerprint().String()
+	escalations, err := r.escalationStore.GetAllByGroup(ctx, orgID, ruleID, groupFingerprint)
+	if err != nil {
+		return nil, err
+	}
+
+	escalationByPolicy := make(map[string]*alertmanagertypes.Escalation, len(escalations))
+	for _, escalation := range escalations {
+		escalationByPolicy[escalation.PolicyID] = escalation
+	}
+
+	channels := []string{}
+	for _, policy := range policies {
+		if !policy.AttachedTo(ruleID, routeIDs) {
+			continue
+		}
+
+		escalation, ok := escalationByPolicy[policy.ID.StringValue()]
+		if !ok {
+			escalation = alertmanagertypes.NewEscalation(orgID, ruleID, groupFingerprint, policy, now)
+		}
+
+		due := policy.Advance(escalation, now)
+		if ok && len(due) == 0 {
+			continue
+		}
+
+		if err := r.escalationStore.Set(ctx, escalation); err != nil {
+			return nil, err
+		}
+		channels = append(channels, due...)
+	}
+
+	slices.Sort(channels)
+	return slices.Compact(channels), nil
+}
+
+func (r *provider) StopEscalation(ctx context.Context, orgID string, ruleID string, groupLabels model.LabelSet) error {
+	return r.escalationStore.DeleteAllByGroup(ctx, orgID, ruleID, groupLabels.Fingerprint().String())
+}
+
+func (r *provider) StopStaleEscalations(ctx context.Context, orgID string, firing map[string][]model.LabelSet) error {
+	escalations, err := r.escalationStore.GetAll(ctx, orgID)
+	if err != nil {
+		return err
+	}
+
+	groups := map[string]struct{}{}
+	for ruleID, labelSets := range firing {
+		for _, groupLabels := range labelSets {
+			groups[ruleID+"/"+groupLabels.Fingerprint().String()] = struct{}{}
+		}
+	}
+
+	for _, escalation := range escalations {
+		key := escalation.RuleID + "/" + escalation.GroupFingerprint
+		if _, ok := groups[key]; ok {
+			continue
+		}
+
+		if err := r.escalationStore.DeleteAllByGroup(ctx, orgID, escalation.RuleID, escalation.GroupFingerprint); err != nil {
+			return err
+		}
+		// the other escalations of the group are deleted along
+		groups[key] = struct{}{}
+	}
+
+	return nil
 }
 
 // convertLabelSetToEnv converts a flat label set with dotted keys into a nested map structure for expr env.
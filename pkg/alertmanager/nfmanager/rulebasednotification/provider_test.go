@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/SigNoz/signoz/pkg/alertmanager/nfmanager"
+	"github.com/SigNoz/signoz/pkg/alertmanager/nfmanager/nfescalationstore/nfescalationstoretest"
 	"github.com/SigNoz/signoz/pkg/alertmanager/nfmanager/nfroutingstore/nfroutingstoretest"
 	"github.com/SigNoz/signoz/pkg/factory"
 	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
@@ -26,7 +27,7 @@ func createTestProviderSettings() factory.ProviderSettings {
 
 func TestNewFactory(t *testing.T) {
 	routeStore := nfroutingstoretest.NewMockSQLRouteStore()
-	providerFactory := NewFactory(routeStore)
+	providerFactory := NewFactory(routeStore, nfescalationstoretest.NewStore())
 	assert.NotNil(t, providerFactory)
 	assert.Equal(t, "rulebased", providerFactory.Name().String())
 }
@@ -37,7 +38,7 @@ func TestNew(t *testing.T) {
 	config := nfmanager.Config{}
 
 	routeStore := nfroutingstoretest.NewMockSQLRouteStore()
-	provider, err := New(ctx, providerSettings, config, routeStore)
+	provider, err := New(ctx, providerSettings, config, routeStore, nfescalationstoretest.NewStore())
 	require.NoError(t, err)
 	assert.NotNil(t, provider)
 
@@ -51,7 +52,7 @@ func TestProvider_SetNotificationConfig(t *testing.T) {
 	config := nfmanager.Config{}
 
 	routeStore := nfroutingstoretest.NewMockSQLRouteStore()
-	provider, err := New(ctx, providerSettings, config, routeStore)
+	provider, err := New(ctx, providerSettings, config, routeStore, nfescalationstoretest.NewStore())
 	require.NoError(t, err)
 
 	tests := []struct {
@@ -132,7 +133,7 @@ func TestProvider_GetNotificationConfig(t *testing.T) {
 	config := nfmanager.Config{}
 
 	routeStore := nfroutingstoretest.NewMockSQLRouteStore()
-	provider, err := New(ctx, providerSettings, config, routeStore)
+	provider, err := New(ctx, providerSettings, config, routeStore, nfescalationstoretest.NewStore())
 	require.NoError(t, err)
 
 	orgID := "test-org"
@@ -239,7 +240,7 @@ func TestProvider_ConcurrentAccess(t *testing.T) {
 	config := nfmanager.Config{}
 
 	routeStore := nfroutingstoretest.NewMockSQLRouteStore()
-	provider, err := New(ctx, providerSettings, config, routeStore)
+	provider, err := New(ctx, providerSettings, config, routeStore, nfescalationstoretest.NewStore())
 	require.NoError(t, err)
 
 	orgID := "test-org"
@@ -689,7 +690,7 @@ func TestProvider_DeleteRoute(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			routeStore := nfroutingstoretest.NewMockSQLRouteStore()
-			provider, err := New(ctx, providerSettings, config, routeStore)
+			provider, err := New(ctx, providerSettings, config, routeStore, nfescalationstoretest.NewStore())
 			require.NoError(t, err)
 
 			if !tt.wantErr {
@@ -793,7 +794,7 @@ func TestProvider_CreateRoute(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			routeStore := nfroutingstoretest.NewMockSQLRouteStore()
-			provider, err := New(ctx, providerSettings, config, routeStore)
+			provider, err := New(ctx, providerSettings, config, routeStore, nfescalationstoretest.NewStore())
 			require.NoError(t, err)
 
 			if !tt.wantErr && tt.route != nil {
@@ -818,7 +819,7 @@ func TestProvider_CreateRoutes(t *testing.T) {
 	config := nfmanager.Config{}
 
 	routeStore := nfroutingstoretest.NewMockSQLRouteStore()
-	provider, err := New(ctx, providerSettings, config, routeStore)
+	provider, err := New(ctx, providerSettings, config, routeStore, nfescalationstoretest.NewStore())
 	require.NoError(t, err)
 
 	validRoute1 := &alertmanagertypes.RoutePolicy{
@@ -978,3 +979,74 @@ func TestConvertLabelSetToEnv(t *testing.T) {
 		})
 	}
 }
+
+func TestProvider_Escalate(t *testing.T) {
+	ctx := context.Background()
+	routeStore := nfroutingstoretest.NewMockSQLRouteStore()
+	provider, err := New(ctx, createTestProviderSettings(), nfmanager.Config{}, routeStore, nfescalationstoretest.NewStore())
+	require.NoError(t, err)
+
+	orgID := "test-org"
+	ruleID := "rule-1"
+	notificationConfig := alertmanagertypes.NewNotificationConfig([]string{"service"}, time.Hour, time.Hour, true)
+	require.NoError(t, provider.SetNotificationConfig(orgID, ruleID, &notificationConfig))
+
+	route := &alertmanagertypes.RoutePolicy{
+		Identifiable:   types.Identifiable{ID: valuer.GenerateUUID()},
+		Expression:     `service == "payments"`,
+		ExpressionKind: alertmanagertypes.PolicyBasedExpression,
+		Name:           "payments",
+		Channels:       []string{"payments"},
+		OrgID:          orgID,
+	}
+
+	start := time.Now()
+	for _, postable := range []*alertmanagertypes.PostableEscalationPolicy{
+		{
+			Name:  "on rule",
+			Steps: []alertmanagertypes.EscalationStep{{Channels: []string{"oncall"}}, {Delay: valuer.MustParseTextDuration("15m"), Channels: []string{"secondary"}}},
+			Rules: []string{ruleID},
+		},
+		{
+			Name:          "on route policy",
+			Steps:         []alertmanagertypes.EscalationStep{{Delay: valuer.MustParseTextDuration("30m"), Channels: []string{"manager"}}},
+			RoutePolicies: []string{route.ID.StringValue()},
+		},
+		{
+			Name:  "on other rule",
+			Steps: []alertmanagertypes.EscalationStep{{Channels: []string{"other"}}},
+			Rules: []string{"rule-2"},
+		},
+	} {
+		policy, err := alertmanagertypes.NewEscalationPolicy(orgID, "admin@signoz.io", postable, start)
+		require.NoError(t, err)
+		require.NoError(t, provider.CreateEscalationPolicy(ctx, orgID, policy))
+	}
+
+	groupLabels := model.LabelSet{"ruleId": model.LabelValue(ruleID), "service": "payments"}
+	alerts := []model.LabelSet{{"ruleId": model.LabelValue(ruleID), "service": "payments", "pod": "a"}}
+	escalate := func(at time.Time) []string {
+		routeStore.ExpectGetAllByKindAndOrgID(orgID, alertmanagertypes.PolicyBasedExpression, []*alertmanagertypes.RoutePolicy{route})
+		channels, err := provider.Escalate(ctx, orgID, ruleID, groupLabels, alerts, at)
+		require.NoError(t, err)
+		return channels
+	}
+
+	assert.Equal(t, []string{"oncall"}, escalate(start))
+	assert.Empty(t, escalate(start.Add(10*time.Minute)))
+	assert.Equal(t, []string{"secondary"}, escalate(start.Add(15*time.Minute)))
+	assert.Equal(t, []string{"manager"}, escalate(start.Add(30*time.Minute)))
+	assert.Empty(t, escalate(start.Add(time.Hour)))
+
+	// the escalations start over once stopped
+	require.NoError(t, provider.StopEscalation(ctx, orgID, ruleID, groupLabels))
+	assert.Equal(t, []string{"oncall"}, escalate(start.Add(2*time.Hour)))
+
+	// the escalations of the firing groups are kept, the others start over
+	require.NoError(t, provider.StopStaleEscalations(ctx, orgID, map[string][]model.LabelSet{ruleID: {groupLabels}}))
+	assert.Empty(t, escalate(start.Add(2*time.Hour+10*time.Minute)))
+	require.NoError(t, provider.StopStaleEscalations(ctx, orgID, map[string][]model.LabelSet{}))
+	assert.Equal(t, []string{"oncall"}, escalate(start.Add(3*time.Hour)))
+
+	require.NoError(t, routeStore.Mock().ExpectationsWereMet())
+}
@@ -3,16 +3,22 @@ package alertmanager
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/prometheus/alertmanager/featurecontrol"
 	"github.com/prometheus/alertmanager/matcher/compat"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
 
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify/signozwebhook"
 	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagerserver"
 	"github.com/SigNoz/signoz/pkg/alertmanager/nfmanager"
+	"github.com/SigNoz/signoz/pkg/emailing"
 	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/factory"
 	"github.com/SigNoz/signoz/pkg/modules/organization"
 	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+	"github.com/SigNoz/signoz/pkg/types/oncalltypes"
 )
 
 type Service struct {
@@ -22,6 +28,15 @@ type Service struct {
 	// stateStore is the state store for the alertmanager service
 	stateStore alertmanagertypes.StateStore
 
+	// acknowledgementStore is the store of the acknowledgements of the alert groups
+	acknowledgementStore alertmanagertypes.AcknowledgementStore
+
+	// webhookDeliveryStore is the store of the deliveries of the signoz webhooks
+	webhookDeliveryStore alertmanagertypes.WebhookDeliveryStore
+
+	// digestStore is the store of the digests of the channels
+	digestStore alertmanagertypes.DigestStore
+
 	// configStore is the config store for the alertmanager service
 	configStore alertmanagertypes.ConfigStore
 
@@ -38,6 +53,12 @@ type Service struct {
 	serversMtx sync.RWMutex
 
 	notificationManager nfmanager.NotificationManager
+
+	// onCallStore is the store of the on-call schedules targeted by the channels
+	onCallStore oncalltypes.Store
+
+	// emailing sends the emails of the on-call channels and of the digests
+	emailing emailing.Emailing
 }
 
 func New(
@@ -45,19 +66,29 @@ func New(
 	settings factory.ScopedProviderSettings,
 	config alertmanagerserver.Config,
 	stateStore alertmanagertypes.StateStore,
+	acknowledgementStore alertmanagertypes.AcknowledgementStore,
+	webhookDeliveryStore alertmanagertypes.WebhookDeliveryStore,
+	digestStore alertmanagertypes.DigestStore,
 	configStore alertmanagertypes.ConfigStore,
 	orgGetter organization.Getter,
 	nfManager nfmanager.NotificationManager,
+	onCallStore oncalltypes.Store,
+	emailing emailing.Emailing,
 ) *Service {
 	service := &Service{
-		config:              config,
-		stateStore:          stateStore,
-		configStore:         configStore,
-		orgGetter:           orgGetter,
-		settings:            settings,
-		servers:             make(map[string]*alertmanagerserver.Server),
-		serversMtx:          sync.RWMutex{},
-		notificationManager: nfManager,
+		config:               config,
+		stateStore:           stateStore,
+		acknowledgementStore: acknowledgementStore,
+		webhookDeliveryStore: webhookDeliveryStore,
+		digestStore:          digestStore,
+		configStore:          configStore,
+		orgGetter:            orgGetter,
+		settings:             settings,
+		servers:              make(map[string]*alertmanagerserver.Server),
+		serversMtx:           sync.RWMutex{},
+		notificationManager:  nfManager,
+		onCallStore:          onCallStore,
+		emailing:             emailing,
 	}
 
 	return service
@@ -158,6 +189,179 @@ func (service *Service) TestAlert(ctx context.Context, orgID string, receiversMa
 	return server.TestAlert(ctx, receiversMap, config)
 }
 
+func (service *Service) ListSilences(ctx context.Context, orgID string) (alertmanagertypes.GettableSilences, error) {
+	service.serversMtx.RLock()
+	defer service.serversMtx.RUnlock()
+
+	server, err := service.getServer(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	return server.ListSilences(ctx)
+}
+
+func (service *Service) GetSilence(ctx context.Context, orgID string, id string) (*alertmanagertypes.GettableSilence, error) {
+	service.serversMtx.RLock()
+	defer service.serversMtx.RUnlock()
+
+	server, err := service.getServer(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	return server.GetSilence(ctx, id)
+}
+
+func (service *Service) SetSilence(ctx context.Context, orgID string, silence *alertmanagertypes.Silence) (string, error) {
+	service.serversMtx.RLock()
+	defer service.serversMtx.RUnlock()
+
+	server, err := service.getServer(orgID)
+	if err != nil {
+		return "", err
+	}
+
+	return server.SetSilence(ctx, silence)
+}
+
+func (service *Service) ExpireSilence(ctx context.Context, orgID string, id string) error {
+	service.serversMtx.RLock()
+	defer service.serversMtx.RUnlock()
+
+	server, err := service.getServer(orgID)
+	if err != nil {
+		return err
+	}
+
+	return server.ExpireSilence(ctx, id)
+}
+
+func (service *Service) PreviewSilence(ctx context.Context, orgID string, silence *alertmanagertypes.Silence) (alertmanagertypes.GettableAlerts, error) {
+	service.serversMtx.RLock()
+	defer service.serversMtx.RUnlock()
+
+	server, err := service.getServer(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	return server.PreviewSilence(ctx, silence)
+}
+
+func (service *Service) Acknowledge(ctx context.Context, orgID string, labels model.LabelSet, acknowledgedBy string, comment string, now time.Time) (*alertmanagertypes.StorableAcknowledgement, []*types.Alert, error) {
+	service.serversMtx.RLock()
+	defer service.serversMtx.RUnlock()
+
+	server, err := service.getServer(orgID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return server.Acknowledge(ctx, labels, acknowledgedBy, comment, now)
+}
+
+func (service *Service) Unacknowledge(ctx context.Context, orgID string, labels model.LabelSet, now time.Time) ([]*types.Alert, error) {
+	service.serversMtx.RLock()
+	defer service.serversMtx.RUnlock()
+
+	server, err := service.getServer(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	return server.Unacknowledge(ctx, labels, now)
+}
+
+// WebhookNotifier returns the notifier of a signoz webhook of a receiver of the org, built from the config the server
+// of the org is running.
+func (service *Service) WebhookNotifier(ctx context.Context, orgID string, receiver string, integration int) (*signozwebhook.Notifier, error) {
+	service.serversMtx.RLock()
+	defer service.serversMtx.RUnlock()
+
+	server, err := service.getServer(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	return server.WebhookNotifier(ctx, receiver, integration)
+}
+
+func (service *Service) Assign(ctx context.Context, orgID string, labels model.LabelSet, assignee string, assignedBy string, now time.Time) (*alertmanagertypes.StorableAcknowledgement, error) {
+	service.serversMtx.RLock()
+	defer service.serversMtx.RUnlock()
+
+	server, err := service.getServer(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	return server.Assign(ctx, labels, assignee, assignedBy, now)
+}
+
+// NotifyExpiredSilences notifies the expiry of the silences of all the servers. The notifications are sent once the
+// servers are unlocked.
+func (service *Service) NotifyExpiredSilences(ctx context.Context, now time.Time) {
+	service.serversMtx.RLock()
+	notifications := map[string][]*alertmanagerserver.Notification{}
+	for orgID, server := range service.servers {
+		expired, err := server.NotifyExpiredSilences(ctx, now)
+		if err != nil {
+			service.settings.Logger().ErrorContext(ctx, "failed to notify expired silences", "org_id", orgID, "error", err)
+		}
+		notifications[orgID] = expired
+	}
+	service.serversMtx.RUnlock()
+
+	service.send(ctx, notifications)
+}
+
+// Escalate escalates the groups of firing alerts of all the servers. The notifications are sent once the servers are
+// unlocked.
+func (service *Service) Escalate(ctx context.Context, now time.Time) {
+	service.serversMtx.RLock()
+	notifications := map[string][]*alertmanagerserver.Notification{}
+	for orgID, server := range service.servers {
+		escalated, err := server.Escalate(ctx, now)
+		if err != nil {
+			service.settings.Logger().ErrorContext(ctx, "failed to escalate alert groups", "org_id", orgID, "error", err)
+		}
+		notifications[orgID] = escalated
+	}
+	service.serversMtx.RUnlock()
+
+	service.send(ctx, notifications)
+}
+
+// send sends the notifications of all the servers at once.
+func (service *Service) send(ctx context.Context, notifications map[string][]*alertmanagerserver.Notification) {
+	wg := sync.WaitGroup{}
+	for orgID, orgNotifications := range notifications {
+		for _, notification := range orgNotifications {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := notification.Send(ctx); err != nil {
+					service.settings.Logger().ErrorContext(ctx, "failed to send notification", "org_id", orgID, "error", err)
+				}
+			}()
+		}
+	}
+	wg.Wait()
+}
+
+// SendDigests sends the due digests of the channels of all the servers.
+func (service *Service) SendDigests(ctx context.Context, now time.Time) {
+	service.serversMtx.RLock()
+	defer service.serversMtx.RUnlock()
+
+	for orgID, server := range service.servers {
+		if err := server.SendDigests(ctx, now); err != nil {
+			service.settings.Logger().ErrorContext(ctx, "failed to send digests", "org_id", orgID, "error", err)
+		}
+	}
+}
+
 func (service *Service) Stop(ctx context.Context) error {
 	var errs []error
 	for _, server := range service.servers {
@@ -176,7 +380,7 @@ func (service *Service) newServer(ctx context.Context, orgID string) (*alertmana
 		return nil, err
 	}
 
-	server, err := alertmanagerserver.New(ctx, service.settings.Logger(), service.settings.PrometheusRegisterer(), service.config, orgID, service.stateStore, service.notificationManager)
+	server, err := alertmanagerserver.New(ctx, service.settings.Logger(), service.settings.PrometheusRegisterer(), service.config, orgID, service.stateStore, service.acknowledgementStore, service.webhookDeliveryStore, service.digestStore, service.notificationManager, service.onCallStore, service.emailing)
 	if err != nil {
 		return nil, err
 	}
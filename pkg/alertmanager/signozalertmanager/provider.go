@@ -10,38 +10,49 @@ import (
 	amConfig "github.com/prometheus/alertmanager/config"
 
 	"github.com/SigNoz/signoz/pkg/alertmanager"
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagerstore/clickhousealertmanagerstore"
 	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagerstore/sqlalertmanagerstore"
 	"github.com/SigNoz/signoz/pkg/alertmanager/nfmanager"
+	"github.com/SigNoz/signoz/pkg/emailing"
 	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/factory"
 	"github.com/SigNoz/signoz/pkg/modules/organization"
 	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
 	"github.com/SigNoz/signoz/pkg/types"
 	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
 	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	"github.com/SigNoz/signoz/pkg/types/oncalltypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
 )
 
 type provider struct {
-	service             *alertmanager.Service
-	config              alertmanager.Config
-	settings            factory.ScopedProviderSettings
-	configStore         alertmanagertypes.ConfigStore
-	stateStore          alertmanagertypes.StateStore
-	notificationManager nfmanager.NotificationManager
-	stopC               chan struct{}
+	service              *alertmanager.Service
+	config               alertmanager.Config
+	settings             factory.ScopedProviderSettings
+	configStore          alertmanagertypes.ConfigStore
+	stateStore           alertmanagertypes.StateStore
+	acknowledgementStore alertmanagertypes.AcknowledgementStore
+	webhookDeliveryStore alertmanagertypes.WebhookDeliveryStore
+	digestStore          alertmanagertypes.DigestStore
+	historyStore         alertmanagertypes.AcknowledgementHistoryStore
+	notificationManager  nfmanager.NotificationManager
+	stopC                chan struct{}
 }
 
-func NewFactory(sqlstore sqlstore.SQLStore, orgGetter organization.Getter, notificationManager nfmanager.NotificationManager) factory.ProviderFactory[alertmanager.Alertmanager, alertmanager.Config] {
+func NewFactory(sqlstore sqlstore.SQLStore, telemetryStore telemetrystore.TelemetryStore, orgGetter organization.Getter, notificationManager nfmanager.NotificationManager, onCallStore oncalltypes.Store, emailing emailing.Emailing) factory.ProviderFactory[alertmanager.Alertmanager, alertmanager.Config] {
 	return factory.NewProviderFactory(factory.MustNewName("signoz"), func(ctx context.Context, settings factory.ProviderSettings, config alertmanager.Config) (alertmanager.Alertmanager, error) {
-		return New(ctx, settings, config, sqlstore, orgGetter, notificationManager)
+		return New(ctx, settings, config, sqlstore, telemetryStore, orgGetter, notificationManager, onCallStore, emailing)
 	})
 }
 
-func New(ctx context.Context, providerSettings factory.ProviderSettings, config alertmanager.Config, sqlstore sqlstore.SQLStore, orgGetter organization.Getter, notificationManager nfmanager.NotificationManager) (*provider, error) {
+func New(ctx context.Context, providerSettings factory.ProviderSettings, config alertmanager.Config, sqlstore sqlstore.SQLStore, telemetryStore telemetrystore.TelemetryStore, orgGetter organization.Getter, notificationManager nfmanager.NotificationManager, onCallStore oncalltypes.Store, emailing emailing.Emailing) (*provider, error) {
 	settings := factory.NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/pkg/alertmanager/signozalertmanager")
 	configStore := sqlalertmanagerstore.NewConfigStore(sqlstore)
 	stateStore := sqlalertmanagerstore.NewStateStore(sqlstore)
+	acknowledgementStore := sqlalertmanagerstore.NewAcknowledgementStore(sqlstore)
+	webhookDeliveryStore := sqlalertmanagerstore.NewWebhookDeliveryStore(sqlstore)
+	digestStore := sqlalertmanagerstore.NewDigestStore(sqlstore)
 
 	p := &provider{
 		service: alertmanager.New(
@@ -49,16 +60,25 @@ func New(ctx context.Context, providerSettings factory.ProviderSettings, config
 			settings,
 			config.Signoz.Config,
 			stateStore,
+			acknowledgementStore,
+			webhookDeliveryStore,
+			digestStore,
 			configStore,
 			orgGetter,
 			notificationManager,
+			onCallStore,
+			emailing,
 		),
-		settings:            settings,
-		config:              config,
-		configStore:         configStore,
-		stateStore:          stateStore,
-		notificationManager: notificationManager,
-		stopC:               make(chan struct{}),
+		settings:             settings,
+		config:               config,
+		configStore:          configStore,
+		stateStore:           stateStore,
+		acknowledgementStore: acknowledgementStore,
+		webhookDeliveryStore: webhookDeliveryStore,
+		digestStore:          digestStore,
+		historyStore:         clickhousealertmanagerstore.NewHistoryStore(telemetryStore),
+		notificationManager:  notificationManager,
+		stopC:                make(chan struct{}),
 	}
 
 	return p, nil
@@ -76,10 +96,18 @@ func (provider *provider) Start(ctx context.Context) error {
 		select {
 		case <-provider.stopC:
 			return nil
-		case <-ticker.C:
+		case now := <-ticker.C:
 			if err := provider.service.SyncServers(ctx); err != nil {
 				provider.settings.Logger().ErrorContext(ctx, "failed to sync alertmanager servers", "error", err)
 			}
+
+			provider.service.NotifyExpiredSilences(ctx, now)
+			provider.service.Escalate(ctx, now)
+			provider.service.SendDigests(ctx, now)
+
+			if err := provider.webhookDeliveryStore.DeleteBefore(ctx, now.Add(-alertmanagertypes.WebhookDeliveryRetention)); err != nil {
+				provider.settings.Logger().ErrorContext(ctx, "failed to delete expired webhook deliveries", "error", err)
+			}
 		}
 	}
 }
@@ -126,6 +154,197 @@ func (provider *provider) TestAlert(ctx context.Context, orgID string, ruleID st
 	return provider.service.TestAlert(ctx, orgID, receiversMap, config)
 }
 
+func (provider *provider) ListSilences(ctx context.Context, orgID string) (alertmanagertypes.GettableSilences, error) {
+	return provider.service.ListSilences(ctx, orgID)
+}
+
+func (provider *provider) GetSilence(ctx context.Context, orgID string, id string) (*alertmanagertypes.GettableSilence, error) {
+	return provider.service.GetSilence(ctx, orgID, id)
+}
+
+func (provider *provider) SetSilence(ctx context.Context, orgID string, setBy string, postable *alertmanagertypes.PostableSilence) (string, error) {
+	// the creator of an updated silence is kept
+	createdBy, updatedBy := setBy, ""
+	if postable.ID != "" {
+		existing, err := provider.service.GetSilence(ctx, orgID, postable.ID)
+		if err != nil {
+			return "", err
+		}
+		createdBy, updatedBy = *existing.CreatedBy, setBy
+	}
+
+	silence, err := alertmanagertypes.NewSilenceFromPostableSilence(postable, createdBy, updatedBy, time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	return provider.service.SetSilence(ctx, orgID, silence)
+}
+
+func (provider *provider) ExpireSilence(ctx context.Context, orgID string, id string) error {
+	return provider.service.ExpireSilence(ctx, orgID, id)
+}
+
+func (provider *provider) PreviewSilence(ctx context.Context, orgID string, postable *alertmanagertypes.PostableSilence) (alertmanagertypes.GettableAlerts, error) {
+	silence, err := alertmanagertypes.NewPreviewSilenceFromPostableSilence(postable, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return provider.service.PreviewSilence(ctx, orgID, silence)
+}
+
+func (provider *provider) ListAcknowledgements(ctx context.Context, orgID string) (alertmanagertypes.GettableAcknowledgements, error) {
+	acknowledgements, err := provider.acknowledgementStore.List(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	return alertmanagertypes.NewGettableAcknowledgementsFromStorableAcknowledgements(acknowledgements)
+}
+
+func (provider *provider) Acknowledge(ctx context.Context, orgID string, acknowledgedBy string, postable *alertmanagertypes.PostableAcknowledgement) (*alertmanagertypes.GettableAcknowledgement, error) {
+	if err := postable.Validate(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	acknowledgement, alerts, err := provider.service.Acknowledge(ctx, orgID, postable.LabelSet(), acknowledgedBy, postable.Comment, now)
+	if err != nil {
+		return nil, err
+	}
+
+	provider.recordHistory(ctx, acknowledgement.RuleID, alertmanagertypes.AcknowledgementEventAcknowledged, alerts, now)
+
+	return alertmanagertypes.NewGettableAcknowledgementFromStorableAcknowledgement(acknowledgement)
+}
+
+func (provider *provider) Unacknowledge(ctx context.Context, orgID string, postable *alertmanagertypes.PostableAcknowledgement) error {
+	if err := postable.Validate(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	alerts, err := provider.service.Unacknowledge(ctx, orgID, postable.LabelSet(), now)
+	if err != nil {
+		return err
+	}
+
+	provider.recordHistory(ctx, postable.Labels[alertmanagertypes.DefaultGroupBy], alertmanagertypes.AcknowledgementEventUnacknowledged, alerts, now)
+
+	return nil
+}
+
+func (provider *provider) Assign(ctx context.Context, orgID string, assignedBy string, postable *alertmanagertypes.PostableAcknowledgement) (*alertmanagertypes.GettableAcknowledgement, error) {
+	if err := postable.Validate(); err != nil {
+		return nil, err
+	}
+
+	acknowledgement, err := provider.service.Assign(ctx, orgID, postable.LabelSet(), postable.Assignee, assignedBy, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return alertmanagertypes.NewGettableAcknowledgementFromStorableAcknowledgement(acknowledgement)
+}
+
+func (provider *provider) ListWebhookDeliveries(ctx context.Context, orgID string, params *alertmanagertypes.ListWebhookDeliveriesParams) (alertmanagertypes.GettableWebhookDeliveries, error) {
+	deliveries, err := provider.webhookDeliveryStore.List(ctx, orgID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return alertmanagertypes.NewGettableWebhookDeliveriesFromStorableWebhookDeliveries(deliveries)
+}
+
+func (provider *provider) ResendWebhookDelivery(ctx context.Context, orgID string, id valuer.UUID) (*alertmanagertypes.GettableWebhookDelivery, error) {
+	delivery, err := provider.webhookDeliveryStore.Get(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	notifier, err := provider.service.WebhookNotifier(ctx, orgID, delivery.Receiver, delivery.Integration)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := notifier.Redeliver(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	return alertmanagertypes.NewGettableWebhookDeliveryFromStorableWebhookDelivery(delivery)
+}
+
+func (provider *provider) ListDigests(ctx context.Context, orgID string) (alertmanagertypes.GettableDigests, error) {
+	digests, err := provider.digestStore.List(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	return alertmanagertypes.NewGettableDigests(digests), nil
+}
+
+func (provider *provider) SetDigest(ctx context.Context, orgID string, updatedBy string, postable *alertmanagertypes.PostableDigest) (*alertmanagertypes.GettableDigest, error) {
+	if err := postable.Validate(); err != nil {
+		return nil, err
+	}
+
+	config, err := provider.configStore.Get(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := config.GetReceiver(postable.Channel); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	digest, err := provider.digestStore.GetByChannel(ctx, orgID, postable.Channel)
+	if err != nil {
+		if !errors.Ast(err, errors.TypeNotFound) {
+			return nil, err
+		}
+
+		digest, err = alertmanagertypes.NewStorableDigest(orgID, updatedBy, postable, now)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		digest.Update(postable, updatedBy, now)
+	}
+
+	if err := provider.digestStore.Set(ctx, digest); err != nil {
+		return nil, err
+	}
+
+	return alertmanagertypes.NewGettableDigest(digest), nil
+}
+
+func (provider *provider) DeleteDigest(ctx context.Context, orgID string, id valuer.UUID) error {
+	if _, err := provider.digestStore.Get(ctx, orgID, id); err != nil {
+		return err
+	}
+
+	return provider.digestStore.Delete(ctx, orgID, id)
+}
+
+// recordHistory records an acknowledgement event of the firing alerts in the alert history. The acknowledgement is
+// already stored, a failure to record it is logged and does not fail the request.
+func (provider *provider) recordHistory(ctx context.Context, ruleID string, event alertmanagertypes.AcknowledgementEvent, alerts []*alertmanagertypes.Alert, now time.Time) {
+	if len(alerts) == 0 {
+		return
+	}
+
+	labelSets := make([]model.LabelSet, 0, len(alerts))
+	for _, alert := range alerts {
+		labelSets = append(labelSets, alert.Labels)
+	}
+
+	if err := provider.historyStore.Record(ctx, ruleID, event, labelSets, now); err != nil {
+		provider.settings.Logger().ErrorContext(ctx, "failed to record the acknowledgement in the alert history", "rule_id", ruleID, "event", event.StringValue(), "error", err)
+	}
+}
+
 func (provider *provider) ListChannels(ctx context.Context, orgID string) ([]*alertmanagertypes.Channel, error) {
 	return provider.configStore.ListChannels(ctx, orgID)
 }
@@ -144,6 +363,10 @@ func (provider *provider) UpdateChannelByReceiverAndID(ctx context.Context, orgI
 		return err
 	}
 
+	if err := channel.RestoreRedactedSecrets(receiver); err != nil {
+		return err
+	}
+
 	if err := channel.Update(receiver); err != nil {
 		return err
 	}
@@ -515,6 +738,84 @@ func (provider *provider) DeleteRoutePolicyByID(ctx context.Context, routeID str
 	return provider.notificationManager.DeleteRoutePolicy(ctx, orgID.String(), routeID)
 }
 
+func (provider *provider) CreateEscalationPolicy(ctx context.Context, postable *alertmanagertypes.PostableEscalationPolicy) (*alertmanagertypes.GettableEscalationPolicy, error) {
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := alertmanagertypes.NewEscalationPolicy(claims.OrgID, claims.Email, postable, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := provider.notificationManager.CreateEscalationPolicy(ctx, claims.OrgID, policy); err != nil {
+		return nil, err
+	}
+
+	return alertmanagertypes.NewGettableEscalationPolicy(policy), nil
+}
+
+func (provider *provider) GetEscalationPolicyByID(ctx context.Context, policyID string) (*alertmanagertypes.GettableEscalationPolicy, error) {
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := provider.notificationManager.GetEscalationPolicyByID(ctx, claims.OrgID, policyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return alertmanagertypes.NewGettableEscalationPolicy(policy), nil
+}
+
+func (provider *provider) GetAllEscalationPolicies(ctx context.Context) ([]*alertmanagertypes.GettableEscalationPolicy, error) {
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	policies, err := provider.notificationManager.GetAllEscalationPolicies(ctx, claims.OrgID)
+	if err != nil {
+		return nil, err
+	}
+
+	return alertmanagertypes.NewGettableEscalationPolicies(policies), nil
+}
+
+func (provider *provider) UpdateEscalationPolicyByID(ctx context.Context, policyID string, postable *alertmanagertypes.PostableEscalationPolicy) (*alertmanagertypes.GettableEscalationPolicy, error) {
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := postable.Validate(); err != nil {
+		return nil, err
+	}
+
+	policy, err := provider.notificationManager.GetEscalationPolicyByID(ctx, claims.OrgID, policyID)
+	if err != nil {
+		return nil, err
+	}
+
+	policy.Update(postable, claims.Email, time.Now())
+	if err := provider.notificationManager.UpdateEscalationPolicy(ctx, claims.OrgID, policy); err != nil {
+		return nil, err
+	}
+
+	return alertmanagertypes.NewGettableEscalationPolicy(policy), nil
+}
+
+func (provider *provider) DeleteEscalationPolicyByID(ctx context.Context, policyID string) error {
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return provider.notificationManager.DeleteEscalationPolicy(ctx, claims.OrgID, policyID)
+}
+
 func (provider *provider) CreateInhibitRules(ctx context.Context, orgID valuer.UUID, rules []amConfig.InhibitRule) error {
 	config, err := provider.configStore.Get(ctx, orgID.String())
 	if err != nil {
@@ -1,6 +1,9 @@
 package cache
 
 import (
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/factory"
 )
 
@@ -16,10 +19,22 @@ type Redis struct {
 	DB       int    `mapstructure:"db"`
 }
 
+// Tiered is the in-memory tier kept by every replica in front of the Redis tier shared by all of them
+type Tiered struct {
+	NumCounters int64 `mapstructure:"num_counters"`
+	// MaxCost is the total size in bytes of the entries kept in memory
+	MaxCost int64 `mapstructure:"max_cost"`
+	// TTL is the most time an entry is kept in memory, it bounds the staleness of an entry whose invalidation was missed
+	TTL time.Duration `mapstructure:"ttl"`
+	// Channel is the Redis channel the replicas publish the invalidations of their entries on
+	Channel string `mapstructure:"channel"`
+}
+
 type Config struct {
 	Provider string `mapstructure:"provider"`
 	Memory   Memory `mapstructure:"memory"`
 	Redis    Redis  `mapstructure:"redis"`
+	Tiered   Tiered `mapstructure:"tiered"`
 }
 
 func NewConfigFactory() factory.ConfigFactory {
@@ -39,10 +54,30 @@ func newConfig() factory.Config {
 			Password: "",
 			DB:       0,
 		},
+		Tiered: Tiered{
+			NumCounters: 10 * 10000,
+			MaxCost:     1 << 27, // 128 MB
+			TTL:         5 * time.Minute,
+			Channel:     "signoz:cache:invalidations",
+		},
 	}
 
 }
 
 func (c Config) Validate() error {
+	if c.Provider == "tiered" {
+		if c.Tiered.MaxCost <= 0 || c.Tiered.NumCounters <= 0 {
+			return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "tiered::max_cost and tiered::num_counters must be positive")
+		}
+
+		if c.Tiered.TTL <= 0 {
+			return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "tiered::ttl must be positive")
+		}
+
+		if c.Tiered.Channel == "" {
+			return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "tiered::channel must be set")
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,308 @@
+package tieredcache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/cache"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/types/cachetypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/dgraph-io/ristretto/v2"
+	"github.com/redis/go-redis/extra/redisotel/v9"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	tierMemory = "memory"
+	tierRedis  = "redis"
+)
+
+// invalidation is published on the channel by a replica which changed or deleted keys, so that the other replicas
+// drop them from memory
+type invalidation struct {
+	Origin string   `json:"origin"`
+	Keys   []string `json:"keys"`
+}
+
+type provider struct {
+	cc        *ristretto.Cache[string, any]
+	client    *redis.Client
+	config    cache.Tiered
+	settings  factory.ScopedProviderSettings
+	telemetry *telemetry
+	// origin identifies the invalidations published by this replica
+	origin string
+}
+
+func NewFactory() factory.ProviderFactory[cache.Cache, cache.Config] {
+	return factory.NewProviderFactory(factory.MustNewName("tiered"), New)
+}
+
+func New(ctx context.Context, providerSettings factory.ProviderSettings, config cache.Config) (cache.Cache, error) {
+	settings := factory.NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/pkg/cache/tieredcache")
+
+	cc, err := ristretto.NewCache(&ristretto.Config[string, any]{
+		NumCounters: config.Tiered.NumCounters,
+		MaxCost:     config.Tiered.MaxCost,
+		BufferItems: 64,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     strings.Join([]string{config.Redis.Host, fmt.Sprint(config.Redis.Port)}, ":"),
+		Password: config.Redis.Password,
+		DB:       config.Redis.DB,
+	})
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, err
+	}
+
+	if err := redisotel.InstrumentTracing(client, redisotel.WithTracerProvider(providerSettings.TracerProvider), redisotel.WithDBStatement(true)); err != nil {
+		return nil, err
+	}
+
+	if err := redisotel.InstrumentMetrics(client, redisotel.WithMeterProvider(providerSettings.MeterProvider)); err != nil {
+		return nil, err
+	}
+
+	telemetry, err := newMetrics(settings.Meter())
+	if err != nil {
+		return nil, err
+	}
+
+	provider := &provider{
+		cc:        cc,
+		client:    client,
+		config:    config.Tiered,
+		settings:  settings,
+		telemetry: telemetry,
+		origin:    valuer.GenerateUUID().StringValue(),
+	}
+
+	// wait for the subscription so that no invalidation published after New returns is missed
+	pubsub := client.Subscribe(ctx, config.Tiered.Channel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return nil, err
+	}
+
+	go provider.subscribe(ctx, pubsub)
+
+	return provider, nil
+}
+
+func (provider *provider) Set(ctx context.Context, orgID valuer.UUID, cacheKey string, data cachetypes.Cacheable, ttl time.Duration) error {
+	err := cachetypes.CheckCacheablePointer(data)
+	if err != nil {
+		return err
+	}
+
+	key := strings.Join([]string{orgID.StringValue(), cacheKey}, "::")
+
+	raw, err := data.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	if err := provider.client.Set(ctx, key, raw, ttl).Err(); err != nil {
+		return err
+	}
+
+	provider.setMemory(key, data, raw, ttl)
+
+	// the other replicas must not keep serving the value this one replaced
+	provider.publish(ctx, []string{key})
+	return nil
+}
+
+func (provider *provider) Get(ctx context.Context, orgID valuer.UUID, cacheKey string, dest cachetypes.Cacheable) error {
+	ctx, span := provider.settings.Tracer().Start(ctx, "tiered.get", trace.WithAttributes(
+		attribute.String("tiered.key", strings.Join([]string{orgID.StringValue(), cacheKey}, "::")),
+	))
+	defer span.End()
+
+	err := cachetypes.CheckCacheablePointer(dest)
+	if err != nil {
+		return err
+	}
+
+	key := strings.Join([]string{orgID.StringValue(), cacheKey}, "::")
+
+	if cachedData, found := provider.cc.Get(key); found {
+		provider.record(ctx, provider.telemetry.hits, tierMemory)
+		span.SetAttributes(attribute.String("tiered.tier", tierMemory))
+		return fromMemory(cachedData, dest)
+	}
+	provider.record(ctx, provider.telemetry.misses, tierMemory)
+
+	// the remaining ttl is read along with the value so that the entry is never kept in memory past its expiry
+	pipe := provider.client.Pipeline()
+	get := pipe.Get(ctx, key)
+	pttl := pipe.PTTL(ctx, key)
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return err
+	}
+
+	raw, err := get.Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			provider.record(ctx, provider.telemetry.misses, tierRedis)
+			return errors.Newf(errors.TypeNotFound, errors.CodeNotFound, "key miss")
+		}
+
+		return err
+	}
+	provider.record(ctx, provider.telemetry.hits, tierRedis)
+	span.SetAttributes(attribute.String("tiered.tier", tierRedis))
+
+	if err := dest.UnmarshalBinary(raw); err != nil {
+		return err
+	}
+
+	provider.setMemory(key, dest, raw, pttl.Val())
+	return nil
+}
+
+func (provider *provider) Delete(ctx context.Context, orgID valuer.UUID, cacheKey string) {
+	provider.DeleteMany(ctx, orgID, []string{cacheKey})
+}
+
+func (provider *provider) DeleteMany(ctx context.Context, orgID valuer.UUID, cacheKeys []string) {
+	if len(cacheKeys) == 0 {
+		return
+	}
+
+	keys := make([]string, len(cacheKeys))
+	for i, cacheKey := range cacheKeys {
+		keys[i] = strings.Join([]string{orgID.StringValue(), cacheKey}, "::")
+		provider.cc.Del(keys[i])
+	}
+
+	if err := provider.client.Del(ctx, keys...).Err(); err != nil {
+		provider.settings.Logger().ErrorContext(ctx, "error deleting cache keys", "cache_keys", cacheKeys, "error", err)
+	}
+
+	provider.publish(ctx, keys)
+}
+
+// setMemory keeps the entry in memory at the cost of its size, a clone of the entry is kept when it can be cloned so
+// that the hits in memory don't have to unmarshal it
+func (provider *provider) setMemory(key string, data cachetypes.Cacheable, raw []byte, ttl time.Duration) {
+	if ttl <= 0 || ttl > provider.config.TTL {
+		ttl = provider.config.TTL
+	}
+
+	var value any = raw
+	if cloneable, ok := data.(cachetypes.Cloneable); ok {
+		value = cloneable.Clone()
+	}
+
+	// the entries larger than the memory tier are only kept in redis
+	if ok := provider.cc.SetWithTTL(key, value, int64(len(raw)), ttl); ok {
+		provider.cc.Wait()
+	}
+}
+
+// publish asks the other replicas to drop the keys from memory, the invalidations are delivered at most once and the
+// ttl of the memory tier bounds the staleness of the entries whose invalidation is lost
+func (provider *provider) publish(ctx context.Context, keys []string) {
+	payload, err := json.Marshal(invalidation{Origin: provider.origin, Keys: keys})
+	if err != nil {
+		provider.settings.Logger().ErrorContext(ctx, "error marshalling cache invalidation", "error", err)
+		return
+	}
+
+	if err := provider.client.Publish(ctx, provider.config.Channel, string(payload)).Err(); err != nil {
+		provider.settings.Logger().ErrorContext(ctx, "error publishing cache invalidation", "cache_keys", keys, "error", err)
+	}
+}
+
+// subscribe drops the keys invalidated by the other replicas from memory until ctx is done. The memory tier is
+// cleared whenever the subscription is established again, as the invalidations published in between are lost.
+func (provider *provider) subscribe(ctx context.Context, pubsub *redis.PubSub) {
+	defer pubsub.Close()
+
+	ch := pubsub.ChannelWithSubscriptions()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+
+			switch msg := msg.(type) {
+			case *redis.Subscription:
+				if msg.Kind == "subscribe" {
+					provider.settings.Logger().InfoContext(ctx, "cache invalidations resubscribed, clearing the memory tier", "channel", msg.Channel)
+					provider.cc.Clear()
+				}
+			case *redis.Message:
+				provider.invalidate(ctx, msg.Payload)
+			}
+		}
+	}
+}
+
+// invalidate drops the keys of an invalidation published by another replica from memory
+func (provider *provider) invalidate(ctx context.Context, payload string) {
+	var inv invalidation
+	if err := json.Unmarshal([]byte(payload), &inv); err != nil {
+		provider.settings.Logger().WarnContext(ctx, "error unmarshalling cache invalidation", "error", err)
+		return
+	}
+
+	if inv.Origin == provider.origin {
+		return
+	}
+
+	for _, key := range inv.Keys {
+		provider.cc.Del(key)
+	}
+	provider.telemetry.invalidations.Add(ctx, int64(len(inv.Keys)))
+}
+
+func (provider *provider) record(ctx context.Context, counter metric.Int64Counter, tier string) {
+	counter.Add(ctx, 1, metric.WithAttributes(attribute.String("tier", tier)))
+}
+
+// fromMemory sets dest to the entry kept in memory
+func fromMemory(cachedData any, dest cachetypes.Cacheable) error {
+	if cloneable, ok := cachedData.(cachetypes.Cloneable); ok {
+		// check if the destination value is settable
+		dstv := reflect.ValueOf(dest)
+		if !dstv.Elem().CanSet() {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "unsettable: (value: \"%s\")", dstv.Elem())
+		}
+
+		fromCache := cloneable.Clone()
+
+		// check the type compatbility between the src and dest
+		srcv := reflect.ValueOf(fromCache)
+		if !srcv.Type().AssignableTo(dstv.Type()) {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "unassignable: (src: \"%s\", dst: \"%s\")", srcv.Type().String(), dstv.Type().String())
+		}
+
+		dstv.Elem().Set(srcv.Elem())
+		return nil
+	}
+
+	if fromCache, ok := cachedData.([]byte); ok {
+		return dest.UnmarshalBinary(fromCache)
+	}
+
+	return errors.NewInternalf(errors.CodeInternal, "unrecognized: (value: \"%s\")", reflect.TypeOf(cachedData).String())
+}
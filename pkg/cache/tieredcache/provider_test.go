@@ -0,0 +1,174 @@
+package tieredcache
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/cache"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
+	"github.com/SigNoz/signoz/pkg/types/cachetypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/dgraph-io/ristretto/v2"
+	"github.com/go-redis/redismock/v9"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type CacheableA struct {
+	Key   string
+	Value int
+}
+
+func (cacheable *CacheableA) Clone() cachetypes.Cacheable {
+	return &CacheableA{
+		Key:   cacheable.Key,
+		Value: cacheable.Value,
+	}
+}
+
+func (cacheable *CacheableA) MarshalBinary() ([]byte, error) {
+	return json.Marshal(cacheable)
+}
+
+func (cacheable *CacheableA) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, cacheable)
+}
+
+func newTestProvider(t *testing.T, client *redis.Client, maxCost int64) *provider {
+	cc, err := ristretto.NewCache(&ristretto.Config[string, any]{NumCounters: 1000, MaxCost: maxCost, BufferItems: 64})
+	require.NoError(t, err)
+
+	settings := factory.NewScopedProviderSettings(instrumentationtest.New().ToProviderSettings(), "github.com/SigNoz/signoz/pkg/cache/tieredcache")
+	telemetry, err := newMetrics(settings.Meter())
+	require.NoError(t, err)
+
+	return &provider{
+		cc:        cc,
+		client:    client,
+		config:    cache.Tiered{TTL: time.Minute, Channel: "invalidations"},
+		settings:  settings,
+		telemetry: telemetry,
+		origin:    valuer.GenerateUUID().StringValue(),
+	}
+}
+
+func invalidationOf(t *testing.T, origin string, keys ...string) string {
+	payload, err := json.Marshal(invalidation{Origin: origin, Keys: keys})
+	require.NoError(t, err)
+	return string(payload)
+}
+
+func TestSetAndGet(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	provider := newTestProvider(t, db, 1<<20)
+
+	orgID := valuer.GenerateUUID()
+	key := strings.Join([]string{orgID.StringValue(), "key"}, "::")
+	cacheable := &CacheableA{Key: "some-random-key", Value: 1}
+	raw, err := cacheable.MarshalBinary()
+	require.NoError(t, err)
+
+	mock.ExpectSet(key, raw, 10*time.Second).SetVal("OK")
+	mock.ExpectPublish("invalidations", invalidationOf(t, provider.origin, key)).SetVal(1)
+	require.NoError(t, provider.Set(context.Background(), orgID, "key", cacheable, 10*time.Second))
+
+	// served from memory without a call to redis
+	retrieved := new(CacheableA)
+	require.NoError(t, provider.Get(context.Background(), orgID, "key", retrieved))
+	assert.Equal(t, cacheable, retrieved)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetFromRedis(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	provider := newTestProvider(t, db, 1<<20)
+
+	orgID := valuer.GenerateUUID()
+	key := strings.Join([]string{orgID.StringValue(), "key"}, "::")
+	cacheable := &CacheableA{Key: "some-random-key", Value: 2}
+	raw, err := cacheable.MarshalBinary()
+	require.NoError(t, err)
+
+	mock.ExpectGet(key).SetVal(string(raw))
+	mock.ExpectPTTL(key).SetVal(30 * time.Second)
+
+	retrieved := new(CacheableA)
+	require.NoError(t, provider.Get(context.Background(), orgID, "key", retrieved))
+	assert.Equal(t, cacheable, retrieved)
+
+	// the second get is served from memory
+	retrieved = new(CacheableA)
+	require.NoError(t, provider.Get(context.Background(), orgID, "key", retrieved))
+	assert.Equal(t, cacheable, retrieved)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// a miss in both tiers
+	mock.ExpectGet(strings.Join([]string{orgID.StringValue(), "missing"}, "::")).RedisNil()
+	err = provider.Get(context.Background(), orgID, "missing", new(CacheableA))
+	require.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteMany(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	provider := newTestProvider(t, db, 1<<20)
+
+	orgID := valuer.GenerateUUID()
+	keys := []string{strings.Join([]string{orgID.StringValue(), "a"}, "::"), strings.Join([]string{orgID.StringValue(), "b"}, "::")}
+	for _, key := range keys {
+		provider.setMemory(key, &CacheableA{Key: key}, []byte(key), time.Minute)
+	}
+
+	mock.ExpectDel(keys...).SetVal(2)
+	mock.ExpectPublish("invalidations", invalidationOf(t, provider.origin, keys...)).SetVal(1)
+	provider.DeleteMany(context.Background(), orgID, []string{"a", "b"})
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	for _, key := range keys {
+		_, found := provider.cc.Get(key)
+		assert.False(t, found)
+	}
+}
+
+func TestInvalidate(t *testing.T) {
+	db, _ := redismock.NewClientMock()
+	provider := newTestProvider(t, db, 1<<20)
+
+	provider.setMemory("org::a", &CacheableA{Key: "a"}, []byte("a"), time.Minute)
+	provider.setMemory("org::b", &CacheableA{Key: "b"}, []byte("b"), time.Minute)
+
+	// the invalidations of this replica are ignored
+	provider.invalidate(context.Background(), invalidationOf(t, provider.origin, "org::a"))
+	_, found := provider.cc.Get("org::a")
+	assert.True(t, found)
+
+	provider.invalidate(context.Background(), invalidationOf(t, valuer.GenerateUUID().StringValue(), "org::a"))
+	_, found = provider.cc.Get("org::a")
+	assert.False(t, found)
+	_, found = provider.cc.Get("org::b")
+	assert.True(t, found)
+}
+
+func TestSetLargerThanMemory(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	provider := newTestProvider(t, db, 16)
+
+	orgID := valuer.GenerateUUID()
+	key := strings.Join([]string{orgID.StringValue(), "key"}, "::")
+	cacheable := &CacheableA{Key: "a key larger than the memory tier", Value: 3}
+	raw, err := cacheable.MarshalBinary()
+	require.NoError(t, err)
+
+	mock.ExpectSet(key, raw, time.Minute).SetVal("OK")
+	mock.ExpectPublish("invalidations", invalidationOf(t, provider.origin, key)).SetVal(1)
+	require.NoError(t, provider.Set(context.Background(), orgID, "key", cacheable, time.Minute))
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	_, found := provider.cc.Get(key)
+	assert.False(t, found)
+}
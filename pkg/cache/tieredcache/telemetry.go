@@ -0,0 +1,40 @@
+package tieredcache
+
+import (
+	"github.com/SigNoz/signoz/pkg/errors"
+	"go.opentelemetry.io/otel/metric"
+)
+
+type telemetry struct {
+	hits          metric.Int64Counter
+	misses        metric.Int64Counter
+	invalidations metric.Int64Counter
+}
+
+func newMetrics(meter metric.Meter) (*telemetry, error) {
+	var errs error
+	hits, err := meter.Int64Counter("signoz.cache.tier.hits", metric.WithDescription("Hits is the number of Get calls where a value was found in the tier."))
+	if err != nil {
+		errs = errors.Join(errs, err)
+	}
+
+	misses, err := meter.Int64Counter("signoz.cache.tier.misses", metric.WithDescription("Misses is the number of Get calls where a value was not found in the tier."))
+	if err != nil {
+		errs = errors.Join(errs, err)
+	}
+
+	invalidations, err := meter.Int64Counter("signoz.cache.tier.invalidations", metric.WithDescription("Invalidations is the number of keys dropped from the memory tier as other replicas changed them."))
+	if err != nil {
+		errs = errors.Join(errs, err)
+	}
+
+	if errs != nil {
+		return nil, errs
+	}
+
+	return &telemetry{
+		hits:          hits,
+		misses:        misses,
+		invalidations: invalidations,
+	}, nil
+}
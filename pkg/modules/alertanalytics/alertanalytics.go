@@ -0,0 +1,34 @@
+package alertanalytics
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/SigNoz/signoz/pkg/types/alertanalyticstypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// Module analyses the episodes of the alerts recorded in the rule state history.
+type Module interface {
+	// GetTimeline returns the episodes of a rule or a label set, the latest first.
+	GetTimeline(ctx context.Context, orgID valuer.UUID, req *alertanalyticstypes.TimelineRequest) (*alertanalyticstypes.TimelineResponse, error)
+
+	// GetNoisiest returns the rules or label sets which fired the most.
+	GetNoisiest(ctx context.Context, orgID valuer.UUID, req *alertanalyticstypes.NoisiestRequest) (*alertanalyticstypes.NoisiestResponse, error)
+
+	// GetResponseTimes returns the mean time to resolve and acknowledge per rule, team or any other label.
+	GetResponseTimes(ctx context.Context, orgID valuer.UUID, req *alertanalyticstypes.ResponseTimesRequest) (*alertanalyticstypes.ResponseTimesResponse, error)
+
+	// GetFlapping returns the alerts which repeatedly fired and resolved quickly.
+	GetFlapping(ctx context.Context, orgID valuer.UUID, req *alertanalyticstypes.FlappingRequest) (*alertanalyticstypes.FlappingResponse, error)
+}
+
+type Handler interface {
+	GetTimeline(http.ResponseWriter, *http.Request)
+
+	GetNoisiest(http.ResponseWriter, *http.Request)
+
+	GetResponseTimes(http.ResponseWriter, *http.Request)
+
+	GetFlapping(http.ResponseWriter, *http.Request)
+}
@@ -0,0 +1,119 @@
+package implalertanalytics
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/http/render"
+	"github.com/SigNoz/signoz/pkg/modules/alertanalytics"
+	"github.com/SigNoz/signoz/pkg/types/alertanalyticstypes"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type handler struct {
+	module alertanalytics.Module
+}
+
+func NewHandler(module alertanalytics.Module) alertanalytics.Handler {
+	return &handler{
+		module: module,
+	}
+}
+
+func (h *handler) GetTimeline(w http.ResponseWriter, r *http.Request) {
+	claims, err := authtypes.ClaimsFromContext(r.Context())
+	if err != nil {
+		render.Error(w, err)
+		return
+	}
+
+	req := new(alertanalyticstypes.TimelineRequest)
+	if err := parseRequestBody(r, req, req.Validate); err != nil {
+		render.Error(w, err)
+		return
+	}
+
+	result, err := h.module.GetTimeline(r.Context(), valuer.MustNewUUID(claims.OrgID), req)
+	if err != nil {
+		render.Error(w, err)
+		return
+	}
+
+	render.Success(w, http.StatusOK, result)
+}
+
+func (h *handler) GetNoisiest(w http.ResponseWriter, r *http.Request) {
+	claims, err := authtypes.ClaimsFromContext(r.Context())
+	if err != nil {
+		render.Error(w, err)
+		return
+	}
+
+	req := new(alertanalyticstypes.NoisiestRequest)
+	if err := parseRequestBody(r, req, req.Validate); err != nil {
+		render.Error(w, err)
+		return
+	}
+
+	result, err := h.module.GetNoisiest(r.Context(), valuer.MustNewUUID(claims.OrgID), req)
+	if err != nil {
+		render.Error(w, err)
+		return
+	}
+
+	render.Success(w, http.StatusOK, result)
+}
+
+func (h *handler) GetResponseTimes(w http.ResponseWriter, r *http.Request) {
+	claims, err := authtypes.ClaimsFromContext(r.Context())
+	if err != nil {
+		render.Error(w, err)
+		return
+	}
+
+	req := new(alertanalyticstypes.ResponseTimesRequest)
+	if err := parseRequestBody(r, req, req.Validate); err != nil {
+		render.Error(w, err)
+		return
+	}
+
+	result, err := h.module.GetResponseTimes(r.Context(), valuer.MustNewUUID(claims.OrgID), req)
+	if err != nil {
+		render.Error(w, err)
+		return
+	}
+
+	render.Success(w, http.StatusOK, result)
+}
+
+func (h *handler) GetFlapping(w http.ResponseWriter, r *http.Request) {
+	claims, err := authtypes.ClaimsFromContext(r.Context())
+	if err != nil {
+		render.Error(w, err)
+		return
+	}
+
+	req := new(alertanalyticstypes.FlappingRequest)
+	if err := parseRequestBody(r, req, req.Validate); err != nil {
+		render.Error(w, err)
+		return
+	}
+
+	result, err := h.module.GetFlapping(r.Context(), valuer.MustNewUUID(claims.OrgID), req)
+	if err != nil {
+		render.Error(w, err)
+		return
+	}
+
+	render.Success(w, http.StatusOK, result)
+}
+
+func parseRequestBody(r *http.Request, req any, validate func() error) error {
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "cannot parse the request body: %v", err)
+	}
+
+	return validate()
+}
@@ -0,0 +1,66 @@
+package implalertanalytics
+
+import (
+	"context"
+
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/modules/alertanalytics"
+	"github.com/SigNoz/signoz/pkg/querier"
+	"github.com/SigNoz/signoz/pkg/types/alertanalyticstypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type module struct {
+	querier querier.Querier
+}
+
+func NewModule(querier querier.Querier, _ factory.ProviderSettings) alertanalytics.Module {
+	return &module{
+		querier: querier,
+	}
+}
+
+func (m *module) GetTimeline(ctx context.Context, orgID valuer.UUID, req *alertanalyticstypes.TimelineRequest) (*alertanalyticstypes.TimelineResponse, error) {
+	resp, err := m.queryRange(ctx, orgID, req.NewQueryRangeRequest())
+	if err != nil {
+		return nil, err
+	}
+
+	return alertanalyticstypes.NewTimelineResponse(resp)
+}
+
+func (m *module) GetNoisiest(ctx context.Context, orgID valuer.UUID, req *alertanalyticstypes.NoisiestRequest) (*alertanalyticstypes.NoisiestResponse, error) {
+	resp, err := m.queryRange(ctx, orgID, req.NewQueryRangeRequest())
+	if err != nil {
+		return nil, err
+	}
+
+	return alertanalyticstypes.NewNoisiestResponse(resp)
+}
+
+func (m *module) GetResponseTimes(ctx context.Context, orgID valuer.UUID, req *alertanalyticstypes.ResponseTimesRequest) (*alertanalyticstypes.ResponseTimesResponse, error) {
+	resp, err := m.queryRange(ctx, orgID, req.NewQueryRangeRequest())
+	if err != nil {
+		return nil, err
+	}
+
+	return alertanalyticstypes.NewResponseTimesResponse(resp)
+}
+
+func (m *module) GetFlapping(ctx context.Context, orgID valuer.UUID, req *alertanalyticstypes.FlappingRequest) (*alertanalyticstypes.FlappingResponse, error) {
+	resp, err := m.queryRange(ctx, orgID, req.NewQueryRangeRequest())
+	if err != nil {
+		return nil, err
+	}
+
+	return alertanalyticstypes.NewFlappingResponse(resp)
+}
+
+func (m *module) queryRange(ctx context.Context, orgID valuer.UUID, req *qbtypes.QueryRangeRequest) (*qbtypes.QueryRangeResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	return m.querier.QueryRange(ctx, orgID, req)
+}
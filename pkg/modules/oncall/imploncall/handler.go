@@ -0,0 +1,255 @@
+package imploncall
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/http/binding"
+	"github.com/SigNoz/signoz/pkg/http/render"
+	"github.com/SigNoz/signoz/pkg/modules/oncall"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	"github.com/SigNoz/signoz/pkg/types/oncalltypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/gorilla/mux"
+)
+
+type handler struct {
+	module oncall.Module
+}
+
+func NewHandler(module oncall.Module) oncall.Handler {
+	return &handler{module: module}
+}
+
+// Endpoint: POST /api/v1/oncall_schedules
+func (handler *handler) Create(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	body := new(oncalltypes.PostableSchedule)
+	if err := binding.JSON.BindBody(r.Body, body); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	schedule, err := handler.module.Create(ctx, valuer.MustNewUUID(claims.OrgID), claims.Email, body)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusCreated, schedule)
+}
+
+// Endpoint: GET /api/v1/oncall_schedules/{id}
+func (handler *handler) Get(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	id, err := valuer.NewUUID(mux.Vars(r)["id"])
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	schedule, err := handler.module.Get(ctx, valuer.MustNewUUID(claims.OrgID), id)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, schedule)
+}
+
+// Endpoint: GET /api/v1/oncall_schedules
+func (handler *handler) List(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	schedules, err := handler.module.List(ctx, valuer.MustNewUUID(claims.OrgID))
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, schedules)
+}
+
+// Replaces the rotations of a schedule, its overrides are kept.
+//
+// Endpoint: PUT /api/v1/oncall_schedules/{id}
+func (handler *handler) Update(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	id, err := valuer.NewUUID(mux.Vars(r)["id"])
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	body := new(oncalltypes.PostableSchedule)
+	if err := binding.JSON.BindBody(r.Body, body); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	schedule, err := handler.module.Update(ctx, valuer.MustNewUUID(claims.OrgID), id, claims.Email, body)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, schedule)
+}
+
+// Endpoint: DELETE /api/v1/oncall_schedules/{id}
+func (handler *handler) Delete(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	id, err := valuer.NewUUID(mux.Vars(r)["id"])
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	if err := handler.module.Delete(ctx, valuer.MustNewUUID(claims.OrgID), id); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusNoContent, nil)
+}
+
+// Endpoint: POST /api/v1/oncall_schedules/{id}/overrides
+func (handler *handler) CreateOverride(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	id, err := valuer.NewUUID(mux.Vars(r)["id"])
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	body := new(oncalltypes.PostableOverride)
+	if err := binding.JSON.BindBody(r.Body, body); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	override, err := handler.module.CreateOverride(ctx, valuer.MustNewUUID(claims.OrgID), id, claims.Email, body)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusCreated, override)
+}
+
+// Endpoint: DELETE /api/v1/oncall_schedules/{id}/overrides/{overrideId}
+func (handler *handler) DeleteOverride(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	id, err := valuer.NewUUID(mux.Vars(r)["id"])
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	overrideID, err := valuer.NewUUID(mux.Vars(r)["overrideId"])
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	if err := handler.module.DeleteOverride(ctx, valuer.MustNewUUID(claims.OrgID), id, overrideID, claims.Email); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusNoContent, nil)
+}
+
+// Returns who is on call of a schedule at the RFC3339 time of the time query parameter, or now if not set.
+//
+// Endpoint: GET /api/v1/oncall_schedules/{id}/oncall
+func (handler *handler) GetOnCall(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	id, err := valuer.NewUUID(mux.Vars(r)["id"])
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	at := time.Now()
+	if value := r.URL.Query().Get("time"); value != "" {
+		at, err = time.Parse(time.RFC3339, value)
+		if err != nil {
+			render.Error(rw, errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid time %s, expected an RFC3339 time", value))
+			return
+		}
+	}
+
+	onCall, err := handler.module.GetOnCall(ctx, valuer.MustNewUUID(claims.OrgID), id, at)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, onCall)
+}
@@ -0,0 +1,171 @@
+package imploncall
+
+import (
+	"context"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/alertmanager"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/modules/oncall"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+	"github.com/SigNoz/signoz/pkg/types/oncalltypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type module struct {
+	store        oncalltypes.Store
+	alertmanager alertmanager.Alertmanager
+}
+
+func NewModule(store oncalltypes.Store, alertmanager alertmanager.Alertmanager) oncall.Module {
+	return &module{store: store, alertmanager: alertmanager}
+}
+
+func (module *module) Create(ctx context.Context, orgID valuer.UUID, createdBy string, postable *oncalltypes.PostableSchedule) (*oncalltypes.Schedule, error) {
+	schedule, err := oncalltypes.NewSchedule(orgID, createdBy, postable)
+	if err != nil {
+		return nil, err
+	}
+
+	storable, err := oncalltypes.NewStorableScheduleFromSchedule(schedule)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := module.store.Create(ctx, storable); err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+func (module *module) Get(ctx context.Context, orgID valuer.UUID, id valuer.UUID) (*oncalltypes.Schedule, error) {
+	storable, err := module.store.Get(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return oncalltypes.NewScheduleFromStorableSchedule(storable)
+}
+
+func (module *module) List(ctx context.Context, orgID valuer.UUID) ([]*oncalltypes.Schedule, error) {
+	storables, err := module.store.List(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules := make([]*oncalltypes.Schedule, 0, len(storables))
+	for _, storable := range storables {
+		schedule, err := oncalltypes.NewScheduleFromStorableSchedule(storable)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
+	}
+
+	return schedules, nil
+}
+
+func (module *module) Update(ctx context.Context, orgID valuer.UUID, id valuer.UUID, updatedBy string, postable *oncalltypes.PostableSchedule) (*oncalltypes.Schedule, error) {
+	var schedule *oncalltypes.Schedule
+	err := module.mutate(ctx, orgID, id, func(mutable *oncalltypes.Schedule) error {
+		schedule = mutable
+		return schedule.Update(updatedBy, postable)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+func (module *module) Delete(ctx context.Context, orgID valuer.UUID, id valuer.UUID) error {
+	if _, err := module.store.Get(ctx, orgID, id); err != nil {
+		return err
+	}
+
+	channels, err := module.alertmanager.ListChannels(ctx, orgID.StringValue())
+	if err != nil {
+		return err
+	}
+
+	// the channels targeting the schedule would fail to send their notifications
+	for _, channel := range channels {
+		if targetsSchedule(channel, id) {
+			return errors.Newf(errors.TypeInvalidInput, oncalltypes.ErrCodeScheduleInUse, "oncall schedule with id %s is targeted by the channel %s, update or delete the channel first", id, channel.Name)
+		}
+	}
+
+	return module.store.Delete(ctx, orgID, id)
+}
+
+func (module *module) CreateOverride(ctx context.Context, orgID valuer.UUID, id valuer.UUID, createdBy string, postable *oncalltypes.PostableOverride) (*oncalltypes.Override, error) {
+	var override *oncalltypes.Override
+	err := module.mutate(ctx, orgID, id, func(schedule *oncalltypes.Schedule) error {
+		var err error
+		override, err = schedule.AddOverride(createdBy, postable)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return override, nil
+}
+
+func (module *module) DeleteOverride(ctx context.Context, orgID valuer.UUID, id valuer.UUID, overrideID valuer.UUID, deletedBy string) error {
+	return module.mutate(ctx, orgID, id, func(schedule *oncalltypes.Schedule) error {
+		return schedule.DeleteOverride(deletedBy, overrideID)
+	})
+}
+
+func (module *module) GetOnCall(ctx context.Context, orgID valuer.UUID, id valuer.UUID, at time.Time) (*oncalltypes.OnCall, error) {
+	schedule, err := module.Get(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return schedule.OnCall(at), nil
+}
+
+// mutate reads the schedule, applies fn and writes the schedule back in a single transaction, so that concurrent
+// changes of the same schedule don't overwrite each other.
+func (module *module) mutate(ctx context.Context, orgID valuer.UUID, id valuer.UUID, fn func(*oncalltypes.Schedule) error) error {
+	return module.store.RunInTx(ctx, func(ctx context.Context) error {
+		schedule, err := module.Get(ctx, orgID, id)
+		if err != nil {
+			return err
+		}
+
+		if err := fn(schedule); err != nil {
+			return err
+		}
+
+		storable, err := oncalltypes.NewStorableScheduleFromSchedule(schedule)
+		if err != nil {
+			return err
+		}
+
+		return module.store.Update(ctx, storable)
+	})
+}
+
+// targetsSchedule returns whether a webhook config of the channel targets the schedule.
+func targetsSchedule(channel *alertmanagertypes.Channel, id valuer.UUID) bool {
+	receiver, err := alertmanagertypes.NewReceiver(channel.Data)
+	if err != nil {
+		return false
+	}
+
+	for _, webhookConfig := range receiver.WebhookConfigs {
+		if webhookConfig == nil || webhookConfig.URL == nil {
+			continue
+		}
+
+		if scheduleID, ok := oncalltypes.ScheduleIDFromChannelURL(webhookConfig.URL.URL); ok && scheduleID == id {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,49 @@
+package oncall
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/types/oncalltypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type Module interface {
+	Create(ctx context.Context, orgID valuer.UUID, createdBy string, postable *oncalltypes.PostableSchedule) (*oncalltypes.Schedule, error)
+
+	Get(ctx context.Context, orgID valuer.UUID, id valuer.UUID) (*oncalltypes.Schedule, error)
+
+	List(ctx context.Context, orgID valuer.UUID) ([]*oncalltypes.Schedule, error)
+
+	// Update replaces the rotations of a schedule, its overrides are kept.
+	Update(ctx context.Context, orgID valuer.UUID, id valuer.UUID, updatedBy string, postable *oncalltypes.PostableSchedule) (*oncalltypes.Schedule, error)
+
+	Delete(ctx context.Context, orgID valuer.UUID, id valuer.UUID) error
+
+	// CreateOverride temporarily puts a participant on call in place of the rotations of a schedule.
+	CreateOverride(ctx context.Context, orgID valuer.UUID, id valuer.UUID, createdBy string, postable *oncalltypes.PostableOverride) (*oncalltypes.Override, error)
+
+	DeleteOverride(ctx context.Context, orgID valuer.UUID, id valuer.UUID, overrideID valuer.UUID, deletedBy string) error
+
+	// GetOnCall returns who is on call of a schedule at the given time.
+	GetOnCall(ctx context.Context, orgID valuer.UUID, id valuer.UUID, at time.Time) (*oncalltypes.OnCall, error)
+}
+
+type Handler interface {
+	Create(http.ResponseWriter, *http.Request)
+
+	Get(http.ResponseWriter, *http.Request)
+
+	List(http.ResponseWriter, *http.Request)
+
+	Update(http.ResponseWriter, *http.Request)
+
+	Delete(http.ResponseWriter, *http.Request)
+
+	CreateOverride(http.ResponseWriter, *http.Request)
+
+	DeleteOverride(http.ResponseWriter, *http.Request)
+
+	GetOnCall(http.ResponseWriter, *http.Request)
+}
@@ -0,0 +1,508 @@
+package implrawdataexport
+
+import (
+	"io"
+	"math"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/telemetrylogs"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/compress"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+)
+
+// recordWriter is the common surface of the parquet and arrow IPC writers.
+// Every record passed to Write is flushed as its own row group (parquet) or batch (arrow).
+type recordWriter interface {
+	Write(rec arrow.Record) error
+	Close() error
+}
+
+type newRecordWriterFunc func(schema *arrow.Schema, writer io.Writer) (recordWriter, error)
+
+func newParquetRecordWriter(schema *arrow.Schema, writer io.Writer) (recordWriter, error) {
+	props := parquet.NewWriterProperties(
+		parquet.WithCompression(compress.Codecs.Zstd),
+		parquet.WithMaxRowGroupLength(RowGroupSize),
+		parquet.WithCreatedBy("signoz"),
+	)
+
+	fileWriter, err := pqarrow.NewFileWriter(schema, writer, props, pqarrow.NewArrowWriterProperties(pqarrow.WithStoreSchema()))
+	if err != nil {
+		return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to create parquet writer")
+	}
+
+	return fileWriter, nil
+}
+
+func newArrowRecordWriter(schema *arrow.Schema, writer io.Writer) (recordWriter, error) {
+	return ipc.NewWriter(writer, ipc.WithSchema(schema), ipc.WithAllocator(memory.DefaultAllocator)), nil
+}
+
+func exportColumnar(rowChan <-chan *qbtypes.RawRow, errChan <-chan error, columns []telemetrytypes.TelemetryFieldKey, dataTypes map[string]telemetrytypes.FieldDataType, writer io.Writer, newRecordWriter newRecordWriterFunc) (bool, error) {
+	var (
+		builder      *array.RecordBuilder
+		recWriter    recordWriter
+		totalBytes   uint64
+		bufferedRows int
+	)
+
+	defer func() {
+		if builder != nil {
+			builder.Release()
+		}
+	}()
+
+	start := func(data map[string]any) error {
+		schema := constructArrowSchemaFromQueryResponse(data, columns, dataTypes)
+
+		var err error
+		recWriter, err = newRecordWriter(schema, writer)
+		if err != nil {
+			return err
+		}
+
+		builder = array.NewRecordBuilder(memory.DefaultAllocator, schema)
+		return nil
+	}
+
+	flush := func() error {
+		if bufferedRows == 0 {
+			return nil
+		}
+
+		rec := builder.NewRecord()
+		defer rec.Release()
+		bufferedRows = 0
+
+		totalBytes += getSizeOfRecord(rec)
+		if err := recWriter.Write(rec); err != nil {
+			return errors.WrapInternalf(err, errors.CodeInternal, "error writing record batch")
+		}
+
+		return nil
+	}
+
+	finish := func() error {
+		if builder == nil {
+			// no rows were received, write a file which only carries the schema
+			if err := start(nil); err != nil {
+				return err
+			}
+		}
+
+		if err := flush(); err != nil {
+			return err
+		}
+
+		if err := recWriter.Close(); err != nil {
+			return errors.WrapInternalf(err, errors.CodeInternal, "error closing writer")
+		}
+
+		return nil
+	}
+
+	for {
+		select {
+		case row, ok := <-rowChan:
+			if !ok {
+				if err := finish(); err != nil {
+					return false, err
+				}
+				return true, nil
+			}
+
+			if builder == nil {
+				if err := start(row.Data); err != nil {
+					return false, err
+				}
+			}
+
+			for i, field := range builder.Schema().Fields() {
+				appendArrowValue(builder.Field(i), row.Data[field.Name])
+			}
+			bufferedRows++
+
+			if bufferedRows < RowGroupSize {
+				continue
+			}
+
+			if err := flush(); err != nil {
+				return false, err
+			}
+
+			if totalBytes > MaxExportBytesLimit {
+				if err := finish(); err != nil {
+					return false, err
+				}
+				return false, nil
+			}
+		case err := <-errChan:
+			if err != nil {
+				return false, err
+			}
+		}
+	}
+}
+
+// constructArrowSchemaFromQueryResponse builds the schema for a columnar export.
+// The type of a column is taken from the telemetry field metadata when it is known, otherwise
+// it is inferred from the value in the first row. Timestamp and id always come first followed by the rest of the
+// columns sorted by name.
+func constructArrowSchemaFromQueryResponse(data map[string]any, columns []telemetrytypes.TelemetryFieldKey, dataTypes map[string]telemetrytypes.FieldDataType) *arrow.Schema {
+	names := make([]string, 0, len(data))
+	if len(data) > 0 {
+		for name := range data {
+			names = append(names, name)
+		}
+	} else {
+		// without a sample row fall back to the requested columns
+		names = append(names, telemetrylogs.LogsV2TimestampColumn, telemetrylogs.LogsV2IDColumn)
+		for _, column := range columns {
+			if !slices.Contains(names, column.Name) {
+				names = append(names, column.Name)
+			}
+		}
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		ri, rj := columnRank(names[i]), columnRank(names[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return names[i] < names[j]
+	})
+
+	fields := make([]arrow.Field, 0, len(names))
+	for _, name := range names {
+		var dataType arrow.DataType
+		if fieldDataType, ok := dataTypes[name]; ok {
+			dataType = arrowTypeForFieldDataType(fieldDataType)
+		}
+
+		if dataType == nil {
+			dataType = arrowTypeForColumn(name, data[name])
+		}
+
+		fields = append(fields, arrow.Field{Name: name, Type: dataType, Nullable: true})
+	}
+
+	return arrow.NewSchema(fields, nil)
+}
+
+func columnRank(name string) int {
+	switch name {
+	case telemetrylogs.LogsV2TimestampColumn:
+		return 0
+	case telemetrylogs.LogsV2IDColumn:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func arrowTypeForFieldDataType(fieldDataType telemetrytypes.FieldDataType) arrow.DataType {
+	switch fieldDataType {
+	case telemetrytypes.FieldDataTypeString:
+		return arrow.BinaryTypes.String
+	case telemetrytypes.FieldDataTypeBool:
+		return arrow.FixedWidthTypes.Boolean
+	case telemetrytypes.FieldDataTypeInt64:
+		return arrow.PrimitiveTypes.Int64
+	case telemetrytypes.FieldDataTypeFloat64, telemetrytypes.FieldDataTypeNumber:
+		return arrow.PrimitiveTypes.Float64
+	case telemetrytypes.FieldDataTypeArrayString:
+		return arrow.ListOf(arrow.BinaryTypes.String)
+	case telemetrytypes.FieldDataTypeArrayBool:
+		return arrow.ListOf(arrow.FixedWidthTypes.Boolean)
+	case telemetrytypes.FieldDataTypeArrayInt64:
+		return arrow.ListOf(arrow.PrimitiveTypes.Int64)
+	case telemetrytypes.FieldDataTypeArrayFloat64, telemetrytypes.FieldDataTypeArrayNumber:
+		return arrow.ListOf(arrow.PrimitiveTypes.Float64)
+	default:
+		return nil
+	}
+}
+
+// arrowTypeForColumn infers the arrow type of a column without metadata from its Go value.
+func arrowTypeForColumn(name string, value any) arrow.DataType {
+	// timestamp is stored as epoch nanoseconds in the logs table
+	if name == telemetrylogs.LogsV2TimestampColumn {
+		return arrow.FixedWidthTypes.Timestamp_ns
+	}
+
+	if value == nil {
+		return arrow.BinaryTypes.String
+	}
+
+	return arrowTypeForGoType(reflect.TypeOf(value))
+}
+
+func arrowTypeForGoType(typ reflect.Type) arrow.DataType {
+	if typ == reflect.TypeOf(time.Time{}) {
+		return arrow.FixedWidthTypes.Timestamp_ns
+	}
+
+	switch typ.Kind() {
+	case reflect.Pointer:
+		return arrowTypeForGoType(typ.Elem())
+	case reflect.String:
+		return arrow.BinaryTypes.String
+	case reflect.Bool:
+		return arrow.FixedWidthTypes.Boolean
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return arrow.PrimitiveTypes.Int64
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return arrow.PrimitiveTypes.Uint64
+	case reflect.Float32, reflect.Float64:
+		return arrow.PrimitiveTypes.Float64
+	case reflect.Map:
+		if typ.Key().Kind() != reflect.String {
+			return arrow.BinaryTypes.String
+		}
+		itemType := arrowTypeForGoType(typ.Elem())
+		if isNestedArrowType(itemType) {
+			return arrow.BinaryTypes.String
+		}
+		return arrow.MapOf(arrow.BinaryTypes.String, itemType)
+	case reflect.Slice, reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			return arrow.BinaryTypes.String
+		}
+		itemType := arrowTypeForGoType(typ.Elem())
+		if isNestedArrowType(itemType) {
+			return arrow.BinaryTypes.String
+		}
+		return arrow.ListOf(itemType)
+	default:
+		// structs, interfaces and everything else is exported as JSON
+		return arrow.BinaryTypes.String
+	}
+}
+
+func isNestedArrowType(dataType arrow.DataType) bool {
+	switch dataType.ID() {
+	case arrow.MAP, arrow.LIST:
+		return true
+	default:
+		return false
+	}
+}
+
+// appendArrowValue appends the value to the builder, coercing it to the builder's type.
+// Values which can not be coerced are appended as nulls.
+func appendArrowValue(builder array.Builder, value any) {
+	if value == nil {
+		builder.AppendNull()
+		return
+	}
+
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			builder.AppendNull()
+			return
+		}
+		rv = rv.Elem()
+	}
+	value = rv.Interface()
+
+	switch b := builder.(type) {
+	case *array.StringBuilder:
+		b.Append(stringifyValue(value))
+	case *array.BooleanBuilder:
+		if v, ok := toBool(value); ok {
+			b.Append(v)
+			return
+		}
+		b.AppendNull()
+	case *array.Int64Builder:
+		if v, ok := toInt64(value); ok {
+			b.Append(v)
+			return
+		}
+		b.AppendNull()
+	case *array.Uint64Builder:
+		if v, ok := toUint64(value); ok {
+			b.Append(v)
+			return
+		}
+		b.AppendNull()
+	case *array.Float64Builder:
+		if v, ok := toFloat64(value); ok {
+			b.Append(v)
+			return
+		}
+		b.AppendNull()
+	case *array.TimestampBuilder:
+		switch v := value.(type) {
+		case time.Time:
+			b.Append(arrow.Timestamp(v.UnixNano()))
+		case uint64:
+			b.Append(arrow.Timestamp(int64(v)))
+		case int64:
+			b.Append(arrow.Timestamp(v))
+		default:
+			b.AppendNull()
+		}
+	case *array.MapBuilder:
+		if rv.Kind() != reflect.Map {
+			b.AppendNull()
+			return
+		}
+		keys := rv.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+
+		b.Append(true)
+		for _, key := range keys {
+			b.KeyBuilder().(*array.StringBuilder).Append(key.String())
+			appendArrowValue(b.ItemBuilder(), rv.MapIndex(key).Interface())
+		}
+	case *array.ListBuilder:
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			b.AppendNull()
+			return
+		}
+
+		b.Append(true)
+		for i := 0; i < rv.Len(); i++ {
+			appendArrowValue(b.ValueBuilder(), rv.Index(i).Interface())
+		}
+	default:
+		builder.AppendNull()
+	}
+}
+
+func toFloat64(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	case bool:
+		if v {
+			return 1, true
+		}
+		return 0, true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// toInt64 converts the integers without going through a float, so that they don't lose precision above 2^53.
+func toInt64(value any) (int64, bool) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if rv.Uint() > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(rv.Uint()), true
+	case reflect.String:
+		if v, err := strconv.ParseInt(rv.String(), 10, 64); err == nil {
+			return v, true
+		}
+	}
+
+	f, ok := toFloat64(value)
+	if !ok || math.IsNaN(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, false
+	}
+	return int64(f), true
+}
+
+// toUint64 converts the integers without going through a float, so that they don't lose precision above 2^53.
+func toUint64(value any) (uint64, bool) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if rv.Int() < 0 {
+			return 0, false
+		}
+		return uint64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return rv.Uint(), true
+	case reflect.String:
+		if v, err := strconv.ParseUint(rv.String(), 10, 64); err == nil {
+			return v, true
+		}
+	}
+
+	f, ok := toFloat64(value)
+	if !ok || math.IsNaN(f) || f < 0 || f >= math.MaxUint64 {
+		return 0, false
+	}
+	return uint64(f), true
+}
+
+func toBool(value any) (bool, bool) {
+	switch v := value.(type) {
+	case bool:
+		return v, true
+	case string:
+		b, err := strconv.ParseBool(v)
+		return b, err == nil
+	default:
+		if f, ok := toFloat64(v); ok {
+			return f != 0, true
+		}
+		return false, false
+	}
+}
+
+func getSizeOfRecord(rec arrow.Record) uint64 {
+	var totalBytes uint64
+	for _, column := range rec.Columns() {
+		totalBytes += getSizeOfArrayData(column.Data())
+	}
+	return totalBytes
+}
+
+func getSizeOfArrayData(data arrow.ArrayData) uint64 {
+	var totalBytes uint64
+	for _, buf := range data.Buffers() {
+		if buf != nil {
+			totalBytes += uint64(buf.Len())
+		}
+	}
+	for _, child := range data.Children() {
+		totalBytes += getSizeOfArrayData(child)
+	}
+	return totalBytes
+}
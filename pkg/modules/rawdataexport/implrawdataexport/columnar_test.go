@@ -0,0 +1,194 @@
+package implrawdataexport
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"testing"
+
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRawRows(n int) (chan *qbtypes.RawRow, chan error) {
+	rowChan := make(chan *qbtypes.RawRow, n)
+	errChan := make(chan error, 1)
+
+	for i := 0; i < n; i++ {
+		rowChan <- &qbtypes.RawRow{
+			Data: map[string]any{
+				"timestamp":         uint64(1693612800000000000 + i),
+				"id":                "log-id",
+				"body":              "hello",
+				"severity_number":   uint8(9),
+				"attributes_string": map[string]string{"service.name": "frontend"},
+				"attributes_number": map[string]float64{"http.status_code": 200},
+				"duration":          "12.5",
+			},
+		}
+	}
+	close(rowChan)
+
+	return rowChan, errChan
+}
+
+func TestConstructArrowSchemaFromQueryResponse(t *testing.T) {
+	data := map[string]any{
+		"timestamp":         uint64(1693612800000000000),
+		"id":                "log-id",
+		"body":              "hello",
+		"severity_number":   uint8(9),
+		"attributes_string": map[string]string{"service.name": "frontend"},
+		"attributes_bool":   map[string]bool{"retry": true},
+		"duration":          "12.5",
+		"tags":              []string{"a", "b"},
+	}
+
+	dataTypes := map[string]telemetrytypes.FieldDataType{
+		"duration": telemetrytypes.FieldDataTypeFloat64,
+	}
+
+	schema := constructArrowSchemaFromQueryResponse(data, nil, dataTypes)
+
+	expected := []arrow.Field{
+		{Name: "timestamp", Type: arrow.FixedWidthTypes.Timestamp_ns, Nullable: true},
+		{Name: "id", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "attributes_bool", Type: arrow.MapOf(arrow.BinaryTypes.String, arrow.FixedWidthTypes.Boolean), Nullable: true},
+		{Name: "attributes_string", Type: arrow.MapOf(arrow.BinaryTypes.String, arrow.BinaryTypes.String), Nullable: true},
+		{Name: "body", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "duration", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: "severity_number", Type: arrow.PrimitiveTypes.Uint64, Nullable: true},
+		{Name: "tags", Type: arrow.ListOf(arrow.BinaryTypes.String), Nullable: true},
+	}
+
+	require.Len(t, schema.Fields(), len(expected))
+	for i, field := range schema.Fields() {
+		assert.Equal(t, expected[i].Name, field.Name)
+		assert.True(t, arrow.TypeEqual(expected[i].Type, field.Type), "unexpected type %s for %s", field.Type, field.Name)
+	}
+}
+
+func TestConstructArrowSchemaFromQueryResponseWithoutRows(t *testing.T) {
+	columns := []telemetrytypes.TelemetryFieldKey{{Name: "body"}, {Name: "timestamp"}}
+
+	schema := constructArrowSchemaFromQueryResponse(nil, columns, nil)
+
+	names := make([]string, 0, len(schema.Fields()))
+	for _, field := range schema.Fields() {
+		names = append(names, field.Name)
+	}
+	assert.Equal(t, []string{"timestamp", "id", "body"}, names)
+}
+
+func TestExportLogsParquet(t *testing.T) {
+	rowChan, errChan := newTestRawRows(RowGroupSize + 5)
+	dataTypes := map[string]telemetrytypes.FieldDataType{
+		"duration": telemetrytypes.FieldDataTypeFloat64,
+	}
+
+	var buf bytes.Buffer
+	isComplete, err := exportColumnar(rowChan, errChan, nil, dataTypes, &buf, newParquetRecordWriter)
+	require.NoError(t, err)
+	assert.True(t, isComplete)
+
+	table, err := pqarrow.ReadTable(context.Background(), bytes.NewReader(buf.Bytes()), parquet.NewReaderProperties(memory.DefaultAllocator), pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	require.NoError(t, err)
+	defer table.Release()
+
+	assert.Equal(t, int64(RowGroupSize+5), table.NumRows())
+
+	durationIdx := table.Schema().FieldIndices("duration")
+	require.Len(t, durationIdx, 1)
+	duration := table.Column(durationIdx[0]).Data().Chunk(0).(*array.Float64)
+	assert.Equal(t, 12.5, duration.Value(0))
+
+	timestampIdx := table.Schema().FieldIndices("timestamp")
+	require.Len(t, timestampIdx, 1)
+	timestamp := table.Column(timestampIdx[0]).Data().Chunk(0).(*array.Timestamp)
+	assert.Equal(t, arrow.Timestamp(1693612800000000000), timestamp.Value(0))
+}
+
+func TestExportLogsArrow(t *testing.T) {
+	rowChan, errChan := newTestRawRows(RowGroupSize + 5)
+
+	var buf bytes.Buffer
+	isComplete, err := exportColumnar(rowChan, errChan, nil, nil, &buf, newArrowRecordWriter)
+	require.NoError(t, err)
+	assert.True(t, isComplete)
+
+	reader, err := ipc.NewReader(&buf)
+	require.NoError(t, err)
+	defer reader.Release()
+
+	var batches, rows int64
+	for reader.Next() {
+		rec := reader.Record()
+		batches++
+		rows += rec.NumRows()
+
+		attributesIdx := rec.Schema().FieldIndices("attributes_number")
+		require.Len(t, attributesIdx, 1)
+		attributes := rec.Column(attributesIdx[0]).(*array.Map)
+		assert.Equal(t, 200.0, attributes.Items().(*array.Float64).Value(0))
+	}
+	require.NoError(t, reader.Err())
+
+	assert.Equal(t, int64(2), batches)
+	assert.Equal(t, int64(RowGroupSize+5), rows)
+}
+
+func TestExportLogsColumnarWithoutRows(t *testing.T) {
+	rowChan, errChan := newTestRawRows(0)
+
+	var buf bytes.Buffer
+	isComplete, err := exportColumnar(rowChan, errChan, nil, nil, &buf, newParquetRecordWriter)
+	require.NoError(t, err)
+	assert.True(t, isComplete)
+
+	table, err := pqarrow.ReadTable(context.Background(), bytes.NewReader(buf.Bytes()), parquet.NewReaderProperties(memory.DefaultAllocator), pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	require.NoError(t, err)
+	defer table.Release()
+
+	assert.Equal(t, int64(0), table.NumRows())
+}
+
+func TestExportColumnarKeepsIntegerPrecision(t *testing.T) {
+	rowChan := make(chan *qbtypes.RawRow, 1)
+	errChan := make(chan error, 1)
+	rowChan <- &qbtypes.RawRow{
+		Data: map[string]any{
+			"timestamp":          uint64(1693612800000000000),
+			"max_int":            int64(math.MaxInt64),
+			"observed_timestamp": uint64(1<<63 + 1),
+		},
+	}
+	close(rowChan)
+
+	var buf bytes.Buffer
+	isComplete, err := exportColumnar(rowChan, errChan, nil, nil, &buf, newArrowRecordWriter)
+	require.NoError(t, err)
+	assert.True(t, isComplete)
+
+	reader, err := ipc.NewReader(&buf)
+	require.NoError(t, err)
+	defer reader.Release()
+
+	require.True(t, reader.Next())
+	rec := reader.Record()
+
+	maxIntIdx := rec.Schema().FieldIndices("max_int")
+	require.Len(t, maxIntIdx, 1)
+	assert.Equal(t, int64(math.MaxInt64), rec.Column(maxIntIdx[0]).(*array.Int64).Value(0))
+
+	observedIdx := rec.Schema().FieldIndices("observed_timestamp")
+	require.Len(t, observedIdx, 1)
+	assert.Equal(t, uint64(1<<63+1), rec.Column(observedIdx[0]).(*array.Uint64).Value(0))
+}
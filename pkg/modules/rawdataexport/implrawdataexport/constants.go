@@ -16,4 +16,15 @@ const (
 	ChunkSize                         = 5_000 // 5k
 	ClickhouseExportRawDataMaxThreads = 2
 	ClickhouseExportRawDataTimeout    = 10 * time.Minute
+
+	// Job Limits
+	MaxExportJobRowCountLimit = 10_000_000 // 10M, also the default when the request has no limit
+	ExportJobChunkSize        = 100_000    // 100k rows per object of an export job
+	ExportJobPollInterval     = 10 * time.Second
+	MaxConcurrentExportJobs   = 2
+	ExportJobTTL              = 7 * 24 * time.Hour // finished jobs and their chunks are deleted after this long
+	ExportJobSweepInterval    = time.Hour
+
+	// Columnar Limits
+	RowGroupSize = 10_000 // 10k rows per parquet row group / arrow record batch
 )
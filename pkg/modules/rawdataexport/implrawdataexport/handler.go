@@ -1,6 +1,7 @@
 package implrawdataexport
 
 import (
+	"context"
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
@@ -14,13 +15,16 @@ import (
 	"unicode/utf8"
 
 	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/http/binding"
 	"github.com/SigNoz/signoz/pkg/http/render"
 	"github.com/SigNoz/signoz/pkg/modules/rawdataexport"
 	"github.com/SigNoz/signoz/pkg/telemetrylogs"
 	"github.com/SigNoz/signoz/pkg/types/authtypes"
 	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/rawdataexporttypes"
 	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/gorilla/mux"
 )
 
 type handler struct {
@@ -41,7 +45,8 @@ func NewHandler(module rawdataexport.Module) rawdataexport.Handler {
 //   - source (optional): Type of data to export ["logs" (default), "metrics", "traces"]
 //     Note: Currently only "logs" is fully supported
 //
-//   - format (optional): Output format ["csv" (default), "jsonl"]
+//   - format (optional): Output format ["csv" (default), "jsonl", "parquet", "arrow"]
+//     Note: "parquet" and "arrow" (Arrow IPC stream) keep the column types from the telemetry field metadata
 //
 //   - start (required): Start time for query (Unix timestamp in nanoseconds)
 //
@@ -61,7 +66,7 @@ func NewHandler(module rawdataexport.Module) rawdataexport.Handler {
 //     Default: ["timestamp:desc", "id:desc"]
 //
 // Response Headers:
-//   - Content-Type: "text/csv" or "application/x-ndjson"
+//   - Content-Type: "text/csv", "application/x-ndjson", "application/vnd.apache.parquet" or "application/vnd.apache.arrow.stream"
 //   - Content-Encoding: "gzip" (handled by HTTP middleware)
 //   - Content-Disposition: "attachment; filename=\"data_exported.[format]\""
 //   - Cache-Control: "no-cache"
@@ -73,6 +78,8 @@ func NewHandler(module rawdataexport.Module) rawdataexport.Handler {
 //
 //	CSV: Headers in first row, data in subsequent rows
 //	JSONL: One JSON object per line
+//	Parquet: Single parquet file, rows are written in row groups of RowGroupSize
+//	Arrow: Arrow IPC stream, rows are written in record batches of RowGroupSize
 //
 // Example Usage:
 //
@@ -195,6 +202,15 @@ func (handler *handler) exportLogs(rw http.ResponseWriter, r *http.Request) {
 
 	queryRangeRequest.CompositeQuery.Queries[0].Spec = spec
 
+	var dataTypes map[string]telemetrytypes.FieldDataType
+	if format == "parquet" || format == "arrow" {
+		dataTypes, err = handler.module.GetColumnDataTypes(r.Context(), orgID, &queryRangeRequest)
+		if err != nil {
+			render.Error(rw, err)
+			return
+		}
+	}
+
 	// This will signal Export module to stop sending data
 	doneChan := make(chan any)
 	defer close(doneChan)
@@ -206,7 +222,7 @@ func (handler *handler) exportLogs(rw http.ResponseWriter, r *http.Request) {
 	case "csv", "":
 		rw.Header().Set("Content-Type", "text/csv")
 		csvWriter := csv.NewWriter(rw)
-		isComplete, err = handler.exportLogsCSV(rowChan, errChan, csvWriter)
+		isComplete, err = exportCSV(rowChan, errChan, csvWriter)
 		if err != nil {
 			render.Error(rw, err)
 			return
@@ -214,20 +230,34 @@ func (handler *handler) exportLogs(rw http.ResponseWriter, r *http.Request) {
 		csvWriter.Flush()
 	case "jsonl":
 		rw.Header().Set("Content-Type", "application/x-ndjson")
-		isComplete, err = handler.exportLogsJSONL(rowChan, errChan, rw)
+		isComplete, err = exportJSONL(rowChan, errChan, rw)
+		if err != nil {
+			render.Error(rw, err)
+			return
+		}
+	case "parquet":
+		rw.Header().Set("Content-Type", "application/vnd.apache.parquet")
+		isComplete, err = exportColumnar(rowChan, errChan, columns, dataTypes, rw, newParquetRecordWriter)
+		if err != nil {
+			render.Error(rw, err)
+			return
+		}
+	case "arrow":
+		rw.Header().Set("Content-Type", "application/vnd.apache.arrow.stream")
+		isComplete, err = exportColumnar(rowChan, errChan, columns, dataTypes, rw, newArrowRecordWriter)
 		if err != nil {
 			render.Error(rw, err)
 			return
 		}
 	default:
-		render.Error(rw, errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid format: must be csv or jsonl"))
+		render.Error(rw, errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid format: must be csv, jsonl, parquet or arrow"))
 		return
 	}
 
 	rw.Header().Set("X-Response-Complete", strconv.FormatBool(isComplete))
 }
 
-func (handler *handler) exportLogsCSV(rowChan <-chan *qbtypes.RawRow, errChan <-chan error, csvWriter *csv.Writer) (bool, error) {
+func exportCSV(rowChan <-chan *qbtypes.RawRow, errChan <-chan error, csvWriter *csv.Writer) (bool, error) {
 	var header []string
 
 	headerToIndexMapping := make(map[string]int, len(header))
@@ -268,7 +298,7 @@ func (handler *handler) exportLogsCSV(rowChan <-chan *qbtypes.RawRow, errChan <-
 	}
 }
 
-func (handler *handler) exportLogsJSONL(rowChan <-chan *qbtypes.RawRow, errChan <-chan error, writer io.Writer) (bool, error) {
+func exportJSONL(rowChan <-chan *qbtypes.RawRow, errChan <-chan error, writer io.Writer) (bool, error) {
 
 	totalBytes := uint64(0)
 	for {
@@ -318,8 +348,12 @@ func getExportQueryFormat(queryParams url.Values) (string, error) {
 		return "csv", nil
 	case "jsonl":
 		return "jsonl", nil
+	case "parquet":
+		return "parquet", nil
+	case "arrow":
+		return "arrow", nil
 	default:
-		return "", errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid format: must be csv or jsonl")
+		return "", errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid format: must be csv, jsonl, parquet or arrow")
 	}
 }
 
@@ -396,48 +430,47 @@ func constructCSVRecordFromQueryResponse(data map[string]any, headerToIndexMappi
 
 	for key, value := range data {
 		if index, exists := headerToIndexMapping[key]; exists && value != nil {
-
-			var valueStr string
-			switch v := value.(type) {
-			case string:
-				valueStr = v
-			case int:
-				valueStr = strconv.FormatInt(int64(v), 10)
-			case int32:
-				valueStr = strconv.FormatInt(int64(v), 10)
-			case int64:
-				valueStr = strconv.FormatInt(v, 10)
-			case uint:
-				valueStr = strconv.FormatUint(uint64(v), 10)
-			case uint32:
-				valueStr = strconv.FormatUint(uint64(v), 10)
-			case uint64:
-				valueStr = strconv.FormatUint(v, 10)
-			case float32:
-				valueStr = strconv.FormatFloat(float64(v), 'f', -1, 32)
-			case float64:
-				valueStr = strconv.FormatFloat(v, 'f', -1, 64)
-			case bool:
-				valueStr = strconv.FormatBool(v)
-			case time.Time:
-				valueStr = v.Format(time.RFC3339Nano)
-			case []byte:
-				valueStr = string(v)
-			case fmt.Stringer:
-				valueStr = v.String()
-
-			default:
-				// For all other complex types (maps, structs, etc.)
-				jsonBytes, _ := json.Marshal(v)
-				valueStr = string(jsonBytes)
-			}
-
-			record[index] = sanitizeForCSV(valueStr)
+			record[index] = sanitizeForCSV(stringifyValue(value))
 		}
 	}
 	return record
 }
 
+// stringifyValue formats a single value as a string, complex types (maps, structs, etc.) are encoded as JSON.
+func stringifyValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case int:
+		return strconv.FormatInt(int64(v), 10)
+	case int32:
+		return strconv.FormatInt(int64(v), 10)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case []byte:
+		return string(v)
+	case fmt.Stringer:
+		return v.String()
+	default:
+		jsonBytes, _ := json.Marshal(v)
+		return string(jsonBytes)
+	}
+}
+
 // getExportQueryColumns parses the "columns" query parameters and returns a slice of TelemetryFieldKey structs.
 // Each column should be a valid telemetry field key in the format "context.field:type" or "context.field" or "field"
 func getExportQueryColumns(queryParams url.Values) []telemetrytypes.TelemetryFieldKey {
@@ -514,3 +547,165 @@ func getExportQueryOrderBy(queryParams url.Values) ([]qbtypes.OrderBy, error) {
 	}
 	return orderBy, nil
 }
+
+// CreateJob creates an export job which is run in the background, see GetJob to follow its progress and GetJobChunk
+// to download the exported rows.
+//
+// Endpoint: POST /api/v1/export_jobs
+//
+// The body contains the format of the chunks and a raw query range request with a single logs or traces builder
+// query. The limit of the query is the total number of rows exported by the job, it defaults to and cannot exceed
+// MaxExportJobRowCountLimit.
+func (handler *handler) CreateJob(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	body := new(rawdataexporttypes.PostableJob)
+	if err := binding.JSON.BindBody(r.Body, body); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	job, err := handler.module.CreateJob(ctx, valuer.MustNewUUID(claims.OrgID), claims.Email, body)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusCreated, job)
+}
+
+// Endpoint: GET /api/v1/export_jobs/{id}
+func (handler *handler) GetJob(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	id, err := valuer.NewUUID(mux.Vars(r)["id"])
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	job, err := handler.module.GetJob(ctx, valuer.MustNewUUID(claims.OrgID), id)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, job)
+}
+
+// Endpoint: GET /api/v1/export_jobs
+func (handler *handler) ListJobs(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	jobs, err := handler.module.ListJobs(ctx, valuer.MustNewUUID(claims.OrgID))
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, jobs)
+}
+
+// Endpoint: POST /api/v1/export_jobs/{id}/cancel
+func (handler *handler) CancelJob(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	id, err := valuer.NewUUID(mux.Vars(r)["id"])
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	if err := handler.module.CancelJob(ctx, valuer.MustNewUUID(claims.OrgID), id); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusNoContent, nil)
+}
+
+// GetJobChunk downloads a chunk of an export job. Chunks are numbered from 0 to the number of chunks of the job
+// excluding, each chunk is a standalone file in the format of the job.
+//
+// Endpoint: GET /api/v1/export_jobs/{id}/chunks/{index}
+func (handler *handler) GetJobChunk(rw http.ResponseWriter, r *http.Request) {
+	claims, err := authtypes.ClaimsFromContext(r.Context())
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	id, err := valuer.NewUUID(mux.Vars(r)["id"])
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	index, err := strconv.Atoi(mux.Vars(r)["index"])
+	if err != nil {
+		render.Error(rw, errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid chunk index: %s", err.Error()))
+		return
+	}
+
+	job, err := handler.module.GetJob(r.Context(), valuer.MustNewUUID(claims.OrgID), id)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	reader, err := handler.module.GetJobChunk(r.Context(), job.OrgID, job.ID, index)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+	defer reader.Close()
+
+	filename := fmt.Sprintf("data_exported_%s_%06d.%s", job.ID.StringValue(), index, job.Format)
+	rw.Header().Set("Content-Type", getContentType(job.Format))
+	rw.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+	rw.Header().Set("Access-Control-Expose-Headers", "Content-Disposition")
+	rw.WriteHeader(http.StatusOK)
+
+	_, _ = io.Copy(rw, reader)
+}
+
+func getContentType(format string) string {
+	switch format {
+	case "jsonl":
+		return "application/x-ndjson"
+	case "parquet":
+		return "application/vnd.apache.parquet"
+	case "arrow":
+		return "application/vnd.apache.arrow.stream"
+	default:
+		return "text/csv"
+	}
+}
@@ -88,6 +88,18 @@ func TestGetExportQueryFormat(t *testing.T) {
 			expectedFormat: "jsonl",
 			expectedError:  false,
 		},
+		{
+			name:           "parquet format",
+			queryParams:    url.Values{"format": {"parquet"}},
+			expectedFormat: "parquet",
+			expectedError:  false,
+		},
+		{
+			name:           "arrow format",
+			queryParams:    url.Values{"format": {"arrow"}},
+			expectedFormat: "arrow",
+			expectedError:  false,
+		},
 		{
 			name:           "invalid format",
 			queryParams:    url.Values{"format": {"xml"}},
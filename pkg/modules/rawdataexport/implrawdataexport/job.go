@@ -0,0 +1,397 @@
+package implrawdataexport
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/ctxtypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/rawdataexporttypes"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+func (m *Module) Start(ctx context.Context) error {
+	ticker := time.NewTicker(ExportJobPollInterval)
+	defer ticker.Stop()
+
+	sweepTicker := time.NewTicker(ExportJobSweepInterval)
+	defer sweepTicker.Stop()
+
+	for {
+		select {
+		case <-m.stopC:
+			return nil
+		case <-ticker.C:
+			m.scheduleJobs(ctx)
+		case <-sweepTicker.C:
+			m.sweepJobs(ctx)
+		}
+	}
+}
+
+// Stop interrupts the running jobs and waits for them to return. Interrupted jobs stay in the running state and are
+// resumed from their cursor by the next instance which owns the org.
+func (m *Module) Stop(ctx context.Context) error {
+	close(m.stopC)
+
+	m.mtx.Lock()
+	for _, cancel := range m.running {
+		cancel()
+	}
+	m.mtx.Unlock()
+
+	m.wg.Wait()
+	return nil
+}
+
+func (m *Module) CreateJob(ctx context.Context, orgID valuer.UUID, createdBy string, postable *rawdataexporttypes.PostableJob) (*rawdataexporttypes.Job, error) {
+	job, err := rawdataexporttypes.NewJob(orgID, createdBy, postable.Format, &postable.Request, MaxExportJobRowCountLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	storable, err := rawdataexporttypes.NewStorableJobFromJob(job)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.store.Create(ctx, storable); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (m *Module) GetJob(ctx context.Context, orgID valuer.UUID, id valuer.UUID) (*rawdataexporttypes.Job, error) {
+	storable, err := m.store.Get(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return rawdataexporttypes.NewJobFromStorableJob(storable)
+}
+
+func (m *Module) ListJobs(ctx context.Context, orgID valuer.UUID) ([]*rawdataexporttypes.Job, error) {
+	storables, err := m.store.List(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	return rawdataexporttypes.NewJobsFromStorableJobs(storables)
+}
+
+func (m *Module) CancelJob(ctx context.Context, orgID valuer.UUID, id valuer.UUID) error {
+	ok, err := m.store.UpdateStatus(ctx, orgID, id, []rawdataexporttypes.JobStatus{rawdataexporttypes.JobStatusPending, rawdataexporttypes.JobStatusRunning}, rawdataexporttypes.JobStatusCancelled, "")
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		job, err := m.GetJob(ctx, orgID, id)
+		if err != nil {
+			return err
+		}
+
+		return errors.Newf(errors.TypeInvalidInput, rawdataexporttypes.ErrCodeExportJobNotRunnable, "export job with id %s has already %s", id.StringValue(), job.Status.StringValue())
+	}
+
+	// If the job is being run by another instance, it stops at the next chunk as the progress can no longer be saved.
+	m.mtx.Lock()
+	if cancel, ok := m.running[id]; ok {
+		cancel()
+	}
+	m.mtx.Unlock()
+
+	return nil
+}
+
+func (m *Module) GetJobChunk(ctx context.Context, orgID valuer.UUID, id valuer.UUID, index int) (io.ReadCloser, error) {
+	job, err := m.GetJob(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if index < 0 || index >= job.Chunks {
+		return nil, errors.Newf(errors.TypeNotFound, rawdataexporttypes.ErrCodeExportJobNotFound, "export job with id %s has %d chunks, chunk %d doesn't exist", id.StringValue(), job.Chunks, index)
+	}
+
+	return m.objectStore.Get(ctx, orgID, job.ChunkKey(index))
+}
+
+func (m *Module) scheduleJobs(ctx context.Context) {
+	orgs, err := m.orgGetter.ListByOwnedKeyRange(ctx)
+	if err != nil {
+		m.settings.Logger().ErrorContext(ctx, "failed to get orgs data", "error", err)
+		return
+	}
+
+	for _, org := range orgs {
+		storables, err := m.store.ListByStatus(ctx, org.ID, rawdataexporttypes.JobStatusPending, rawdataexporttypes.JobStatusRunning)
+		if err != nil {
+			m.settings.Logger().ErrorContext(ctx, "failed to list export jobs", "error", err, "org_id", org.ID)
+			continue
+		}
+
+		for _, storable := range storables {
+			job, err := rawdataexporttypes.NewJobFromStorableJob(storable)
+			if err != nil {
+				m.settings.Logger().ErrorContext(ctx, "failed to load export job", "error", err, "org_id", org.ID, "job_id", storable.ID)
+				continue
+			}
+
+			if !m.startJob(ctx, job) {
+				return
+			}
+		}
+	}
+}
+
+// sweepJobs deletes the jobs which finished more than ExportJobTTL ago along with their chunks.
+func (m *Module) sweepJobs(ctx context.Context) {
+	orgs, err := m.orgGetter.ListByOwnedKeyRange(ctx)
+	if err != nil {
+		m.settings.Logger().ErrorContext(ctx, "failed to get orgs data", "error", err)
+		return
+	}
+
+	for _, org := range orgs {
+		if err := m.sweepOrgJobs(ctx, org.ID, time.Now().Add(-ExportJobTTL)); err != nil {
+			m.settings.Logger().ErrorContext(ctx, "failed to delete expired export jobs", "error", err, "org_id", org.ID)
+		}
+	}
+}
+
+func (m *Module) sweepOrgJobs(ctx context.Context, orgID valuer.UUID, before time.Time) error {
+	storables, err := m.store.ListFinishedBefore(ctx, orgID, before)
+	if err != nil {
+		return err
+	}
+
+	for _, storable := range storables {
+		job, err := rawdataexporttypes.NewJobFromStorableJob(storable)
+		if err != nil {
+			return err
+		}
+
+		// the chunks are deleted first so that a failure leaves the job around to be swept again
+		if err := m.objectStore.Delete(ctx, orgID, job.ChunkPrefix()); err != nil {
+			return err
+		}
+
+		if err := m.store.Delete(ctx, orgID, job.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// startJob runs the job in the background. It returns false if no more jobs can be run concurrently.
+func (m *Module) startJob(ctx context.Context, job *rawdataexporttypes.Job) bool {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if _, ok := m.running[job.ID]; ok {
+		return true
+	}
+
+	if len(m.running) >= MaxConcurrentExportJobs {
+		return false
+	}
+
+	jobCtx, cancel := context.WithCancel(ctx)
+	m.running[job.ID] = cancel
+	m.wg.Add(1)
+
+	go func() {
+		defer m.wg.Done()
+		defer func() {
+			m.mtx.Lock()
+			delete(m.running, job.ID)
+			m.mtx.Unlock()
+			cancel()
+		}()
+
+		m.runJob(jobCtx, job)
+	}()
+
+	return true
+}
+
+func (m *Module) runJob(ctx context.Context, job *rawdataexporttypes.Job) {
+	if job.Status == rawdataexporttypes.JobStatusPending {
+		ok, err := m.store.UpdateStatus(ctx, job.OrgID, job.ID, []rawdataexporttypes.JobStatus{rawdataexporttypes.JobStatusPending}, rawdataexporttypes.JobStatusRunning, "")
+		if err != nil {
+			m.settings.Logger().ErrorContext(ctx, "failed to start export job", "error", err, "org_id", job.OrgID, "job_id", job.ID)
+			return
+		}
+
+		// the job was cancelled before it could be started
+		if !ok {
+			return
+		}
+
+		job.Status = rawdataexporttypes.JobStatusRunning
+	}
+
+	if err := m.exportJob(ctx, job); err != nil {
+		// the job was interrupted either by a cancellation or by the instance stopping
+		if ctx.Err() != nil {
+			return
+		}
+
+		m.settings.Logger().ErrorContext(ctx, "failed to run export job", "error", err, "org_id", job.OrgID, "job_id", job.ID)
+		if _, err := m.store.UpdateStatus(ctx, job.OrgID, job.ID, []rawdataexporttypes.JobStatus{rawdataexporttypes.JobStatusRunning}, rawdataexporttypes.JobStatusFailed, err.Error()); err != nil {
+			m.settings.Logger().ErrorContext(ctx, "failed to mark export job as failed", "error", err, "org_id", job.OrgID, "job_id", job.ID)
+		}
+		return
+	}
+
+	// a no-op if the job was cancelled in the meantime
+	if _, err := m.store.UpdateStatus(ctx, job.OrgID, job.ID, []rawdataexporttypes.JobStatus{rawdataexporttypes.JobStatusRunning}, rawdataexporttypes.JobStatusSucceeded, ""); err != nil {
+		m.settings.Logger().ErrorContext(ctx, "failed to mark export job as succeeded", "error", err, "org_id", job.OrgID, "job_id", job.ID)
+	}
+}
+
+// exportJob exports the job chunk by chunk starting from its cursor, saving the progress after every chunk.
+func (m *Module) exportJob(ctx context.Context, job *rawdataexporttypes.Job) error {
+	var dataTypes map[string]telemetrytypes.FieldDataType
+	if job.Format == "parquet" || job.Format == "arrow" {
+		var err error
+		dataTypes, err = m.GetColumnDataTypes(ctx, job.OrgID, job.Request)
+		if err != nil {
+			return err
+		}
+	}
+	_, columns := getSelectFields(job.Request)
+
+	limit := job.Limit()
+	for job.Cursor < limit {
+		chunkSize := min(ExportJobChunkSize, limit-job.Cursor)
+
+		rows, err := m.queryJobChunk(ctx, job, chunkSize)
+		if err != nil {
+			return err
+		}
+
+		if len(rows) == 0 {
+			return nil
+		}
+
+		n, err := m.putJobChunk(ctx, job, rows, columns, dataTypes)
+		if err != nil {
+			return err
+		}
+
+		job.Cursor += len(rows)
+		job.Chunks++
+		job.Bytes += n
+
+		storable, err := rawdataexporttypes.NewStorableJobFromJob(job)
+		if err != nil {
+			return err
+		}
+
+		ok, err := m.store.UpdateProgress(ctx, storable)
+		if err != nil {
+			return err
+		}
+
+		// the job is no longer running, it was cancelled
+		if !ok {
+			return nil
+		}
+
+		if len(rows) < chunkSize {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (m *Module) queryJobChunk(ctx context.Context, job *rawdataexporttypes.Job, chunkSize int) ([]*qbtypes.RawRow, error) {
+	ctx = ctxtypes.SetClickhouseMaxThreads(ctx, ClickhouseExportRawDataMaxThreads)
+	ctx, cancel := context.WithTimeout(ctx, ClickhouseExportRawDataTimeout)
+	defer cancel()
+
+	rows := make([]*qbtypes.RawRow, 0, chunkSize)
+	for len(rows) < chunkSize {
+		limit := min(ChunkSize, chunkSize-len(rows))
+
+		response, err := m.querier.QueryRange(ctx, job.OrgID, job.ChunkRequest(job.Cursor+len(rows), limit))
+		if err != nil {
+			return nil, err
+		}
+
+		newRowsCount := 0
+		for _, result := range response.Data.Results {
+			resultData, ok := result.(*qbtypes.RawData)
+			if !ok {
+				return nil, errors.NewInternalf(errors.CodeInternal, "expected RawData, got %T", result)
+			}
+
+			newRowsCount += len(resultData.Rows)
+			rows = append(rows, resultData.Rows...)
+		}
+
+		if newRowsCount < limit {
+			break
+		}
+	}
+
+	return rows, nil
+}
+
+// putJobChunk writes the rows as the next chunk of the job and returns the size of the chunk.
+func (m *Module) putJobChunk(ctx context.Context, job *rawdataexporttypes.Job, rows []*qbtypes.RawRow, columns []telemetrytypes.TelemetryFieldKey, dataTypes map[string]telemetrytypes.FieldDataType) (int64, error) {
+	reader, writer := io.Pipe()
+
+	go func() {
+		writer.CloseWithError(writeChunk(job.Format, rows, columns, dataTypes, writer))
+	}()
+
+	n, err := m.objectStore.Put(ctx, job.OrgID, job.ChunkKey(job.Chunks), reader)
+	// unblocks the writer if the object store returned before consuming the whole chunk
+	_ = reader.CloseWithError(io.ErrClosedPipe)
+	if err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+func writeChunk(format string, rows []*qbtypes.RawRow, columns []telemetrytypes.TelemetryFieldKey, dataTypes map[string]telemetrytypes.FieldDataType, writer io.Writer) error {
+	rowChan := make(chan *qbtypes.RawRow, len(rows))
+	for _, row := range rows {
+		rowChan <- row
+	}
+	close(rowChan)
+
+	errChan := make(chan error)
+
+	var err error
+	switch format {
+	case "csv":
+		csvWriter := csv.NewWriter(writer)
+		if _, err = exportCSV(rowChan, errChan, csvWriter); err != nil {
+			return err
+		}
+		csvWriter.Flush()
+		err = csvWriter.Error()
+	case "jsonl":
+		_, err = exportJSONL(rowChan, errChan, writer)
+	case "parquet":
+		_, err = exportColumnar(rowChan, errChan, columns, dataTypes, writer, newParquetRecordWriter)
+	case "arrow":
+		_, err = exportColumnar(rowChan, errChan, columns, dataTypes, writer, newArrowRecordWriter)
+	default:
+		err = errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid format: must be csv, jsonl, parquet or arrow")
+	}
+
+	return err
+}
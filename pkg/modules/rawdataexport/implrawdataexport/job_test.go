@@ -0,0 +1,126 @@
+package implrawdataexport
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/SigNoz/signoz/pkg/objectstore"
+	"github.com/SigNoz/signoz/pkg/objectstore/fsobjectstore"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/rawdataexporttypes"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteChunk(t *testing.T) {
+	rows := []*qbtypes.RawRow{
+		{Data: map[string]any{"body": "hello"}},
+		{Data: map[string]any{"body": "world"}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeChunk("csv", rows, nil, nil, &buf))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"body"}, {"hello"}, {"world"}}, records)
+
+	buf.Reset()
+	require.NoError(t, writeChunk("jsonl", rows, nil, nil, &buf))
+
+	lines := 0
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		lines++
+	}
+	assert.Equal(t, 2, lines)
+
+	assert.Error(t, writeChunk("xml", rows, nil, nil, &buf))
+}
+
+// finishedJobsStore serves the finished jobs of a single org, the other methods are not used by the sweep.
+type finishedJobsStore struct {
+	rawdataexporttypes.Store
+	jobs []*rawdataexporttypes.StorableJob
+}
+
+func (store *finishedJobsStore) ListFinishedBefore(_ context.Context, _ valuer.UUID, before time.Time) ([]*rawdataexporttypes.StorableJob, error) {
+	jobs := make([]*rawdataexporttypes.StorableJob, 0)
+	for _, job := range store.jobs {
+		if job.Status.IsTerminal() && job.UpdatedAt.Before(before) {
+			jobs = append(jobs, job)
+		}
+	}
+
+	return jobs, nil
+}
+
+func (store *finishedJobsStore) Delete(_ context.Context, _ valuer.UUID, id valuer.UUID) error {
+	for i, job := range store.jobs {
+		if job.ID == id {
+			store.jobs = append(store.jobs[:i], store.jobs[i+1:]...)
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func TestSweepOrgJobs(t *testing.T) {
+	ctx := context.Background()
+	orgID := valuer.GenerateUUID()
+
+	objectStore, err := fsobjectstore.New(ctx, factorytest.NewSettings(), objectstore.Config{Provider: "fs", FS: objectstore.FS{Directory: t.TempDir()}})
+	require.NoError(t, err)
+
+	request := &qbtypes.QueryRangeRequest{
+		RequestType: qbtypes.RequestTypeRaw,
+		CompositeQuery: qbtypes.CompositeQuery{
+			Queries: []qbtypes.QueryEnvelope{{
+				Type: qbtypes.QueryTypeBuilder,
+				Spec: qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{Name: "A", Signal: telemetrytypes.SignalLogs, Limit: 10},
+			}},
+		},
+	}
+
+	newJob := func(status rawdataexporttypes.JobStatus, updatedAt time.Time) *rawdataexporttypes.StorableJob {
+		job, err := rawdataexporttypes.NewJob(orgID, "user", "csv", request, MaxExportJobRowCountLimit)
+		require.NoError(t, err)
+
+		job.Status = status
+		job.UpdatedAt = updatedAt
+		_, err = objectStore.Put(ctx, orgID, job.ChunkKey(0), strings.NewReader("body\nhello\n"))
+		require.NoError(t, err)
+
+		storable, err := rawdataexporttypes.NewStorableJobFromJob(job)
+		require.NoError(t, err)
+		return storable
+	}
+
+	now := time.Now()
+	expired := newJob(rawdataexporttypes.JobStatusSucceeded, now.Add(-2*ExportJobTTL))
+	recent := newJob(rawdataexporttypes.JobStatusSucceeded, now)
+	running := newJob(rawdataexporttypes.JobStatusRunning, now.Add(-2*ExportJobTTL))
+
+	store := &finishedJobsStore{jobs: []*rawdataexporttypes.StorableJob{expired, recent, running}}
+	module := &Module{store: store, objectStore: objectStore}
+
+	require.NoError(t, module.sweepOrgJobs(ctx, orgID, now.Add(-ExportJobTTL)))
+
+	assert.Equal(t, []*rawdataexporttypes.StorableJob{recent, running}, store.jobs)
+
+	keys, err := objectStore.List(ctx, orgID, "rawdataexport/")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		"rawdataexport/" + recent.ID.StringValue() + "/000000.csv",
+		"rawdataexport/" + running.ID.StringValue() + "/000000.csv",
+	}, keys)
+}
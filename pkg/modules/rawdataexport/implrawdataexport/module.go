@@ -2,22 +2,47 @@ package implrawdataexport
 
 import (
 	"context"
+	"sync"
 
 	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/modules/organization"
 	"github.com/SigNoz/signoz/pkg/modules/rawdataexport"
+	"github.com/SigNoz/signoz/pkg/objectstore"
 	"github.com/SigNoz/signoz/pkg/querier"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
 	"github.com/SigNoz/signoz/pkg/types/ctxtypes"
 	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/rawdataexporttypes"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
 )
 
 type Module struct {
-	querier querier.Querier
+	querier       querier.Querier
+	metadataStore telemetrytypes.MetadataStore
+	store         rawdataexporttypes.Store
+	objectStore   objectstore.ObjectStore
+	orgGetter     organization.Getter
+	settings      factory.ScopedProviderSettings
+	stopC         chan struct{}
+
+	// running holds the cancel functions of the jobs being run by this instance.
+	mtx     sync.Mutex
+	running map[valuer.UUID]context.CancelFunc
+	wg      sync.WaitGroup
 }
 
-func NewModule(querier querier.Querier) rawdataexport.Module {
+func NewModule(querier querier.Querier, metadataStore telemetrytypes.MetadataStore, sqlstore sqlstore.SQLStore, objectStore objectstore.ObjectStore, orgGetter organization.Getter, providerSettings factory.ProviderSettings) rawdataexport.Module {
 	return &Module{
-		querier: querier,
+		querier:       querier,
+		metadataStore: metadataStore,
+		store:         NewStore(sqlstore),
+		objectStore:   objectStore,
+		orgGetter:     orgGetter,
+		settings:      factory.NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/pkg/modules/rawdataexport/implrawdataexport"),
+		stopC:         make(chan struct{}),
+		running:       make(map[valuer.UUID]context.CancelFunc),
 	}
 }
 
@@ -87,3 +112,61 @@ func (m *Module) ExportRawData(ctx context.Context, orgID valuer.UUID, rangeRequ
 	return rowChan, errChan
 
 }
+
+func (m *Module) GetColumnDataTypes(ctx context.Context, orgID valuer.UUID, rangeRequest *qbtypes.QueryRangeRequest) (map[string]telemetrytypes.FieldDataType, error) {
+	signal, selectFields := getSelectFields(rangeRequest)
+
+	dataTypes := make(map[string]telemetrytypes.FieldDataType, len(selectFields))
+	selectors := make([]*telemetrytypes.FieldKeySelector, 0, len(selectFields))
+	for _, field := range selectFields {
+		// the type was given explicitly as part of the column
+		if field.FieldDataType != telemetrytypes.FieldDataTypeUnspecified {
+			dataTypes[field.Name] = field.FieldDataType
+			continue
+		}
+
+		selectors = append(selectors, &telemetrytypes.FieldKeySelector{
+			Name:              field.Name,
+			Signal:            signal,
+			FieldContext:      field.FieldContext,
+			SelectorMatchType: telemetrytypes.FieldSelectorMatchTypeExact,
+		})
+	}
+
+	if len(selectors) == 0 {
+		return dataTypes, nil
+	}
+
+	keys, _, err := m.metadataStore.GetKeysMulti(ctx, selectors)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, selector := range selectors {
+		keysForField := keys[selector.Name]
+		switch len(keysForField) {
+		case 0:
+			// intrinsic columns and unknown fields are typed from the values
+			continue
+		case 1:
+			dataTypes[selector.Name] = keysForField[0].FieldDataType
+		default:
+			// ambiguous keys are coalesced into a string by the statement builder
+			dataTypes[selector.Name] = telemetrytypes.FieldDataTypeString
+		}
+	}
+
+	return dataTypes, nil
+}
+
+// getSelectFields returns the signal and the selected columns of the first query of the request.
+func getSelectFields(rangeRequest *qbtypes.QueryRangeRequest) (telemetrytypes.Signal, []telemetrytypes.TelemetryFieldKey) {
+	switch spec := rangeRequest.CompositeQuery.Queries[0].Spec.(type) {
+	case qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]:
+		return telemetrytypes.SignalLogs, spec.SelectFields
+	case qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]:
+		return telemetrytypes.SignalTraces, spec.SelectFields
+	default:
+		return telemetrytypes.SignalUnspecified, nil
+	}
+}
@@ -0,0 +1,169 @@
+package implrawdataexport
+
+import (
+	"context"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/types/rawdataexporttypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/uptrace/bun"
+)
+
+type store struct {
+	sqlstore sqlstore.SQLStore
+}
+
+func NewStore(sqlstore sqlstore.SQLStore) rawdataexporttypes.Store {
+	return &store{sqlstore: sqlstore}
+}
+
+func (store *store) Create(ctx context.Context, job *rawdataexporttypes.StorableJob) error {
+	_, err := store.
+		sqlstore.
+		BunDBCtx(ctx).
+		NewInsert().
+		Model(job).
+		Exec(ctx)
+	if err != nil {
+		return store.sqlstore.WrapAlreadyExistsErrf(err, rawdataexporttypes.ErrCodeExportJobInvalid, "export job with id %s already exists", job.ID)
+	}
+
+	return nil
+}
+
+func (store *store) Get(ctx context.Context, orgID valuer.UUID, id valuer.UUID) (*rawdataexporttypes.StorableJob, error) {
+	job := new(rawdataexporttypes.StorableJob)
+	err := store.
+		sqlstore.
+		BunDBCtx(ctx).
+		NewSelect().
+		Model(job).
+		Where("id = ?", id).
+		Where("org_id = ?", orgID).
+		Scan(ctx)
+	if err != nil {
+		return nil, store.sqlstore.WrapNotFoundErrf(err, rawdataexporttypes.ErrCodeExportJobNotFound, "export job with id %s doesn't exist", id)
+	}
+
+	return job, nil
+}
+
+func (store *store) List(ctx context.Context, orgID valuer.UUID) ([]*rawdataexporttypes.StorableJob, error) {
+	jobs := make([]*rawdataexporttypes.StorableJob, 0)
+	err := store.
+		sqlstore.
+		BunDBCtx(ctx).
+		NewSelect().
+		Model(&jobs).
+		Where("org_id = ?", orgID).
+		Order("created_at DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+func (store *store) ListByStatus(ctx context.Context, orgID valuer.UUID, statuses ...rawdataexporttypes.JobStatus) ([]*rawdataexporttypes.StorableJob, error) {
+	jobs := make([]*rawdataexporttypes.StorableJob, 0)
+	err := store.
+		sqlstore.
+		BunDBCtx(ctx).
+		NewSelect().
+		Model(&jobs).
+		Where("org_id = ?", orgID).
+		Where("status IN (?)", bun.In(statuses)).
+		Order("created_at ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+func (store *store) ListFinishedBefore(ctx context.Context, orgID valuer.UUID, before time.Time) ([]*rawdataexporttypes.StorableJob, error) {
+	jobs := make([]*rawdataexporttypes.StorableJob, 0)
+	err := store.
+		sqlstore.
+		BunDBCtx(ctx).
+		NewSelect().
+		Model(&jobs).
+		Where("org_id = ?", orgID).
+		Where("status IN (?)", bun.In([]rawdataexporttypes.JobStatus{rawdataexporttypes.JobStatusSucceeded, rawdataexporttypes.JobStatusFailed, rawdataexporttypes.JobStatusCancelled})).
+		Where("updated_at < ?", before).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+func (store *store) UpdateProgress(ctx context.Context, job *rawdataexporttypes.StorableJob) (bool, error) {
+	job.UpdatedAt = time.Now()
+
+	result, err := store.
+		sqlstore.
+		BunDBCtx(ctx).
+		NewUpdate().
+		Model(job).
+		Column("cursor", "chunks", "bytes", "updated_at").
+		Where("id = ?", job.ID).
+		Where("org_id = ?", job.OrgID).
+		Where("status = ?", rawdataexporttypes.JobStatusRunning).
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (store *store) UpdateStatus(ctx context.Context, orgID valuer.UUID, id valuer.UUID, from []rawdataexporttypes.JobStatus, to rawdataexporttypes.JobStatus, message string) (bool, error) {
+	result, err := store.
+		sqlstore.
+		BunDBCtx(ctx).
+		NewUpdate().
+		Model(new(rawdataexporttypes.StorableJob)).
+		Set("status = ?", to).
+		Set("message = ?", message).
+		Set("updated_at = ?", time.Now()).
+		Where("id = ?", id).
+		Where("org_id = ?", orgID).
+		Where("status IN (?)", bun.In(from)).
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (store *store) Delete(ctx context.Context, orgID valuer.UUID, id valuer.UUID) error {
+	_, err := store.
+		sqlstore.
+		BunDBCtx(ctx).
+		NewDelete().
+		Model(new(rawdataexporttypes.StorableJob)).
+		Where("id = ?", id).
+		Where("org_id = ?", orgID).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
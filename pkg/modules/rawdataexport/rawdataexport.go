@@ -2,16 +2,52 @@ package rawdataexport
 
 import (
 	"context"
+	"io"
 	"net/http"
 
+	"github.com/SigNoz/signoz/pkg/factory"
 	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/rawdataexporttypes"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
 )
 
 type Module interface {
+	// Runs the export jobs of the orgs owned by the instance and deletes the expired ones along with their chunks.
+	factory.Service
+
 	ExportRawData(ctx context.Context, orgID valuer.UUID, rangeRequest *qbtypes.QueryRangeRequest, doneChan chan any) (chan *qbtypes.RawRow, chan error)
+
+	// GetColumnDataTypes resolves the data types of the selected columns of the export from the telemetry field metadata.
+	// Columns whose type can not be determined are omitted from the result.
+	GetColumnDataTypes(ctx context.Context, orgID valuer.UUID, rangeRequest *qbtypes.QueryRangeRequest) (map[string]telemetrytypes.FieldDataType, error)
+
+	// CreateJob creates an export job which is run asynchronously. The rows are written to the object store in chunks.
+	CreateJob(ctx context.Context, orgID valuer.UUID, createdBy string, postable *rawdataexporttypes.PostableJob) (*rawdataexporttypes.Job, error)
+
+	// GetJob gets an export job by id.
+	GetJob(ctx context.Context, orgID valuer.UUID, id valuer.UUID) (*rawdataexporttypes.Job, error)
+
+	// ListJobs lists the export jobs of the org, latest first.
+	ListJobs(ctx context.Context, orgID valuer.UUID) ([]*rawdataexporttypes.Job, error)
+
+	// CancelJob cancels a pending or running export job. The chunks which were already exported are kept.
+	CancelJob(ctx context.Context, orgID valuer.UUID, id valuer.UUID) error
+
+	// GetJobChunk returns a reader for the chunk at the zero based index of an export job.
+	GetJobChunk(ctx context.Context, orgID valuer.UUID, id valuer.UUID, index int) (io.ReadCloser, error)
 }
 
 type Handler interface {
 	ExportRawData(http.ResponseWriter, *http.Request)
+
+	CreateJob(http.ResponseWriter, *http.Request)
+
+	GetJob(http.ResponseWriter, *http.Request)
+
+	ListJobs(http.ResponseWriter, *http.Request)
+
+	CancelJob(http.ResponseWriter, *http.Request)
+
+	GetJobChunk(http.ResponseWriter, *http.Request)
 }
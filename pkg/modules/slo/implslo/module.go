@@ -0,0 +1,206 @@
+package implslo
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/modules/slo"
+	"github.com/SigNoz/signoz/pkg/querier"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/types/slotypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type module struct {
+	store       slotypes.Store
+	querier     querier.Querier
+	ruleManager slo.RuleManager
+}
+
+func NewModule(sqlstore sqlstore.SQLStore, querier querier.Querier) slo.Module {
+	return &module{
+		store:   NewStore(sqlstore),
+		querier: querier,
+	}
+}
+
+func (module *module) SetRuleManager(ruleManager slo.RuleManager) {
+	module.ruleManager = ruleManager
+}
+
+func (module *module) Create(ctx context.Context, orgID valuer.UUID, createdBy string, postable *slotypes.PostableSLO) (*slotypes.SLO, error) {
+	s, err := slotypes.NewSLO(orgID, createdBy, postable)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := module.syncRule(ctx, s); err != nil {
+		return nil, err
+	}
+
+	storable, err := slotypes.NewStorableSLOFromSLO(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := module.store.Create(ctx, storable); err != nil {
+		if s.RuleID != "" {
+			_ = module.ruleManager.DeleteRule(ctx, s.RuleID)
+		}
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (module *module) Get(ctx context.Context, orgID valuer.UUID, id valuer.UUID) (*slotypes.SLO, error) {
+	storable, err := module.store.Get(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return slotypes.NewSLOFromStorableSLO(storable)
+}
+
+func (module *module) List(ctx context.Context, orgID valuer.UUID) ([]*slotypes.SLO, error) {
+	storables, err := module.store.List(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	slos := make([]*slotypes.SLO, 0, len(storables))
+	for _, storable := range storables {
+		s, err := slotypes.NewSLOFromStorableSLO(storable)
+		if err != nil {
+			return nil, err
+		}
+		slos = append(slos, s)
+	}
+
+	return slos, nil
+}
+
+func (module *module) Update(ctx context.Context, orgID valuer.UUID, id valuer.UUID, updatedBy string, postable *slotypes.PostableSLO) (*slotypes.SLO, error) {
+	s, err := module.Get(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	previous := *s
+
+	if err := s.Update(updatedBy, postable); err != nil {
+		return nil, err
+	}
+
+	// the rule of the SLO is deleted only once the SLO is stored, so that it is left as it was if the store fails
+	if s.Alerting.Enabled {
+		if err := module.syncRule(ctx, s); err != nil {
+			return nil, err
+		}
+	} else {
+		s.RuleID = ""
+	}
+
+	storable, err := slotypes.NewStorableSLOFromSLO(s)
+	if err == nil {
+		err = module.store.Update(ctx, storable)
+	}
+	if err != nil {
+		module.restoreRule(ctx, &previous, s)
+		return nil, err
+	}
+
+	if previous.RuleID != "" && s.RuleID == "" {
+		if err := module.ruleManager.DeleteRule(ctx, previous.RuleID); err != nil && !errors.Ast(err, errors.TypeNotFound) {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+func (module *module) Delete(ctx context.Context, orgID valuer.UUID, id valuer.UUID) error {
+	s, err := module.Get(ctx, orgID, id)
+	if err != nil {
+		return err
+	}
+
+	if s.RuleID != "" {
+		if err := module.ruleManager.DeleteRule(ctx, s.RuleID); err != nil && !errors.Ast(err, errors.TypeNotFound) {
+			return err
+		}
+	}
+
+	return module.store.Delete(ctx, orgID, id)
+}
+
+func (module *module) GetStatus(ctx context.Context, orgID valuer.UUID, id valuer.UUID) (*slotypes.Status, error) {
+	s, err := module.Get(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := s.Window.Range(time.Now())
+	resp, err := module.querier.QueryRange(ctx, orgID, ruletypes.NewSLIQueryRangeRequest(s.Good, s.Total, start, end))
+	if err != nil {
+		return nil, err
+	}
+
+	good, total, err := ruletypes.SLIEventsFromResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return slotypes.NewStatus(s.Target, start, end, good, total), nil
+}
+
+// restoreRule restores the burn rate rule of the SLO as it was before a failed update.
+func (module *module) restoreRule(ctx context.Context, previous *slotypes.SLO, s *slotypes.SLO) {
+	switch {
+	case s.RuleID == "":
+		// the rule was not deleted yet
+		return
+	case previous.RuleID == "":
+		_ = module.ruleManager.DeleteRule(ctx, s.RuleID)
+	default:
+		_ = module.syncRule(ctx, previous)
+	}
+}
+
+// syncRule creates, updates or deletes the burn rate rule of the SLO to match its alerting.
+func (module *module) syncRule(ctx context.Context, s *slotypes.SLO) error {
+	if !s.Alerting.Enabled {
+		if s.RuleID == "" {
+			return nil
+		}
+
+		if err := module.ruleManager.DeleteRule(ctx, s.RuleID); err != nil && !errors.Ast(err, errors.TypeNotFound) {
+			return err
+		}
+		s.RuleID = ""
+		return nil
+	}
+
+	rule, err := json.Marshal(s.NewPostableRule())
+	if err != nil {
+		return errors.WrapInternalf(err, errors.CodeInternal, "failed to marshal the burn rate rule of slo %s", s.ID.StringValue())
+	}
+
+	if s.RuleID == "" {
+		gettable, err := module.ruleManager.CreateRule(ctx, string(rule))
+		if err != nil {
+			return err
+		}
+		s.RuleID = gettable.Id
+		return nil
+	}
+
+	ruleID, err := valuer.NewUUID(s.RuleID)
+	if err != nil {
+		return err
+	}
+
+	return module.ruleManager.EditRule(ctx, string(rule), ruleID)
+}
@@ -0,0 +1,54 @@
+package slo
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/types/slotypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type Module interface {
+	// Create creates an SLO and its burn rate rule when alerting is enabled.
+	Create(ctx context.Context, orgID valuer.UUID, createdBy string, postable *slotypes.PostableSLO) (*slotypes.SLO, error)
+
+	Get(ctx context.Context, orgID valuer.UUID, id valuer.UUID) (*slotypes.SLO, error)
+
+	List(ctx context.Context, orgID valuer.UUID) ([]*slotypes.SLO, error)
+
+	// Update updates an SLO, its burn rate rule is created, updated or deleted to match the alerting of the SLO.
+	Update(ctx context.Context, orgID valuer.UUID, id valuer.UUID, updatedBy string, postable *slotypes.PostableSLO) (*slotypes.SLO, error)
+
+	// Delete deletes an SLO and its burn rate rule.
+	Delete(ctx context.Context, orgID valuer.UUID, id valuer.UUID) error
+
+	// GetStatus computes the SLI and the remaining error budget of an SLO over its current window.
+	GetStatus(ctx context.Context, orgID valuer.UUID, id valuer.UUID) (*slotypes.Status, error)
+
+	// SetRuleManager sets the manager of the burn rate rules, which is created after the modules.
+	SetRuleManager(ruleManager RuleManager)
+}
+
+type Handler interface {
+	Create(http.ResponseWriter, *http.Request)
+
+	Get(http.ResponseWriter, *http.Request)
+
+	List(http.ResponseWriter, *http.Request)
+
+	Update(http.ResponseWriter, *http.Request)
+
+	Delete(http.ResponseWriter, *http.Request)
+
+	GetStatus(http.ResponseWriter, *http.Request)
+}
+
+// RuleManager manages the burn rate rules of the SLOs. The org and the user of the rules are read from the claims in the context.
+type RuleManager interface {
+	CreateRule(ctx context.Context, ruleStr string) (*ruletypes.GettableRule, error)
+
+	EditRule(ctx context.Context, ruleStr string, id valuer.UUID) error
+
+	DeleteRule(ctx context.Context, idStr string) error
+}
@@ -0,0 +1,40 @@
+package objectstore
+
+import (
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+)
+
+type Config struct {
+	// Provider is the object store provider to use.
+	Provider string `mapstructure:"provider"`
+
+	// FS is the config for the local filesystem provider.
+	FS FS `mapstructure:"fs"`
+}
+
+type FS struct {
+	// Directory is the root directory under which objects are stored.
+	Directory string `mapstructure:"directory"`
+}
+
+func NewConfigFactory() factory.ConfigFactory {
+	return factory.NewConfigFactory(factory.MustNewName("objectstore"), newConfig)
+}
+
+func newConfig() factory.Config {
+	return &Config{
+		Provider: "fs",
+		FS: FS{
+			Directory: "/var/lib/signoz/objects",
+		},
+	}
+}
+
+func (c Config) Validate() error {
+	if c.Provider == "fs" && c.FS.Directory == "" {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "objectstore.fs.directory must be set")
+	}
+
+	return nil
+}
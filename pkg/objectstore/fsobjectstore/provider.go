@@ -0,0 +1,162 @@
+package fsobjectstore
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/objectstore"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type provider struct {
+	directory string
+	settings  factory.ScopedProviderSettings
+}
+
+func NewFactory() factory.ProviderFactory[objectstore.ObjectStore, objectstore.Config] {
+	return factory.NewProviderFactory(factory.MustNewName("fs"), New)
+}
+
+func New(ctx context.Context, providerSettings factory.ProviderSettings, config objectstore.Config) (objectstore.ObjectStore, error) {
+	settings := factory.NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/pkg/objectstore/fsobjectstore")
+
+	// the directory is created on the first put, instances which never store objects don't need it to be writable
+	return &provider{
+		directory: config.FS.Directory,
+		settings:  settings,
+	}, nil
+}
+
+func (provider *provider) Put(ctx context.Context, orgID valuer.UUID, key string, reader io.Reader) (int64, error) {
+	filePath, err := provider.pathFor(orgID, key)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o750); err != nil {
+		return 0, errors.WrapInternalf(err, errors.CodeInternal, "failed to create directory for object %q", key)
+	}
+
+	// write to a temporary file first so that readers never observe a partially written object
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), ".tmp-*")
+	if err != nil {
+		return 0, errors.WrapInternalf(err, errors.CodeInternal, "failed to create object %q", key)
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	n, err := io.Copy(tmp, reader)
+	if err != nil {
+		_ = tmp.Close()
+		return 0, errors.WrapInternalf(err, errors.CodeInternal, "failed to write object %q", key)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return 0, errors.WrapInternalf(err, errors.CodeInternal, "failed to write object %q", key)
+	}
+
+	if err := os.Rename(tmp.Name(), filePath); err != nil {
+		return 0, errors.WrapInternalf(err, errors.CodeInternal, "failed to commit object %q", key)
+	}
+
+	return n, nil
+}
+
+func (provider *provider) Get(ctx context.Context, orgID valuer.UUID, key string) (io.ReadCloser, error) {
+	filePath, err := provider.pathFor(orgID, key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, errors.Newf(errors.TypeNotFound, objectstore.ErrCodeObjectNotFound, "object %q does not exist", key)
+		}
+		return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to open object %q", key)
+	}
+
+	return file, nil
+}
+
+func (provider *provider) List(ctx context.Context, orgID valuer.UUID, prefix string) ([]string, error) {
+	root := filepath.Join(provider.directory, orgID.StringValue())
+
+	keys := []string{}
+	err := filepath.WalkDir(root, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".tmp-") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, filePath)
+		if err != nil {
+			return err
+		}
+
+		key := filepath.ToSlash(rel)
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to list objects with prefix %q", prefix)
+	}
+
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (provider *provider) Delete(ctx context.Context, orgID valuer.UUID, prefix string) error {
+	keys, err := provider.List(ctx, orgID, prefix)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		filePath, err := provider.pathFor(orgID, key)
+		if err != nil {
+			return err
+		}
+
+		if err := os.Remove(filePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return errors.WrapInternalf(err, errors.CodeInternal, "failed to delete object %q", key)
+		}
+
+		// clean up the directories which became empty, stopping at the org root
+		root := filepath.Join(provider.directory, orgID.StringValue())
+		for dir := filepath.Dir(filePath); dir != root && strings.HasPrefix(dir, root); dir = filepath.Dir(dir) {
+			if err := os.Remove(dir); err != nil {
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+func (provider *provider) pathFor(orgID valuer.UUID, key string) (string, error) {
+	cleaned := path.Clean("/" + key)
+	if key == "" || cleaned == "/" || cleaned != "/"+key {
+		return "", errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "invalid object key %q", key)
+	}
+
+	return filepath.Join(provider.directory, orgID.StringValue(), filepath.FromSlash(cleaned)), nil
+}
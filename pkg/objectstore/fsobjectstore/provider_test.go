@@ -0,0 +1,80 @@
+package fsobjectstore
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/SigNoz/signoz/pkg/objectstore"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestProvider(t *testing.T) objectstore.ObjectStore {
+	store, err := New(context.Background(), factorytest.NewSettings(), objectstore.Config{Provider: "fs", FS: objectstore.FS{Directory: t.TempDir()}})
+	require.NoError(t, err)
+
+	return store
+}
+
+func TestPutGet(t *testing.T) {
+	store := newTestProvider(t)
+	orgID := valuer.GenerateUUID()
+
+	n, err := store.Put(context.Background(), orgID, "jobs/a/chunk-000001.jsonl", strings.NewReader("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), n)
+
+	// overwriting an object replaces it
+	_, err = store.Put(context.Background(), orgID, "jobs/a/chunk-000001.jsonl", strings.NewReader("world"))
+	require.NoError(t, err)
+
+	reader, err := store.Get(context.Background(), orgID, "jobs/a/chunk-000001.jsonl")
+	require.NoError(t, err)
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, "world", string(data))
+
+	// objects are scoped to the org
+	_, err = store.Get(context.Background(), valuer.GenerateUUID(), "jobs/a/chunk-000001.jsonl")
+	assert.True(t, errors.Asc(err, objectstore.ErrCodeObjectNotFound))
+}
+
+func TestListDelete(t *testing.T) {
+	store := newTestProvider(t)
+	orgID := valuer.GenerateUUID()
+
+	for _, key := range []string{"jobs/b/2", "jobs/a/2", "jobs/a/1"} {
+		_, err := store.Put(context.Background(), orgID, key, strings.NewReader(key))
+		require.NoError(t, err)
+	}
+
+	keys, err := store.List(context.Background(), orgID, "jobs/a/")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"jobs/a/1", "jobs/a/2"}, keys)
+
+	require.NoError(t, store.Delete(context.Background(), orgID, "jobs/a/"))
+
+	keys, err = store.List(context.Background(), orgID, "jobs/")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"jobs/b/2"}, keys)
+
+	keys, err = store.List(context.Background(), valuer.GenerateUUID(), "")
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+}
+
+func TestInvalidKey(t *testing.T) {
+	store := newTestProvider(t)
+
+	for _, key := range []string{"", "../escape", "a/../../b", "/abs"} {
+		_, err := store.Put(context.Background(), valuer.GenerateUUID(), key, strings.NewReader(""))
+		assert.Error(t, err, key)
+	}
+}
@@ -0,0 +1,27 @@
+package objectstore
+
+import (
+	"context"
+	"io"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+var (
+	ErrCodeObjectNotFound = errors.MustNewCode("object_not_found")
+)
+
+type ObjectStore interface {
+	// Put writes the object under the key, replacing any existing object with the same key.
+	Put(ctx context.Context, orgID valuer.UUID, key string, reader io.Reader) (int64, error)
+
+	// Get returns a reader for the object under the key. The caller is responsible for closing the reader.
+	Get(ctx context.Context, orgID valuer.UUID, key string) (io.ReadCloser, error)
+
+	// List returns the keys of all objects with the given prefix in lexical order.
+	List(ctx context.Context, orgID valuer.UUID, prefix string) ([]string, error)
+
+	// Delete deletes all objects with the given prefix.
+	Delete(ctx context.Context, orgID valuer.UUID, prefix string) error
+}
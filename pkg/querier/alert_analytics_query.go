@@ -0,0 +1,59 @@
+package querier
+
+import (
+	"context"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+)
+
+type alertAnalyticsQuery struct {
+	telemetryStore telemetrystore.TelemetryStore
+	stmtBuilder    qbtypes.AlertAnalyticsStatementBuilder
+	spec           qbtypes.AlertAnalyticsQuery
+	// the history is restricted to the rules of the organization
+	ruleIDs   []string
+	variables map[string]qbtypes.VariableItem
+	fromMS    uint64
+	toMS      uint64
+	kind      qbtypes.RequestType
+}
+
+var _ qbtypes.Query = (*alertAnalyticsQuery)(nil)
+
+func (q *alertAnalyticsQuery) Fingerprint() string {
+	return ""
+}
+
+func (q *alertAnalyticsQuery) Window() (uint64, uint64) {
+	return q.fromMS, q.toMS
+}
+
+func (q *alertAnalyticsQuery) statement(ctx context.Context) (*qbtypes.Statement, error) {
+	return q.stmtBuilder.Build(ctx, q.fromMS, q.toMS, q.kind, q.spec, q.ruleIDs, q.variables)
+}
+
+func (q *alertAnalyticsQuery) Execute(ctx context.Context) (*qbtypes.Result, error) {
+	stmt, err := q.statement(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	queryWindow := &qbtypes.TimeRange{From: q.fromMS, To: q.toMS}
+
+	payload, stats, err := executeStatement(ctx, q.telemetryStore, stmt.Query, stmt.Args, func(rows driver.Rows) (any, error) {
+		return consume(rows, q.kind, queryWindow, q.spec.StepInterval, q.spec.Name)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &qbtypes.Result{
+		Type:           q.kind,
+		Value:          payload,
+		Stats:          stats,
+		Warnings:       stmt.Warnings,
+		WarningsDocURL: stmt.WarningsDocURL,
+	}, nil
+}
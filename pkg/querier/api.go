@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"runtime/debug"
 	"strconv"
+	"strings"
 
 	"github.com/SigNoz/signoz/pkg/analytics"
 	"github.com/SigNoz/signoz/pkg/errors"
@@ -89,6 +90,80 @@ func (a *API) QueryRange(rw http.ResponseWriter, req *http.Request) {
 
 	render.Success(rw, http.StatusOK, queryRangeResponse)
 }
+
+// QueryRangeStream runs a query range request and streams its results as NDJSON, or as server-sent events when asked
+// for with the format parameter or the Accept header.
+func (a *API) QueryRangeStream(rw http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	format, err := streamFormatFromRequest(req)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	var queryRangeRequest qbtypes.QueryRangeRequest
+	if err := json.NewDecoder(req.Body).Decode(&queryRangeRequest); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	flusher, ok := rw.(http.Flusher)
+	if !ok {
+		render.Error(rw, errors.Newf(errors.TypeUnsupported, errors.CodeUnsupported, "streaming is not supported"))
+		return
+	}
+
+	stream := &httpStreamWriter{rw: rw, flusher: flusher, format: format}
+
+	defer func() {
+		if r := recover(); r != nil {
+			stackTrace := string(debug.Stack())
+
+			queryJSON, _ := json.Marshal(queryRangeRequest)
+
+			a.set.Logger.ErrorContext(ctx, "panic in QueryRangeStream",
+				"error", r,
+				"user", claims.UserID,
+				"payload", string(queryJSON),
+				"stacktrace", stackTrace,
+			)
+
+			stream.error(errors.NewInternalf(
+				errors.CodeInternal,
+				"Something went wrong on our end. It's not you, it's us. Our team is notified about it. Reach out to support if issue persists.",
+			))
+		}
+	}()
+
+	// Validate the query request
+	if err := queryRangeRequest.Validate(); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	orgID, err := valuer.NewUUID(claims.OrgID)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	if err := a.querier.QueryRangeStream(ctx, orgID, &queryRangeRequest, stream); err != nil {
+		stream.error(err)
+		return
+	}
+
+	if stream.event != nil {
+		a.logEvent(req.Context(), req.Header.Get("Referer"), stream.event)
+	}
+}
+
 func (a *API) QueryRawStream(rw http.ResponseWriter, req *http.Request) {
 	ctx := req.Context()
 
@@ -218,6 +293,79 @@ func (a *API) QueryRawStream(rw http.ResponseWriter, req *http.Request) {
 	}
 }
 
+// streamFormatFromRequest returns the format of a streamed response asked for with the format parameter, or with the
+// Accept header, NDJSON by default
+func streamFormatFromRequest(req *http.Request) (qbtypes.StreamFormat, error) {
+	switch req.URL.Query().Get("format") {
+	case qbtypes.StreamFormatNDJSON.StringValue():
+		return qbtypes.StreamFormatNDJSON, nil
+	case qbtypes.StreamFormatSSE.StringValue():
+		return qbtypes.StreamFormatSSE, nil
+	case "":
+		if strings.Contains(req.Header.Get("Accept"), "text/event-stream") {
+			return qbtypes.StreamFormatSSE, nil
+		}
+		return qbtypes.StreamFormatNDJSON, nil
+	default:
+		return qbtypes.StreamFormat{}, errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid stream format %q, supported formats are ndjson and sse", req.URL.Query().Get("format"))
+	}
+}
+
+// httpStreamWriter writes the events of a streamed response to the client in its format, every event is flushed as it
+// is written. The headers are written with the first event so that the errors before it are rendered as usual.
+type httpStreamWriter struct {
+	rw      http.ResponseWriter
+	flusher http.Flusher
+	format  qbtypes.StreamFormat
+	started bool
+	event   *qbtypes.QBEvent
+}
+
+func (w *httpStreamWriter) Write(event *qbtypes.StreamEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return errors.WrapInternalf(err, errors.CodeInternal, "failed to marshal the %s event", event.Type.StringValue())
+	}
+
+	if !w.started {
+		if w.format == qbtypes.StreamFormatSSE {
+			w.rw.Header().Set("Content-Type", "text/event-stream")
+			w.rw.Header().Set("Connection", "keep-alive")
+		} else {
+			w.rw.Header().Set("Content-Type", "application/x-ndjson")
+		}
+		w.rw.Header().Set("Cache-Control", "no-cache")
+		w.rw.WriteHeader(http.StatusOK)
+		w.started = true
+	}
+
+	if event.QBEvent != nil {
+		w.event = event.QBEvent
+	}
+
+	if w.format == qbtypes.StreamFormatSSE {
+		_, err = fmt.Fprintf(w.rw, "event: %s\ndata: %s\n\n", event.Type.StringValue(), data)
+	} else {
+		_, err = w.rw.Write(append(data, '\n'))
+	}
+	if err != nil {
+		return err
+	}
+
+	w.flusher.Flush()
+	return nil
+}
+
+// error renders the error, as an error event once the stream has started
+func (w *httpStreamWriter) error(err error) {
+	if !w.started {
+		render.Error(w.rw, err)
+		return
+	}
+
+	_ = w.Write(&qbtypes.StreamEvent{Type: qbtypes.StreamEventTypeError, Error: errors.AsJSON(err)})
+}
+
 // TODO(srikanthccv): everything done here can be done on frontend as well
 // For the time being I am adding a helper function
 func (a *API) ReplaceVariables(rw http.ResponseWriter, req *http.Request) {
@@ -272,6 +420,94 @@ func (a *API) ReplaceVariables(rw http.ResponseWriter, req *http.Request) {
 	render.Success(rw, http.StatusOK, queryRangeRequest)
 }
 
+// SetWatermark records the watermark reported by a source of the data of a signal
+func (a *API) SetWatermark(rw http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	orgID, err := valuer.NewUUID(claims.OrgID)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	var postable qbtypes.PostableWatermark
+	if err := json.NewDecoder(req.Body).Decode(&postable); err != nil {
+		render.Error(rw, errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid request body: %v", err))
+		return
+	}
+
+	if err := a.querier.SetWatermark(ctx, orgID, &postable); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusNoContent, nil)
+}
+
+func (a *API) ListWatermarks(rw http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	orgID, err := valuer.NewUUID(claims.OrgID)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	watermarks, err := a.querier.ListWatermarks(ctx, orgID)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, watermarks)
+}
+
+// PurgeCache purges the cached results of the queries of a signal
+func (a *API) PurgeCache(rw http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	orgID, err := valuer.NewUUID(claims.OrgID)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	var postable qbtypes.PostableCachePurge
+	if err := json.NewDecoder(req.Body).Decode(&postable); err != nil {
+		render.Error(rw, errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid request body: %v", err))
+		return
+	}
+
+	if postable.Reason.StringValue() == "" {
+		postable.Reason = qbtypes.CachePurgeReasonManual
+	}
+
+	if err := a.querier.PurgeCache(ctx, orgID, claims.Email, &postable); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusNoContent, nil)
+}
+
 func (a *API) logEvent(ctx context.Context, referrer string, event *qbtypes.QBEvent) {
 	claims, err := authtypes.ClaimsFromContext(ctx)
 	if err != nil {
@@ -5,34 +5,54 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"maps"
 	"slices"
+	"sync"
 	"time"
 
 	"github.com/SigNoz/signoz/pkg/cache"
 	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/factory"
 	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
 )
 
 // bucketCache implements the BucketCache interface
 type bucketCache struct {
-	cache        cache.Cache
-	logger       *slog.Logger
-	cacheTTL     time.Duration
-	fluxInterval time.Duration
+	cache         cache.Cache
+	logger        *slog.Logger
+	cacheTTL      time.Duration
+	fluxInterval  time.Duration
+	fluxIntervals map[telemetrytypes.Signal]time.Duration
+	invalidator   Invalidator
+
+	mtx sync.Mutex
+	// generations are the keys of the results this instance cached within the current generation of every signal,
+	// they are deleted once the signal is purged rather than left in the cache until the cache ttl
+	generations map[invalidationKey]*cachedGeneration
+}
+
+// cachedGeneration is the keys of the results cached within a generation of a signal
+type cachedGeneration struct {
+	generation int64
+	keys       map[string]struct{}
 }
 
 var _ BucketCache = (*bucketCache)(nil)
 
-// NewBucketCache creates a new BucketCache implementation
-func NewBucketCache(settings factory.ProviderSettings, cache cache.Cache, cacheTTL time.Duration, fluxInterval time.Duration) BucketCache {
+// NewBucketCache creates a new BucketCache implementation, fluxIntervals overrides the flux interval of the signals
+// and the invalidator, if any, extends them to the lag of the sources and purges the cached results
+func NewBucketCache(settings factory.ProviderSettings, cache cache.Cache, cacheTTL time.Duration, fluxInterval time.Duration, fluxIntervals map[telemetrytypes.Signal]time.Duration, invalidator Invalidator) BucketCache {
 	cacheSettings := factory.NewScopedProviderSettings(settings, "github.com/SigNoz/signoz/pkg/querier/bucket_cache")
 	return &bucketCache{
-		cache:        cache,
-		logger:       cacheSettings.Logger(),
-		cacheTTL:     cacheTTL,
-		fluxInterval: fluxInterval,
+		cache:         cache,
+		logger:        cacheSettings.Logger(),
+		cacheTTL:      cacheTTL,
+		fluxInterval:  fluxInterval,
+		fluxIntervals: fluxIntervals,
+		invalidator:   invalidator,
+		generations:   make(map[invalidationKey]*cachedGeneration),
 	}
 }
 
@@ -50,7 +70,7 @@ func (bc *bucketCache) GetMissRanges(
 	bc.logger.DebugContext(ctx, "getting miss ranges", "fingerprint", q.Fingerprint(), "start", startMs, "end", endMs)
 
 	// Generate cache key
-	cacheKey := bc.generateCacheKey(q)
+	cacheKey := bc.generateCacheKey(ctx, orgID, q)
 
 	bc.logger.DebugContext(ctx, "cache key", "cache_key", cacheKey)
 
@@ -69,8 +89,12 @@ func (bc *bucketCache) GetMissRanges(
 	// Extract step interval if this is a builder query
 	stepMs := uint64(step.Duration.Milliseconds())
 
+	// The buckets past the flux boundary may miss late data, as the flux interval grew since they were cached
+	fluxInterval := bc.fluxIntervalOf(ctx, orgID, q)
+	data.Buckets = bc.dropUnsettledBuckets(data.Buckets, uint64(time.Now().UnixMilli())-uint64(fluxInterval.Milliseconds()))
+
 	// Find missing ranges with step alignment
-	missing = bc.findMissingRangesWithStep(data.Buckets, startMs, endMs, stepMs)
+	missing = bc.findMissingRangesWithStep(data.Buckets, startMs, endMs, stepMs, fluxInterval)
 	bc.logger.DebugContext(ctx, "missing ranges", "missing", missing, "step", stepMs)
 
 	// If no cached data overlaps with requested range, return empty result
@@ -100,7 +124,7 @@ func (bc *bucketCache) Put(ctx context.Context, orgID valuer.UUID, q qbtypes.Que
 
 	// Calculate the flux boundary - data after this point should not be cached
 	currentMs := uint64(time.Now().UnixMilli())
-	fluxBoundary := currentMs - uint64(bc.fluxInterval.Milliseconds())
+	fluxBoundary := currentMs - uint64(bc.fluxIntervalOf(ctx, orgID, q).Milliseconds())
 
 	// If the entire range is within flux interval, skip caching
 	if startMs >= fluxBoundary {
@@ -121,13 +145,14 @@ func (bc *bucketCache) Put(ctx context.Context, orgID valuer.UUID, q qbtypes.Que
 	}
 
 	// Generate cache key
-	cacheKey := bc.generateCacheKey(q)
+	cacheKey := bc.generateCacheKey(ctx, orgID, q)
 
-	// Get existing cached data
+	// Get existing cached data, the buckets past the flux boundary are replaced by the fresh ones
 	var existingData qbtypes.CachedData
 	if err := bc.cache.Get(ctx, orgID, cacheKey, &existingData); err != nil {
 		existingData = qbtypes.CachedData{}
 	}
+	existingData.Buckets = bc.dropUnsettledBuckets(existingData.Buckets, fluxBoundary)
 
 	// Trim the result to exclude data within flux interval
 	trimmedResult := bc.trimResultToFluxBoundary(fresh, cachableEndMs)
@@ -191,18 +216,89 @@ func (bc *bucketCache) Put(ctx context.Context, orgID valuer.UUID, q qbtypes.Que
 	}
 }
 
-// generateCacheKey creates a unique cache key based on query fingerprint
-func (bc *bucketCache) generateCacheKey(q qbtypes.Query) string {
+// generateCacheKey creates a unique cache key based on query fingerprint, the key changes whenever the cached
+// results of the signal of the query are purged
+func (bc *bucketCache) generateCacheKey(ctx context.Context, orgID valuer.UUID, q qbtypes.Query) string {
 	fingerprint := q.Fingerprint()
 
-	return fmt.Sprintf("v5:query:%s", fingerprint)
+	sq, ok := q.(signalQuery)
+	if !ok || bc.invalidator == nil {
+		return fmt.Sprintf("v5:query:%s", fingerprint)
+	}
+
+	generation := bc.invalidator.Generation(ctx, orgID, sq.signal())
+	cacheKey := fmt.Sprintf("v5:query:%s", fingerprint)
+	if generation > 0 {
+		cacheKey = fmt.Sprintf("v5:query:%d:%s", generation, fingerprint)
+	}
+
+	bc.track(ctx, orgID, sq.signal(), generation, cacheKey)
+	return cacheKey
+}
+
+// track remembers the key within the generation of the signal, the keys of the previous generation can't be read
+// anymore and are deleted in the background
+func (bc *bucketCache) track(ctx context.Context, orgID valuer.UUID, signal telemetrytypes.Signal, generation int64, cacheKey string) {
+	key := invalidationKey{orgID: orgID.StringValue(), signal: signal}
+
+	bc.mtx.Lock()
+	current, ok := bc.generations[key]
+	if ok && generation < current.generation {
+		bc.mtx.Unlock()
+		return
+	}
+
+	var purged []string
+	if !ok || generation > current.generation {
+		if ok {
+			purged = slices.Collect(maps.Keys(current.keys))
+		}
+		current = &cachedGeneration{generation: generation, keys: make(map[string]struct{})}
+		bc.generations[key] = current
+	}
+	current.keys[cacheKey] = struct{}{}
+	bc.mtx.Unlock()
+
+	if len(purged) > 0 {
+		bc.logger.DebugContext(ctx, "deleting the results cached before the purge", "org_id", orgID.StringValue(), "signal", signal.StringValue(), "keys", len(purged))
+		go bc.cache.DeleteMany(context.WithoutCancel(ctx), orgID, purged)
+	}
+}
+
+// fluxIntervalOf returns the interval for recent data of the query that should not be cached, the flux interval of its
+// signal extended to the lag of the sources of the signal
+func (bc *bucketCache) fluxIntervalOf(ctx context.Context, orgID valuer.UUID, q qbtypes.Query) time.Duration {
+	sq, ok := q.(signalQuery)
+	if !ok {
+		return bc.fluxInterval
+	}
+
+	fluxInterval, ok := bc.fluxIntervals[sq.signal()]
+	if !ok {
+		fluxInterval = bc.fluxInterval
+	}
+
+	if bc.invalidator != nil {
+		if lag := bc.invalidator.Lag(ctx, orgID, sq.signal()); lag > fluxInterval {
+			fluxInterval = lag
+		}
+	}
+
+	return fluxInterval
+}
+
+// dropUnsettledBuckets drops the buckets which end past the flux boundary
+func (bc *bucketCache) dropUnsettledBuckets(buckets []*qbtypes.CachedBucket, fluxBoundary uint64) []*qbtypes.CachedBucket {
+	return slices.DeleteFunc(buckets, func(bucket *qbtypes.CachedBucket) bool {
+		return bucket.EndMs > fluxBoundary
+	})
 }
 
 // findMissingRangesWithStep identifies time ranges not covered by cached buckets with step alignment
-func (bc *bucketCache) findMissingRangesWithStep(buckets []*qbtypes.CachedBucket, startMs, endMs uint64, stepMs uint64) []*qbtypes.TimeRange {
+func (bc *bucketCache) findMissingRangesWithStep(buckets []*qbtypes.CachedBucket, startMs, endMs uint64, stepMs uint64, fluxInterval time.Duration) []*qbtypes.TimeRange {
 	// When step is 0 or window is too small to be cached, use simple algorithm
 	if stepMs == 0 || (startMs+stepMs) > endMs {
-		return bc.findMissingRangesBasic(buckets, startMs, endMs)
+		return bc.findMissingRangesBasic(buckets, startMs, endMs, fluxInterval)
 	}
 
 	// When no buckets exist, handle partial windows specially
@@ -316,7 +412,7 @@ func (bc *bucketCache) findMissingRangesWithStep(buckets []*qbtypes.CachedBucket
 }
 
 // findMissingRangesBasic is the simple algorithm without step alignment
-func (bc *bucketCache) findMissingRangesBasic(buckets []*qbtypes.CachedBucket, startMs, endMs uint64) []*qbtypes.TimeRange {
+func (bc *bucketCache) findMissingRangesBasic(buckets []*qbtypes.CachedBucket, startMs, endMs uint64, fluxInterval time.Duration) []*qbtypes.TimeRange {
 	// Check if already sorted before sorting
 	needsSort := false
 	for i := 1; i < len(buckets); i++ {
@@ -368,7 +464,7 @@ func (bc *bucketCache) findMissingRangesBasic(buckets []*qbtypes.CachedBucket, s
 	if currentMs < endMs {
 		// Check if we need to limit due to flux interval
 		currentTime := uint64(time.Now().UnixMilli())
-		fluxBoundary := currentTime - uint64(bc.fluxInterval.Milliseconds())
+		fluxBoundary := currentTime - uint64(fluxInterval.Milliseconds())
 
 		// If the missing range extends beyond flux boundary, limit it
 		if currentMs < fluxBoundary {
@@ -469,8 +565,8 @@ func (bc *bucketCache) mergeTimeSeriesValues(ctx context.Context, buckets []*qbt
 	seriesMap := make(map[seriesKey]*qbtypes.TimeSeries, estimatedSeries)
 
 	for _, bucket := range buckets {
-		var tsData *qbtypes.TimeSeriesData
-		if err := json.Unmarshal(bucket.Value, &tsData); err != nil {
+		tsData, err := bucket.TimeSeriesData()
+		if err != nil {
 			bc.logger.ErrorContext(ctx, "failed to unmarshal time series data", "error", err)
 			continue
 		}
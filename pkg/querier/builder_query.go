@@ -9,7 +9,9 @@ import (
 	"time"
 
 	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
 	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/querybuilder"
 	"github.com/SigNoz/signoz/pkg/telemetrylogs"
 	"github.com/SigNoz/signoz/pkg/telemetrystore"
 	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
@@ -22,13 +24,19 @@ type builderQuery[T any] struct {
 	stmtBuilder    qbtypes.StatementBuilder[T]
 	spec           qbtypes.QueryBuilderQuery[T]
 	variables      map[string]qbtypes.VariableItem
+	subQueries     []*subQuery
 
 	fromMS uint64
 	toMS   uint64
 	kind   qbtypes.RequestType
+
+	// emit receives the result in parts when the query is streamed
+	emit emitFunc
 }
 
 var _ qbtypes.Query = (*builderQuery[any])(nil)
+var _ streamingQuery = (*builderQuery[any])(nil)
+var _ signalQuery = (*builderQuery[any])(nil)
 
 func newBuilderQuery[T any](
 	telemetryStore telemetrystore.TelemetryStore,
@@ -49,6 +57,10 @@ func newBuilderQuery[T any](
 	}
 }
 
+func (q *builderQuery[T]) signal() telemetrytypes.Signal {
+	return q.spec.Signal
+}
+
 func (q *builderQuery[T]) Fingerprint() string {
 
 	if (q.spec.Signal == telemetrytypes.SignalTraces ||
@@ -57,6 +69,16 @@ func (q *builderQuery[T]) Fingerprint() string {
 		return ""
 	}
 
+	// distributions are bucketed over the whole window, so they can't be merged from cached windows
+	if q.kind == qbtypes.RequestTypeDistribution {
+		return ""
+	}
+
+	// the results depend on the sub queries over the whole window, so they can't be merged from cached windows either
+	if len(q.subQueries) > 0 {
+		return ""
+	}
+
 	// Create a deterministic fingerprint for builder queries
 	// This needs to include all fields that affect the query results
 	parts := []string{"builder"}
@@ -189,7 +211,7 @@ func (q *builderQuery[T]) Execute(ctx context.Context) (*qbtypes.Result, error)
 		return q.executeWindowList(ctx)
 	}
 
-	stmt, err := q.stmtBuilder.Build(ctx, q.fromMS, q.toMS, q.kind, q.spec, q.variables)
+	stmt, err := q.buildStatement(ctx, q.fromMS, q.toMS)
 	if err != nil {
 		return nil, err
 	}
@@ -205,47 +227,56 @@ func (q *builderQuery[T]) Execute(ctx context.Context) (*qbtypes.Result, error)
 	return result, nil
 }
 
-// executeWithContext executes the query with query window and step context for partial value detection
-func (q *builderQuery[T]) executeWithContext(ctx context.Context, query string, args []any) (*qbtypes.Result, error) {
-	totalRows := uint64(0)
-	totalBytes := uint64(0)
-	elapsed := time.Duration(0)
+func (q *builderQuery[T]) streamTo(emit emitFunc) {
+	q.emit = emit
+}
 
-	ctx = clickhouse.Context(ctx, clickhouse.WithProgress(func(p *clickhouse.Progress) {
-		totalRows += p.Rows
-		totalBytes += p.Bytes
-		elapsed += p.Elapsed
-	}))
+func (q *builderQuery[T]) statement(ctx context.Context) (*qbtypes.Statement, error) {
+	return q.buildStatement(ctx, q.fromMS, q.toMS)
+}
 
-	rows, err := q.telemetryStore.ClickhouseDB().Query(ctx, query, args...)
+// buildStatement builds the statement of the query for the window, with the CTEs of its sub queries attached
+func (q *builderQuery[T]) buildStatement(ctx context.Context, fromMS, toMS uint64) (*qbtypes.Statement, error) {
+	stmt, err := q.stmtBuilder.Build(ctx, fromMS, toMS, q.kind, q.spec, q.variables)
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			return nil, errors.Newf(errors.TypeTimeout, errors.CodeTimeout, "Query timed out").
-				WithAdditional("Try refining your search by adding relevant resource attributes filtering")
-		}
+		return nil, err
+	}
 
-		if !errors.Is(err, context.Canceled) {
-			return nil, errors.Newf(
-				errors.TypeInternal,
-				errors.CodeInternal,
-				"Something went wrong on our end. It's not you, it's us. Our team is notified about it. Reach out to support if issue persists.",
-			)
+	var (
+		cteFragments []string
+		cteArgs      [][]any
+	)
+	for _, sq := range q.subQueries {
+		sqStmt, err := sq.build(ctx)
+		if err != nil {
+			return nil, err
 		}
 
-		return nil, err
+		frag, args := querybuilder.BuildSubQueryCTE(sq.name, sq.groupBy, sqStmt)
+		cteFragments = append(cteFragments, frag)
+		cteArgs = append(cteArgs, args)
 	}
-	defer rows.Close()
 
+	return querybuilder.AttachCTEs(stmt, cteFragments, cteArgs), nil
+}
+
+// executeWithContext executes the query with query window and step context for partial value detection
+func (q *builderQuery[T]) executeWithContext(ctx context.Context, query string, args []any) (*qbtypes.Result, error) {
 	// Pass query window and step for partial value detection
 	queryWindow := &qbtypes.TimeRange{From: q.fromMS, To: q.toMS}
 
 	kind := q.kind
 	// all metric queries are time series then reduced if required
-	if q.spec.Signal == telemetrytypes.SignalMetrics {
+	if q.spec.Signal == telemetrytypes.SignalMetrics && q.kind != qbtypes.RequestTypeDistribution {
 		kind = qbtypes.RequestTypeTimeSeries
 	}
 
-	payload, err := consume(rows, kind, queryWindow, q.spec.StepInterval, q.spec.Name)
+	payload, stats, err := executeStatement(ctx, q.telemetryStore, query, args, func(rows driver.Rows) (any, error) {
+		if q.emit != nil {
+			return nil, consumeStream(rows, kind, queryWindow, q.spec.StepInterval, q.spec.Name, q.emit)
+		}
+		return consume(rows, kind, queryWindow, q.spec.StepInterval, q.spec.Name)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -287,11 +318,57 @@ func (q *builderQuery[T]) executeWithContext(ctx context.Context, query string,
 	return &qbtypes.Result{
 		Type:  q.kind,
 		Value: payload,
-		Stats: qbtypes.ExecStats{
-			RowsScanned:  totalRows,
-			BytesScanned: totalBytes,
-			DurationMS:   uint64(elapsed.Milliseconds()),
-		},
+		Stats: stats,
+	}, nil
+}
+
+// executeStatement runs the statement and consumes its rows, the stats of the execution are tracked from the progress
+// of the query.
+func executeStatement(
+	ctx context.Context,
+	telemetryStore telemetrystore.TelemetryStore,
+	query string,
+	args []any,
+	consumeRows func(driver.Rows) (any, error),
+) (any, qbtypes.ExecStats, error) {
+	totalRows := uint64(0)
+	totalBytes := uint64(0)
+	elapsed := time.Duration(0)
+
+	ctx = clickhouse.Context(ctx, clickhouse.WithProgress(func(p *clickhouse.Progress) {
+		totalRows += p.Rows
+		totalBytes += p.Bytes
+		elapsed += p.Elapsed
+	}))
+
+	rows, err := telemetryStore.ClickhouseDB().Query(ctx, query, args...)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, qbtypes.ExecStats{}, errors.Newf(errors.TypeTimeout, errors.CodeTimeout, "Query timed out").
+				WithAdditional("Try refining your search by adding relevant resource attributes filtering")
+		}
+
+		if !errors.Is(err, context.Canceled) {
+			return nil, qbtypes.ExecStats{}, errors.Newf(
+				errors.TypeInternal,
+				errors.CodeInternal,
+				"Something went wrong on our end. It's not you, it's us. Our team is notified about it. Reach out to support if issue persists.",
+			)
+		}
+
+		return nil, qbtypes.ExecStats{}, err
+	}
+	defer rows.Close()
+
+	payload, err := consumeRows(rows)
+	if err != nil {
+		return nil, qbtypes.ExecStats{}, err
+	}
+
+	return payload, qbtypes.ExecStats{
+		RowsScanned:  totalRows,
+		BytesScanned: totalBytes,
+		DurationMS:   uint64(elapsed.Milliseconds()),
 	}, nil
 }
 
@@ -345,7 +422,7 @@ func (q *builderQuery[T]) executeWindowList(ctx context.Context) (*qbtypes.Resul
 		q.spec.Offset = 0
 		q.spec.Limit = need
 
-		stmt, err := q.stmtBuilder.Build(ctx, r.fromNS/1e6, r.toNS/1e6, q.kind, q.spec, q.variables)
+		stmt, err := q.buildStatement(ctx, r.fromNS/1e6, r.toNS/1e6)
 		if err != nil {
 			return nil, err
 		}
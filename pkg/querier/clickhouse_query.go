@@ -27,9 +27,13 @@ type chSQLQuery struct {
 	toMS   uint64
 	kind   qbtypes.RequestType
 	vars   map[string]qbtypes.VariableItem
+
+	// emit receives the result in parts when the query is streamed
+	emit emitFunc
 }
 
 var _ qbtypes.Query = (*chSQLQuery)(nil)
+var _ streamingQuery = (*chSQLQuery)(nil)
 
 func newchSQLQuery(
 	logger *slog.Logger,
@@ -97,6 +101,19 @@ func (q *chSQLQuery) renderVars(query string, vars map[string]qbtypes.VariableIt
 	return newQuery.String(), nil
 }
 
+func (q *chSQLQuery) streamTo(emit emitFunc) {
+	q.emit = emit
+}
+
+func (q *chSQLQuery) statement(_ context.Context) (*qbtypes.Statement, error) {
+	query, err := q.renderVars(q.query.Query, q.vars, q.fromMS, q.toMS)
+	if err != nil {
+		return nil, err
+	}
+
+	return &qbtypes.Statement{Query: query, Args: q.args}, nil
+}
+
 func (q *chSQLQuery) Execute(ctx context.Context) (*qbtypes.Result, error) {
 
 	totalRows := uint64(0)
@@ -109,19 +126,24 @@ func (q *chSQLQuery) Execute(ctx context.Context) (*qbtypes.Result, error) {
 		elapsed += p.Elapsed
 	}))
 
-	query, err := q.renderVars(q.query.Query, q.vars, q.fromMS, q.toMS)
+	stmt, err := q.statement(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	rows, err := q.telemetryStore.ClickhouseDB().Query(ctx, query, q.args...)
+	rows, err := q.telemetryStore.ClickhouseDB().Query(ctx, stmt.Query, stmt.Args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
 	// TODO: map the errors from ClickHouse to our error types
-	payload, err := consume(rows, q.kind, nil, qbtypes.Step{}, q.query.Name)
+	var payload any
+	if q.emit != nil {
+		err = consumeStream(rows, q.kind, nil, qbtypes.Step{}, q.query.Name, q.emit)
+	} else {
+		payload, err = consume(rows, q.kind, nil, qbtypes.Step{}, q.query.Name)
+	}
 	if err != nil {
 		return nil, err
 	}
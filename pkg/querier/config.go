@@ -5,16 +5,80 @@ import (
 
 	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
 )
 
 // Config represents the configuration for the querier
 type Config struct {
 	// CacheTTL is the TTL for cached query results
 	CacheTTL time.Duration `yaml:"cache_ttl" mapstructure:"cache_ttl"`
-	// FluxInterval is the interval for recent data that should not be cached
+	// FluxInterval is the interval for recent data that should not be cached, for the signals without their own
 	FluxInterval time.Duration `yaml:"flux_interval" mapstructure:"flux_interval"`
+	// FluxIntervals overrides the flux interval of the signals
+	FluxIntervals FluxIntervals `yaml:"flux_intervals" mapstructure:"flux_intervals"`
+	// Invalidation is the invalidation of the cached results on late data and on purges
+	Invalidation InvalidationConfig `yaml:"invalidation" mapstructure:"invalidation"`
 	// MaxConcurrentQueries is the maximum number of concurrent queries for missing ranges
 	MaxConcurrentQueries int `yaml:"max_concurrent_queries" mapstructure:"max_concurrent_queries"`
+	// Budget is the budget of the queries of the organizations and their users
+	Budget BudgetConfig `yaml:"budget" mapstructure:"budget"`
+}
+
+// FluxIntervals represents the flux intervals of the signals, a zero interval is the flux interval of the querier
+type FluxIntervals struct {
+	Traces  time.Duration `yaml:"traces" mapstructure:"traces"`
+	Logs    time.Duration `yaml:"logs" mapstructure:"logs"`
+	Metrics time.Duration `yaml:"metrics" mapstructure:"metrics"`
+}
+
+// BySignal returns the flux intervals set for the signals
+func (f FluxIntervals) BySignal() map[telemetrytypes.Signal]time.Duration {
+	intervals := make(map[telemetrytypes.Signal]time.Duration)
+	for signal, interval := range map[telemetrytypes.Signal]time.Duration{
+		telemetrytypes.SignalTraces:  f.Traces,
+		telemetrytypes.SignalLogs:    f.Logs,
+		telemetrytypes.SignalMetrics: f.Metrics,
+	} {
+		if interval > 0 {
+			intervals[signal] = interval
+		}
+	}
+	return intervals
+}
+
+// InvalidationConfig represents the invalidation of the cached results. The sources of the data report their
+// watermarks and the data past the watermarks is not cached. The purges of the cached results of a signal, on the
+// changes of the retention or of the pipelines, are recorded in the sql store for every instance of the querier.
+type InvalidationConfig struct {
+	// RefreshInterval is the interval at which the watermarks and the purges are read again from the sql store
+	RefreshInterval time.Duration `yaml:"refresh_interval" mapstructure:"refresh_interval"`
+	// WatermarkTTL is the time after which the watermark of a source which stopped reporting is ignored
+	WatermarkTTL time.Duration `yaml:"watermark_ttl" mapstructure:"watermark_ttl"`
+	// MaxLag caps the lag learned from the watermarks, the data older than it is cached regardless of the watermarks
+	MaxLag time.Duration `yaml:"max_lag" mapstructure:"max_lag"`
+}
+
+// BudgetConfig represents the budgets of the queries, the budgets are enforced by every instance of the querier
+type BudgetConfig struct {
+	// Enabled enables the budgets, the cost of the queries of the users is estimated before they are executed
+	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
+	// Org is the budget of every organization
+	Org Budget `yaml:"org" mapstructure:"org"`
+	// User is the budget of every user of an organization
+	User Budget `yaml:"user" mapstructure:"user"`
+	// Orgs overrides the budget of the organizations by their id
+	Orgs map[string]Budget `yaml:"orgs" mapstructure:"orgs"`
+}
+
+// Budget represents the limits of the queries, a zero limit is no limit
+type Budget struct {
+	// MaxBytesPerMinute is the maximum number of bytes the queries are estimated to read in a minute
+	MaxBytesPerMinute uint64 `yaml:"max_bytes_per_minute" mapstructure:"max_bytes_per_minute"`
+	// MaxConcurrentQueries is the maximum number of queries running at once
+	MaxConcurrentQueries int `yaml:"max_concurrent_queries" mapstructure:"max_concurrent_queries"`
+	// MaxQueriesPerMinute is the maximum number of queries started in a minute
+	MaxQueriesPerMinute int `yaml:"max_queries_per_minute" mapstructure:"max_queries_per_minute"`
 }
 
 // NewConfigFactory creates a new config factory for querier
@@ -28,6 +92,24 @@ func newConfig() factory.Config {
 		CacheTTL:             168 * time.Hour,
 		FluxInterval:         5 * time.Minute,
 		MaxConcurrentQueries: 4,
+		Invalidation: InvalidationConfig{
+			RefreshInterval: 30 * time.Second,
+			WatermarkTTL:    time.Hour,
+			MaxLag:          24 * time.Hour,
+		},
+		Budget: BudgetConfig{
+			Enabled: false,
+			Org: Budget{
+				MaxBytesPerMinute:    1 << 40,
+				MaxConcurrentQueries: 64,
+				MaxQueriesPerMinute:  3000,
+			},
+			User: Budget{
+				MaxBytesPerMinute:    1 << 38,
+				MaxConcurrentQueries: 16,
+				MaxQueriesPerMinute:  600,
+			},
+		},
 	}
 }
 
@@ -39,9 +121,26 @@ func (c Config) Validate() error {
 	if c.FluxInterval <= 0 {
 		return errors.NewInvalidInputf(errors.CodeInvalidInput, "flux_interval must be positive, got %v", c.FluxInterval)
 	}
+	if c.FluxIntervals.Traces < 0 || c.FluxIntervals.Logs < 0 || c.FluxIntervals.Metrics < 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "flux_intervals cannot be negative, got %+v", c.FluxIntervals)
+	}
+	if c.Invalidation.RefreshInterval <= 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "invalidation.refresh_interval must be positive, got %v", c.Invalidation.RefreshInterval)
+	}
+	if c.Invalidation.WatermarkTTL <= 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "invalidation.watermark_ttl must be positive, got %v", c.Invalidation.WatermarkTTL)
+	}
+	if c.Invalidation.MaxLag < 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "invalidation.max_lag cannot be negative, got %v", c.Invalidation.MaxLag)
+	}
 	if c.MaxConcurrentQueries <= 0 {
 		return errors.NewInvalidInputf(errors.CodeInvalidInput, "max_concurrent_queries must be positive, got %v", c.MaxConcurrentQueries)
 	}
+	for id := range c.Budget.Orgs {
+		if _, err := valuer.NewUUID(id); err != nil {
+			return errors.WrapInvalidInputf(err, errors.CodeInvalidInput, "budget.orgs must be keyed by the id of the organizations, got %q", id)
+		}
+	}
 	return nil
 }
 
@@ -12,6 +12,8 @@ import (
 	"time"
 
 	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/querybuilder"
 	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
 	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
 	"github.com/bytedance/sonic"
@@ -25,6 +27,14 @@ var (
 	legacyReservedColumnTargetAliases = []string{"__result", "__value", "result", "res", "value"}
 )
 
+// streamBatchSize is the number of points or rows read before they are handed to the emitFunc of a streamed query
+const streamBatchSize = 10000
+
+// emitFunc receives the parts of the payload of a streamed query as its rows are read, a *qbtypes.TimeSeriesData with
+// the points read since the previous part for time series and a *qbtypes.ScalarData with the rows read since the
+// previous part for scalar.
+type emitFunc func(part any) error
+
 // consume reads every row and shapes it into the payload expected for the
 // given request type.
 //
@@ -40,18 +50,47 @@ func consume(rows driver.Rows, kind qbtypes.RequestType, queryWindow *qbtypes.Ti
 
 	switch kind {
 	case qbtypes.RequestTypeTimeSeries:
-		payload, err = readAsTimeSeries(rows, queryWindow, step, queryName)
+		payload, err = readAsTimeSeries(rows, queryWindow, step, queryName, nil)
 	case qbtypes.RequestTypeScalar:
-		payload, err = readAsScalar(rows, queryName)
+		payload, err = readAsScalar(rows, queryName, nil)
 	case qbtypes.RequestTypeRaw, qbtypes.RequestTypeTrace, qbtypes.RequestTypeRawStream:
 		payload, err = readAsRaw(rows, queryName)
+	case qbtypes.RequestTypeDistribution:
+		payload, err = readAsDistribution(rows, queryName)
 		// TODO: add support for other request types
 	}
 
 	return payload, err
 }
 
-func readAsTimeSeries(rows driver.Rows, queryWindow *qbtypes.TimeRange, step qbtypes.Step, queryName string) (*qbtypes.TimeSeriesData, error) {
+// consumeStream reads every row like consume but hands the payload to emit in
+// parts as the rows are read instead of returning it, only time series and
+// scalar payloads can be streamed.
+func consumeStream(rows driver.Rows, kind qbtypes.RequestType, queryWindow *qbtypes.TimeRange, step qbtypes.Step, queryName string, emit emitFunc) error {
+	var err error
+
+	switch kind {
+	case qbtypes.RequestTypeTimeSeries:
+		_, err = readAsTimeSeries(rows, queryWindow, step, queryName, emit)
+	case qbtypes.RequestTypeScalar:
+		_, err = readAsScalar(rows, queryName, emit)
+	default:
+		err = errors.NewInvalidInputf(errors.CodeInvalidInput, "%s results can't be streamed", kind.StringValue())
+	}
+
+	return err
+}
+
+// seriesKey identifies a series of an aggregation
+type seriesKey struct {
+	agg int
+	key string // deterministic join of label values
+}
+
+// readAsTimeSeries reads the rows into a series per aggregation and labels. With
+// emit set, the points are handed to emit every streamBatchSize points and the
+// returned payload has no aggregations.
+func readAsTimeSeries(rows driver.Rows, queryWindow *qbtypes.TimeRange, step qbtypes.Step, queryName string, emit emitFunc) (*qbtypes.TimeSeriesData, error) {
 	colTypes := rows.ColumnTypes()
 	colNames := rows.Columns()
 
@@ -64,11 +103,32 @@ func readAsTimeSeries(rows driver.Rows, queryWindow *qbtypes.TimeRange, step qbt
 		}
 	}
 
-	type sKey struct {
-		agg int
-		key string // deterministic join of label values
+	seriesMap := map[seriesKey]*qbtypes.TimeSeries{}
+
+	// points read since the last flush
+	pending := 0
+
+	// flush hands the points read since the last flush to emit, the series are
+	// kept without their points to keep their labels
+	flush := func() error {
+		part := map[seriesKey]*qbtypes.TimeSeries{}
+		for k, s := range seriesMap {
+			if len(s.Values) == 0 {
+				continue
+			}
+			part[k] = &qbtypes.TimeSeries{Labels: s.Labels, Values: s.Values}
+			s.Values = nil
+		}
+		pending = 0
+
+		if len(part) == 0 {
+			return nil
+		}
+		return emit(&qbtypes.TimeSeriesData{
+			QueryName:    queryName,
+			Aggregations: aggregationBuckets(part),
+		})
 	}
-	seriesMap := map[sKey]*qbtypes.TimeSeries{}
 
 	stepMs := uint64(step.Duration.Milliseconds())
 
@@ -213,7 +273,7 @@ func readAsTimeSeries(rows driver.Rows, queryWindow *qbtypes.TimeRange, step qbt
 				continue
 			}
 
-			key := sKey{agg: aggIdx, key: labelsKey}
+			key := seriesKey{agg: aggIdx, key: labelsKey}
 
 			series, ok := seriesMap[key]
 			if !ok {
@@ -225,12 +285,37 @@ func readAsTimeSeries(rows driver.Rows, queryWindow *qbtypes.TimeRange, step qbt
 				Value:     val,
 				Partial:   isPartialValue(ts),
 			})
+			pending++
+		}
+
+		if emit != nil && pending >= streamBatchSize {
+			if err := flush(); err != nil {
+				return nil, err
+			}
 		}
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 
+	if emit != nil {
+		if err := flush(); err != nil {
+			return nil, err
+		}
+		return &qbtypes.TimeSeriesData{
+			QueryName: queryName,
+		}, nil
+	}
+
+	return &qbtypes.TimeSeriesData{
+		QueryName:    queryName,
+		Aggregations: aggregationBuckets(seriesMap),
+	}, nil
+}
+
+// aggregationBuckets groups the series by their aggregation, the aggregations
+// without any series are dropped
+func aggregationBuckets(seriesMap map[seriesKey]*qbtypes.TimeSeries) []*qbtypes.AggregationBucket {
 	maxAgg := -1
 	for k := range seriesMap {
 		if k.agg > maxAgg {
@@ -238,9 +323,7 @@ func readAsTimeSeries(rows driver.Rows, queryWindow *qbtypes.TimeRange, step qbt
 		}
 	}
 	if maxAgg < 0 {
-		return &qbtypes.TimeSeriesData{
-			QueryName: queryName,
-		}, nil
+		return nil
 	}
 
 	buckets := make([]*qbtypes.AggregationBucket, maxAgg+1)
@@ -261,10 +344,7 @@ func readAsTimeSeries(rows driver.Rows, queryWindow *qbtypes.TimeRange, step qbt
 		}
 	}
 
-	return &qbtypes.TimeSeriesData{
-		QueryName:    queryName,
-		Aggregations: nonEmpty,
-	}, nil
+	return nonEmpty
 }
 
 func numericKind(k reflect.Kind) bool {
@@ -278,7 +358,10 @@ func numericKind(k reflect.Kind) bool {
 	}
 }
 
-func readAsScalar(rows driver.Rows, queryName string) (*qbtypes.ScalarData, error) {
+// readAsScalar reads the rows as they are. With emit set, the rows are handed to
+// emit every streamBatchSize rows with the columns in the first part, and the
+// returned payload has no rows.
+func readAsScalar(rows driver.Rows, queryName string, emit emitFunc) (*qbtypes.ScalarData, error) {
 	colNames := rows.Columns()
 	colTypes := rows.ColumnTypes()
 
@@ -309,6 +392,18 @@ func readAsScalar(rows driver.Rows, queryName string) (*qbtypes.ScalarData, erro
 
 	var data [][]any
 
+	// the columns are sent with the first part only
+	emitted := false
+	flush := func() error {
+		part := &qbtypes.ScalarData{QueryName: queryName, Data: data}
+		if !emitted {
+			part.Columns = cd
+		}
+		data = nil
+		emitted = true
+		return emit(part)
+	}
+
 	for rows.Next() {
 		if err := rows.Scan(scan...); err != nil {
 			return nil, err
@@ -320,11 +415,29 @@ func readAsScalar(rows driver.Rows, queryName string) (*qbtypes.ScalarData, erro
 			row[i] = derefValue(cell)
 		}
 		data = append(data, row)
+
+		if emit != nil && len(data) >= streamBatchSize {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 
+	if emit != nil {
+		if len(data) != 0 || !emitted {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+		return &qbtypes.ScalarData{
+			QueryName: queryName,
+			Columns:   cd,
+		}, nil
+	}
+
 	return &qbtypes.ScalarData{
 		QueryName: queryName,
 		Columns:   cd,
@@ -332,6 +445,74 @@ func readAsScalar(rows driver.Rows, queryName string) (*qbtypes.ScalarData, erro
 	}, nil
 }
 
+// readAsDistribution reads the rows of a distribution statement, one row per bucket ordered by the groups, into a
+// series per group.
+func readAsDistribution(rows driver.Rows, queryName string) (*qbtypes.DistributionData, error) {
+	colNames := rows.Columns()
+	colTypes := rows.ColumnTypes()
+
+	scan := make([]any, len(colTypes))
+	for i := range scan {
+		scan[i] = reflect.New(colTypes[i].ScanType()).Interface()
+	}
+
+	series := []*qbtypes.DistributionSeries{}
+	seriesMap := map[string]*qbtypes.DistributionSeries{}
+
+	for rows.Next() {
+		if err := rows.Scan(scan...); err != nil {
+			return nil, err
+		}
+
+		var (
+			lblVals = make([]string, 0, len(colNames))
+			lblObjs = make([]*qbtypes.Label, 0, len(colNames))
+			bucket  = &qbtypes.DistributionBucket{}
+		)
+
+		for i, cell := range scan {
+			val := derefValue(cell)
+
+			switch colNames[i] {
+			case querybuilder.DistributionBucketColumn:
+				continue
+			case querybuilder.DistributionLowerColumn:
+				bucket.Lower = numericAsFloat(val)
+			case querybuilder.DistributionUpperColumn:
+				bucket.Upper = numericAsFloat(val)
+			case "__result_0":
+				bucket.Count = uint64(numericAsFloat(val))
+			default:
+				if val == nil {
+					val = ""
+				}
+				lblVals = append(lblVals, fmt.Sprint(val))
+				lblObjs = append(lblObjs, &qbtypes.Label{
+					Key:   telemetrytypes.TelemetryFieldKey{Name: colNames[i]},
+					Value: val,
+				})
+			}
+		}
+
+		key := strings.Join(lblVals, ",")
+		s, ok := seriesMap[key]
+		if !ok {
+			s = &qbtypes.DistributionSeries{Labels: lblObjs}
+			seriesMap[key] = s
+			series = append(series, s)
+		}
+		s.Buckets = append(s.Buckets, bucket)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &qbtypes.DistributionData{
+		QueryName: queryName,
+		Series:    series,
+	}, nil
+}
+
 func derefValue(v any) any {
 	if v == nil {
 		return nil
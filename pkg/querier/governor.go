@@ -0,0 +1,342 @@
+package querier
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/dustin/go-humanize"
+)
+
+var (
+	CodeQueryBudgetExceeded = errors.MustNewCode("query_budget_exceeded")
+)
+
+const (
+	// budgetWindow is the window of the per minute limits of the budgets
+	budgetWindow = time.Minute
+	// rowSizesTTL is the time the average sizes of the rows of the tables are used before they are refreshed
+	rowSizesTTL = 15 * time.Minute
+	// rowSizesRetryTTL is the time the average sizes of the rows of the tables are used before they are refreshed
+	// again, after they failed to be refreshed
+	rowSizesRetryTTL = time.Minute
+	// defaultRowSize is the size of the rows of the tables without any part on the instance of ClickHouse
+	defaultRowSize = 512
+)
+
+// charge is the number of bytes a statement is estimated to read, charged at a time
+type charge struct {
+	at    time.Time
+	bytes uint64
+}
+
+// usage is the usage of the budget of an organization or of a user
+type usage struct {
+	running int
+	starts  []time.Time
+	charges []charge
+}
+
+// prune drops the starts and the charges out of the budget window ending at now
+func (u *usage) prune(now time.Time) {
+	cutoff := now.Add(-budgetWindow)
+
+	i := 0
+	for i < len(u.starts) && !u.starts[i].After(cutoff) {
+		i++
+	}
+	u.starts = u.starts[i:]
+
+	j := 0
+	for j < len(u.charges) && !u.charges[j].at.After(cutoff) {
+		j++
+	}
+	u.charges = u.charges[j:]
+}
+
+func (u *usage) bytes() uint64 {
+	total := uint64(0)
+	for _, c := range u.charges {
+		total += c.bytes
+	}
+	return total
+}
+
+func (u *usage) idle() bool {
+	return u.running == 0 && len(u.starts) == 0 && len(u.charges) == 0
+}
+
+// scope is an organization or a user, limited by its budget
+type scope struct {
+	key    string
+	name   string
+	budget Budget
+}
+
+// governor implements the Governor interface
+type governor struct {
+	telemetryStore telemetrystore.TelemetryStore
+	logger         *slog.Logger
+	config         BudgetConfig
+
+	mtx       sync.Mutex
+	usages    map[string]*usage
+	lastSweep time.Time
+
+	rowSizesMtx      sync.Mutex
+	rowSizes         map[string]float64
+	rowSizesExpireAt time.Time
+}
+
+var _ Governor = (*governor)(nil)
+
+// NewGovernor creates a new Governor implementation, the budgets are enforced for the queries of the users only
+func NewGovernor(settings factory.ProviderSettings, telemetryStore telemetrystore.TelemetryStore, config BudgetConfig) Governor {
+	governorSettings := factory.NewScopedProviderSettings(settings, "github.com/SigNoz/signoz/pkg/querier/governor")
+	return &governor{
+		telemetryStore: telemetryStore,
+		logger:         governorSettings.Logger(),
+		config:         config,
+		usages:         make(map[string]*usage),
+		rowSizes:       make(map[string]float64),
+	}
+}
+
+// Admit admits the query of the user if neither the user nor its organization is running or has started too many
+// queries.
+func (g *governor) Admit(ctx context.Context, orgID valuer.UUID) (func(), error) {
+	scopes := g.scopes(ctx, orgID)
+	if len(scopes) == 0 {
+		return func() {}, nil
+	}
+
+	now := time.Now()
+
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	g.sweep(now)
+
+	usages := make([]*usage, len(scopes))
+	for i, s := range scopes {
+		usages[i] = g.usage(s.key, now)
+
+		if s.budget.MaxConcurrentQueries > 0 && usages[i].running >= s.budget.MaxConcurrentQueries {
+			g.logger.InfoContext(ctx, "query rejected", "scope", s.name, "key", s.key, "running", usages[i].running)
+			return nil, newBudgetExceededError(s, []string{"Try again once the running queries are done, or refresh fewer panels at once"}, "%d queries are already running", usages[i].running)
+		}
+
+		if s.budget.MaxQueriesPerMinute > 0 && len(usages[i].starts) >= s.budget.MaxQueriesPerMinute {
+			g.logger.InfoContext(ctx, "query rejected", "scope", s.name, "key", s.key, "started", len(usages[i].starts))
+			return nil, newBudgetExceededError(s, []string{"Try again in a minute, or increase the refresh interval of the dashboards"}, "%d queries were started in the last minute", len(usages[i].starts))
+		}
+	}
+
+	for _, u := range usages {
+		u.running++
+		u.starts = append(u.starts, now)
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			g.mtx.Lock()
+			defer g.mtx.Unlock()
+			for _, u := range usages {
+				u.running--
+			}
+		})
+	}, nil
+}
+
+// Charge estimates the number of bytes the statements read and charges them to the user and its organization, the
+// statements are rejected if they don't fit the bytes left in the budget of either.
+func (g *governor) Charge(ctx context.Context, orgID valuer.UUID, stmts []*qbtypes.Statement) error {
+	if len(stmts) == 0 || !g.LimitsBytes(ctx, orgID) {
+		return nil
+	}
+
+	scopes := g.scopes(ctx, orgID)
+
+	bytes := g.estimate(ctx, stmts)
+	now := time.Now()
+
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	usages := make([]*usage, len(scopes))
+	for i, s := range scopes {
+		usages[i] = g.usage(s.key, now)
+
+		charged := usages[i].bytes()
+		if s.budget.MaxBytesPerMinute > 0 && charged+bytes > s.budget.MaxBytesPerMinute {
+			g.logger.InfoContext(ctx, "query rejected", "scope", s.name, "key", s.key, "estimated_bytes", bytes, "charged_bytes", charged)
+			return newBudgetExceededError(
+				s,
+				[]string{"Try narrowing the time range of the query", "Try narrowing the filter of the query, filters on resource attributes such as service.name are the cheapest"},
+				"the query is estimated to read %s while %s are left for the last minute",
+				humanize.IBytes(bytes),
+				humanize.IBytes(s.budget.MaxBytesPerMinute-min(charged, s.budget.MaxBytesPerMinute)),
+			)
+		}
+	}
+
+	for _, u := range usages {
+		u.charges = append(u.charges, charge{at: now, bytes: bytes})
+	}
+
+	return nil
+}
+
+// LimitsBytes returns whether the user or its organization has a limit on the bytes, without a limit there is nothing
+// to estimate.
+func (g *governor) LimitsBytes(ctx context.Context, orgID valuer.UUID) bool {
+	for _, s := range g.scopes(ctx, orgID) {
+		if s.budget.MaxBytesPerMinute > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// scopes returns the organization and the user of ctx, the queries without a user, such as the queries of the rules,
+// are not limited.
+func (g *governor) scopes(ctx context.Context, orgID valuer.UUID) []scope {
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		return nil
+	}
+
+	budget := g.config.Org
+	if override, ok := g.config.Orgs[orgID.StringValue()]; ok {
+		budget = override
+	}
+
+	scopes := []scope{{key: orgID.StringValue(), name: "organization", budget: budget}}
+	if claims.UserID != "" {
+		scopes = append(scopes, scope{key: orgID.StringValue() + "/" + claims.UserID, name: "user", budget: g.config.User})
+	}
+
+	return scopes
+}
+
+// usage returns the usage of the key within the budget window ending at now, it must be called with the lock held
+func (g *governor) usage(key string, now time.Time) *usage {
+	u, ok := g.usages[key]
+	if !ok {
+		u = &usage{}
+		g.usages[key] = u
+	}
+
+	u.prune(now)
+	return u
+}
+
+// sweep drops the idle usages once per budget window, it must be called with the lock held
+func (g *governor) sweep(now time.Time) {
+	if now.Sub(g.lastSweep) < budgetWindow {
+		return
+	}
+
+	for key, u := range g.usages {
+		u.prune(now)
+		if u.idle() {
+			delete(g.usages, key)
+		}
+	}
+	g.lastSweep = now
+}
+
+// estimate returns the number of bytes the statements are estimated to read, from the number of rows EXPLAIN ESTIMATE
+// selects in every table and the average size of the rows of the table. The statements which can't be estimated are
+// not charged.
+func (g *governor) estimate(ctx context.Context, stmts []*qbtypes.Statement) uint64 {
+	rowSizes := g.getRowSizes(ctx)
+
+	total := uint64(0)
+	for _, stmt := range stmts {
+		rows, err := g.telemetryStore.ClickhouseDB().Query(ctx, "EXPLAIN ESTIMATE "+stmt.Query, stmt.Args...)
+		if err != nil {
+			g.logger.WarnContext(ctx, "failed to estimate the statement", "error", err)
+			continue
+		}
+
+		for rows.Next() {
+			var (
+				database, table     string
+				parts, count, marks uint64
+			)
+			if err := rows.Scan(&database, &table, &parts, &count, &marks); err != nil {
+				g.logger.WarnContext(ctx, "failed to scan the estimate of the statement", "error", err)
+				break
+			}
+
+			rowSize, ok := rowSizes[database+"."+table]
+			if !ok {
+				rowSize = defaultRowSize
+			}
+			total += uint64(float64(count) * rowSize)
+		}
+		rows.Close()
+	}
+
+	return total
+}
+
+// getRowSizes returns the average uncompressed size of the rows of the tables by their qualified name, the sizes which
+// failed to be refreshed are kept until the next retry so that the charges don't query system.parts every time.
+func (g *governor) getRowSizes(ctx context.Context) map[string]float64 {
+	g.rowSizesMtx.Lock()
+	defer g.rowSizesMtx.Unlock()
+
+	now := time.Now()
+	if now.Before(g.rowSizesExpireAt) {
+		return g.rowSizes
+	}
+	g.rowSizesExpireAt = now.Add(rowSizesRetryTTL)
+
+	rows, err := g.telemetryStore.ClickhouseDB().Query(ctx, "SELECT database, table, sum(data_uncompressed_bytes) / sum(rows) FROM system.parts WHERE active AND rows > 0 GROUP BY database, table")
+	if err != nil {
+		g.logger.WarnContext(ctx, "failed to get the size of the rows of the tables", "error", err)
+		return g.rowSizes
+	}
+	defer rows.Close()
+
+	rowSizes := make(map[string]float64)
+	for rows.Next() {
+		var (
+			database, table string
+			size            float64
+		)
+		if err := rows.Scan(&database, &table, &size); err != nil {
+			g.logger.WarnContext(ctx, "failed to scan the size of the rows of the tables", "error", err)
+			return g.rowSizes
+		}
+		rowSizes[database+"."+table] = size
+	}
+
+	if err := rows.Err(); err != nil {
+		g.logger.WarnContext(ctx, "failed to get the size of the rows of the tables", "error", err)
+		return g.rowSizes
+	}
+
+	g.rowSizes = rowSizes
+	g.rowSizesExpireAt = now.Add(rowSizesTTL)
+	return g.rowSizes
+}
+
+// newBudgetExceededError returns the error of a query rejected by the budget of the scope, with the additional
+// messages suggesting how to fit the budget
+func newBudgetExceededError(s scope, additional []string, format string, args ...any) error {
+	return errors.
+		Newf(errors.TypeTooManyRequests, CodeQueryBudgetExceeded, "query exceeds the budget of the %s, %s", s.name, fmt.Sprintf(format, args...)).
+		WithAdditional(additional...)
+}
@@ -0,0 +1,150 @@
+package querier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/telemetrystore/telemetrystoretest"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	cmock "github.com/srikanthccv/ClickHouse-go-mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newGovernorContext(orgID valuer.UUID, userID string) context.Context {
+	return authtypes.NewContextWithClaims(context.Background(), authtypes.Claims{OrgID: orgID.StringValue(), UserID: userID})
+}
+
+func TestGovernorAdmit(t *testing.T) {
+	orgID := valuer.GenerateUUID()
+	telemetryStore := telemetrystoretest.New(telemetrystore.Config{Provider: "clickhouse"}, sqlmock.QueryMatcherRegexp)
+	governor := NewGovernor(instrumentationtest.New().ToProviderSettings(), telemetryStore, BudgetConfig{
+		Enabled: true,
+		Org:     Budget{MaxQueriesPerMinute: 3},
+		User:    Budget{MaxConcurrentQueries: 1},
+	})
+
+	alice := newGovernorContext(orgID, "alice")
+	bob := newGovernorContext(orgID, "bob")
+
+	release, err := governor.Admit(alice, orgID)
+	require.NoError(t, err)
+
+	// alice is already running a query
+	_, err = governor.Admit(alice, orgID)
+	require.Error(t, err)
+	assert.True(t, errors.Ast(err, errors.TypeTooManyRequests))
+	assert.True(t, errors.Asc(err, CodeQueryBudgetExceeded))
+
+	release()
+	release()
+
+	releaseAlice, err := governor.Admit(alice, orgID)
+	require.NoError(t, err)
+	defer releaseAlice()
+
+	releaseBob, err := governor.Admit(bob, orgID)
+	require.NoError(t, err)
+	defer releaseBob()
+
+	// the organization has started three queries in the last minute
+	_, err = governor.Admit(newGovernorContext(orgID, "carol"), orgID)
+	require.Error(t, err)
+	assert.True(t, errors.Asc(err, CodeQueryBudgetExceeded))
+
+	// the queries without a user are not limited
+	for i := 0; i < 5; i++ {
+		release, err := governor.Admit(context.Background(), orgID)
+		require.NoError(t, err)
+		release()
+	}
+}
+
+func TestGovernorCharge(t *testing.T) {
+	orgID := valuer.GenerateUUID()
+	telemetryStore := telemetrystoretest.New(telemetrystore.Config{Provider: "clickhouse"}, sqlmock.QueryMatcherRegexp)
+	governor := NewGovernor(instrumentationtest.New().ToProviderSettings(), telemetryStore, BudgetConfig{
+		Enabled: true,
+		User:    Budget{MaxBytesPerMinute: 150000},
+	})
+
+	telemetryStore.Mock().
+		ExpectQuery("FROM system.parts").
+		WillReturnRows(cmock.NewRows(
+			[]cmock.ColumnType{{Name: "database", Type: "String"}, {Name: "table", Type: "String"}, {Name: "size", Type: "Float64"}},
+			[][]any{{"signoz_logs", "logs_v2", float64(100)}},
+		))
+
+	estimate := []cmock.ColumnType{
+		{Name: "database", Type: "String"},
+		{Name: "table", Type: "String"},
+		{Name: "parts", Type: "UInt64"},
+		{Name: "rows", Type: "UInt64"},
+		{Name: "marks", Type: "UInt64"},
+	}
+	for i := 0; i < 2; i++ {
+		telemetryStore.Mock().
+			ExpectQuery("EXPLAIN ESTIMATE SELECT body FROM signoz_logs.distributed_logs_v2").
+			WithArgs("%error%").
+			WillReturnRows(cmock.NewRows(estimate, [][]any{{"signoz_logs", "logs_v2", uint64(4), uint64(1000), uint64(1)}}))
+	}
+
+	stmts := []*qbtypes.Statement{{Query: "SELECT body FROM signoz_logs.distributed_logs_v2 WHERE body LIKE ?", Args: []any{"%error%"}}}
+	ctx := newGovernorContext(orgID, "alice")
+
+	// the statement reads 1000 rows of 100 bytes
+	require.NoError(t, governor.Charge(ctx, orgID, stmts))
+
+	err := governor.Charge(ctx, orgID, stmts)
+	require.Error(t, err)
+	assert.True(t, errors.Ast(err, errors.TypeTooManyRequests))
+	_, _, _, _, _, additional := errors.Unwrapb(err)
+	assert.NotEmpty(t, additional)
+
+	require.NoError(t, telemetryStore.Mock().ExpectationsWereMet())
+}
+
+func TestGovernorChargeRetriesRowSizes(t *testing.T) {
+	orgID := valuer.GenerateUUID()
+	telemetryStore := telemetrystoretest.New(telemetrystore.Config{Provider: "clickhouse"}, sqlmock.QueryMatcherRegexp)
+	g := NewGovernor(instrumentationtest.New().ToProviderSettings(), telemetryStore, BudgetConfig{
+		Enabled: true,
+		User:    Budget{MaxBytesPerMinute: 1 << 30},
+	}).(*governor)
+
+	// the organization has no limit on the bytes, only its users do
+	assert.False(t, g.LimitsBytes(context.Background(), orgID))
+	assert.True(t, g.LimitsBytes(newGovernorContext(orgID, "alice"), orgID))
+
+	telemetryStore.Mock().
+		ExpectQuery("FROM system.parts").
+		WillReturnError(errors.Newf(errors.TypeInternal, errors.CodeInternal, "system.parts is not available"))
+
+	estimate := []cmock.ColumnType{
+		{Name: "database", Type: "String"},
+		{Name: "table", Type: "String"},
+		{Name: "parts", Type: "UInt64"},
+		{Name: "rows", Type: "UInt64"},
+		{Name: "marks", Type: "UInt64"},
+	}
+	telemetryStore.Mock().
+		ExpectQuery("EXPLAIN ESTIMATE SELECT body FROM signoz_logs.distributed_logs_v2").
+		WillReturnRows(cmock.NewRows(estimate, [][]any{{"signoz_logs", "logs_v2", uint64(4), uint64(1000), uint64(1)}}))
+
+	stmts := []*qbtypes.Statement{{Query: "SELECT body FROM signoz_logs.distributed_logs_v2"}}
+	require.NoError(t, g.Charge(newGovernorContext(orgID, "alice"), orgID, stmts))
+
+	// the sizes of the rows which failed to be refreshed are refreshed again after the retry ttl only
+	expireAt := g.rowSizesExpireAt
+	assert.True(t, expireAt.After(time.Now()))
+	assert.False(t, expireAt.After(time.Now().Add(rowSizesRetryTTL)))
+
+	require.NoError(t, telemetryStore.Mock().ExpectationsWereMet())
+}
@@ -2,8 +2,10 @@ package querier
 
 import (
 	"context"
+	"time"
 
 	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
 )
 
@@ -11,6 +13,28 @@ import (
 type Querier interface {
 	QueryRange(ctx context.Context, orgID valuer.UUID, req *qbtypes.QueryRangeRequest) (*qbtypes.QueryRangeResponse, error)
 	QueryRawStream(ctx context.Context, orgID valuer.UUID, req *qbtypes.QueryRangeRequest, client *qbtypes.RawStream)
+	QueryRangeStream(ctx context.Context, orgID valuer.UUID, req *qbtypes.QueryRangeRequest, w qbtypes.StreamWriter) error
+	SetWatermark(ctx context.Context, orgID valuer.UUID, postable *qbtypes.PostableWatermark) error
+	ListWatermarks(ctx context.Context, orgID valuer.UUID) (qbtypes.GettableWatermarks, error)
+	PurgeCache(ctx context.Context, orgID valuer.UUID, createdBy string, postable *qbtypes.PostableCachePurge) error
+}
+
+// statementQuery is implemented by the queries which execute a single ClickHouse statement
+type statementQuery interface {
+	// the statement of the query over its window
+	statement(ctx context.Context) (*qbtypes.Statement, error)
+}
+
+// streamingQuery is implemented by the queries which can hand their result in parts as their rows are read
+type streamingQuery interface {
+	// stream the result of the query to emit when it is executed, instead of returning it
+	streamTo(emit emitFunc)
+}
+
+// signalQuery is implemented by the queries whose results are of a single signal
+type signalQuery interface {
+	// the signal of the data the query reads
+	signal() telemetrytypes.Signal
 }
 
 // BucketCache is the interface for bucket-based caching
@@ -20,3 +44,27 @@ type BucketCache interface {
 	// store fresh buckets for future hits
 	Put(ctx context.Context, orgID valuer.UUID, q qbtypes.Query, step qbtypes.Step, fresh *qbtypes.Result)
 }
+
+// Invalidator is the interface for the invalidation of the cached results
+type Invalidator interface {
+	// the lag of the data of a signal, learned from the watermarks of its sources
+	Lag(ctx context.Context, orgID valuer.UUID, signal telemetrytypes.Signal) time.Duration
+	// the generation of the cached results of a signal, which changes whenever they are purged
+	Generation(ctx context.Context, orgID valuer.UUID, signal telemetrytypes.Signal) int64
+	// record the watermark reported by a source
+	SetWatermark(ctx context.Context, orgID valuer.UUID, postable *qbtypes.PostableWatermark) error
+	// list the watermarks of the sources of an organization
+	ListWatermarks(ctx context.Context, orgID valuer.UUID) (qbtypes.GettableWatermarks, error)
+	// purge the cached results of a signal
+	Purge(ctx context.Context, orgID valuer.UUID, createdBy string, postable *qbtypes.PostableCachePurge) error
+}
+
+// Governor is the interface for the budgets of the queries
+type Governor interface {
+	// admit a query range request, release must be called once the request is done
+	Admit(ctx context.Context, orgID valuer.UUID) (release func(), err error)
+	// whether the cost of the statements is limited, the statements need not be built for Charge otherwise
+	LimitsBytes(ctx context.Context, orgID valuer.UUID) bool
+	// estimate the cost of the statements before they are executed and charge it
+	Charge(ctx context.Context, orgID valuer.UUID, stmts []*qbtypes.Statement) error
+}
@@ -0,0 +1,216 @@
+package querier
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// invalidationKey is a signal of an organization
+type invalidationKey struct {
+	orgID  string
+	signal telemetrytypes.Signal
+}
+
+// invalidator implements the Invalidator interface, the watermarks and the latest purges of all the organizations
+// are read from the store in the background every refresh interval so that the queries only read them from memory
+type invalidator struct {
+	store  qbtypes.InvalidationStore
+	logger *slog.Logger
+	config InvalidationConfig
+
+	mtx         sync.RWMutex
+	watermarks  map[invalidationKey]map[string]*qbtypes.StorableWatermark
+	generations map[invalidationKey]int64
+
+	// loadOnce makes the first queries wait on the first read of the store rather than ignore the purges
+	loadOnce sync.Once
+}
+
+var _ Invalidator = (*invalidator)(nil)
+
+// NewInvalidator creates a new Invalidator implementation
+func NewInvalidator(settings factory.ProviderSettings, store qbtypes.InvalidationStore, config InvalidationConfig) Invalidator {
+	invalidatorSettings := factory.NewScopedProviderSettings(settings, "github.com/SigNoz/signoz/pkg/querier/invalidator")
+	return &invalidator{
+		store:       store,
+		logger:      invalidatorSettings.Logger(),
+		config:      config,
+		watermarks:  make(map[invalidationKey]map[string]*qbtypes.StorableWatermark),
+		generations: make(map[invalidationKey]int64),
+	}
+}
+
+// Lag returns the largest lag of the sources of the signal which reported a watermark within the watermark ttl,
+// capped by the max lag.
+func (inv *invalidator) Lag(ctx context.Context, orgID valuer.UUID, signal telemetrytypes.Signal) time.Duration {
+	inv.load(ctx)
+
+	now := time.Now()
+	cutoff := now.Add(-inv.config.WatermarkTTL)
+
+	inv.mtx.RLock()
+	defer inv.mtx.RUnlock()
+
+	lag := time.Duration(0)
+	for _, watermark := range inv.watermarks[invalidationKey{orgID: orgID.StringValue(), signal: signal}] {
+		if watermark.UpdatedAt.After(cutoff) && watermark.Lag(now) > lag {
+			lag = watermark.Lag(now)
+		}
+	}
+
+	if lag > inv.config.MaxLag {
+		return inv.config.MaxLag
+	}
+
+	return lag
+}
+
+// Generation returns the time of the latest purge of the signal in epoch milliseconds, zero if it was never purged.
+func (inv *invalidator) Generation(ctx context.Context, orgID valuer.UUID, signal telemetrytypes.Signal) int64 {
+	inv.load(ctx)
+
+	inv.mtx.RLock()
+	defer inv.mtx.RUnlock()
+
+	return inv.generations[invalidationKey{orgID: orgID.StringValue(), signal: signal}]
+}
+
+func (inv *invalidator) SetWatermark(ctx context.Context, orgID valuer.UUID, postable *qbtypes.PostableWatermark) error {
+	watermark, err := qbtypes.NewStorableWatermark(orgID.StringValue(), postable, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if err := inv.store.SetWatermark(ctx, watermark); err != nil {
+		return err
+	}
+
+	// the watermark applies to this instance right away and to the others on their next refresh
+	inv.mtx.Lock()
+	defer inv.mtx.Unlock()
+	inv.setWatermark(watermark)
+
+	return nil
+}
+
+func (inv *invalidator) ListWatermarks(ctx context.Context, orgID valuer.UUID) (qbtypes.GettableWatermarks, error) {
+	watermarks, err := inv.store.ListOrgWatermarks(ctx, orgID.StringValue())
+	if err != nil {
+		return nil, err
+	}
+
+	return qbtypes.NewGettableWatermarks(watermarks, time.Now()), nil
+}
+
+func (inv *invalidator) Purge(ctx context.Context, orgID valuer.UUID, createdBy string, postable *qbtypes.PostableCachePurge) error {
+	purge, err := qbtypes.NewStorableCachePurge(orgID.StringValue(), createdBy, postable, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if err := inv.store.CreatePurge(ctx, purge); err != nil {
+		return err
+	}
+
+	inv.logger.InfoContext(ctx, "cached results purged", "org_id", orgID.StringValue(), "signal", postable.Signal.StringValue(), "reason", postable.Reason.StringValue())
+
+	inv.mtx.Lock()
+	defer inv.mtx.Unlock()
+	inv.setGeneration(purge)
+
+	return nil
+}
+
+// load reads the watermarks and the latest purges on the first read of the invalidator, and then refreshes them in
+// the background every refresh interval for the lifetime of the process
+func (inv *invalidator) load(ctx context.Context) {
+	inv.loadOnce.Do(func() {
+		ctx := context.WithoutCancel(ctx)
+		inv.refresh(ctx)
+
+		go func() {
+			ticker := time.NewTicker(inv.config.RefreshInterval)
+			defer ticker.Stop()
+
+			for range ticker.C {
+				inv.refresh(ctx)
+			}
+		}()
+	})
+}
+
+// refresh reads the watermarks and the latest purges again, the previous ones are kept if the store can't be read
+func (inv *invalidator) refresh(ctx context.Context) {
+	now := time.Now()
+	watermarks, err := inv.store.ListWatermarks(ctx, now.Add(-inv.config.WatermarkTTL))
+	if err != nil {
+		inv.logger.ErrorContext(ctx, "error listing the watermarks", "error", err)
+		return
+	}
+
+	purges, err := inv.store.ListLatestPurges(ctx)
+	if err != nil {
+		inv.logger.ErrorContext(ctx, "error listing the purges of the cached results", "error", err)
+		return
+	}
+
+	inv.mtx.Lock()
+	defer inv.mtx.Unlock()
+
+	inv.watermarks = make(map[invalidationKey]map[string]*qbtypes.StorableWatermark)
+	for _, watermark := range watermarks {
+		inv.setWatermark(watermark)
+	}
+
+	// the generations never go back, a purge of this instance may not be visible in the store yet
+	for _, purge := range purges {
+		inv.setGeneration(purge)
+	}
+}
+
+func (inv *invalidator) setWatermark(watermark *qbtypes.StorableWatermark) {
+	key := invalidationKey{orgID: watermark.OrgID, signal: watermark.Signal}
+	if _, ok := inv.watermarks[key]; !ok {
+		inv.watermarks[key] = make(map[string]*qbtypes.StorableWatermark)
+	}
+	inv.watermarks[key][watermark.Source] = watermark
+}
+
+func (inv *invalidator) setGeneration(purge *qbtypes.StorableCachePurge) {
+	key := invalidationKey{orgID: purge.OrgID, signal: purge.Signal}
+	if generation := purge.CreatedAt.UnixMilli(); generation > inv.generations[key] {
+		inv.generations[key] = generation
+	}
+}
+
+func (q *querier) SetWatermark(ctx context.Context, orgID valuer.UUID, postable *qbtypes.PostableWatermark) error {
+	if q.invalidator == nil {
+		return errors.Newf(errors.TypeUnsupported, errors.CodeUnsupported, "the invalidation of the cached results is not enabled")
+	}
+
+	return q.invalidator.SetWatermark(ctx, orgID, postable)
+}
+
+func (q *querier) ListWatermarks(ctx context.Context, orgID valuer.UUID) (qbtypes.GettableWatermarks, error) {
+	if q.invalidator == nil {
+		return qbtypes.GettableWatermarks{}, nil
+	}
+
+	return q.invalidator.ListWatermarks(ctx, orgID)
+}
+
+func (q *querier) PurgeCache(ctx context.Context, orgID valuer.UUID, createdBy string, postable *qbtypes.PostableCachePurge) error {
+	if q.invalidator == nil {
+		return errors.Newf(errors.TypeUnsupported, errors.CodeUnsupported, "the invalidation of the cached results is not enabled")
+	}
+
+	return q.invalidator.Purge(ctx, orgID, createdBy, postable)
+}
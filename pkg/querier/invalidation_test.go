@@ -0,0 +1,373 @@
+package querier
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeInvalidationStore keeps the watermarks and the purges in memory
+type fakeInvalidationStore struct {
+	mtx        sync.Mutex
+	watermarks []*qbtypes.StorableWatermark
+	purges     []*qbtypes.StorableCachePurge
+	err        error
+}
+
+func (store *fakeInvalidationStore) SetWatermark(_ context.Context, watermark *qbtypes.StorableWatermark) error {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+
+	for i, existing := range store.watermarks {
+		if existing.OrgID == watermark.OrgID && existing.Signal == watermark.Signal && existing.Source == watermark.Source {
+			store.watermarks[i] = watermark
+			return nil
+		}
+	}
+	store.watermarks = append(store.watermarks, watermark)
+	return nil
+}
+
+func (store *fakeInvalidationStore) ListWatermarks(_ context.Context, since time.Time) ([]*qbtypes.StorableWatermark, error) {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+
+	if store.err != nil {
+		return nil, store.err
+	}
+
+	watermarks := []*qbtypes.StorableWatermark{}
+	for _, watermark := range store.watermarks {
+		if watermark.UpdatedAt.After(since) {
+			watermarks = append(watermarks, watermark)
+		}
+	}
+	return watermarks, nil
+}
+
+func (store *fakeInvalidationStore) ListOrgWatermarks(_ context.Context, orgID string) ([]*qbtypes.StorableWatermark, error) {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+
+	watermarks := []*qbtypes.StorableWatermark{}
+	for _, watermark := range store.watermarks {
+		if watermark.OrgID == orgID {
+			watermarks = append(watermarks, watermark)
+		}
+	}
+	return watermarks, nil
+}
+
+func (store *fakeInvalidationStore) CreatePurge(_ context.Context, purge *qbtypes.StorableCachePurge) error {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+
+	store.purges = append(store.purges, purge)
+	return nil
+}
+
+func (store *fakeInvalidationStore) ListLatestPurges(_ context.Context) ([]*qbtypes.StorableCachePurge, error) {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+
+	if store.err != nil {
+		return nil, store.err
+	}
+	return store.purges, nil
+}
+
+func newTestWatermark(t *testing.T, orgID valuer.UUID, signal telemetrytypes.Signal, source string, lag time.Duration, updatedAt time.Time) *qbtypes.StorableWatermark {
+	watermark, err := qbtypes.NewStorableWatermark(orgID.StringValue(), &qbtypes.PostableWatermark{
+		Signal:    signal,
+		Source:    source,
+		Watermark: time.Now().Add(-lag).UnixMilli(),
+	}, updatedAt)
+	require.NoError(t, err)
+	return watermark
+}
+
+func TestInvalidatorLag(t *testing.T) {
+	orgID := valuer.GenerateUUID()
+	now := time.Now()
+
+	store := &fakeInvalidationStore{
+		watermarks: []*qbtypes.StorableWatermark{
+			newTestWatermark(t, orgID, telemetrytypes.SignalLogs, "shipper-a", 10*time.Minute, now),
+			newTestWatermark(t, orgID, telemetrytypes.SignalLogs, "shipper-b", 30*time.Minute, now),
+			// stale, the source stopped reporting
+			newTestWatermark(t, orgID, telemetrytypes.SignalLogs, "shipper-c", 5*time.Hour, now.Add(-2*time.Hour)),
+			newTestWatermark(t, orgID, telemetrytypes.SignalTraces, "collector", 48*time.Hour, now),
+		},
+	}
+
+	inv := NewInvalidator(instrumentationtest.New().ToProviderSettings(), store, InvalidationConfig{
+		RefreshInterval: time.Minute,
+		WatermarkTTL:    time.Hour,
+		MaxLag:          24 * time.Hour,
+	})
+
+	ctx := context.Background()
+
+	lag := inv.Lag(ctx, orgID, telemetrytypes.SignalLogs)
+	assert.InDelta(t, (30 * time.Minute).Seconds(), lag.Seconds(), 1)
+
+	// capped by the max lag
+	assert.Equal(t, 24*time.Hour, inv.Lag(ctx, orgID, telemetrytypes.SignalTraces))
+
+	// no watermarks
+	assert.Equal(t, time.Duration(0), inv.Lag(ctx, orgID, telemetrytypes.SignalMetrics))
+	assert.Equal(t, time.Duration(0), inv.Lag(ctx, valuer.GenerateUUID(), telemetrytypes.SignalLogs))
+}
+
+func TestInvalidatorSetWatermark(t *testing.T) {
+	orgID := valuer.GenerateUUID()
+	store := &fakeInvalidationStore{}
+	inv := NewInvalidator(instrumentationtest.New().ToProviderSettings(), store, InvalidationConfig{
+		RefreshInterval: time.Hour,
+		WatermarkTTL:    time.Hour,
+		MaxLag:          24 * time.Hour,
+	})
+
+	ctx := context.Background()
+	assert.Equal(t, time.Duration(0), inv.Lag(ctx, orgID, telemetrytypes.SignalLogs))
+
+	// applies right away without waiting for the refresh
+	err := inv.SetWatermark(ctx, orgID, &qbtypes.PostableWatermark{
+		Signal:    telemetrytypes.SignalLogs,
+		Source:    "shipper",
+		Watermark: time.Now().Add(-15 * time.Minute).UnixMilli(),
+	})
+	require.NoError(t, err)
+	assert.InDelta(t, (15 * time.Minute).Seconds(), inv.Lag(ctx, orgID, telemetrytypes.SignalLogs).Seconds(), 1)
+
+	watermarks, err := inv.ListWatermarks(ctx, orgID)
+	require.NoError(t, err)
+	require.Len(t, watermarks, 1)
+	assert.Equal(t, "shipper", watermarks[0].Source)
+
+	err = inv.SetWatermark(ctx, orgID, &qbtypes.PostableWatermark{Signal: telemetrytypes.SignalLogs, Watermark: time.Now().UnixMilli()})
+	assert.True(t, errors.Ast(err, errors.TypeInvalidInput))
+}
+
+func TestInvalidatorGeneration(t *testing.T) {
+	orgID := valuer.GenerateUUID()
+	store := &fakeInvalidationStore{}
+	inv := NewInvalidator(instrumentationtest.New().ToProviderSettings(), store, InvalidationConfig{
+		RefreshInterval: time.Hour,
+		WatermarkTTL:    time.Hour,
+		MaxLag:          24 * time.Hour,
+	})
+
+	ctx := context.Background()
+	assert.Equal(t, int64(0), inv.Generation(ctx, orgID, telemetrytypes.SignalLogs))
+
+	err := inv.Purge(ctx, orgID, "admin@signoz.io", &qbtypes.PostableCachePurge{Signal: telemetrytypes.SignalLogs, Reason: qbtypes.CachePurgeReasonPipelines})
+	require.NoError(t, err)
+	require.Len(t, store.purges, 1)
+
+	generation := inv.Generation(ctx, orgID, telemetrytypes.SignalLogs)
+	assert.Equal(t, store.purges[0].CreatedAt.UnixMilli(), generation)
+	assert.Equal(t, int64(0), inv.Generation(ctx, orgID, telemetrytypes.SignalTraces))
+
+	err = inv.Purge(ctx, orgID, "admin@signoz.io", &qbtypes.PostableCachePurge{Signal: telemetrytypes.SignalLogs, Reason: qbtypes.CachePurgeReason{}})
+	assert.True(t, errors.Ast(err, errors.TypeInvalidInput))
+}
+
+func TestInvalidatorRefresh(t *testing.T) {
+	orgID := valuer.GenerateUUID()
+	store := &fakeInvalidationStore{}
+	inv := NewInvalidator(instrumentationtest.New().ToProviderSettings(), store, InvalidationConfig{
+		RefreshInterval: time.Millisecond,
+		WatermarkTTL:    time.Hour,
+		MaxLag:          24 * time.Hour,
+	})
+
+	ctx := context.Background()
+
+	// a purge by another instance
+	purge, err := qbtypes.NewStorableCachePurge(orgID.StringValue(), "", &qbtypes.PostableCachePurge{Signal: telemetrytypes.SignalMetrics, Reason: qbtypes.CachePurgeReasonRetention}, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, store.CreatePurge(ctx, purge))
+	require.NoError(t, store.SetWatermark(ctx, newTestWatermark(t, orgID, telemetrytypes.SignalMetrics, "collector", 20*time.Minute, time.Now())))
+
+	assert.Equal(t, purge.CreatedAt.UnixMilli(), inv.Generation(ctx, orgID, telemetrytypes.SignalMetrics))
+	assert.InDelta(t, (20 * time.Minute).Seconds(), inv.Lag(ctx, orgID, telemetrytypes.SignalMetrics).Seconds(), 1)
+
+	// the previous snapshot is kept while the store can't be read
+	store.mtx.Lock()
+	store.err = errors.Newf(errors.TypeInternal, errors.CodeInternal, "store is down")
+	store.mtx.Unlock()
+	time.Sleep(2 * time.Millisecond)
+
+	assert.Equal(t, purge.CreatedAt.UnixMilli(), inv.Generation(ctx, orgID, telemetrytypes.SignalMetrics))
+	assert.InDelta(t, (20 * time.Minute).Seconds(), inv.Lag(ctx, orgID, telemetrytypes.SignalMetrics).Seconds(), 1)
+
+	// the purges of the other instances are read in the background once the store is back
+	next, err := qbtypes.NewStorableCachePurge(orgID.StringValue(), "", &qbtypes.PostableCachePurge{Signal: telemetrytypes.SignalMetrics, Reason: qbtypes.CachePurgeReasonRetention}, purge.CreatedAt.Add(time.Second))
+	require.NoError(t, err)
+	store.mtx.Lock()
+	store.err = nil
+	store.purges = append(store.purges, next)
+	store.mtx.Unlock()
+
+	assert.Eventually(t, func() bool {
+		return inv.Generation(ctx, orgID, telemetrytypes.SignalMetrics) == next.CreatedAt.UnixMilli()
+	}, time.Second, time.Millisecond)
+}
+
+// fakeInvalidator returns fixed lags and generations
+type fakeInvalidator struct {
+	lags        map[telemetrytypes.Signal]time.Duration
+	generations map[telemetrytypes.Signal]int64
+}
+
+func (inv *fakeInvalidator) Lag(_ context.Context, _ valuer.UUID, signal telemetrytypes.Signal) time.Duration {
+	return inv.lags[signal]
+}
+
+func (inv *fakeInvalidator) Generation(_ context.Context, _ valuer.UUID, signal telemetrytypes.Signal) int64 {
+	return inv.generations[signal]
+}
+
+func (inv *fakeInvalidator) SetWatermark(context.Context, valuer.UUID, *qbtypes.PostableWatermark) error {
+	return nil
+}
+
+func (inv *fakeInvalidator) ListWatermarks(context.Context, valuer.UUID) (qbtypes.GettableWatermarks, error) {
+	return qbtypes.GettableWatermarks{}, nil
+}
+
+func (inv *fakeInvalidator) Purge(context.Context, valuer.UUID, string, *qbtypes.PostableCachePurge) error {
+	return nil
+}
+
+// signalMockQuery is a mockQuery of a signal
+type signalMockQuery struct {
+	mockQuery
+	sig telemetrytypes.Signal
+}
+
+func (m *signalMockQuery) signal() telemetrytypes.Signal {
+	return m.sig
+}
+
+func newSignalMockQuery(signal telemetrytypes.Signal, startMs, endMs uint64) *signalMockQuery {
+	return &signalMockQuery{
+		mockQuery: mockQuery{fingerprint: "test-signal-query", startMs: startMs, endMs: endMs},
+		sig:       signal,
+	}
+}
+
+func TestBucketCache_PurgeGeneration(t *testing.T) {
+	inv := &fakeInvalidator{generations: map[telemetrytypes.Signal]int64{}}
+	c := createTestCache(t)
+	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), c, cacheTTL, defaultFluxInterval, nil, inv)
+
+	ctx := context.Background()
+	orgID := valuer.GenerateUUID()
+	step := qbtypes.Step{Duration: time.Second}
+
+	endMs := uint64(time.Now().Add(-time.Hour).Truncate(time.Minute).UnixMilli())
+	startMs := endMs - uint64(time.Hour.Milliseconds())
+	query := newSignalMockQuery(telemetrytypes.SignalLogs, startMs, endMs)
+
+	bc.Put(ctx, orgID, query, step, &qbtypes.Result{
+		Type:  qbtypes.RequestTypeTimeSeries,
+		Value: createTestTimeSeries("A", startMs, endMs, 1000),
+	})
+
+	cached, missing := bc.GetMissRanges(ctx, orgID, query, step)
+	require.NotNil(t, cached)
+	assert.Empty(t, missing)
+
+	// a purge of another signal leaves the results cached
+	inv.generations[telemetrytypes.SignalTraces] = time.Now().UnixMilli()
+	cached, missing = bc.GetMissRanges(ctx, orgID, query, step)
+	require.NotNil(t, cached)
+	assert.Empty(t, missing)
+
+	inv.generations[telemetrytypes.SignalLogs] = time.Now().UnixMilli()
+	cached, missing = bc.GetMissRanges(ctx, orgID, query, step)
+	assert.Nil(t, cached)
+	require.Len(t, missing, 1)
+	assert.Equal(t, startMs, missing[0].From)
+	assert.Equal(t, endMs, missing[0].To)
+
+	// the results cached before the purge are deleted rather than left in the cache until the cache ttl
+	assert.Eventually(t, func() bool {
+		var data qbtypes.CachedData
+		err := c.Get(ctx, orgID, "v5:query:test-signal-query", &data)
+		return err != nil && errors.Ast(err, errors.TypeNotFound)
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestBucketCache_LagExtendsFluxInterval(t *testing.T) {
+	inv := &fakeInvalidator{lags: map[telemetrytypes.Signal]time.Duration{}}
+	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), createTestCache(t), cacheTTL, defaultFluxInterval, nil, inv)
+
+	ctx := context.Background()
+	orgID := valuer.GenerateUUID()
+	step := qbtypes.Step{Duration: time.Second}
+
+	endMs := uint64(time.Now().Add(-30 * time.Minute).Truncate(time.Minute).UnixMilli())
+	startMs := endMs - uint64(time.Hour.Milliseconds())
+	query := newSignalMockQuery(telemetrytypes.SignalLogs, startMs, endMs)
+
+	bc.Put(ctx, orgID, query, step, &qbtypes.Result{
+		Type:  qbtypes.RequestTypeTimeSeries,
+		Value: createTestTimeSeries("A", startMs, endMs, 1000),
+	})
+
+	cached, missing := bc.GetMissRanges(ctx, orgID, query, step)
+	require.NotNil(t, cached)
+	assert.Empty(t, missing)
+
+	// a source reports that its data is an hour behind, the cached results of the last hour are not settled anymore
+	inv.lags[telemetrytypes.SignalLogs] = time.Hour
+	cached, missing = bc.GetMissRanges(ctx, orgID, query, step)
+	assert.Nil(t, cached)
+	require.NotEmpty(t, missing)
+}
+
+func TestBucketCache_SignalFluxInterval(t *testing.T) {
+	fluxIntervals := map[telemetrytypes.Signal]time.Duration{telemetrytypes.SignalLogs: time.Hour}
+	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), createTestCache(t), cacheTTL, defaultFluxInterval, fluxIntervals, nil)
+
+	ctx := context.Background()
+	orgID := valuer.GenerateUUID()
+	step := qbtypes.Step{Duration: time.Second}
+
+	endMs := uint64(time.Now().Add(-30 * time.Minute).Truncate(time.Minute).UnixMilli())
+	startMs := endMs - uint64(time.Hour.Milliseconds())
+
+	for _, tc := range []struct {
+		signal  telemetrytypes.Signal
+		settled bool
+	}{
+		{signal: telemetrytypes.SignalTraces, settled: true},
+		{signal: telemetrytypes.SignalLogs, settled: false},
+	} {
+		t.Run(tc.signal.StringValue(), func(t *testing.T) {
+			query := newSignalMockQuery(tc.signal, startMs, endMs)
+			query.fingerprint = "test-" + tc.signal.StringValue()
+
+			bc.Put(ctx, orgID, query, step, &qbtypes.Result{
+				Type:  qbtypes.RequestTypeTimeSeries,
+				Value: createTestTimeSeries("A", startMs, endMs, 1000),
+			})
+
+			// the results of the last hour of logs are never cached
+			_, missing := bc.GetMissRanges(ctx, orgID, query, step)
+			assert.Equal(t, tc.settled, len(missing) == 0)
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package querier
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/types/metrictypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+)
+
+// joinSide is a builder query referenced by a join, its statement is built and attached to the join statement.
+type joinSide struct {
+	name  string
+	spec  any
+	build func(ctx context.Context) (*qbtypes.Statement, error)
+	// overflow builds the statement of the side with one row more than the max limit, it is set when the side is
+	// capped at the max limit by the join rather than by its own limit
+	overflow func(ctx context.Context) (*qbtypes.Statement, error)
+}
+
+type joinQuery struct {
+	telemetryStore telemetrystore.TelemetryStore
+	stmtBuilder    qbtypes.JoinStatementBuilder
+	spec           qbtypes.QueryBuilderJoin
+	left           *joinSide
+	right          *joinSide
+	variables      map[string]qbtypes.VariableItem
+	fromMS         uint64
+	toMS           uint64
+	kind           qbtypes.RequestType
+}
+
+var _ qbtypes.Query = (*joinQuery)(nil)
+
+func (q *joinQuery) Fingerprint() string {
+	return ""
+}
+
+func (q *joinQuery) Window() (uint64, uint64) {
+	return q.fromMS, q.toMS
+}
+
+func (q *joinQuery) statement(ctx context.Context) (*qbtypes.Statement, error) {
+	left, err := q.buildSide(ctx, q.left)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := q.buildSide(ctx, q.right)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.stmtBuilder.Build(ctx, q.fromMS, q.toMS, q.kind, q.spec, left, right, q.variables)
+}
+
+func (q *joinQuery) Execute(ctx context.Context) (*qbtypes.Result, error) {
+	stmt, err := q.statement(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	queryWindow := &qbtypes.TimeRange{From: q.fromMS, To: q.toMS}
+
+	payload, stats, err := executeStatement(ctx, q.telemetryStore, stmt.Query, stmt.Args, func(rows driver.Rows) (any, error) {
+		return consume(rows, q.kind, queryWindow, qbtypes.Step{}, q.spec.Name)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	warnings := stmt.Warnings
+	for _, side := range []*joinSide{q.left, q.right} {
+		truncated, err := q.truncated(ctx, side)
+		if err != nil {
+			return nil, err
+		}
+
+		if truncated {
+			warnings = append(warnings, fmt.Sprintf("query '%s' has more than %d rows, only the first %d are joined by '%s', set a limit or narrow its filter", side.name, qbtypes.MaxQueryLimit, qbtypes.MaxQueryLimit, q.spec.Name))
+		}
+	}
+
+	return &qbtypes.Result{
+		Type:           q.kind,
+		Value:          payload,
+		Stats:          stats,
+		Warnings:       warnings,
+		WarningsDocURL: stmt.WarningsDocURL,
+	}, nil
+}
+
+func (q *joinQuery) buildSide(ctx context.Context, side *joinSide) (*qbtypes.JoinedQuery, error) {
+	stmt, err := side.build(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &qbtypes.JoinedQuery{Spec: side.spec, Statement: stmt}, nil
+}
+
+// truncated tells whether the side is capped at the max limit by the join and has more rows than it.
+func (q *joinQuery) truncated(ctx context.Context, side *joinSide) (bool, error) {
+	if side.overflow == nil {
+		return false, nil
+	}
+
+	stmt, err := side.overflow(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var count uint64
+	if err := q.telemetryStore.ClickhouseDB().QueryRow(ctx, fmt.Sprintf("SELECT count() FROM (%s)", stmt.Query), stmt.Args...).Scan(&count); err != nil {
+		return false, errors.WrapInternalf(err, errors.CodeInternal, "failed to count the rows of query '%s' joined by '%s'", side.name, q.spec.Name)
+	}
+
+	return count > uint64(qbtypes.MaxQueryLimit), nil
+}
+
+// newJoinSide creates the side of the join for the referenced builder query. Queries with aggregations are run as
+// scalar queries and the others as list queries, which return up to the max limit unless they set a limit. The result
+// of the join is warned about when a list query is capped at the max limit.
+func (q *querier) newJoinSide(
+	name string,
+	specs map[string]any,
+	tr qbtypes.TimeRange,
+	variables map[string]qbtypes.VariableItem,
+	metricTemporality map[string]metrictypes.Temporality,
+) (*joinSide, error) {
+	switch spec := specs[name].(type) {
+	case qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]:
+		subQueries, err := q.newSubQueries(spec.Filter, specs, tr, variables, metricTemporality)
+		if err != nil {
+			return nil, err
+		}
+		return newJoinSideFor(q.telemetryStore, q.traceStmtBuilder, spec, tr, variables, subQueries), nil
+	case qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]:
+		subQueries, err := q.newSubQueries(spec.Filter, specs, tr, variables, metricTemporality)
+		if err != nil {
+			return nil, err
+		}
+		return newJoinSideFor(q.telemetryStore, q.logStmtBuilder, spec, tr, variables, subQueries), nil
+	}
+
+	return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "query '%s' referenced by the join doesn't exist or is not a logs or traces builder query", name)
+}
+
+func newJoinSideFor[T any](
+	telemetryStore telemetrystore.TelemetryStore,
+	stmtBuilder qbtypes.StatementBuilder[T],
+	spec qbtypes.QueryBuilderQuery[T],
+	tr qbtypes.TimeRange,
+	variables map[string]qbtypes.VariableItem,
+	subQueries []*subQuery,
+) *joinSide {
+	kind := qbtypes.JoinedQueryRequestType(spec)
+	capped := kind == qbtypes.RequestTypeRaw && spec.Limit == 0
+	if capped {
+		spec.Limit = qbtypes.MaxQueryLimit
+	}
+
+	bq := newBuilderQuery(telemetryStore, stmtBuilder, spec, tr, kind, variables)
+	bq.subQueries = subQueries
+
+	side := &joinSide{
+		name: spec.Name,
+		spec: spec,
+		build: func(ctx context.Context) (*qbtypes.Statement, error) {
+			return bq.buildStatement(ctx, bq.fromMS, bq.toMS)
+		},
+	}
+
+	if capped {
+		overflowSpec := spec
+		overflowSpec.Limit = qbtypes.MaxQueryLimit + 1
+		overflow := newBuilderQuery(telemetryStore, stmtBuilder, overflowSpec, tr, kind, variables)
+		overflow.subQueries = subQueries
+		side.overflow = func(ctx context.Context) (*qbtypes.Statement, error) {
+			return overflow.buildStatement(ctx, overflow.fromMS, overflow.toMS)
+		}
+	}
+
+	return side
+}
@@ -0,0 +1,88 @@
+package querier
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/telemetrystore/telemetrystoretest"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	cmock "github.com/srikanthccv/ClickHouse-go-mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeJoinStatementBuilder struct{}
+
+func (fakeJoinStatementBuilder) Build(_ context.Context, _, _ uint64, _ qbtypes.RequestType, _ qbtypes.QueryBuilderJoin, left *qbtypes.JoinedQuery, right *qbtypes.JoinedQuery, _ map[string]qbtypes.VariableItem) (*qbtypes.Statement, error) {
+	return &qbtypes.Statement{Query: "SELECT service FROM (" + left.Statement.Query + ") JOIN (" + right.Statement.Query + ")"}, nil
+}
+
+func newFakeJoinSide(name string, query string, overflowQuery string) *joinSide {
+	side := &joinSide{
+		name: name,
+		build: func(context.Context) (*qbtypes.Statement, error) {
+			return &qbtypes.Statement{Query: query}, nil
+		},
+	}
+	if overflowQuery != "" {
+		side.overflow = func(context.Context) (*qbtypes.Statement, error) {
+			return &qbtypes.Statement{Query: overflowQuery}, nil
+		}
+	}
+	return side
+}
+
+func TestJoinQuery_WarnsWhenSideIsTruncated(t *testing.T) {
+	cases := []struct {
+		name     string
+		count    uint64
+		warnings []string
+	}{
+		{
+			name:  "side within the max limit",
+			count: uint64(qbtypes.MaxQueryLimit),
+		},
+		{
+			name:     "side over the max limit",
+			count:    uint64(qbtypes.MaxQueryLimit) + 1,
+			warnings: []string{"query 'A' has more than 10000 rows, only the first 10000 are joined by 'J', set a limit or narrow its filter"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			telemetryStore := telemetrystoretest.New(telemetrystore.Config{Provider: "clickhouse"}, sqlmock.QueryMatcherRegexp)
+			q := &joinQuery{
+				telemetryStore: telemetryStore,
+				stmtBuilder:    fakeJoinStatementBuilder{},
+				spec:           qbtypes.QueryBuilderJoin{Name: "J"},
+				// only the left side is capped by the join, the right side sets its own limit
+				left:   newFakeJoinSide("A", "SELECT left LIMIT 10000", "SELECT left LIMIT 10001"),
+				right:  newFakeJoinSide("B", "SELECT right LIMIT 10", ""),
+				fromMS: 1735689600000,
+				toMS:   1735693200000,
+				kind:   qbtypes.RequestTypeRaw,
+			}
+
+			telemetryStore.Mock().
+				ExpectQuery(`SELECT service FROM \(SELECT left LIMIT 10000\) JOIN \(SELECT right LIMIT 10\)`).
+				WillReturnRows(cmock.NewRows(
+					[]cmock.ColumnType{{Name: "service", Type: "String"}},
+					[][]any{{"cart"}},
+				))
+			telemetryStore.Mock().
+				ExpectQueryRow(`SELECT count\(\) FROM \(SELECT left LIMIT 10001\)`).
+				WillReturnRow(cmock.NewRow(
+					[]cmock.ColumnType{{Name: "count()", Type: "UInt64"}},
+					[]any{c.count},
+				))
+
+			result, err := q.Execute(context.Background())
+			require.NoError(t, err)
+			require.NoError(t, telemetryStore.Mock().ExpectationsWereMet())
+			assert.Equal(t, c.warnings, result.Warnings)
+		})
+	}
+}
@@ -33,6 +33,10 @@ func getqueryInfo(spec any) queryInfo {
 		return queryInfo{Name: s.Name, Disabled: s.Disabled, Step: s.StepInterval}
 	case qbtypes.QueryBuilderFormula:
 		return queryInfo{Name: s.Name, Disabled: s.Disabled}
+	case qbtypes.QueryBuilderJoin:
+		return queryInfo{Name: s.Name, Disabled: s.Disabled}
+	case qbtypes.AlertAnalyticsQuery:
+		return queryInfo{Name: s.Name, Disabled: s.Disabled, Step: s.StepInterval}
 	case qbtypes.PromQuery:
 		return queryInfo{Name: s.Name, Disabled: s.Disabled, Step: s.Step}
 	case qbtypes.ClickHouseQuery:
@@ -167,6 +171,7 @@ func postProcessBuilderQuery[T any](
 ) *qbtypes.Result {
 
 	result = q.applySeriesLimit(result, query.Limit, query.Order)
+	result = q.fillDistributionBuckets(result, query.Distribution)
 
 	// Apply functions
 	if len(query.Functions) > 0 {
@@ -200,6 +205,7 @@ func postProcessMetricQuery(
 	}
 
 	result = q.applySeriesLimit(result, query.Limit, query.Order)
+	result = q.fillDistributionBuckets(result, query.Distribution)
 
 	if len(query.Functions) > 0 {
 		step := query.StepInterval.Duration.Milliseconds()
@@ -277,6 +283,32 @@ func (q *querier) applySeriesLimit(result *qbtypes.Result, limit int, orderBy []
 	return result
 }
 
+// fillDistributionBuckets adds the empty buckets of fixed boundaries distributions, the statement only returns the
+// buckets with at least one value
+func (q *querier) fillDistributionBuckets(result *qbtypes.Result, distribution *qbtypes.Distribution) *qbtypes.Result {
+	distData, ok := result.Value.(*qbtypes.DistributionData)
+	if !ok || distData == nil || distribution == nil || !distribution.IsFixed() {
+		return result
+	}
+
+	for _, series := range distData.Series {
+		buckets := make([]*qbtypes.DistributionBucket, 0, distribution.NumBuckets())
+		next := 0
+		for i := 0; i < distribution.NumBuckets(); i++ {
+			lower, upper := distribution.Boundaries[i], distribution.Boundaries[i+1]
+			if next < len(series.Buckets) && series.Buckets[next].Lower == lower {
+				buckets = append(buckets, series.Buckets[next])
+				next++
+				continue
+			}
+			buckets = append(buckets, &qbtypes.DistributionBucket{Lower: lower, Upper: upper})
+		}
+		series.Buckets = buckets
+	}
+
+	return result
+}
+
 // applyFunctions applies functions to time series data
 func (q *querier) applyFunctions(result *qbtypes.Result, functions []qbtypes.Function) *qbtypes.Result {
 	tsData, ok := result.Value.(*qbtypes.TimeSeriesData)
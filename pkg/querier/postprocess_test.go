@@ -0,0 +1,49 @@
+package querier
+
+import (
+	"testing"
+
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFillDistributionBuckets(t *testing.T) {
+	q := &querier{}
+
+	result := &qbtypes.Result{
+		Value: &qbtypes.DistributionData{
+			QueryName: "A",
+			Series: []*qbtypes.DistributionSeries{
+				{
+					Buckets: []*qbtypes.DistributionBucket{
+						{Lower: 10, Upper: 100, Count: 4},
+					},
+				},
+			},
+		},
+	}
+
+	result = q.fillDistributionBuckets(result, &qbtypes.Distribution{Boundaries: []float64{0, 10, 100, 1000}})
+
+	assert.Equal(t, []*qbtypes.DistributionBucket{
+		{Lower: 0, Upper: 10, Count: 0},
+		{Lower: 10, Upper: 100, Count: 4},
+		{Lower: 100, Upper: 1000, Count: 0},
+	}, result.Value.(*qbtypes.DistributionData).Series[0].Buckets)
+
+	// automatic buckets are left as is
+	result = &qbtypes.Result{
+		Value: &qbtypes.DistributionData{
+			Series: []*qbtypes.DistributionSeries{
+				{
+					Buckets: []*qbtypes.DistributionBucket{
+						{Lower: 1, Upper: 2, Count: 1},
+					},
+				},
+			},
+		},
+	}
+
+	result = q.fillDistributionBuckets(result, &qbtypes.Distribution{BucketCount: 5})
+	assert.Len(t, result.Value.(*qbtypes.DistributionData).Series[0].Buckets, 1)
+}
@@ -74,6 +74,7 @@ type promqlQuery struct {
 }
 
 var _ qbv5.Query = (*promqlQuery)(nil)
+var _ signalQuery = (*promqlQuery)(nil)
 
 func newPromqlQuery(
 	logger *slog.Logger,
@@ -86,6 +87,10 @@ func newPromqlQuery(
 	return &promqlQuery{logger, promEngine, query, tr, requestType, variables}
 }
 
+func (q *promqlQuery) signal() telemetrytypes.Signal {
+	return telemetrytypes.SignalMetrics
+}
+
 func (q *promqlQuery) Fingerprint() string {
 	query, err := q.renderVars(q.query.Query, q.vars, q.tr.From, q.tr.To)
 	if err != nil {
@@ -29,17 +29,22 @@ var (
 )
 
 type querier struct {
-	logger                   *slog.Logger
-	telemetryStore           telemetrystore.TelemetryStore
-	metadataStore            telemetrytypes.MetadataStore
-	promEngine               prometheus.Prometheus
-	traceStmtBuilder         qbtypes.StatementBuilder[qbtypes.TraceAggregation]
-	logStmtBuilder           qbtypes.StatementBuilder[qbtypes.LogAggregation]
-	metricStmtBuilder        qbtypes.StatementBuilder[qbtypes.MetricAggregation]
-	meterStmtBuilder         qbtypes.StatementBuilder[qbtypes.MetricAggregation]
-	traceOperatorStmtBuilder qbtypes.TraceOperatorStatementBuilder
-	bucketCache              BucketCache
-	liveDataRefreshSeconds   time.Duration
+	logger                    *slog.Logger
+	telemetryStore            telemetrystore.TelemetryStore
+	metadataStore             telemetrytypes.MetadataStore
+	promEngine                prometheus.Prometheus
+	traceStmtBuilder          qbtypes.StatementBuilder[qbtypes.TraceAggregation]
+	logStmtBuilder            qbtypes.StatementBuilder[qbtypes.LogAggregation]
+	metricStmtBuilder         qbtypes.StatementBuilder[qbtypes.MetricAggregation]
+	meterStmtBuilder          qbtypes.StatementBuilder[qbtypes.MetricAggregation]
+	traceOperatorStmtBuilder  qbtypes.TraceOperatorStatementBuilder
+	joinStmtBuilder           qbtypes.JoinStatementBuilder
+	alertAnalyticsStmtBuilder qbtypes.AlertAnalyticsStatementBuilder
+	alertAnalyticsStore       qbtypes.AlertAnalyticsStore
+	bucketCache               BucketCache
+	governor                  Governor
+	invalidator               Invalidator
+	liveDataRefreshSeconds    time.Duration
 }
 
 var _ Querier = (*querier)(nil)
@@ -54,21 +59,31 @@ func New(
 	metricStmtBuilder qbtypes.StatementBuilder[qbtypes.MetricAggregation],
 	meterStmtBuilder qbtypes.StatementBuilder[qbtypes.MetricAggregation],
 	traceOperatorStmtBuilder qbtypes.TraceOperatorStatementBuilder,
+	joinStmtBuilder qbtypes.JoinStatementBuilder,
+	alertAnalyticsStmtBuilder qbtypes.AlertAnalyticsStatementBuilder,
+	alertAnalyticsStore qbtypes.AlertAnalyticsStore,
 	bucketCache BucketCache,
+	governor Governor,
+	invalidator Invalidator,
 ) *querier {
 	querierSettings := factory.NewScopedProviderSettings(settings, "github.com/SigNoz/signoz/pkg/querier")
 	return &querier{
-		logger:                   querierSettings.Logger(),
-		telemetryStore:           telemetryStore,
-		metadataStore:            metadataStore,
-		promEngine:               promEngine,
-		traceStmtBuilder:         traceStmtBuilder,
-		logStmtBuilder:           logStmtBuilder,
-		metricStmtBuilder:        metricStmtBuilder,
-		meterStmtBuilder:         meterStmtBuilder,
-		traceOperatorStmtBuilder: traceOperatorStmtBuilder,
-		bucketCache:              bucketCache,
-		liveDataRefreshSeconds:   5,
+		logger:                    querierSettings.Logger(),
+		telemetryStore:            telemetryStore,
+		metadataStore:             metadataStore,
+		promEngine:                promEngine,
+		traceStmtBuilder:          traceStmtBuilder,
+		logStmtBuilder:            logStmtBuilder,
+		metricStmtBuilder:         metricStmtBuilder,
+		meterStmtBuilder:          meterStmtBuilder,
+		traceOperatorStmtBuilder:  traceOperatorStmtBuilder,
+		joinStmtBuilder:           joinStmtBuilder,
+		alertAnalyticsStmtBuilder: alertAnalyticsStmtBuilder,
+		alertAnalyticsStore:       alertAnalyticsStore,
+		bucketCache:               bucketCache,
+		governor:                  governor,
+		invalidator:               invalidator,
+		liveDataRefreshSeconds:    5,
 	}
 }
 
@@ -120,7 +135,54 @@ func adjustTimeRangeForShift[T any](spec qbtypes.QueryBuilderQuery[T], tr qbtype
 }
 
 func (q *querier) QueryRange(ctx context.Context, orgID valuer.UUID, req *qbtypes.QueryRangeRequest) (*qbtypes.QueryRangeResponse, error) {
+	if q.governor != nil {
+		release, err := q.governor.Admit(ctx, orgID)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+
+	prepared, err := q.prepare(ctx, orgID, req)
+	if err != nil {
+		return nil, err
+	}
 
+	qbResp, qbErr := q.run(ctx, orgID, prepared.queries, req, prepared.steps, prepared.event)
+	if qbResp != nil {
+		qbResp.QBEvent = prepared.event
+		qbResp.Warning = prepared.warning(req, qbResp.Warning)
+	}
+	return qbResp, qbErr
+}
+
+// preparedRequest is a query range request with its queries built and their steps set, ready to be run
+type preparedRequest struct {
+	queries          map[string]qbtypes.Query
+	steps            map[string]qbtypes.Step
+	event            *qbtypes.QBEvent
+	intervalWarnings []string
+}
+
+// warning returns the warning of the response, the overridden step intervals are reported for the time series
+// responses without any other warning
+func (p *preparedRequest) warning(req *qbtypes.QueryRangeRequest, warning *qbtypes.QueryWarnData) *qbtypes.QueryWarnData {
+	if warning != nil || len(p.intervalWarnings) == 0 || req.RequestType != qbtypes.RequestTypeTimeSeries {
+		return warning
+	}
+
+	warning = &qbtypes.QueryWarnData{
+		Warnings: make([]qbtypes.QueryWarnDataAdditional, len(p.intervalWarnings)),
+	}
+	for idx := range p.intervalWarnings {
+		warning.Warnings[idx] = qbtypes.QueryWarnDataAdditional{Message: p.intervalWarnings[idx]}
+	}
+	return warning
+}
+
+// prepare builds the queries of the request, the steps of the builder queries are set to the recommended value when
+// missing or too small for the time range.
+func (q *querier) prepare(ctx context.Context, orgID valuer.UUID, req *qbtypes.QueryRangeRequest) (*preparedRequest, error) {
 	tmplVars := req.Variables
 	if tmplVars == nil {
 		tmplVars = make(map[string]qbtypes.VariableItem)
@@ -150,13 +212,21 @@ func (q *querier) QueryRange(ctx context.Context, orgID valuer.UUID, req *qbtype
 				traceOperatorQueries[spec.Name] = spec
 			}
 		}
+
+		// the queries referenced by a join are run as part of the join
+		if query.Type == qbtypes.QueryTypeJoin {
+			if spec, ok := query.Spec.(qbtypes.QueryBuilderJoin); ok {
+				dependencyQueries[spec.Left.Name] = true
+				dependencyQueries[spec.Right.Name] = true
+			}
+		}
 	}
 
 	// First pass: collect all metric names that need temporality
 	metricNames := make([]string, 0)
 	for idx, query := range req.CompositeQuery.Queries {
 		event.QueryType = query.Type.StringValue()
-		if query.Type == qbtypes.QueryTypeBuilder {
+		if query.Type == qbtypes.QueryTypeBuilder || query.Type == qbtypes.QueryTypeSubQuery {
 			if spec, ok := query.Spec.(qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]); ok {
 				for _, agg := range spec.Aggregations {
 					if agg.MetricName != "" {
@@ -245,6 +315,15 @@ func (q *querier) QueryRange(ctx context.Context, orgID valuer.UUID, req *qbtype
 					event.TracesUsed = strings.Contains(spec.Query, "signoz_traces")
 				}
 			}
+		} else if query.Type == qbtypes.QueryTypeAlertAnalytics {
+			if spec, ok := query.Spec.(qbtypes.AlertAnalyticsQuery); ok {
+				if spec.StepInterval.Seconds() == 0 {
+					spec.StepInterval = qbtypes.Step{
+						Duration: time.Second * time.Duration(querybuilder.RecommendedStepInterval(req.Start, req.End)),
+					}
+				}
+				req.CompositeQuery.Queries[idx].Spec = spec
+			}
 		} else if query.Type == qbtypes.QueryTypeTraceOperator {
 			if spec, ok := query.Spec.(qbtypes.QueryBuilderTraceOperator); ok {
 				if spec.StepInterval.Seconds() == 0 {
@@ -278,8 +357,26 @@ func (q *querier) QueryRange(ctx context.Context, orgID valuer.UUID, req *qbtype
 		q.logger.DebugContext(ctx, "fetched metric temporalities", "metric_temporality", metricTemporality)
 	}
 
+	// builder queries by name, for the queries referenced by the filters of other builder queries
+	builderSpecs := make(map[string]any)
+	for _, query := range req.CompositeQuery.Queries {
+		if query.Type != qbtypes.QueryTypeBuilder && query.Type != qbtypes.QueryTypeSubQuery {
+			continue
+		}
+		switch spec := query.Spec.(type) {
+		case qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]:
+			builderSpecs[spec.Name] = spec
+		case qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]:
+			builderSpecs[spec.Name] = spec
+		case qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]:
+			builderSpecs[spec.Name] = spec
+		}
+	}
+
 	queries := make(map[string]qbtypes.Query)
 	steps := make(map[string]qbtypes.Step)
+	// the ids of the rules of the organization, listed once for all the alert analytics queries
+	var ruleIDs []string
 
 	for _, query := range req.CompositeQuery.Queries {
 		var queryName string
@@ -346,32 +443,83 @@ func (q *querier) QueryRange(ctx context.Context, orgID valuer.UUID, req *qbtype
 			}
 			queries[traceOpQuery.Name] = toq
 			steps[traceOpQuery.Name] = traceOpQuery.StepInterval
+		case qbtypes.QueryTypeJoin:
+			joinSpec, ok := query.Spec.(qbtypes.QueryBuilderJoin)
+			if !ok {
+				return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid join query spec %T", query.Spec)
+			}
+			timeRange := qbtypes.TimeRange{From: req.Start, To: req.End}
+			left, err := q.newJoinSide(joinSpec.Left.Name, builderSpecs, timeRange, tmplVars, metricTemporality)
+			if err != nil {
+				return nil, err
+			}
+			right, err := q.newJoinSide(joinSpec.Right.Name, builderSpecs, timeRange, tmplVars, metricTemporality)
+			if err != nil {
+				return nil, err
+			}
+			queries[joinSpec.Name] = &joinQuery{
+				telemetryStore: q.telemetryStore,
+				stmtBuilder:    q.joinStmtBuilder,
+				spec:           joinSpec,
+				left:           left,
+				right:          right,
+				variables:      tmplVars,
+				fromMS:         req.Start,
+				toMS:           req.End,
+				kind:           req.RequestType,
+			}
+		case qbtypes.QueryTypeAlertAnalytics:
+			alertAnalyticsSpec, ok := query.Spec.(qbtypes.AlertAnalyticsQuery)
+			if !ok {
+				return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid alert analytics query spec %T", query.Spec)
+			}
+			// the history of the rules is not scoped by organization, so it is restricted to the rules of the organization
+			if ruleIDs == nil {
+				if q.alertAnalyticsStore == nil {
+					return nil, errors.Newf(errors.TypeUnsupported, errors.CodeUnsupported, "alert analytics queries are not supported")
+				}
+				var err error
+				if ruleIDs, err = q.alertAnalyticsStore.ListRuleIDs(ctx, orgID); err != nil {
+					return nil, err
+				}
+			}
+			queries[alertAnalyticsSpec.Name] = &alertAnalyticsQuery{
+				telemetryStore: q.telemetryStore,
+				stmtBuilder:    q.alertAnalyticsStmtBuilder,
+				spec:           alertAnalyticsSpec,
+				ruleIDs:        ruleIDs,
+				variables:      tmplVars,
+				fromMS:         req.Start,
+				toMS:           req.End,
+				kind:           req.RequestType,
+			}
+			steps[alertAnalyticsSpec.Name] = alertAnalyticsSpec.StepInterval
 		case qbtypes.QueryTypeBuilder:
 			switch spec := query.Spec.(type) {
 			case qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]:
 				spec.ShiftBy = extractShiftFromBuilderQuery(spec)
 				timeRange := adjustTimeRangeForShift(spec, qbtypes.TimeRange{From: req.Start, To: req.End}, req.RequestType)
 				bq := newBuilderQuery(q.telemetryStore, q.traceStmtBuilder, spec, timeRange, req.RequestType, tmplVars)
+				subQueries, err := q.newSubQueries(spec.Filter, builderSpecs, timeRange, tmplVars, metricTemporality)
+				if err != nil {
+					return nil, err
+				}
+				bq.subQueries = subQueries
 				queries[spec.Name] = bq
 				steps[spec.Name] = spec.StepInterval
 			case qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]:
 				spec.ShiftBy = extractShiftFromBuilderQuery(spec)
 				timeRange := adjustTimeRangeForShift(spec, qbtypes.TimeRange{From: req.Start, To: req.End}, req.RequestType)
 				bq := newBuilderQuery(q.telemetryStore, q.logStmtBuilder, spec, timeRange, req.RequestType, tmplVars)
+				subQueries, err := q.newSubQueries(spec.Filter, builderSpecs, timeRange, tmplVars, metricTemporality)
+				if err != nil {
+					return nil, err
+				}
+				bq.subQueries = subQueries
 				queries[spec.Name] = bq
 				steps[spec.Name] = spec.StepInterval
 			case qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]:
-				for i := range spec.Aggregations {
-					if spec.Aggregations[i].MetricName != "" && spec.Aggregations[i].Temporality == metrictypes.Unknown {
-						if temp, ok := metricTemporality[spec.Aggregations[i].MetricName]; ok && temp != metrictypes.Unknown {
-							spec.Aggregations[i].Temporality = temp
-						}
-					}
-					// TODO(srikanthccv): warn when the metric is missing
-					if spec.Aggregations[i].Temporality == metrictypes.Unknown {
-						spec.Aggregations[i].Temporality = metrictypes.Unspecified
-					}
-				}
+				spec = withMetricTemporality(spec, metricTemporality)
 				spec.ShiftBy = extractShiftFromBuilderQuery(spec)
 				timeRange := adjustTimeRangeForShift(spec, qbtypes.TimeRange{From: req.Start, To: req.End}, req.RequestType)
 				var bq *builderQuery[qbtypes.MetricAggregation]
@@ -382,6 +530,11 @@ func (q *querier) QueryRange(ctx context.Context, orgID valuer.UUID, req *qbtype
 				} else {
 					bq = newBuilderQuery(q.telemetryStore, q.metricStmtBuilder, spec, timeRange, req.RequestType, tmplVars)
 				}
+				subQueries, err := q.newSubQueries(spec.Filter, builderSpecs, timeRange, tmplVars, metricTemporality)
+				if err != nil {
+					return nil, err
+				}
+				bq.subQueries = subQueries
 
 				queries[spec.Name] = bq
 				steps[spec.Name] = spec.StepInterval
@@ -390,21 +543,13 @@ func (q *querier) QueryRange(ctx context.Context, orgID valuer.UUID, req *qbtype
 			}
 		}
 	}
-	qbResp, qbErr := q.run(ctx, orgID, queries, req, steps, event)
-	if qbResp != nil {
-		qbResp.QBEvent = event
-		if len(intervalWarnings) != 0 && req.RequestType == qbtypes.RequestTypeTimeSeries {
-			if qbResp.Warning == nil {
-				qbResp.Warning = &qbtypes.QueryWarnData{
-					Warnings: make([]qbtypes.QueryWarnDataAdditional, len(intervalWarnings)),
-				}
-				for idx := range intervalWarnings {
-					qbResp.Warning.Warnings[idx] = qbtypes.QueryWarnDataAdditional{Message: intervalWarnings[idx]}
-				}
-			}
-		}
-	}
-	return qbResp, qbErr
+
+	return &preparedRequest{
+		queries:          queries,
+		steps:            steps,
+		event:            event,
+		intervalWarnings: intervalWarnings,
+	}, nil
 }
 
 func (q *querier) QueryRawStream(ctx context.Context, orgID valuer.UUID, req *qbtypes.QueryRangeRequest, client *qbtypes.RawStream) {
@@ -527,6 +672,10 @@ func (q *querier) run(
 			if val, ok := result.Value.(*qbtypes.RawData); ok && val != nil {
 				return len(val.Rows) != 0
 			}
+		case qbtypes.RequestTypeDistribution:
+			if val, ok := result.Value.(*qbtypes.DistributionData); ok && val != nil {
+				return len(val.Series) != 0
+			}
 		case qbtypes.RequestTypeTimeSeries:
 			if val, ok := result.Value.(*qbtypes.TimeSeriesData); ok && val != nil {
 				if len(val.Aggregations) != 0 {
@@ -553,6 +702,9 @@ func (q *querier) run(
 			} else {
 				q.logger.InfoContext(ctx, "no bucket cache or fingerprint, executing query", "fingerprint", query.Fingerprint())
 			}
+			if err := q.charge(ctx, orgID, query); err != nil {
+				return nil, err
+			}
 			result, err := query.Execute(ctx)
 			qbEvent.HasData = qbEvent.HasData || hasData(result)
 			if err != nil {
@@ -577,6 +729,8 @@ func (q *querier) run(
 				v.QueryName = name
 			case *qbtypes.RawData:
 				v.QueryName = name
+			case *qbtypes.DistributionData:
+				v.QueryName = name
 			}
 
 			results[name] = result.Value
@@ -652,6 +806,9 @@ func (q *querier) executeWithCache(ctx context.Context, orgID valuer.UUID, query
 	if cachedResult == nil && len(missingRanges) == 1 {
 		startMs, endMs := query.Window()
 		if missingRanges[0].From == startMs && missingRanges[0].To == endMs {
+			if err := q.charge(ctx, orgID, query); err != nil {
+				return nil, err
+			}
 			result, err := query.Execute(ctx)
 			if err != nil {
 				return nil, err
@@ -671,6 +828,15 @@ func (q *querier) executeWithCache(ctx context.Context, orgID valuer.UUID, query
 		"missing_ranges_count", len(missingRanges),
 		"ranges", missingRanges)
 
+	// Create a new query for every missing time range, only the missing ranges are charged
+	rangedQueries := make([]qbtypes.Query, len(missingRanges))
+	for i, timeRange := range missingRanges {
+		rangedQueries[i] = q.createRangedQuery(query, *timeRange)
+	}
+	if err := q.charge(ctx, orgID, rangedQueries...); err != nil {
+		return nil, err
+	}
+
 	sem := make(chan struct{}, 4)
 	var wg sync.WaitGroup
 
@@ -682,8 +848,7 @@ func (q *querier) executeWithCache(ctx context.Context, orgID valuer.UUID, query
 			sem <- struct{}{}
 			defer func() { <-sem }()
 
-			// Create a new query with the missing time range
-			rangedQuery := q.createRangedQuery(query, *tr)
+			rangedQuery := rangedQueries[idx]
 			if rangedQuery == nil {
 				errs[idx] = errors.NewInternalf(errors.CodeInternal, "failed to create ranged query for range %d-%d", tr.From, tr.To)
 				return
@@ -741,9 +906,33 @@ func (q *querier) executeWithCache(ctx context.Context, orgID valuer.UUID, query
 	return mergedResult, nil
 }
 
+// charge charges the estimated cost of the statements of the queries to the budgets, before the queries are executed
+func (q *querier) charge(ctx context.Context, orgID valuer.UUID, queries ...qbtypes.Query) error {
+	// the statements, subqueries included, are built only when there is a limit on the bytes to charge them to
+	if q.governor == nil || !q.governor.LimitsBytes(ctx, orgID) {
+		return nil
+	}
+
+	stmts := make([]*qbtypes.Statement, 0, len(queries))
+	for _, query := range queries {
+		sq, ok := query.(statementQuery)
+		if !ok {
+			continue
+		}
+
+		stmt, err := sq.statement(ctx)
+		if err != nil {
+			return err
+		}
+		stmts = append(stmts, stmt)
+	}
+
+	return q.governor.Charge(ctx, orgID, stmts)
+}
+
 // createRangedQuery creates a copy of the query with a different time range
 func (q *querier) createRangedQuery(originalQuery qbtypes.Query, timeRange qbtypes.TimeRange) qbtypes.Query {
-	// this is called in a goroutine, so we create a copy of the query to avoid race conditions
+	// the ranged queries are executed in goroutines, so we create a copy of the query to avoid race conditions
 	switch qt := originalQuery.(type) {
 	case *promqlQuery:
 		queryCopy := qt.query.Copy()
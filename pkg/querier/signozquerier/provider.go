@@ -8,14 +8,19 @@ import (
 	"github.com/SigNoz/signoz/pkg/flagger"
 	"github.com/SigNoz/signoz/pkg/prometheus"
 	"github.com/SigNoz/signoz/pkg/querier"
+	"github.com/SigNoz/signoz/pkg/querier/sqlquerierstore"
 	"github.com/SigNoz/signoz/pkg/querybuilder"
+	"github.com/SigNoz/signoz/pkg/querybuilder/join"
 	"github.com/SigNoz/signoz/pkg/querybuilder/resourcefilter"
+	"github.com/SigNoz/signoz/pkg/querybuilder/rulestatehistory"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
 	"github.com/SigNoz/signoz/pkg/telemetrylogs"
 	"github.com/SigNoz/signoz/pkg/telemetrymetadata"
 	"github.com/SigNoz/signoz/pkg/telemetrymeter"
 	"github.com/SigNoz/signoz/pkg/telemetrymetrics"
 	"github.com/SigNoz/signoz/pkg/telemetrystore"
 	"github.com/SigNoz/signoz/pkg/telemetrytraces"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
 )
 
 // NewFactory creates a new factory for the signoz querier provider
@@ -24,6 +29,7 @@ func NewFactory(
 	prometheus prometheus.Prometheus,
 	cache cache.Cache,
 	flagger flagger.Flagger,
+	sqlstore sqlstore.SQLStore,
 ) factory.ProviderFactory[querier.Querier, querier.Config] {
 	return factory.NewProviderFactory(
 		factory.MustNewName("signoz"),
@@ -32,7 +38,7 @@ func NewFactory(
 			settings factory.ProviderSettings,
 			cfg querier.Config,
 		) (querier.Querier, error) {
-			return newProvider(ctx, settings, cfg, telemetryStore, prometheus, cache, flagger)
+			return newProvider(ctx, settings, cfg, telemetryStore, prometheus, cache, flagger, sqlstore)
 		},
 	)
 }
@@ -45,6 +51,7 @@ func newProvider(
 	prometheus prometheus.Prometheus,
 	cache cache.Cache,
 	flagger flagger.Flagger,
+	sqlstore sqlstore.SQLStore,
 ) (querier.Querier, error) {
 
 	// Create telemetry metadata store
@@ -152,14 +159,36 @@ func newProvider(
 		metricStmtBuilder,
 	)
 
+	// Create join statement builder
+	joinStmtBuilder := join.NewJoinStatementBuilder(settings)
+
+	// Create alert analytics statement builder
+	alertAnalyticsStmtBuilder := rulestatehistory.NewAlertAnalyticsStatementBuilder(settings)
+
+	// Create the invalidator of the cached results and the store of the rules the alert analytics are restricted to
+	var invalidator querier.Invalidator
+	var alertAnalyticsStore qbtypes.AlertAnalyticsStore
+	if sqlstore != nil {
+		invalidator = querier.NewInvalidator(settings, sqlquerierstore.NewInvalidationStore(sqlstore), cfg.Invalidation)
+		alertAnalyticsStore = sqlquerierstore.NewAlertAnalyticsStore(sqlstore)
+	}
+
 	// Create bucket cache
 	bucketCache := querier.NewBucketCache(
 		settings,
 		cache,
 		cfg.CacheTTL,
 		cfg.FluxInterval,
+		cfg.FluxIntervals.BySignal(),
+		invalidator,
 	)
 
+	// Create the governor of the budgets of the queries
+	var governor querier.Governor
+	if cfg.Budget.Enabled {
+		governor = querier.NewGovernor(settings, telemetryStore, cfg.Budget)
+	}
+
 	// Create and return the querier
 	return querier.New(
 		settings,
@@ -171,6 +200,11 @@ func newProvider(
 		metricStmtBuilder,
 		meterStmtBuilder,
 		traceOperatorStmtBuilder,
+		joinStmtBuilder,
+		alertAnalyticsStmtBuilder,
+		alertAnalyticsStore,
 		bucketCache,
+		governor,
+		invalidator,
 	), nil
 }
@@ -0,0 +1,37 @@
+package sqlquerierstore
+
+import (
+	"context"
+
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type alertAnalytics struct {
+	sqlstore sqlstore.SQLStore
+}
+
+func NewAlertAnalyticsStore(sqlstore sqlstore.SQLStore) qbtypes.AlertAnalyticsStore {
+	return &alertAnalytics{sqlstore: sqlstore}
+}
+
+// ListRuleIDs implements qbtypes.AlertAnalyticsStore.
+func (store *alertAnalytics) ListRuleIDs(ctx context.Context, orgID valuer.UUID) ([]string, error) {
+	ruleIDs := make([]string, 0)
+
+	err := store.
+		sqlstore.
+		BunDB().
+		NewSelect().
+		Model((*ruletypes.Rule)(nil)).
+		Column("id").
+		Where("org_id = ?", orgID.StringValue()).
+		Scan(ctx, &ruleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return ruleIDs, nil
+}
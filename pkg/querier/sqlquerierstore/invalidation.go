@@ -0,0 +1,107 @@
+package sqlquerierstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+)
+
+type invalidation struct {
+	sqlstore sqlstore.SQLStore
+}
+
+func NewInvalidationStore(sqlstore sqlstore.SQLStore) qbtypes.InvalidationStore {
+	return &invalidation{sqlstore: sqlstore}
+}
+
+// SetWatermark implements qbtypes.InvalidationStore.
+func (store *invalidation) SetWatermark(ctx context.Context, storableWatermark *qbtypes.StorableWatermark) error {
+	_, err := store.
+		sqlstore.
+		BunDB().
+		NewInsert().
+		Model(storableWatermark).
+		On("CONFLICT (org_id, signal, source) DO UPDATE").
+		Set("watermark = EXCLUDED.watermark").
+		Set("updated_at = EXCLUDED.updated_at").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListWatermarks implements qbtypes.InvalidationStore.
+func (store *invalidation) ListWatermarks(ctx context.Context, since time.Time) ([]*qbtypes.StorableWatermark, error) {
+	storableWatermarks := make([]*qbtypes.StorableWatermark, 0)
+
+	err := store.
+		sqlstore.
+		BunDB().
+		NewSelect().
+		Model(&storableWatermarks).
+		Where("updated_at > ?", since).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return storableWatermarks, nil
+}
+
+// ListOrgWatermarks implements qbtypes.InvalidationStore.
+func (store *invalidation) ListOrgWatermarks(ctx context.Context, orgID string) ([]*qbtypes.StorableWatermark, error) {
+	storableWatermarks := make([]*qbtypes.StorableWatermark, 0)
+
+	err := store.
+		sqlstore.
+		BunDB().
+		NewSelect().
+		Model(&storableWatermarks).
+		Where("org_id = ?", orgID).
+		Order("signal ASC", "source ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return storableWatermarks, nil
+}
+
+// CreatePurge implements qbtypes.InvalidationStore.
+func (store *invalidation) CreatePurge(ctx context.Context, storablePurge *qbtypes.StorableCachePurge) error {
+	_, err := store.
+		sqlstore.
+		BunDB().
+		NewInsert().
+		Model(storablePurge).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListLatestPurges implements qbtypes.InvalidationStore.
+func (store *invalidation) ListLatestPurges(ctx context.Context) ([]*qbtypes.StorableCachePurge, error) {
+	storablePurges := make([]*qbtypes.StorableCachePurge, 0)
+
+	err := store.
+		sqlstore.
+		BunDB().
+		NewSelect().
+		Model(&storablePurges).
+		Column("org_id", "signal").
+		ColumnExpr("MAX(created_at) AS created_at").
+		Group("org_id", "signal").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return storablePurges, nil
+}
@@ -0,0 +1,211 @@
+package querier
+
+import (
+	"context"
+
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// QueryRangeStream runs the request like QueryRange but writes the results of the time series and scalar queries to w
+// in parts as their rows are read. The results which need the full result of their query, for formulas, functions,
+// series limits or the shape of the response, are buffered and written whole with the reason.
+func (q *querier) QueryRangeStream(ctx context.Context, orgID valuer.UUID, req *qbtypes.QueryRangeRequest, w qbtypes.StreamWriter) error {
+	if q.governor != nil {
+		release, err := q.governor.Admit(ctx, orgID)
+		if err != nil {
+			return err
+		}
+		defer release()
+	}
+
+	prepared, err := q.prepare(ctx, orgID, req)
+	if err != nil {
+		return err
+	}
+
+	if reason := bufferedRequestReason(req); reason != "" {
+		resp, err := q.run(ctx, orgID, prepared.queries, req, prepared.steps, prepared.event)
+		if err != nil {
+			return err
+		}
+
+		for _, result := range resp.Data.Results {
+			if err := w.Write(&qbtypes.StreamEvent{
+				Type:      qbtypes.StreamEventTypeResult,
+				QueryName: resultQueryName(result),
+				Result:    result,
+				Buffered:  reason,
+			}); err != nil {
+				return err
+			}
+		}
+
+		return w.Write(&qbtypes.StreamEvent{
+			Type:    qbtypes.StreamEventTypeEnd,
+			Meta:    &resp.Meta,
+			Warning: prepared.warning(req, resp.Warning),
+			QBEvent: prepared.event,
+		})
+	}
+
+	stats := qbtypes.ExecStats{}
+	warnings := make([]string, 0)
+	warningsDocURL := ""
+
+	for _, envelope := range req.CompositeQuery.Queries {
+		info := getqueryInfo(envelope.Spec)
+		query, ok := prepared.queries[info.Name]
+		// the disabled queries are only run for the formulas, which are never streamed
+		if !ok || info.Disabled {
+			continue
+		}
+
+		var result *qbtypes.Result
+		if reason := bufferedQueryReason(query, req.RequestType); reason != "" {
+			resp, err := q.run(ctx, orgID, map[string]qbtypes.Query{info.Name: query}, req, prepared.steps, prepared.event)
+			if err != nil {
+				return err
+			}
+
+			for _, value := range resp.Data.Results {
+				if err := w.Write(&qbtypes.StreamEvent{
+					Type:      qbtypes.StreamEventTypeResult,
+					QueryName: info.Name,
+					Result:    value,
+					Buffered:  reason,
+				}); err != nil {
+					return err
+				}
+			}
+
+			result = &qbtypes.Result{Stats: resp.Meta}
+			if resp.Warning != nil {
+				for _, warning := range resp.Warning.Warnings {
+					result.Warnings = append(result.Warnings, warning.Message)
+				}
+				result.WarningsDocURL = resp.Warning.Url
+			}
+		} else {
+			query.(streamingQuery).streamTo(func(part any) error {
+				prepared.event.HasData = true
+				return w.Write(&qbtypes.StreamEvent{
+					Type:      qbtypes.StreamEventTypePart,
+					QueryName: info.Name,
+					Result:    part,
+				})
+			})
+
+			if err := q.charge(ctx, orgID, query); err != nil {
+				return err
+			}
+
+			result, err = query.Execute(ctx)
+			if err != nil {
+				return err
+			}
+		}
+
+		warnings = append(warnings, result.Warnings...)
+		if result.WarningsDocURL != "" {
+			warningsDocURL = result.WarningsDocURL
+		}
+		stats.RowsScanned += result.Stats.RowsScanned
+		stats.BytesScanned += result.Stats.BytesScanned
+		stats.DurationMS += result.Stats.DurationMS
+	}
+
+	stats.StepIntervals = make(map[string]uint64, len(prepared.steps))
+	for name, step := range prepared.steps {
+		stats.StepIntervals[name] = uint64(step.Duration.Seconds())
+	}
+
+	var warning *qbtypes.QueryWarnData
+	if len(warnings) != 0 {
+		warning = &qbtypes.QueryWarnData{
+			Message:  "Encountered warnings",
+			Url:      warningsDocURL,
+			Warnings: make([]qbtypes.QueryWarnDataAdditional, len(warnings)),
+		}
+		for i, message := range warnings {
+			warning.Warnings[i] = qbtypes.QueryWarnDataAdditional{Message: message}
+		}
+	}
+
+	return w.Write(&qbtypes.StreamEvent{
+		Type:    qbtypes.StreamEventTypeEnd,
+		Meta:    &stats,
+		Warning: prepared.warning(req, warning),
+		QBEvent: prepared.event,
+	})
+}
+
+// bufferedRequestReason returns the reason none of the results of the request can be streamed, or an empty string
+func bufferedRequestReason(req *qbtypes.QueryRangeRequest) string {
+	if req.RequestType != qbtypes.RequestTypeTimeSeries && req.RequestType != qbtypes.RequestTypeScalar {
+		return "only time series and scalar results are streamed"
+	}
+
+	for _, query := range req.CompositeQuery.Queries {
+		if query.Type == qbtypes.QueryTypeFormula {
+			return "formulas are evaluated over the full results of their queries"
+		}
+	}
+
+	if req.FormatOptions != nil {
+		if req.RequestType == qbtypes.RequestTypeTimeSeries && req.FormatOptions.FillGaps {
+			return "the gaps are filled in the full series"
+		}
+		if req.RequestType == qbtypes.RequestTypeScalar && req.FormatOptions.FormatTableResultForUI {
+			return "the table merges the full results of the queries"
+		}
+	}
+
+	return ""
+}
+
+// bufferedQueryReason returns the reason the result of the query can't be streamed, or an empty string
+func bufferedQueryReason(query qbtypes.Query, kind qbtypes.RequestType) string {
+	switch query := query.(type) {
+	case *builderQuery[qbtypes.TraceAggregation]:
+		return bufferedBuilderQueryReason(query.spec, kind)
+	case *builderQuery[qbtypes.LogAggregation]:
+		return bufferedBuilderQueryReason(query.spec, kind)
+	case *builderQuery[qbtypes.MetricAggregation]:
+		if kind == qbtypes.RequestTypeScalar {
+			return "the series of metrics are reduced to a value once complete"
+		}
+		return bufferedBuilderQueryReason(query.spec, kind)
+	case *chSQLQuery:
+		return ""
+	default:
+		return "only builder and ClickHouse SQL queries are streamed"
+	}
+}
+
+func bufferedBuilderQueryReason[T any](spec qbtypes.QueryBuilderQuery[T], kind qbtypes.RequestType) string {
+	if len(spec.Functions) != 0 {
+		return "functions are applied to the full series"
+	}
+
+	if kind == qbtypes.RequestTypeTimeSeries && spec.Limit != 0 {
+		return "the series limit is applied to the full series"
+	}
+
+	return ""
+}
+
+// resultQueryName returns the name of the query of a result of a response
+func resultQueryName(result any) string {
+	switch v := result.(type) {
+	case *qbtypes.TimeSeriesData:
+		return v.QueryName
+	case *qbtypes.ScalarData:
+		return v.QueryName
+	case *qbtypes.RawData:
+		return v.QueryName
+	case *qbtypes.DistributionData:
+		return v.QueryName
+	}
+	return ""
+}
@@ -0,0 +1,143 @@
+package querier
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/telemetrystore/telemetrystoretest"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	cmock "github.com/srikanthccv/ClickHouse-go-mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingStreamWriter struct {
+	events []*qbtypes.StreamEvent
+}
+
+func (w *recordingStreamWriter) Write(event *qbtypes.StreamEvent) error {
+	w.events = append(w.events, event)
+	return nil
+}
+
+func newStreamRequest(kind qbtypes.RequestType, query string) *qbtypes.QueryRangeRequest {
+	return &qbtypes.QueryRangeRequest{
+		Start:       1735689600000,
+		End:         1735693200000,
+		RequestType: kind,
+		CompositeQuery: qbtypes.CompositeQuery{
+			Queries: []qbtypes.QueryEnvelope{
+				{Type: qbtypes.QueryTypeClickHouseSQL, Spec: qbtypes.ClickHouseQuery{Name: "A", Query: query}},
+			},
+		},
+	}
+}
+
+func TestQueryRangeStream(t *testing.T) {
+	telemetryStore := telemetrystoretest.New(telemetrystore.Config{Provider: "clickhouse"}, sqlmock.QueryMatcherRegexp)
+	q := New(instrumentationtest.New().ToProviderSettings(), telemetryStore, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	services := []string{"cart", "checkout", "frontend"}
+	points := streamBatchSize/2 + 1
+	values := make([][]any, 0, len(services)*points)
+	for i := 0; i < points; i++ {
+		for _, service := range services {
+			values = append(values, []any{time.UnixMilli(1735689600000 + int64(i)*1000), service, float64(i)})
+		}
+	}
+
+	telemetryStore.Mock().
+		ExpectQuery("SELECT ts, service, value FROM signoz_traces").
+		WillReturnRows(cmock.NewRows(
+			[]cmock.ColumnType{{Name: "ts", Type: "DateTime"}, {Name: "service", Type: "String"}, {Name: "value", Type: "Float64"}},
+			values,
+		))
+
+	w := &recordingStreamWriter{}
+	req := newStreamRequest(qbtypes.RequestTypeTimeSeries, "SELECT ts, service, value FROM signoz_traces")
+	require.NoError(t, q.QueryRangeStream(context.Background(), valuer.GenerateUUID(), req, w))
+	require.NoError(t, telemetryStore.Mock().ExpectationsWereMet())
+
+	// the points are sent in two parts, then the end of the stream
+	require.Len(t, w.events, 3)
+	assert.Equal(t, qbtypes.StreamEventTypeEnd, w.events[2].Type)
+	assert.NotNil(t, w.events[2].Meta)
+
+	merged := map[string]int{}
+	for _, event := range w.events[:2] {
+		assert.Equal(t, qbtypes.StreamEventTypePart, event.Type)
+		assert.Equal(t, "A", event.QueryName)
+		assert.Empty(t, event.Buffered)
+
+		part, ok := event.Result.(*qbtypes.TimeSeriesData)
+		require.True(t, ok)
+		require.Len(t, part.Aggregations, 1)
+		for _, series := range part.Aggregations[0].Series {
+			merged[fmt.Sprint(series.Labels[0].Value)] += len(series.Values)
+		}
+	}
+	assert.Equal(t, map[string]int{"cart": points, "checkout": points, "frontend": points}, merged)
+}
+
+func TestQueryRangeStreamBuffered(t *testing.T) {
+	telemetryStore := telemetrystoretest.New(telemetrystore.Config{Provider: "clickhouse"}, sqlmock.QueryMatcherRegexp)
+	q := New(instrumentationtest.New().ToProviderSettings(), telemetryStore, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	telemetryStore.Mock().
+		ExpectQuery("SELECT service, count").
+		WillReturnRows(cmock.NewRows(
+			[]cmock.ColumnType{{Name: "service", Type: "String"}, {Name: "__result_0", Type: "UInt64"}},
+			[][]any{{"cart", uint64(10)}, {"checkout", uint64(4)}},
+		))
+
+	w := &recordingStreamWriter{}
+	req := newStreamRequest(qbtypes.RequestTypeScalar, "SELECT service, count() AS __result_0 FROM signoz_traces GROUP BY service")
+	req.FormatOptions = &qbtypes.FormatOptions{FormatTableResultForUI: true}
+	require.NoError(t, q.QueryRangeStream(context.Background(), valuer.GenerateUUID(), req, w))
+	require.NoError(t, telemetryStore.Mock().ExpectationsWereMet())
+
+	require.Len(t, w.events, 2)
+	assert.Equal(t, qbtypes.StreamEventTypeResult, w.events[0].Type)
+	assert.Equal(t, "A", w.events[0].QueryName)
+	assert.NotEmpty(t, w.events[0].Buffered)
+
+	result, ok := w.events[0].Result.(*qbtypes.ScalarData)
+	require.True(t, ok)
+	assert.Len(t, result.Data, 2)
+	assert.Equal(t, qbtypes.StreamEventTypeEnd, w.events[1].Type)
+}
+
+func TestBufferedRequestReason(t *testing.T) {
+	formula := newStreamRequest(qbtypes.RequestTypeTimeSeries, "SELECT 1")
+	formula.CompositeQuery.Queries = append(formula.CompositeQuery.Queries, qbtypes.QueryEnvelope{
+		Type: qbtypes.QueryTypeFormula,
+		Spec: qbtypes.QueryBuilderFormula{Name: "F1", Expression: "A * 2"},
+	})
+
+	fillGaps := newStreamRequest(qbtypes.RequestTypeTimeSeries, "SELECT 1")
+	fillGaps.FormatOptions = &qbtypes.FormatOptions{FillGaps: true}
+
+	testCases := []struct {
+		name     string
+		req      *qbtypes.QueryRangeRequest
+		buffered bool
+	}{
+		{name: "TimeSeries", req: newStreamRequest(qbtypes.RequestTypeTimeSeries, "SELECT 1"), buffered: false},
+		{name: "Scalar", req: newStreamRequest(qbtypes.RequestTypeScalar, "SELECT 1"), buffered: false},
+		{name: "Raw", req: newStreamRequest(qbtypes.RequestTypeRaw, "SELECT 1"), buffered: true},
+		{name: "Formula", req: formula, buffered: true},
+		{name: "FillGaps", req: fillGaps, buffered: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.buffered, bufferedRequestReason(tc.req) != "")
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package querier
+
+import (
+	"context"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/metrictypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+)
+
+// subQuery is a builder query referenced by the filter of another builder query as `key IN @name`. It is run as a
+// scalar query, and its statement is attached as a CTE to the statement of the referencing query.
+//
+// For metrics, the statement of the referenced query isn't reduced, so the referenced query can't have a limit or an
+// order, which is rejected by the validation of the request.
+type subQuery struct {
+	name    string
+	groupBy qbtypes.GroupByKey
+	build   func(ctx context.Context) (*qbtypes.Statement, error)
+}
+
+// newSubQueries creates the sub queries referenced by the filter, the referenced queries can in turn reference other
+// queries. The references are validated with the request, so they are known and acyclic.
+func (q *querier) newSubQueries(
+	filter *qbtypes.Filter,
+	specs map[string]any,
+	tr qbtypes.TimeRange,
+	variables map[string]qbtypes.VariableItem,
+	metricTemporality map[string]metrictypes.Temporality,
+) ([]*subQuery, error) {
+	if filter == nil {
+		return nil, nil
+	}
+
+	var subQueries []*subQuery
+	for _, name := range qbtypes.SubQueryRefs(filter.Expression) {
+		var (
+			sq  *subQuery
+			err error
+		)
+
+		switch spec := specs[name].(type) {
+		case qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]:
+			bq := newBuilderQuery(q.telemetryStore, q.traceStmtBuilder, spec, tr, qbtypes.RequestTypeScalar, variables)
+			if bq.subQueries, err = q.newSubQueries(spec.Filter, specs, tr, variables, metricTemporality); err != nil {
+				return nil, err
+			}
+			sq = newSubQuery(name, spec.GroupBy, bq)
+		case qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]:
+			bq := newBuilderQuery(q.telemetryStore, q.logStmtBuilder, spec, tr, qbtypes.RequestTypeScalar, variables)
+			if bq.subQueries, err = q.newSubQueries(spec.Filter, specs, tr, variables, metricTemporality); err != nil {
+				return nil, err
+			}
+			sq = newSubQuery(name, spec.GroupBy, bq)
+		case qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]:
+			spec = withMetricTemporality(spec, metricTemporality)
+			stmtBuilder := q.metricStmtBuilder
+			if spec.Source == telemetrytypes.SourceMeter {
+				stmtBuilder = q.meterStmtBuilder
+			}
+			bq := newBuilderQuery(q.telemetryStore, stmtBuilder, spec, tr, qbtypes.RequestTypeScalar, variables)
+			if bq.subQueries, err = q.newSubQueries(spec.Filter, specs, tr, variables, metricTemporality); err != nil {
+				return nil, err
+			}
+			sq = newSubQuery(name, spec.GroupBy, bq)
+		default:
+			return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "query '%s' referenced with `IN @%s` doesn't exist or is not a builder query", name, name)
+		}
+
+		subQueries = append(subQueries, sq)
+	}
+
+	return subQueries, nil
+}
+
+func newSubQuery[T any](name string, groupBy []qbtypes.GroupByKey, bq *builderQuery[T]) *subQuery {
+	return &subQuery{
+		name:    name,
+		groupBy: groupBy[0],
+		build: func(ctx context.Context) (*qbtypes.Statement, error) {
+			// the sub query always covers the whole time range of the request
+			return bq.buildStatement(ctx, bq.fromMS, bq.toMS)
+		},
+	}
+}
+
+// withMetricTemporality sets the temporality of the aggregations which don't have one
+func withMetricTemporality(spec qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation], metricTemporality map[string]metrictypes.Temporality) qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation] {
+	for i := range spec.Aggregations {
+		if spec.Aggregations[i].MetricName != "" && spec.Aggregations[i].Temporality == metrictypes.Unknown {
+			if temp, ok := metricTemporality[spec.Aggregations[i].MetricName]; ok && temp != metrictypes.Unknown {
+				spec.Aggregations[i].Temporality = temp
+			}
+		}
+		// TODO(srikanthccv): warn when the metric is missing
+		if spec.Aggregations[i].Temporality == metrictypes.Unknown {
+			spec.Aggregations[i].Temporality = metrictypes.Unspecified
+		}
+	}
+	return spec
+}
@@ -29,8 +29,8 @@ func (q *traceOperatorQuery) Window() (uint64, uint64) {
 	return q.fromMS, q.toMS
 }
 
-func (q *traceOperatorQuery) Execute(ctx context.Context) (*qbtypes.Result, error) {
-	stmt, err := q.stmtBuilder.Build(
+func (q *traceOperatorQuery) statement(ctx context.Context) (*qbtypes.Statement, error) {
+	return q.stmtBuilder.Build(
 		ctx,
 		q.fromMS,
 		q.toMS,
@@ -38,6 +38,10 @@ func (q *traceOperatorQuery) Execute(ctx context.Context) (*qbtypes.Result, erro
 		q.spec,
 		q.compositeQuery,
 	)
+}
+
+func (q *traceOperatorQuery) Execute(ctx context.Context) (*qbtypes.Result, error) {
+	stmt, err := q.statement(ctx)
 	if err != nil {
 		return nil, err
 	}
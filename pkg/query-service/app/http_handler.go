@@ -69,6 +69,7 @@ import (
 	"github.com/SigNoz/signoz/pkg/types/pipelinetypes"
 	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
 	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
 	traceFunnels "github.com/SigNoz/signoz/pkg/types/tracefunneltypes"
 
 	"go.uber.org/zap"
@@ -468,7 +469,11 @@ func (aH *APIHandler) RegisterQueryRangeV4Routes(router *mux.Router, am *middlew
 func (aH *APIHandler) RegisterQueryRangeV5Routes(router *mux.Router, am *middleware.AuthZ) {
 	subRouter := router.PathPrefix("/api/v5").Subrouter()
 	subRouter.HandleFunc("/query_range", am.ViewAccess(aH.QuerierAPI.QueryRange)).Methods(http.MethodPost)
+	subRouter.HandleFunc("/query_range/stream", am.ViewAccess(aH.QuerierAPI.QueryRangeStream)).Methods(http.MethodPost)
 	subRouter.HandleFunc("/substitute_vars", am.ViewAccess(aH.QuerierAPI.ReplaceVariables)).Methods(http.MethodPost)
+	subRouter.HandleFunc("/watermarks", am.EditAccess(aH.QuerierAPI.SetWatermark)).Methods(http.MethodPost)
+	subRouter.HandleFunc("/watermarks", am.ViewAccess(aH.QuerierAPI.ListWatermarks)).Methods(http.MethodGet)
+	subRouter.HandleFunc("/cache/purge", am.AdminAccess(aH.QuerierAPI.PurgeCache)).Methods(http.MethodPost)
 }
 
 // todo(remove): Implemented at render package (github.com/SigNoz/signoz/pkg/http/render) with the new error structure
@@ -493,7 +498,40 @@ func (aH *APIHandler) RegisterRoutes(router *mux.Router, am *middleware.AuthZ) {
 	router.HandleFunc("/api/v1/route_policies/{id}", am.AdminAccess(aH.AlertmanagerAPI.DeleteRoutePolicyByID)).Methods(http.MethodDelete)
 	router.HandleFunc("/api/v1/route_policies/{id}", am.AdminAccess(aH.AlertmanagerAPI.UpdateRoutePolicy)).Methods(http.MethodPut)
 
+	router.HandleFunc("/api/v1/escalation_policies", am.ViewAccess(aH.AlertmanagerAPI.GetAllEscalationPolicies)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/escalation_policies/{id}", am.ViewAccess(aH.AlertmanagerAPI.GetEscalationPolicyByID)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/escalation_policies", am.AdminAccess(aH.AlertmanagerAPI.CreateEscalationPolicy)).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/escalation_policies/{id}", am.AdminAccess(aH.AlertmanagerAPI.DeleteEscalationPolicyByID)).Methods(http.MethodDelete)
+	router.HandleFunc("/api/v1/escalation_policies/{id}", am.AdminAccess(aH.AlertmanagerAPI.UpdateEscalationPolicy)).Methods(http.MethodPut)
+
+	router.HandleFunc("/api/v1/oncall_schedules", am.ViewAccess(aH.Signoz.Handlers.OnCall.List)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/oncall_schedules", am.AdminAccess(aH.Signoz.Handlers.OnCall.Create)).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/oncall_schedules/{id}", am.ViewAccess(aH.Signoz.Handlers.OnCall.Get)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/oncall_schedules/{id}", am.AdminAccess(aH.Signoz.Handlers.OnCall.Update)).Methods(http.MethodPut)
+	router.HandleFunc("/api/v1/oncall_schedules/{id}", am.AdminAccess(aH.Signoz.Handlers.OnCall.Delete)).Methods(http.MethodDelete)
+	router.HandleFunc("/api/v1/oncall_schedules/{id}/oncall", am.ViewAccess(aH.Signoz.Handlers.OnCall.GetOnCall)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/oncall_schedules/{id}/overrides", am.EditAccess(aH.Signoz.Handlers.OnCall.CreateOverride)).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/oncall_schedules/{id}/overrides/{overrideId}", am.EditAccess(aH.Signoz.Handlers.OnCall.DeleteOverride)).Methods(http.MethodDelete)
+
 	router.HandleFunc("/api/v1/alerts", am.ViewAccess(aH.AlertmanagerAPI.GetAlerts)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/alerts/acknowledgements", am.ViewAccess(aH.AlertmanagerAPI.ListAcknowledgements)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/alerts/acknowledge", am.EditAccess(aH.AlertmanagerAPI.Acknowledge)).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/alerts/unacknowledge", am.EditAccess(aH.AlertmanagerAPI.Unacknowledge)).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/alerts/assign", am.EditAccess(aH.AlertmanagerAPI.Assign)).Methods(http.MethodPost)
+
+	router.HandleFunc("/api/v1/webhook_deliveries", am.ViewAccess(aH.AlertmanagerAPI.ListWebhookDeliveries)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/webhook_deliveries/{id}/resend", am.EditAccess(aH.AlertmanagerAPI.ResendWebhookDelivery)).Methods(http.MethodPost)
+
+	router.HandleFunc("/api/v1/digests", am.ViewAccess(aH.AlertmanagerAPI.ListDigests)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/digests", am.EditAccess(aH.AlertmanagerAPI.SetDigest)).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/digests/{id}", am.EditAccess(aH.AlertmanagerAPI.DeleteDigest)).Methods(http.MethodDelete)
+
+	router.HandleFunc("/api/v1/silences", am.ViewAccess(aH.AlertmanagerAPI.ListSilences)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/silences", am.EditAccess(aH.AlertmanagerAPI.CreateSilence)).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/silences/preview", am.ViewAccess(aH.AlertmanagerAPI.PreviewSilence)).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/silences/{id}", am.ViewAccess(aH.AlertmanagerAPI.GetSilence)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/silences/{id}", am.EditAccess(aH.AlertmanagerAPI.UpdateSilence)).Methods(http.MethodPut)
+	router.HandleFunc("/api/v1/silences/{id}", am.EditAccess(aH.AlertmanagerAPI.ExpireSilence)).Methods(http.MethodDelete)
 
 	router.HandleFunc("/api/v1/rules", am.ViewAccess(aH.listRules)).Methods(http.MethodGet)
 	router.HandleFunc("/api/v1/rules/{id}", am.ViewAccess(aH.getRule)).Methods(http.MethodGet)
@@ -502,10 +540,21 @@ func (aH *APIHandler) RegisterRoutes(router *mux.Router, am *middleware.AuthZ) {
 	router.HandleFunc("/api/v1/rules/{id}", am.EditAccess(aH.deleteRule)).Methods(http.MethodDelete)
 	router.HandleFunc("/api/v1/rules/{id}", am.EditAccess(aH.patchRule)).Methods(http.MethodPatch)
 	router.HandleFunc("/api/v1/testRule", am.EditAccess(aH.testRule)).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/backtestRule", am.EditAccess(aH.backtestRule)).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/backtestRule/{id}", am.EditAccess(aH.getBacktest)).Methods(http.MethodGet)
 	router.HandleFunc("/api/v1/rules/{id}/history/stats", am.ViewAccess(aH.getRuleStats)).Methods(http.MethodPost)
 	router.HandleFunc("/api/v1/rules/{id}/history/timeline", am.ViewAccess(aH.getRuleStateHistory)).Methods(http.MethodPost)
 	router.HandleFunc("/api/v1/rules/{id}/history/top_contributors", am.ViewAccess(aH.getRuleStateHistoryTopContributors)).Methods(http.MethodPost)
 	router.HandleFunc("/api/v1/rules/{id}/history/overall_status", am.ViewAccess(aH.getOverallStateTransitions)).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/rules/{id}/absent_groups", am.ViewAccess(aH.listAbsentGroups)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/rules/{id}/absent_groups", am.EditAccess(aH.retireAbsentGroups)).Methods(http.MethodDelete)
+
+	router.HandleFunc("/api/v1/slos", am.ViewAccess(aH.Signoz.Handlers.SLO.List)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/slos", am.EditAccess(aH.Signoz.Handlers.SLO.Create)).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/slos/{id}", am.ViewAccess(aH.Signoz.Handlers.SLO.Get)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/slos/{id}", am.EditAccess(aH.Signoz.Handlers.SLO.Update)).Methods(http.MethodPut)
+	router.HandleFunc("/api/v1/slos/{id}", am.EditAccess(aH.Signoz.Handlers.SLO.Delete)).Methods(http.MethodDelete)
+	router.HandleFunc("/api/v1/slos/{id}/status", am.ViewAccess(aH.Signoz.Handlers.SLO.GetStatus)).Methods(http.MethodGet)
 
 	router.HandleFunc("/api/v1/downtime_schedules", am.ViewAccess(aH.listDowntimeSchedules)).Methods(http.MethodGet)
 	router.HandleFunc("/api/v1/downtime_schedules/{id}", am.ViewAccess(aH.getDowntimeSchedule)).Methods(http.MethodGet)
@@ -575,9 +624,19 @@ func (aH *APIHandler) RegisterRoutes(router *mux.Router, am *middleware.AuthZ) {
 
 	// Export
 	router.HandleFunc("/api/v1/export_raw_data", am.ViewAccess(aH.Signoz.Handlers.RawDataExport.ExportRawData)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/export_jobs", am.EditAccess(aH.Signoz.Handlers.RawDataExport.CreateJob)).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/export_jobs", am.ViewAccess(aH.Signoz.Handlers.RawDataExport.ListJobs)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/export_jobs/{id}", am.ViewAccess(aH.Signoz.Handlers.RawDataExport.GetJob)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/export_jobs/{id}/cancel", am.EditAccess(aH.Signoz.Handlers.RawDataExport.CancelJob)).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/export_jobs/{id}/chunks/{index}", am.ViewAccess(aH.Signoz.Handlers.RawDataExport.GetJobChunk)).Methods(http.MethodGet)
 
 	router.HandleFunc("/api/v1/span_percentile", am.ViewAccess(aH.Signoz.Handlers.SpanPercentile.GetSpanPercentileDetails)).Methods(http.MethodPost)
 
+	router.HandleFunc("/api/v1/alert_analytics/timeline", am.ViewAccess(aH.Signoz.Handlers.AlertAnalytics.GetTimeline)).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/alert_analytics/noisiest", am.ViewAccess(aH.Signoz.Handlers.AlertAnalytics.GetNoisiest)).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/alert_analytics/response_times", am.ViewAccess(aH.Signoz.Handlers.AlertAnalytics.GetResponseTimes)).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/alert_analytics/flapping", am.ViewAccess(aH.Signoz.Handlers.AlertAnalytics.GetFlapping)).Methods(http.MethodPost)
+
 	// Query Filter Analyzer api used to extract metric names and grouping columns from a query
 	router.HandleFunc("/api/v1/query_filter/analyze", am.ViewAccess(aH.QueryParserAPI.AnalyzeQueryFilter)).Methods(http.MethodPost)
 }
@@ -644,6 +703,48 @@ func (aH *APIHandler) getRule(w http.ResponseWriter, r *http.Request) {
 	aH.Respond(w, ruleResponse)
 }
 
+func (aH *APIHandler) listAbsentGroups(w http.ResponseWriter, r *http.Request) {
+	idStr := mux.Vars(r)["id"]
+	id, err := valuer.NewUUID(idStr)
+	if err != nil {
+		RespondError(w, &model.ApiError{Typ: model.ErrorBadData, Err: err}, nil)
+		return
+	}
+
+	groups, err := aH.ruleManager.ListAbsentGroups(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			RespondError(w, &model.ApiError{Typ: model.ErrorNotFound, Err: fmt.Errorf("rule not found")}, nil)
+			return
+		}
+		RespondError(w, &model.ApiError{Typ: model.ErrorInternal, Err: err}, nil)
+		return
+	}
+	aH.Respond(w, groups)
+}
+
+// retireAbsentGroups retires the groups with the fingerprints given in the
+// query, or all of the groups of the rule if none is given.
+func (aH *APIHandler) retireAbsentGroups(w http.ResponseWriter, r *http.Request) {
+	idStr := mux.Vars(r)["id"]
+	id, err := valuer.NewUUID(idStr)
+	if err != nil {
+		RespondError(w, &model.ApiError{Typ: model.ErrorBadData, Err: err}, nil)
+		return
+	}
+
+	err = aH.ruleManager.RetireAbsentGroups(r.Context(), id, r.URL.Query()["fingerprint"])
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			RespondError(w, &model.ApiError{Typ: model.ErrorNotFound, Err: fmt.Errorf("rule not found")}, nil)
+			return
+		}
+		RespondError(w, &model.ApiError{Typ: model.ErrorInternal, Err: err}, nil)
+		return
+	}
+	aH.Respond(w, "absent groups successfully retired")
+}
+
 // populateTemporality adds the temporality to the query if it is not present
 func (aH *APIHandler) PopulateTemporality(ctx context.Context, orgID valuer.UUID, qp *v3.QueryRangeParamsV3) error {
 
@@ -1345,6 +1446,65 @@ func (aH *APIHandler) testRule(w http.ResponseWriter, r *http.Request) {
 	aH.Respond(w, response)
 }
 
+// backtestRule starts to replay the rule in the body over the given range in
+// the background, see getBacktest for its result.
+func (aH *APIHandler) backtestRule(w http.ResponseWriter, r *http.Request) {
+	claims, err := authtypes.ClaimsFromContext(r.Context())
+	if err != nil {
+		render.Error(w, err)
+		return
+	}
+	orgID, err := valuer.NewUUID(claims.OrgID)
+	if err != nil {
+		render.Error(w, err)
+		return
+	}
+
+	defer r.Body.Close()
+	postable := new(ruletypes.PostableBacktest)
+	if err := json.NewDecoder(r.Body).Decode(postable); err != nil {
+		render.Error(w, errors.WrapInvalidInputf(err, errors.CodeInvalidInput, "failed to decode the backtest"))
+		return
+	}
+
+	backtest, err := aH.ruleManager.Backtest(r.Context(), orgID, postable)
+	if err != nil {
+		render.Error(w, err)
+		return
+	}
+
+	render.Success(w, http.StatusAccepted, backtest)
+}
+
+// getBacktest returns a backtest along with the intervals the alerts of its
+// rule would have been firing in, once it succeeded.
+func (aH *APIHandler) getBacktest(w http.ResponseWriter, r *http.Request) {
+	claims, err := authtypes.ClaimsFromContext(r.Context())
+	if err != nil {
+		render.Error(w, err)
+		return
+	}
+	orgID, err := valuer.NewUUID(claims.OrgID)
+	if err != nil {
+		render.Error(w, err)
+		return
+	}
+
+	id, err := valuer.NewUUID(mux.Vars(r)["id"])
+	if err != nil {
+		render.Error(w, errors.WrapInvalidInputf(err, errors.CodeInvalidInput, "id is not a valid uuid"))
+		return
+	}
+
+	backtest, err := aH.ruleManager.GetBacktest(r.Context(), orgID, id)
+	if err != nil {
+		render.Error(w, err)
+		return
+	}
+
+	render.Success(w, http.StatusOK, backtest)
+}
+
 func (aH *APIHandler) deleteRule(w http.ResponseWriter, r *http.Request) {
 
 	id := mux.Vars(r)["id"]
@@ -1891,10 +2051,27 @@ func (aH *APIHandler) setTTL(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	aH.purgeQuerierCache(ctx, claims, ttlParams.Type, qbtypes.CachePurgeReasonRetention)
+
 	aH.WriteJSON(w, r, result)
 
 }
 
+// purgeQuerierCache purges the cached query results of a signal whose data changed with its settings, the error is
+// logged rather than failing the change of the settings
+func (aH *APIHandler) purgeQuerierCache(ctx context.Context, claims authtypes.Claims, signal string, reason qbtypes.CachePurgeReason) {
+	orgID, err := valuer.NewUUID(claims.OrgID)
+	if err != nil {
+		zap.L().Error("error purging the cached query results", zap.String("signal", signal), zap.Error(err))
+		return
+	}
+
+	postable := &qbtypes.PostableCachePurge{Signal: telemetrytypes.Signal{String: valuer.NewString(signal)}, Reason: reason}
+	if err := aH.Signoz.Querier.PurgeCache(ctx, orgID, claims.Email, postable); err != nil {
+		zap.L().Error("error purging the cached query results", zap.String("signal", signal), zap.Error(err))
+	}
+}
+
 func (aH *APIHandler) setCustomRetentionTTL(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	claims, errv2 := authtypes.ClaimsFromContext(ctx)
@@ -1916,6 +2093,8 @@ func (aH *APIHandler) setCustomRetentionTTL(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	aH.purgeQuerierCache(ctx, claims, params.Type, qbtypes.CachePurgeReasonRetention)
+
 	aH.WriteJSON(w, r, result)
 }
 
@@ -4217,6 +4396,8 @@ func (aH *APIHandler) CreateLogsPipeline(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	aH.purgeQuerierCache(r.Context(), claims, telemetrytypes.SignalLogs.StringValue(), qbtypes.CachePurgeReasonPipelines)
+
 	aH.Respond(w, res)
 }
 
@@ -11,6 +11,7 @@ import (
 	"github.com/SigNoz/signoz/pkg/cache/memorycache"
 	"github.com/SigNoz/signoz/pkg/factory"
 	"github.com/SigNoz/signoz/pkg/queryparser"
+	"github.com/SigNoz/signoz/pkg/ruler/rulestore/clickhouserulestore"
 	"github.com/SigNoz/signoz/pkg/ruler/rulestore/sqlrulestore"
 	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
 
@@ -32,6 +33,7 @@ import (
 	"github.com/SigNoz/signoz/pkg/query-service/app/opamp"
 	opAmpModel "github.com/SigNoz/signoz/pkg/query-service/app/opamp/model"
 	"github.com/SigNoz/signoz/pkg/query-service/interfaces"
+	"github.com/SigNoz/signoz/pkg/sharder"
 	"github.com/SigNoz/signoz/pkg/signoz"
 	"github.com/SigNoz/signoz/pkg/sqlstore"
 	"github.com/SigNoz/signoz/pkg/telemetrystore"
@@ -110,11 +112,14 @@ func NewServer(config signoz.Config, signoz *signoz.SigNoz) (*Server, error) {
 		signoz.Querier,
 		signoz.Instrumentation.ToProviderSettings(),
 		signoz.QueryParser,
+		signoz.Sharder,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	signoz.Modules.SLO.SetRuleManager(rm)
+
 	logParsingPipelineController, err := logparsingpipeline.NewLogParsingPipelinesController(
 		signoz.SQLStore,
 		integrationsController.GetPipelinesForInstalledIntegrations,
@@ -342,9 +347,11 @@ func makeRulesManager(
 	querier querier.Querier,
 	providerSettings factory.ProviderSettings,
 	queryParser queryparser.QueryParser,
+	sharder sharder.Sharder,
 ) (*rules.Manager, error) {
 	ruleStore := sqlrulestore.NewRuleStore(sqlstore, queryParser, providerSettings)
 	maintenanceStore := sqlrulestore.NewMaintenanceStore(sqlstore)
+	absentGroupStore := sqlrulestore.NewAbsentGroupStore(sqlstore)
 	// create manager opts
 	managerOpts := &rules.ManagerOptions{
 		TelemetryStore:   telemetryStore,
@@ -361,8 +368,11 @@ func makeRulesManager(
 		Alertmanager:     alertmanager,
 		RuleStore:        ruleStore,
 		MaintenanceStore: maintenanceStore,
+		AbsentGroupStore: absentGroupStore,
 		SqlStore:         sqlstore,
 		QueryParser:      queryParser,
+		RecordingStore:   clickhouserulestore.NewRecordingStore(telemetryStore),
+		Sharder:          sharder,
 	}
 
 	// create Manager
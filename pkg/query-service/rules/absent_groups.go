@@ -0,0 +1,164 @@
+package rules
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/query-service/constants"
+	v3 "github.com/SigNoz/signoz/pkg/query-service/model/v3"
+	"github.com/SigNoz/signoz/pkg/query-service/utils/labels"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// absentGroupsSyncInterval is how often the last time a group was seen is
+// written to the store while the group keeps reporting data.
+const absentGroupsSyncInterval = 5 * time.Minute
+
+// absentGroup is a group tracked by the rule and the last time it was synced to the store.
+type absentGroup struct {
+	group    *ruletypes.AbsentGroup
+	syncedAt time.Time
+}
+
+func WithAbsentGroupStore(store ruletypes.AbsentGroupStore) RuleOption {
+	return func(r *BaseRule) {
+		r.absentGroupStore = store
+	}
+}
+
+// HandleAbsentGroups tracks the groups of the series returned at ts and returns
+// a missing data sample for every group which was seen within the lookback but
+// hasn't reported data for the absent duration of the rule. Groups which are
+// not seen for longer than the lookback are retired.
+func (r *BaseRule) HandleAbsentGroups(ctx context.Context, ts time.Time, series []*v3.Series) ruletypes.Vector {
+	condition := r.ruleCondition.AbsentGroups
+	if !condition.IsEnabled() {
+		return nil
+	}
+
+	r.absentGroupsMtx.Lock()
+	defer r.absentGroupsMtx.Unlock()
+
+	r.loadAbsentGroups(ctx)
+
+	seen := make(map[string]struct{}, len(series))
+	synced := make([]*ruletypes.AbsentGroup, 0)
+	for _, s := range series {
+		lbls := labels.NewBuilder(labels.FromMap(s.Labels)).Del(labels.MetricNameLabel).Del(labels.TemporalityLabel).Labels()
+		// a series without labels is the whole rule, which is covered by the missing data alert
+		if len(lbls) == 0 {
+			continue
+		}
+
+		fingerprint := strconv.FormatUint(lbls.Hash(), 10)
+		seen[fingerprint] = struct{}{}
+
+		tracked, ok := r.absentGroups[fingerprint]
+		if !ok {
+			tracked = &absentGroup{group: &ruletypes.AbsentGroup{
+				Identifiable: types.Identifiable{ID: valuer.GenerateUUID()},
+				OrgID:        r.orgID.StringValue(),
+				RuleID:       r.ID(),
+				Fingerprint:  fingerprint,
+			}}
+			r.absentGroups[fingerprint] = tracked
+		}
+		tracked.group.Labels = lbls.Map()
+		tracked.group.LastSeen = ts
+
+		if ts.Sub(tracked.syncedAt) >= absentGroupsSyncInterval {
+			tracked.syncedAt = ts
+			synced = append(synced, tracked.group)
+		}
+	}
+
+	if r.absentGroupStore != nil && len(synced) > 0 {
+		if err := r.absentGroupStore.UpsertAbsentGroups(ctx, synced); err != nil {
+			r.logger.ErrorContext(ctx, "failed to store the absent groups", "rule_id", r.ID(), "error", err)
+		}
+	}
+
+	var resultVector ruletypes.Vector
+	retired := make([]string, 0)
+	for fingerprint, tracked := range r.absentGroups {
+		if _, ok := seen[fingerprint]; ok {
+			continue
+		}
+
+		absentFor := ts.Sub(tracked.group.LastSeen)
+		if absentFor > condition.GetLookback() {
+			retired = append(retired, fingerprint)
+			delete(r.absentGroups, fingerprint)
+			continue
+		}
+
+		if absentFor < condition.For.Duration() {
+			continue
+		}
+
+		lb := labels.NewBuilder(labels.FromMap(tracked.group.Labels))
+		lb.Set(ruletypes.LabelLastSeen, tracked.group.LastSeen.Format(constants.AlertTimeFormat))
+		resultVector = append(resultVector, ruletypes.Sample{Metric: lb.Labels(), IsMissing: true})
+	}
+
+	if r.absentGroupStore != nil && len(retired) > 0 {
+		if err := r.absentGroupStore.DeleteAbsentGroups(ctx, r.ID(), retired); err != nil {
+			r.logger.ErrorContext(ctx, "failed to retire the absent groups", "rule_id", r.ID(), "error", err)
+		}
+	}
+
+	if len(resultVector) > 0 {
+		r.logger.InfoContext(ctx, "groups absent for rule condition", "rule_id", r.ID(), "groups_count", len(resultVector))
+	}
+
+	sort.Slice(resultVector, func(i, j int) bool {
+		return labels.Compare(resultVector[i].Metric, resultVector[j].Metric) < 0
+	})
+
+	return resultVector
+}
+
+// ForgetAbsentGroups stops tracking the groups with the given fingerprints, or
+// all of the groups of the rule if none is given.
+func (r *BaseRule) ForgetAbsentGroups(fingerprints []string) {
+	r.absentGroupsMtx.Lock()
+	defer r.absentGroupsMtx.Unlock()
+
+	if len(fingerprints) == 0 {
+		r.absentGroups = nil
+		r.absentGroupsLoaded = false
+		return
+	}
+
+	for _, fingerprint := range fingerprints {
+		delete(r.absentGroups, fingerprint)
+	}
+}
+
+// loadAbsentGroups loads the groups tracked before the rule was (re)created,
+// it is called with the lock held.
+func (r *BaseRule) loadAbsentGroups(ctx context.Context) {
+	if r.absentGroupsLoaded {
+		return
+	}
+
+	r.absentGroups = map[string]*absentGroup{}
+	if r.absentGroupStore != nil {
+		groups, err := r.absentGroupStore.ListAbsentGroups(ctx, r.ID())
+		if err != nil {
+			// the groups are loaded on the next evaluation
+			r.logger.ErrorContext(ctx, "failed to load the absent groups", "rule_id", r.ID(), "error", err)
+			return
+		}
+
+		for _, group := range groups {
+			r.absentGroups[group.Fingerprint] = &absentGroup{group: group, syncedAt: group.LastSeen}
+		}
+	}
+
+	r.absentGroupsLoaded = true
+}
@@ -0,0 +1,120 @@
+package rules
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
+	v3 "github.com/SigNoz/signoz/pkg/query-service/model/v3"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryAbsentGroupStore struct {
+	groups map[string]*ruletypes.AbsentGroup
+}
+
+func (s *memoryAbsentGroupStore) ListAbsentGroups(_ context.Context, ruleID string) ([]*ruletypes.AbsentGroup, error) {
+	groups := make([]*ruletypes.AbsentGroup, 0)
+	for _, group := range s.groups {
+		if group.RuleID == ruleID {
+			stored := *group
+			groups = append(groups, &stored)
+		}
+	}
+	return groups, nil
+}
+
+func (s *memoryAbsentGroupStore) UpsertAbsentGroups(_ context.Context, groups []*ruletypes.AbsentGroup) error {
+	for _, group := range groups {
+		stored := *group
+		s.groups[group.Fingerprint] = &stored
+	}
+	return nil
+}
+
+func (s *memoryAbsentGroupStore) DeleteAbsentGroups(_ context.Context, ruleID string, fingerprints []string) error {
+	for fingerprint, group := range s.groups {
+		if group.RuleID != ruleID {
+			continue
+		}
+		if len(fingerprints) == 0 {
+			delete(s.groups, fingerprint)
+		}
+		for _, f := range fingerprints {
+			if f == fingerprint {
+				delete(s.groups, fingerprint)
+			}
+		}
+	}
+	return nil
+}
+
+func newTestAbsentGroupsRule(t *testing.T, store ruletypes.AbsentGroupStore) *BaseRule {
+	t.Helper()
+
+	postableRule := createPostableRule(&v3.CompositeQuery{
+		QueryType:   v3.QueryTypePromQL,
+		PromQueries: map[string]*v3.PromQuery{"A": {Query: "sum by (service_name) (rate(calls_total[5m]))"}},
+	})
+	postableRule.RuleCondition.AbsentGroups = &ruletypes.AbsentGroupsCondition{
+		Enabled:  true,
+		For:      valuer.MustParseTextDuration("5m"),
+		Lookback: valuer.MustParseTextDuration("1h"),
+	}
+
+	rule, err := NewBaseRule(valuer.GenerateUUID().StringValue(), valuer.GenerateUUID(), &postableRule, nil, WithLogger(instrumentationtest.New().Logger()), WithAbsentGroupStore(store))
+	require.NoError(t, err)
+	return rule
+}
+
+func TestBaseRule_HandleAbsentGroups(t *testing.T) {
+	store := &memoryAbsentGroupStore{groups: map[string]*ruletypes.AbsentGroup{}}
+	rule := newTestAbsentGroupsRule(t, store)
+
+	frontend := createTestSeries(map[string]string{"service_name": "frontend"}, nil)
+	checkout := createTestSeries(map[string]string{"service_name": "checkout"}, nil)
+	ts := time.Unix(1700000000, 0)
+
+	assert.Empty(t, rule.HandleAbsentGroups(t.Context(), ts, []*v3.Series{frontend, checkout}))
+	assert.Len(t, store.groups, 2)
+
+	// checkout stops reporting, it isn't absent for long enough yet
+	assert.Empty(t, rule.HandleAbsentGroups(t.Context(), ts.Add(3*time.Minute), []*v3.Series{frontend}))
+
+	absent := rule.HandleAbsentGroups(t.Context(), ts.Add(5*time.Minute), []*v3.Series{frontend})
+	require.Len(t, absent, 1)
+	assert.True(t, absent[0].IsMissing)
+	assert.Equal(t, "checkout", absent[0].Metric.Get("service_name"))
+	assert.NotEmpty(t, absent[0].Metric.Get(ruletypes.LabelLastSeen))
+
+	// the tracked groups survive the rule being recreated
+	restarted := newTestAbsentGroupsRule(t, store)
+	restarted.id = rule.ID()
+	absent = restarted.HandleAbsentGroups(t.Context(), ts.Add(6*time.Minute), []*v3.Series{frontend})
+	require.Len(t, absent, 1)
+	assert.Equal(t, "checkout", absent[0].Metric.Get("service_name"))
+
+	// checkout is retired once it is gone for longer than the lookback
+	absent = restarted.HandleAbsentGroups(t.Context(), ts.Add(61*time.Minute), []*v3.Series{frontend})
+	assert.Empty(t, absent)
+	assert.Len(t, store.groups, 1)
+}
+
+func TestBaseRule_ForgetAbsentGroups(t *testing.T) {
+	store := &memoryAbsentGroupStore{groups: map[string]*ruletypes.AbsentGroup{}}
+	rule := newTestAbsentGroupsRule(t, store)
+
+	checkout := createTestSeries(map[string]string{"service_name": "checkout"}, nil)
+	ts := time.Unix(1700000000, 0)
+
+	assert.Empty(t, rule.HandleAbsentGroups(t.Context(), ts, []*v3.Series{checkout}))
+	require.Len(t, rule.HandleAbsentGroups(t.Context(), ts.Add(10*time.Minute), nil), 1)
+
+	require.NoError(t, store.DeleteAbsentGroups(t.Context(), rule.ID(), nil))
+	rule.ForgetAbsentGroups(nil)
+	assert.Empty(t, rule.HandleAbsentGroups(t.Context(), ts.Add(11*time.Minute), nil))
+}
@@ -0,0 +1,176 @@
+package rules
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	ruletypes "github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// BacktestRuleOptions returns the options of a rule which is replayed by a
+// backtest, the rule neither records its state history nor tracks its absent
+// groups.
+func BacktestRuleOptions(opts PrepareTestRuleOptions) []RuleOption {
+	return []RuleOption{
+		WithoutStateHistory(),
+		WithSQLStore(opts.SQLStore),
+		WithQueryParser(opts.ManagerOpts.QueryParser),
+		WithMetadataStore(opts.ManagerOpts.MetadataStore),
+	}
+}
+
+// DefaultBacktestRule prepares the threshold or promql rule which is replayed
+// by a backtest.
+func DefaultBacktestRule(opts PrepareTestRuleOptions) (Rule, error) {
+	if opts.Rule == nil {
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "rule is required")
+	}
+
+	id := valuer.GenerateUUID().StringValue()
+
+	switch opts.Rule.RuleType {
+	case ruletypes.RuleTypeThreshold:
+		return NewThresholdRule(
+			id,
+			opts.OrgID,
+			opts.Rule,
+			opts.Reader,
+			opts.Querier,
+			opts.SLogger,
+			append(BacktestRuleOptions(opts), WithEvalDelay(opts.ManagerOpts.EvalDelay))...,
+		)
+	case ruletypes.RuleTypeProm:
+		return NewPromRule(
+			id,
+			opts.OrgID,
+			opts.Rule,
+			opts.SLogger,
+			opts.Reader,
+			opts.ManagerOpts.Prometheus,
+			BacktestRuleOptions(opts)...,
+		)
+	default:
+		return nil, errors.NewInvalidInputf(errors.CodeUnsupported, "backtest is not supported for rules of type %s", opts.Rule.RuleType)
+	}
+}
+
+// replay evaluates the rule at every timestamp of the backtest, in the same
+// way as its task would, and records the alerts after every evaluation.
+func replay(ctx context.Context, rule Rule, backtest *ruletypes.Backtest) (*ruletypes.GettableBacktest, error) {
+	for _, ts := range backtest.Timestamps() {
+		if err := ctx.Err(); err != nil {
+			return nil, errors.WrapTimeoutf(err, errors.CodeTimeout, "backtest was cancelled at %s", ts)
+		}
+
+		if _, err := rule.Eval(ctx, ts); err != nil {
+			return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to evaluate the rule at %s", ts)
+		}
+
+		backtest.Observe(ts, rule.ActiveAlerts())
+	}
+
+	return backtest.Gettable(), nil
+}
+
+type backtestJob struct {
+	orgID    valuer.UUID
+	gettable ruletypes.GettableBacktestJob
+}
+
+// backtests replays the rules of the backtests in the background, the
+// finished backtests are kept for BacktestRetention.
+type backtests struct {
+	mtx    sync.Mutex
+	jobs   map[valuer.UUID]*backtestJob
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func newBacktests() *backtests {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &backtests{
+		jobs:   map[valuer.UUID]*backtestJob{},
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// start replays the rule in the background and returns the running backtest.
+func (b *backtests) start(orgID valuer.UUID, rule Rule, backtest *ruletypes.Backtest, now time.Time) (*ruletypes.GettableBacktestJob, error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	running := 0
+	for id, job := range b.jobs {
+		if job.gettable.Status == ruletypes.BacktestStatusRunning {
+			running++
+			continue
+		}
+
+		if now.Sub(job.gettable.UpdatedAt) > ruletypes.BacktestRetention {
+			delete(b.jobs, id)
+		}
+	}
+
+	if running >= ruletypes.MaxRunningBacktests {
+		return nil, errors.Newf(errors.TypeTooManyRequests, ruletypes.ErrCodeTooManyBacktests, "%d backtests are already running, try again once one of them finishes", running)
+	}
+
+	job := &backtestJob{
+		orgID: orgID,
+		gettable: ruletypes.GettableBacktestJob{
+			ID:        valuer.GenerateUUID(),
+			Status:    ruletypes.BacktestStatusRunning,
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+	}
+	b.jobs[job.gettable.ID] = job
+
+	go b.run(job, rule, backtest)
+
+	gettable := job.gettable
+	return &gettable, nil
+}
+
+func (b *backtests) run(job *backtestJob, rule Rule, backtest *ruletypes.Backtest) {
+	ctx, cancel := context.WithTimeout(b.ctx, ruletypes.BacktestTimeout)
+	defer cancel()
+
+	result, err := replay(ctx, rule, backtest)
+
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	job.gettable.UpdatedAt = time.Now()
+	if err != nil {
+		job.gettable.Status = ruletypes.BacktestStatusFailed
+		job.gettable.Error = err.Error()
+		return
+	}
+
+	job.gettable.Status = ruletypes.BacktestStatusSucceeded
+	job.gettable.Result = result
+}
+
+func (b *backtests) get(orgID valuer.UUID, id valuer.UUID) (*ruletypes.GettableBacktestJob, error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	job, ok := b.jobs[id]
+	if !ok || job.orgID != orgID {
+		return nil, errors.Newf(errors.TypeNotFound, ruletypes.ErrCodeBacktestNotFound, "backtest with id %s doesn't exist", id.StringValue())
+	}
+
+	gettable := job.gettable
+	return &gettable, nil
+}
+
+// stop cancels the running backtests.
+func (b *backtests) stop() {
+	b.cancel()
+}
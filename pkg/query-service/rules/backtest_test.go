@@ -0,0 +1,205 @@
+package rules
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/cache"
+	"github.com/SigNoz/signoz/pkg/cache/cachetest"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
+	"github.com/SigNoz/signoz/pkg/prometheus"
+	"github.com/SigNoz/signoz/pkg/prometheus/prometheustest"
+	"github.com/SigNoz/signoz/pkg/query-service/app/clickhouseReader"
+	v3 "github.com/SigNoz/signoz/pkg/query-service/model/v3"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/telemetrystore/telemetrystoretest"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	cmock "github.com/srikanthccv/ClickHouse-go-mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThresholdRuleBacktest(t *testing.T) {
+	target := 10.0
+	postable := &ruletypes.PostableBacktest{
+		Rule: ruletypes.PostableRule{
+			AlertName: "Backtest",
+			AlertType: ruletypes.AlertTypeMetric,
+			RuleType:  ruletypes.RuleTypeThreshold,
+			Evaluation: &ruletypes.EvaluationEnvelope{Kind: ruletypes.RollingEvaluation, Spec: ruletypes.RollingWindow{
+				EvalWindow: valuer.MustParseTextDuration("5m"),
+				Frequency:  valuer.MustParseTextDuration("1m"),
+			}},
+			RuleCondition: &ruletypes.RuleCondition{
+				CompositeQuery: &v3.CompositeQuery{
+					QueryType: v3.QueryTypeBuilder,
+					BuilderQueries: map[string]*v3.BuilderQuery{
+						"A": {
+							QueryName:    "A",
+							StepInterval: 60,
+							AggregateAttribute: v3.AttributeKey{
+								Key: "signoz_calls_total",
+							},
+							AggregateOperator: v3.AggregateOperatorSumRate,
+							DataSource:        v3.DataSourceMetrics,
+							Expression:        "A",
+						},
+					},
+				},
+				Thresholds: &ruletypes.RuleThresholdData{
+					Kind: ruletypes.BasicThresholdKind,
+					Spec: ruletypes.BasicRuleThresholds{
+						{
+							Name:        "critical",
+							TargetValue: &target,
+							MatchType:   ruletypes.AtleastOnce,
+							CompareOp:   ruletypes.ValueIsAbove,
+						},
+					},
+				},
+			},
+			NotificationSettings: &ruletypes.NotificationSettings{},
+		},
+		Start: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2025, 3, 1, 0, 4, 0, 0, time.UTC),
+	}
+
+	telemetryStore := telemetrystoretest.New(telemetrystore.Config{}, &queryMatcherAny{})
+	cols := []cmock.ColumnType{
+		{Name: "value", Type: "Float64"},
+		{Name: "attr", Type: "String"},
+		{Name: "timestamp", Type: "DateTime"},
+	}
+
+	// the rule fires at the first and the fourth evaluation and resolves at the third and the fifth one
+	for i, value := range []float64{20, 20, 5, 20, 5} {
+		ts := postable.Start.Add(time.Duration(i) * time.Minute)
+		telemetryStore.Mock().
+			ExpectQuery("SELECT any").
+			WillReturnRows(cmock.NewRows(cols, [][]interface{}{{value, "attr", ts}}))
+	}
+
+	readerCache, err := cachetest.New(cache.Config{Provider: "memory", Memory: cache.Memory{NumCounters: 10 * 1000, MaxCost: 1 << 26}})
+	require.NoError(t, err)
+	options := clickhouseReader.NewOptions("", "", "archiveNamespace")
+	reader := clickhouseReader.NewReader(nil, telemetryStore, prometheustest.New(context.Background(), instrumentationtest.New().ToProviderSettings(), prometheus.Config{}, telemetryStore), "", time.Second, nil, readerCache, options)
+
+	backtest, err := ruletypes.NewBacktest(postable)
+	require.NoError(t, err)
+
+	rule, err := DefaultBacktestRule(PrepareTestRuleOptions{
+		Rule:        &postable.Rule,
+		Reader:      reader,
+		SLogger:     instrumentationtest.New().Logger(),
+		ManagerOpts: &ManagerOptions{},
+		OrgID:       valuer.GenerateUUID(),
+	})
+	require.NoError(t, err)
+	rule.(*ThresholdRule).TemporalityMap = map[string]map[v3.Temporality]bool{
+		"signoz_calls_total": {
+			v3.Delta: true,
+		},
+	}
+
+	gettable, err := replay(context.Background(), rule, backtest)
+	require.NoError(t, err)
+	require.NoError(t, telemetryStore.Mock().ExpectationsWereMet())
+
+	assert.Equal(t, 5, gettable.Evaluations)
+	assert.Equal(t, 4, gettable.Notifications)
+	require.Len(t, gettable.Series, 1)
+	assert.Equal(t, "attr", gettable.Series[0].Labels["attr"])
+	require.Len(t, gettable.Series[0].Intervals, 2)
+	assert.Equal(t, postable.Start, gettable.Series[0].Intervals[0].FiredAt)
+	assert.Equal(t, postable.Start.Add(2*time.Minute), gettable.Series[0].Intervals[0].ResolvedAt)
+	assert.Equal(t, postable.Start.Add(3*time.Minute), gettable.Series[0].Intervals[1].FiredAt)
+	assert.Equal(t, postable.Start.Add(4*time.Minute), gettable.Series[0].Intervals[1].ResolvedAt)
+}
+
+// blockingRule blocks its evaluations until it is released, the other methods
+// are not used by a backtest.
+type blockingRule struct {
+	Rule
+	release chan struct{}
+}
+
+func (r *blockingRule) Eval(ctx context.Context, _ time.Time) (int, error) {
+	select {
+	case <-r.release:
+		return 0, nil
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+}
+
+func (r *blockingRule) ActiveAlerts() []*ruletypes.Alert {
+	return nil
+}
+
+func TestBacktests(t *testing.T) {
+	postable := &ruletypes.PostableBacktest{
+		Rule:  ruletypes.PostableRule{Frequency: valuer.MustParseTextDuration("1m")},
+		Start: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2025, 3, 1, 0, 4, 0, 0, time.UTC),
+	}
+
+	backtests := newBacktests()
+	defer backtests.stop()
+
+	orgID := valuer.GenerateUUID()
+	rules := make([]*blockingRule, 0, ruletypes.MaxRunningBacktests)
+	jobs := make([]*ruletypes.GettableBacktestJob, 0, ruletypes.MaxRunningBacktests)
+	for i := 0; i < ruletypes.MaxRunningBacktests; i++ {
+		backtest, err := ruletypes.NewBacktest(postable)
+		require.NoError(t, err)
+
+		rule := &blockingRule{release: make(chan struct{})}
+		job, err := backtests.start(orgID, rule, backtest, time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, ruletypes.BacktestStatusRunning, job.Status)
+
+		rules = append(rules, rule)
+		jobs = append(jobs, job)
+	}
+
+	backtest, err := ruletypes.NewBacktest(postable)
+	require.NoError(t, err)
+	_, err = backtests.start(orgID, &blockingRule{release: make(chan struct{})}, backtest, time.Now())
+	assert.True(t, errors.Ast(err, errors.TypeTooManyRequests))
+
+	// the backtests of another org are not found
+	_, err = backtests.get(valuer.GenerateUUID(), jobs[0].ID)
+	assert.True(t, errors.Ast(err, errors.TypeNotFound))
+
+	close(rules[0].release)
+	require.Eventually(t, func() bool {
+		job, err := backtests.get(orgID, jobs[0].ID)
+		require.NoError(t, err)
+		return job.Status == ruletypes.BacktestStatusSucceeded
+	}, 5*time.Second, 10*time.Millisecond)
+
+	job, err := backtests.get(orgID, jobs[0].ID)
+	require.NoError(t, err)
+	require.NotNil(t, job.Result)
+	assert.Equal(t, 5, job.Result.Evaluations)
+
+	// the finished backtest expires once another one starts
+	backtest, err = ruletypes.NewBacktest(postable)
+	require.NoError(t, err)
+	_, err = backtests.start(orgID, &blockingRule{release: make(chan struct{})}, backtest, time.Now().Add(2*ruletypes.BacktestRetention))
+	require.NoError(t, err)
+
+	_, err = backtests.get(orgID, jobs[0].ID)
+	assert.True(t, errors.Ast(err, errors.TypeNotFound))
+
+	// the running backtests fail once they are stopped
+	backtests.stop()
+	require.Eventually(t, func() bool {
+		job, err := backtests.get(orgID, jobs[1].ID)
+		require.NoError(t, err)
+		return job.Status == ruletypes.BacktestStatusFailed
+	}, 5*time.Second, 10*time.Millisecond)
+}
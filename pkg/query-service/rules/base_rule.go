@@ -2,6 +2,7 @@ package rules
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"sync"
@@ -81,6 +82,10 @@ type BaseRule struct {
 	// sendAlways will send alert irrespective of resendDelay or other params
 	sendAlways bool
 
+	// skipStateHistory doesn't record the state changes of the rule, this is
+	// used when the rule is replayed over historical data.
+	skipStateHistory bool
+
 	// TemporalityMap is a map of metric name to temporality to avoid fetching
 	// temporality for the same metric multiple times.
 	// Querying the v4 table on low cardinal temporality column should be fast,
@@ -97,6 +102,13 @@ type BaseRule struct {
 	newGroupEvalDelay valuer.TextDuration
 
 	queryParser queryparser.QueryParser
+
+	// absentGroups are the groups seen within the lookback of the absent
+	// groups condition, keyed by the fingerprint of their labels
+	absentGroups       map[string]*absentGroup
+	absentGroupsLoaded bool
+	absentGroupsMtx    sync.Mutex
+	absentGroupStore   ruletypes.AbsentGroupStore
 }
 
 type RuleOption func(*BaseRule)
@@ -113,6 +125,12 @@ func WithSendUnmatched() RuleOption {
 	}
 }
 
+func WithoutStateHistory() RuleOption {
+	return func(r *BaseRule) {
+		r.skipStateHistory = true
+	}
+}
+
 func WithEvalDelay(dur valuer.TextDuration) RuleOption {
 	return func(r *BaseRule) {
 		r.evalDelay = dur
@@ -339,7 +357,16 @@ func (r *BaseRule) GetEvaluationTimestamp() time.Time {
 	return r.evaluationTimestamp
 }
 
+// State returns the highest state of the active alerts of the rule. It is safe
+// to call from other goroutines than the one evaluating the rule.
 func (r *BaseRule) State() model.AlertState {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.state()
+}
+
+// state must be called with r.mtx held
+func (r *BaseRule) state() model.AlertState {
 	maxState := model.StateInactive
 	for _, a := range r.Active {
 		if a.State > maxState {
@@ -349,6 +376,133 @@ func (r *BaseRule) State() model.AlertState {
 	return maxState
 }
 
+// UpdateActiveAlerts merges the alerts found at ts, keyed by the hash of their
+// labels, into the active alerts of the rule and moves every active alert
+// through its states: the alerts no longer found are resolved, the pending
+// ones fire once they were active for the hold duration and the firing ones
+// move to and from recovering. The state changes are recorded in the state
+// history and the number of active alerts is returned.
+func (r *BaseRule) UpdateActiveAlerts(ctx context.Context, ts time.Time, alerts map[uint64]*ruletypes.Alert) int {
+	r.mtx.Lock()
+
+	prevState := r.state()
+
+	for h, a := range alerts {
+		// Check whether we already have alerting state for the identifying label set.
+		// Update the last value and annotations if so, create a new alert entry otherwise.
+		if alert, ok := r.Active[h]; ok && alert.State != model.StateInactive {
+			alert.Value = a.Value
+			alert.Annotations = a.Annotations
+			// Update the recovering and missing state of existing alert
+			alert.IsRecovering = a.IsRecovering
+			alert.Missing = a.Missing
+			if _, ok := alert.Labels.Map()[ruletypes.LabelThresholdName]; ok {
+				alert.Receivers = a.Receivers
+			}
+			continue
+		}
+
+		r.Active[h] = a
+	}
+
+	itemsToAdd := []model.RuleStateHistory{}
+
+	// Check if any pending alerts should be removed or fire now.
+	for fp, a := range r.Active {
+		labelsJSON, err := json.Marshal(a.QueryResultLables)
+		if err != nil {
+			r.logger.ErrorContext(ctx, "error marshaling labels", "error", err, "labels", a.Labels)
+		}
+		if _, ok := alerts[fp]; !ok {
+			// If the alert was previously firing, keep it around for a given
+			// retention time so it is reported as resolved to the AlertManager.
+			if a.State == model.StatePending || (!a.ResolvedAt.IsZero() && ts.Sub(a.ResolvedAt) > ruletypes.ResolvedRetention) {
+				delete(r.Active, fp)
+			}
+			if a.State != model.StateInactive {
+				r.logger.DebugContext(ctx, "converting firing alert to inActive", "name", r.Name())
+				a.State = model.StateInactive
+				a.ResolvedAt = ts
+				itemsToAdd = append(itemsToAdd, model.RuleStateHistory{
+					RuleID:       r.ID(),
+					RuleName:     r.Name(),
+					State:        model.StateInactive,
+					StateChanged: true,
+					UnixMilli:    ts.UnixMilli(),
+					Labels:       model.LabelsString(labelsJSON),
+					Fingerprint:  a.QueryResultLables.Hash(),
+					Value:        a.Value,
+				})
+			}
+			continue
+		}
+
+		if a.State == model.StatePending && ts.Sub(a.ActiveAt) >= r.holdDuration.Duration() {
+			r.logger.DebugContext(ctx, "converting pending alert to firing", "name", r.Name())
+			a.State = model.StateFiring
+			a.FiredAt = ts
+			state := model.StateFiring
+			if a.Missing {
+				state = model.StateNoData
+			}
+			itemsToAdd = append(itemsToAdd, model.RuleStateHistory{
+				RuleID:       r.ID(),
+				RuleName:     r.Name(),
+				State:        state,
+				StateChanged: true,
+				UnixMilli:    ts.UnixMilli(),
+				Labels:       model.LabelsString(labelsJSON),
+				Fingerprint:  a.QueryResultLables.Hash(),
+				Value:        a.Value,
+			})
+		}
+
+		// We need to change firing alert to recovering if the returned sample meets recovery threshold
+		changeFiringToRecovering := a.State == model.StateFiring && a.IsRecovering
+		// We need to change recovering alerts to firing if the returned sample meets target threshold
+		changeRecoveringToFiring := a.State == model.StateRecovering && !a.IsRecovering && !a.Missing
+		// in any of the above case we need to update the status of alert
+		if changeFiringToRecovering || changeRecoveringToFiring {
+			state := model.StateRecovering
+			if changeRecoveringToFiring {
+				state = model.StateFiring
+			}
+			a.State = state
+			r.logger.DebugContext(ctx, "converting alert state", "name", r.Name(), "state", state)
+			itemsToAdd = append(itemsToAdd, model.RuleStateHistory{
+				RuleID:       r.ID(),
+				RuleName:     r.Name(),
+				State:        state,
+				StateChanged: true,
+				UnixMilli:    ts.UnixMilli(),
+				Labels:       model.LabelsString(labelsJSON),
+				Fingerprint:  a.QueryResultLables.Hash(),
+				Value:        a.Value,
+			})
+		}
+	}
+
+	currentState := r.state()
+	activeCount := len(r.Active)
+
+	r.mtx.Unlock()
+
+	overallStateChanged := currentState != prevState
+	for idx, item := range itemsToAdd {
+		item.OverallStateChanged = overallStateChanged
+		item.OverallState = currentState
+		itemsToAdd[idx] = item
+	}
+
+	if r.reader != nil {
+		if err := r.RecordRuleStateHistory(ctx, prevState, currentState, itemsToAdd); err != nil {
+			r.logger.ErrorContext(ctx, "error recording the rule state history", "error", err, "rule_name", r.Name())
+		}
+	}
+
+	return activeCount
+}
+
 func (r *BaseRule) ActiveAlerts() []*ruletypes.Alert {
 	var res []*ruletypes.Alert
 	for _, a := range r.currentAlerts() {
@@ -400,6 +554,10 @@ func (r *BaseRule) ForEachActiveAlert(f func(*ruletypes.Alert)) {
 }
 
 func (r *BaseRule) RecordRuleStateHistory(ctx context.Context, prevState, currentState model.AlertState, itemsToAdd []model.RuleStateHistory) error {
+	if r.skipStateHistory {
+		return nil
+	}
+
 	zap.L().Debug("recording rule state history", zap.String("ruleid", r.ID()), zap.Any("prevState", prevState), zap.Any("currentState", currentState), zap.Any("itemsToAdd", itemsToAdd))
 	revisedItemsToAdd := map[uint64]model.RuleStateHistory{}
 
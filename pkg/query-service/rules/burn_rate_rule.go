@@ -0,0 +1,162 @@
+package rules
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	querierV5 "github.com/SigNoz/signoz/pkg/querier"
+	"github.com/SigNoz/signoz/pkg/query-service/interfaces"
+	"github.com/SigNoz/signoz/pkg/query-service/model"
+	"github.com/SigNoz/signoz/pkg/query-service/utils/labels"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// BurnRateRule alerts when the error budget of an SLI is consumed too fast. Each
+// window of the condition fires its own alert when the burn rate over both the
+// long and the short window of the window is above its burn rate.
+type BurnRateRule struct {
+	*BaseRule
+	condition *ruletypes.BurnRateCondition
+	querierV5 querierV5.Querier
+}
+
+var _ Rule = (*BurnRateRule)(nil)
+
+func NewBurnRateRule(
+	id string,
+	orgID valuer.UUID,
+	postableRule *ruletypes.PostableRule,
+	querierV5 querierV5.Querier,
+	reader interfaces.Reader,
+	logger *slog.Logger,
+	opts ...RuleOption,
+) (*BurnRateRule, error) {
+	opts = append(opts, WithLogger(logger))
+
+	baseRule, err := NewBaseRule(id, orgID, postableRule, reader, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BurnRateRule{
+		BaseRule:  baseRule,
+		condition: postableRule.RuleCondition.BurnRate,
+		querierV5: querierV5,
+	}, nil
+}
+
+func (r *BurnRateRule) Type() ruletypes.RuleType {
+	return ruletypes.RuleTypeBurnRate
+}
+
+// slis returns the ratio of the good events over each of the durations of the windows ending at end.
+func (r *BurnRateRule) slis(ctx context.Context, end time.Time) (map[time.Duration]float64, error) {
+	slis := map[time.Duration]float64{}
+	for _, duration := range r.condition.Durations() {
+		req := ruletypes.NewSLIQueryRangeRequest(r.condition.Good, r.condition.Total, end.Add(-duration), end)
+		resp, err := r.querierV5.QueryRange(ctx, r.orgID, req)
+		if err != nil {
+			return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to query the sli over %s", duration)
+		}
+
+		good, total, err := ruletypes.SLIEventsFromResponse(resp)
+		if err != nil {
+			return nil, err
+		}
+
+		// without any event, no budget is consumed
+		slis[duration] = 1
+		if total > 0 {
+			slis[duration] = good / total
+		}
+	}
+
+	return slis, nil
+}
+
+func (r *BurnRateRule) alertLabels(window ruletypes.BurnRateWindow) labels.Labels {
+	lb := labels.NewBuilder(labels.FromMap(r.labels.Map()))
+	lb.Set(labels.AlertNameLabel, r.Name())
+	lb.Set(labels.AlertRuleIdLabel, r.ID())
+	lb.Set(labels.RuleSourceLabel, r.GeneratorURL())
+	lb.Set(ruletypes.LabelThresholdName, window.Name)
+	lb.Set(ruletypes.LabelBurnRateWindow, window.Long.String())
+	return lb.Labels()
+}
+
+func (r *BurnRateRule) Eval(ctx context.Context, ts time.Time) (int, error) {
+	slis, err := r.slis(ctx, ts.Add(-r.evalDelay.Duration()))
+	if err != nil {
+		r.SetHealth(ruletypes.HealthBad)
+		r.SetLastError(err)
+		return 0, err
+	}
+
+	ruleReceiverMap := make(map[string][]string)
+	for _, receiver := range r.Threshold.GetRuleReceivers() {
+		ruleReceiverMap[receiver.Name] = receiver.Channels
+	}
+
+	alerts := make(map[uint64]*ruletypes.Alert, len(r.condition.Windows))
+	for _, window := range r.condition.Windows {
+		long, short := r.condition.BurnRate(slis[window.Long.Duration()]), r.condition.BurnRate(slis[window.Short.Duration()])
+		if long < window.BurnRate || short < window.BurnRate {
+			continue
+		}
+
+		lbs := r.alertLabels(window)
+
+		annotations := make(labels.Labels, 0, len(r.annotations.Map())+2)
+		for name, value := range r.annotations.Map() {
+			annotations = append(annotations, labels.Label{Name: name, Value: value})
+		}
+		annotations = append(annotations,
+			labels.Label{Name: "long_window_burn_rate", Value: strconv.FormatFloat(long, 'f', 2, 64)},
+			labels.Label{Name: "short_window_burn_rate", Value: strconv.FormatFloat(short, 'f', 2, 64)},
+		)
+
+		alerts[lbs.Hash()] = &ruletypes.Alert{
+			Labels:            lbs,
+			QueryResultLables: lbs,
+			Annotations:       annotations,
+			ActiveAt:          ts,
+			State:             model.StatePending,
+			Value:             long,
+			GeneratorURL:      r.GeneratorURL(),
+			Receivers:         ruleReceiverMap[window.Name],
+		}
+	}
+
+	r.logger.InfoContext(ctx, "burn rate rule evaluated", "rule_name", r.Name(), "burning_windows", len(alerts))
+
+	activeCount := r.UpdateActiveAlerts(ctx, ts, alerts)
+
+	r.SetHealth(ruletypes.HealthGood)
+	r.SetLastError(nil)
+
+	return activeCount, nil
+}
+
+func (r *BurnRateRule) String() string {
+	ar := ruletypes.PostableRule{
+		AlertName:         r.name,
+		RuleCondition:     r.ruleCondition,
+		EvalWindow:        r.evalWindow,
+		Labels:            r.labels.Map(),
+		Annotations:       r.annotations.Map(),
+		PreferredChannels: r.preferredChannels,
+	}
+
+	byt, err := json.Marshal(ar)
+	if err != nil {
+		return fmt.Sprintf("error marshaling alerting rule: %s", err.Error())
+	}
+
+	return string(byt)
+}
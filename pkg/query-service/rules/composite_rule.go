@@ -0,0 +1,175 @@
+package rules
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/query-service/interfaces"
+	"github.com/SigNoz/signoz/pkg/query-service/model"
+	"github.com/SigNoz/signoz/pkg/query-service/utils/labels"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// RuleStateFunc returns the state of the rule with the given id, ok is false
+// when the manager has no such rule.
+type RuleStateFunc func(id string) (state model.AlertState, ok bool)
+
+// CompositeRule fires when the boolean expression over the states of other
+// rules holds for the hold duration of the condition. It doesn't query any
+// data, it reads the states the other rules had at their last evaluation.
+type CompositeRule struct {
+	*BaseRule
+	condition *ruletypes.CompositeCondition
+	expr      ruletypes.CompositeExpr
+	ruleState RuleStateFunc
+}
+
+var _ Rule = (*CompositeRule)(nil)
+
+func NewCompositeRule(
+	id string,
+	orgID valuer.UUID,
+	postableRule *ruletypes.PostableRule,
+	ruleState RuleStateFunc,
+	reader interfaces.Reader,
+	logger *slog.Logger,
+	opts ...RuleOption,
+) (*CompositeRule, error) {
+	opts = append(opts, WithLogger(logger))
+
+	baseRule, err := NewBaseRule(id, orgID, postableRule, reader, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	condition := postableRule.RuleCondition.Composite
+	expr, err := condition.Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dependency := range condition.RuleIDs() {
+		if dependency == id {
+			return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "composite rule %s can not depend on itself", id)
+		}
+	}
+
+	baseRule.holdDuration = condition.For
+
+	logger.Info("creating new composite rule", "rule_name", baseRule.name, "expression", expr.String())
+	return &CompositeRule{
+		BaseRule:  baseRule,
+		condition: condition,
+		expr:      expr,
+		ruleState: ruleState,
+	}, nil
+}
+
+func (r *CompositeRule) Type() ruletypes.RuleType {
+	return ruletypes.RuleTypeComposite
+}
+
+// Dependencies returns the ids of the rules the composite rule depends on.
+func (r *CompositeRule) Dependencies() []string {
+	return r.condition.RuleIDs()
+}
+
+// firingReferences returns the sorted references of the expression whose rules are firing.
+func (r *CompositeRule) firingReferences() []string {
+	firing := []string{}
+	for ref, id := range r.condition.Rules {
+		state, ok := r.ruleState(id)
+		if ok && (state == model.StateFiring || state == model.StateRecovering) {
+			firing = append(firing, ref)
+		}
+	}
+	sort.Strings(firing)
+	return firing
+}
+
+func (r *CompositeRule) alertLabels() labels.Labels {
+	lb := labels.NewBuilder(labels.FromMap(r.labels.Map()))
+	lb.Set(labels.AlertNameLabel, r.Name())
+	lb.Set(labels.AlertRuleIdLabel, r.ID())
+	lb.Set(labels.RuleSourceLabel, r.GeneratorURL())
+
+	// the first threshold names the alert, so that the route policies of the rule match it
+	if receivers := r.Threshold.GetRuleReceivers(); len(receivers) > 0 {
+		lb.Set(ruletypes.LabelThresholdName, receivers[0].Name)
+	}
+	return lb.Labels()
+}
+
+func (r *CompositeRule) Eval(ctx context.Context, ts time.Time) (int, error) {
+	firing := r.firingReferences()
+	isFiring := make(map[string]bool, len(firing))
+	for _, ref := range firing {
+		isFiring[ref] = true
+	}
+	holds := r.expr.Eval(func(ref string) bool { return isFiring[ref] })
+
+	alerts := map[uint64]*ruletypes.Alert{}
+	if holds {
+		lbs := r.alertLabels()
+
+		annotations := make(labels.Labels, 0, len(r.annotations.Map())+1)
+		for name, value := range r.annotations.Map() {
+			annotations = append(annotations, labels.Label{Name: name, Value: value})
+		}
+		annotations = append(annotations, labels.Label{Name: "firing_rules", Value: strings.Join(firing, ", ")})
+
+		var receivers []string
+		if value, ok := lbs.Map()[ruletypes.LabelThresholdName]; ok {
+			for _, receiver := range r.Threshold.GetRuleReceivers() {
+				if receiver.Name == value {
+					receivers = receiver.Channels
+				}
+			}
+		}
+
+		alerts[lbs.Hash()] = &ruletypes.Alert{
+			Labels:            lbs,
+			QueryResultLables: lbs,
+			Annotations:       annotations,
+			ActiveAt:          ts,
+			State:             model.StatePending,
+			Value:             1,
+			GeneratorURL:      r.GeneratorURL(),
+			Receivers:         receivers,
+		}
+	}
+
+	r.logger.InfoContext(ctx, "composite rule evaluated", "rule_name", r.Name(), "expression", r.expr.String(), "holds", holds)
+
+	activeCount := r.UpdateActiveAlerts(ctx, ts, alerts)
+
+	r.health = ruletypes.HealthGood
+	r.lastError = nil
+
+	return activeCount, nil
+}
+
+func (r *CompositeRule) String() string {
+	ar := ruletypes.PostableRule{
+		AlertName:         r.name,
+		RuleCondition:     r.ruleCondition,
+		EvalWindow:        r.evalWindow,
+		Labels:            r.labels.Map(),
+		Annotations:       r.annotations.Map(),
+		PreferredChannels: r.preferredChannels,
+	}
+
+	byt, err := json.Marshal(ar)
+	if err != nil {
+		return fmt.Sprintf("error marshaling alerting rule: %s", err.Error())
+	}
+
+	return string(byt)
+}
@@ -0,0 +1,147 @@
+package rules
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/query-service/model"
+	ruletypes "github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// CompositeRuleTask holds a composite rule, it evaluates the rule at its own
+// frequency over the last published states of the rules it depends on.
+type CompositeRuleTask struct {
+	*RuleTask
+}
+
+// NewCompositeRuleTask makes a new CompositeRuleTask with the given name, options, and rules.
+func NewCompositeRuleTask(name, file string, frequency time.Duration, rules []Rule, opts *ManagerOptions, notify NotifyFunc, maintenanceStore ruletypes.MaintenanceStore, orgID valuer.UUID) *CompositeRuleTask {
+	return &CompositeRuleTask{
+		RuleTask: NewRuleTask(name, file, frequency, rules, opts, notify, maintenanceStore, orgID),
+	}
+}
+
+func (g *CompositeRuleTask) Type() TaskType { return TaskTypeComposite }
+
+// CopyState copies the alerting state of the composite rules from the given task.
+func (g *CompositeRuleTask) CopyState(fromTask Task) error {
+	from, ok := fromTask.(*CompositeRuleTask)
+	if !ok {
+		return fmt.Errorf("invalid from task for copy")
+	}
+	g.evaluationTime = from.evaluationTime
+	g.lastEvaluation = from.lastEvaluation
+
+	for _, rule := range g.rules {
+		ar, ok := rule.(*CompositeRule)
+		if !ok {
+			continue
+		}
+		for _, fromRule := range from.rules {
+			far, ok := fromRule.(*CompositeRule)
+			if !ok || nameAndLabels(far) != nameAndLabels(ar) {
+				continue
+			}
+			for fp, a := range far.Active {
+				ar.Active[fp] = a
+			}
+			ar.handledRestart = far.handledRestart
+			break
+		}
+	}
+
+	return nil
+}
+
+// compositeRegistry keeps track of the rules of the manager for the composite
+// rules, which read their states, and of the rules the composite rules depend
+// on to reject the cycles.
+type compositeRegistry struct {
+	mtx   sync.RWMutex
+	rules map[string]Rule
+	// dependencies maps the id of a composite rule to the ids of the rules it depends on
+	dependencies map[string][]string
+}
+
+func newCompositeRegistry() *compositeRegistry {
+	return &compositeRegistry{
+		rules:        map[string]Rule{},
+		dependencies: map[string][]string{},
+	}
+}
+
+// ruleState returns the state of the rule, it is called by the composite rules
+// from the goroutines of their tasks.
+func (e *compositeRegistry) ruleState(id string) (model.AlertState, bool) {
+	e.mtx.RLock()
+	rule, ok := e.rules[id]
+	e.mtx.RUnlock()
+
+	if !ok {
+		return model.StateInactive, false
+	}
+	return rule.State(), true
+}
+
+// checkCycles returns an error if a composite rule of the task depends on
+// itself, directly or through other composite rules.
+func (e *compositeRegistry) checkCycles(task Task) error {
+	e.mtx.RLock()
+	defer e.mtx.RUnlock()
+
+	for _, rule := range task.Rules() {
+		cr, ok := rule.(*CompositeRule)
+		if !ok {
+			continue
+		}
+
+		visited := map[string]bool{}
+		stack := cr.Dependencies()
+		for len(stack) > 0 {
+			id := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if id == cr.ID() {
+				return errors.NewInvalidInputf(errors.CodeInvalidInput, "composite rule %s depends on itself", cr.ID())
+			}
+			if visited[id] {
+				continue
+			}
+			visited[id] = true
+			stack = append(stack, e.dependencies[id]...)
+		}
+	}
+
+	return nil
+}
+
+// replace registers the rules of the task, replacing the ones of the old task
+// whose state is copied to the new task.
+func (e *compositeRegistry) replace(task Task, oldTask Task) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	if oldTask != nil {
+		task.CopyState(oldTask)
+	}
+
+	for _, rule := range task.Rules() {
+		e.rules[rule.ID()] = rule
+		delete(e.dependencies, rule.ID())
+
+		if cr, ok := rule.(*CompositeRule); ok {
+			e.dependencies[cr.ID()] = cr.Dependencies()
+		}
+	}
+}
+
+// remove unregisters the rule.
+func (e *compositeRegistry) remove(id string) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	delete(e.rules, id)
+	delete(e.dependencies, id)
+}
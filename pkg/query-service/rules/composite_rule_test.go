@@ -0,0 +1,142 @@
+package rules
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
+	"github.com/SigNoz/signoz/pkg/query-service/model"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCompositeRule(t *testing.T, id string, expression string, refs map[string]string, holdFor string, ruleState RuleStateFunc) *CompositeRule {
+	t.Helper()
+
+	composite := map[string]any{"expression": expression, "rules": refs}
+	if holdFor != "" {
+		composite["for"] = holdFor
+	}
+	data, err := json.Marshal(map[string]any{
+		"alert":     "composite " + id,
+		"condition": map[string]any{"composite": composite},
+	})
+	require.NoError(t, err)
+
+	var postableRule ruletypes.PostableRule
+	require.NoError(t, json.Unmarshal(data, &postableRule))
+
+	rule, err := NewCompositeRule(id, valuer.GenerateUUID(), &postableRule, ruleState, nil, instrumentationtest.New().Logger())
+	require.NoError(t, err)
+	return rule
+}
+
+func TestCompositeRule_Eval(t *testing.T) {
+	latency, errorRate, deploy := valuer.GenerateUUID().StringValue(), valuer.GenerateUUID().StringValue(), valuer.GenerateUUID().StringValue()
+	states := map[string]model.AlertState{}
+	ruleState := func(id string) (model.AlertState, bool) {
+		state, ok := states[id]
+		return state, ok
+	}
+
+	rule := newTestCompositeRule(t, valuer.GenerateUUID().StringValue(), "(high_latency AND high_error_rate) AND NOT deploy_in_progress", map[string]string{
+		"high_latency":       latency,
+		"high_error_rate":    errorRate,
+		"deploy_in_progress": deploy,
+	}, "2m", ruleState)
+
+	ts := time.Unix(1700000000, 0)
+	steps := []struct {
+		states map[string]model.AlertState
+		want   model.AlertState
+	}{
+		// only one of the rules is firing
+		{states: map[string]model.AlertState{latency: model.StateFiring, errorRate: model.StatePending}, want: model.StateInactive},
+		// both are firing, the rule waits for the hold duration
+		{states: map[string]model.AlertState{latency: model.StateFiring, errorRate: model.StateFiring}, want: model.StatePending},
+		{states: map[string]model.AlertState{latency: model.StateFiring, errorRate: model.StateRecovering}, want: model.StatePending},
+		{states: map[string]model.AlertState{latency: model.StateFiring, errorRate: model.StateFiring}, want: model.StateFiring},
+		// the deployment suppresses the rule, which resolves
+		{states: map[string]model.AlertState{latency: model.StateFiring, errorRate: model.StateFiring, deploy: model.StateFiring}, want: model.StateInactive},
+		// the pending alert is dropped when the expression stops holding
+		{states: map[string]model.AlertState{latency: model.StateFiring, errorRate: model.StateFiring}, want: model.StatePending},
+		{states: map[string]model.AlertState{latency: model.StateFiring}, want: model.StateInactive},
+	}
+
+	for i, step := range steps {
+		states = step.states
+		_, err := rule.Eval(t.Context(), ts.Add(time.Duration(i)*time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, step.want, rule.State(), "step %d", i)
+
+		for _, alert := range rule.ActiveAlerts() {
+			assert.Equal(t, rule.ID(), alert.Labels.Map()["ruleId"])
+			assert.Equal(t, ruletypes.CriticalThresholdName, alert.Labels.Map()[ruletypes.LabelThresholdName])
+		}
+	}
+
+	// the pending alert is dropped right away
+	assert.Empty(t, rule.Active)
+}
+
+func TestCompositeRegistry(t *testing.T) {
+	registry := newCompositeRegistry()
+	register := func(rule *CompositeRule) {
+		task := NewCompositeRuleTask(prepareTaskName(rule.ID()), "", time.Minute, []Rule{rule}, &ManagerOptions{}, nil, nil, valuer.GenerateUUID())
+		require.NoError(t, registry.checkCycles(task))
+		registry.replace(task, nil)
+	}
+
+	a, b, d := valuer.GenerateUUID().StringValue(), valuer.GenerateUUID().StringValue(), valuer.GenerateUUID().StringValue()
+	c1, c2 := valuer.GenerateUUID().StringValue(), valuer.GenerateUUID().StringValue()
+
+	register(newTestCompositeRule(t, c2, "c1 OR d", map[string]string{"c1": c1, "d": d}, "", registry.ruleState))
+	register(newTestCompositeRule(t, c1, "a AND b", map[string]string{"a": a, "b": b}, "", registry.ruleState))
+
+	state, ok := registry.ruleState(c1)
+	assert.True(t, ok)
+	assert.Equal(t, model.StateInactive, state)
+
+	// c1 can not depend on c2, which depends on it
+	cyclic := newTestCompositeRule(t, c1, "c2", map[string]string{"c2": c2}, "", registry.ruleState)
+	task := NewCompositeRuleTask(prepareTaskName(c1), "", time.Minute, []Rule{cyclic}, &ManagerOptions{}, nil, nil, valuer.GenerateUUID())
+	require.Error(t, registry.checkCycles(task))
+
+	registry.remove(c1)
+	state, ok = registry.ruleState(c1)
+	assert.False(t, ok)
+	assert.Equal(t, model.StateInactive, state)
+}
+
+func TestCompositeRule_ConcurrentDependencyEval(t *testing.T) {
+	registry := newCompositeRegistry()
+
+	dependency := newTestCompositeRule(t, valuer.GenerateUUID().StringValue(), "a", map[string]string{"a": valuer.GenerateUUID().StringValue()}, "", func(string) (model.AlertState, bool) {
+		return model.StateFiring, true
+	})
+	registry.replace(NewCompositeRuleTask(prepareTaskName(dependency.ID()), "", time.Minute, []Rule{dependency}, &ManagerOptions{}, nil, nil, valuer.GenerateUUID()), nil)
+
+	rule := newTestCompositeRule(t, valuer.GenerateUUID().StringValue(), "dependency", map[string]string{"dependency": dependency.ID()}, "", registry.ruleState)
+
+	// the dependency is evaluated by its own task while the composite rule reads its state
+	ts := time.Unix(1700000000, 0)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			_, _ = dependency.Eval(t.Context(), ts.Add(time.Duration(i)*time.Minute))
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		_, err := rule.Eval(t.Context(), ts.Add(time.Duration(i)*time.Minute))
+		require.NoError(t, err)
+	}
+	<-done
+
+	_, err := rule.Eval(t.Context(), ts.Add(200*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, model.StateFiring, rule.State())
+}
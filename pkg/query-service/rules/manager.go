@@ -25,6 +25,7 @@ import (
 	querierV5 "github.com/SigNoz/signoz/pkg/querier"
 	"github.com/SigNoz/signoz/pkg/query-service/interfaces"
 	"github.com/SigNoz/signoz/pkg/query-service/model"
+	"github.com/SigNoz/signoz/pkg/sharder"
 	"github.com/SigNoz/signoz/pkg/sqlstore"
 	"github.com/SigNoz/signoz/pkg/telemetrystore"
 	"github.com/SigNoz/signoz/pkg/types"
@@ -49,6 +50,8 @@ type PrepareTaskOptions struct {
 	NotifyFunc       NotifyFunc
 	SQLStore         sqlstore.SQLStore
 	OrgID            valuer.UUID
+	// RuleState returns the state of the rules the composite rules depend on
+	RuleState RuleStateFunc
 }
 
 type PrepareTestRuleOptions struct {
@@ -105,8 +108,17 @@ type ManagerOptions struct {
 	OrgGetter           organization.Getter
 	RuleStore           ruletypes.RuleStore
 	MaintenanceStore    ruletypes.MaintenanceStore
+	AbsentGroupStore    ruletypes.AbsentGroupStore
 	SqlStore            sqlstore.SQLStore
 	QueryParser         queryparser.QueryParser
+
+	// PrepareBacktestRuleFunc prepares the rule which is replayed by a backtest
+	PrepareBacktestRuleFunc func(opts PrepareTestRuleOptions) (Rule, error)
+
+	// RecordingStore writes the results of the recording rules, the rules are
+	// evaluated only by the instance owning their organization in Sharder
+	RecordingStore ruletypes.RecordingStore
+	Sharder        sharder.Sharder
 }
 
 // The Manager manages recording and alerting rules.
@@ -119,18 +131,25 @@ type Manager struct {
 	// datastore to store alert definitions
 	ruleStore        ruletypes.RuleStore
 	maintenanceStore ruletypes.MaintenanceStore
+	absentGroupStore ruletypes.AbsentGroupStore
 
 	logger              *zap.Logger
 	reader              interfaces.Reader
 	cache               cache.Cache
 	prepareTaskFunc     func(opts PrepareTaskOptions) (Task, error)
 	prepareTestRuleFunc func(opts PrepareTestRuleOptions) (int, *model.ApiError)
+	// prepareBacktestRuleFunc prepares the rule which is replayed by a backtest
+	prepareBacktestRuleFunc func(opts PrepareTestRuleOptions) (Rule, error)
 
 	alertmanager alertmanager.Alertmanager
 	sqlstore     sqlstore.SQLStore
 	orgGetter    organization.Getter
 	// queryParser is used for parsing queries for rules
 	queryParser queryparser.QueryParser
+	// composites gives the composite rules the states of the rules they depend on
+	composites *compositeRegistry
+	// backtests runs the backtests in the background
+	backtests *backtests
 }
 
 func defaultOptions(o *ManagerOptions) *ManagerOptions {
@@ -146,6 +165,9 @@ func defaultOptions(o *ManagerOptions) *ManagerOptions {
 	if o.PrepareTestRuleFunc == nil {
 		o.PrepareTestRuleFunc = defaultTestNotification
 	}
+	if o.PrepareBacktestRuleFunc == nil {
+		o.PrepareBacktestRuleFunc = DefaultBacktestRule
+	}
 	return o
 }
 
@@ -174,6 +196,7 @@ func defaultPrepareTaskFunc(opts PrepareTaskOptions) (Task, error) {
 			WithSQLStore(opts.SQLStore),
 			WithQueryParser(opts.ManagerOpts.QueryParser),
 			WithMetadataStore(opts.ManagerOpts.MetadataStore),
+			WithAbsentGroupStore(opts.ManagerOpts.AbsentGroupStore),
 		)
 
 		if err != nil {
@@ -198,6 +221,7 @@ func defaultPrepareTaskFunc(opts PrepareTaskOptions) (Task, error) {
 			WithSQLStore(opts.SQLStore),
 			WithQueryParser(opts.ManagerOpts.QueryParser),
 			WithMetadataStore(opts.ManagerOpts.MetadataStore),
+			WithAbsentGroupStore(opts.ManagerOpts.AbsentGroupStore),
 		)
 
 		if err != nil {
@@ -209,8 +233,78 @@ func defaultPrepareTaskFunc(opts PrepareTaskOptions) (Task, error) {
 		// create promql rule task for evaluation
 		task = newTask(TaskTypeProm, opts.TaskName, taskNameSuffix, evaluation.GetFrequency().Duration(), rules, opts.ManagerOpts, opts.NotifyFunc, opts.MaintenanceStore, opts.OrgID)
 
+	} else if opts.Rule.RuleType == ruletypes.RuleTypeComposite {
+
+		// create composite rule
+		cr, err := NewCompositeRule(
+			ruleId,
+			opts.OrgID,
+			opts.Rule,
+			opts.RuleState,
+			opts.Reader,
+			opts.SLogger,
+			WithSQLStore(opts.SQLStore),
+		)
+
+		if err != nil {
+			return task, err
+		}
+
+		rules = append(rules, cr)
+
+		// create composite rule task, the manager evaluates it after the rules it depends on
+		task = newTask(TaskTypeComposite, opts.TaskName, taskNameSuffix, evaluation.GetFrequency().Duration(), rules, opts.ManagerOpts, opts.NotifyFunc, opts.MaintenanceStore, opts.OrgID)
+
+	} else if opts.Rule.RuleType == ruletypes.RuleTypeBurnRate {
+
+		// create burn rate rule
+		br, err := NewBurnRateRule(
+			ruleId,
+			opts.OrgID,
+			opts.Rule,
+			opts.Querier,
+			opts.Reader,
+			opts.SLogger,
+			WithEvalDelay(opts.ManagerOpts.EvalDelay),
+			WithSQLStore(opts.SQLStore),
+		)
+
+		if err != nil {
+			return task, err
+		}
+
+		rules = append(rules, br)
+
+		// create ch rule task for evaluation
+		task = newTask(TaskTypeCh, opts.TaskName, taskNameSuffix, evaluation.GetFrequency().Duration(), rules, opts.ManagerOpts, opts.NotifyFunc, opts.MaintenanceStore, opts.OrgID)
+
+	} else if opts.Rule.RuleType == ruletypes.RuleTypeRecording {
+
+		// create recording rule
+		rr, err := NewRecordingRule(
+			ruleId,
+			opts.OrgID,
+			opts.Rule,
+			opts.Querier,
+			opts.ManagerOpts.RecordingStore,
+			opts.ManagerOpts.Sharder,
+			opts.Reader,
+			opts.SLogger,
+			WithEvalDelay(opts.ManagerOpts.EvalDelay),
+			WithSQLStore(opts.SQLStore),
+		)
+
+		if err != nil {
+			return task, err
+		}
+
+		rules = append(rules, rr)
+
+		// create ch rule task for evaluation
+		task = newTask(TaskTypeCh, opts.TaskName, taskNameSuffix, evaluation.GetFrequency().Duration(), rules, opts.ManagerOpts, opts.NotifyFunc, opts.MaintenanceStore, opts.OrgID)
+
 	} else {
-		return nil, fmt.Errorf("unsupported rule type %s. Supported types: %s, %s", opts.Rule.RuleType, ruletypes.RuleTypeProm, ruletypes.RuleTypeThreshold)
+		return nil, fmt.Errorf("unsupported rule type %s. Supported types: %s, %s, %s, %s, %s", opts.Rule.RuleType, ruletypes.RuleTypeProm, ruletypes.RuleTypeThreshold, ruletypes.RuleTypeComposite, ruletypes.RuleTypeBurnRate, ruletypes.RuleTypeRecording)
 	}
 
 	return task, nil
@@ -222,21 +316,24 @@ func NewManager(o *ManagerOptions) (*Manager, error) {
 	o = defaultOptions(o)
 
 	m := &Manager{
-		tasks:               map[string]Task{},
-		rules:               map[string]Rule{},
-		ruleStore:           o.RuleStore,
-		maintenanceStore:    o.MaintenanceStore,
-		opts:                o,
-		block:               make(chan struct{}),
-		logger:              o.Logger,
-		reader:              o.Reader,
-		cache:               o.Cache,
-		prepareTaskFunc:     o.PrepareTaskFunc,
-		prepareTestRuleFunc: o.PrepareTestRuleFunc,
-		alertmanager:        o.Alertmanager,
-		orgGetter:           o.OrgGetter,
-		sqlstore:            o.SqlStore,
-		queryParser:         o.QueryParser,
+		tasks:                   map[string]Task{},
+		rules:                   map[string]Rule{},
+		ruleStore:               o.RuleStore,
+		maintenanceStore:        o.MaintenanceStore,
+		absentGroupStore:        o.AbsentGroupStore,
+		opts:                    o,
+		block:                   make(chan struct{}),
+		logger:                  o.Logger,
+		reader:                  o.Reader,
+		cache:                   o.Cache,
+		prepareTaskFunc:         o.PrepareTaskFunc,
+		prepareTestRuleFunc:     o.PrepareTestRuleFunc,
+		prepareBacktestRuleFunc: o.PrepareBacktestRuleFunc,
+		alertmanager:            o.Alertmanager,
+		orgGetter:               o.OrgGetter,
+		sqlstore:                o.SqlStore,
+		queryParser:             o.QueryParser,
+		composites:              newCompositeRegistry(),
 	}
 
 	zap.L().Debug("Manager created successfully with NotificationGroup")
@@ -333,6 +430,8 @@ func (m *Manager) Stop(_ context.Context) {
 		t.Stop()
 	}
 
+	m.backtests.stop()
+
 	zap.L().Info("Rule manager stopped")
 }
 
@@ -422,6 +521,7 @@ func (m *Manager) editTask(_ context.Context, orgID valuer.UUID, rule *ruletypes
 		NotifyFunc:       m.prepareNotifyFunc(),
 		SQLStore:         m.sqlstore,
 		OrgID:            orgID,
+		RuleState:        m.composites.ruleState,
 	})
 
 	if err != nil {
@@ -429,6 +529,10 @@ func (m *Manager) editTask(_ context.Context, orgID valuer.UUID, rule *ruletypes
 		return errors.NewInvalidInputf(errors.CodeInvalidInput, "error preparing rule with given parameters, previous rule set restored")
 	}
 
+	if err := m.composites.checkCycles(newTask); err != nil {
+		return err
+	}
+
 	for _, r := range newTask.Rules() {
 		m.rules[r.ID()] = r
 	}
@@ -444,8 +548,10 @@ func (m *Manager) editTask(_ context.Context, orgID valuer.UUID, rule *ruletypes
 
 	if ok {
 		oldTask.Stop()
-		newTask.CopyState(oldTask)
+	} else {
+		oldTask = nil
 	}
+	m.composites.replace(newTask, oldTask)
 	go func() {
 		// Wait with starting evaluation until the rule manager
 		// is told to run. This is necessary to avoid running
@@ -514,10 +620,55 @@ func (m *Manager) DeleteRule(ctx context.Context, idStr string) error {
 		taskName := prepareTaskName(id.StringValue())
 		m.deleteTask(taskName)
 
+		if m.absentGroupStore != nil {
+			err = m.absentGroupStore.DeleteAbsentGroups(ctx, id.StringValue(), nil)
+			if err != nil {
+				return err
+			}
+		}
+
 		return nil
 	})
 }
 
+// ListAbsentGroups returns the groups tracked by the absent groups condition of the rule.
+func (m *Manager) ListAbsentGroups(ctx context.Context, id valuer.UUID) ([]*ruletypes.AbsentGroup, error) {
+	_, err := m.ruleStore.GetStoredRule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.absentGroupStore == nil {
+		return []*ruletypes.AbsentGroup{}, nil
+	}
+
+	return m.absentGroupStore.ListAbsentGroups(ctx, id.StringValue())
+}
+
+// RetireAbsentGroups stops tracking the groups of the rule with the given
+// fingerprints, or all of them if none is given, e.g. when the groups are
+// gone for good and shouldn't alert anymore.
+func (m *Manager) RetireAbsentGroups(ctx context.Context, id valuer.UUID, fingerprints []string) error {
+	_, err := m.ruleStore.GetStoredRule(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	m.mtx.RLock()
+	if rule, ok := m.rules[id.StringValue()]; ok {
+		if tracker, ok := rule.(interface{ ForgetAbsentGroups([]string) }); ok {
+			tracker.ForgetAbsentGroups(fingerprints)
+		}
+	}
+	m.mtx.RUnlock()
+
+	if m.absentGroupStore == nil {
+		return nil
+	}
+
+	return m.absentGroupStore.DeleteAbsentGroups(ctx, id.StringValue(), fingerprints)
+}
+
 func (m *Manager) deleteTask(taskName string) {
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
@@ -528,6 +679,7 @@ func (m *Manager) deleteTask(taskName string) {
 		oldg.Stop()
 		delete(m.tasks, taskName)
 		delete(m.rules, RuleIdFromTaskName(taskName))
+		m.composites.remove(RuleIdFromTaskName(taskName))
 		zap.L().Debug("rule task deleted", zap.String("name", taskName))
 	} else {
 		zap.L().Info("rule not found for deletion", zap.String("name", taskName))
@@ -632,6 +784,7 @@ func (m *Manager) addTask(_ context.Context, orgID valuer.UUID, rule *ruletypes.
 		NotifyFunc:       m.prepareNotifyFunc(),
 		SQLStore:         m.sqlstore,
 		OrgID:            orgID,
+		RuleState:        m.composites.ruleState,
 	})
 
 	if err != nil {
@@ -639,6 +792,10 @@ func (m *Manager) addTask(_ context.Context, orgID valuer.UUID, rule *ruletypes.
 		return errors.NewInvalidInputf(errors.CodeInvalidInput, "error loading rules, previous rule set restored")
 	}
 
+	if err := m.composites.checkCycles(newTask); err != nil {
+		return err
+	}
+
 	for _, r := range newTask.Rules() {
 		m.rules[r.ID()] = r
 	}
@@ -648,6 +805,7 @@ func (m *Manager) addTask(_ context.Context, orgID valuer.UUID, rule *ruletypes.
 	if ok {
 		return fmt.Errorf("a rule with the same name already exists")
 	}
+	m.composites.replace(newTask, nil)
 
 	go func() {
 		// Wait with starting evaluation until the rule manager
@@ -1021,6 +1179,42 @@ func (m *Manager) TestNotification(ctx context.Context, orgID valuer.UUID, ruleS
 	return alertCount, apiErr
 }
 
+// Backtest starts to replay the rule of the backtest over its range at the
+// frequency of the rule, in the background. The result holds the intervals its
+// alerts would have been firing in, see GetBacktest. No notification is sent
+// and no state history is recorded.
+func (m *Manager) Backtest(ctx context.Context, orgID valuer.UUID, postable *ruletypes.PostableBacktest) (*ruletypes.GettableBacktestJob, error) {
+	backtest, err := ruletypes.NewBacktest(postable)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := m.prepareBacktestRuleFunc(PrepareTestRuleOptions{
+		Rule:             &postable.Rule,
+		RuleStore:        m.ruleStore,
+		MaintenanceStore: m.maintenanceStore,
+		Logger:           m.logger,
+		SLogger:          m.opts.SLogger,
+		Reader:           m.reader,
+		Querier:          m.opts.Querier,
+		Cache:            m.cache,
+		ManagerOpts:      m.opts,
+		SQLStore:         m.sqlstore,
+		OrgID:            orgID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return m.backtests.start(orgID, rule, backtest, time.Now())
+}
+
+// GetBacktest returns a backtest started by this instance, along with its
+// result once it succeeded.
+func (m *Manager) GetBacktest(_ context.Context, orgID valuer.UUID, id valuer.UUID) (*ruletypes.GettableBacktestJob, error) {
+	return m.backtests.get(orgID, id)
+}
+
 func (m *Manager) GetAlertDetailsForMetricNames(ctx context.Context, metricNames []string) (map[string][]ruletypes.GettableRule, *model.ApiError) {
 	claims, err := authtypes.ClaimsFromContext(ctx)
 	if err != nil {
@@ -0,0 +1,120 @@
+package rules
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	querierV5 "github.com/SigNoz/signoz/pkg/querier"
+	"github.com/SigNoz/signoz/pkg/query-service/interfaces"
+	"github.com/SigNoz/signoz/pkg/sharder"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// RecordingRule evaluates its query on schedule and writes the latest value of
+// every series of the result as a new metric. The rule never alerts.
+type RecordingRule struct {
+	*BaseRule
+	condition *ruletypes.RecordingCondition
+	querierV5 querierV5.Querier
+	store     ruletypes.RecordingStore
+	sharder   sharder.Sharder
+}
+
+var _ Rule = (*RecordingRule)(nil)
+
+func NewRecordingRule(
+	id string,
+	orgID valuer.UUID,
+	postableRule *ruletypes.PostableRule,
+	querierV5 querierV5.Querier,
+	store ruletypes.RecordingStore,
+	sharder sharder.Sharder,
+	reader interfaces.Reader,
+	logger *slog.Logger,
+	opts ...RuleOption,
+) (*RecordingRule, error) {
+	if store == nil {
+		return nil, errors.NewInvalidInputf(errors.CodeUnsupported, "recording rules are not supported without a recording store")
+	}
+
+	opts = append(opts, WithLogger(logger))
+
+	baseRule, err := NewBaseRule(id, orgID, postableRule, reader, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("creating new recording rule", "rule_name", baseRule.name, "metric", postableRule.RuleCondition.Recording.Metric)
+	return &RecordingRule{
+		BaseRule:  baseRule,
+		condition: postableRule.RuleCondition.Recording,
+		querierV5: querierV5,
+		store:     store,
+		sharder:   sharder,
+	}, nil
+}
+
+func (r *RecordingRule) Type() ruletypes.RuleType {
+	return ruletypes.RuleTypeRecording
+}
+
+func (r *RecordingRule) Eval(ctx context.Context, ts time.Time) (int, error) {
+	// the ownership of the organization is checked on every evaluation as the
+	// keys move between the instances, so that a series is recorded only once
+	if r.sharder != nil {
+		if err := r.sharder.IsMyOwnedKey(ctx, types.NewOrganizationKey(r.orgID)); err != nil {
+			r.logger.DebugContext(ctx, "skipping recording rule of an organization owned by another instance", "rule_name", r.Name(), "error", err)
+			return 0, nil
+		}
+	}
+
+	start, end := r.Timestamps(ts)
+	resp, err := r.querierV5.QueryRange(ctx, r.orgID, ruletypes.NewRecordingQueryRangeRequest(r.condition, start, end))
+	if err != nil {
+		r.health = ruletypes.HealthBad
+		r.lastError = err
+		return 0, errors.WrapInternalf(err, errors.CodeInternal, "failed to query the recording of %s", r.condition.Metric)
+	}
+
+	// the buckets older than the window of the evaluation were recorded by the earlier evaluations
+	samples := ruletypes.RecordedSamplesFromResponse(r.condition, resp, end.Add(-r.evalWindow.Duration()))
+	if err := r.store.Write(ctx, samples); err != nil {
+		r.health = ruletypes.HealthBad
+		r.lastError = err
+		return 0, err
+	}
+
+	r.logger.InfoContext(ctx, "recording rule evaluated", "rule_name", r.Name(), "metric", r.condition.Metric, "series", len(samples))
+
+	r.health = ruletypes.HealthGood
+	r.lastError = nil
+
+	return len(samples), nil
+}
+
+// SendAlerts does nothing, recording rules have no alerts to send.
+func (r *RecordingRule) SendAlerts(_ context.Context, _ time.Time, _ time.Duration, _ time.Duration, _ NotifyFunc) {
+}
+
+func (r *RecordingRule) String() string {
+	ar := ruletypes.PostableRule{
+		AlertName:     r.name,
+		RuleCondition: r.ruleCondition,
+		EvalWindow:    r.evalWindow,
+		Labels:        r.labels.Map(),
+		Annotations:   r.annotations.Map(),
+	}
+
+	byt, err := json.Marshal(ar)
+	if err != nil {
+		return fmt.Sprintf("error marshaling recording rule: %s", err.Error())
+	}
+
+	return string(byt)
+}
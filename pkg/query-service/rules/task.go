@@ -11,8 +11,9 @@ import (
 type TaskType string
 
 const (
-	TaskTypeProm = "promql_ruletask"
-	TaskTypeCh   = "ch_ruletask"
+	TaskTypeProm      = "promql_ruletask"
+	TaskTypeCh        = "ch_ruletask"
+	TaskTypeComposite = "composite_ruletask"
 )
 
 type Task interface {
@@ -36,5 +37,8 @@ func newTask(taskType TaskType, name, file string, frequency time.Duration, rule
 	if taskType == TaskTypeCh {
 		return NewRuleTask(name, file, frequency, rules, opts, notify, maintenanceStore, orgID)
 	}
+	if taskType == TaskTypeComposite {
+		return NewCompositeRuleTask(name, file, frequency, rules, opts, notify, maintenanceStore, orgID)
+	}
 	return NewPromRuleTask(name, file, frequency, rules, opts, notify, maintenanceStore, orgID)
 }
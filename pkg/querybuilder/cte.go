@@ -1,7 +1,10 @@
 package querybuilder
 
 import (
+	"fmt"
 	"strings"
+
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
 )
 
 // combineCTEs takes any number of individual CTE fragments like
@@ -25,3 +28,34 @@ func PrependArgs(cteArgs [][]any, mainArgs []any) []any {
 	}
 	return append(out, mainArgs...)
 }
+
+// SubQueryCTEName returns the name of the CTE a query referenced as `key IN @name` is compiled into.
+func SubQueryCTEName(name string) string {
+	return "__subquery_" + name
+}
+
+// BuildSubQueryCTE renders the CTE of the query referenced as `key IN @name`. The CTE selects the single group by
+// column of the statement of the referenced query, so that it can be used as the right hand side of IN.
+func BuildSubQueryCTE(name string, groupBy qbtypes.GroupByKey, stmt *qbtypes.Statement) (string, []any) {
+	return fmt.Sprintf("%s AS (SELECT `%s` FROM (%s))", SubQueryCTEName(name), groupBy.TelemetryFieldKey.Name, stmt.Query), stmt.Args
+}
+
+// AttachCTEs adds the CTEs in front of the CTEs of the statement, if any. The statement can refer to the attached
+// CTEs from any of its own CTEs.
+func AttachCTEs(stmt *qbtypes.Statement, cteFragments []string, cteArgs [][]any) *qbtypes.Statement {
+	if len(cteFragments) == 0 {
+		return stmt
+	}
+
+	query := CombineCTEs(cteFragments) + stmt.Query
+	if rest, ok := strings.CutPrefix(stmt.Query, "WITH "); ok {
+		query = "WITH " + strings.Join(cteFragments, ", ") + ", " + rest
+	}
+
+	return &qbtypes.Statement{
+		Query:          query,
+		Args:           PrependArgs(cteArgs, stmt.Args),
+		Warnings:       stmt.Warnings,
+		WarningsDocURL: stmt.WarningsDocURL,
+	}
+}
@@ -0,0 +1,58 @@
+package querybuilder
+
+import (
+	"testing"
+
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildSubQueryCTE(t *testing.T) {
+	groupBy := qbtypes.GroupByKey{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "service.name"}}
+	stmt := &qbtypes.Statement{
+		Query: "SELECT `service.name`, count() AS __result_0 FROM logs WHERE ts >= ? GROUP BY `service.name`",
+		Args:  []any{1},
+	}
+
+	fragment, args := BuildSubQueryCTE("B", groupBy, stmt)
+
+	assert.Equal(t, "__subquery_B AS (SELECT `service.name` FROM (SELECT `service.name`, count() AS __result_0 FROM logs WHERE ts >= ? GROUP BY `service.name`))", fragment)
+	assert.Equal(t, []any{1}, args)
+}
+
+func TestAttachCTEs(t *testing.T) {
+	tests := []struct {
+		name         string
+		stmt         *qbtypes.Statement
+		cteFragments []string
+		cteArgs      [][]any
+		expected     *qbtypes.Statement
+	}{
+		{
+			name:     "no ctes",
+			stmt:     &qbtypes.Statement{Query: "SELECT 1 WHERE x = ?", Args: []any{1}},
+			expected: &qbtypes.Statement{Query: "SELECT 1 WHERE x = ?", Args: []any{1}},
+		},
+		{
+			name:         "statement without ctes",
+			stmt:         &qbtypes.Statement{Query: "SELECT 1 WHERE x = ?", Args: []any{1}, Warnings: []string{"warning"}},
+			cteFragments: []string{"__subquery_A AS (SELECT ?)"},
+			cteArgs:      [][]any{{0}},
+			expected:     &qbtypes.Statement{Query: "WITH __subquery_A AS (SELECT ?) SELECT 1 WHERE x = ?", Args: []any{0, 1}, Warnings: []string{"warning"}},
+		},
+		{
+			name:         "statement with ctes",
+			stmt:         &qbtypes.Statement{Query: "WITH __resource_filter AS (SELECT ?) SELECT 1 WHERE x = ?", Args: []any{1, 2}},
+			cteFragments: []string{"__subquery_A AS (SELECT ?)", "__subquery_B AS (SELECT 1)"},
+			cteArgs:      [][]any{{0}, nil},
+			expected:     &qbtypes.Statement{Query: "WITH __subquery_A AS (SELECT ?), __subquery_B AS (SELECT 1), __resource_filter AS (SELECT ?) SELECT 1 WHERE x = ?", Args: []any{0, 1, 2}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, AttachCTEs(tt.stmt, tt.cteFragments, tt.cteArgs))
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package querybuilder
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+const (
+	// DistributionValueColumn is the column of the values to bucket in the __distribution_values CTE
+	// for the logs and traces statement builders.
+	DistributionValueColumn = "__value"
+
+	DistributionBucketColumn = "__bucket"
+	DistributionLowerColumn  = "__lower"
+	DistributionUpperColumn  = "__upper"
+)
+
+// BuildDistributionStatement buckets the values of valueColumn of the rows returned by valuesSQL and counts the
+// values in every bucket per group.
+//
+// valuesSQL is attached as the __distribution_values CTE after the given CTEs, it must select the group by keys
+// under their names along with the value column. The statement returns the group by keys followed by the
+// __bucket, __lower, __upper and __result_0 (count) columns, ordered by the groups and the buckets.
+//
+// With fixed boundaries, values outside of the first and last boundary are dropped. Otherwise the range between
+// the min and max value across all the groups is split into equal width buckets, so that the buckets of all the
+// groups line up.
+func BuildDistributionStatement(
+	cteFragments []string,
+	cteArgs [][]any,
+	valuesSQL string,
+	valuesArgs []any,
+	valueColumn string,
+	groupBy []qbtypes.GroupByKey,
+	distribution *qbtypes.Distribution,
+) *qbtypes.Statement {
+	cteFragments = append(cteFragments, fmt.Sprintf("__distribution_values AS (%s)", valuesSQL))
+	cteArgs = append(cteArgs, valuesArgs)
+
+	value := fmt.Sprintf("`%s`", valueColumn)
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(GroupByKeys(groupBy)...)
+
+	if distribution.IsFixed() {
+		boundaries := make([]string, len(distribution.Boundaries))
+		for i, boundary := range distribution.Boundaries {
+			boundaries[i] = strconv.FormatFloat(boundary, 'f', -1, 64)
+		}
+		array := fmt.Sprintf("CAST([%s], 'Array(Float64)')", strings.Join(boundaries, ", "))
+
+		// arrayCount gives the 1 based index of the bucket, the last boundary falls in the last bucket
+		sb.SelectMore(fmt.Sprintf("least(arrayCount(x -> x <= %s, %s), %d) AS %s", value, array, distribution.NumBuckets(), DistributionBucketColumn))
+		sb.SelectMore(fmt.Sprintf("arrayElement(%s, %s) AS %s", array, DistributionBucketColumn, DistributionLowerColumn))
+		sb.SelectMore(fmt.Sprintf("arrayElement(%s, %s + 1) AS %s", array, DistributionBucketColumn, DistributionUpperColumn))
+		sb.SelectMore("count() AS __result_0")
+		sb.From("__distribution_values")
+		sb.Where(
+			fmt.Sprintf("isFinite(%s)", value),
+			fmt.Sprintf("%s >= %s", value, boundaries[0]),
+			fmt.Sprintf("%s <= %s", value, boundaries[len(boundaries)-1]),
+		)
+	} else {
+		numBuckets := distribution.NumBuckets()
+		cteFragments = append(cteFragments, fmt.Sprintf(
+			"__distribution_range AS (SELECT min(%[1]s) AS __min, if(max(%[1]s) > min(%[1]s), (max(%[1]s) - min(%[1]s)) / %[2]d, 1) AS __width FROM __distribution_values WHERE isFinite(%[1]s))",
+			value, numBuckets,
+		))
+		cteArgs = append(cteArgs, nil)
+
+		// the max value falls in the last bucket
+		sb.SelectMore(fmt.Sprintf("least(toUInt64(floor((%s - __min) / __width)), %d) AS %s", value, numBuckets-1, DistributionBucketColumn))
+		sb.SelectMore(fmt.Sprintf("__min + %s * __width AS %s", DistributionBucketColumn, DistributionLowerColumn))
+		sb.SelectMore(fmt.Sprintf("__min + (%s + 1) * __width AS %s", DistributionBucketColumn, DistributionUpperColumn))
+		sb.SelectMore("count() AS __result_0")
+		sb.From("__distribution_values CROSS JOIN __distribution_range")
+		sb.Where(fmt.Sprintf("isFinite(%s)", value))
+	}
+
+	sb.GroupBy(GroupByKeys(groupBy)...)
+	sb.GroupBy(DistributionBucketColumn, DistributionLowerColumn, DistributionUpperColumn)
+	sb.OrderBy(GroupByKeys(groupBy)...)
+	sb.OrderBy(DistributionBucketColumn)
+
+	mainSQL, mainArgs := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+
+	return &qbtypes.Statement{
+		Query: CombineCTEs(cteFragments) + mainSQL,
+		Args:  PrependArgs(cteArgs, mainArgs),
+	}
+}
@@ -29,11 +29,21 @@ func (r *HavingExpressionRewriter) RewriteForLogs(expression string, aggregation
 	return r.rewriteExpression(expression)
 }
 
+func (r *HavingExpressionRewriter) RewriteForJoin(expression string, aggregations []qbtypes.JoinAggregation) string {
+	r.buildJoinColumnMap(aggregations)
+	return r.rewriteExpression(expression)
+}
+
 func (r *HavingExpressionRewriter) RewriteForMetrics(expression string, aggregations []qbtypes.MetricAggregation) string {
 	r.buildMetricColumnMap(aggregations)
 	return r.rewriteExpression(expression)
 }
 
+func (r *HavingExpressionRewriter) RewriteForAlertAnalytics(expression string, aggregations []qbtypes.AlertAnalyticsAggregation) string {
+	r.buildAlertAnalyticsColumnMap(aggregations)
+	return r.rewriteExpression(expression)
+}
+
 func (r *HavingExpressionRewriter) buildTraceColumnMap(aggregations []qbtypes.TraceAggregation) {
 	r.columnMap = make(map[string]string)
 
@@ -74,6 +84,46 @@ func (r *HavingExpressionRewriter) buildLogColumnMap(aggregations []qbtypes.LogA
 	}
 }
 
+func (r *HavingExpressionRewriter) buildJoinColumnMap(aggregations []qbtypes.JoinAggregation) {
+	r.columnMap = make(map[string]string)
+
+	for idx, agg := range aggregations {
+		sqlColumn := fmt.Sprintf("__result_%d", idx)
+
+		if agg.Alias != "" {
+			r.columnMap[agg.Alias] = sqlColumn
+		}
+
+		r.columnMap[agg.Expression] = sqlColumn
+
+		r.columnMap[fmt.Sprintf("__result%d", idx)] = sqlColumn
+
+		if len(aggregations) == 1 {
+			r.columnMap["__result"] = sqlColumn
+		}
+	}
+}
+
+func (r *HavingExpressionRewriter) buildAlertAnalyticsColumnMap(aggregations []qbtypes.AlertAnalyticsAggregation) {
+	r.columnMap = make(map[string]string)
+
+	for idx, agg := range aggregations {
+		sqlColumn := fmt.Sprintf("__result_%d", idx)
+
+		if agg.Alias != "" {
+			r.columnMap[agg.Alias] = sqlColumn
+		}
+
+		r.columnMap[agg.Metric.StringValue()] = sqlColumn
+
+		r.columnMap[fmt.Sprintf("__result%d", idx)] = sqlColumn
+
+		if len(aggregations) == 1 {
+			r.columnMap["__result"] = sqlColumn
+		}
+	}
+}
+
 func (r *HavingExpressionRewriter) buildMetricColumnMap(aggregations []qbtypes.MetricAggregation) {
 	r.columnMap = make(map[string]string)
 
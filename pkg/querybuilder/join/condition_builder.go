@@ -0,0 +1,121 @@
+package join
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/querybuilder"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+type conditionBuilder struct {
+	fm qbtypes.FieldMapper
+}
+
+var _ qbtypes.ConditionBuilder = (*conditionBuilder)(nil)
+
+func newConditionBuilder(fm qbtypes.FieldMapper) *conditionBuilder {
+	return &conditionBuilder{fm: fm}
+}
+
+func (c *conditionBuilder) ConditionFor(
+	ctx context.Context,
+	key *telemetrytypes.TelemetryFieldKey,
+	operator qbtypes.FilterOperator,
+	value any,
+	sb *sqlbuilder.SelectBuilder,
+	_ uint64,
+	_ uint64,
+) (string, error) {
+
+	switch operator {
+	case qbtypes.FilterOperatorContains,
+		qbtypes.FilterOperatorNotContains,
+		qbtypes.FilterOperatorILike,
+		qbtypes.FilterOperatorNotILike,
+		qbtypes.FilterOperatorLike,
+		qbtypes.FilterOperatorNotLike:
+		value = querybuilder.FormatValueForContains(value)
+	}
+
+	fieldName, err := c.fm.FieldFor(ctx, key)
+	if err != nil {
+		return "", err
+	}
+
+	switch operator {
+	case qbtypes.FilterOperatorEqual:
+		return sb.E(fieldName, value), nil
+	case qbtypes.FilterOperatorNotEqual:
+		return sb.NE(fieldName, value), nil
+	case qbtypes.FilterOperatorGreaterThan:
+		return sb.G(fieldName, value), nil
+	case qbtypes.FilterOperatorGreaterThanOrEq:
+		return sb.GE(fieldName, value), nil
+	case qbtypes.FilterOperatorLessThan:
+		return sb.LT(fieldName, value), nil
+	case qbtypes.FilterOperatorLessThanOrEq:
+		return sb.LE(fieldName, value), nil
+
+	// like and not like
+	case qbtypes.FilterOperatorLike:
+		return sb.Like(fieldName, value), nil
+	case qbtypes.FilterOperatorNotLike:
+		return sb.NotLike(fieldName, value), nil
+	case qbtypes.FilterOperatorILike:
+		return sb.ILike(fieldName, value), nil
+	case qbtypes.FilterOperatorNotILike:
+		return sb.NotILike(fieldName, value), nil
+
+	case qbtypes.FilterOperatorContains:
+		return sb.ILike(fieldName, fmt.Sprintf("%%%s%%", value)), nil
+	case qbtypes.FilterOperatorNotContains:
+		return sb.NotILike(fieldName, fmt.Sprintf("%%%s%%", value)), nil
+
+	case qbtypes.FilterOperatorRegexp:
+		// Note: Escape $$ to $$$$ to avoid sqlbuilder interpreting materialized $ signs
+		// Only needed because we are using sprintf instead of sb.Match (not implemented in sqlbuilder)
+		return fmt.Sprintf(`match(%s, %s)`, sqlbuilder.Escape(fieldName), sb.Var(value)), nil
+	case qbtypes.FilterOperatorNotRegexp:
+		return fmt.Sprintf(`NOT match(%s, %s)`, sqlbuilder.Escape(fieldName), sb.Var(value)), nil
+
+	// between and not between
+	case qbtypes.FilterOperatorBetween:
+		values, ok := value.([]any)
+		if !ok || len(values) != 2 {
+			return "", qbtypes.ErrBetweenValues
+		}
+		return sb.Between(fieldName, values[0], values[1]), nil
+	case qbtypes.FilterOperatorNotBetween:
+		values, ok := value.([]any)
+		if !ok || len(values) != 2 {
+			return "", qbtypes.ErrBetweenValues
+		}
+		return sb.NotBetween(fieldName, values[0], values[1]), nil
+
+	// in and not in
+	case qbtypes.FilterOperatorIn:
+		values, ok := value.([]any)
+		if !ok {
+			return "", qbtypes.ErrInValues
+		}
+		return sb.In(fieldName, values), nil
+	case qbtypes.FilterOperatorNotIn:
+		values, ok := value.([]any)
+		if !ok {
+			return "", qbtypes.ErrInValues
+		}
+		return sb.NotIn(fieldName, values), nil
+
+	// the columns of the joined queries always exist
+	case qbtypes.FilterOperatorExists:
+		return "true", nil
+	case qbtypes.FilterOperatorNotExists:
+		return "false", nil
+	}
+
+	return "", errors.NewInvalidInputf(errors.CodeInvalidInput, "unsupported operator: %v", operator)
+}
@@ -0,0 +1,160 @@
+package join
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	schema "github.com/SigNoz/signoz-otel-collector/cmd/signozschemamigrator/schema_migrator"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/telemetrylogs"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+)
+
+// joinedQuery holds the columns of the statement of a query referenced by the join.
+type joinedQuery struct {
+	name string
+	// columns of the statement, in the order they are selected
+	columns []string
+	// aggregation aliases to the columns of the aggregations
+	aliases map[string]string
+}
+
+func newJoinedQuery(spec any) (*joinedQuery, error) {
+	switch s := spec.(type) {
+	case qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]:
+		aliases := make([]string, len(s.Aggregations))
+		for idx, agg := range s.Aggregations {
+			aliases[idx] = agg.Alias
+		}
+		return newJoinedQueryFor(s.Name, s.GroupBy, aliases, s.SelectFields, nil), nil
+	case qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]:
+		aliases := make([]string, len(s.Aggregations))
+		for idx, agg := range s.Aggregations {
+			aliases[idx] = agg.Alias
+		}
+		// the logs list statement always selects the timestamp and id
+		return newJoinedQueryFor(s.Name, s.GroupBy, aliases, s.SelectFields, []string{telemetrylogs.LogsV2TimestampColumn, telemetrylogs.LogsV2IDColumn}), nil
+	}
+
+	return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "unsupported joined query spec %T, only logs and traces builder queries can be joined", spec)
+}
+
+func newJoinedQueryFor(name string, groupBy []qbtypes.GroupByKey, aggregationAliases []string, selectFields []telemetrytypes.TelemetryFieldKey, defaultColumns []string) *joinedQuery {
+	query := &joinedQuery{name: name, aliases: make(map[string]string)}
+
+	// aggregated queries are run as scalar queries which select the group by keys and the aggregations
+	if len(aggregationAliases) > 0 {
+		for _, gb := range groupBy {
+			query.columns = append(query.columns, gb.TelemetryFieldKey.Name)
+		}
+		for idx, alias := range aggregationAliases {
+			column := fmt.Sprintf("__result_%d", idx)
+			query.columns = append(query.columns, column)
+			if alias != "" {
+				query.aliases[alias] = column
+			}
+		}
+		return query
+	}
+
+	query.columns = append(query.columns, defaultColumns...)
+	for _, field := range selectFields {
+		if !slices.Contains(query.columns, field.Name) {
+			query.columns = append(query.columns, field.Name)
+		}
+	}
+
+	return query
+}
+
+// column returns the column of the statement for the name it is referenced with after the name of the query.
+func (q *joinedQuery) column(name string) (string, bool) {
+	if column, ok := q.aliases[name]; ok {
+		return column, true
+	}
+
+	if slices.Contains(q.columns, name) {
+		return name, true
+	}
+
+	return "", false
+}
+
+// fieldMapper maps the columns of the joined queries, referenced as `<query name>.<column>`, to the columns of
+// the joined statements.
+type fieldMapper struct {
+	queries []*joinedQuery
+}
+
+var _ qbtypes.FieldMapper = (*fieldMapper)(nil)
+
+func newFieldMapper(queries ...*joinedQuery) *fieldMapper {
+	return &fieldMapper{queries: queries}
+}
+
+// resolve returns the joined query and the column of its statement for the referenced column.
+func (m *fieldMapper) resolve(name string) (*joinedQuery, string, error) {
+	for _, query := range m.queries {
+		columnName, ok := strings.CutPrefix(name, query.name+".")
+		if !ok {
+			continue
+		}
+
+		if column, ok := query.column(columnName); ok {
+			return query, column, nil
+		}
+	}
+
+	return nil, "", qbtypes.ErrColumnNotFound
+}
+
+// keys returns the field keys for all the columns of the joined queries.
+func (m *fieldMapper) keys() map[string][]*telemetrytypes.TelemetryFieldKey {
+	keys := make(map[string][]*telemetrytypes.TelemetryFieldKey)
+	for _, query := range m.queries {
+		for _, column := range query.columns {
+			name := query.name + "." + column
+			keys[name] = []*telemetrytypes.TelemetryFieldKey{{Name: name}}
+		}
+		for alias := range query.aliases {
+			name := query.name + "." + alias
+			keys[name] = []*telemetrytypes.TelemetryFieldKey{{Name: name}}
+		}
+	}
+
+	return keys
+}
+
+func (m *fieldMapper) ColumnFor(_ context.Context, key *telemetrytypes.TelemetryFieldKey) (*schema.Column, error) {
+	_, column, err := m.resolve(key.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.Column{Name: column, Type: schema.ColumnTypeString}, nil
+}
+
+func (m *fieldMapper) FieldFor(_ context.Context, key *telemetrytypes.TelemetryFieldKey) (string, error) {
+	query, column, err := m.resolve(key.Name)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("`%s`.`%s`", query.name, column), nil
+}
+
+func (m *fieldMapper) ColumnExpressionFor(
+	ctx context.Context,
+	key *telemetrytypes.TelemetryFieldKey,
+	_ map[string][]*telemetrytypes.TelemetryFieldKey,
+) (string, error) {
+	fieldName, err := m.FieldFor(ctx, key)
+	if err != nil {
+		return "", errors.WithAdditionalf(err, "column `%s` not found, columns of the joined queries are referenced as `<query name>.<column>`", key.Name)
+	}
+
+	return fmt.Sprintf("%s AS `%s`", fieldName, key.Name), nil
+}
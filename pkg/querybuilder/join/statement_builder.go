@@ -0,0 +1,392 @@
+package join
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	grammar "github.com/SigNoz/signoz/pkg/parser/grammar"
+	"github.com/SigNoz/signoz/pkg/querybuilder"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+const (
+	leftCTEName  = "__join_left"
+	rightCTEName = "__join_right"
+)
+
+type joinStatementBuilder struct {
+	logger   *slog.Logger
+	settings factory.ProviderSettings
+}
+
+var _ qbtypes.JoinStatementBuilder = (*joinStatementBuilder)(nil)
+
+func NewJoinStatementBuilder(settings factory.ProviderSettings) *joinStatementBuilder {
+	joinSettings := factory.NewScopedProviderSettings(settings, "github.com/SigNoz/signoz/pkg/querybuilder/join")
+	return &joinStatementBuilder{
+		logger:   joinSettings.Logger(),
+		settings: settings,
+	}
+}
+
+// Build builds the join query. The statements of the joined queries are attached as the __join_left and
+// __join_right CTEs, which are joined under the names of the queries so that their columns are referenced as
+// `<query name>.<column>` in the on condition, filter, select fields, group by, aggregations and order.
+func (b *joinStatementBuilder) Build(
+	ctx context.Context,
+	start uint64,
+	end uint64,
+	requestType qbtypes.RequestType,
+	query qbtypes.QueryBuilderJoin,
+	left *qbtypes.JoinedQuery,
+	right *qbtypes.JoinedQuery,
+	variables map[string]qbtypes.VariableItem,
+) (*qbtypes.Statement, error) {
+	start = querybuilder.ToNanoSecs(start)
+	end = querybuilder.ToNanoSecs(end)
+
+	leftQuery, err := newJoinedQuery(left.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	rightQuery, err := newJoinedQuery(right.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	fm := newFieldMapper(leftQuery, rightQuery)
+	cb := newConditionBuilder(fm)
+	keys := fm.keys()
+
+	from, err := buildFrom(query, leftQuery, rightQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.From(from)
+
+	var stmt *qbtypes.Statement
+	switch requestType {
+	case qbtypes.RequestTypeRaw:
+		stmt, err = b.buildListQuery(ctx, sb, query, fm, cb, keys, leftQuery, rightQuery, variables)
+	case qbtypes.RequestTypeScalar:
+		stmt, err = b.buildScalarQuery(ctx, sb, query, fm, cb, keys, start, end, variables)
+	default:
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "unsupported request type %s for join '%s'", requestType.StringValue(), query.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cteFragments := []string{
+		fmt.Sprintf("%s AS (%s)", leftCTEName, left.Statement.Query),
+		fmt.Sprintf("%s AS (%s)", rightCTEName, right.Statement.Query),
+	}
+	cteArgs := [][]any{left.Statement.Args, right.Statement.Args}
+
+	stmt.Query = querybuilder.CombineCTEs(cteFragments) + stmt.Query
+	stmt.Args = querybuilder.PrependArgs(cteArgs, stmt.Args)
+	stmt.Warnings = append(append(append([]string{}, left.Statement.Warnings...), right.Statement.Warnings...), stmt.Warnings...)
+
+	return stmt, nil
+}
+
+func (b *joinStatementBuilder) buildListQuery(
+	ctx context.Context,
+	sb *sqlbuilder.SelectBuilder,
+	query qbtypes.QueryBuilderJoin,
+	fm *fieldMapper,
+	cb *conditionBuilder,
+	keys map[string][]*telemetrytypes.TelemetryFieldKey,
+	left *joinedQuery,
+	right *joinedQuery,
+	variables map[string]qbtypes.VariableItem,
+) (*qbtypes.Statement, error) {
+
+	selectFields := query.SelectFields
+	if len(selectFields) == 0 {
+		// select all the columns of the joined queries
+		for _, joined := range []*joinedQuery{left, right} {
+			for _, column := range joined.columns {
+				selectFields = append(selectFields, telemetrytypes.TelemetryFieldKey{Name: joined.name + "." + column})
+			}
+		}
+	}
+
+	for index := range selectFields {
+		colExpr, err := fm.ColumnExpressionFor(ctx, &selectFields[index], keys)
+		if err != nil {
+			return nil, err
+		}
+		sb.SelectMore(colExpr)
+	}
+
+	preparedWhereClause, err := b.addFilterCondition(sb, query, fm, cb, keys, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, orderBy := range query.Order {
+		fieldName, err := fm.FieldFor(ctx, &orderBy.Key.TelemetryFieldKey)
+		if err != nil {
+			return nil, errors.WithAdditionalf(err, "order by key `%s` is not a column of the joined queries", orderBy.Key.Name)
+		}
+		sb.OrderBy(fmt.Sprintf("%s %s", fieldName, orderBy.Direction.StringValue()))
+	}
+
+	if query.Limit > 0 {
+		sb.Limit(query.Limit)
+	} else {
+		sb.Limit(100)
+	}
+
+	if query.Offset > 0 {
+		sb.Offset(query.Offset)
+	}
+
+	mainSQL, mainArgs := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+
+	stmt := &qbtypes.Statement{
+		Query: mainSQL,
+		Args:  mainArgs,
+	}
+	if preparedWhereClause != nil {
+		stmt.Warnings = preparedWhereClause.Warnings
+		stmt.WarningsDocURL = preparedWhereClause.WarningsDocURL
+	}
+
+	return stmt, nil
+}
+
+func (b *joinStatementBuilder) buildScalarQuery(
+	ctx context.Context,
+	sb *sqlbuilder.SelectBuilder,
+	query qbtypes.QueryBuilderJoin,
+	fm *fieldMapper,
+	cb *conditionBuilder,
+	keys map[string][]*telemetrytypes.TelemetryFieldKey,
+	start, end uint64,
+	variables map[string]qbtypes.VariableItem,
+) (*qbtypes.Statement, error) {
+
+	aggregations, err := query.JoinAggregations()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(aggregations) == 0 {
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "at least one aggregation is required for join '%s'", query.Name)
+	}
+
+	for _, gb := range query.GroupBy {
+		fieldName, err := fm.FieldFor(ctx, &gb.TelemetryFieldKey)
+		if err != nil {
+			return nil, errors.WithAdditionalf(err, "group by key `%s` is not a column of the joined queries", gb.Name)
+		}
+		sb.SelectMore(fmt.Sprintf("toString(%s) AS `%s`", fieldName, gb.TelemetryFieldKey.Name))
+	}
+
+	// for scalar queries, the rate would be end-start
+	rateInterval := (end - start) / querybuilder.NsToSeconds
+
+	aggExprRewriter := querybuilder.NewAggExprRewriter(b.settings, nil, fm, cb, nil)
+
+	allAggChArgs := []any{}
+	for idx, aggregation := range aggregations {
+		rewritten, chArgs, err := aggExprRewriter.Rewrite(ctx, aggregation.Expression, rateInterval, keys)
+		if err != nil {
+			return nil, err
+		}
+		allAggChArgs = append(allAggChArgs, chArgs...)
+		sb.SelectMore(fmt.Sprintf("%s AS __result_%d", rewritten, idx))
+	}
+
+	preparedWhereClause, err := b.addFilterCondition(sb, query, fm, cb, keys, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	sb.GroupBy(querybuilder.GroupByKeys(query.GroupBy)...)
+
+	if query.Having != nil && query.Having.Expression != "" {
+		rewriter := querybuilder.NewHavingExpressionRewriter()
+		sb.Having(rewriter.RewriteForJoin(query.Having.Expression, aggregations))
+	}
+
+	for _, orderBy := range query.Order {
+		if idx, ok := aggOrderBy(orderBy, aggregations); ok {
+			sb.OrderBy(fmt.Sprintf("__result_%d %s", idx, orderBy.Direction.StringValue()))
+		} else {
+			sb.OrderBy(fmt.Sprintf("`%s` %s", orderBy.Key.Name, orderBy.Direction.StringValue()))
+		}
+	}
+
+	// if there is no order by, then use the __result_0 as the order by
+	if len(query.Order) == 0 {
+		sb.OrderBy("__result_0 DESC")
+	}
+
+	if query.Limit > 0 {
+		sb.Limit(query.Limit)
+	}
+
+	if query.Offset > 0 {
+		sb.Offset(query.Offset)
+	}
+
+	mainSQL, mainArgs := sb.BuildWithFlavor(sqlbuilder.ClickHouse, allAggChArgs...)
+
+	stmt := &qbtypes.Statement{
+		Query: mainSQL,
+		Args:  mainArgs,
+	}
+	if preparedWhereClause != nil {
+		stmt.Warnings = preparedWhereClause.Warnings
+		stmt.WarningsDocURL = preparedWhereClause.WarningsDocURL
+	}
+
+	return stmt, nil
+}
+
+// addFilterCondition filters the joined rows
+func (b *joinStatementBuilder) addFilterCondition(
+	sb *sqlbuilder.SelectBuilder,
+	query qbtypes.QueryBuilderJoin,
+	fm *fieldMapper,
+	cb *conditionBuilder,
+	keys map[string][]*telemetrytypes.TelemetryFieldKey,
+	variables map[string]qbtypes.VariableItem,
+) (*querybuilder.PreparedWhereClause, error) {
+	if query.Filter == nil || query.Filter.Expression == "" {
+		return nil, nil
+	}
+
+	preparedWhereClause, err := querybuilder.PrepareWhereClause(query.Filter.Expression, querybuilder.FilterExprVisitorOpts{
+		Logger:           b.logger,
+		FieldMapper:      fm,
+		ConditionBuilder: cb,
+		FieldKeys:        keys,
+		Variables:        variables,
+	}, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	sb.AddWhereClause(preparedWhereClause.WhereClause)
+
+	return preparedWhereClause, nil
+}
+
+// buildFrom joins the CTEs of the joined queries on the on condition of the join.
+func buildFrom(query qbtypes.QueryBuilderJoin, left *joinedQuery, right *joinedQuery) (string, error) {
+	var operator string
+	switch query.Type {
+	case qbtypes.JoinTypeInner:
+		operator = "INNER JOIN"
+	case qbtypes.JoinTypeLeft:
+		operator = "LEFT JOIN"
+	case qbtypes.JoinTypeRight:
+		operator = "RIGHT JOIN"
+	case qbtypes.JoinTypeFull:
+		operator = "FULL OUTER JOIN"
+	case qbtypes.JoinTypeCross:
+		return fmt.Sprintf("%s AS `%s` CROSS JOIN %s AS `%s`", leftCTEName, left.name, rightCTEName, right.name), nil
+	default:
+		return "", errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid join type '%s' for join '%s'", query.Type.StringValue(), query.Name)
+	}
+
+	on, err := buildOnCondition(query, newFieldMapper(left), newFieldMapper(right))
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s AS `%s` %s %s AS `%s` ON %s", leftCTEName, left.name, operator, rightCTEName, right.name, on), nil
+}
+
+// buildOnCondition builds the on condition of the join, which must be a conjunction of equalities between a column
+// of the left query and a column of the right query, like `A.trace_id = B.trace_id AND A.span_id = B.span_id`.
+func buildOnCondition(query qbtypes.QueryBuilderJoin, left *fieldMapper, right *fieldMapper) (string, error) {
+	invalid := func(reason string, args ...any) error {
+		return errors.NewInvalidInputf(
+			errors.CodeInvalidInput,
+			"invalid on condition `%s` for join '%s': %s",
+			query.On,
+			query.Name,
+			fmt.Sprintf(reason, args...),
+		).WithAdditional(
+			fmt.Sprintf("The on condition must compare the columns of the left and right queries with = and AND, e.g. `%s.trace_id = %s.trace_id`", query.Left.Name, query.Right.Name),
+		)
+	}
+
+	var tokens []antlr.Token
+	lexer := grammar.NewFilterQueryLexer(antlr.NewInputStream(query.On))
+	for {
+		tok := lexer.NextToken()
+		if tok.GetTokenType() == antlr.TokenEOF {
+			break
+		}
+		tokens = append(tokens, tok)
+	}
+
+	if len(tokens) == 0 {
+		return "", invalid("the condition is empty")
+	}
+
+	var conds []string
+	for idx := 0; idx < len(tokens); idx += 4 {
+		if idx+2 >= len(tokens) ||
+			tokens[idx].GetTokenType() != grammar.FilterQueryLexerKEY ||
+			tokens[idx+1].GetTokenType() != grammar.FilterQueryLexerEQUALS ||
+			tokens[idx+2].GetTokenType() != grammar.FilterQueryLexerKEY {
+			return "", invalid("expected an equality between two columns at position %d", tokens[idx].GetStart())
+		}
+
+		if idx+3 < len(tokens) && tokens[idx+3].GetTokenType() != grammar.FilterQueryLexerAND {
+			return "", invalid("expected AND at position %d", tokens[idx+3].GetStart())
+		}
+
+		if idx+3 == len(tokens)-1 {
+			return "", invalid("expected an equality after the last AND")
+		}
+
+		lhs := &telemetrytypes.TelemetryFieldKey{Name: tokens[idx].GetText()}
+		rhs := &telemetrytypes.TelemetryFieldKey{Name: tokens[idx+2].GetText()}
+
+		// the columns can be compared in either order
+		leftField, leftErr := left.FieldFor(context.Background(), lhs)
+		rightField, rightErr := right.FieldFor(context.Background(), rhs)
+		if leftErr != nil || rightErr != nil {
+			leftField, leftErr = left.FieldFor(context.Background(), rhs)
+			rightField, rightErr = right.FieldFor(context.Background(), lhs)
+		}
+		if leftErr != nil || rightErr != nil {
+			return "", invalid("`%s = %s` must compare a column of query '%s' with a column of query '%s'", lhs.Name, rhs.Name, query.Left.Name, query.Right.Name)
+		}
+
+		conds = append(conds, fmt.Sprintf("%s = %s", leftField, rightField))
+	}
+
+	return strings.Join(conds, " AND "), nil
+}
+
+func aggOrderBy(k qbtypes.OrderBy, aggregations []qbtypes.JoinAggregation) (int, bool) {
+	for i, agg := range aggregations {
+		if k.Key.Name == agg.Alias ||
+			k.Key.Name == agg.Expression ||
+			k.Key.Name == strconv.Itoa(i) ||
+			k.Key.Name == fmt.Sprintf("__result_%d", i) {
+			return i, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,213 @@
+package join
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJoinStatementBuilder(t *testing.T) {
+	logs := &qbtypes.JoinedQuery{
+		Spec: qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{
+			Name:         "A",
+			Signal:       telemetrytypes.SignalLogs,
+			SelectFields: []telemetrytypes.TelemetryFieldKey{{Name: "trace_id"}, {Name: "body"}},
+		},
+		Statement: &qbtypes.Statement{
+			Query: "SELECT timestamp, id, trace_id AS `trace_id`, body AS `body` FROM logs WHERE ts >= ? LIMIT ?",
+			Args:  []any{1, 10000},
+		},
+	}
+
+	traces := &qbtypes.JoinedQuery{
+		Spec: qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]{
+			Name:         "B",
+			Signal:       telemetrytypes.SignalTraces,
+			SelectFields: []telemetrytypes.TelemetryFieldKey{{Name: "trace_id"}, {Name: "duration_nano"}},
+		},
+		Statement: &qbtypes.Statement{
+			Query: "SELECT trace_id AS `trace_id`, duration_nano AS `duration_nano` FROM traces WHERE ts >= ? LIMIT ?",
+			Args:  []any{2, 10000},
+		},
+	}
+
+	aggregatedTraces := &qbtypes.JoinedQuery{
+		Spec: qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]{
+			Name:         "B",
+			Signal:       telemetrytypes.SignalTraces,
+			Aggregations: []qbtypes.TraceAggregation{{Expression: "count()", Alias: "spans"}},
+			GroupBy: []qbtypes.GroupByKey{
+				{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "trace_id"}},
+			},
+		},
+		Statement: &qbtypes.Statement{
+			Query: "SELECT toString(trace_id) AS `trace_id`, count() AS __result_0 FROM traces WHERE ts >= ? GROUP BY `trace_id`",
+			Args:  []any{2},
+		},
+	}
+
+	cases := []struct {
+		name        string
+		requestType qbtypes.RequestType
+		query       qbtypes.QueryBuilderJoin
+		left        *qbtypes.JoinedQuery
+		right       *qbtypes.JoinedQuery
+		expected    qbtypes.Statement
+		expectedErr string
+	}{
+		{
+			name:        "list join of logs and traces",
+			requestType: qbtypes.RequestTypeRaw,
+			query: qbtypes.QueryBuilderJoin{
+				Name:  "C",
+				Left:  qbtypes.QueryRef{Name: "A"},
+				Right: qbtypes.QueryRef{Name: "B"},
+				Type:  qbtypes.JoinTypeInner,
+				On:    "A.trace_id = B.trace_id",
+				Filter: &qbtypes.Filter{
+					Expression: "B.duration_nano > 1000000",
+				},
+				Order: []qbtypes.OrderBy{
+					{Key: qbtypes.OrderByKey{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "B.duration_nano"}}, Direction: qbtypes.OrderDirectionDesc},
+				},
+				Limit: 10,
+			},
+			left:  logs,
+			right: traces,
+			expected: qbtypes.Statement{
+				Query: "WITH __join_left AS (SELECT timestamp, id, trace_id AS `trace_id`, body AS `body` FROM logs WHERE ts >= ? LIMIT ?), __join_right AS (SELECT trace_id AS `trace_id`, duration_nano AS `duration_nano` FROM traces WHERE ts >= ? LIMIT ?) SELECT `A`.`timestamp` AS `A.timestamp`, `A`.`id` AS `A.id`, `A`.`trace_id` AS `A.trace_id`, `A`.`body` AS `A.body`, `B`.`trace_id` AS `B.trace_id`, `B`.`duration_nano` AS `B.duration_nano` FROM __join_left AS `A` INNER JOIN __join_right AS `B` ON `A`.`trace_id` = `B`.`trace_id` WHERE `B`.`duration_nano` > ? ORDER BY `B`.`duration_nano` desc LIMIT ?",
+				Args:  []any{1, 10000, 2, 10000, float64(1000000), 10},
+			},
+		},
+		{
+			name:        "list join with select fields and reversed on condition",
+			requestType: qbtypes.RequestTypeRaw,
+			query: qbtypes.QueryBuilderJoin{
+				Name:         "C",
+				Left:         qbtypes.QueryRef{Name: "A"},
+				Right:        qbtypes.QueryRef{Name: "B"},
+				Type:         qbtypes.JoinTypeLeft,
+				On:           "B.trace_id = A.trace_id",
+				SelectFields: []telemetrytypes.TelemetryFieldKey{{Name: "A.body"}, {Name: "B.duration_nano"}},
+				Offset:       20,
+			},
+			left:  logs,
+			right: traces,
+			expected: qbtypes.Statement{
+				Query: "WITH __join_left AS (SELECT timestamp, id, trace_id AS `trace_id`, body AS `body` FROM logs WHERE ts >= ? LIMIT ?), __join_right AS (SELECT trace_id AS `trace_id`, duration_nano AS `duration_nano` FROM traces WHERE ts >= ? LIMIT ?) SELECT `A`.`body` AS `A.body`, `B`.`duration_nano` AS `B.duration_nano` FROM __join_left AS `A` LEFT JOIN __join_right AS `B` ON `A`.`trace_id` = `B`.`trace_id` LIMIT ? OFFSET ?",
+				Args:  []any{1, 10000, 2, 10000, 100, 20},
+			},
+		},
+		{
+			name:        "scalar join with an aggregated query",
+			requestType: qbtypes.RequestTypeScalar,
+			query: qbtypes.QueryBuilderJoin{
+				Name:  "C",
+				Left:  qbtypes.QueryRef{Name: "A"},
+				Right: qbtypes.QueryRef{Name: "B"},
+				Type:  qbtypes.JoinTypeInner,
+				On:    "A.trace_id = B.trace_id",
+				GroupBy: []qbtypes.GroupByKey{
+					{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "A.trace_id"}},
+				},
+				Aggregations: []any{
+					qbtypes.JoinAggregation{Expression: "count()", Alias: "logs"},
+					map[string]any{"expression": "max(B.spans)"},
+				},
+				Having: &qbtypes.Having{Expression: "logs > 10"},
+				Order: []qbtypes.OrderBy{
+					{Key: qbtypes.OrderByKey{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "max(B.spans)"}}, Direction: qbtypes.OrderDirectionDesc},
+					{Key: qbtypes.OrderByKey{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "A.trace_id"}}, Direction: qbtypes.OrderDirectionAsc},
+				},
+				Limit: 10,
+			},
+			left:  logs,
+			right: aggregatedTraces,
+			expected: qbtypes.Statement{
+				Query: "WITH __join_left AS (SELECT timestamp, id, trace_id AS `trace_id`, body AS `body` FROM logs WHERE ts >= ? LIMIT ?), __join_right AS (SELECT toString(trace_id) AS `trace_id`, count() AS __result_0 FROM traces WHERE ts >= ? GROUP BY `trace_id`) SELECT toString(`A`.`trace_id`) AS `A.trace_id`, count() AS __result_0, max(multiIf(true, `B`.`__result_0`, NULL)) AS __result_1 FROM __join_left AS `A` INNER JOIN __join_right AS `B` ON `A`.`trace_id` = `B`.`trace_id` GROUP BY `A.trace_id` HAVING __result_0 > 10 ORDER BY __result_1 desc, `A.trace_id` asc LIMIT ?",
+				Args:  []any{1, 10000, 2, 10},
+			},
+		},
+		{
+			name:        "cross join",
+			requestType: qbtypes.RequestTypeRaw,
+			query: qbtypes.QueryBuilderJoin{
+				Name:         "C",
+				Left:         qbtypes.QueryRef{Name: "A"},
+				Right:        qbtypes.QueryRef{Name: "B"},
+				Type:         qbtypes.JoinTypeCross,
+				SelectFields: []telemetrytypes.TelemetryFieldKey{{Name: "A.id"}},
+			},
+			left:  logs,
+			right: traces,
+			expected: qbtypes.Statement{
+				Query: "WITH __join_left AS (SELECT timestamp, id, trace_id AS `trace_id`, body AS `body` FROM logs WHERE ts >= ? LIMIT ?), __join_right AS (SELECT trace_id AS `trace_id`, duration_nano AS `duration_nano` FROM traces WHERE ts >= ? LIMIT ?) SELECT `A`.`id` AS `A.id` FROM __join_left AS `A` CROSS JOIN __join_right AS `B` LIMIT ?",
+				Args:  []any{1, 10000, 2, 10000, 100},
+			},
+		},
+		{
+			name:        "on condition with another operator",
+			requestType: qbtypes.RequestTypeRaw,
+			query: qbtypes.QueryBuilderJoin{
+				Name:  "C",
+				Left:  qbtypes.QueryRef{Name: "A"},
+				Right: qbtypes.QueryRef{Name: "B"},
+				Type:  qbtypes.JoinTypeInner,
+				On:    "A.trace_id != B.trace_id",
+			},
+			left:        logs,
+			right:       traces,
+			expectedErr: "invalid on condition `A.trace_id != B.trace_id` for join 'C': expected an equality between two columns at position 0",
+		},
+		{
+			name:        "on condition comparing the same query",
+			requestType: qbtypes.RequestTypeRaw,
+			query: qbtypes.QueryBuilderJoin{
+				Name:  "C",
+				Left:  qbtypes.QueryRef{Name: "A"},
+				Right: qbtypes.QueryRef{Name: "B"},
+				Type:  qbtypes.JoinTypeInner,
+				On:    "A.trace_id = B.trace_id AND A.id = A.trace_id",
+			},
+			left:        logs,
+			right:       traces,
+			expectedErr: "invalid on condition `A.trace_id = B.trace_id AND A.id = A.trace_id` for join 'C': `A.id = A.trace_id` must compare a column of query 'A' with a column of query 'B'",
+		},
+		{
+			name:        "unknown column",
+			requestType: qbtypes.RequestTypeRaw,
+			query: qbtypes.QueryBuilderJoin{
+				Name:         "C",
+				Left:         qbtypes.QueryRef{Name: "A"},
+				Right:        qbtypes.QueryRef{Name: "B"},
+				Type:         qbtypes.JoinTypeInner,
+				On:           "A.trace_id = B.trace_id",
+				SelectFields: []telemetrytypes.TelemetryFieldKey{{Name: "B.name"}},
+			},
+			left:        logs,
+			right:       traces,
+			expectedErr: qbtypes.ErrColumnNotFound.Error(),
+		},
+	}
+
+	statementBuilder := NewJoinStatementBuilder(instrumentationtest.New().ToProviderSettings())
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			q, err := statementBuilder.Build(context.Background(), 1747947419000, 1747983448000, c.requestType, c.query, c.left, c.right, nil)
+
+			if c.expectedErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), c.expectedErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, c.expected.Query, q.Query)
+				require.Equal(t, c.expected.Args, q.Args)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package rulestatehistory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	schema "github.com/SigNoz/signoz-otel-collector/cmd/signozschemamigrator/schema_migrator"
+	"github.com/SigNoz/signoz/pkg/querybuilder"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+)
+
+// the columns of the rule state history which are not labels of the alerts
+var columns = []string{
+	qbtypes.AlertAnalyticsKeyRuleID,
+	qbtypes.AlertAnalyticsKeyRuleName,
+	qbtypes.AlertAnalyticsKeyFingerprint,
+	qbtypes.AlertAnalyticsKeyLabels,
+}
+
+// fieldMapper maps the rule_id, rule_name, fingerprint and labels keys to the columns of the rule state history, any
+// other key is a label of the alerts which is extracted from the labels column.
+type fieldMapper struct{}
+
+var _ qbtypes.FieldMapper = (*fieldMapper)(nil)
+
+func newFieldMapper() *fieldMapper {
+	return &fieldMapper{}
+}
+
+func isColumn(name string) bool {
+	return slices.Contains(columns, name)
+}
+
+// labelName escapes the name of a label for a string literal.
+func labelName(name string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(name, `\`, `\\`), `'`, `\'`)
+}
+
+// keys returns the field keys referenced by the filter expression.
+func (m *fieldMapper) keys(expression string) map[string][]*telemetrytypes.TelemetryFieldKey {
+	keys := make(map[string][]*telemetrytypes.TelemetryFieldKey)
+	for _, selector := range querybuilder.QueryStringToKeysSelectors(expression) {
+		keys[selector.Name] = []*telemetrytypes.TelemetryFieldKey{{Name: selector.Name}}
+	}
+
+	return keys
+}
+
+func (m *fieldMapper) ColumnFor(_ context.Context, key *telemetrytypes.TelemetryFieldKey) (*schema.Column, error) {
+	if isColumn(key.Name) {
+		return &schema.Column{Name: key.Name, Type: schema.ColumnTypeString}, nil
+	}
+
+	return &schema.Column{Name: qbtypes.AlertAnalyticsKeyLabels, Type: schema.ColumnTypeString}, nil
+}
+
+func (m *fieldMapper) FieldFor(_ context.Context, key *telemetrytypes.TelemetryFieldKey) (string, error) {
+	if isColumn(key.Name) {
+		return key.Name, nil
+	}
+
+	return fmt.Sprintf("JSONExtractString(%s, '%s')", qbtypes.AlertAnalyticsKeyLabels, labelName(key.Name)), nil
+}
+
+func (m *fieldMapper) ColumnExpressionFor(
+	ctx context.Context,
+	key *telemetrytypes.TelemetryFieldKey,
+	_ map[string][]*telemetrytypes.TelemetryFieldKey,
+) (string, error) {
+	fieldName, err := m.FieldFor(ctx, key)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s AS `%s`", fieldName, key.Name), nil
+}
@@ -0,0 +1,399 @@
+package rulestatehistory
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/querybuilder"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+const (
+	DBName    = "signoz_analytics"
+	TableName = "distributed_rule_state_history_v0"
+
+	transitionsCTEName = "__transitions"
+	episodesCTEName    = "__episodes"
+	limitCTEName       = "__limit_cte"
+)
+
+// The states recorded in the rule state history.
+const (
+	StateFiring   = "firing"
+	StateNoData   = "nodata"
+	StateInactive = "inactive"
+	// written without a state change when a firing alert is acknowledged
+	StateAcknowledged = "acknowledged"
+	// written without a state change when the acknowledgement of a firing alert is withdrawn
+	StateUnacknowledged = "unacknowledged"
+)
+
+type alertAnalyticsStatementBuilder struct {
+	logger *slog.Logger
+}
+
+var _ qbtypes.AlertAnalyticsStatementBuilder = (*alertAnalyticsStatementBuilder)(nil)
+
+func NewAlertAnalyticsStatementBuilder(settings factory.ProviderSettings) *alertAnalyticsStatementBuilder {
+	alertAnalyticsSettings := factory.NewScopedProviderSettings(settings, "github.com/SigNoz/signoz/pkg/querybuilder/rulestatehistory")
+	return &alertAnalyticsStatementBuilder{
+		logger: alertAnalyticsSettings.Logger(),
+	}
+}
+
+// Build builds the alert analytics query over the history of the rules with the given ids, which are the rules of the
+// organization as the history is not scoped by organization. The state changes of the alerts between start and end are
+// attached as the __transitions CTE, in which every change is numbered with the episode it belongs to: the number of
+// times the alert was resolved before it. The __episodes CTE has a row for every episode which started firing in the
+// range, with the time it started firing, was acknowledged and was resolved, if it was.
+func (b *alertAnalyticsStatementBuilder) Build(
+	ctx context.Context,
+	start uint64,
+	end uint64,
+	requestType qbtypes.RequestType,
+	query qbtypes.AlertAnalyticsQuery,
+	ruleIDs []string,
+	variables map[string]qbtypes.VariableItem,
+) (*qbtypes.Statement, error) {
+	fm := newFieldMapper()
+	cb := newConditionBuilder(fm)
+
+	transitions, preparedWhereClause, err := b.buildTransitions(start, end, query, ruleIDs, fm, cb, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	cteFragments := []string{
+		fmt.Sprintf("%s AS (%s)", transitionsCTEName, transitions.Query),
+		fmt.Sprintf("%s AS (%s)", episodesCTEName, buildEpisodes()),
+	}
+	cteArgs := [][]any{transitions.Args}
+
+	var stmt *qbtypes.Statement
+	switch requestType {
+	case qbtypes.RequestTypeRaw:
+		stmt, err = b.buildListQuery(ctx, query, fm)
+	case qbtypes.RequestTypeTimeSeries:
+		var limitCTE *qbtypes.Statement
+		stmt, limitCTE, err = b.buildTimeSeriesQuery(ctx, start, end, query, fm)
+		if limitCTE != nil {
+			cteFragments = append(cteFragments, fmt.Sprintf("%s AS (%s)", limitCTEName, limitCTE.Query))
+			cteArgs = append(cteArgs, limitCTE.Args)
+		}
+	case qbtypes.RequestTypeScalar:
+		stmt, err = b.buildScalarQuery(ctx, end, query, fm, query.Having, query.Order, query.Limit, query.Offset)
+	default:
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "unsupported request type %s for alert analytics query '%s'", requestType.StringValue(), query.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	stmt.Query = querybuilder.CombineCTEs(cteFragments) + stmt.Query
+	stmt.Args = querybuilder.PrependArgs(cteArgs, stmt.Args)
+	if preparedWhereClause != nil {
+		stmt.Warnings = preparedWhereClause.Warnings
+		stmt.WarningsDocURL = preparedWhereClause.WarningsDocURL
+	}
+
+	return stmt, nil
+}
+
+// buildTransitions selects the state changes and the acknowledgements of the alerts of the rules which match the
+// filter.
+func (b *alertAnalyticsStatementBuilder) buildTransitions(
+	start, end uint64,
+	query qbtypes.AlertAnalyticsQuery,
+	ruleIDs []string,
+	fm *fieldMapper,
+	cb *conditionBuilder,
+	variables map[string]qbtypes.VariableItem,
+) (*qbtypes.Statement, *querybuilder.PreparedWhereClause, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(
+		"rule_id",
+		"rule_name",
+		"fingerprint",
+		"labels",
+		"state",
+		"unix_milli",
+		fmt.Sprintf("sum(state = '%s') OVER (PARTITION BY rule_id, fingerprint ORDER BY unix_milli ASC ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING) AS episode", StateInactive),
+	)
+	sb.From(fmt.Sprintf("%s.%s", DBName, TableName))
+	ruleIDValues := make([]any, len(ruleIDs))
+	for idx, ruleID := range ruleIDs {
+		ruleIDValues[idx] = ruleID
+	}
+
+	sb.Where(
+		sb.GE("unix_milli", start),
+		sb.LT("unix_milli", end),
+		// an organization without any rule has no history
+		sb.In("rule_id", ruleIDValues...),
+		fmt.Sprintf("(state_changed = true OR state = '%s')", StateAcknowledged),
+	)
+
+	var preparedWhereClause *querybuilder.PreparedWhereClause
+	if query.Filter != nil && query.Filter.Expression != "" {
+		var err error
+		preparedWhereClause, err = querybuilder.PrepareWhereClause(query.Filter.Expression, querybuilder.FilterExprVisitorOpts{
+			Logger:           b.logger,
+			FieldMapper:      fm,
+			ConditionBuilder: cb,
+			FieldKeys:        fm.keys(query.Filter.Expression),
+			Variables:        variables,
+		}, 0, 0)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		sb.AddWhereClause(preparedWhereClause.WhereClause)
+	}
+
+	transitionsSQL, transitionsArgs := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+	return &qbtypes.Statement{Query: transitionsSQL, Args: transitionsArgs}, preparedWhereClause, nil
+}
+
+// buildEpisodes groups the transitions into episodes. The episodes which were already firing at the start of the
+// range are dropped as the time they started firing is not known.
+func buildEpisodes() string {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(
+		"rule_id",
+		"any(rule_name) AS rule_name",
+		"fingerprint",
+		"any(labels) AS labels",
+		fmt.Sprintf("minIf(unix_milli, state IN ('%s', '%s')) AS firing_time", StateFiring, StateNoData),
+		fmt.Sprintf("minIf(unix_milli, state = '%s') AS resolved_time", StateInactive),
+		fmt.Sprintf("minIf(unix_milli, state = '%s') AS acked_time", StateAcknowledged),
+	)
+	sb.From(transitionsCTEName)
+	sb.GroupBy("rule_id", "fingerprint", "episode")
+	sb.Having(fmt.Sprintf("argMin(state, unix_milli) IN ('%s', '%s')", StateFiring, StateNoData))
+
+	episodesSQL, _ := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+	return episodesSQL
+}
+
+func (b *alertAnalyticsStatementBuilder) buildListQuery(
+	ctx context.Context,
+	query qbtypes.AlertAnalyticsQuery,
+	fm *fieldMapper,
+) (*qbtypes.Statement, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(
+		"fromUnixTimestamp64Milli(firing_time) AS timestamp",
+		"rule_id",
+		"rule_name",
+		"fingerprint",
+		"labels",
+		"firing_time",
+		"resolved_time",
+		"acked_time",
+	)
+	sb.From(episodesCTEName)
+
+	for _, orderBy := range query.Order {
+		var fieldName string
+		switch orderBy.Key.Name {
+		case "timestamp", "firing_time":
+			fieldName = "firing_time"
+		case "resolved_time", "acked_time":
+			fieldName = orderBy.Key.Name
+		default:
+			var err error
+			fieldName, err = fm.FieldFor(ctx, &orderBy.Key.TelemetryFieldKey)
+			if err != nil {
+				return nil, err
+			}
+		}
+		sb.OrderBy(fmt.Sprintf("%s %s", fieldName, orderBy.Direction.StringValue()))
+	}
+
+	// the latest episodes come first
+	if len(query.Order) == 0 {
+		sb.OrderBy("firing_time DESC")
+	}
+
+	if query.Limit > 0 {
+		sb.Limit(query.Limit)
+	} else {
+		sb.Limit(100)
+	}
+
+	if query.Offset > 0 {
+		sb.Offset(query.Offset)
+	}
+
+	mainSQL, mainArgs := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+	return &qbtypes.Statement{Query: mainSQL, Args: mainArgs}, nil
+}
+
+// buildTimeSeriesQuery buckets the episodes by the time they started firing. If there is a limit, only the top groups
+// of the range are returned, which are selected by the returned limit CTE.
+func (b *alertAnalyticsStatementBuilder) buildTimeSeriesQuery(
+	ctx context.Context,
+	start, end uint64,
+	query qbtypes.AlertAnalyticsQuery,
+	fm *fieldMapper,
+) (*qbtypes.Statement, *qbtypes.Statement, error) {
+	step := uint64(query.StepInterval.Seconds())
+	if step == 0 {
+		step = querybuilder.RecommendedStepInterval(start, end)
+	}
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(fmt.Sprintf("toStartOfInterval(toDateTime(intDiv(firing_time, 1000)), INTERVAL %d SECOND) AS ts", step))
+
+	groupExprs, err := b.selectGroupBy(ctx, sb, query, fm)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for idx, aggregation := range query.Aggregations {
+		sb.SelectMore(fmt.Sprintf("%s AS __result_%d", aggregationExpr(aggregation.Metric, end, false), idx))
+	}
+
+	sb.From(episodesCTEName)
+
+	var limitCTE *qbtypes.Statement
+	if query.Limit > 0 && len(query.GroupBy) > 0 {
+		limitCTE, err = b.buildScalarQuery(ctx, end, query, fm, nil, query.Order, query.Limit, 0)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		sb.Where(fmt.Sprintf("(%s) GLOBAL IN (SELECT %s FROM %s)", strings.Join(groupExprs, ", "), strings.Join(querybuilder.GroupByKeys(query.GroupBy), ", "), limitCTEName))
+	}
+
+	sb.GroupBy("ts")
+	sb.GroupBy(querybuilder.GroupByKeys(query.GroupBy)...)
+
+	if query.Having != nil && query.Having.Expression != "" {
+		rewriter := querybuilder.NewHavingExpressionRewriter()
+		sb.Having(rewriter.RewriteForAlertAnalytics(query.Having.Expression, query.Aggregations))
+	}
+
+	sb.OrderBy(querybuilder.GroupByKeys(query.GroupBy)...)
+	sb.OrderBy("ts ASC")
+
+	mainSQL, mainArgs := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+	return &qbtypes.Statement{Query: mainSQL, Args: mainArgs}, limitCTE, nil
+}
+
+func (b *alertAnalyticsStatementBuilder) buildScalarQuery(
+	ctx context.Context,
+	end uint64,
+	query qbtypes.AlertAnalyticsQuery,
+	fm *fieldMapper,
+	having *qbtypes.Having,
+	order []qbtypes.OrderBy,
+	limit int,
+	offset int,
+) (*qbtypes.Statement, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+
+	if _, err := b.selectGroupBy(ctx, sb, query, fm); err != nil {
+		return nil, err
+	}
+
+	for idx, aggregation := range query.Aggregations {
+		sb.SelectMore(fmt.Sprintf("%s AS __result_%d", aggregationExpr(aggregation.Metric, end, true), idx))
+	}
+
+	sb.From(episodesCTEName)
+	sb.GroupBy(querybuilder.GroupByKeys(query.GroupBy)...)
+
+	if having != nil && having.Expression != "" {
+		rewriter := querybuilder.NewHavingExpressionRewriter()
+		sb.Having(rewriter.RewriteForAlertAnalytics(having.Expression, query.Aggregations))
+	}
+
+	for _, orderBy := range order {
+		if idx, ok := aggOrderBy(orderBy, query.Aggregations); ok {
+			sb.OrderBy(fmt.Sprintf("__result_%d %s", idx, orderBy.Direction.StringValue()))
+		} else {
+			sb.OrderBy(fmt.Sprintf("`%s` %s", orderBy.Key.Name, orderBy.Direction.StringValue()))
+		}
+	}
+
+	// if there is no order by, then use the __result_0 as the order by
+	if len(order) == 0 {
+		sb.OrderBy("__result_0 DESC")
+	}
+
+	if limit > 0 {
+		sb.Limit(limit)
+	}
+
+	if offset > 0 {
+		sb.Offset(offset)
+	}
+
+	mainSQL, mainArgs := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+	return &qbtypes.Statement{Query: mainSQL, Args: mainArgs}, nil
+}
+
+// selectGroupBy selects the group by keys and returns their expressions.
+func (b *alertAnalyticsStatementBuilder) selectGroupBy(
+	ctx context.Context,
+	sb *sqlbuilder.SelectBuilder,
+	query qbtypes.AlertAnalyticsQuery,
+	fm *fieldMapper,
+) ([]string, error) {
+	exprs := make([]string, 0, len(query.GroupBy))
+	for _, gb := range query.GroupBy {
+		fieldName, err := fm.FieldFor(ctx, &gb.TelemetryFieldKey)
+		if err != nil {
+			return nil, err
+		}
+
+		expr := fmt.Sprintf("toString(%s)", fieldName)
+		exprs = append(exprs, expr)
+		sb.SelectMore(fmt.Sprintf("%s AS `%s`", expr, gb.TelemetryFieldKey.Name))
+	}
+
+	return exprs, nil
+}
+
+// aggregationExpr returns the expression of a metric over the episodes. The episodes which are still firing at the
+// end of the range count as firing until the end. The means are null instead of nan when there is no episode to
+// average if the result is nullable.
+func aggregationExpr(metric qbtypes.AlertAnalyticsMetric, end uint64, nullable bool) string {
+	mean := func(column string) string {
+		expr := fmt.Sprintf("avgIf((%s - firing_time) / 1000, %s > 0)", column, column)
+		if nullable {
+			return fmt.Sprintf("if(countIf(%s > 0) = 0, NULL, %s)", column, expr)
+		}
+		return expr
+	}
+
+	switch metric {
+	case qbtypes.AlertAnalyticsMetricMTTR:
+		return mean("resolved_time")
+	case qbtypes.AlertAnalyticsMetricMTTA:
+		return mean("acked_time")
+	case qbtypes.AlertAnalyticsMetricFiringDuration:
+		return fmt.Sprintf("sum((if(resolved_time > 0, resolved_time, %d) - firing_time) / 1000)", end)
+	}
+
+	return "count()"
+}
+
+func aggOrderBy(k qbtypes.OrderBy, aggregations []qbtypes.AlertAnalyticsAggregation) (int, bool) {
+	for i, agg := range aggregations {
+		if k.Key.Name == agg.Alias ||
+			k.Key.Name == agg.Metric.StringValue() ||
+			k.Key.Name == strconv.Itoa(i) ||
+			k.Key.Name == fmt.Sprintf("__result_%d", i) {
+			return i, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,113 @@
+package rulestatehistory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertAnalyticsStatementBuilder(t *testing.T) {
+	cases := []struct {
+		name        string
+		requestType qbtypes.RequestType
+		query       qbtypes.AlertAnalyticsQuery
+		expected    qbtypes.Statement
+		expectedErr string
+	}{
+		{
+			name:        "timeline of a rule",
+			requestType: qbtypes.RequestTypeRaw,
+			query: qbtypes.AlertAnalyticsQuery{
+				Name:   "A",
+				Filter: &qbtypes.Filter{Expression: "rule_id = 'r1' AND service = 'api'"},
+				Limit:  10,
+				Offset: 20,
+			},
+			expected: qbtypes.Statement{
+				Query: "WITH __transitions AS (SELECT rule_id, rule_name, fingerprint, labels, state, unix_milli, sum(state = 'inactive') OVER (PARTITION BY rule_id, fingerprint ORDER BY unix_milli ASC ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING) AS episode FROM signoz_analytics.distributed_rule_state_history_v0 WHERE unix_milli >= ? AND unix_milli < ? AND rule_id IN (?, ?) AND (state_changed = true OR state = 'acknowledged') AND (rule_id = ? AND JSONExtractString(labels, 'service') = ?)), __episodes AS (SELECT rule_id, any(rule_name) AS rule_name, fingerprint, any(labels) AS labels, minIf(unix_milli, state IN ('firing', 'nodata')) AS firing_time, minIf(unix_milli, state = 'inactive') AS resolved_time, minIf(unix_milli, state = 'acknowledged') AS acked_time FROM __transitions GROUP BY rule_id, fingerprint, episode HAVING argMin(state, unix_milli) IN ('firing', 'nodata')) SELECT fromUnixTimestamp64Milli(firing_time) AS timestamp, rule_id, rule_name, fingerprint, labels, firing_time, resolved_time, acked_time FROM __episodes ORDER BY firing_time DESC LIMIT ? OFFSET ?",
+				Args:  []any{uint64(1747947419000), uint64(1747983448000), "r1", "r2", "r1", "api", 10, 20},
+			},
+		},
+		{
+			name:        "response times by team",
+			requestType: qbtypes.RequestTypeScalar,
+			query: qbtypes.AlertAnalyticsQuery{
+				Name: "A",
+				Aggregations: []qbtypes.AlertAnalyticsAggregation{
+					{Metric: qbtypes.AlertAnalyticsMetricTriggers},
+					{Metric: qbtypes.AlertAnalyticsMetricMTTR, Alias: "resolve"},
+					{Metric: qbtypes.AlertAnalyticsMetricMTTA},
+				},
+				Filter: &qbtypes.Filter{Expression: "team EXISTS"},
+				GroupBy: []qbtypes.GroupByKey{
+					{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "team"}},
+				},
+				Having: &qbtypes.Having{Expression: "triggers >= 3 AND resolve <= 900"},
+				Order: []qbtypes.OrderBy{
+					{Key: qbtypes.OrderByKey{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "mtta"}}, Direction: qbtypes.OrderDirectionDesc},
+				},
+				Limit: 5,
+			},
+			expected: qbtypes.Statement{
+				Query: "WITH __transitions AS (SELECT rule_id, rule_name, fingerprint, labels, state, unix_milli, sum(state = 'inactive') OVER (PARTITION BY rule_id, fingerprint ORDER BY unix_milli ASC ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING) AS episode FROM signoz_analytics.distributed_rule_state_history_v0 WHERE unix_milli >= ? AND unix_milli < ? AND rule_id IN (?, ?) AND (state_changed = true OR state = 'acknowledged') AND JSONHas(labels, 'team')), __episodes AS (SELECT rule_id, any(rule_name) AS rule_name, fingerprint, any(labels) AS labels, minIf(unix_milli, state IN ('firing', 'nodata')) AS firing_time, minIf(unix_milli, state = 'inactive') AS resolved_time, minIf(unix_milli, state = 'acknowledged') AS acked_time FROM __transitions GROUP BY rule_id, fingerprint, episode HAVING argMin(state, unix_milli) IN ('firing', 'nodata')) SELECT toString(JSONExtractString(labels, 'team')) AS `team`, count() AS __result_0, if(countIf(resolved_time > 0) = 0, NULL, avgIf((resolved_time - firing_time) / 1000, resolved_time > 0)) AS __result_1, if(countIf(acked_time > 0) = 0, NULL, avgIf((acked_time - firing_time) / 1000, acked_time > 0)) AS __result_2 FROM __episodes GROUP BY `team` HAVING __result_0 >= 3 AND __result_1 <= 900 ORDER BY __result_2 desc LIMIT ?",
+				Args:  []any{uint64(1747947419000), uint64(1747983448000), "r1", "r2", 5},
+			},
+		},
+		{
+			name:        "top rules by firing duration over time",
+			requestType: qbtypes.RequestTypeTimeSeries,
+			query: qbtypes.AlertAnalyticsQuery{
+				Name:         "A",
+				StepInterval: qbtypes.Step{Duration: time.Hour},
+				Aggregations: []qbtypes.AlertAnalyticsAggregation{
+					{Metric: qbtypes.AlertAnalyticsMetricFiringDuration},
+				},
+				GroupBy: []qbtypes.GroupByKey{
+					{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "rule_name"}},
+				},
+				Limit: 3,
+			},
+			expected: qbtypes.Statement{
+				Query: "WITH __transitions AS (SELECT rule_id, rule_name, fingerprint, labels, state, unix_milli, sum(state = 'inactive') OVER (PARTITION BY rule_id, fingerprint ORDER BY unix_milli ASC ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING) AS episode FROM signoz_analytics.distributed_rule_state_history_v0 WHERE unix_milli >= ? AND unix_milli < ? AND rule_id IN (?, ?) AND (state_changed = true OR state = 'acknowledged')), __episodes AS (SELECT rule_id, any(rule_name) AS rule_name, fingerprint, any(labels) AS labels, minIf(unix_milli, state IN ('firing', 'nodata')) AS firing_time, minIf(unix_milli, state = 'inactive') AS resolved_time, minIf(unix_milli, state = 'acknowledged') AS acked_time FROM __transitions GROUP BY rule_id, fingerprint, episode HAVING argMin(state, unix_milli) IN ('firing', 'nodata')), __limit_cte AS (SELECT toString(rule_name) AS `rule_name`, sum((if(resolved_time > 0, resolved_time, 1747983448000) - firing_time) / 1000) AS __result_0 FROM __episodes GROUP BY `rule_name` ORDER BY __result_0 DESC LIMIT ?) SELECT toStartOfInterval(toDateTime(intDiv(firing_time, 1000)), INTERVAL 3600 SECOND) AS ts, toString(rule_name) AS `rule_name`, sum((if(resolved_time > 0, resolved_time, 1747983448000) - firing_time) / 1000) AS __result_0 FROM __episodes WHERE (toString(rule_name)) GLOBAL IN (SELECT `rule_name` FROM __limit_cte) GROUP BY ts, `rule_name` ORDER BY `rule_name`, ts ASC",
+				Args:  []any{uint64(1747947419000), uint64(1747983448000), "r1", "r2", 3},
+			},
+		},
+		{
+			name:        "unsupported request type",
+			requestType: qbtypes.RequestTypeDistribution,
+			query:       qbtypes.AlertAnalyticsQuery{Name: "A"},
+			expectedErr: "unsupported request type distribution for alert analytics query 'A'",
+		},
+	}
+
+	statementBuilder := NewAlertAnalyticsStatementBuilder(instrumentationtest.New().ToProviderSettings())
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			q, err := statementBuilder.Build(context.Background(), 1747947419000, 1747983448000, c.requestType, c.query, []string{"r1", "r2"}, nil)
+
+			if c.expectedErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), c.expectedErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, c.expected.Query, q.Query)
+				require.Equal(t, c.expected.Args, q.Args)
+			}
+		})
+	}
+}
+
+func TestAlertAnalyticsStatementBuilderWithoutRules(t *testing.T) {
+	statementBuilder := NewAlertAnalyticsStatementBuilder(instrumentationtest.New().ToProviderSettings())
+
+	q, err := statementBuilder.Build(context.Background(), 1747947419000, 1747983448000, qbtypes.RequestTypeRaw, qbtypes.AlertAnalyticsQuery{Name: "A"}, nil, nil)
+	require.NoError(t, err)
+	require.Contains(t, q.Query, "WHERE unix_milli >= ? AND unix_milli < ? AND 0 = 1 AND")
+	require.Equal(t, []any{uint64(1747947419000), uint64(1747983448000), 100}, q.Args)
+}
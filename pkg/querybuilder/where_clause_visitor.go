@@ -400,6 +400,10 @@ func (v *filterExpressionVisitor) VisitComparison(ctx *grammar.ComparisonContext
 	// Handle IN clause
 	if ctx.InClause() != nil || ctx.NotInClause() != nil {
 
+		if name, ok := subQueryRef(ctx); ok {
+			return v.subQueryCondition(keys, name, ctx.NotInClause() != nil)
+		}
+
 		var values []any
 		var retValue any
 		if ctx.InClause() != nil {
@@ -602,6 +606,56 @@ func (v *filterExpressionVisitor) warnIfLikeWithoutWildcards(op string, value an
 	}
 }
 
+// subQueryRef returns the name of the query referenced by `IN @name`, the reference is an unquoted value
+func subQueryRef(ctx *grammar.ComparisonContext) (string, bool) {
+	var valCtx grammar.IValueContext
+	if ctx.InClause() != nil {
+		valCtx = ctx.InClause().Value()
+	} else if ctx.NotInClause() != nil {
+		valCtx = ctx.NotInClause().Value()
+	}
+
+	if valCtx == nil || valCtx.KEY() == nil {
+		return "", false
+	}
+
+	name, ok := strings.CutPrefix(valCtx.KEY().GetText(), qbtypes.SubQueryRefPrefix)
+	return name, ok && name != ""
+}
+
+// subQueryCondition builds the condition matching the keys against the values of the referenced query, which is
+// attached as a CTE to the statement. The values of the referenced query are strings, so are the keys.
+func (v *filterExpressionVisitor) subQueryCondition(keys []*telemetrytypes.TelemetryFieldKey, name string, not bool) any {
+	op := "GLOBAL IN"
+	if not {
+		op = "GLOBAL NOT IN"
+	}
+
+	var conds []string
+	for _, key := range keys {
+		fieldName, err := v.fieldMapper.FieldFor(context.Background(), key)
+		if errors.Is(err, qbtypes.ErrColumnNotFound) {
+			// the key is not a column of this table, like an attribute for the resource filter,
+			// the condition is applied by the statement on the table which has it
+			conds = append(conds, "true")
+			continue
+		}
+		if err != nil {
+			v.errors = append(v.errors, fmt.Sprintf("failed to build sub query condition for key `%s`: %s", key.Name, err.Error()))
+			return ""
+		}
+		conds = append(conds, fmt.Sprintf("toString(%s) %s (SELECT * FROM %s)", fieldName, op, SubQueryCTEName(name)))
+	}
+
+	if len(conds) == 1 {
+		return conds[0]
+	}
+	if not {
+		return v.builder.And(conds...)
+	}
+	return v.builder.Or(conds...)
+}
+
 // VisitInClause handles IN expressions
 func (v *filterExpressionVisitor) VisitInClause(ctx *grammar.InClauseContext) any {
 	if ctx.ValueList() != nil {
@@ -0,0 +1,82 @@
+package clickhouserulestore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/query-service/utils/labels"
+	"github.com/SigNoz/signoz/pkg/telemetrymetrics"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+)
+
+const (
+	// recordingEnv is the deployment environment of the recorded series
+	recordingEnv = "default"
+	// recordingTemporality and recordingType are the temporality and the type of the recorded metrics, as stored
+	recordingTemporality = "Unspecified"
+	recordingType        = "Gauge"
+)
+
+type recording struct {
+	telemetryStore telemetrystore.TelemetryStore
+}
+
+func NewRecordingStore(telemetryStore telemetrystore.TelemetryStore) ruletypes.RecordingStore {
+	return &recording{telemetryStore: telemetryStore}
+}
+
+// Write implements ruletypes.RecordingStore. The samples are written as gauges, their series are written to the time
+// series table with the hour of the sample as the collector does, so that the recorded metrics are queried like any
+// other metric.
+func (store *recording) Write(ctx context.Context, samples []*ruletypes.RecordedSample) error {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	series, err := store.telemetryStore.ClickhouseDB().PrepareBatch(ctx, fmt.Sprintf("INSERT INTO %s.%s (env, temporality, metric_name, description, unit, type, is_monotonic, fingerprint, unix_milli, labels, attrs, scope_attrs, resource_attrs, __normalized)", telemetrymetrics.DBName, telemetrymetrics.TimeseriesV4TableName))
+	if err != nil {
+		return errors.WrapInternalf(err, errors.CodeInternal, "failed to prepare the batch of the recorded series")
+	}
+	defer series.Abort() //nolint:errcheck
+
+	values, err := store.telemetryStore.ClickhouseDB().PrepareBatch(ctx, fmt.Sprintf("INSERT INTO %s.%s (env, temporality, metric_name, fingerprint, unix_milli, value, flags)", telemetrymetrics.DBName, telemetrymetrics.SamplesV4TableName))
+	if err != nil {
+		return errors.WrapInternalf(err, errors.CodeInternal, "failed to prepare the batch of the recorded samples")
+	}
+	defer values.Abort() //nolint:errcheck
+
+	for _, sample := range samples {
+		metric := sample.Labels.Get(labels.MetricNameLabel)
+		fingerprint := sample.Labels.Hash()
+
+		lbls, err := json.Marshal(sample.Labels.Map())
+		if err != nil {
+			return errors.WrapInternalf(err, errors.CodeInternal, "failed to marshal the labels of the recorded series")
+		}
+
+		attrs := sample.Labels.Map()
+		delete(attrs, labels.MetricNameLabel)
+
+		if err := series.Append(recordingEnv, recordingTemporality, metric, "", "", recordingType, false, fingerprint, sample.Timestamp.Truncate(time.Hour).UnixMilli(), string(lbls), attrs, map[string]string{}, map[string]string{}, false); err != nil {
+			return errors.WrapInternalf(err, errors.CodeInternal, "failed to append to the batch of the recorded series")
+		}
+
+		if err := values.Append(recordingEnv, recordingTemporality, metric, fingerprint, sample.Timestamp.UnixMilli(), sample.Value, uint32(0)); err != nil {
+			return errors.WrapInternalf(err, errors.CodeInternal, "failed to append to the batch of the recorded samples")
+		}
+	}
+
+	if err := series.Send(); err != nil {
+		return errors.WrapInternalf(err, errors.CodeInternal, "failed to write the recorded series")
+	}
+
+	if err := values.Send(); err != nil {
+		return errors.WrapInternalf(err, errors.CodeInternal, "failed to write the recorded samples")
+	}
+
+	return nil
+}
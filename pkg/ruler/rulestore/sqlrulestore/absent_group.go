@@ -0,0 +1,71 @@
+package sqlrulestore
+
+import (
+	"context"
+
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	ruletypes "github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/uptrace/bun"
+)
+
+type absentGroup struct {
+	sqlstore sqlstore.SQLStore
+}
+
+func NewAbsentGroupStore(store sqlstore.SQLStore) ruletypes.AbsentGroupStore {
+	return &absentGroup{sqlstore: store}
+}
+
+func (r *absentGroup) ListAbsentGroups(ctx context.Context, ruleID string) ([]*ruletypes.AbsentGroup, error) {
+	groups := make([]*ruletypes.AbsentGroup, 0)
+	err := r.sqlstore.
+		BunDB().
+		NewSelect().
+		Model(&groups).
+		Where("rule_id = ?", ruleID).
+		Order("last_seen DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+func (r *absentGroup) UpsertAbsentGroups(ctx context.Context, groups []*ruletypes.AbsentGroup) error {
+	if len(groups) == 0 {
+		return nil
+	}
+
+	_, err := r.sqlstore.
+		BunDB().
+		NewInsert().
+		Model(&groups).
+		On("CONFLICT (rule_id, fingerprint) DO UPDATE").
+		Set("labels = EXCLUDED.labels").
+		Set("last_seen = EXCLUDED.last_seen").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *absentGroup) DeleteAbsentGroups(ctx context.Context, ruleID string, fingerprints []string) error {
+	query := r.sqlstore.
+		BunDB().
+		NewDelete().
+		Model(new(ruletypes.AbsentGroup)).
+		Where("rule_id = ?", ruleID)
+	if len(fingerprints) > 0 {
+		query = query.Where("fingerprint IN (?)", bun.In(fingerprints))
+	}
+
+	_, err := query.Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/SigNoz/signoz/pkg/cache"
 	"github.com/SigNoz/signoz/pkg/cache/memorycache"
 	"github.com/SigNoz/signoz/pkg/cache/rediscache"
+	"github.com/SigNoz/signoz/pkg/cache/tieredcache"
 	"github.com/SigNoz/signoz/pkg/emailing"
 	"github.com/SigNoz/signoz/pkg/emailing/noopemailing"
 	"github.com/SigNoz/signoz/pkg/emailing/smtpemailing"
@@ -30,6 +31,8 @@ import (
 	"github.com/SigNoz/signoz/pkg/modules/session/implsession"
 	"github.com/SigNoz/signoz/pkg/modules/user"
 	"github.com/SigNoz/signoz/pkg/modules/user/impluser"
+	"github.com/SigNoz/signoz/pkg/objectstore"
+	"github.com/SigNoz/signoz/pkg/objectstore/fsobjectstore"
 	"github.com/SigNoz/signoz/pkg/prometheus"
 	"github.com/SigNoz/signoz/pkg/prometheus/clickhouseprometheus"
 	"github.com/SigNoz/signoz/pkg/querier"
@@ -58,6 +61,7 @@ import (
 	"github.com/SigNoz/signoz/pkg/tokenizer/tokenizerstore/sqltokenizerstore"
 	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
 	"github.com/SigNoz/signoz/pkg/types/featuretypes"
+	"github.com/SigNoz/signoz/pkg/types/oncalltypes"
 	"github.com/SigNoz/signoz/pkg/version"
 	"github.com/SigNoz/signoz/pkg/web"
 	"github.com/SigNoz/signoz/pkg/web/noopweb"
@@ -75,6 +79,7 @@ func NewCacheProviderFactories() factory.NamedMap[factory.ProviderFactory[cache.
 	return factory.MustNewNamedMap(
 		memorycache.NewFactory(),
 		rediscache.NewFactory(),
+		tieredcache.NewFactory(),
 	)
 }
 
@@ -167,6 +172,15 @@ func NewSQLMigrationProviderFactories(
 		sqlmigration.NewMigrateRbacToAuthzFactory(sqlstore),
 		sqlmigration.NewMigratePublicDashboardsFactory(sqlstore),
 		sqlmigration.NewAddAnonymousPublicDashboardTransactionFactory(sqlstore),
+		sqlmigration.NewAddRawDataExportJobFactory(sqlstore, sqlschema),
+		sqlmigration.NewAddRuleAbsentGroupFactory(sqlstore, sqlschema),
+		sqlmigration.NewAddSLOFactory(sqlstore, sqlschema),
+		sqlmigration.NewAddAlertAcknowledgementFactory(sqlstore, sqlschema),
+		sqlmigration.NewAddEscalationPolicyFactory(sqlstore, sqlschema),
+		sqlmigration.NewAddOnCallScheduleFactory(sqlstore, sqlschema),
+		sqlmigration.NewAddWebhookDeliveryFactory(sqlstore, sqlschema),
+		sqlmigration.NewAddAlertDigestFactory(sqlstore, sqlschema),
+		sqlmigration.NewAddQuerierInvalidationFactory(sqlstore, sqlschema),
 	)
 }
 
@@ -186,15 +200,15 @@ func NewPrometheusProviderFactories(telemetryStore telemetrystore.TelemetryStore
 	)
 }
 
-func NewNotificationManagerProviderFactories(routeStore alertmanagertypes.RouteStore) factory.NamedMap[factory.ProviderFactory[nfmanager.NotificationManager, nfmanager.Config]] {
+func NewNotificationManagerProviderFactories(routeStore alertmanagertypes.RouteStore, escalationStore alertmanagertypes.EscalationStore) factory.NamedMap[factory.ProviderFactory[nfmanager.NotificationManager, nfmanager.Config]] {
 	return factory.MustNewNamedMap(
-		rulebasednotification.NewFactory(routeStore),
+		rulebasednotification.NewFactory(routeStore, escalationStore),
 	)
 }
 
-func NewAlertmanagerProviderFactories(sqlstore sqlstore.SQLStore, orgGetter organization.Getter, nfManager nfmanager.NotificationManager) factory.NamedMap[factory.ProviderFactory[alertmanager.Alertmanager, alertmanager.Config]] {
+func NewAlertmanagerProviderFactories(sqlstore sqlstore.SQLStore, telemetryStore telemetrystore.TelemetryStore, orgGetter organization.Getter, nfManager nfmanager.NotificationManager, onCallStore oncalltypes.Store, emailing emailing.Emailing) factory.NamedMap[factory.ProviderFactory[alertmanager.Alertmanager, alertmanager.Config]] {
 	return factory.MustNewNamedMap(
-		signozalertmanager.NewFactory(sqlstore, orgGetter, nfManager),
+		signozalertmanager.NewFactory(sqlstore, telemetryStore, orgGetter, nfManager, onCallStore, emailing),
 	)
 }
 
@@ -225,9 +239,9 @@ func NewStatsReporterProviderFactories(telemetryStore telemetrystore.TelemetrySt
 	)
 }
 
-func NewQuerierProviderFactories(telemetryStore telemetrystore.TelemetryStore, prometheus prometheus.Prometheus, cache cache.Cache, flagger flagger.Flagger) factory.NamedMap[factory.ProviderFactory[querier.Querier, querier.Config]] {
+func NewQuerierProviderFactories(telemetryStore telemetrystore.TelemetryStore, prometheus prometheus.Prometheus, cache cache.Cache, flagger flagger.Flagger, sqlstore sqlstore.SQLStore) factory.NamedMap[factory.ProviderFactory[querier.Querier, querier.Config]] {
 	return factory.MustNewNamedMap(
-		signozquerier.NewFactory(telemetryStore, prometheus, cache, flagger),
+		signozquerier.NewFactory(telemetryStore, prometheus, cache, flagger, sqlstore),
 	)
 }
 
@@ -254,6 +268,12 @@ func NewAPIServerProviderFactories(orgGetter organization.Getter, authz authz.Au
 	)
 }
 
+func NewObjectStoreProviderFactories() factory.NamedMap[factory.ProviderFactory[objectstore.ObjectStore, objectstore.Config]] {
+	return factory.MustNewNamedMap(
+		fsobjectstore.NewFactory(),
+	)
+}
+
 func NewTokenizerProviderFactories(cache cache.Cache, sqlstore sqlstore.SQLStore, orgGetter organization.Getter) factory.NamedMap[factory.ProviderFactory[tokenizer.Tokenizer, tokenizer.Config]] {
 	tokenStore := sqltokenizerstore.NewStore(sqlstore)
 	return factory.MustNewNamedMap(
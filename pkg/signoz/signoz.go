@@ -5,6 +5,7 @@ import (
 
 	"github.com/SigNoz/signoz/pkg/alertmanager"
 	"github.com/SigNoz/signoz/pkg/alertmanager/nfmanager"
+	"github.com/SigNoz/signoz/pkg/alertmanager/nfmanager/nfescalationstore/sqlescalationstore"
 	"github.com/SigNoz/signoz/pkg/alertmanager/nfmanager/nfroutingstore/sqlroutingstore"
 	"github.com/SigNoz/signoz/pkg/analytics"
 	"github.com/SigNoz/signoz/pkg/apiserver"
@@ -19,9 +20,11 @@ import (
 	"github.com/SigNoz/signoz/pkg/instrumentation"
 	"github.com/SigNoz/signoz/pkg/licensing"
 	"github.com/SigNoz/signoz/pkg/modules/dashboard"
+	"github.com/SigNoz/signoz/pkg/modules/oncall/imploncall"
 	"github.com/SigNoz/signoz/pkg/modules/organization"
 	"github.com/SigNoz/signoz/pkg/modules/organization/implorganization"
 	"github.com/SigNoz/signoz/pkg/modules/user/impluser"
+	"github.com/SigNoz/signoz/pkg/objectstore"
 	"github.com/SigNoz/signoz/pkg/prometheus"
 	"github.com/SigNoz/signoz/pkg/querier"
 	"github.com/SigNoz/signoz/pkg/queryparser"
@@ -65,6 +68,7 @@ type SigNoz struct {
 	Sharder                sharder.Sharder
 	StatsReporter          statsreporter.StatsReporter
 	Tokenizer              pkgtokenizer.Tokenizer
+	ObjectStore            objectstore.ObjectStore
 	Authz                  authz.AuthZ
 	Modules                Modules
 	Handlers               Handlers
@@ -217,7 +221,7 @@ func New(
 		ctx,
 		providerSettings,
 		config.Querier,
-		NewQuerierProviderFactories(telemetrystore, prometheus, cache, flagger),
+		NewQuerierProviderFactories(telemetrystore, prometheus, cache, flagger, sqlstore),
 		config.Querier.Provider(),
 	)
 	if err != nil {
@@ -278,6 +282,18 @@ func New(
 		return nil, err
 	}
 
+	// Initialize object store from the available object store provider factories
+	objectStore, err := factory.NewProviderFromNamedMap(
+		ctx,
+		providerSettings,
+		config.ObjectStore,
+		NewObjectStoreProviderFactories(),
+		config.ObjectStore.Provider,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	// Initialize user getter
 	userGetter := impluser.NewGetter(impluser.NewStore(sqlstore, providerSettings))
 
@@ -309,7 +325,7 @@ func New(
 		ctx,
 		providerSettings,
 		nfmanager.Config{},
-		NewNotificationManagerProviderFactories(sqlroutingstore.NewStore(sqlstore)),
+		NewNotificationManagerProviderFactories(sqlroutingstore.NewStore(sqlstore), sqlescalationstore.NewStore(sqlstore)),
 		"rulebased",
 	)
 	if err != nil {
@@ -321,7 +337,7 @@ func New(
 		ctx,
 		providerSettings,
 		config.Alertmanager,
-		NewAlertmanagerProviderFactories(sqlstore, orgGetter, nfManager),
+		NewAlertmanagerProviderFactories(sqlstore, telemetrystore, orgGetter, nfManager, imploncall.NewStore(sqlstore), emailing),
 		config.Alertmanager.Provider,
 	)
 	if err != nil {
@@ -387,7 +403,7 @@ func New(
 	}
 
 	// Initialize all modules
-	modules := NewModules(sqlstore, tokenizer, emailing, providerSettings, orgGetter, alertmanager, analytics, querier, telemetrystore, telemetryMetadataStore, authNs, authz, cache, queryParser, config, dashboard)
+	modules := NewModules(sqlstore, tokenizer, emailing, providerSettings, orgGetter, alertmanager, analytics, querier, telemetrystore, telemetryMetadataStore, authNs, authz, cache, queryParser, config, dashboard, objectStore)
 
 	// Initialize all handlers for the modules
 	handlers := NewHandlers(modules, providerSettings, querier, licensing, global, flagger, gateway, telemetryMetadataStore, authz)
@@ -438,6 +454,7 @@ func New(
 		factory.NewNamedService(factory.MustNewName("statsreporter"), statsReporter),
 		factory.NewNamedService(factory.MustNewName("tokenizer"), tokenizer),
 		factory.NewNamedService(factory.MustNewName("authz"), authz),
+		factory.NewNamedService(factory.MustNewName("rawdataexport"), modules.RawDataExport),
 	)
 	if err != nil {
 		return nil, err
@@ -461,6 +478,7 @@ func New(
 		Emailing:               emailing,
 		Sharder:                sharder,
 		Tokenizer:              tokenizer,
+		ObjectStore:            objectStore,
 		Authz:                  authz,
 		Modules:                modules,
 		Handlers:               handlers,
@@ -233,6 +233,96 @@ func TestStatementBuilderTimeSeries(t *testing.T) {
 	}
 }
 
+func TestStatementBuilderDistribution(t *testing.T) {
+	cases := []struct {
+		name        string
+		requestType qbtypes.RequestType
+		query       qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]
+		expected    qbtypes.Statement
+		expectedErr error
+	}{
+		{
+			name:        "Distribution with fixed boundaries and group by",
+			requestType: qbtypes.RequestTypeDistribution,
+			query: qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{
+				Signal: telemetrytypes.SignalLogs,
+				Filter: &qbtypes.Filter{
+					Expression: "service.name = 'cartservice'",
+				},
+				GroupBy: []qbtypes.GroupByKey{
+					{
+						TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{
+							Name: "service.name",
+						},
+					},
+				},
+				Distribution: &qbtypes.Distribution{
+					Key:        telemetrytypes.TelemetryFieldKey{Name: "duration"},
+					Boundaries: []float64{0, 10, 100.5},
+				},
+			},
+			expected: qbtypes.Statement{
+				Query: "WITH __resource_filter AS (SELECT fingerprint FROM signoz_logs.distributed_logs_v2_resource WHERE (simpleJSONExtractString(labels, 'service.name') = ? AND labels LIKE ? AND labels LIKE ?) AND seen_at_ts_bucket_start >= ? AND seen_at_ts_bucket_start <= ?), __distribution_values AS (SELECT toString(multiIf(multiIf(resource.`service.name` IS NOT NULL, resource.`service.name`::String, mapContains(resources_string, 'service.name'), resources_string['service.name'], NULL) IS NOT NULL, multiIf(resource.`service.name` IS NOT NULL, resource.`service.name`::String, mapContains(resources_string, 'service.name'), resources_string['service.name'], NULL), NULL)) AS `service.name`, toFloat64(multiIf(mapContains(attributes_number, 'duration') = ?, toFloat64(attributes_number['duration']), NULL)) AS `__value` FROM signoz_logs.distributed_logs_v2 WHERE resource_fingerprint GLOBAL IN (SELECT fingerprint FROM __resource_filter) AND true AND timestamp >= ? AND ts_bucket_start >= ? AND timestamp < ? AND ts_bucket_start <= ?) SELECT `service.name`, least(arrayCount(x -> x <= `__value`, CAST([0, 10, 100.5], 'Array(Float64)')), 2) AS __bucket, arrayElement(CAST([0, 10, 100.5], 'Array(Float64)'), __bucket) AS __lower, arrayElement(CAST([0, 10, 100.5], 'Array(Float64)'), __bucket + 1) AS __upper, count() AS __result_0 FROM __distribution_values WHERE isFinite(`__value`) AND `__value` >= 0 AND `__value` <= 100.5 GROUP BY `service.name`, __bucket, __lower, __upper ORDER BY `service.name`, __bucket",
+				Args:  []any{"cartservice", "%service.name%", "%service.name\":\"cartservice%", uint64(1747945619), uint64(1747983448), true, "1747947419000000000", uint64(1747945619), "1747983448000000000", uint64(1747983448)},
+			},
+			expectedErr: nil,
+		},
+		{
+			name:        "Distribution with bucket count",
+			requestType: qbtypes.RequestTypeDistribution,
+			query: qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{
+				Signal: telemetrytypes.SignalLogs,
+				Distribution: &qbtypes.Distribution{
+					Key:         telemetrytypes.TelemetryFieldKey{Name: "duration"},
+					BucketCount: 10,
+				},
+			},
+			expected: qbtypes.Statement{
+				Query: "WITH __resource_filter AS (SELECT fingerprint FROM signoz_logs.distributed_logs_v2_resource WHERE seen_at_ts_bucket_start >= ? AND seen_at_ts_bucket_start <= ?), __distribution_values AS (SELECT toFloat64(multiIf(mapContains(attributes_number, 'duration') = ?, toFloat64(attributes_number['duration']), NULL)) AS `__value` FROM signoz_logs.distributed_logs_v2 WHERE resource_fingerprint GLOBAL IN (SELECT fingerprint FROM __resource_filter) AND timestamp >= ? AND ts_bucket_start >= ? AND timestamp < ? AND ts_bucket_start <= ?), __distribution_range AS (SELECT min(`__value`) AS __min, if(max(`__value`) > min(`__value`), (max(`__value`) - min(`__value`)) / 10, 1) AS __width FROM __distribution_values WHERE isFinite(`__value`)) SELECT least(toUInt64(floor((`__value` - __min) / __width)), 9) AS __bucket, __min + __bucket * __width AS __lower, __min + (__bucket + 1) * __width AS __upper, count() AS __result_0 FROM __distribution_values CROSS JOIN __distribution_range WHERE isFinite(`__value`) GROUP BY __bucket, __lower, __upper ORDER BY __bucket",
+				Args:  []any{uint64(1747945619), uint64(1747983448), true, "1747947419000000000", uint64(1747945619), "1747983448000000000", uint64(1747983448)},
+			},
+			expectedErr: nil,
+		},
+	}
+
+	mockMetadataStore := telemetrytypestest.NewMockMetadataStore()
+	mockMetadataStore.KeysMap = buildCompleteFieldKeyMap()
+	fm := NewFieldMapper()
+	cb := NewConditionBuilder(fm)
+
+	aggExprRewriter := querybuilder.NewAggExprRewriter(instrumentationtest.New().ToProviderSettings(), nil, fm, cb, nil)
+
+	resourceFilterStmtBuilder := resourceFilterStmtBuilder()
+
+	statementBuilder := NewLogQueryStatementBuilder(
+		instrumentationtest.New().ToProviderSettings(),
+		mockMetadataStore,
+		fm,
+		cb,
+		resourceFilterStmtBuilder,
+		aggExprRewriter,
+		DefaultFullTextColumn,
+		GetBodyJSONKey,
+	)
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+
+			q, err := statementBuilder.Build(context.Background(), 1747947419000, 1747983448000, c.requestType, c.query, nil)
+
+			if c.expectedErr != nil {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), c.expectedErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, c.expected.Query, q.Query)
+				require.Equal(t, c.expected.Args, q.Args)
+				require.Equal(t, c.expected.Warnings, q.Warnings)
+			}
+		})
+	}
+}
+
 func TestStatementBuilderListQuery(t *testing.T) {
 	cases := []struct {
 		name        string
@@ -257,6 +347,22 @@ func TestStatementBuilderListQuery(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		{
+			name:        "list query with sub query",
+			requestType: qbtypes.RequestTypeRaw,
+			query: qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{
+				Signal: telemetrytypes.SignalLogs,
+				Filter: &qbtypes.Filter{
+					Expression: "email IN @A AND service.name NOT IN @B",
+				},
+				Limit: 10,
+			},
+			expected: qbtypes.Statement{
+				Query: "WITH __resource_filter AS (SELECT fingerprint FROM signoz_logs.distributed_logs_v2_resource WHERE (true AND toString(simpleJSONExtractString(labels, 'service.name')) GLOBAL NOT IN (SELECT * FROM __subquery_B)) AND seen_at_ts_bucket_start >= ? AND seen_at_ts_bucket_start <= ?) SELECT timestamp, id, trace_id, span_id, trace_flags, severity_text, severity_number, scope_name, scope_version, body, attributes_string, attributes_number, attributes_bool, resources_string, scope_string FROM signoz_logs.distributed_logs_v2 WHERE resource_fingerprint GLOBAL IN (SELECT fingerprint FROM __resource_filter) AND (toString(attributes_string['email']) GLOBAL IN (SELECT * FROM __subquery_A)) AND timestamp >= ? AND ts_bucket_start >= ? AND timestamp < ? AND ts_bucket_start <= ? LIMIT ?",
+				Args:  []any{uint64(1747945619), uint64(1747983448), "1747947419000000000", uint64(1747945619), "1747983448000000000", uint64(1747983448), 10},
+			},
+			expectedErr: nil,
+		},
 		{
 			name:        "list query with mat col order by",
 			requestType: qbtypes.RequestTypeRaw,
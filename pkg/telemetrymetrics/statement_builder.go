@@ -128,7 +128,7 @@ func (b *MetricQueryStatementBuilder) Build(
 	ctx context.Context,
 	start uint64,
 	end uint64,
-	_ qbtypes.RequestType,
+	requestType qbtypes.RequestType,
 	query qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation],
 	variables map[string]qbtypes.VariableItem,
 ) (*qbtypes.Statement, error) {
@@ -140,7 +140,7 @@ func (b *MetricQueryStatementBuilder) Build(
 
 	start, end = querybuilder.AdjustedMetricTimeRange(start, end, uint64(query.StepInterval.Seconds()), query)
 
-	return b.buildPipelineStatement(ctx, start, end, query, keys, variables)
+	return b.buildPipelineStatement(ctx, start, end, requestType, query, keys, variables)
 }
 
 // Fast‑path (no fingerprint grouping)
@@ -194,6 +194,7 @@ func (b *MetricQueryStatementBuilder) CanShortCircuitDelta(q qbtypes.QueryBuilde
 func (b *MetricQueryStatementBuilder) buildPipelineStatement(
 	ctx context.Context,
 	start, end uint64,
+	requestType qbtypes.RequestType,
 	query qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation],
 	keys map[string][]*telemetrytypes.TelemetryFieldKey,
 	variables map[string]qbtypes.VariableItem,
@@ -282,7 +283,7 @@ func (b *MetricQueryStatementBuilder) buildPipelineStatement(
 	query.GroupBy = origGroupBy
 
 	// final SELECT
-	return b.BuildFinalSelect(cteFragments, cteArgs, query)
+	return b.BuildFinalSelect(cteFragments, cteArgs, requestType, query)
 }
 
 func (b *MetricQueryStatementBuilder) buildTemporalAggDeltaFastPath(
@@ -554,18 +555,14 @@ func (b *MetricQueryStatementBuilder) buildSpatialAggregationCTE(
 	return fmt.Sprintf("__spatial_aggregation_cte AS (%s)", q), args
 }
 
+// BuildFinalSelect builds the final select over the spatial aggregation CTE. For the distribution request type,
+// the values of the final select are bucketed per group instead of being returned as series.
 func (b *MetricQueryStatementBuilder) BuildFinalSelect(
 	cteFragments []string,
 	cteArgs [][]any,
+	requestType qbtypes.RequestType,
 	query qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation],
 ) (*qbtypes.Statement, error) {
-	combined := querybuilder.CombineCTEs(cteFragments)
-
-	var args []any
-	for _, a := range cteArgs {
-		args = append(args, a...)
-	}
-
 	sb := sqlbuilder.NewSelectBuilder()
 
 	var quantile float64
@@ -603,5 +600,17 @@ func (b *MetricQueryStatementBuilder) BuildFinalSelect(
 	}
 
 	q, a := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+
+	if requestType == qbtypes.RequestTypeDistribution {
+		return querybuilder.BuildDistributionStatement(cteFragments, cteArgs, q, a, "value", query.GroupBy, query.Distribution), nil
+	}
+
+	combined := querybuilder.CombineCTEs(cteFragments)
+
+	var args []any
+	for _, a := range cteArgs {
+		args = append(args, a...)
+	}
+
 	return &qbtypes.Statement{Query: combined + q, Args: append(args, a...)}, nil
 }
@@ -140,6 +140,8 @@ func (b *traceQueryStatementBuilder) Build(
 		return b.buildScalarQuery(ctx, q, query, start, end, keys, variables, false, false)
 	case qbtypes.RequestTypeTrace:
 		return b.buildTraceQuery(ctx, q, query, start, end, keys, variables)
+	case qbtypes.RequestTypeDistribution:
+		return b.buildDistributionQuery(ctx, q, query, start, end, keys, variables)
 	}
 
 	return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "unsupported request type: %s", requestType)
@@ -187,6 +189,15 @@ func getKeySelectors(query qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation])
 		})
 	}
 
+	if query.Distribution != nil {
+		keySelectors = append(keySelectors, &telemetrytypes.FieldKeySelector{
+			Name:          query.Distribution.Key.Name,
+			Signal:        telemetrytypes.SignalTraces,
+			FieldContext:  query.Distribution.Key.FieldContext,
+			FieldDataType: query.Distribution.Key.FieldDataType,
+		})
+	}
+
 	for idx := range keySelectors {
 		keySelectors[idx].Signal = telemetrytypes.SignalTraces
 		keySelectors[idx].SelectorMatchType = telemetrytypes.FieldSelectorMatchTypeExact
@@ -246,6 +257,9 @@ func (b *traceQueryStatementBuilder) adjustKeys(ctx context.Context, keys map[st
 	for idx := range query.Order {
 		actions = append(actions, b.adjustKey(&query.Order[idx].Key.TelemetryFieldKey, keys)...)
 	}
+	if query.Distribution != nil {
+		actions = append(actions, b.adjustKey(&query.Distribution.Key, keys)...)
+	}
 
 	for _, action := range actions {
 		// TODO: change to debug level once we are confident about the behavior
@@ -744,6 +758,67 @@ func (b *traceQueryStatementBuilder) buildScalarQuery(
 	return stmt, nil
 }
 
+// buildDistributionQuery builds a query for the histogram panel type, counting the spans per bucket of the values
+// of the distribution key
+func (b *traceQueryStatementBuilder) buildDistributionQuery(
+	ctx context.Context,
+	sb *sqlbuilder.SelectBuilder,
+	query qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation],
+	start, end uint64,
+	keys map[string][]*telemetrytypes.TelemetryFieldKey,
+	variables map[string]qbtypes.VariableItem,
+) (*qbtypes.Statement, error) {
+
+	var (
+		cteFragments []string
+		cteArgs      [][]any
+	)
+
+	if frag, args, err := b.maybeAttachResourceFilter(ctx, sb, query, start, end, variables); err != nil {
+		return nil, err
+	} else if frag != "" {
+		cteFragments = append(cteFragments, frag)
+		cteArgs = append(cteArgs, args)
+	}
+
+	var allArgs []any
+
+	for _, gb := range query.GroupBy {
+		expr, args, err := querybuilder.CollisionHandledFinalExpr(ctx, &gb.TelemetryFieldKey, b.fm, b.cb, keys, telemetrytypes.FieldDataTypeString, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		allArgs = append(allArgs, args...)
+		sb.SelectMore(fmt.Sprintf("toString(%s) AS `%s`", expr, gb.TelemetryFieldKey.Name))
+	}
+
+	valueExpr, valueArgs, err := querybuilder.CollisionHandledFinalExpr(ctx, &query.Distribution.Key, b.fm, b.cb, keys, telemetrytypes.FieldDataTypeFloat64, nil)
+	if err != nil {
+		return nil, err
+	}
+	allArgs = append(allArgs, valueArgs...)
+	sb.SelectMore(fmt.Sprintf("toFloat64(%s) AS `%s`", valueExpr, querybuilder.DistributionValueColumn))
+
+	sb.From(fmt.Sprintf("%s.%s", DBName, SpanIndexV3TableName))
+
+	// Add filter conditions
+	preparedWhereClause, err := b.addFilterCondition(ctx, sb, start, end, query, keys, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	valuesSQL, valuesArgs := sb.BuildWithFlavor(sqlbuilder.ClickHouse, allArgs...)
+
+	stmt := querybuilder.BuildDistributionStatement(cteFragments, cteArgs, valuesSQL, valuesArgs, querybuilder.DistributionValueColumn, query.GroupBy, query.Distribution)
+	if preparedWhereClause != nil {
+		stmt.Warnings = preparedWhereClause.Warnings
+		stmt.WarningsDocURL = preparedWhereClause.WarningsDocURL
+	}
+
+	return stmt, nil
+}
+
 // buildFilterCondition builds SQL condition from filter expression
 func (b *traceQueryStatementBuilder) addFilterCondition(
 	_ context.Context,
@@ -364,6 +364,32 @@ func TestStatementBuilder(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		{
+			name:        "distribution of duration with fixed boundaries",
+			requestType: qbtypes.RequestTypeDistribution,
+			query: qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]{
+				Signal: telemetrytypes.SignalTraces,
+				Filter: &qbtypes.Filter{
+					Expression: "service.name = 'redis-manual'",
+				},
+				GroupBy: []qbtypes.GroupByKey{
+					{
+						TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{
+							Name: "service.name",
+						},
+					},
+				},
+				Distribution: &qbtypes.Distribution{
+					Key:        telemetrytypes.TelemetryFieldKey{Name: "duration_nano"},
+					Boundaries: []float64{0, 1000000, 10000000},
+				},
+			},
+			expected: qbtypes.Statement{
+				Query: "WITH __resource_filter AS (SELECT fingerprint FROM signoz_traces.distributed_traces_v3_resource WHERE (simpleJSONExtractString(labels, 'service.name') = ? AND labels LIKE ? AND labels LIKE ?) AND seen_at_ts_bucket_start >= ? AND seen_at_ts_bucket_start <= ?), __distribution_values AS (SELECT toString(multiIf(multiIf(resource.`service.name` IS NOT NULL, resource.`service.name`::String, mapContains(resources_string, 'service.name'), resources_string['service.name'], NULL) IS NOT NULL, multiIf(resource.`service.name` IS NOT NULL, resource.`service.name`::String, mapContains(resources_string, 'service.name'), resources_string['service.name'], NULL), NULL)) AS `service.name`, toFloat64(multiIf(duration_nano <> ?, toFloat64(duration_nano), NULL)) AS `__value` FROM signoz_traces.distributed_signoz_index_v3 WHERE resource_fingerprint GLOBAL IN (SELECT fingerprint FROM __resource_filter) AND true AND timestamp >= ? AND timestamp < ? AND ts_bucket_start >= ? AND ts_bucket_start <= ?) SELECT `service.name`, least(arrayCount(x -> x <= `__value`, CAST([0, 1000000, 10000000], 'Array(Float64)')), 2) AS __bucket, arrayElement(CAST([0, 1000000, 10000000], 'Array(Float64)'), __bucket) AS __lower, arrayElement(CAST([0, 1000000, 10000000], 'Array(Float64)'), __bucket + 1) AS __upper, count() AS __result_0 FROM __distribution_values WHERE isFinite(`__value`) AND `__value` >= 0 AND `__value` <= 10000000 GROUP BY `service.name`, __bucket, __lower, __upper ORDER BY `service.name`, __bucket",
+				Args:  []any{"redis-manual", "%service.name%", "%service.name\":\"redis-manual%", uint64(1747945619), uint64(1747983448), 0, "1747947419000000000", "1747983448000000000", uint64(1747945619), uint64(1747983448)},
+			},
+			expectedErr: nil,
+		},
 	}
 
 	fm := NewFieldMapper()
@@ -0,0 +1,593 @@
+package alertanalyticstypes
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+const (
+	QueryName = "A"
+
+	DefaultTimelineLimit     = 100
+	DefaultNoisiestLimit     = 10
+	DefaultFlappingLimit     = 100
+	DefaultFlappingTriggers  = 3
+	DefaultFlappingResolveIn = 15 * time.Minute
+)
+
+// Grouping is what the noisiest alerts are grouped by.
+type Grouping struct{ valuer.String }
+
+var (
+	GroupingRule     = Grouping{valuer.NewString("rule")}
+	GroupingLabelSet = Grouping{valuer.NewString("label_set")}
+)
+
+// Window is the time range of a request, in epoch milliseconds.
+type Window struct {
+	Start uint64 `json:"start"`
+	End   uint64 `json:"end"`
+}
+
+func (w Window) Validate() error {
+	if w.Start >= w.End {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "start time must be before end time")
+	}
+
+	return nil
+}
+
+type TimelineRequest struct {
+	Window
+	// the episodes of a rule, if set
+	RuleID string `json:"ruleId,omitempty"`
+	// the episodes of the alerts which have these labels, if set
+	Labels map[string]string `json:"labels,omitempty"`
+	Limit  int               `json:"limit,omitempty"`
+	Offset int               `json:"offset,omitempty"`
+}
+
+func (req *TimelineRequest) Validate() error {
+	if err := req.Window.Validate(); err != nil {
+		return err
+	}
+
+	for key := range req.Labels {
+		if key == "" {
+			return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "label key cannot be empty")
+		}
+	}
+
+	if req.Limit < 0 || req.Limit > qbtypes.MaxQueryLimit {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "limit must be between 0 and %d", qbtypes.MaxQueryLimit)
+	}
+
+	if req.Offset < 0 {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "offset must not be negative")
+	}
+
+	return nil
+}
+
+func (req *TimelineRequest) NewQueryRangeRequest() *qbtypes.QueryRangeRequest {
+	labels := make(map[string]string, len(req.Labels)+1)
+	for key, value := range req.Labels {
+		labels[key] = value
+	}
+	if req.RuleID != "" {
+		labels[qbtypes.AlertAnalyticsKeyRuleID] = req.RuleID
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = DefaultTimelineLimit
+	}
+
+	return newQueryRangeRequest(req.Window, qbtypes.RequestTypeRaw, qbtypes.AlertAnalyticsQuery{
+		Name:   QueryName,
+		Filter: newEqualsFilter(labels),
+		Limit:  limit,
+		Offset: req.Offset,
+	})
+}
+
+// Episode is a period during which an alert was firing, from the time it started firing until it was resolved.
+type Episode struct {
+	RuleID         string            `json:"ruleId"`
+	RuleName       string            `json:"ruleName"`
+	Fingerprint    uint64            `json:"fingerprint"`
+	Labels         map[string]string `json:"labels"`
+	FiredAt        time.Time         `json:"firedAt"`
+	AcknowledgedAt *time.Time        `json:"acknowledgedAt,omitempty"`
+	ResolvedAt     *time.Time        `json:"resolvedAt,omitempty"`
+}
+
+type TimelineResponse struct {
+	Episodes []*Episode `json:"episodes"`
+}
+
+func NewTimelineResponse(resp *qbtypes.QueryRangeResponse) (*TimelineResponse, error) {
+	episodes := []*Episode{}
+	for _, result := range resp.Data.Results {
+		data, ok := result.(*qbtypes.RawData)
+		if !ok || data == nil {
+			continue
+		}
+
+		for _, row := range data.Rows {
+			labels, err := newLabels(row.Data[qbtypes.AlertAnalyticsKeyLabels])
+			if err != nil {
+				return nil, err
+			}
+
+			episodes = append(episodes, &Episode{
+				RuleID:         toString(row.Data[qbtypes.AlertAnalyticsKeyRuleID]),
+				RuleName:       toString(row.Data[qbtypes.AlertAnalyticsKeyRuleName]),
+				Fingerprint:    toUint64(row.Data[qbtypes.AlertAnalyticsKeyFingerprint]),
+				Labels:         labels,
+				FiredAt:        time.UnixMilli(toInt64(row.Data["firing_time"])),
+				AcknowledgedAt: toTime(row.Data["acked_time"]),
+				ResolvedAt:     toTime(row.Data["resolved_time"]),
+			})
+		}
+	}
+
+	return &TimelineResponse{Episodes: episodes}, nil
+}
+
+type NoisiestRequest struct {
+	Window
+	By Grouping `json:"by"`
+	// a filter expression over the rule_id, rule_name and the labels of the alerts
+	Filter string `json:"filter,omitempty"`
+	Limit  int    `json:"limit,omitempty"`
+}
+
+func (req *NoisiestRequest) Validate() error {
+	if err := req.Window.Validate(); err != nil {
+		return err
+	}
+
+	if req.By.IsZero() {
+		req.By = GroupingRule
+	}
+
+	if req.By != GroupingRule && req.By != GroupingLabelSet {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "invalid grouping %q, the noisiest alerts are grouped by rule or label_set", req.By.StringValue())
+	}
+
+	if req.Limit < 0 || req.Limit > qbtypes.MaxQueryLimit {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "limit must be between 0 and %d", qbtypes.MaxQueryLimit)
+	}
+
+	return nil
+}
+
+func (req *NoisiestRequest) NewQueryRangeRequest() *qbtypes.QueryRangeRequest {
+	groupBy := []string{qbtypes.AlertAnalyticsKeyRuleID, qbtypes.AlertAnalyticsKeyRuleName}
+	if req.By == GroupingLabelSet {
+		groupBy = append(groupBy, qbtypes.AlertAnalyticsKeyFingerprint, qbtypes.AlertAnalyticsKeyLabels)
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = DefaultNoisiestLimit
+	}
+
+	return newQueryRangeRequest(req.Window, qbtypes.RequestTypeScalar, qbtypes.AlertAnalyticsQuery{
+		Name: QueryName,
+		Aggregations: []qbtypes.AlertAnalyticsAggregation{
+			{Metric: qbtypes.AlertAnalyticsMetricTriggers},
+			{Metric: qbtypes.AlertAnalyticsMetricFiringDuration},
+		},
+		Filter:  newFilter(req.Filter),
+		GroupBy: newGroupBy(groupBy),
+		Order:   []qbtypes.OrderBy{newOrderBy(qbtypes.AlertAnalyticsMetricTriggers.StringValue(), qbtypes.OrderDirectionDesc)},
+		Limit:   limit,
+	})
+}
+
+type Noisy struct {
+	RuleID   string `json:"ruleId"`
+	RuleName string `json:"ruleName"`
+	// set when grouped by label set
+	Fingerprint uint64            `json:"fingerprint,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Triggers    uint64            `json:"triggers"`
+	// the total time spent firing, in seconds
+	FiringDuration float64 `json:"firingDuration"`
+}
+
+type NoisiestResponse struct {
+	Items []*Noisy `json:"items"`
+}
+
+func NewNoisiestResponse(resp *qbtypes.QueryRangeResponse) (*NoisiestResponse, error) {
+	items := []*Noisy{}
+	err := forEachScalarRow(resp, func(groups map[string]string, aggregations []any) error {
+		labels, err := newLabels(groups[qbtypes.AlertAnalyticsKeyLabels])
+		if err != nil {
+			return err
+		}
+
+		items = append(items, &Noisy{
+			RuleID:         groups[qbtypes.AlertAnalyticsKeyRuleID],
+			RuleName:       groups[qbtypes.AlertAnalyticsKeyRuleName],
+			Fingerprint:    toUint64(groups[qbtypes.AlertAnalyticsKeyFingerprint]),
+			Labels:         labels,
+			Triggers:       toUint64(aggregations[0]),
+			FiringDuration: toFloat64(aggregations[1]),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &NoisiestResponse{Items: items}, nil
+}
+
+type ResponseTimesRequest struct {
+	Window
+	// the keys the response times are grouped by, rule_id and rule_name if empty, any other key than rule_id,
+	// rule_name and fingerprint is a label of the alerts, e.g. team
+	GroupBy []string `json:"groupBy,omitempty"`
+	// a filter expression over the rule_id, rule_name and the labels of the alerts
+	Filter string `json:"filter,omitempty"`
+}
+
+func (req *ResponseTimesRequest) Validate() error {
+	if err := req.Window.Validate(); err != nil {
+		return err
+	}
+
+	if slices.Contains(req.GroupBy, "") {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "group by key cannot be empty")
+	}
+
+	return nil
+}
+
+func (req *ResponseTimesRequest) NewQueryRangeRequest() *qbtypes.QueryRangeRequest {
+	groupBy := req.GroupBy
+	if len(groupBy) == 0 {
+		groupBy = []string{qbtypes.AlertAnalyticsKeyRuleID, qbtypes.AlertAnalyticsKeyRuleName}
+	}
+
+	return newQueryRangeRequest(req.Window, qbtypes.RequestTypeScalar, qbtypes.AlertAnalyticsQuery{
+		Name: QueryName,
+		Aggregations: []qbtypes.AlertAnalyticsAggregation{
+			{Metric: qbtypes.AlertAnalyticsMetricTriggers},
+			{Metric: qbtypes.AlertAnalyticsMetricMTTR},
+			{Metric: qbtypes.AlertAnalyticsMetricMTTA},
+		},
+		Filter:  newFilter(req.Filter),
+		GroupBy: newGroupBy(groupBy),
+	})
+}
+
+type ResponseTimes struct {
+	Group    map[string]string `json:"group"`
+	Triggers uint64            `json:"triggers"`
+	// the mean time to resolve, in seconds, if any episode was resolved
+	MTTR *float64 `json:"mttr"`
+	// the mean time to acknowledge, in seconds, if any episode was acknowledged
+	MTTA *float64 `json:"mtta"`
+}
+
+type ResponseTimesResponse struct {
+	Items []*ResponseTimes `json:"items"`
+}
+
+func NewResponseTimesResponse(resp *qbtypes.QueryRangeResponse) (*ResponseTimesResponse, error) {
+	items := []*ResponseTimes{}
+	err := forEachScalarRow(resp, func(groups map[string]string, aggregations []any) error {
+		items = append(items, &ResponseTimes{
+			Group:    groups,
+			Triggers: toUint64(aggregations[0]),
+			MTTR:     toFloat64Ptr(aggregations[1]),
+			MTTA:     toFloat64Ptr(aggregations[2]),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ResponseTimesResponse{Items: items}, nil
+}
+
+// FlappingRequest finds the alerts which fired at least MinTriggers times and were resolved within MaxResolveTime
+// on average.
+type FlappingRequest struct {
+	Window
+	// a filter expression over the rule_id, rule_name and the labels of the alerts
+	Filter         string              `json:"filter,omitempty"`
+	MinTriggers    int                 `json:"minTriggers,omitempty"`
+	MaxResolveTime valuer.TextDuration `json:"maxResolveTime,omitzero"`
+	Limit          int                 `json:"limit,omitempty"`
+}
+
+func (req *FlappingRequest) Validate() error {
+	if err := req.Window.Validate(); err != nil {
+		return err
+	}
+
+	if req.MinTriggers < 0 {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "min triggers must not be negative")
+	}
+
+	if req.MaxResolveTime.Duration() < 0 {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "max resolve time must not be negative")
+	}
+
+	if req.Limit < 0 || req.Limit > qbtypes.MaxQueryLimit {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "limit must be between 0 and %d", qbtypes.MaxQueryLimit)
+	}
+
+	return nil
+}
+
+func (req *FlappingRequest) NewQueryRangeRequest() *qbtypes.QueryRangeRequest {
+	minTriggers := req.MinTriggers
+	if minTriggers == 0 {
+		minTriggers = DefaultFlappingTriggers
+	}
+
+	maxResolveTime := req.MaxResolveTime.Duration()
+	if maxResolveTime == 0 {
+		maxResolveTime = DefaultFlappingResolveIn
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = DefaultFlappingLimit
+	}
+
+	return newQueryRangeRequest(req.Window, qbtypes.RequestTypeScalar, qbtypes.AlertAnalyticsQuery{
+		Name: QueryName,
+		Aggregations: []qbtypes.AlertAnalyticsAggregation{
+			{Metric: qbtypes.AlertAnalyticsMetricTriggers},
+			{Metric: qbtypes.AlertAnalyticsMetricMTTR},
+		},
+		Filter: newFilter(req.Filter),
+		GroupBy: newGroupBy([]string{
+			qbtypes.AlertAnalyticsKeyRuleID,
+			qbtypes.AlertAnalyticsKeyRuleName,
+			qbtypes.AlertAnalyticsKeyFingerprint,
+			qbtypes.AlertAnalyticsKeyLabels,
+		}),
+		Having: &qbtypes.Having{
+			Expression: fmt.Sprintf("triggers >= %d AND mttr <= %g", minTriggers, maxResolveTime.Seconds()),
+		},
+		Order: []qbtypes.OrderBy{newOrderBy(qbtypes.AlertAnalyticsMetricTriggers.StringValue(), qbtypes.OrderDirectionDesc)},
+		Limit: limit,
+	})
+}
+
+type Flapping struct {
+	RuleID      string            `json:"ruleId"`
+	RuleName    string            `json:"ruleName"`
+	Fingerprint uint64            `json:"fingerprint"`
+	Labels      map[string]string `json:"labels"`
+	Triggers    uint64            `json:"triggers"`
+	// the mean time to resolve, in seconds
+	MTTR float64 `json:"mttr"`
+}
+
+type FlappingResponse struct {
+	Items []*Flapping `json:"items"`
+}
+
+func NewFlappingResponse(resp *qbtypes.QueryRangeResponse) (*FlappingResponse, error) {
+	items := []*Flapping{}
+	err := forEachScalarRow(resp, func(groups map[string]string, aggregations []any) error {
+		labels, err := newLabels(groups[qbtypes.AlertAnalyticsKeyLabels])
+		if err != nil {
+			return err
+		}
+
+		items = append(items, &Flapping{
+			RuleID:      groups[qbtypes.AlertAnalyticsKeyRuleID],
+			RuleName:    groups[qbtypes.AlertAnalyticsKeyRuleName],
+			Fingerprint: toUint64(groups[qbtypes.AlertAnalyticsKeyFingerprint]),
+			Labels:      labels,
+			Triggers:    toUint64(aggregations[0]),
+			MTTR:        toFloat64(aggregations[1]),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &FlappingResponse{Items: items}, nil
+}
+
+func newQueryRangeRequest(window Window, requestType qbtypes.RequestType, query qbtypes.AlertAnalyticsQuery) *qbtypes.QueryRangeRequest {
+	return &qbtypes.QueryRangeRequest{
+		SchemaVersion: "v5",
+		Start:         window.Start,
+		End:           window.End,
+		RequestType:   requestType,
+		CompositeQuery: qbtypes.CompositeQuery{
+			Queries: []qbtypes.QueryEnvelope{{Type: qbtypes.QueryTypeAlertAnalytics, Spec: query}},
+		},
+	}
+}
+
+func newFilter(expression string) *qbtypes.Filter {
+	if strings.TrimSpace(expression) == "" {
+		return nil
+	}
+
+	return &qbtypes.Filter{Expression: expression}
+}
+
+// newEqualsFilter returns a filter matching all the key value pairs.
+func newEqualsFilter(pairs map[string]string) *qbtypes.Filter {
+	keys := make([]string, 0, len(pairs))
+	for key := range pairs {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	conds := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value := strings.ReplaceAll(strings.ReplaceAll(pairs[key], `\`, `\\`), `'`, `\'`)
+		conds = append(conds, fmt.Sprintf("%s = '%s'", key, value))
+	}
+
+	return newFilter(strings.Join(conds, " AND "))
+}
+
+func newGroupBy(keys []string) []qbtypes.GroupByKey {
+	groupBy := make([]qbtypes.GroupByKey, 0, len(keys))
+	for _, key := range keys {
+		groupBy = append(groupBy, qbtypes.GroupByKey{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: key}})
+	}
+
+	return groupBy
+}
+
+func newOrderBy(key string, direction qbtypes.OrderDirection) qbtypes.OrderBy {
+	return qbtypes.OrderBy{Key: qbtypes.OrderByKey{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: key}}, Direction: direction}
+}
+
+// forEachScalarRow calls fn with the group by values and the aggregations, in the order of the aggregations of the
+// query, of every row of the scalar results.
+func forEachScalarRow(resp *qbtypes.QueryRangeResponse, fn func(map[string]string, []any) error) error {
+	for _, result := range resp.Data.Results {
+		data, ok := result.(*qbtypes.ScalarData)
+		if !ok || data == nil {
+			continue
+		}
+
+		for _, row := range data.Data {
+			groups := make(map[string]string)
+			var aggregations []any
+			for idx, column := range data.Columns {
+				if idx >= len(row) {
+					break
+				}
+
+				if column.Type == qbtypes.ColumnTypeAggregation {
+					for int64(len(aggregations)) <= column.AggregationIndex {
+						aggregations = append(aggregations, nil)
+					}
+					aggregations[column.AggregationIndex] = row[idx]
+					continue
+				}
+
+				groups[column.Name] = toString(row[idx])
+			}
+
+			if err := fn(groups, aggregations); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func newLabels(value any) (map[string]string, error) {
+	raw := toString(value)
+	if raw == "" {
+		return nil, nil
+	}
+
+	labels := make(map[string]string)
+	if err := json.Unmarshal([]byte(raw), &labels); err != nil {
+		return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to unmarshal the labels of the alert")
+	}
+
+	return labels, nil
+}
+
+func toString(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+
+	return fmt.Sprintf("%v", value)
+}
+
+func toFloat64Ptr(value any) *float64 {
+	if value == nil {
+		return nil
+	}
+
+	v := toFloat64(value)
+	return &v
+}
+
+func toFloat64(value any) float64 {
+	switch v := value.(type) {
+	case float64:
+		return v
+	case float32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case string:
+		f, _ := strconv.ParseFloat(v, 64)
+		return f
+	}
+
+	return 0
+}
+
+func toUint64(value any) uint64 {
+	switch v := value.(type) {
+	case uint64:
+		return v
+	case int64:
+		return uint64(v)
+	case float64:
+		return uint64(v)
+	case string:
+		u, _ := strconv.ParseUint(v, 10, 64)
+		return u
+	}
+
+	return 0
+}
+
+func toInt64(value any) int64 {
+	switch v := value.(type) {
+	case int64:
+		return v
+	case uint64:
+		return int64(v)
+	case float64:
+		return int64(v)
+	}
+
+	return 0
+}
+
+// toTime returns the time of an epoch in milliseconds, which is zero if the episode didn't reach the state.
+func toTime(value any) *time.Time {
+	ms := toInt64(value)
+	if ms <= 0 {
+		return nil
+	}
+
+	t := time.UnixMilli(ms)
+	return &t
+}
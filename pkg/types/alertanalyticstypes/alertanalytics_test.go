@@ -0,0 +1,124 @@
+package alertanalyticstypes
+
+import (
+	"testing"
+	"time"
+
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testWindow = Window{Start: 1747947419000, End: 1747983448000}
+
+func alertAnalyticsQuery(t *testing.T, req *qbtypes.QueryRangeRequest) qbtypes.AlertAnalyticsQuery {
+	t.Helper()
+
+	require.NoError(t, req.Validate())
+	require.Len(t, req.CompositeQuery.Queries, 1)
+	query, ok := req.CompositeQuery.Queries[0].Spec.(qbtypes.AlertAnalyticsQuery)
+	require.True(t, ok)
+	return query
+}
+
+func TestTimelineRequest_NewQueryRangeRequest(t *testing.T) {
+	req := &TimelineRequest{Window: testWindow, RuleID: "r1", Labels: map[string]string{"team": "o'neil", "service": "api"}}
+	require.NoError(t, req.Validate())
+
+	rangeReq := req.NewQueryRangeRequest()
+	assert.Equal(t, qbtypes.RequestTypeRaw, rangeReq.RequestType)
+
+	query := alertAnalyticsQuery(t, rangeReq)
+	assert.Equal(t, `rule_id = 'r1' AND service = 'api' AND team = 'o\'neil'`, query.Filter.Expression)
+	assert.Equal(t, DefaultTimelineLimit, query.Limit)
+}
+
+func TestFlappingRequest_NewQueryRangeRequest(t *testing.T) {
+	req := &FlappingRequest{Window: testWindow}
+	require.NoError(t, req.Validate())
+	assert.Equal(t, "triggers >= 3 AND mttr <= 900", alertAnalyticsQuery(t, req.NewQueryRangeRequest()).Having.Expression)
+
+	req = &FlappingRequest{Window: testWindow, MinTriggers: 5, MaxResolveTime: valuer.MustParseTextDuration("90s")}
+	require.NoError(t, req.Validate())
+	assert.Equal(t, "triggers >= 5 AND mttr <= 90", alertAnalyticsQuery(t, req.NewQueryRangeRequest()).Having.Expression)
+}
+
+func TestNoisiestRequest_Validate(t *testing.T) {
+	req := &NoisiestRequest{Window: testWindow}
+	require.NoError(t, req.Validate())
+	assert.Equal(t, GroupingRule, req.By)
+	assert.Len(t, alertAnalyticsQuery(t, req.NewQueryRangeRequest()).GroupBy, 2)
+
+	req = &NoisiestRequest{Window: testWindow, By: GroupingLabelSet}
+	require.NoError(t, req.Validate())
+	assert.Len(t, alertAnalyticsQuery(t, req.NewQueryRangeRequest()).GroupBy, 4)
+
+	req = &NoisiestRequest{Window: testWindow, By: Grouping{valuer.NewString("team")}}
+	assert.Error(t, req.Validate())
+
+	req = &NoisiestRequest{Window: Window{Start: 2, End: 1}}
+	assert.Error(t, req.Validate())
+}
+
+func TestNewTimelineResponse(t *testing.T) {
+	resp := &qbtypes.QueryRangeResponse{Data: qbtypes.QueryData{Results: []any{
+		&qbtypes.RawData{QueryName: QueryName, Rows: []*qbtypes.RawRow{{
+			Data: map[string]any{
+				"rule_id":       "r1",
+				"rule_name":     "high latency",
+				"fingerprint":   uint64(42),
+				"labels":        `{"team":"payments"}`,
+				"firing_time":   int64(1747947420000),
+				"resolved_time": int64(1747947480000),
+				"acked_time":    int64(0),
+			},
+		}}},
+	}}}
+
+	timeline, err := NewTimelineResponse(resp)
+	require.NoError(t, err)
+	require.Len(t, timeline.Episodes, 1)
+
+	episode := timeline.Episodes[0]
+	assert.Equal(t, "r1", episode.RuleID)
+	assert.Equal(t, uint64(42), episode.Fingerprint)
+	assert.Equal(t, map[string]string{"team": "payments"}, episode.Labels)
+	assert.Equal(t, time.UnixMilli(1747947420000), episode.FiredAt)
+	assert.Nil(t, episode.AcknowledgedAt)
+	require.NotNil(t, episode.ResolvedAt)
+	assert.Equal(t, time.UnixMilli(1747947480000), *episode.ResolvedAt)
+}
+
+func TestNewResponseTimesResponse(t *testing.T) {
+	column := func(name string, columnType qbtypes.ColumnType, index int64) *qbtypes.ColumnDescriptor {
+		return &qbtypes.ColumnDescriptor{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: name}, QueryName: QueryName, AggregationIndex: index, Type: columnType}
+	}
+
+	resp := &qbtypes.QueryRangeResponse{Data: qbtypes.QueryData{Results: []any{
+		&qbtypes.ScalarData{
+			QueryName: QueryName,
+			Columns: []*qbtypes.ColumnDescriptor{
+				column("team", qbtypes.ColumnTypeGroup, 0),
+				column("__result_0", qbtypes.ColumnTypeAggregation, 0),
+				column("__result_1", qbtypes.ColumnTypeAggregation, 1),
+				column("__result_2", qbtypes.ColumnTypeAggregation, 2),
+			},
+			Data: [][]any{
+				{"payments", uint64(4), float64(120), nil},
+			},
+		},
+	}}}
+
+	responseTimes, err := NewResponseTimesResponse(resp)
+	require.NoError(t, err)
+	require.Len(t, responseTimes.Items, 1)
+
+	item := responseTimes.Items[0]
+	assert.Equal(t, map[string]string{"team": "payments"}, item.Group)
+	assert.Equal(t, uint64(4), item.Triggers)
+	require.NotNil(t, item.MTTR)
+	assert.Equal(t, float64(120), *item.MTTR)
+	assert.Nil(t, item.MTTA)
+}
@@ -0,0 +1,201 @@
+package alertmanagertypes
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/prometheus/common/model"
+	"github.com/uptrace/bun"
+)
+
+var (
+	ErrCodeAlertmanagerAcknowledgementNotFound = errors.MustNewCode("alertmanager_acknowledgement_not_found")
+	ErrCodeAlertmanagerAcknowledgementInvalid  = errors.MustNewCode("alertmanager_acknowledgement_invalid")
+)
+
+// AcknowledgementEvent is an event of the acknowledgement of a group of alerts which is recorded in the history of the
+// alerts.
+type AcknowledgementEvent struct{ valuer.String }
+
+var (
+	AcknowledgementEventAcknowledged   = AcknowledgementEvent{valuer.NewString("acknowledged")}
+	AcknowledgementEventUnacknowledged = AcknowledgementEvent{valuer.NewString("unacknowledged")}
+)
+
+// StorableAcknowledgement is the ownership of a group of firing alerts of a rule. A group is acknowledged by someone
+// working on it and assigned to someone responsible for it, either of which takes the incident. The acknowledgement
+// is released when all the alerts of the group are resolved.
+type StorableAcknowledgement struct {
+	bun.BaseModel `bun:"table:alert_acknowledgement"`
+
+	types.Identifiable
+	types.TimeAuditable
+	OrgID            string    `bun:"org_id"`
+	RuleID           string    `bun:"rule_id"`
+	GroupFingerprint string    `bun:"group_fingerprint"`
+	GroupLabels      string    `bun:"group_labels"`
+	AcknowledgedBy   string    `bun:"acknowledged_by,nullzero"`
+	AcknowledgedAt   time.Time `bun:"acknowledged_at,nullzero"`
+	Assignee         string    `bun:"assignee,nullzero"`
+	AssignedBy       string    `bun:"assigned_by,nullzero"`
+	Comment          string    `bun:"comment,nullzero"`
+}
+
+// PostableAcknowledgement references a group by the labels of one of its alerts, the group of the alert is found with
+// the notification settings of its rule.
+type PostableAcknowledgement struct {
+	Labels  map[string]string `json:"labels"`
+	Comment string            `json:"comment,omitempty"`
+	// the user to assign the group to, an empty assignee unassigns the group
+	Assignee string `json:"assignee,omitempty"`
+}
+
+type GettableAcknowledgement struct {
+	ID             valuer.UUID       `json:"id"`
+	RuleID         string            `json:"ruleId"`
+	GroupLabels    map[string]string `json:"groupLabels"`
+	Acknowledged   bool              `json:"acknowledged"`
+	AcknowledgedBy string            `json:"acknowledgedBy,omitempty"`
+	AcknowledgedAt *time.Time        `json:"acknowledgedAt,omitempty"`
+	Assignee       string            `json:"assignee,omitempty"`
+	AssignedBy     string            `json:"assignedBy,omitempty"`
+	Comment        string            `json:"comment,omitempty"`
+	CreatedAt      time.Time         `json:"createdAt"`
+	UpdatedAt      time.Time         `json:"updatedAt"`
+}
+
+type GettableAcknowledgements = []*GettableAcknowledgement
+
+func (postable *PostableAcknowledgement) Validate() error {
+	if postable.Labels[DefaultGroupBy] == "" {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeAlertmanagerAcknowledgementInvalid, "the %s label is required", DefaultGroupBy)
+	}
+
+	return nil
+}
+
+// LabelSet returns the labels of the alert referenced by the postable acknowledgement.
+func (postable *PostableAcknowledgement) LabelSet() model.LabelSet {
+	labelSet := make(model.LabelSet, len(postable.Labels))
+	for name, value := range postable.Labels {
+		labelSet[model.LabelName(name)] = model.LabelValue(value)
+	}
+
+	return labelSet
+}
+
+// NewStorableAcknowledgement returns an acknowledgement of a group which is neither acknowledged nor assigned yet.
+func NewStorableAcknowledgement(orgID string, ruleID string, groupLabels model.LabelSet, now time.Time) (*StorableAcknowledgement, error) {
+	labels, err := json.Marshal(groupLabels)
+	if err != nil {
+		return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to marshal the group labels")
+	}
+
+	return &StorableAcknowledgement{
+		Identifiable: types.Identifiable{
+			ID: valuer.GenerateUUID(),
+		},
+		TimeAuditable: types.TimeAuditable{
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		OrgID:            orgID,
+		RuleID:           ruleID,
+		GroupFingerprint: groupLabels.Fingerprint().String(),
+		GroupLabels:      string(labels),
+	}, nil
+}
+
+func (acknowledgement *StorableAcknowledgement) Acknowledge(acknowledgedBy string, comment string, now time.Time) {
+	acknowledgement.AcknowledgedBy = acknowledgedBy
+	acknowledgement.AcknowledgedAt = now
+	acknowledgement.Comment = comment
+	acknowledgement.UpdatedAt = now
+}
+
+func (acknowledgement *StorableAcknowledgement) Unacknowledge(now time.Time) {
+	acknowledgement.AcknowledgedBy = ""
+	acknowledgement.AcknowledgedAt = time.Time{}
+	acknowledgement.Comment = ""
+	acknowledgement.UpdatedAt = now
+}
+
+func (acknowledgement *StorableAcknowledgement) Assign(assignee string, assignedBy string, now time.Time) {
+	acknowledgement.Assignee = assignee
+	acknowledgement.AssignedBy = assignedBy
+	if assignee == "" {
+		acknowledgement.AssignedBy = ""
+	}
+	acknowledgement.UpdatedAt = now
+}
+
+func (acknowledgement *StorableAcknowledgement) Acknowledged() bool {
+	return !acknowledgement.AcknowledgedAt.IsZero()
+}
+
+// Taken returns true if someone has taken the incident, either by acknowledging the group or by being assigned to it.
+func (acknowledgement *StorableAcknowledgement) Taken() bool {
+	return acknowledgement.Acknowledged() || acknowledgement.Assignee != ""
+}
+
+func NewGettableAcknowledgementFromStorableAcknowledgement(acknowledgement *StorableAcknowledgement) (*GettableAcknowledgement, error) {
+	groupLabels := map[string]string{}
+	if err := json.Unmarshal([]byte(acknowledgement.GroupLabels), &groupLabels); err != nil {
+		return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to unmarshal the group labels of acknowledgement %s", acknowledgement.ID.StringValue())
+	}
+
+	gettable := &GettableAcknowledgement{
+		ID:             acknowledgement.ID,
+		RuleID:         acknowledgement.RuleID,
+		GroupLabels:    groupLabels,
+		Acknowledged:   acknowledgement.Acknowledged(),
+		AcknowledgedBy: acknowledgement.AcknowledgedBy,
+		Assignee:       acknowledgement.Assignee,
+		AssignedBy:     acknowledgement.AssignedBy,
+		Comment:        acknowledgement.Comment,
+		CreatedAt:      acknowledgement.CreatedAt,
+		UpdatedAt:      acknowledgement.UpdatedAt,
+	}
+	if acknowledgement.Acknowledged() {
+		acknowledgedAt := acknowledgement.AcknowledgedAt
+		gettable.AcknowledgedAt = &acknowledgedAt
+	}
+
+	return gettable, nil
+}
+
+func NewGettableAcknowledgementsFromStorableAcknowledgements(acknowledgements []*StorableAcknowledgement) (GettableAcknowledgements, error) {
+	gettables := make(GettableAcknowledgements, 0, len(acknowledgements))
+	for _, acknowledgement := range acknowledgements {
+		gettable, err := NewGettableAcknowledgementFromStorableAcknowledgement(acknowledgement)
+		if err != nil {
+			return nil, err
+		}
+		gettables = append(gettables, gettable)
+	}
+
+	return gettables, nil
+}
+
+type AcknowledgementStore interface {
+	// Get gets the acknowledgement of a group of alerts of a rule by the fingerprint of the group labels.
+	Get(ctx context.Context, orgID string, ruleID string, groupFingerprint string) (*StorableAcknowledgement, error)
+
+	// List lists the acknowledgements of the organization.
+	List(ctx context.Context, orgID string) ([]*StorableAcknowledgement, error)
+
+	// Set creates the acknowledgement of a group or updates the existing one.
+	Set(ctx context.Context, acknowledgement *StorableAcknowledgement) error
+
+	// Delete deletes the acknowledgement of a group, it does nothing if the group is not acknowledged.
+	Delete(ctx context.Context, orgID string, ruleID string, groupFingerprint string) error
+}
+
+type AcknowledgementHistoryStore interface {
+	// Record records an acknowledgement event of the firing alerts of a rule in the history of the states of the alerts.
+	Record(ctx context.Context, ruleID string, event AcknowledgementEvent, alerts []model.LabelSet, at time.Time) error
+}
@@ -0,0 +1,46 @@
+package alertmanagertypes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostableAcknowledgementValidate(t *testing.T) {
+	postable := &PostableAcknowledgement{Labels: map[string]string{"service": "checkout"}}
+	assert.Error(t, postable.Validate())
+
+	postable = &PostableAcknowledgement{Labels: map[string]string{"ruleId": "r1", "service": "checkout"}}
+	require.NoError(t, postable.Validate())
+	assert.Equal(t, model.LabelSet{"ruleId": "r1", "service": "checkout"}, postable.LabelSet())
+}
+
+func TestStorableAcknowledgement(t *testing.T) {
+	now := time.Now()
+	acknowledgement, err := NewStorableAcknowledgement("org", "r1", model.LabelSet{"ruleId": "r1", "service": "checkout"}, now)
+	require.NoError(t, err)
+	assert.Equal(t, model.LabelSet{"ruleId": "r1", "service": "checkout"}.Fingerprint().String(), acknowledgement.GroupFingerprint)
+	assert.False(t, acknowledgement.Taken())
+
+	acknowledgement.Acknowledge("oncall@signoz.io", "looking into it", now)
+	assert.True(t, acknowledgement.Acknowledged())
+	assert.True(t, acknowledgement.Taken())
+
+	acknowledgement.Assign("sre@signoz.io", "oncall@signoz.io", now)
+	acknowledgement.Unacknowledge(now)
+	assert.False(t, acknowledgement.Acknowledged())
+	assert.True(t, acknowledgement.Taken())
+
+	gettable, err := NewGettableAcknowledgementFromStorableAcknowledgement(acknowledgement)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"ruleId": "r1", "service": "checkout"}, gettable.GroupLabels)
+	assert.Equal(t, "sre@signoz.io", gettable.Assignee)
+	assert.Nil(t, gettable.AcknowledgedAt)
+
+	acknowledgement.Assign("", "oncall@signoz.io", now)
+	assert.False(t, acknowledgement.Taken())
+	assert.Empty(t, acknowledgement.AssignedBy)
+}
@@ -0,0 +1,71 @@
+package alertmanagertypestest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+)
+
+var _ alertmanagertypes.AcknowledgementStore = (*AcknowledgementStore)(nil)
+
+type AcknowledgementStore struct {
+	acknowledgements map[string]*alertmanagertypes.StorableAcknowledgement
+	mtx              sync.RWMutex
+}
+
+func NewAcknowledgementStore() *AcknowledgementStore {
+	return &AcknowledgementStore{
+		acknowledgements: make(map[string]*alertmanagertypes.StorableAcknowledgement),
+	}
+}
+
+func acknowledgementKey(orgID string, ruleID string, groupFingerprint string) string {
+	return orgID + "/" + ruleID + "/" + groupFingerprint
+}
+
+func (s *AcknowledgementStore) Get(ctx context.Context, orgID string, ruleID string, groupFingerprint string) (*alertmanagertypes.StorableAcknowledgement, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	acknowledgement, ok := s.acknowledgements[acknowledgementKey(orgID, ruleID, groupFingerprint)]
+	if !ok {
+		return nil, errors.Newf(errors.TypeNotFound, alertmanagertypes.ErrCodeAlertmanagerAcknowledgementNotFound, "acknowledgement of group %q not found", groupFingerprint)
+	}
+
+	copied := *acknowledgement
+	return &copied, nil
+}
+
+func (s *AcknowledgementStore) List(ctx context.Context, orgID string) ([]*alertmanagertypes.StorableAcknowledgement, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	acknowledgements := []*alertmanagertypes.StorableAcknowledgement{}
+	for _, acknowledgement := range s.acknowledgements {
+		if acknowledgement.OrgID == orgID {
+			copied := *acknowledgement
+			acknowledgements = append(acknowledgements, &copied)
+		}
+	}
+
+	return acknowledgements, nil
+}
+
+func (s *AcknowledgementStore) Set(ctx context.Context, acknowledgement *alertmanagertypes.StorableAcknowledgement) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	copied := *acknowledgement
+	s.acknowledgements[acknowledgementKey(acknowledgement.OrgID, acknowledgement.RuleID, acknowledgement.GroupFingerprint)] = &copied
+	return nil
+}
+
+func (s *AcknowledgementStore) Delete(ctx context.Context, orgID string, ruleID string, groupFingerprint string) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	delete(s.acknowledgements, acknowledgementKey(orgID, ruleID, groupFingerprint))
+	return nil
+}
@@ -0,0 +1,97 @@
+package alertmanagertypestest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+var _ alertmanagertypes.DigestStore = (*DigestStore)(nil)
+
+type DigestStore struct {
+	digests map[string]*alertmanagertypes.StorableDigest
+	mtx     sync.RWMutex
+}
+
+func NewDigestStore() *DigestStore {
+	return &DigestStore{
+		digests: make(map[string]*alertmanagertypes.StorableDigest),
+	}
+}
+
+func digestKey(orgID string, channel string) string {
+	return orgID + "/" + channel
+}
+
+func (s *DigestStore) Get(ctx context.Context, orgID string, id valuer.UUID) (*alertmanagertypes.StorableDigest, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	for _, digest := range s.digests {
+		if digest.OrgID == orgID && digest.ID == id {
+			copied := *digest
+			return &copied, nil
+		}
+	}
+
+	return nil, errors.Newf(errors.TypeNotFound, alertmanagertypes.ErrCodeAlertmanagerDigestNotFound, "cannot find digest with id %s", id.StringValue())
+}
+
+func (s *DigestStore) GetByChannel(ctx context.Context, orgID string, channel string) (*alertmanagertypes.StorableDigest, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	digest, ok := s.digests[digestKey(orgID, channel)]
+	if !ok {
+		return nil, errors.Newf(errors.TypeNotFound, alertmanagertypes.ErrCodeAlertmanagerDigestNotFound, "cannot find digest of channel %s", channel)
+	}
+
+	copied := *digest
+	return &copied, nil
+}
+
+func (s *DigestStore) List(ctx context.Context, orgID string) ([]*alertmanagertypes.StorableDigest, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	digests := []*alertmanagertypes.StorableDigest{}
+	for _, digest := range s.digests {
+		if digest.OrgID == orgID {
+			copied := *digest
+			digests = append(digests, &copied)
+		}
+	}
+
+	return digests, nil
+}
+
+func (s *DigestStore) Set(ctx context.Context, digest *alertmanagertypes.StorableDigest) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	copied := *digest
+	if existing, ok := s.digests[digestKey(digest.OrgID, digest.Channel)]; ok {
+		copied.Identifiable = existing.Identifiable
+		copied.CreatedAt = existing.CreatedAt
+		copied.CreatedBy = existing.CreatedBy
+	}
+
+	s.digests[digestKey(digest.OrgID, digest.Channel)] = &copied
+	return nil
+}
+
+func (s *DigestStore) Delete(ctx context.Context, orgID string, id valuer.UUID) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	for key, digest := range s.digests {
+		if digest.OrgID == orgID && digest.ID == id {
+			delete(s.digests, key)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package alertmanagertypestest
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+var _ alertmanagertypes.WebhookDeliveryStore = (*WebhookDeliveryStore)(nil)
+
+type WebhookDeliveryStore struct {
+	deliveries map[string]*alertmanagertypes.StorableWebhookDelivery
+	mtx        sync.RWMutex
+}
+
+func NewWebhookDeliveryStore() *WebhookDeliveryStore {
+	return &WebhookDeliveryStore{
+		deliveries: make(map[string]*alertmanagertypes.StorableWebhookDelivery),
+	}
+}
+
+func (s *WebhookDeliveryStore) Create(ctx context.Context, delivery *alertmanagertypes.StorableWebhookDelivery) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	copied := *delivery
+	s.deliveries[delivery.ID.StringValue()] = &copied
+	return nil
+}
+
+func (s *WebhookDeliveryStore) Get(ctx context.Context, orgID string, id valuer.UUID) (*alertmanagertypes.StorableWebhookDelivery, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	delivery, ok := s.deliveries[id.StringValue()]
+	if !ok || delivery.OrgID != orgID {
+		return nil, errors.Newf(errors.TypeNotFound, alertmanagertypes.ErrCodeAlertmanagerWebhookDeliveryNotFound, "cannot find webhook delivery with id %s", id.StringValue())
+	}
+
+	copied := *delivery
+	return &copied, nil
+}
+
+func (s *WebhookDeliveryStore) List(ctx context.Context, orgID string, params *alertmanagertypes.ListWebhookDeliveriesParams) ([]*alertmanagertypes.StorableWebhookDelivery, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	deliveries := []*alertmanagertypes.StorableWebhookDelivery{}
+	for _, delivery := range s.deliveries {
+		if delivery.OrgID != orgID || (params.Receiver != "" && delivery.Receiver != params.Receiver) || (!params.Status.IsZero() && delivery.Status != params.Status) {
+			continue
+		}
+
+		copied := *delivery
+		deliveries = append(deliveries, &copied)
+	}
+
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].CreatedAt.After(deliveries[j].CreatedAt) })
+	if params.Limit > 0 && len(deliveries) > params.Limit {
+		deliveries = deliveries[:params.Limit]
+	}
+
+	return deliveries, nil
+}
+
+func (s *WebhookDeliveryStore) Update(ctx context.Context, delivery *alertmanagertypes.StorableWebhookDelivery) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if _, ok := s.deliveries[delivery.ID.StringValue()]; !ok {
+		return errors.Newf(errors.TypeNotFound, alertmanagertypes.ErrCodeAlertmanagerWebhookDeliveryNotFound, "cannot find webhook delivery with id %s", delivery.ID.StringValue())
+	}
+
+	copied := *delivery
+	s.deliveries[delivery.ID.StringValue()] = &copied
+	return nil
+}
+
+func (s *WebhookDeliveryStore) DeleteBefore(ctx context.Context, before time.Time) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	for id, delivery := range s.deliveries {
+		if delivery.CreatedAt.Before(before) {
+			delete(s.deliveries, id)
+		}
+	}
+
+	return nil
+}
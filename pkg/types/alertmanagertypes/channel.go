@@ -107,6 +107,52 @@ func NewChannelFromReceiver(receiver config.Receiver, orgID string) (*Channel, e
 	return &channel, nil
 }
 
+// NewGettableChannelFromChannel returns a copy of the channel whose secrets of the signoz webhooks are redacted.
+func NewGettableChannelFromChannel(channel *Channel) (*Channel, error) {
+	gettable := *channel
+	if channel.Type != "webhook" {
+		return &gettable, nil
+	}
+
+	receiver := Receiver{}
+	if err := json.Unmarshal([]byte(channel.Data), &receiver); err != nil {
+		return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to unmarshal channel %q", channel.Name)
+	}
+
+	redacted := false
+	for _, c := range receiver.WebhookConfigs {
+		if WebhookKindOf(c) == WebhookKindSigNoz && c.HTTPConfig != nil && c.HTTPConfig.Authorization != nil && c.HTTPConfig.Authorization.Credentials != "" {
+			c.HTTPConfig.Authorization.Credentials = RedactedSecret
+			redacted = true
+		}
+	}
+
+	if !redacted {
+		return &gettable, nil
+	}
+
+	data, err := json.Marshal(receiver)
+	if err != nil {
+		return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to marshal channel %q", channel.Name)
+	}
+
+	gettable.Data = string(data)
+	return &gettable, nil
+}
+
+func NewGettableChannelsFromChannels(channels Channels) (GettableChannels, error) {
+	gettables := make(GettableChannels, 0, len(channels))
+	for _, channel := range channels {
+		gettable, err := NewGettableChannelFromChannel(channel)
+		if err != nil {
+			return nil, err
+		}
+		gettables = append(gettables, gettable)
+	}
+
+	return gettables, nil
+}
+
 func NewConfigFromChannels(globalConfig GlobalConfig, routeConfig RouteConfig, channels Channels, orgID string) (*Config, error) {
 	cfg, err := NewDefaultConfig(
 		globalConfig,
@@ -168,6 +214,29 @@ func NewStatsFromChannels(channels Channels) map[string]any {
 	return stats
 }
 
+// RestoreRedactedSecrets sets the secrets of the signoz webhooks of the receiver which are redacted to the ones of the
+// channel, so that a channel returned by the api can be updated as is.
+func (c *Channel) RestoreRedactedSecrets(receiver Receiver) error {
+	stored := Receiver{}
+	if err := json.Unmarshal([]byte(c.Data), &stored); err != nil {
+		return errors.WrapInternalf(err, errors.CodeInternal, "failed to unmarshal channel %q", c.Name)
+	}
+
+	for i, webhookConfig := range receiver.WebhookConfigs {
+		if WebhookKindOf(webhookConfig) != WebhookKindSigNoz || webhookConfig.HTTPConfig == nil || webhookConfig.HTTPConfig.Authorization == nil || webhookConfig.HTTPConfig.Authorization.Credentials != RedactedSecret {
+			continue
+		}
+
+		if i >= len(stored.WebhookConfigs) || WebhookKindOf(stored.WebhookConfigs[i]) != WebhookKindSigNoz || stored.WebhookConfigs[i].HTTPConfig == nil || stored.WebhookConfigs[i].HTTPConfig.Authorization == nil {
+			return errors.Newf(errors.TypeInvalidInput, ErrCodeAlertmanagerChannelInvalid, "secret of signoz webhook %d of channel '%s' must be set", i, receiver.Name)
+		}
+
+		webhookConfig.HTTPConfig.Authorization.Credentials = stored.WebhookConfigs[i].HTTPConfig.Authorization.Credentials
+	}
+
+	return nil
+}
+
 func (c *Channel) Update(receiver Receiver) error {
 	channel, err := NewChannelFromReceiver(receiver, c.OrgID)
 	if err != nil {
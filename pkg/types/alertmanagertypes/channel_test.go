@@ -9,6 +9,7 @@ import (
 	"github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewConfigFromChannels(t *testing.T) {
@@ -292,3 +293,30 @@ func TestNewChannelFromReceiver(t *testing.T) {
 	}
 
 }
+
+func TestNewGettableChannelFromChannelAndRestoreRedactedSecrets(t *testing.T) {
+	receiver, err := NewReceiver(`{"name":"automation","webhook_configs":[{"url":"https://automation.signoz.io/alerts#signoz","http_config":{"authorization":{"type":"HMAC-SHA256","credentials":"s3cr3t"}}}]}`)
+	require.NoError(t, err)
+
+	channel, err := NewChannelFromReceiver(receiver, "1")
+	require.NoError(t, err)
+	require.Contains(t, channel.Data, "s3cr3t")
+
+	gettable, err := NewGettableChannelFromChannel(channel)
+	require.NoError(t, err)
+	assert.NotContains(t, gettable.Data, "s3cr3t")
+	assert.Contains(t, channel.Data, "s3cr3t")
+
+	// the channel returned by the api is updated as is
+	updated, err := NewReceiver(gettable.Data)
+	require.NoError(t, err)
+	assert.Equal(t, RedactedSecret, string(updated.WebhookConfigs[0].HTTPConfig.Authorization.Credentials))
+	require.NoError(t, channel.RestoreRedactedSecrets(updated))
+	assert.Equal(t, "s3cr3t", string(updated.WebhookConfigs[0].HTTPConfig.Authorization.Credentials))
+
+	// a new secret replaces the stored one
+	updated, err = NewReceiver(`{"name":"automation","webhook_configs":[{"url":"https://automation.signoz.io/alerts#signoz","http_config":{"authorization":{"type":"HMAC-SHA256","credentials":"n3w"}}}]}`)
+	require.NoError(t, err)
+	require.NoError(t, channel.RestoreRedactedSecrets(updated))
+	assert.Equal(t, "n3w", string(updated.WebhookConfigs[0].HTTPConfig.Authorization.Credentials))
+}
@@ -466,6 +466,8 @@ func (nc *NotificationConfig) DeepCopy() NotificationConfig {
 type ReNotificationConfig struct {
 	NoDataInterval   time.Duration
 	RenotifyInterval time.Duration
+	// SuppressWhenAcknowledged suppresses the re-notifications of the acknowledged groups
+	SuppressWhenAcknowledged bool
 }
 
 func NewNotificationConfig(groups []string, renotifyInterval time.Duration, noDataRenotifyInterval time.Duration, policy bool) NotificationConfig {
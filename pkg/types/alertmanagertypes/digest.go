@@ -0,0 +1,228 @@
+package alertmanagertypes
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/prometheus/common/model"
+	"github.com/uptrace/bun"
+)
+
+var (
+	ErrCodeAlertmanagerDigestNotFound = errors.MustNewCode("alertmanager_digest_not_found")
+	ErrCodeAlertmanagerDigestInvalid  = errors.MustNewCode("alertmanager_digest_invalid")
+)
+
+// DigestLabel is the group label of the notifications of a digest, whose value is the period of the digest.
+const DigestLabel = "digest"
+
+type DigestPeriod struct{ valuer.String }
+
+var (
+	DigestPeriodHourly = DigestPeriod{valuer.NewString("hourly")}
+	DigestPeriodDaily  = DigestPeriod{valuer.NewString("daily")}
+)
+
+// PostableDigest makes a channel collect the alerts notified to it over a period and send one summary of them at the
+// end of the period, instead of notifying them. Severities restricts the digest to the alerts of these severities, the
+// other alerts being notified right away.
+type PostableDigest struct {
+	Channel string       `json:"channel"`
+	Period  DigestPeriod `json:"period"`
+	// Hour is the hour of the day the daily digests are sent at, in the timezone of the digest.
+	Hour       int      `json:"hour,omitempty"`
+	Timezone   string   `json:"timezone,omitempty"`
+	Severities []string `json:"severities,omitempty"`
+}
+
+func (postable *PostableDigest) Validate() error {
+	if strings.TrimSpace(postable.Channel) == "" {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeAlertmanagerDigestInvalid, "channel is required")
+	}
+
+	if postable.Period != DigestPeriodHourly && postable.Period != DigestPeriodDaily {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeAlertmanagerDigestInvalid, "period must be one of hourly or daily")
+	}
+
+	if postable.Hour < 0 || postable.Hour > 23 {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeAlertmanagerDigestInvalid, "hour must be between 0 and 23")
+	}
+
+	if _, err := time.LoadLocation(postable.Timezone); err != nil {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeAlertmanagerDigestInvalid, "invalid timezone %q", postable.Timezone)
+	}
+
+	if slices.Contains(postable.Severities, "") {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeAlertmanagerDigestInvalid, "severities cannot be empty")
+	}
+
+	return nil
+}
+
+// StorableDigest is the digest of a channel, a channel has at most one digest.
+type StorableDigest struct {
+	bun.BaseModel `bun:"table:alert_digest"`
+	types.Identifiable
+	types.TimeAuditable
+	types.UserAuditable
+
+	Channel    string       `bun:"channel,type:text,notnull"`
+	Period     DigestPeriod `bun:"period,type:text,notnull"`
+	Hour       int          `bun:"hour"`
+	Timezone   string       `bun:"timezone,type:text"`
+	Severities []string     `bun:"severities,type:jsonb"`
+	// SentAt is the time the last digest was sent at, which starts the current period.
+	SentAt time.Time `bun:"sent_at,nullzero"`
+
+	OrgID string `bun:"org_id,type:text,notnull"`
+}
+
+type GettableDigest struct {
+	PostableDigest
+
+	ID valuer.UUID `json:"id"`
+	// SentAt and NextAt are the times the last and the next digests are sent at.
+	SentAt *time.Time `json:"sentAt,omitempty"`
+	NextAt time.Time  `json:"nextAt"`
+
+	// Audit fields
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	CreatedBy string    `json:"createdBy"`
+	UpdatedBy string    `json:"updatedBy"`
+}
+
+type GettableDigests = []*GettableDigest
+
+func NewStorableDigest(orgID string, createdBy string, postable *PostableDigest, now time.Time) (*StorableDigest, error) {
+	if err := postable.Validate(); err != nil {
+		return nil, err
+	}
+
+	digest := &StorableDigest{
+		Identifiable: types.Identifiable{
+			ID: valuer.GenerateUUID(),
+		},
+		TimeAuditable: types.TimeAuditable{
+			CreatedAt: now,
+		},
+		UserAuditable: types.UserAuditable{
+			CreatedBy: createdBy,
+		},
+		OrgID: orgID,
+	}
+	digest.Update(postable, createdBy, now)
+
+	return digest, nil
+}
+
+// Update replaces the definition of the digest with the postable digest, which is expected to be valid.
+func (digest *StorableDigest) Update(postable *PostableDigest, updatedBy string, now time.Time) {
+	digest.Channel = postable.Channel
+	digest.Period = postable.Period
+	digest.Hour = postable.Hour
+	digest.Timezone = postable.Timezone
+	digest.Severities = postable.Severities
+	digest.UpdatedBy = updatedBy
+	digest.UpdatedAt = now
+}
+
+// Since returns the start of the current period of the digest.
+func (digest *StorableDigest) Since() time.Time {
+	if digest.SentAt.IsZero() {
+		return digest.CreatedAt
+	}
+
+	return digest.SentAt
+}
+
+// NextAt returns the end of the current period of the digest, hourly digests are sent at the start of every hour and
+// daily digests at their hour of the day, in the timezone of the digest.
+func (digest *StorableDigest) NextAt() time.Time {
+	loc, err := time.LoadLocation(digest.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	since := digest.Since().In(loc)
+	if digest.Period == DigestPeriodHourly {
+		// the hours are truncated in the timezone, whose offset is not always a whole number of hours
+		return time.Date(since.Year(), since.Month(), since.Day(), since.Hour()+1, 0, 0, 0, loc)
+	}
+
+	next := time.Date(since.Year(), since.Month(), since.Day(), digest.Hour, 0, 0, 0, loc)
+	if !next.After(since) {
+		next = time.Date(since.Year(), since.Month(), since.Day()+1, digest.Hour, 0, 0, 0, loc)
+	}
+
+	return next
+}
+
+// Due returns true if the current period of the digest is over at now.
+func (digest *StorableDigest) Due(now time.Time) bool {
+	return !digest.NextAt().After(now)
+}
+
+// Digests returns true if an alert with the given labels is collected by the digest rather than notified.
+func (digest *StorableDigest) Digests(labels model.LabelSet) bool {
+	if len(digest.Severities) == 0 {
+		return true
+	}
+
+	return slices.Contains(digest.Severities, string(labels["severity"]))
+}
+
+func NewGettableDigest(digest *StorableDigest) *GettableDigest {
+	gettable := &GettableDigest{
+		PostableDigest: PostableDigest{
+			Channel:    digest.Channel,
+			Period:     digest.Period,
+			Hour:       digest.Hour,
+			Timezone:   digest.Timezone,
+			Severities: digest.Severities,
+		},
+		ID:        digest.ID,
+		NextAt:    digest.NextAt(),
+		CreatedAt: digest.CreatedAt,
+		UpdatedAt: digest.UpdatedAt,
+		CreatedBy: digest.CreatedBy,
+		UpdatedBy: digest.UpdatedBy,
+	}
+	if !digest.SentAt.IsZero() {
+		sentAt := digest.SentAt
+		gettable.SentAt = &sentAt
+	}
+
+	return gettable
+}
+
+func NewGettableDigests(digests []*StorableDigest) GettableDigests {
+	gettables := make(GettableDigests, 0, len(digests))
+	for _, digest := range digests {
+		gettables = append(gettables, NewGettableDigest(digest))
+	}
+
+	return gettables
+}
+
+type DigestStore interface {
+	// Get gets a digest of the organization by its id.
+	Get(ctx context.Context, orgID string, id valuer.UUID) (*StorableDigest, error)
+
+	// GetByChannel gets the digest of a channel of the organization.
+	GetByChannel(ctx context.Context, orgID string, channel string) (*StorableDigest, error)
+
+	// List lists the digests of the organization.
+	List(ctx context.Context, orgID string) ([]*StorableDigest, error)
+
+	// Set creates the digest of a channel or updates the existing one.
+	Set(ctx context.Context, digest *StorableDigest) error
+
+	// Delete deletes a digest of the organization.
+	Delete(ctx context.Context, orgID string, id valuer.UUID) error
+}
@@ -0,0 +1,140 @@
+package alertmanagertypes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostableDigestValidate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		postable PostableDigest
+		pass     bool
+	}{
+		{
+			name:     "Hourly",
+			postable: PostableDigest{Channel: "low-severity", Period: DigestPeriodHourly},
+			pass:     true,
+		},
+		{
+			name:     "DailyWithTimezone",
+			postable: PostableDigest{Channel: "low-severity", Period: DigestPeriodDaily, Hour: 9, Timezone: "Asia/Kolkata", Severities: []string{"info", "warning"}},
+			pass:     true,
+		},
+		{
+			name:     "NoChannel",
+			postable: PostableDigest{Period: DigestPeriodHourly},
+			pass:     false,
+		},
+		{
+			name:     "InvalidPeriod",
+			postable: PostableDigest{Channel: "low-severity", Period: DigestPeriod{}},
+			pass:     false,
+		},
+		{
+			name:     "InvalidHour",
+			postable: PostableDigest{Channel: "low-severity", Period: DigestPeriodDaily, Hour: 24},
+			pass:     false,
+		},
+		{
+			name:     "InvalidTimezone",
+			postable: PostableDigest{Channel: "low-severity", Period: DigestPeriodDaily, Timezone: "Mars/Olympus"},
+			pass:     false,
+		},
+		{
+			name:     "EmptySeverity",
+			postable: PostableDigest{Channel: "low-severity", Period: DigestPeriodHourly, Severities: []string{""}},
+			pass:     false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.postable.Validate()
+			if tc.pass {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestStorableDigestNextAt(t *testing.T) {
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		postable PostableDigest
+		since    time.Time
+		expected time.Time
+	}{
+		{
+			name:     "Hourly",
+			postable: PostableDigest{Channel: "low-severity", Period: DigestPeriodHourly},
+			since:    time.Date(2025, 3, 10, 10, 20, 0, 0, time.UTC),
+			expected: time.Date(2025, 3, 10, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			// Kolkata is 5h30 ahead of UTC, its hours start at half past the UTC hours
+			name:     "HourlyWithTimezone",
+			postable: PostableDigest{Channel: "low-severity", Period: DigestPeriodHourly, Timezone: "Asia/Kolkata"},
+			since:    time.Date(2025, 3, 10, 10, 20, 0, 0, time.UTC),
+			expected: time.Date(2025, 3, 10, 10, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "DailyLaterToday",
+			postable: PostableDigest{Channel: "low-severity", Period: DigestPeriodDaily, Hour: 9},
+			since:    time.Date(2025, 3, 10, 8, 0, 0, 0, time.UTC),
+			expected: time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "DailyTomorrow",
+			postable: PostableDigest{Channel: "low-severity", Period: DigestPeriodDaily, Hour: 9},
+			since:    time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC),
+			expected: time.Date(2025, 3, 11, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "DailyWithTimezone",
+			postable: PostableDigest{Channel: "low-severity", Period: DigestPeriodDaily, Hour: 9, Timezone: "Asia/Kolkata"},
+			since:    time.Date(2025, 3, 10, 4, 0, 0, 0, time.UTC),
+			expected: time.Date(2025, 3, 11, 9, 0, 0, 0, kolkata),
+		},
+		{
+			// New York switches to daylight saving time on 2025-03-09, the digest is still sent at 9 in New York
+			name:     "DailyAcrossDaylightSavingTime",
+			postable: PostableDigest{Channel: "low-severity", Period: DigestPeriodDaily, Hour: 9, Timezone: "America/New_York"},
+			since:    time.Date(2025, 3, 8, 14, 0, 0, 0, time.UTC),
+			expected: time.Date(2025, 3, 9, 13, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, err := NewStorableDigest("1", "admin@signoz.io", &tc.postable, tc.since.Add(-24*time.Hour))
+			require.NoError(t, err)
+			digest.SentAt = tc.since
+
+			assert.True(t, tc.expected.Equal(digest.NextAt()), "expected %s, got %s", tc.expected, digest.NextAt())
+			assert.False(t, digest.Due(tc.expected.Add(-time.Second)))
+			assert.True(t, digest.Due(tc.expected))
+		})
+	}
+}
+
+func TestStorableDigestDigests(t *testing.T) {
+	digest, err := NewStorableDigest("1", "admin@signoz.io", &PostableDigest{Channel: "low-severity", Period: DigestPeriodHourly, Severities: []string{"info"}}, time.Now())
+	require.NoError(t, err)
+
+	assert.True(t, digest.Digests(model.LabelSet{"severity": "info"}))
+	assert.False(t, digest.Digests(model.LabelSet{"severity": "critical"}))
+	assert.False(t, digest.Digests(model.LabelSet{}))
+
+	digest.Severities = nil
+	assert.True(t, digest.Digests(model.LabelSet{"severity": "critical"}))
+}
@@ -0,0 +1,266 @@
+package alertmanagertypes
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/uptrace/bun"
+)
+
+var (
+	ErrCodeEscalationPolicyNotFound = errors.MustNewCode("escalation_policy_not_found")
+	ErrCodeEscalationPolicyInvalid  = errors.MustNewCode("escalation_policy_invalid")
+)
+
+// EscalationStep notifies its channels once the incident is left untaken for the delay of the step. The delay of the
+// first step runs from the start of the escalation, the delay of the other steps from the previous step.
+type EscalationStep struct {
+	Delay    valuer.TextDuration `json:"delay"`
+	Channels []string            `json:"channels"`
+}
+
+type PostableEscalationPolicy struct {
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Steps       []EscalationStep `json:"steps"`
+	// Repeat is the number of times the steps are repeated after the last step.
+	Repeat int `json:"repeat,omitempty"`
+	// RepeatInterval is the time between the last step and the first step of the next repetition.
+	RepeatInterval valuer.TextDuration `json:"repeatInterval,omitzero"`
+	// Rules and RoutePolicies are the ids of the rules and of the route policies the policy is attached to.
+	Rules         []string `json:"rules,omitempty"`
+	RoutePolicies []string `json:"routePolicies,omitempty"`
+}
+
+func (p *PostableEscalationPolicy) Validate() error {
+	if strings.TrimSpace(p.Name) == "" {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeEscalationPolicyInvalid, "name is required")
+	}
+
+	if len(p.Steps) == 0 {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeEscalationPolicyInvalid, "at least one step is required")
+	}
+
+	for i, step := range p.Steps {
+		if step.Delay.Duration() < 0 {
+			return errors.Newf(errors.TypeInvalidInput, ErrCodeEscalationPolicyInvalid, "delay of step %d cannot be negative", i)
+		}
+
+		if len(step.Channels) == 0 {
+			return errors.Newf(errors.TypeInvalidInput, ErrCodeEscalationPolicyInvalid, "step %d requires at least one channel", i)
+		}
+
+		if slices.Contains(step.Channels, "") {
+			return errors.Newf(errors.TypeInvalidInput, ErrCodeEscalationPolicyInvalid, "channels of step %d cannot be empty", i)
+		}
+	}
+
+	if p.Repeat < 0 {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeEscalationPolicyInvalid, "repeat cannot be negative")
+	}
+
+	if p.Repeat > 0 && !p.RepeatInterval.IsPositive() {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeEscalationPolicyInvalid, "repeat interval must be positive when the steps are repeated")
+	}
+
+	if len(p.Rules) == 0 && len(p.RoutePolicies) == 0 {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeEscalationPolicyInvalid, "the policy must be attached to at least one rule or route policy")
+	}
+
+	return nil
+}
+
+type GettableEscalationPolicy struct {
+	PostableEscalationPolicy
+
+	ID string `json:"id"`
+
+	// Audit fields
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	CreatedBy string    `json:"createdBy"`
+	UpdatedBy string    `json:"updatedBy"`
+}
+
+// EscalationPolicy represents the database model for escalation policies.
+type EscalationPolicy struct {
+	bun.BaseModel `bun:"table:escalation_policy"`
+	types.Identifiable
+	types.TimeAuditable
+	types.UserAuditable
+
+	Name           string              `bun:"name,type:text,notnull"`
+	Description    string              `bun:"description,type:text"`
+	Steps          []EscalationStep    `bun:"steps,type:jsonb"`
+	Repeat         int                 `bun:"repeat"`
+	RepeatInterval valuer.TextDuration `bun:"repeat_interval,type:text"`
+	Rules          []string            `bun:"rules,type:jsonb"`
+	RoutePolicies  []string            `bun:"route_policies,type:jsonb"`
+
+	OrgID string `bun:"org_id,type:text,notnull"`
+}
+
+func NewEscalationPolicy(orgID string, createdBy string, postable *PostableEscalationPolicy, now time.Time) (*EscalationPolicy, error) {
+	if err := postable.Validate(); err != nil {
+		return nil, err
+	}
+
+	policy := &EscalationPolicy{
+		Identifiable: types.Identifiable{
+			ID: valuer.GenerateUUID(),
+		},
+		TimeAuditable: types.TimeAuditable{
+			CreatedAt: now,
+		},
+		UserAuditable: types.UserAuditable{
+			CreatedBy: createdBy,
+		},
+		OrgID: orgID,
+	}
+	policy.Update(postable, createdBy, now)
+
+	return policy, nil
+}
+
+// Update replaces the definition of the policy with the postable policy, which is expected to be valid.
+func (policy *EscalationPolicy) Update(postable *PostableEscalationPolicy, updatedBy string, now time.Time) {
+	policy.Name = postable.Name
+	policy.Description = postable.Description
+	policy.Steps = postable.Steps
+	policy.Repeat = postable.Repeat
+	policy.RepeatInterval = postable.RepeatInterval
+	policy.Rules = postable.Rules
+	policy.RoutePolicies = postable.RoutePolicies
+	policy.UpdatedBy = updatedBy
+	policy.UpdatedAt = now
+}
+
+// AttachedTo returns true if the policy is attached to the rule or to one of the route policies.
+func (policy *EscalationPolicy) AttachedTo(ruleID string, routePolicyIDs []string) bool {
+	if slices.Contains(policy.Rules, ruleID) {
+		return true
+	}
+
+	for _, routePolicyID := range routePolicyIDs {
+		if slices.Contains(policy.RoutePolicies, routePolicyID) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Advance moves the escalation past the steps of the policy which are due at now and returns the channels of these
+// steps. Steps missed while the escalation was not advanced, e.g. during a restart, are notified at once.
+func (policy *EscalationPolicy) Advance(escalation *Escalation, now time.Time) []string {
+	channels := []string{}
+	for escalation.Step < len(policy.Steps) && !escalation.NextAt.After(now) {
+		channels = append(channels, policy.Steps[escalation.Step].Channels...)
+		escalation.NotifiedAt = now
+		escalation.UpdatedAt = now
+		escalation.Step++
+
+		switch {
+		case escalation.Step < len(policy.Steps):
+			escalation.NextAt = escalation.NextAt.Add(policy.Steps[escalation.Step].Delay.Duration())
+		case escalation.Repetition < policy.Repeat:
+			escalation.Step = 0
+			escalation.Repetition++
+			escalation.NextAt = escalation.NextAt.Add(policy.RepeatInterval.Duration() + policy.Steps[0].Delay.Duration())
+		}
+	}
+
+	slices.Sort(channels)
+	return slices.Compact(channels)
+}
+
+func NewGettableEscalationPolicy(policy *EscalationPolicy) *GettableEscalationPolicy {
+	return &GettableEscalationPolicy{
+		PostableEscalationPolicy: PostableEscalationPolicy{
+			Name:           policy.Name,
+			Description:    policy.Description,
+			Steps:          policy.Steps,
+			Repeat:         policy.Repeat,
+			RepeatInterval: policy.RepeatInterval,
+			Rules:          policy.Rules,
+			RoutePolicies:  policy.RoutePolicies,
+		},
+		ID:        policy.ID.StringValue(),
+		CreatedAt: policy.CreatedAt,
+		UpdatedAt: policy.UpdatedAt,
+		CreatedBy: policy.CreatedBy,
+		UpdatedBy: policy.UpdatedBy,
+	}
+}
+
+func NewGettableEscalationPolicies(policies []*EscalationPolicy) []*GettableEscalationPolicy {
+	gettables := make([]*GettableEscalationPolicy, 0, len(policies))
+	for _, policy := range policies {
+		gettables = append(gettables, NewGettableEscalationPolicy(policy))
+	}
+
+	return gettables
+}
+
+// Escalation is the state of the escalation of a group of firing alerts of a rule by a policy. It is kept until the
+// group is resolved or someone takes the incident, even once all the steps of the policy are notified.
+type Escalation struct {
+	bun.BaseModel `bun:"table:alert_escalation"`
+
+	types.Identifiable
+	types.TimeAuditable
+	OrgID            string `bun:"org_id"`
+	RuleID           string `bun:"rule_id"`
+	GroupFingerprint string `bun:"group_fingerprint"`
+	PolicyID         string `bun:"policy_id"`
+	// Step is the index of the next step to notify, the escalation is over once it reaches the number of steps.
+	Step       int       `bun:"step"`
+	Repetition int       `bun:"repetition"`
+	NextAt     time.Time `bun:"next_at"`
+	NotifiedAt time.Time `bun:"notified_at,nullzero"`
+}
+
+// NewEscalation starts the escalation of a group by a policy at now.
+func NewEscalation(orgID string, ruleID string, groupFingerprint string, policy *EscalationPolicy, now time.Time) *Escalation {
+	return &Escalation{
+		Identifiable: types.Identifiable{
+			ID: valuer.GenerateUUID(),
+		},
+		TimeAuditable: types.TimeAuditable{
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		OrgID:            orgID,
+		RuleID:           ruleID,
+		GroupFingerprint: groupFingerprint,
+		PolicyID:         policy.ID.StringValue(),
+		NextAt:           now.Add(policy.Steps[0].Delay.Duration()),
+	}
+}
+
+type EscalationStore interface {
+	GetPolicyByID(ctx context.Context, orgID string, id string) (*EscalationPolicy, error)
+	GetAllPolicies(ctx context.Context, orgID string) ([]*EscalationPolicy, error)
+	CreatePolicy(ctx context.Context, policy *EscalationPolicy) error
+	UpdatePolicy(ctx context.Context, policy *EscalationPolicy) error
+
+	// DeletePolicy deletes the policy along with the escalations by the policy.
+	DeletePolicy(ctx context.Context, orgID string, id string) error
+
+	// GetAll gets the escalations of all the groups of the organization.
+	GetAll(ctx context.Context, orgID string) ([]*Escalation, error)
+
+	// GetAllByGroup gets the escalations of a group of alerts of a rule by the fingerprint of the group labels.
+	GetAllByGroup(ctx context.Context, orgID string, ruleID string, groupFingerprint string) ([]*Escalation, error)
+
+	// Set creates the escalation of a group by a policy or updates the existing one.
+	Set(ctx context.Context, escalation *Escalation) error
+
+	// DeleteAllByGroup deletes the escalations of a group, it does nothing if the group is not escalated.
+	DeleteAllByGroup(ctx context.Context, orgID string, ruleID string, groupFingerprint string) error
+}
@@ -0,0 +1,79 @@
+package alertmanagertypes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostableEscalationPolicy_Validate(t *testing.T) {
+	valid := func() *PostableEscalationPolicy {
+		return &PostableEscalationPolicy{
+			Name:  "payments",
+			Steps: []EscalationStep{{Channels: []string{"oncall"}}, {Delay: valuer.MustParseTextDuration("15m"), Channels: []string{"secondary"}}},
+			Rules: []string{"rule-1"},
+		}
+	}
+	require.NoError(t, valid().Validate())
+
+	testCases := []struct {
+		name   string
+		mutate func(*PostableEscalationPolicy)
+	}{
+		{name: "NoName", mutate: func(p *PostableEscalationPolicy) { p.Name = " " }},
+		{name: "NoSteps", mutate: func(p *PostableEscalationPolicy) { p.Steps = nil }},
+		{name: "StepWithoutChannels", mutate: func(p *PostableEscalationPolicy) { p.Steps[1].Channels = nil }},
+		{name: "StepWithEmptyChannel", mutate: func(p *PostableEscalationPolicy) { p.Steps[1].Channels = []string{""} }},
+		{name: "NegativeDelay", mutate: func(p *PostableEscalationPolicy) { p.Steps[1].Delay = valuer.MustParseTextDuration("-1m") }},
+		{name: "RepeatWithoutInterval", mutate: func(p *PostableEscalationPolicy) { p.Repeat = 2 }},
+		{name: "NotAttached", mutate: func(p *PostableEscalationPolicy) { p.Rules = nil }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			postable := valid()
+			tc.mutate(postable)
+			assert.Error(t, postable.Validate())
+		})
+	}
+}
+
+func TestEscalationPolicy_Advance(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	policy, err := NewEscalationPolicy("org-1", "admin@signoz.io", &PostableEscalationPolicy{
+		Name: "payments",
+		Steps: []EscalationStep{
+			{Channels: []string{"oncall"}},
+			{Delay: valuer.MustParseTextDuration("15m"), Channels: []string{"secondary"}},
+			{Delay: valuer.MustParseTextDuration("15m"), Channels: []string{"manager"}},
+		},
+		Repeat:         1,
+		RepeatInterval: valuer.MustParseTextDuration("1h"),
+		Rules:          []string{"rule-1"},
+	}, start)
+	require.NoError(t, err)
+
+	escalation := NewEscalation("org-1", "rule-1", "fp", policy, start)
+	assert.Equal(t, []string{"oncall"}, policy.Advance(escalation, start))
+	assert.Empty(t, policy.Advance(escalation, start.Add(14*time.Minute)))
+	assert.Equal(t, []string{"secondary"}, policy.Advance(escalation, start.Add(15*time.Minute)))
+
+	// the steps missed in between are notified at once
+	assert.Equal(t, []string{"manager"}, policy.Advance(escalation, start.Add(45*time.Minute)))
+	assert.Equal(t, start.Add(90*time.Minute), escalation.NextAt)
+
+	assert.Equal(t, []string{"manager", "oncall", "secondary"}, policy.Advance(escalation, start.Add(3*time.Hour)))
+	assert.Equal(t, len(policy.Steps), escalation.Step)
+	assert.Empty(t, policy.Advance(escalation, start.Add(24*time.Hour)))
+}
+
+func TestEscalationPolicy_AttachedTo(t *testing.T) {
+	policy := &EscalationPolicy{Rules: []string{"rule-1"}, RoutePolicies: []string{"route-1"}}
+
+	assert.True(t, policy.AttachedTo("rule-1", nil))
+	assert.True(t, policy.AttachedTo("rule-2", []string{"route-2", "route-1"}))
+	assert.False(t, policy.AttachedTo("rule-2", []string{"route-2"}))
+}
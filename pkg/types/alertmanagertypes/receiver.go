@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"github.com/prometheus/common/model"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/SigNoz/signoz/pkg/errors"
@@ -22,6 +23,30 @@ type (
 	ReceiverIntegrationsFunc = func(nc Receiver, tmpl *template.Template, logger *slog.Logger) ([]notify.Integration, error)
 )
 
+// The chat integrations without a config upstream are webhook configs marked by the fragment of their url, which is
+// never sent over the wire. The parameters of a kind follow it in the fragment as a query (#signoz?max_attempts=5).
+const (
+	WebhookKindMattermost = "mattermost"
+	WebhookKindGoogleChat = "googlechat"
+	WebhookKindZulip      = "zulip"
+	WebhookKindSigNoz     = "signoz"
+)
+
+// WebhookKindOf returns the kind of chat integration targeted by a webhook config, or an empty string for a plain webhook.
+func WebhookKindOf(c *config.WebhookConfig) string {
+	if c.URL == nil || c.URL.URL == nil {
+		return ""
+	}
+
+	kind, _, _ := strings.Cut(c.URL.Fragment, "?")
+	switch kind {
+	case WebhookKindMattermost, WebhookKindGoogleChat, WebhookKindZulip, WebhookKindSigNoz:
+		return kind
+	}
+
+	return ""
+}
+
 // Creates a new receiver from a string. The input is initialized with the default values from the upstream alertmanager.
 // The only default value which is missed is `send_resolved` (as it is a bool) which if not set in the input will always be set to `false`.
 func NewReceiver(input string) (Receiver, error) {
@@ -50,6 +75,55 @@ func NewReceiver(input string) (Receiver, error) {
 	return receiverWithDefaults, nil
 }
 
+// ValidateReceiver validates the configs of the chat integrations notified by SigNoz, on top of the validation of the
+// upstream alertmanager. It validates the receivers which are created, updated or tested, the stored receivers are
+// loaded as they are so that a stricter validation doesn't break the existing channels.
+func ValidateReceiver(receiver Receiver) error {
+	for _, c := range receiver.DiscordConfigs {
+		if c.WebhookURL != nil && !strings.HasPrefix(c.WebhookURL.Path, "/api/webhooks/") {
+			return errors.Newf(errors.TypeInvalidInput, ErrCodeAlertmanagerChannelInvalid, "discord webhook_url of channel '%s' must be a discord webhook url (https://discord.com/api/webhooks/...)", receiver.Name)
+		}
+
+		if len([]rune(c.Username)) > 80 {
+			return errors.Newf(errors.TypeInvalidInput, ErrCodeAlertmanagerChannelInvalid, "discord username of channel '%s' must be at most 80 characters", receiver.Name)
+		}
+	}
+
+	for _, c := range receiver.TelegramConfigs {
+		if c.MessageThreadID < 0 {
+			return errors.Newf(errors.TypeInvalidInput, ErrCodeAlertmanagerChannelInvalid, "telegram message_thread_id of channel '%s' cannot be negative", receiver.Name)
+		}
+	}
+
+	for _, c := range receiver.WebhookConfigs {
+		switch WebhookKindOf(c) {
+		case WebhookKindMattermost:
+			if !strings.Contains(c.URL.Path, "/hooks/") {
+				return errors.Newf(errors.TypeInvalidInput, ErrCodeAlertmanagerChannelInvalid, "mattermost url of channel '%s' must be an incoming webhook url (https://<mattermost>/hooks/<key>)", receiver.Name)
+			}
+		case WebhookKindGoogleChat:
+			query := c.URL.Query()
+			if c.URL.Scheme != "https" || c.URL.Host != "chat.googleapis.com" || !strings.HasPrefix(c.URL.Path, "/v1/spaces/") || query.Get("key") == "" || query.Get("token") == "" {
+				return errors.Newf(errors.TypeInvalidInput, ErrCodeAlertmanagerChannelInvalid, "google chat url of channel '%s' must be a space webhook url (https://chat.googleapis.com/v1/spaces/<space>/messages?key=<key>&token=<token>)", receiver.Name)
+			}
+		case WebhookKindZulip:
+			if !strings.HasSuffix(c.URL.Path, "/api/v1/messages") || c.URL.Query().Get("stream") == "" {
+				return errors.Newf(errors.TypeInvalidInput, ErrCodeAlertmanagerChannelInvalid, "zulip url of channel '%s' must be the messages api url with a stream (https://<zulip>/api/v1/messages?stream=<stream>&topic=<topic>)", receiver.Name)
+			}
+
+			if c.HTTPConfig == nil || c.HTTPConfig.BasicAuth == nil || c.HTTPConfig.BasicAuth.Username == "" || (c.HTTPConfig.BasicAuth.Password == "" && c.HTTPConfig.BasicAuth.PasswordFile == "") {
+				return errors.Newf(errors.TypeInvalidInput, ErrCodeAlertmanagerChannelInvalid, "zulip channel '%s' must set the email and api key of its bot as the basic_auth of its http_config", receiver.Name)
+			}
+		case WebhookKindSigNoz:
+			if _, err := NewSigNozWebhook(c); err != nil {
+				return errors.WithAdditionalf(err, "channel '%s'", receiver.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
 func TestReceiver(ctx context.Context, receiver Receiver, receiverIntegrationsFunc ReceiverIntegrationsFunc, config *Config, tmpl *template.Template, logger *slog.Logger, lSet model.LabelSet, alert ...*Alert) error {
 	ctx = notify.WithGroupKey(ctx, fmt.Sprintf("%s-%s-%d", receiver.Name, lSet.Fingerprint(), time.Now().Unix()))
 	ctx = notify.WithGroupLabels(ctx, lSet)
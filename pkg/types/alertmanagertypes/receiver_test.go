@@ -3,6 +3,9 @@ package alertmanagertypes
 import (
 	"encoding/json"
 	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -39,3 +42,127 @@ func TestNewReceiver(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateReceiver(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+		pass  bool
+	}{
+		{
+			name:  "DiscordWebhook",
+			input: `{"name":"discord","discord_configs":[{"webhook_url":"https://discord.com/api/webhooks/1/token"}]}`,
+			pass:  true,
+		},
+		{
+			name:  "DiscordNotAWebhook",
+			input: `{"name":"discord","discord_configs":[{"webhook_url":"https://discord.com/channels/1"}]}`,
+			pass:  false,
+		},
+		{
+			name:  "TelegramNegativeThread",
+			input: `{"name":"telegram","telegram_configs":[{"chat":12345,"token":"1234567890","message_thread_id":-1}]}`,
+			pass:  false,
+		},
+		{
+			name:  "Mattermost",
+			input: `{"name":"mattermost","webhook_configs":[{"url":"https://mattermost.signoz.io/hooks/xyz#mattermost"}]}`,
+			pass:  true,
+		},
+		{
+			name:  "MattermostNotAHook",
+			input: `{"name":"mattermost","webhook_configs":[{"url":"https://mattermost.signoz.io/api/v4/posts#mattermost"}]}`,
+			pass:  false,
+		},
+		{
+			name:  "GoogleChat",
+			input: `{"name":"googlechat","webhook_configs":[{"url":"https://chat.googleapis.com/v1/spaces/AAA/messages?key=k&token=t#googlechat"}]}`,
+			pass:  true,
+		},
+		{
+			name:  "GoogleChatWrongHost",
+			input: `{"name":"googlechat","webhook_configs":[{"url":"https://chat.signoz.io/v1/spaces/AAA/messages?key=k&token=t#googlechat"}]}`,
+			pass:  false,
+		},
+		{
+			name:  "Zulip",
+			input: `{"name":"zulip","webhook_configs":[{"url":"https://signoz.zulipchat.com/api/v1/messages?stream=alerts#zulip","http_config":{"basic_auth":{"username":"bot@signoz.zulipchat.com","password":"key"}}}]}`,
+			pass:  true,
+		},
+		{
+			name:  "ZulipWithoutBasicAuth",
+			input: `{"name":"zulip","webhook_configs":[{"url":"https://signoz.zulipchat.com/api/v1/messages?stream=alerts#zulip"}]}`,
+			pass:  false,
+		},
+		{
+			name:  "ZulipWithoutStream",
+			input: `{"name":"zulip","webhook_configs":[{"url":"https://signoz.zulipchat.com/api/v1/messages#zulip","http_config":{"basic_auth":{"username":"bot@signoz.zulipchat.com","password":"key"}}}]}`,
+			pass:  false,
+		},
+		{
+			name:  "SigNozWebhook",
+			input: `{"name":"signoz","webhook_configs":[{"url":"https://automation.signoz.io/alerts#signoz?max_attempts=5","http_config":{"authorization":{"type":"HMAC-SHA256","credentials":"s3cr3t"}}}]}`,
+			pass:  true,
+		},
+		{
+			name:  "SigNozWebhookWithoutSecret",
+			input: `{"name":"signoz","webhook_configs":[{"url":"https://automation.signoz.io/alerts#signoz"}]}`,
+			pass:  false,
+		},
+		{
+			name:  "SigNozWebhookWithBearerAuthorization",
+			input: `{"name":"signoz","webhook_configs":[{"url":"https://automation.signoz.io/alerts#signoz","http_config":{"authorization":{"credentials":"s3cr3t"}}}]}`,
+			pass:  false,
+		},
+		{
+			name:  "SigNozWebhookWithSecretInURL",
+			input: `{"name":"signoz","webhook_configs":[{"url":"https://automation.signoz.io/alerts#signoz?secret=s3cr3t","http_config":{"authorization":{"type":"HMAC-SHA256","credentials":"s3cr3t"}}}]}`,
+			pass:  false,
+		},
+		{
+			name:  "SigNozWebhookTooManyAttempts",
+			input: `{"name":"signoz","webhook_configs":[{"url":"https://automation.signoz.io/alerts#signoz?max_attempts=11","http_config":{"authorization":{"type":"HMAC-SHA256","credentials":"s3cr3t"}}}]}`,
+			pass:  false,
+		},
+		{
+			name:  "WebhookWithUnknownFragment",
+			input: `{"name":"webhook","webhook_configs":[{"url":"https://signoz.io/hooks#anything"}]}`,
+			pass:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			receiver, err := NewReceiver(tc.input)
+			require.NoError(t, err)
+
+			err = ValidateReceiver(receiver)
+			if tc.pass {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNewConfigFromChannelsLoadsUnvalidatedChannels(t *testing.T) {
+	// a discord channel stored before its webhook url was validated is still loaded
+	data := `{"name":"discord","discord_configs":[{"webhook_url":"https://discord.com/channels/1"}]}`
+
+	receiver, err := NewReceiver(data)
+	require.NoError(t, err)
+	assert.Error(t, ValidateReceiver(receiver))
+
+	c, err := NewConfigFromChannels(
+		GlobalConfig{ResolveTimeout: model.Duration(5 * time.Minute)},
+		RouteConfig{GroupByStr: []string{"alertname"}, GroupInterval: 5 * time.Minute, GroupWait: 30 * time.Second, RepeatInterval: 4 * time.Hour},
+		Channels{{Name: "discord", Type: "discord", Data: data}},
+		"1",
+	)
+	require.NoError(t, err)
+
+	_, err = c.GetReceiver("discord")
+	assert.NoError(t, err)
+}
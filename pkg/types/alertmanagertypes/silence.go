@@ -0,0 +1,230 @@
+package alertmanagertypes
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/go-openapi/strfmt"
+	v2 "github.com/prometheus/alertmanager/api/v2"
+	"github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/prometheus/common/model"
+)
+
+var (
+	ErrCodeAlertmanagerSilenceNotFound = errors.MustNewCode("alertmanager_silence_not_found")
+	ErrCodeAlertmanagerSilenceInvalid  = errors.MustNewCode("alertmanager_silence_invalid")
+)
+
+const (
+	SilenceExpiredAlertName = "SilenceExpired"
+	SilenceIDLabel          = "silence_id"
+)
+
+type (
+	// An alias for the Silence type from the alertmanager package.
+	Silence = silencepb.Silence
+
+	// An alias for the PostableSilence type from the alertmanager package.
+	PostableSilence = models.PostableSilence
+
+	// A slice of GettableSilence.
+	GettableSilences = []*GettableSilence
+)
+
+// GettableSilence is the GettableSilence type from the alertmanager package along with the user who last updated the
+// silence, if it was updated.
+type GettableSilence struct {
+	models.GettableSilence
+	UpdatedBy string `json:"updatedBy,omitempty"`
+}
+
+// MarshalJSON adds the user who last updated the silence to the silence of the alertmanager package, which is
+// marshalled on its own.
+func (gettable GettableSilence) MarshalJSON() ([]byte, error) {
+	silence, err := gettable.GettableSilence.MarshalJSON()
+	if err != nil || gettable.UpdatedBy == "" {
+		return silence, err
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(silence, &fields); err != nil {
+		return nil, err
+	}
+
+	fields["updatedBy"], err = json.Marshal(gettable.UpdatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(fields)
+}
+
+// NewSilenceFromPostableSilence validates a silence and records who created it. The user who updates an existing
+// silence is recorded apart from its creator, it is empty when the silence is created. A comment explaining why the
+// alerts are silenced is required.
+func NewSilenceFromPostableSilence(postable *PostableSilence, createdBy string, updatedBy string, now time.Time) (*Silence, error) {
+	postable.CreatedBy = &createdBy
+	if err := postable.Validate(strfmt.Default); err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInvalidInput, ErrCodeAlertmanagerSilenceInvalid, "invalid silence")
+	}
+
+	if strings.TrimSpace(*postable.Comment) == "" {
+		return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeAlertmanagerSilenceInvalid, "comment is required")
+	}
+
+	if len(postable.Matchers) == 0 {
+		return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeAlertmanagerSilenceInvalid, "at least one matcher is required")
+	}
+
+	silence, err := v2.PostableSilenceToProto(postable)
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInvalidInput, ErrCodeAlertmanagerSilenceInvalid, "invalid silence")
+	}
+
+	if !silence.StartsAt.Before(silence.EndsAt) {
+		return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeAlertmanagerSilenceInvalid, "start time must be before end time")
+	}
+
+	if silence.EndsAt.Before(now) {
+		return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeAlertmanagerSilenceInvalid, "end time can't be in the past")
+	}
+
+	if _, err := NewMatchersFromSilence(silence); err != nil {
+		return nil, err
+	}
+
+	// the comments of the silences are not used anymore, the last update is recorded as one
+	if updatedBy != "" {
+		silence.Comments = []*silencepb.Comment{{Author: updatedBy, Comment: "updated", Timestamp: now}}
+	}
+
+	return silence, nil
+}
+
+// NewPreviewSilenceFromPostableSilence returns a silence with the matchers of the postable silence. Only the matchers
+// are required to preview the alerts which a silence would mute.
+func NewPreviewSilenceFromPostableSilence(postable *PostableSilence, now time.Time) (*Silence, error) {
+	if len(postable.Matchers) == 0 {
+		return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeAlertmanagerSilenceInvalid, "at least one matcher is required")
+	}
+
+	if err := postable.Matchers.Validate(strfmt.Default); err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInvalidInput, ErrCodeAlertmanagerSilenceInvalid, "invalid matchers")
+	}
+
+	startsAt, endsAt, empty := strfmt.DateTime(now), strfmt.DateTime(now), ""
+	silence, err := v2.PostableSilenceToProto(&PostableSilence{
+		Silence: models.Silence{
+			Matchers:  postable.Matchers,
+			StartsAt:  &startsAt,
+			EndsAt:    &endsAt,
+			Comment:   &empty,
+			CreatedBy: &empty,
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInvalidInput, ErrCodeAlertmanagerSilenceInvalid, "invalid matchers")
+	}
+
+	if _, err := NewMatchersFromSilence(silence); err != nil {
+		return nil, err
+	}
+
+	return silence, nil
+}
+
+func NewGettableSilenceFromSilence(silence *Silence) (*GettableSilence, error) {
+	gettable, err := v2.GettableSilenceFromProto(silence)
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to convert silence %s", silence.Id)
+	}
+
+	updatedBy := ""
+	if len(silence.Comments) > 0 {
+		updatedBy = silence.Comments[len(silence.Comments)-1].Author
+	}
+
+	return &GettableSilence{GettableSilence: gettable, UpdatedBy: updatedBy}, nil
+}
+
+// NewGettableSilencesFromSilences converts the silences, the active ones come first, then the pending and the expired
+// ones.
+func NewGettableSilencesFromSilences(silences []*Silence) (GettableSilences, error) {
+	sorted := make(models.GettableSilences, 0, len(silences))
+	byGettable := make(map[*models.GettableSilence]*GettableSilence, len(silences))
+	for _, silence := range silences {
+		gettable, err := NewGettableSilenceFromSilence(silence)
+		if err != nil {
+			return nil, err
+		}
+		sorted = append(sorted, &gettable.GettableSilence)
+		byGettable[&gettable.GettableSilence] = gettable
+	}
+
+	v2.SortSilences(sorted)
+
+	gettables := make(GettableSilences, 0, len(sorted))
+	for _, gettable := range sorted {
+		gettables = append(gettables, byGettable[gettable])
+	}
+
+	return gettables, nil
+}
+
+// NewMatchersFromSilence returns the label matchers of a silence.
+func NewMatchersFromSilence(silence *Silence) (labels.Matchers, error) {
+	matchers := make(labels.Matchers, 0, len(silence.Matchers))
+	for _, m := range silence.Matchers {
+		var matchType labels.MatchType
+		switch m.Type {
+		case silencepb.Matcher_EQUAL:
+			matchType = labels.MatchEqual
+		case silencepb.Matcher_NOT_EQUAL:
+			matchType = labels.MatchNotEqual
+		case silencepb.Matcher_REGEXP:
+			matchType = labels.MatchRegexp
+		case silencepb.Matcher_NOT_REGEXP:
+			matchType = labels.MatchNotRegexp
+		default:
+			return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeAlertmanagerSilenceInvalid, "unknown type of matcher %s", m.Name)
+		}
+
+		matcher, err := labels.NewMatcher(matchType, m.Name, m.Pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, errors.TypeInvalidInput, ErrCodeAlertmanagerSilenceInvalid, "invalid matcher %s", m.Name)
+		}
+		matchers = append(matchers, matcher)
+	}
+
+	return matchers, nil
+}
+
+// NewSilenceExpiredAlert returns the alert notifying that a silence expired while the alerts it muted are still
+// firing.
+func NewSilenceExpiredAlert(silence *Silence, firing []*Alert, now time.Time, resolveTimeout time.Duration) *Alert {
+	names := make([]string, 0, len(firing))
+	for _, alert := range firing {
+		names = append(names, alert.Name())
+	}
+
+	return &Alert{
+		Alert: model.Alert{
+			Labels: model.LabelSet{
+				model.AlertNameLabel: SilenceExpiredAlertName,
+				SilenceIDLabel:       model.LabelValue(silence.Id),
+			},
+			Annotations: model.LabelSet{
+				"summary":     model.LabelValue(fmt.Sprintf("The silence created by %s has expired", silence.CreatedBy)),
+				"description": model.LabelValue(fmt.Sprintf("%d alerts muted by the silence are still firing: %s. The silence was created because: %s", len(firing), strings.Join(names, ", "), silence.Comment)),
+			},
+			StartsAt: now,
+			EndsAt:   now.Add(resolveTimeout),
+		},
+		UpdatedAt: now,
+	}
+}
@@ -0,0 +1,106 @@
+package alertmanagertypes
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPostableSilence(comment string, startsAt time.Time, endsAt time.Time, matchers ...*models.Matcher) *PostableSilence {
+	start, end := strfmt.DateTime(startsAt), strfmt.DateTime(endsAt)
+	return &PostableSilence{
+		Silence: models.Silence{
+			Matchers: matchers,
+			StartsAt: &start,
+			EndsAt:   &end,
+			Comment:  &comment,
+		},
+	}
+}
+
+func newTestMatcher(name string, value string, isEqual bool, isRegex bool) *models.Matcher {
+	return &models.Matcher{Name: &name, Value: &value, IsEqual: &isEqual, IsRegex: &isRegex}
+}
+
+func TestNewSilenceFromPostableSilence(t *testing.T) {
+	now := time.Now()
+	service := newTestMatcher("service", "checkout", true, false)
+
+	testCases := []struct {
+		name        string
+		postable    *PostableSilence
+		expectedErr bool
+	}{
+		{name: "Valid", postable: newTestPostableSilence("deploying checkout", now, now.Add(time.Hour), service)},
+		{name: "MissingComment", postable: newTestPostableSilence(" ", now, now.Add(time.Hour), service), expectedErr: true},
+		{name: "NoMatchers", postable: newTestPostableSilence("deploying checkout", now, now.Add(time.Hour)), expectedErr: true},
+		{name: "EndBeforeStart", postable: newTestPostableSilence("deploying checkout", now, now.Add(-time.Minute), service), expectedErr: true},
+		{name: "EndInThePast", postable: newTestPostableSilence("deploying checkout", now.Add(-2*time.Hour), now.Add(-time.Hour), service), expectedErr: true},
+		{name: "InvalidRegex", postable: newTestPostableSilence("deploying checkout", now, now.Add(time.Hour), newTestMatcher("service", "(", true, true)), expectedErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			silence, err := NewSilenceFromPostableSilence(tc.postable, "oncall@signoz.io", "", now)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "oncall@signoz.io", silence.CreatedBy)
+			assert.Equal(t, "deploying checkout", silence.Comment)
+		})
+	}
+}
+
+func TestNewGettableSilenceFromSilence_UpdatedBy(t *testing.T) {
+	now := time.Now()
+	postable := newTestPostableSilence("deploying checkout", now, now.Add(time.Hour), newTestMatcher("service", "checkout", true, false))
+	postable.ID = "d9e3f1e4-6b5a-4f1c-9d2e-2f1b6c1f0a11"
+
+	silence, err := NewSilenceFromPostableSilence(postable, "oncall@signoz.io", "sre@signoz.io", now)
+	require.NoError(t, err)
+	assert.Equal(t, "oncall@signoz.io", silence.CreatedBy)
+
+	gettable, err := NewGettableSilenceFromSilence(silence)
+	require.NoError(t, err)
+	assert.Equal(t, "oncall@signoz.io", *gettable.CreatedBy)
+	assert.Equal(t, "sre@signoz.io", gettable.UpdatedBy)
+
+	body, err := json.Marshal(gettable)
+	require.NoError(t, err)
+
+	fields := map[string]any{}
+	require.NoError(t, json.Unmarshal(body, &fields))
+	assert.Equal(t, "oncall@signoz.io", fields["createdBy"])
+	assert.Equal(t, "sre@signoz.io", fields["updatedBy"])
+	assert.Equal(t, "deploying checkout", fields["comment"])
+}
+
+func TestNewMatchersFromSilence(t *testing.T) {
+	silence, err := NewPreviewSilenceFromPostableSilence(&PostableSilence{
+		Silence: models.Silence{
+			Matchers: models.Matchers{
+				newTestMatcher("service", "checkout|frontend", true, true),
+				newTestMatcher("env", "staging", false, false),
+			},
+		},
+	}, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, silencepb.Matcher_REGEXP, silence.Matchers[0].Type)
+	assert.Equal(t, silencepb.Matcher_NOT_EQUAL, silence.Matchers[1].Type)
+
+	matchers, err := NewMatchersFromSilence(silence)
+	require.NoError(t, err)
+	assert.True(t, matchers.Matches(model.LabelSet{"service": "checkout", "env": "production"}))
+	assert.False(t, matchers.Matches(model.LabelSet{"service": "checkout", "env": "staging"}))
+	assert.False(t, matchers.Matches(model.LabelSet{"service": "cart", "env": "production"}))
+}
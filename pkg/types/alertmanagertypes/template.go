@@ -27,8 +27,63 @@ func customTemplateOption() alertmanagertemplate.Option {
 	}
 }
 
+// chatTemplates are the default templates of the chat integrations notified by SigNoz. Each alert lists its threshold,
+// summary and description along with the links to its related logs and traces, and every message links to the rule.
+// The discord and telegram ones override the defaults of the upstream alertmanager.
+const chatTemplates = `
+{{ define "__signozThreshold" }}{{ with index .Labels "threshold.name" }}{{ . }}{{ end }}{{ with .Labels.severity }}{{ if ne . (index $.Labels "threshold.name") }} ({{ . }}){{ end }}{{ end }}{{ end }}
+
+{{ define "__signozMarkdownAlertList" }}{{ range . }}
+**{{ .Labels.alertname }}**{{ if index .Labels "threshold.name" }} · threshold {{ template "__signozThreshold" . }}{{ end }}
+{{ with .Annotations.summary }}{{ . }}
+{{ end }}{{ with .Annotations.description }}{{ . }}
+{{ end }}{{ with .Annotations.related_logs }}[Related logs]({{ . }}) {{ end }}{{ with .Annotations.related_traces }}[Related traces]({{ . }}){{ end }}
+{{ end }}{{ end }}
+
+{{ define "__signozMarkdownMessage" }}{{ if gt (len .Alerts.Firing) 0 }}**Firing**
+{{ template "__signozMarkdownAlertList" .Alerts.Firing }}{{ end }}{{ if gt (len .Alerts.Resolved) 0 }}**Resolved**
+{{ template "__signozMarkdownAlertList" .Alerts.Resolved }}{{ end }}
+[View rule]({{ template "__alertmanagerURL" . }}){{ end }}
+
+{{ define "discord.default.message" }}{{ template "__signozMarkdownMessage" . }}{{ end }}
+{{ define "discord.default.titleLink" }}{{ template "__alertmanagerURL" . }}{{ end }}
+
+{{ define "mattermost.default.title" }}{{ template "__subject" . }}{{ end }}
+{{ define "mattermost.default.titleLink" }}{{ template "__alertmanagerURL" . }}{{ end }}
+{{ define "mattermost.default.text" }}{{ template "__signozMarkdownMessage" . }}{{ end }}
+
+{{ define "zulip.default.topic" }}{{ .CommonLabels.alertname }}{{ end }}
+{{ define "zulip.default.content" }}**[{{ template "__subject" . }}]({{ template "__alertmanagerURL" . }})**
+{{ template "__signozMarkdownMessage" . }}{{ end }}
+
+{{ define "__signozGoogleChatAlertList" }}{{ range . }}
+*{{ .Labels.alertname }}*{{ if index .Labels "threshold.name" }} · threshold {{ template "__signozThreshold" . }}{{ end }}
+{{ with .Annotations.summary }}{{ . }}
+{{ end }}{{ with .Annotations.description }}{{ . }}
+{{ end }}{{ with .Annotations.related_logs }}<{{ . }}|Related logs> {{ end }}{{ with .Annotations.related_traces }}<{{ . }}|Related traces>{{ end }}
+{{ end }}{{ end }}
+
+{{ define "googlechat.default.text" }}*<{{ template "__alertmanagerURL" . }}|{{ template "__subject" . }}>*
+{{ if gt (len .Alerts.Firing) 0 }}*Firing*
+{{ template "__signozGoogleChatAlertList" .Alerts.Firing }}{{ end }}{{ if gt (len .Alerts.Resolved) 0 }}*Resolved*
+{{ template "__signozGoogleChatAlertList" .Alerts.Resolved }}{{ end }}{{ end }}
+
+{{ define "__signozTelegramAlertList" }}{{ range . }}
+<b>{{ .Labels.alertname }}</b>{{ if index .Labels "threshold.name" }} · threshold {{ template "__signozThreshold" . }}{{ end }}
+{{ with .Annotations.summary }}{{ . }}
+{{ end }}{{ with .Annotations.description }}{{ . }}
+{{ end }}{{ with .Annotations.related_logs }}<a href="{{ . }}">Related logs</a> {{ end }}{{ with .Annotations.related_traces }}<a href="{{ . }}">Related traces</a>{{ end }}
+{{ end }}{{ end }}
+
+{{ define "telegram.default.message" }}<a href="{{ template "__alertmanagerURL" . }}">{{ template "__subject" . }}</a>
+{{ if gt (len .Alerts.Firing) 0 }}<b>Firing</b>
+{{ template "__signozTelegramAlertList" .Alerts.Firing }}{{ end }}{{ if gt (len .Alerts.Resolved) 0 }}<b>Resolved</b>
+{{ template "__signozTelegramAlertList" .Alerts.Resolved }}{{ end }}{{ end }}
+`
+
 // FromGlobs overrides the default alertmanager template to add a ruleIdPath template.
 // This is used to generate a link to the rule in the alertmanager.
+// The subject of the digests, grouped by the digest label, summarizes the alerts of the period.
 //
 // It checks for a ruleId label and generates a path to the rule.
 // If testAlert=true label is present, it adds isTestAlert=true query parameter to the URL.
@@ -42,9 +97,14 @@ func FromGlobs(paths []string) (*alertmanagertemplate.Template, error) {
 	{{ define "__ruleIdPath" }}{{- $isTestAlert := "" -}}{{- range .CommonLabels.SortedPairs -}}{{- if eq .Name "testalert" -}}{{- if eq .Value "true" -}}{{- $isTestAlert = "true" -}}{{- end -}}{{- end -}}{{- end -}}{{- range .CommonLabels.SortedPairs -}}{{- if eq .Name "ruleId" -}}{{- if ne .Value "" -}}/edit?ruleId={{ .Value | urlescape }}{{- if $isTestAlert -}}&isTestAlert=true{{- end -}}{{- end -}}{{- end -}}{{- end -}}{{- end }}
 	{{ define "__alertmanagerURL" }}{{ .ExternalURL }}/alerts{{ template "__ruleIdPath" . }}{{ end }}
 	{{ define "msteamsv2.default.titleLink" }}{{ template "__alertmanagerURL" . }}{{ end }}
+	{{ define "__subject" }}{{ with .GroupLabels.digest }}[DIGEST] {{ . | title }} digest of {{ $.Receiver }}: {{ $.Alerts.Firing | len }} firing, {{ $.Alerts.Resolved | len }} resolved{{ else }}[{{ .Status | toUpper }}{{ if eq .Status "firing" }}:{{ .Alerts.Firing | len }}{{ end }}] {{ .GroupLabels.SortedPairs.Values | join " " }} {{ if gt (len .CommonLabels) (len .GroupLabels) }}({{ with .CommonLabels.Remove .GroupLabels.Names }}{{ .Values | join " " }}{{ end }}){{ end }}{{ end }}{{ end }}
 	`))); err != nil {
 		return nil, errors.WrapInternalf(err, errors.CodeInternal, "error parsing alertmanager templates")
 	}
 
+	if err := t.Parse(bytes.NewReader([]byte(chatTemplates))); err != nil {
+		return nil, errors.WrapInternalf(err, errors.CodeInternal, "error parsing alertmanager templates")
+	}
+
 	return t, nil
 }
@@ -201,3 +201,128 @@ func TestFromGlobs(t *testing.T) {
 		})
 	}
 }
+
+func TestFromGlobsChatTemplates(t *testing.T) {
+	template, err := FromGlobs([]string{})
+	require.NoError(t, err)
+	template.ExternalURL = &url.URL{Scheme: "http", Host: "localhost:8080", Path: ""}
+
+	data := template.Data("__receiver", model.LabelSet{"alertname": "HighLatency"}, &types.Alert{
+		Alert: model.Alert{
+			Labels: model.LabelSet{
+				"alertname":      "HighLatency",
+				"ruleId":         "01961575-461c-7668-875f-05d374062bfc",
+				"threshold.name": "critical",
+				"severity":       "page",
+			},
+			Annotations: model.LabelSet{
+				"summary":        "latency is high",
+				"related_logs":   "http://localhost:8080/logs/logs-explorer?compositeQuery=logs",
+				"related_traces": "http://localhost:8080/traces-explorer?compositeQuery=traces",
+			},
+			StartsAt: time.Now(),
+			EndsAt:   time.Now().Add(time.Hour),
+		},
+		UpdatedAt: time.Now(),
+	})
+
+	testCases := []struct {
+		name     string
+		template string
+		html     bool
+		expected []string
+	}{
+		{
+			name:     "Discord",
+			template: `{{ template "discord.default.message" . }}`,
+			expected: []string{"**HighLatency** · threshold critical (page)", "latency is high", "[Related logs](http://localhost:8080/logs/logs-explorer?compositeQuery=logs)", "[Related traces](http://localhost:8080/traces-explorer?compositeQuery=traces)", "[View rule](http://localhost:8080/alerts/edit?ruleId=01961575-461c-7668-875f-05d374062bfc)"},
+		},
+		{
+			name:     "Mattermost",
+			template: `{{ template "mattermost.default.text" . }}`,
+			expected: []string{"**Firing**", "[Related logs](http://localhost:8080/logs/logs-explorer?compositeQuery=logs)"},
+		},
+		{
+			name:     "Zulip",
+			template: `{{ template "zulip.default.content" . }}`,
+			expected: []string{"**[[FIRING:1] HighLatency", "](http://localhost:8080/alerts/edit?ruleId=01961575-461c-7668-875f-05d374062bfc)**"},
+		},
+		{
+			name:     "GoogleChat",
+			template: `{{ template "googlechat.default.text" . }}`,
+			expected: []string{"*<http://localhost:8080/alerts/edit?ruleId=01961575-461c-7668-875f-05d374062bfc|[FIRING:1] HighLatency", "<http://localhost:8080/logs/logs-explorer?compositeQuery=logs|Related logs>"},
+		},
+		{
+			name:     "Telegram",
+			template: `{{ template "telegram.default.message" . }}`,
+			html:     true,
+			expected: []string{`<a href="http://localhost:8080/alerts/edit?ruleId=01961575-461c-7668-875f-05d374062bfc">`, "<b>HighLatency</b> · threshold critical (page)", `<a href="http://localhost:8080/traces-explorer?compositeQuery=traces">Related traces</a>`},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			execute := template.ExecuteTextString
+			if tc.html {
+				execute = template.ExecuteHTMLString
+			}
+
+			message, err := execute(tc.template, data)
+			require.NoError(t, err)
+			for _, expected := range tc.expected {
+				assert.Contains(t, message, expected)
+			}
+		})
+	}
+}
+
+func TestFromGlobsSubject(t *testing.T) {
+	template, err := FromGlobs([]string{})
+	require.NoError(t, err)
+	template.ExternalURL = &url.URL{Scheme: "http", Host: "localhost:8080", Path: ""}
+
+	firing := &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": "HighLatency", "severity": "info"},
+			StartsAt: time.Now().Add(-time.Hour),
+			EndsAt:   time.Now().Add(time.Hour),
+		},
+	}
+	resolved := &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": "HighErrorRate", "severity": "info"},
+			StartsAt: time.Now().Add(-time.Hour),
+			EndsAt:   time.Now().Add(-time.Minute),
+		},
+	}
+
+	testCases := []struct {
+		name        string
+		groupLabels model.LabelSet
+		alerts      []*types.Alert
+		expected    string
+	}{
+		{
+			name:        "Group",
+			groupLabels: model.LabelSet{"alertname": "HighLatency"},
+			alerts:      []*types.Alert{firing},
+			expected:    "[FIRING:1] HighLatency (info)",
+		},
+		{
+			name:        "Digest",
+			groupLabels: model.LabelSet{DigestLabel: model.LabelValue(DigestPeriodDaily.StringValue())},
+			alerts:      []*types.Alert{firing, resolved},
+			expected:    "[DIGEST] Daily digest of low-severity: 1 firing, 1 resolved",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data := template.Data("low-severity", tc.groupLabels, tc.alerts...)
+
+			subject, err := template.ExecuteTextString(`{{ template "__subject" . }}`, data)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, subject)
+		})
+	}
+}
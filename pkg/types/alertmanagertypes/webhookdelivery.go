@@ -0,0 +1,298 @@
+package alertmanagertypes
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/prometheus/alertmanager/config"
+	"github.com/uptrace/bun"
+)
+
+var (
+	ErrCodeAlertmanagerWebhookDeliveryNotFound = errors.MustNewCode("alertmanager_webhook_delivery_not_found")
+	ErrCodeAlertmanagerWebhookDeliveryInvalid  = errors.MustNewCode("alertmanager_webhook_delivery_invalid")
+)
+
+const (
+	// DefaultWebhookMaxAttempts is the number of attempts of a delivery of a signoz webhook which does not set it.
+	DefaultWebhookMaxAttempts = 3
+
+	// MaxWebhookMaxAttempts bounds the number of attempts of a delivery.
+	MaxWebhookMaxAttempts = 10
+
+	// WebhookSignatureAuthorizationType is the type of the authorization of the http config of a signoz webhook, whose
+	// credentials are the secret signing its payloads.
+	WebhookSignatureAuthorizationType = "HMAC-SHA256"
+
+	// RedactedSecret replaces the secrets of the channels returned by the api. A channel updated with it keeps its
+	// secret.
+	RedactedSecret = "<secret>"
+
+	// MaxWebhookResponseBytes is the number of bytes of the response to an attempt which are recorded.
+	MaxWebhookResponseBytes = 1024
+
+	// WebhookDeliveryRetention is how long the deliveries are kept.
+	WebhookDeliveryRetention = 7 * 24 * time.Hour
+
+	// DefaultListWebhookDeliveriesLimit and MaxListWebhookDeliveriesLimit bound the number of listed deliveries.
+	DefaultListWebhookDeliveriesLimit = 100
+	MaxListWebhookDeliveriesLimit     = 1000
+)
+
+type WebhookDeliveryStatus struct{ valuer.String }
+
+var (
+	WebhookDeliveryStatusPending   = WebhookDeliveryStatus{valuer.NewString("pending")}
+	WebhookDeliveryStatusSucceeded = WebhookDeliveryStatus{valuer.NewString("succeeded")}
+	WebhookDeliveryStatusFailed    = WebhookDeliveryStatus{valuer.NewString("failed")}
+)
+
+func NewWebhookDeliveryStatus(status string) (WebhookDeliveryStatus, error) {
+	switch status {
+	case WebhookDeliveryStatusPending.StringValue():
+		return WebhookDeliveryStatusPending, nil
+	case WebhookDeliveryStatusSucceeded.StringValue():
+		return WebhookDeliveryStatusSucceeded, nil
+	case WebhookDeliveryStatusFailed.StringValue():
+		return WebhookDeliveryStatusFailed, nil
+	}
+
+	return WebhookDeliveryStatus{}, errors.Newf(errors.TypeInvalidInput, ErrCodeAlertmanagerWebhookDeliveryInvalid, "invalid webhook delivery status %q, must be one of pending, succeeded or failed", status)
+}
+
+// SigNozWebhook is a webhook config marked as signoz, whose payloads are signed with its secret and whose deliveries
+// are retried by SigNoz and recorded. The secret is the credentials of the authorization of its http config, which
+// is never sent to the receiver, and its max attempts are given in the fragment of its url:
+// https://<receiver>#signoz?max_attempts=<attempts>.
+type SigNozWebhook struct {
+	Secret      string
+	MaxAttempts int
+}
+
+func NewSigNozWebhook(c *config.WebhookConfig) (*SigNozWebhook, error) {
+	if WebhookKindOf(c) != WebhookKindSigNoz {
+		return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeAlertmanagerChannelInvalid, "webhook is not a signoz webhook")
+	}
+
+	_, rawParams, _ := strings.Cut(c.URL.Fragment, "?")
+	params, err := url.ParseQuery(rawParams)
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInvalidInput, ErrCodeAlertmanagerChannelInvalid, "invalid parameters of signoz webhook")
+	}
+
+	if params.Has("secret") {
+		return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeAlertmanagerChannelInvalid, "the secret of a signoz webhook must be set as the credentials of the %s authorization of its http_config, not in its url", WebhookSignatureAuthorizationType)
+	}
+
+	if c.HTTPConfig == nil || c.HTTPConfig.Authorization == nil || c.HTTPConfig.Authorization.Type != WebhookSignatureAuthorizationType || c.HTTPConfig.Authorization.Credentials == "" {
+		return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeAlertmanagerChannelInvalid, "signoz webhook must set the secret signing its payloads as the credentials of the %s authorization of its http_config", WebhookSignatureAuthorizationType)
+	}
+
+	webhook := &SigNozWebhook{
+		Secret:      string(c.HTTPConfig.Authorization.Credentials),
+		MaxAttempts: DefaultWebhookMaxAttempts,
+	}
+
+	if maxAttempts := params.Get("max_attempts"); maxAttempts != "" {
+		webhook.MaxAttempts, err = strconv.Atoi(maxAttempts)
+		if err != nil || webhook.MaxAttempts < 1 || webhook.MaxAttempts > MaxWebhookMaxAttempts {
+			return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeAlertmanagerChannelInvalid, "max_attempts of signoz webhook must be between 1 and %d", MaxWebhookMaxAttempts)
+		}
+	}
+
+	return webhook, nil
+}
+
+// WebhookDeliveryAttempt is an attempt to deliver a payload, which failed with an error if the receiver could not be
+// reached.
+type WebhookDeliveryAttempt struct {
+	Attempt     int       `json:"attempt"`
+	AttemptedAt time.Time `json:"attemptedAt"`
+	StatusCode  int       `json:"statusCode,omitempty"`
+	LatencyMs   int64     `json:"latencyMs"`
+	Response    string    `json:"response,omitempty"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// StorableWebhookDelivery is the delivery of a payload of a signoz webhook, along with all the attempts made to
+// deliver it. The webhook is referenced by its receiver and index, its url is recorded without its query and fragment
+// which may hold secrets.
+type StorableWebhookDelivery struct {
+	bun.BaseModel `bun:"table:webhook_delivery"`
+
+	types.Identifiable
+	types.TimeAuditable
+	OrgID       string                `bun:"org_id"`
+	Receiver    string                `bun:"receiver"`
+	Integration int                   `bun:"integration"`
+	URL         string                `bun:"url"`
+	GroupKey    string                `bun:"group_key"`
+	Status      WebhookDeliveryStatus `bun:"status"`
+	Payload     string                `bun:"payload"`
+	Attempts    string                `bun:"attempts"`
+}
+
+type GettableWebhookDelivery struct {
+	ID          valuer.UUID               `json:"id"`
+	Receiver    string                    `json:"receiver"`
+	Integration int                       `json:"integration"`
+	URL         string                    `json:"url"`
+	GroupKey    string                    `json:"groupKey"`
+	Status      WebhookDeliveryStatus     `json:"status"`
+	Payload     json.RawMessage           `json:"payload"`
+	Attempts    []*WebhookDeliveryAttempt `json:"attempts"`
+	CreatedAt   time.Time                 `json:"createdAt"`
+	UpdatedAt   time.Time                 `json:"updatedAt"`
+}
+
+type GettableWebhookDeliveries = []*GettableWebhookDelivery
+
+type ListWebhookDeliveriesParams struct {
+	Receiver string
+	Status   WebhookDeliveryStatus
+	Limit    int
+}
+
+// NewListWebhookDeliveriesParams returns the params of a list of deliveries from the receiver, status and limit query
+// parameters.
+func NewListWebhookDeliveriesParams(query url.Values) (*ListWebhookDeliveriesParams, error) {
+	params := &ListWebhookDeliveriesParams{
+		Receiver: query.Get("receiver"),
+		Limit:    DefaultListWebhookDeliveriesLimit,
+	}
+
+	if status := query.Get("status"); status != "" {
+		var err error
+		params.Status, err = NewWebhookDeliveryStatus(status)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		var err error
+		params.Limit, err = strconv.Atoi(limit)
+		if err != nil || params.Limit < 1 || params.Limit > MaxListWebhookDeliveriesLimit {
+			return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeAlertmanagerWebhookDeliveryInvalid, "limit must be between 1 and %d", MaxListWebhookDeliveriesLimit)
+		}
+	}
+
+	return params, nil
+}
+
+// NewStorableWebhookDelivery returns a pending delivery of a payload to the url of a webhook.
+func NewStorableWebhookDelivery(orgID string, receiver string, integration int, u *url.URL, groupKey string, payload []byte, now time.Time) *StorableWebhookDelivery {
+	redacted := url.URL{Scheme: u.Scheme, Host: u.Host, Path: u.Path}
+
+	return &StorableWebhookDelivery{
+		Identifiable: types.Identifiable{
+			ID: valuer.GenerateUUID(),
+		},
+		TimeAuditable: types.TimeAuditable{
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		OrgID:       orgID,
+		Receiver:    receiver,
+		Integration: integration,
+		URL:         redacted.String(),
+		GroupKey:    groupKey,
+		Status:      WebhookDeliveryStatusPending,
+		Payload:     string(payload),
+		Attempts:    "[]",
+	}
+}
+
+func (delivery *StorableWebhookDelivery) WebhookDeliveryAttempts() ([]*WebhookDeliveryAttempt, error) {
+	attempts := []*WebhookDeliveryAttempt{}
+	if err := json.Unmarshal([]byte(delivery.Attempts), &attempts); err != nil {
+		return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to unmarshal the attempts of webhook delivery %s", delivery.ID.StringValue())
+	}
+
+	return attempts, nil
+}
+
+// RecordAttempts appends attempts to the delivery and sets its status.
+func (delivery *StorableWebhookDelivery) RecordAttempts(attempts []*WebhookDeliveryAttempt, status WebhookDeliveryStatus, now time.Time) error {
+	recorded, err := delivery.WebhookDeliveryAttempts()
+	if err != nil {
+		return err
+	}
+
+	marshalled, err := json.Marshal(append(recorded, attempts...))
+	if err != nil {
+		return errors.WrapInternalf(err, errors.CodeInternal, "failed to marshal the attempts of webhook delivery %s", delivery.ID.StringValue())
+	}
+
+	delivery.Attempts = string(marshalled)
+	delivery.Status = status
+	delivery.UpdatedAt = now
+	return nil
+}
+
+// NextAttempt returns the number of the next attempt to deliver the payload.
+func (delivery *StorableWebhookDelivery) NextAttempt() (int, error) {
+	attempts, err := delivery.WebhookDeliveryAttempts()
+	if err != nil {
+		return 0, err
+	}
+
+	return len(attempts) + 1, nil
+}
+
+func NewGettableWebhookDeliveryFromStorableWebhookDelivery(delivery *StorableWebhookDelivery) (*GettableWebhookDelivery, error) {
+	attempts, err := delivery.WebhookDeliveryAttempts()
+	if err != nil {
+		return nil, err
+	}
+
+	return &GettableWebhookDelivery{
+		ID:          delivery.ID,
+		Receiver:    delivery.Receiver,
+		Integration: delivery.Integration,
+		URL:         delivery.URL,
+		GroupKey:    delivery.GroupKey,
+		Status:      delivery.Status,
+		Payload:     json.RawMessage(delivery.Payload),
+		Attempts:    attempts,
+		CreatedAt:   delivery.CreatedAt,
+		UpdatedAt:   delivery.UpdatedAt,
+	}, nil
+}
+
+func NewGettableWebhookDeliveriesFromStorableWebhookDeliveries(deliveries []*StorableWebhookDelivery) (GettableWebhookDeliveries, error) {
+	gettables := make(GettableWebhookDeliveries, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		gettable, err := NewGettableWebhookDeliveryFromStorableWebhookDelivery(delivery)
+		if err != nil {
+			return nil, err
+		}
+		gettables = append(gettables, gettable)
+	}
+
+	return gettables, nil
+}
+
+type WebhookDeliveryStore interface {
+	// Create creates a delivery.
+	Create(ctx context.Context, delivery *StorableWebhookDelivery) error
+
+	// Get gets a delivery of the organization by its id.
+	Get(ctx context.Context, orgID string, id valuer.UUID) (*StorableWebhookDelivery, error)
+
+	// List lists the latest deliveries of the organization matching the params.
+	List(ctx context.Context, orgID string, params *ListWebhookDeliveriesParams) ([]*StorableWebhookDelivery, error)
+
+	// Update updates the status and the attempts of a delivery.
+	Update(ctx context.Context, delivery *StorableWebhookDelivery) error
+
+	// DeleteBefore deletes the deliveries of all the organizations created before a time.
+	DeleteBefore(ctx context.Context, before time.Time) error
+}
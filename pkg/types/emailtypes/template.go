@@ -12,13 +12,15 @@ import (
 var (
 	// Templates is a list of all the templates that are supported by the emailing service.
 	// This list should be updated whenever a new template is added.
-	Templates = []TemplateName{TemplateNameInvitationEmail, TemplateNameUpdateRole, TemplateNameResetPassword}
+	Templates = []TemplateName{TemplateNameInvitationEmail, TemplateNameUpdateRole, TemplateNameResetPassword, TemplateNameOnCallAlert, TemplateNameAlertDigest}
 )
 
 var (
 	TemplateNameInvitationEmail = TemplateName{valuer.NewString("invitation_email")}
 	TemplateNameUpdateRole      = TemplateName{valuer.NewString("update_role")}
 	TemplateNameResetPassword   = TemplateName{valuer.NewString("reset_password_email")}
+	TemplateNameOnCallAlert     = TemplateName{valuer.NewString("oncall_alert")}
+	TemplateNameAlertDigest     = TemplateName{valuer.NewString("alert_digest")}
 )
 
 type TemplateName struct{ valuer.String }
@@ -31,6 +33,10 @@ func NewTemplateName(name string) (TemplateName, error) {
 		return TemplateNameUpdateRole, nil
 	case TemplateNameResetPassword.StringValue():
 		return TemplateNameResetPassword, nil
+	case TemplateNameOnCallAlert.StringValue():
+		return TemplateNameOnCallAlert, nil
+	case TemplateNameAlertDigest.StringValue():
+		return TemplateNameAlertDigest, nil
 	default:
 		return TemplateName{}, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "invalid template name: %s", name)
 	}
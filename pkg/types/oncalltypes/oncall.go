@@ -0,0 +1,450 @@
+package oncalltypes
+
+import (
+	"encoding/json"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/uptrace/bun"
+)
+
+var (
+	ErrCodeScheduleNotFound      = errors.MustNewCode("oncall_schedule_not_found")
+	ErrCodeScheduleAlreadyExists = errors.MustNewCode("oncall_schedule_already_exists")
+	ErrCodeScheduleInvalid       = errors.MustNewCode("oncall_schedule_invalid")
+	ErrCodeScheduleInUse         = errors.MustNewCode("oncall_schedule_in_use")
+	ErrCodeOverrideNotFound      = errors.MustNewCode("oncall_override_not_found")
+	ErrCodeNobodyOnCall          = errors.MustNewCode("oncall_nobody_on_call")
+)
+
+const (
+	// ChannelHost is the host of the url of the webhook configs of the channels which target an on-call schedule, see
+	// NewChannelURL. The .invalid top level domain never resolves, these configs are sent to the participant on call
+	// of the schedule at send time instead of their url.
+	ChannelHost = "oncall.signoz.invalid"
+	channelPath = "/schedules/"
+)
+
+// Participant is someone taking part in the rotations of a schedule, they are notified on their email, their webhook
+// or both.
+type Participant struct {
+	Name       string `json:"name,omitempty"`
+	Email      string `json:"email,omitempty"`
+	WebhookURL string `json:"webhookUrl,omitempty"`
+}
+
+func (participant Participant) Validate() error {
+	if participant.Email == "" && participant.WebhookURL == "" {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeScheduleInvalid, "participant %s requires an email or a webhook url", participant.Name)
+	}
+
+	if participant.Email != "" {
+		if _, err := valuer.NewEmail(participant.Email); err != nil {
+			return errors.Newf(errors.TypeInvalidInput, ErrCodeScheduleInvalid, "invalid email %s of participant %s", participant.Email, participant.Name)
+		}
+	}
+
+	if participant.WebhookURL != "" {
+		u, err := url.Parse(participant.WebhookURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.Newf(errors.TypeInvalidInput, ErrCodeScheduleInvalid, "invalid webhook url of participant %s", participant.Name)
+		}
+	}
+
+	return nil
+}
+
+// Rotation hands the on-call over to the next participant at every occurrence of its recurrence. The recurrence has
+// the semantics of the recurrence of the planned maintenances, its times are wall clock times in the timezone of the
+// schedule:
+//   - daily rotations hand over every day at the time of day of the start time.
+//   - weekly rotations hand over on the days of RepeatOn, or on the weekday of the start time if empty.
+//
+// A positive duration limits each shift to the duration after the handoff, e.g. to cover office hours only, otherwise
+// the shift lasts until the next handoff.
+type Rotation struct {
+	Name         string               `json:"name"`
+	Participants []Participant        `json:"participants"`
+	Recurrence   ruletypes.Recurrence `json:"recurrence"`
+}
+
+func (rotation *Rotation) Validate() error {
+	if len(rotation.Participants) == 0 {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeScheduleInvalid, "rotation %s requires at least one participant", rotation.Name)
+	}
+
+	for _, participant := range rotation.Participants {
+		if err := participant.Validate(); err != nil {
+			return err
+		}
+	}
+
+	recurrence := rotation.Recurrence
+	if recurrence.StartTime.IsZero() {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeScheduleInvalid, "missing start time of rotation %s", rotation.Name)
+	}
+
+	if recurrence.EndTime != nil && recurrence.EndTime.Before(recurrence.StartTime) {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeScheduleInvalid, "end time of rotation %s cannot be before its start time", rotation.Name)
+	}
+
+	if recurrence.Duration.Duration() < 0 {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeScheduleInvalid, "duration of rotation %s cannot be negative", rotation.Name)
+	}
+
+	if recurrence.RepeatType != ruletypes.RepeatTypeDaily && recurrence.RepeatType != ruletypes.RepeatTypeWeekly {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeScheduleInvalid, "repeat type of rotation %s must be one of %s, %s", rotation.Name, ruletypes.RepeatTypeDaily, ruletypes.RepeatTypeWeekly)
+	}
+
+	for _, day := range recurrence.RepeatOn {
+		if _, ok := ruletypes.RepeatOnAllMap[day]; !ok {
+			return errors.Newf(errors.TypeInvalidInput, ErrCodeScheduleInvalid, "invalid repeat on day %s of rotation %s", day, rotation.Name)
+		}
+	}
+
+	return nil
+}
+
+// onCall returns the participant on call at the given time, if any.
+func (rotation *Rotation) onCall(at time.Time, loc *time.Location) (*Participant, bool) {
+	current := at.In(loc)
+	// the handoffs happen at the hour and minute of the start time, like the occurrences of the planned maintenances
+	startTime := rotation.Recurrence.StartTime
+	start := time.Date(startTime.Year(), startTime.Month(), startTime.Day(), startTime.Hour(), startTime.Minute(), 0, 0, loc)
+	if current.Before(start) {
+		return nil, false
+	}
+
+	if rotation.Recurrence.EndTime != nil && current.After(inLocation(*rotation.Recurrence.EndTime, loc)) {
+		return nil, false
+	}
+
+	// Rebase the time of day of the start onto each handoff day of the current week, the latest past candidate is the
+	// last handoff.
+	days := rotation.handoffDays(start)
+	var handoff time.Time
+	for _, day := range days {
+		candidate := time.Date(current.Year(), current.Month(), current.Day(), start.Hour(), start.Minute(), 0, 0, loc).AddDate(0, 0, int(day)-int(current.Weekday()))
+		if candidate.After(current) {
+			candidate = candidate.AddDate(0, 0, -7)
+		}
+
+		if candidate.After(handoff) {
+			handoff = candidate
+		}
+	}
+
+	if handoff.Before(start) {
+		return nil, false
+	}
+
+	if rotation.Recurrence.Duration.IsPositive() && current.Sub(handoff) > rotation.Recurrence.Duration.Duration() {
+		return nil, false
+	}
+
+	// The number of handoffs since the start is the number of handoff days between the start and the last handoff.
+	elapsed := int(date(handoff).Sub(date(start)).Hours() / 24)
+	handoffs := elapsed / 7 * len(days)
+	for i := 0; i < elapsed%7; i++ {
+		if slices.Contains(days, start.AddDate(0, 0, i).Weekday()) {
+			handoffs++
+		}
+	}
+
+	return &rotation.Participants[handoffs%len(rotation.Participants)], true
+}
+
+func (rotation *Rotation) handoffDays(start time.Time) []time.Weekday {
+	if rotation.Recurrence.RepeatType == ruletypes.RepeatTypeDaily {
+		return []time.Weekday{time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday}
+	}
+
+	if len(rotation.Recurrence.RepeatOn) == 0 {
+		return []time.Weekday{start.Weekday()}
+	}
+
+	days := []time.Weekday{}
+	for _, day := range rotation.Recurrence.RepeatOn {
+		days = append(days, ruletypes.RepeatOnAllMap[day])
+	}
+
+	slices.Sort(days)
+	return slices.Compact(days)
+}
+
+// Override temporarily puts a participant on call in place of the rotations, e.g. to swap a shift.
+type Override struct {
+	ID          valuer.UUID `json:"id"`
+	Participant Participant `json:"participant"`
+	StartTime   time.Time   `json:"startTime"`
+	EndTime     time.Time   `json:"endTime"`
+	CreatedBy   string      `json:"createdBy"`
+}
+
+type PostableOverride struct {
+	Participant Participant `json:"participant"`
+	StartTime   time.Time   `json:"startTime"`
+	EndTime     time.Time   `json:"endTime"`
+}
+
+func (postable *PostableOverride) Validate() error {
+	if err := postable.Participant.Validate(); err != nil {
+		return err
+	}
+
+	if postable.StartTime.IsZero() || postable.EndTime.IsZero() {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeScheduleInvalid, "start time and end time of an override are required")
+	}
+
+	if !postable.EndTime.After(postable.StartTime) {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeScheduleInvalid, "end time of an override must be after its start time")
+	}
+
+	return nil
+}
+
+type PostableSchedule struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Timezone    string `json:"timezone"`
+	// Rotations are checked in order, the first rotation with someone on call wins.
+	Rotations []Rotation `json:"rotations"`
+}
+
+func (postable *PostableSchedule) Validate() error {
+	if strings.TrimSpace(postable.Name) == "" {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeScheduleInvalid, "name is required")
+	}
+
+	if postable.Timezone == "" {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeScheduleInvalid, "timezone is required")
+	}
+
+	if _, err := time.LoadLocation(postable.Timezone); err != nil {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeScheduleInvalid, "invalid timezone %s", postable.Timezone)
+	}
+
+	if len(postable.Rotations) == 0 {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeScheduleInvalid, "at least one rotation is required")
+	}
+
+	for i := range postable.Rotations {
+		if err := postable.Rotations[i].Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type Schedule struct {
+	types.Identifiable
+	types.TimeAuditable
+	types.UserAuditable
+	PostableSchedule
+	Overrides []*Override `json:"overrides"`
+	OrgID     valuer.UUID `json:"orgId"`
+}
+
+type StorableSchedule struct {
+	bun.BaseModel `bun:"table:oncall_schedule,alias:oncall_schedule"`
+
+	types.Identifiable
+	types.TimeAuditable
+	types.UserAuditable
+	OrgID valuer.UUID `bun:"org_id,type:text,notnull"`
+	Name  string      `bun:"name,type:text,notnull"`
+	Data  string      `bun:"data,type:text,notnull"`
+}
+
+// storableData is the data of a stored schedule.
+type storableData struct {
+	PostableSchedule
+	Overrides []*Override `json:"overrides"`
+}
+
+// OnCall is the participant on call of a schedule at a time.
+type OnCall struct {
+	ScheduleID valuer.UUID `json:"scheduleId"`
+	At         time.Time   `json:"at"`
+	// Participant is nil when nobody is on call.
+	Participant *Participant `json:"participant"`
+	Rotation    string       `json:"rotation,omitempty"`
+	OverrideID  string       `json:"overrideId,omitempty"`
+}
+
+func NewSchedule(orgID valuer.UUID, createdBy string, postable *PostableSchedule) (*Schedule, error) {
+	if err := postable.Validate(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Schedule{
+		Identifiable: types.Identifiable{
+			ID: valuer.GenerateUUID(),
+		},
+		TimeAuditable: types.TimeAuditable{
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		UserAuditable: types.UserAuditable{
+			CreatedBy: createdBy,
+			UpdatedBy: createdBy,
+		},
+		PostableSchedule: *postable,
+		Overrides:        []*Override{},
+		OrgID:            orgID,
+	}, nil
+}
+
+// Update replaces the definition of the schedule, the overrides are kept.
+func (schedule *Schedule) Update(updatedBy string, postable *PostableSchedule) error {
+	if err := postable.Validate(); err != nil {
+		return err
+	}
+
+	schedule.PostableSchedule = *postable
+	schedule.UpdatedBy = updatedBy
+	schedule.UpdatedAt = time.Now()
+	return nil
+}
+
+// AddOverride adds an override to the schedule and drops the overrides which are over.
+func (schedule *Schedule) AddOverride(createdBy string, postable *PostableOverride) (*Override, error) {
+	if err := postable.Validate(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	schedule.Overrides = slices.DeleteFunc(schedule.Overrides, func(override *Override) bool {
+		return override.EndTime.Before(now)
+	})
+
+	override := &Override{
+		ID:          valuer.GenerateUUID(),
+		Participant: postable.Participant,
+		StartTime:   postable.StartTime,
+		EndTime:     postable.EndTime,
+		CreatedBy:   createdBy,
+	}
+	schedule.Overrides = append(schedule.Overrides, override)
+	schedule.UpdatedBy = createdBy
+	schedule.UpdatedAt = now
+
+	return override, nil
+}
+
+func (schedule *Schedule) DeleteOverride(updatedBy string, id valuer.UUID) error {
+	index := slices.IndexFunc(schedule.Overrides, func(override *Override) bool {
+		return override.ID == id
+	})
+	if index == -1 {
+		return errors.Newf(errors.TypeNotFound, ErrCodeOverrideNotFound, "override with id %s doesn't exist", id)
+	}
+
+	schedule.Overrides = slices.Delete(schedule.Overrides, index, index+1)
+	schedule.UpdatedBy = updatedBy
+	schedule.UpdatedAt = time.Now()
+	return nil
+}
+
+// OnCall returns who is on call at the given time. The latest override covering the time takes precedence over the
+// rotations.
+func (schedule *Schedule) OnCall(at time.Time) *OnCall {
+	onCall := &OnCall{ScheduleID: schedule.ID, At: at}
+
+	for i := len(schedule.Overrides) - 1; i >= 0; i-- {
+		override := schedule.Overrides[i]
+		if !at.Before(override.StartTime) && at.Before(override.EndTime) {
+			onCall.Participant = &override.Participant
+			onCall.OverrideID = override.ID.StringValue()
+			return onCall
+		}
+	}
+
+	loc, err := time.LoadLocation(schedule.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	for i := range schedule.Rotations {
+		if participant, ok := schedule.Rotations[i].onCall(at, loc); ok {
+			onCall.Participant = participant
+			onCall.Rotation = schedule.Rotations[i].Name
+			return onCall
+		}
+	}
+
+	return onCall
+}
+
+func NewStorableScheduleFromSchedule(schedule *Schedule) (*StorableSchedule, error) {
+	data, err := json.Marshal(storableData{PostableSchedule: schedule.PostableSchedule, Overrides: schedule.Overrides})
+	if err != nil {
+		return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to marshal oncall schedule")
+	}
+
+	return &StorableSchedule{
+		Identifiable:  schedule.Identifiable,
+		TimeAuditable: schedule.TimeAuditable,
+		UserAuditable: schedule.UserAuditable,
+		OrgID:         schedule.OrgID,
+		Name:          schedule.Name,
+		Data:          string(data),
+	}, nil
+}
+
+func NewScheduleFromStorableSchedule(storable *StorableSchedule) (*Schedule, error) {
+	data := storableData{}
+	if err := json.Unmarshal([]byte(storable.Data), &data); err != nil {
+		return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to unmarshal oncall schedule %s", storable.ID)
+	}
+
+	if data.Overrides == nil {
+		data.Overrides = []*Override{}
+	}
+
+	return &Schedule{
+		Identifiable:     storable.Identifiable,
+		TimeAuditable:    storable.TimeAuditable,
+		UserAuditable:    storable.UserAuditable,
+		PostableSchedule: data.PostableSchedule,
+		Overrides:        data.Overrides,
+		OrgID:            storable.OrgID,
+	}, nil
+}
+
+// NewChannelURL returns the url of the webhook config of a channel targeting the schedule, e.g.
+// https://oncall.signoz.invalid/schedules/<id>.
+func NewChannelURL(id valuer.UUID) string {
+	return (&url.URL{Scheme: "https", Host: ChannelHost, Path: channelPath + id.StringValue()}).String()
+}
+
+// ScheduleIDFromChannelURL returns the id of the schedule targeted by the url of a webhook config, if any.
+func ScheduleIDFromChannelURL(u *url.URL) (valuer.UUID, bool) {
+	if u == nil || u.Host != ChannelHost || !strings.HasPrefix(u.Path, channelPath) {
+		return valuer.UUID{}, false
+	}
+
+	id, err := valuer.NewUUID(strings.TrimPrefix(u.Path, channelPath))
+	if err != nil {
+		return valuer.UUID{}, false
+	}
+
+	return id, true
+}
+
+// inLocation returns the wall clock time of t in loc.
+func inLocation(t time.Time, loc *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
+
+func date(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
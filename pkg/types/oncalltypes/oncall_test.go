@@ -0,0 +1,235 @@
+package oncalltypes
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var participants = []Participant{
+	{Name: "alice", Email: "alice@signoz.io"},
+	{Name: "bob", Email: "bob@signoz.io"},
+	{Name: "carol", WebhookURL: "https://hooks.signoz.io/carol"},
+}
+
+func newTestSchedule(t *testing.T, timezone string, rotations ...Rotation) *Schedule {
+	schedule, err := NewSchedule(valuer.GenerateUUID(), "admin@signoz.io", &PostableSchedule{
+		Name:      "payments",
+		Timezone:  timezone,
+		Rotations: rotations,
+	})
+	require.NoError(t, err)
+
+	return schedule
+}
+
+func assertOnCall(t *testing.T, schedule *Schedule, at string, name string) {
+	t.Helper()
+
+	ts, err := time.Parse(time.RFC3339, at)
+	require.NoError(t, err)
+
+	onCall := schedule.OnCall(ts)
+	if name == "" {
+		assert.Nil(t, onCall.Participant, at)
+		return
+	}
+
+	require.NotNil(t, onCall.Participant, at)
+	assert.Equal(t, name, onCall.Participant.Name, at)
+}
+
+func TestSchedule_OnCall_Daily(t *testing.T) {
+	// the start time is a wall clock time in the timezone of the schedule, 09:00 IST is 03:30 UTC
+	schedule := newTestSchedule(t, "Asia/Kolkata", Rotation{
+		Name:         "primary",
+		Participants: participants,
+		Recurrence: ruletypes.Recurrence{
+			StartTime:  time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC),
+			RepeatType: ruletypes.RepeatTypeDaily,
+		},
+	})
+
+	assertOnCall(t, schedule, "2025-01-06T03:29:00Z", "")
+	assertOnCall(t, schedule, "2025-01-06T03:30:00Z", "alice")
+	assertOnCall(t, schedule, "2025-01-07T03:29:00Z", "alice")
+	assertOnCall(t, schedule, "2025-01-07T03:30:00Z", "bob")
+	assertOnCall(t, schedule, "2025-01-08T12:00:00Z", "carol")
+	assertOnCall(t, schedule, "2025-01-09T03:30:00Z", "alice")
+}
+
+func TestSchedule_OnCall_Weekly(t *testing.T) {
+	schedule := newTestSchedule(t, "UTC", Rotation{
+		Name:         "primary",
+		Participants: participants,
+		Recurrence: ruletypes.Recurrence{
+			// monday
+			StartTime:  time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC),
+			RepeatType: ruletypes.RepeatTypeWeekly,
+			RepeatOn:   []ruletypes.RepeatOn{ruletypes.RepeatOnThursday, ruletypes.RepeatOnMonday},
+		},
+	})
+
+	assertOnCall(t, schedule, "2025-01-08T12:00:00Z", "alice")
+	assertOnCall(t, schedule, "2025-01-09T09:00:00Z", "bob")
+	assertOnCall(t, schedule, "2025-01-13T08:59:00Z", "bob")
+	assertOnCall(t, schedule, "2025-01-13T09:00:00Z", "carol")
+	assertOnCall(t, schedule, "2025-01-16T09:00:00Z", "alice")
+	// 52 weeks later, 104 handoffs
+	assertOnCall(t, schedule, "2026-01-05T09:00:00Z", "carol")
+}
+
+func TestSchedule_OnCall_WeeklyOnStartDay(t *testing.T) {
+	schedule := newTestSchedule(t, "UTC", Rotation{
+		Name:         "primary",
+		Participants: participants,
+		Recurrence: ruletypes.Recurrence{
+			// wednesday
+			StartTime:  time.Date(2025, 1, 8, 9, 0, 0, 0, time.UTC),
+			RepeatType: ruletypes.RepeatTypeWeekly,
+		},
+	})
+
+	assertOnCall(t, schedule, "2025-01-14T12:00:00Z", "alice")
+	assertOnCall(t, schedule, "2025-01-15T09:00:00Z", "bob")
+}
+
+func TestSchedule_OnCall_AcrossDaylightSavingTime(t *testing.T) {
+	schedule := newTestSchedule(t, "America/New_York", Rotation{
+		Name:         "primary",
+		Participants: participants,
+		Recurrence: ruletypes.Recurrence{
+			StartTime:  time.Date(2025, 3, 8, 9, 0, 0, 0, time.UTC),
+			RepeatType: ruletypes.RepeatTypeDaily,
+		},
+	})
+
+	// 09:00 EST is 14:00 UTC, 09:00 EDT is 13:00 UTC
+	assertOnCall(t, schedule, "2025-03-08T14:00:00Z", "alice")
+	assertOnCall(t, schedule, "2025-03-09T13:00:00Z", "bob")
+	assertOnCall(t, schedule, "2025-03-10T12:59:00Z", "bob")
+	assertOnCall(t, schedule, "2025-03-10T13:00:00Z", "carol")
+}
+
+func TestSchedule_OnCall_Layers(t *testing.T) {
+	end := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	schedule := newTestSchedule(t, "UTC",
+		Rotation{
+			Name:         "office-hours",
+			Participants: participants[:1],
+			Recurrence: ruletypes.Recurrence{
+				StartTime:  time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC),
+				EndTime:    &end,
+				Duration:   valuer.MustParseTextDuration("8h"),
+				RepeatType: ruletypes.RepeatTypeDaily,
+			},
+		},
+		Rotation{
+			Name:         "fallback",
+			Participants: participants[1:2],
+			Recurrence: ruletypes.Recurrence{
+				StartTime:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				RepeatType: ruletypes.RepeatTypeDaily,
+			},
+		},
+	)
+
+	assertOnCall(t, schedule, "2025-01-06T17:00:00Z", "alice")
+	assertOnCall(t, schedule, "2025-01-06T17:01:00Z", "bob")
+	assertOnCall(t, schedule, "2025-02-03T10:00:00Z", "bob")
+}
+
+func TestSchedule_OnCall_Override(t *testing.T) {
+	schedule := newTestSchedule(t, "UTC", Rotation{
+		Name:         "primary",
+		Participants: participants[:1],
+		Recurrence: ruletypes.Recurrence{
+			StartTime:  time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC),
+			RepeatType: ruletypes.RepeatTypeDaily,
+		},
+	})
+
+	override, err := schedule.AddOverride("admin@signoz.io", &PostableOverride{
+		Participant: participants[2],
+		StartTime:   time.Now().Add(-time.Hour),
+		EndTime:     time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	onCall := schedule.OnCall(time.Now())
+	require.NotNil(t, onCall.Participant)
+	assert.Equal(t, "carol", onCall.Participant.Name)
+	assert.Equal(t, override.ID.StringValue(), onCall.OverrideID)
+
+	onCall = schedule.OnCall(time.Now().Add(2 * time.Hour))
+	require.NotNil(t, onCall.Participant)
+	assert.Equal(t, "alice", onCall.Participant.Name)
+	assert.Equal(t, "primary", onCall.Rotation)
+
+	require.NoError(t, schedule.DeleteOverride("admin@signoz.io", override.ID))
+	assert.Error(t, schedule.DeleteOverride("admin@signoz.io", override.ID))
+}
+
+func TestPostableSchedule_Validate(t *testing.T) {
+	valid := func() *PostableSchedule {
+		return &PostableSchedule{
+			Name:     "payments",
+			Timezone: "Europe/Paris",
+			Rotations: []Rotation{{
+				Name:         "primary",
+				Participants: []Participant{{Email: "alice@signoz.io"}},
+				Recurrence: ruletypes.Recurrence{
+					StartTime:  time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC),
+					RepeatType: ruletypes.RepeatTypeWeekly,
+				},
+			}},
+		}
+	}
+	require.NoError(t, valid().Validate())
+
+	testCases := []struct {
+		name   string
+		mutate func(*PostableSchedule)
+	}{
+		{name: "NoName", mutate: func(p *PostableSchedule) { p.Name = "" }},
+		{name: "InvalidTimezone", mutate: func(p *PostableSchedule) { p.Timezone = "Mars/Olympus" }},
+		{name: "NoRotations", mutate: func(p *PostableSchedule) { p.Rotations = nil }},
+		{name: "NoParticipants", mutate: func(p *PostableSchedule) { p.Rotations[0].Participants = nil }},
+		{name: "ParticipantWithoutContact", mutate: func(p *PostableSchedule) { p.Rotations[0].Participants[0].Email = "" }},
+		{name: "InvalidEmail", mutate: func(p *PostableSchedule) { p.Rotations[0].Participants[0].Email = "alice" }},
+		{name: "InvalidWebhookURL", mutate: func(p *PostableSchedule) { p.Rotations[0].Participants[0].WebhookURL = "ftp://alice" }},
+		{name: "MonthlyRotation", mutate: func(p *PostableSchedule) { p.Rotations[0].Recurrence.RepeatType = ruletypes.RepeatTypeMonthly }},
+		{name: "InvalidRepeatOn", mutate: func(p *PostableSchedule) { p.Rotations[0].Recurrence.RepeatOn = []ruletypes.RepeatOn{"someday"} }},
+		{name: "NoStartTime", mutate: func(p *PostableSchedule) { p.Rotations[0].Recurrence.StartTime = time.Time{} }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			postable := valid()
+			tc.mutate(postable)
+			assert.Error(t, postable.Validate())
+		})
+	}
+}
+
+func TestScheduleIDFromChannelURL(t *testing.T) {
+	id := valuer.GenerateUUID()
+
+	u, err := url.Parse(NewChannelURL(id))
+	require.NoError(t, err)
+
+	parsed, ok := ScheduleIDFromChannelURL(u)
+	require.True(t, ok)
+	assert.Equal(t, id, parsed)
+
+	u, err = url.Parse("https://hooks.signoz.io/schedules/" + id.StringValue())
+	require.NoError(t, err)
+
+	_, ok = ScheduleIDFromChannelURL(u)
+	assert.False(t, ok)
+}
@@ -0,0 +1,21 @@
+package oncalltypes
+
+import (
+	"context"
+
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type Store interface {
+	Create(context.Context, *StorableSchedule) error
+
+	Get(context.Context, valuer.UUID, valuer.UUID) (*StorableSchedule, error)
+
+	List(context.Context, valuer.UUID) ([]*StorableSchedule, error)
+
+	Update(context.Context, *StorableSchedule) error
+
+	Delete(context.Context, valuer.UUID, valuer.UUID) error
+
+	RunInTx(context.Context, func(ctx context.Context) error) error
+}
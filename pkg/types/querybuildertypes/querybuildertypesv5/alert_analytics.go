@@ -0,0 +1,159 @@
+package querybuildertypesv5
+
+import (
+	"context"
+	"slices"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// AlertAnalyticsMetric is a metric computed over the episodes of the alerts recorded in the rule state history. An
+// episode starts when an alert of a label set starts firing and ends when it is resolved.
+type AlertAnalyticsMetric struct{ valuer.String }
+
+var (
+	// the number of episodes
+	AlertAnalyticsMetricTriggers = AlertAnalyticsMetric{valuer.NewString("triggers")}
+	// the mean time to resolve the episodes, in seconds
+	AlertAnalyticsMetricMTTR = AlertAnalyticsMetric{valuer.NewString("mttr")}
+	// the mean time to acknowledge the episodes, in seconds
+	AlertAnalyticsMetricMTTA = AlertAnalyticsMetric{valuer.NewString("mtta")}
+	// the total time spent firing, in seconds
+	AlertAnalyticsMetricFiringDuration = AlertAnalyticsMetric{valuer.NewString("firing_duration")}
+)
+
+// The keys of the rule state history which are not labels of the alerts.
+const (
+	AlertAnalyticsKeyRuleID      = "rule_id"
+	AlertAnalyticsKeyRuleName    = "rule_name"
+	AlertAnalyticsKeyFingerprint = "fingerprint"
+	AlertAnalyticsKeyLabels      = "labels"
+)
+
+func (m AlertAnalyticsMetric) Valid() bool {
+	return slices.Contains([]AlertAnalyticsMetric{
+		AlertAnalyticsMetricTriggers,
+		AlertAnalyticsMetricMTTR,
+		AlertAnalyticsMetricMTTA,
+		AlertAnalyticsMetricFiringDuration,
+	}, m)
+}
+
+type AlertAnalyticsAggregation struct {
+	Metric AlertAnalyticsMetric `json:"metric"`
+	// if any, it will be used as the alias of the aggregation in the result
+	Alias string `json:"alias,omitempty"`
+}
+
+// AlertAnalyticsQuery queries the episodes of the alerts. The filter, group by and order reference the rule_id,
+// rule_name, fingerprint and labels keys, any other key is a label of the alerts, for example `team`. Raw queries
+// return the episodes, which is the timeline of the alerts.
+type AlertAnalyticsQuery struct {
+	Name     string `json:"name"`
+	Disabled bool   `json:"disabled,omitempty"`
+
+	StepInterval Step                        `json:"stepInterval,omitempty"`
+	Aggregations []AlertAnalyticsAggregation `json:"aggregations,omitempty"`
+	Filter       *Filter                     `json:"filter,omitempty"`
+	GroupBy      []GroupByKey                `json:"groupBy,omitempty"`
+	Having       *Having                     `json:"having,omitempty"`
+	Order        []OrderBy                   `json:"order,omitempty"`
+	Limit        int                         `json:"limit,omitempty"`
+	Offset       int                         `json:"offset,omitempty"`
+
+	Legend string `json:"legend,omitempty"`
+}
+
+// Copy creates a deep copy of AlertAnalyticsQuery
+func (q AlertAnalyticsQuery) Copy() AlertAnalyticsQuery {
+	c := q
+
+	if q.Aggregations != nil {
+		c.Aggregations = make([]AlertAnalyticsAggregation, len(q.Aggregations))
+		copy(c.Aggregations, q.Aggregations)
+	}
+
+	if q.GroupBy != nil {
+		c.GroupBy = make([]GroupByKey, len(q.GroupBy))
+		for i, gb := range q.GroupBy {
+			c.GroupBy[i] = gb.Copy()
+		}
+	}
+
+	if q.Order != nil {
+		c.Order = make([]OrderBy, len(q.Order))
+		for i, o := range q.Order {
+			c.Order[i] = o.Copy()
+		}
+	}
+
+	if q.Filter != nil {
+		c.Filter = q.Filter.Copy()
+	}
+
+	if q.Having != nil {
+		c.Having = q.Having.Copy()
+	}
+
+	return c
+}
+
+func (q AlertAnalyticsQuery) Validate(requestType RequestType) error {
+	if q.Name == "" {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "name is required")
+	}
+
+	switch requestType {
+	case RequestTypeRaw:
+		if len(q.Aggregations) > 0 || len(q.GroupBy) > 0 || q.Having != nil {
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "aggregations, group by and having are not supported for the raw alert analytics queries")
+		}
+	case RequestTypeTimeSeries, RequestTypeScalar:
+		if len(q.Aggregations) == 0 {
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "at least one aggregation is required")
+		}
+	default:
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "request type %s is not supported for the alert analytics queries", requestType.StringValue())
+	}
+
+	for idx, agg := range q.Aggregations {
+		if !agg.Metric.Valid() {
+			return errors.NewInvalidInputf(
+				errors.CodeInvalidInput,
+				"invalid metric %q for aggregation %d",
+				agg.Metric.StringValue(),
+				idx+1,
+			).WithAdditional(
+				"Valid metrics are: triggers, mttr, mtta, firing_duration",
+			)
+		}
+	}
+
+	for _, gb := range q.GroupBy {
+		if gb.Name == "" {
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "group by key name is required")
+		}
+	}
+
+	for _, order := range q.Order {
+		if order.Direction != OrderDirectionAsc && order.Direction != OrderDirectionDesc {
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid order direction %q", order.Direction.StringValue())
+		}
+	}
+
+	if q.Limit < 0 || q.Limit > MaxQueryLimit {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "limit must be between 0 and %d", MaxQueryLimit)
+	}
+
+	if q.Offset < 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "offset must not be negative")
+	}
+
+	return nil
+}
+
+type AlertAnalyticsStore interface {
+	// ListRuleIDs lists the ids of the rules of the organization, including the deleted ones as their history is kept.
+	ListRuleIDs(ctx context.Context, orgID valuer.UUID) ([]string, error)
+}
@@ -586,3 +586,42 @@ func (l *LimitBy) Copy() *LimitBy {
 	}
 	return c
 }
+
+type Distribution struct {
+	// key whose values are bucketed, required for logs and traces.
+	// for metrics the values of the aggregated series are bucketed and the key is ignored
+	Key telemetrytypes.TelemetryFieldKey `json:"key"`
+	// fixed and strictly increasing bucket boundaries, n boundaries make n-1 buckets
+	Boundaries []float64 `json:"boundaries,omitempty"`
+	// number of equal width buckets between the min and max of the values, used when no boundaries are given
+	BucketCount int `json:"bucketCount,omitempty"`
+}
+
+// Copy creates a deep copy of Distribution
+func (d *Distribution) Copy() *Distribution {
+	if d == nil {
+		return nil
+	}
+	c := *d
+	if d.Boundaries != nil {
+		c.Boundaries = make([]float64, len(d.Boundaries))
+		copy(c.Boundaries, d.Boundaries)
+	}
+	return &c
+}
+
+// IsFixed returns true if the buckets are given by the boundaries rather than derived from the values
+func (d *Distribution) IsFixed() bool {
+	return len(d.Boundaries) > 0
+}
+
+// NumBuckets returns the number of buckets of the distribution
+func (d *Distribution) NumBuckets() int {
+	if d.IsFixed() {
+		return len(d.Boundaries) - 1
+	}
+	if d.BucketCount > 0 {
+		return d.BucketCount
+	}
+	return DefaultDistributionBucketCount
+}
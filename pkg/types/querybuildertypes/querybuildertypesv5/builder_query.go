@@ -63,6 +63,9 @@ type QueryBuilderQuery[T any] struct {
 
 	Legend string `json:"legend,omitempty"`
 
+	// distribution configures the buckets of a distribution request
+	Distribution *Distribution `json:"distribution,omitempty"`
+
 	// ShiftBy is extracted from timeShift function for internal use
 	// This field is not serialized to JSON
 	ShiftBy int64 `json:"-"`
@@ -123,6 +126,10 @@ func (q QueryBuilderQuery[T]) Copy() QueryBuilderQuery[T] {
 		c.Having = q.Having.Copy()
 	}
 
+	if q.Distribution != nil {
+		c.Distribution = q.Distribution.Copy()
+	}
+
 	return c
 }
 
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"maps"
+	"slices"
 
 	"github.com/SigNoz/signoz/pkg/types/cachetypes"
 )
@@ -16,6 +17,23 @@ type CachedBucket struct {
 	Type    RequestType     `json:"type"`
 	Value   json.RawMessage `json:"value"`
 	Stats   ExecStats       `json:"stats"`
+
+	// timeSeries is set instead of Value when the bucket is decoded from the binary encoding
+	timeSeries *TimeSeriesData
+}
+
+// TimeSeriesData returns the time series of a bucket of the time series request type
+func (c *CachedBucket) TimeSeriesData() (*TimeSeriesData, error) {
+	if c.timeSeries != nil {
+		return c.timeSeries, nil
+	}
+
+	var tsData *TimeSeriesData
+	if err := json.Unmarshal(c.Value, &tsData); err != nil {
+		return nil, err
+	}
+
+	return tsData, nil
 }
 
 func (c *CachedBucket) Clone() *CachedBucket {
@@ -30,9 +48,39 @@ func (c *CachedBucket) Clone() *CachedBucket {
 			DurationMS:    c.Stats.DurationMS,
 			StepIntervals: maps.Clone(c.Stats.StepIntervals),
 		},
+		timeSeries: cloneTimeSeriesData(c.timeSeries),
 	}
 }
 
+func cloneTimeSeriesData(tsData *TimeSeriesData) *TimeSeriesData {
+	if tsData == nil {
+		return nil
+	}
+
+	cloned := &TimeSeriesData{
+		QueryName:    tsData.QueryName,
+		Aggregations: make([]*AggregationBucket, len(tsData.Aggregations)),
+	}
+	for i, agg := range tsData.Aggregations {
+		clonedAgg := *agg
+		clonedAgg.Series = make([]*TimeSeries, len(agg.Series))
+		for j, series := range agg.Series {
+			clonedSeries := &TimeSeries{
+				Labels: slices.Clone(series.Labels),
+				Values: make([]*TimeSeriesValue, len(series.Values)),
+			}
+			for k, value := range series.Values {
+				clonedValue := *value
+				clonedSeries.Values[k] = &clonedValue
+			}
+			clonedAgg.Series[j] = clonedSeries
+		}
+		cloned.Aggregations[i] = &clonedAgg
+	}
+
+	return cloned
+}
+
 // CachedData represents the full cached data for a query
 type CachedData struct {
 	Buckets  []*CachedBucket `json:"buckets"`
@@ -40,11 +88,11 @@ type CachedData struct {
 }
 
 func (c *CachedData) UnmarshalBinary(data []byte) error {
-	return json.Unmarshal(data, c)
+	return decodeCachedData(data, c)
 }
 
 func (c *CachedData) MarshalBinary() ([]byte, error) {
-	return json.Marshal(c)
+	return encodeCachedData(c)
 }
 
 func (c *CachedData) Clone() cachetypes.Cacheable {
@@ -85,3 +85,30 @@ func BenchmarkCachedData_Clone_10kbuckets(b *testing.B) {
 		_ = data.Clone()
 	}
 }
+
+func BenchmarkCachedData_MarshalBinary_10kbuckets(b *testing.B) {
+	buckets := createBuckets_TimeSeries(10000)
+	data := &CachedData{Buckets: buckets}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, err := data.MarshalBinary()
+		assert.NoError(b, err)
+	}
+}
+
+func BenchmarkCachedData_UnmarshalBinary_10kbuckets(b *testing.B) {
+	buckets := createBuckets_TimeSeries(10000)
+	raw, err := (&CachedData{Buckets: buckets}).MarshalBinary()
+	assert.NoError(b, err)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		var data CachedData
+		assert.NoError(b, data.UnmarshalBinary(raw))
+	}
+}
@@ -0,0 +1,554 @@
+package querybuildertypesv5
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"math/bits"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/klauspost/compress/zstd"
+)
+
+// cachedDataVersion is the first byte of the binary encoding of the cached data. The entries of any other version,
+// including the JSON entries written before the binary encoding, are skipped as misses instead of being mis-decoded.
+const cachedDataVersion byte = 1
+
+const (
+	// the value of the bucket is zstd compressed JSON
+	cachedBucketEncodingJSON byte = 1
+	// the value of the bucket is a time series, its structure is zstd compressed JSON and its points are compressed
+	// with delta-of-delta timestamps and XOR values as described in the Gorilla paper
+	cachedBucketEncodingTimeSeries byte = 2
+)
+
+var (
+	// EncodeAll and DecodeAll are safe for concurrent use
+	zstdEncoder, _ = zstd.NewWriter(nil)
+	zstdDecoder, _ = zstd.NewReader(nil)
+)
+
+func encodeCachedData(c *CachedData) ([]byte, error) {
+	buf := []byte{cachedDataVersion}
+
+	buf = binary.AppendUvarint(buf, uint64(len(c.Buckets)))
+	for _, bucket := range c.Buckets {
+		var err error
+		buf, err = appendCachedBucket(buf, bucket)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	buf = binary.AppendUvarint(buf, uint64(len(c.Warnings)))
+	for _, warning := range c.Warnings {
+		buf = appendBytes(buf, []byte(warning))
+	}
+
+	return buf, nil
+}
+
+func decodeCachedData(data []byte, c *CachedData) error {
+	if len(data) == 0 || data[0] != cachedDataVersion {
+		return errors.Newf(errors.TypeNotFound, errors.CodeNotFound, "skipping cached data of an unknown version")
+	}
+
+	r := &cachedDataReader{data: data[1:]}
+
+	buckets := make([]*CachedBucket, r.count())
+	for i := range buckets {
+		buckets[i] = r.bucket()
+	}
+
+	warnings := make([]string, r.count())
+	for i := range warnings {
+		warnings[i] = string(r.bytes())
+	}
+
+	if r.err != nil {
+		return r.err
+	}
+
+	c.Buckets = buckets
+	c.Warnings = warnings
+	return nil
+}
+
+func appendCachedBucket(buf []byte, bucket *CachedBucket) ([]byte, error) {
+	buf = binary.AppendUvarint(buf, bucket.StartMs)
+	buf = binary.AppendUvarint(buf, bucket.EndMs)
+	buf = appendBytes(buf, []byte(bucket.Type.StringValue()))
+
+	buf = binary.AppendUvarint(buf, bucket.Stats.RowsScanned)
+	buf = binary.AppendUvarint(buf, bucket.Stats.BytesScanned)
+	buf = binary.AppendUvarint(buf, bucket.Stats.DurationMS)
+	buf = binary.AppendUvarint(buf, uint64(len(bucket.Stats.StepIntervals)))
+	for name, step := range bucket.Stats.StepIntervals {
+		buf = appendBytes(buf, []byte(name))
+		buf = binary.AppendUvarint(buf, step)
+	}
+
+	if bucket.Type == RequestTypeTimeSeries {
+		if tsData, err := bucket.TimeSeriesData(); err == nil && isCompressibleTimeSeries(tsData) {
+			buf = append(buf, cachedBucketEncodingTimeSeries)
+			return appendTimeSeries(buf, tsData)
+		}
+	}
+
+	value := []byte(bucket.Value)
+	if value == nil && bucket.timeSeries != nil {
+		var err error
+		value, err = json.Marshal(bucket.timeSeries)
+		if err != nil {
+			return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to marshal the cached time series")
+		}
+	}
+
+	buf = append(buf, cachedBucketEncodingJSON)
+	return appendBytes(buf, zstdEncoder.EncodeAll(value, nil)), nil
+}
+
+// isCompressibleTimeSeries returns true if the time series only has plain points, the points of the heatmaps and the
+// series of the anomalies are kept as JSON
+func isCompressibleTimeSeries(tsData *TimeSeriesData) bool {
+	if tsData == nil {
+		return false
+	}
+
+	for _, agg := range tsData.Aggregations {
+		if agg == nil || len(agg.PredictedSeries) != 0 || len(agg.UpperBoundSeries) != 0 || len(agg.LowerBoundSeries) != 0 || len(agg.AnomalyScores) != 0 {
+			return false
+		}
+
+		for _, series := range agg.Series {
+			if series == nil {
+				return false
+			}
+
+			for _, value := range series.Values {
+				if value == nil || len(value.Values) != 0 || value.Bucket != nil {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// appendTimeSeries appends the structure of the time series without the points, then the points of every series in
+// the order of the structure
+func appendTimeSeries(buf []byte, tsData *TimeSeriesData) ([]byte, error) {
+	skeleton := &TimeSeriesData{
+		QueryName:    tsData.QueryName,
+		Aggregations: make([]*AggregationBucket, len(tsData.Aggregations)),
+	}
+	for i, agg := range tsData.Aggregations {
+		skeleton.Aggregations[i] = &AggregationBucket{
+			Index:  agg.Index,
+			Alias:  agg.Alias,
+			Meta:   agg.Meta,
+			Series: make([]*TimeSeries, len(agg.Series)),
+		}
+		for j, series := range agg.Series {
+			skeleton.Aggregations[i].Series[j] = &TimeSeries{Labels: series.Labels}
+		}
+	}
+
+	structure, err := json.Marshal(skeleton)
+	if err != nil {
+		return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to marshal the cached time series")
+	}
+	buf = appendBytes(buf, zstdEncoder.EncodeAll(structure, nil))
+
+	for _, agg := range tsData.Aggregations {
+		for _, series := range agg.Series {
+			buf = appendPoints(buf, series.Values)
+		}
+	}
+
+	return buf, nil
+}
+
+// appendPoints appends the number of points, the indexes of the partial points, then the timestamps as deltas of
+// deltas and the values as XOR of the previous value, bit packed
+func appendPoints(buf []byte, values []*TimeSeriesValue) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(values)))
+
+	partial := make([]int, 0)
+	for i, value := range values {
+		if value.Partial {
+			partial = append(partial, i)
+		}
+	}
+	buf = binary.AppendUvarint(buf, uint64(len(partial)))
+	for _, i := range partial {
+		buf = binary.AppendUvarint(buf, uint64(i))
+	}
+
+	w := &bitWriter{}
+	var (
+		prevTimestamp, prevDelta int64
+		prevValue                uint64
+		prevLeading              = -1
+		prevTrailing             int
+	)
+	for i, value := range values {
+		valueBits := math.Float64bits(value.Value)
+		if i == 0 {
+			w.writeBits(uint64(value.Timestamp), 64)
+			w.writeBits(valueBits, 64)
+			prevTimestamp, prevValue = value.Timestamp, valueBits
+			continue
+		}
+
+		delta := value.Timestamp - prevTimestamp
+		w.writeDeltaOfDelta(delta - prevDelta)
+		prevTimestamp, prevDelta = value.Timestamp, delta
+
+		xor := valueBits ^ prevValue
+		prevValue = valueBits
+		if xor == 0 {
+			w.writeBits(0, 1)
+			continue
+		}
+		w.writeBits(1, 1)
+
+		leading, trailing := min(bits.LeadingZeros64(xor), 31), bits.TrailingZeros64(xor)
+		if prevLeading >= 0 && leading >= prevLeading && trailing >= prevTrailing {
+			// the meaningful bits fit in the window of the previous value
+			w.writeBits(0, 1)
+			w.writeBits(xor>>prevTrailing, 64-prevLeading-prevTrailing)
+			continue
+		}
+
+		significant := 64 - leading - trailing
+		w.writeBits(1, 1)
+		w.writeBits(uint64(leading), 5)
+		// 64 significant bits are written as 0, there is always at least one
+		w.writeBits(uint64(significant&63), 6)
+		w.writeBits(xor>>trailing, significant)
+		prevLeading, prevTrailing = leading, trailing
+	}
+
+	return appendBytes(buf, w.buf)
+}
+
+func appendBytes(buf []byte, b []byte) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(b)))
+	return append(buf, b...)
+}
+
+// cachedDataReader reads the binary encoding of the cached data, the first error is kept and the reads after it return
+// zero values
+type cachedDataReader struct {
+	data []byte
+	err  error
+}
+
+func (r *cachedDataReader) fail() {
+	if r.err == nil {
+		r.err = errors.NewInternalf(errors.CodeInternal, "corrupted cached data")
+	}
+	r.data = nil
+}
+
+func (r *cachedDataReader) uvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+
+	v, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		r.fail()
+		return 0
+	}
+	r.data = r.data[n:]
+	return v
+}
+
+// count reads the number of the elements which follow, every element takes at least a byte
+func (r *cachedDataReader) count() int {
+	n := r.uvarint()
+	if n > uint64(len(r.data)) {
+		r.fail()
+		return 0
+	}
+	return int(n)
+}
+
+func (r *cachedDataReader) byte() byte {
+	if r.err != nil {
+		return 0
+	}
+
+	if len(r.data) == 0 {
+		r.fail()
+		return 0
+	}
+	b := r.data[0]
+	r.data = r.data[1:]
+	return b
+}
+
+func (r *cachedDataReader) bytes() []byte {
+	n := r.count()
+	if r.err != nil {
+		return nil
+	}
+
+	b := r.data[:n:n]
+	r.data = r.data[n:]
+	return b
+}
+
+func (r *cachedDataReader) bucket() *CachedBucket {
+	bucket := &CachedBucket{
+		StartMs: r.uvarint(),
+		EndMs:   r.uvarint(),
+		Type:    RequestType{valuer.NewString(string(r.bytes()))},
+	}
+
+	bucket.Stats.RowsScanned = r.uvarint()
+	bucket.Stats.BytesScanned = r.uvarint()
+	bucket.Stats.DurationMS = r.uvarint()
+	if n := r.count(); n > 0 {
+		bucket.Stats.StepIntervals = make(map[string]uint64, n)
+		for range n {
+			name := string(r.bytes())
+			bucket.Stats.StepIntervals[name] = r.uvarint()
+		}
+	}
+
+	switch r.byte() {
+	case cachedBucketEncodingJSON:
+		compressed := r.bytes()
+		if r.err != nil {
+			return bucket
+		}
+
+		value, err := zstdDecoder.DecodeAll(compressed, nil)
+		if err != nil {
+			r.fail()
+			return bucket
+		}
+		if len(value) != 0 {
+			bucket.Value = value
+		}
+	case cachedBucketEncodingTimeSeries:
+		bucket.timeSeries = r.timeSeries()
+	default:
+		r.fail()
+	}
+
+	return bucket
+}
+
+func (r *cachedDataReader) timeSeries() *TimeSeriesData {
+	compressed := r.bytes()
+	if r.err != nil {
+		return nil
+	}
+
+	structure, err := zstdDecoder.DecodeAll(compressed, nil)
+	if err != nil {
+		r.fail()
+		return nil
+	}
+
+	var tsData *TimeSeriesData
+	if err := json.Unmarshal(structure, &tsData); err != nil || tsData == nil {
+		r.fail()
+		return nil
+	}
+
+	for _, agg := range tsData.Aggregations {
+		if agg == nil {
+			r.fail()
+			return nil
+		}
+
+		for _, series := range agg.Series {
+			if series == nil {
+				r.fail()
+				return nil
+			}
+			series.Values = r.points()
+		}
+	}
+
+	return tsData
+}
+
+func (r *cachedDataReader) points() []*TimeSeriesValue {
+	n := r.uvarint()
+
+	partial := make([]uint64, r.count())
+	for i := range partial {
+		partial[i] = r.uvarint()
+	}
+
+	br := &bitReader{buf: r.bytes()}
+	if r.err != nil {
+		return nil
+	}
+
+	// every point takes at least two bits
+	if n > uint64(len(br.buf))*4+1 {
+		r.fail()
+		return nil
+	}
+
+	values := make([]*TimeSeriesValue, n)
+	var (
+		prevTimestamp, prevDelta  int64
+		prevValue                 uint64
+		prevLeading, prevTrailing int
+	)
+	for i := range values {
+		if i == 0 {
+			prevTimestamp = int64(br.readBits(64))
+			prevValue = br.readBits(64)
+			values[i] = &TimeSeriesValue{Timestamp: prevTimestamp, Value: math.Float64frombits(prevValue)}
+			continue
+		}
+
+		prevDelta += br.readDeltaOfDelta()
+		prevTimestamp += prevDelta
+
+		if br.readBits(1) == 1 {
+			if br.readBits(1) == 1 {
+				prevLeading = int(br.readBits(5))
+				significant := int(br.readBits(6))
+				if significant == 0 {
+					significant = 64
+				}
+				prevTrailing = 64 - prevLeading - significant
+				if prevTrailing < 0 {
+					r.fail()
+					return nil
+				}
+			}
+			prevValue ^= br.readBits(64-prevLeading-prevTrailing) << prevTrailing
+		}
+
+		values[i] = &TimeSeriesValue{Timestamp: prevTimestamp, Value: math.Float64frombits(prevValue)}
+	}
+
+	if br.err {
+		r.fail()
+		return nil
+	}
+
+	for _, i := range partial {
+		if i >= uint64(len(values)) {
+			r.fail()
+			return nil
+		}
+		values[i].Partial = true
+	}
+
+	return values
+}
+
+// bitWriter packs the bits from the most significant bit of every byte
+type bitWriter struct {
+	buf []byte
+	// the number of bits left in the last byte
+	free int
+}
+
+// writeBits writes the n least significant bits of v
+func (w *bitWriter) writeBits(v uint64, n int) {
+	for n > 0 {
+		if w.free == 0 {
+			w.buf = append(w.buf, 0)
+			w.free = 8
+		}
+
+		take := min(n, w.free)
+		chunk := byte((v >> (n - take)) & (1<<take - 1))
+		w.buf[len(w.buf)-1] |= chunk << (w.free - take)
+		w.free -= take
+		n -= take
+	}
+}
+
+// writeDeltaOfDelta writes the delta of delta of a timestamp with a prefix for the number of bits it takes
+func (w *bitWriter) writeDeltaOfDelta(dod int64) {
+	switch {
+	case dod == 0:
+		w.writeBits(0b0, 1)
+	case dod >= -64 && dod <= 63:
+		w.writeBits(0b10, 2)
+		w.writeBits(uint64(dod), 7)
+	case dod >= -256 && dod <= 255:
+		w.writeBits(0b110, 3)
+		w.writeBits(uint64(dod), 9)
+	case dod >= -2048 && dod <= 2047:
+		w.writeBits(0b1110, 4)
+		w.writeBits(uint64(dod), 12)
+	default:
+		w.writeBits(0b1111, 4)
+		w.writeBits(uint64(dod), 64)
+	}
+}
+
+type bitReader struct {
+	buf []byte
+	// the position of the next bit
+	pos int
+	// set when reading past the end of the buffer
+	err bool
+}
+
+func (r *bitReader) readBits(n int) uint64 {
+	if n == 0 {
+		return 0
+	}
+
+	if r.err || r.pos+n > len(r.buf)*8 {
+		r.err = true
+		return 0
+	}
+
+	var v uint64
+	for n > 0 {
+		off := r.pos % 8
+		take := min(n, 8-off)
+		chunk := (r.buf[r.pos/8] >> (8 - off - take)) & (1<<take - 1)
+		v = v<<take | uint64(chunk)
+		r.pos += take
+		n -= take
+	}
+	return v
+}
+
+func (r *bitReader) readDeltaOfDelta() int64 {
+	ones := 0
+	for ones < 4 && r.readBits(1) == 1 {
+		ones++
+	}
+
+	var width int
+	switch ones {
+	case 0:
+		return 0
+	case 1:
+		width = 7
+	case 2:
+		width = 9
+	case 3:
+		width = 12
+	default:
+		return int64(r.readBits(64))
+	}
+
+	v := r.readBits(width)
+	// sign extend
+	if v&(1<<(width-1)) != 0 {
+		v |= ^uint64(0) << width
+	}
+	return int64(v)
+}
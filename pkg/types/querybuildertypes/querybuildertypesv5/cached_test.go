@@ -0,0 +1,184 @@
+package querybuildertypesv5
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachedDataBinaryRoundTrip(t *testing.T) {
+	timeSeries := &TimeSeriesData{
+		QueryName: "A",
+		Aggregations: []*AggregationBucket{
+			{
+				Index: 0,
+				Alias: "count",
+				Series: []*TimeSeries{
+					{
+						Labels: []*Label{
+							{Key: telemetrytypes.TelemetryFieldKey{Name: "service.name"}, Value: "frontend"},
+						},
+						Values: []*TimeSeriesValue{
+							{Timestamp: 1735689600000, Value: 1, Partial: true},
+							{Timestamp: 1735689660000, Value: 1},
+							{Timestamp: 1735689720000, Value: 12.5},
+							{Timestamp: 1735689780000, Value: -3.75},
+							// irregular timestamps exercise every width of the delta of delta
+							{Timestamp: 1735689780010, Value: 1e300},
+							{Timestamp: 1735689780500, Value: 1e-300},
+							{Timestamp: 1735689790000, Value: 0},
+							{Timestamp: 1735699790000, Value: 42},
+							{Timestamp: 1735699790001, Value: 42},
+						},
+					},
+					{
+						Labels: []*Label{
+							{Key: telemetrytypes.TelemetryFieldKey{Name: "service.name"}, Value: "cart"},
+						},
+						Values: []*TimeSeriesValue{},
+					},
+				},
+			},
+			{
+				Index: 1,
+				Series: []*TimeSeries{
+					{
+						Values: []*TimeSeriesValue{
+							{Timestamp: 1735689600000, Value: 0.1},
+							{Timestamp: 1735689660000, Value: 0.2},
+							{Timestamp: 1735689720000, Value: 0.30000000000000004, Partial: true},
+						},
+					},
+				},
+			},
+		},
+	}
+	timeSeriesValue, err := json.Marshal(timeSeries)
+	require.NoError(t, err)
+
+	data := &CachedData{
+		Buckets: []*CachedBucket{
+			{
+				StartMs: 1735689600000,
+				EndMs:   1735699790002,
+				Type:    RequestTypeTimeSeries,
+				Value:   timeSeriesValue,
+				Stats: ExecStats{
+					RowsScanned:   100,
+					BytesScanned:  2048,
+					DurationMS:    12,
+					StepIntervals: map[string]uint64{"A": 60},
+				},
+			},
+			{
+				StartMs: 1735689600000,
+				EndMs:   1735693200000,
+				Type:    RequestTypeScalar,
+				Value:   json.RawMessage(`{"columns":[],"data":[[1,2]]}`),
+			},
+			{
+				StartMs: 1735689600000,
+				EndMs:   1735693200000,
+				Type:    RequestTypeRaw,
+				Value:   json.RawMessage(`{"rows":[{"data":{"body":"hello"}}]}`),
+			},
+		},
+		Warnings: []string{"a warning"},
+	}
+
+	raw, err := data.MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, cachedDataVersion, raw[0])
+
+	decoded := new(CachedData)
+	require.NoError(t, decoded.UnmarshalBinary(raw))
+	require.Len(t, decoded.Buckets, 3)
+	assert.Equal(t, data.Warnings, decoded.Warnings)
+
+	for i, bucket := range decoded.Buckets {
+		assert.Equal(t, data.Buckets[i].StartMs, bucket.StartMs)
+		assert.Equal(t, data.Buckets[i].EndMs, bucket.EndMs)
+		assert.Equal(t, data.Buckets[i].Type, bucket.Type)
+		assert.Equal(t, data.Buckets[i].Stats, bucket.Stats)
+	}
+
+	expected, err := data.Buckets[0].TimeSeriesData()
+	require.NoError(t, err)
+	actual, err := decoded.Buckets[0].TimeSeriesData()
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	assert.JSONEq(t, string(data.Buckets[1].Value), string(decoded.Buckets[1].Value))
+	assert.JSONEq(t, string(data.Buckets[2].Value), string(decoded.Buckets[2].Value))
+
+	// the decoded time series are copied by the clones and encoded again as is
+	cloned := decoded.Clone().(*CachedData)
+	actual, err = cloned.Buckets[0].TimeSeriesData()
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	reencoded, err := cloned.MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, raw, reencoded)
+}
+
+func TestCachedDataBinaryHeatmap(t *testing.T) {
+	timeSeries := &TimeSeriesData{
+		QueryName: "A",
+		Aggregations: []*AggregationBucket{
+			{
+				Series: []*TimeSeries{
+					{Values: []*TimeSeriesValue{{Timestamp: 1735689600000, Values: []float64{1, 2, 3}}}},
+				},
+			},
+		},
+	}
+	value, err := json.Marshal(timeSeries)
+	require.NoError(t, err)
+
+	data := &CachedData{Buckets: []*CachedBucket{{Type: RequestTypeTimeSeries, Value: value}}}
+	raw, err := data.MarshalBinary()
+	require.NoError(t, err)
+
+	// the heatmaps are kept as JSON
+	decoded := new(CachedData)
+	require.NoError(t, decoded.UnmarshalBinary(raw))
+	assert.JSONEq(t, string(value), string(decoded.Buckets[0].Value))
+}
+
+func TestCachedDataBinarySkipsUnknownVersions(t *testing.T) {
+	legacy, err := json.Marshal(&CachedData{Buckets: createBuckets_TimeSeries(1)})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name string
+		data []byte
+	}{
+		{name: "JSON", data: legacy},
+		{name: "NextVersion", data: []byte{cachedDataVersion + 1, 0, 0}},
+		{name: "Empty", data: []byte{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := new(CachedData).UnmarshalBinary(tc.data)
+			require.Error(t, err)
+			assert.True(t, errors.Ast(err, errors.TypeNotFound))
+		})
+	}
+}
+
+func TestCachedDataBinaryCorrupted(t *testing.T) {
+	raw, err := (&CachedData{Buckets: createBuckets_TimeSeries(2)}).MarshalBinary()
+	require.NoError(t, err)
+
+	for _, n := range []int{2, len(raw) / 2, len(raw) - 1} {
+		err := new(CachedData).UnmarshalBinary(raw[:n])
+		require.Error(t, err)
+		assert.True(t, errors.Ast(err, errors.TypeInternal))
+	}
+}
@@ -0,0 +1,193 @@
+package querybuildertypesv5
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/uptrace/bun"
+)
+
+// CachePurgeReason is the reason the cached results of a signal were purged
+type CachePurgeReason struct {
+	valuer.String
+}
+
+var (
+	CachePurgeReasonManual    = CachePurgeReason{valuer.NewString("manual")}
+	CachePurgeReasonRetention = CachePurgeReason{valuer.NewString("retention")}
+	CachePurgeReasonPipelines = CachePurgeReason{valuer.NewString("pipelines")}
+)
+
+func validateInvalidationSignal(signal telemetrytypes.Signal) error {
+	switch signal {
+	case telemetrytypes.SignalTraces, telemetrytypes.SignalLogs, telemetrytypes.SignalMetrics:
+		return nil
+	}
+
+	return errors.NewInvalidInputf(errors.CodeInvalidInput, "signal must be one of traces, logs or metrics, got %q", signal.StringValue())
+}
+
+// PostableWatermark is reported by a source of the data of a signal, such as a collector or a batch log shipper, once
+// it has delivered all of its data up to the watermark. The cached results never cover the data past the watermarks
+// of the sources, so the data arriving late from them shows up in the results.
+type PostableWatermark struct {
+	Signal telemetrytypes.Signal `json:"signal"`
+	Source string                `json:"source"`
+	// Watermark is the time in epoch milliseconds up to which the source has delivered its data
+	Watermark int64 `json:"watermark"`
+}
+
+func (postable *PostableWatermark) Validate() error {
+	if err := validateInvalidationSignal(postable.Signal); err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(postable.Source) == "" {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "source is required")
+	}
+
+	if postable.Watermark <= 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "watermark must be a positive time in epoch milliseconds")
+	}
+
+	return nil
+}
+
+// StorableWatermark is the last watermark reported by a source, a source has one watermark per signal
+type StorableWatermark struct {
+	bun.BaseModel `bun:"table:querier_watermark"`
+	types.Identifiable
+	types.TimeAuditable
+
+	Signal    telemetrytypes.Signal `bun:"signal,type:text,notnull"`
+	Source    string                `bun:"source,type:text,notnull"`
+	Watermark time.Time             `bun:"watermark,notnull"`
+
+	OrgID string `bun:"org_id,type:text,notnull"`
+}
+
+func NewStorableWatermark(orgID string, postable *PostableWatermark, now time.Time) (*StorableWatermark, error) {
+	if err := postable.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &StorableWatermark{
+		Identifiable: types.Identifiable{
+			ID: valuer.GenerateUUID(),
+		},
+		TimeAuditable: types.TimeAuditable{
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		Signal:    postable.Signal,
+		Source:    postable.Source,
+		Watermark: time.UnixMilli(postable.Watermark),
+		OrgID:     orgID,
+	}, nil
+}
+
+// Lag returns how far the data of the source is behind at now
+func (watermark *StorableWatermark) Lag(now time.Time) time.Duration {
+	return max(now.Sub(watermark.Watermark), 0)
+}
+
+type GettableWatermark struct {
+	PostableWatermark
+
+	// LagMs is how far the data of the source is behind, in milliseconds
+	LagMs     int64     `json:"lagMs"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type GettableWatermarks = []*GettableWatermark
+
+func NewGettableWatermarks(watermarks []*StorableWatermark, now time.Time) GettableWatermarks {
+	gettables := make(GettableWatermarks, 0, len(watermarks))
+	for _, watermark := range watermarks {
+		gettables = append(gettables, &GettableWatermark{
+			PostableWatermark: PostableWatermark{
+				Signal:    watermark.Signal,
+				Source:    watermark.Source,
+				Watermark: watermark.Watermark.UnixMilli(),
+			},
+			LagMs:     watermark.Lag(now).Milliseconds(),
+			UpdatedAt: watermark.UpdatedAt,
+		})
+	}
+
+	return gettables
+}
+
+// PostableCachePurge purges the cached results of the queries of a signal, for the changes which alter the data
+// already cached such as the changes of the retention
+type PostableCachePurge struct {
+	Signal telemetrytypes.Signal `json:"signal"`
+	Reason CachePurgeReason      `json:"reason"`
+}
+
+func (postable *PostableCachePurge) Validate() error {
+	if err := validateInvalidationSignal(postable.Signal); err != nil {
+		return err
+	}
+
+	switch postable.Reason {
+	case CachePurgeReasonManual, CachePurgeReasonRetention, CachePurgeReasonPipelines:
+		return nil
+	}
+
+	return errors.NewInvalidInputf(errors.CodeInvalidInput, "reason must be one of manual, retention or pipelines, got %q", postable.Reason.StringValue())
+}
+
+// StorableCachePurge is the event of a purge of the cached results of a signal. The results cached before the latest
+// purge of a signal are never served again by any instance of the querier.
+type StorableCachePurge struct {
+	bun.BaseModel `bun:"table:querier_cache_purge"`
+	types.Identifiable
+
+	CreatedAt time.Time             `bun:"created_at,notnull"`
+	CreatedBy string                `bun:"created_by,type:text"`
+	Signal    telemetrytypes.Signal `bun:"signal,type:text,notnull"`
+	Reason    CachePurgeReason      `bun:"reason,type:text,notnull"`
+
+	OrgID string `bun:"org_id,type:text,notnull"`
+}
+
+func NewStorableCachePurge(orgID string, createdBy string, postable *PostableCachePurge, now time.Time) (*StorableCachePurge, error) {
+	if err := postable.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &StorableCachePurge{
+		Identifiable: types.Identifiable{
+			ID: valuer.GenerateUUID(),
+		},
+		CreatedAt: now,
+		CreatedBy: createdBy,
+		Signal:    postable.Signal,
+		Reason:    postable.Reason,
+		OrgID:     orgID,
+	}, nil
+}
+
+type InvalidationStore interface {
+	// SetWatermark creates the watermark of a source or updates the existing one.
+	SetWatermark(ctx context.Context, watermark *StorableWatermark) error
+
+	// ListWatermarks lists the watermarks of all the organizations updated after since.
+	ListWatermarks(ctx context.Context, since time.Time) ([]*StorableWatermark, error)
+
+	// ListOrgWatermarks lists the watermarks of the organization.
+	ListOrgWatermarks(ctx context.Context, orgID string) ([]*StorableWatermark, error)
+
+	// CreatePurge creates the event of a purge.
+	CreatePurge(ctx context.Context, purge *StorableCachePurge) error
+
+	// ListLatestPurges lists the latest purge of every signal of every organization, with only their organization,
+	// signal and creation time.
+	ListLatestPurges(ctx context.Context) ([]*StorableCachePurge, error)
+}
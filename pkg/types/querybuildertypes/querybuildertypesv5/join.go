@@ -1,6 +1,7 @@
 package querybuildertypesv5
 
 import (
+	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
 )
@@ -16,6 +17,19 @@ var (
 	JoinTypeCross = JoinType{valuer.NewString("cross")}
 )
 
+// JoinAggregation is an aggregation over the joined rows, the expression references the columns of the joined
+// queries as `<query name>.<column>`, for example countIf(B.duration_nano > 1000000).
+type JoinAggregation struct {
+	Expression string `json:"expression"`
+	// if any, it will be used as the alias of the aggregation in the result
+	Alias string `json:"alias,omitempty"`
+}
+
+// Copy creates a deep copy of JoinAggregation
+func (j JoinAggregation) Copy() JoinAggregation {
+	return j
+}
+
 type QueryRef struct {
 	Name string `json:"name"`
 }
@@ -25,6 +39,24 @@ func (q QueryRef) Copy() QueryRef {
 	return q
 }
 
+// JoinedQueryRequestType returns the request type a builder query referenced by a join is run with. Queries with
+// aggregations are run as scalar queries and the others as raw queries.
+func JoinedQueryRequestType(spec any) RequestType {
+	switch s := spec.(type) {
+	case QueryBuilderQuery[TraceAggregation]:
+		if len(s.Aggregations) > 0 {
+			return RequestTypeScalar
+		}
+	case QueryBuilderQuery[LogAggregation]:
+		if len(s.Aggregations) > 0 {
+			return RequestTypeScalar
+		}
+	case QueryBuilderQuery[MetricAggregation]:
+		return RequestTypeScalar
+	}
+	return RequestTypeRaw
+}
+
 type QueryBuilderJoin struct {
 	Name     string `json:"name"`
 	Disabled bool   `json:"disabled,omitempty"`
@@ -33,7 +65,8 @@ type QueryBuilderJoin struct {
 	Left  QueryRef `json:"left"`
 	Right QueryRef `json:"right"`
 
-	// join type + condition ON
+	// join type + condition ON, the condition is a conjunction of equalities between the columns of the left and
+	// right queries, for example `A.trace_id = B.trace_id`
 	Type JoinType `json:"type"`
 	On   string   `json:"on"`
 
@@ -49,6 +82,7 @@ type QueryBuilderJoin struct {
 	Having                *Having                `json:"having,omitempty"`
 	Order                 []OrderBy              `json:"order,omitempty"`
 	Limit                 int                    `json:"limit,omitempty"`
+	Offset                int                    `json:"offset,omitempty"`
 	SecondaryAggregations []SecondaryAggregation `json:"secondaryAggregations,omitempty"`
 	Functions             []Function             `json:"functions,omitempty"`
 }
@@ -109,3 +143,30 @@ func (q QueryBuilderJoin) Copy() QueryBuilderJoin {
 
 	return c
 }
+
+// JoinAggregations returns the aggregations of the join. The aggregations are decoded from the request as generic
+// values, so they are converted to JoinAggregation here.
+func (q QueryBuilderJoin) JoinAggregations() ([]JoinAggregation, error) {
+	aggregations := make([]JoinAggregation, 0, len(q.Aggregations))
+	for idx, aggregation := range q.Aggregations {
+		switch agg := aggregation.(type) {
+		case JoinAggregation:
+			aggregations = append(aggregations, agg)
+		case map[string]any:
+			expression, _ := agg["expression"].(string)
+			alias, _ := agg["alias"].(string)
+			aggregations = append(aggregations, JoinAggregation{Expression: expression, Alias: alias})
+		default:
+			return nil, errors.NewInvalidInputf(
+				errors.CodeInvalidInput,
+				"invalid aggregation at index %d of join query '%s'",
+				idx,
+				q.Name,
+			).WithAdditional(
+				"Join aggregations must be of the form {\"expression\": \"count()\", \"alias\": \"total\"}",
+			)
+		}
+	}
+
+	return aggregations, nil
+}
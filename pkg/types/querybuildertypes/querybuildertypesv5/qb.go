@@ -58,3 +58,19 @@ type TraceOperatorStatementBuilder interface {
 	// Build builds the trace operator query.
 	Build(ctx context.Context, start, end uint64, requestType RequestType, query QueryBuilderTraceOperator, compositeQuery *CompositeQuery) (*Statement, error)
 }
+
+// JoinedQuery is a builder query referenced by a join, along with the statement it was built into.
+type JoinedQuery struct {
+	Spec      any
+	Statement *Statement
+}
+
+type JoinStatementBuilder interface {
+	// Build builds the join query on top of the statements of the joined queries.
+	Build(ctx context.Context, start, end uint64, requestType RequestType, query QueryBuilderJoin, left *JoinedQuery, right *JoinedQuery, variables map[string]VariableItem) (*Statement, error)
+}
+
+type AlertAnalyticsStatementBuilder interface {
+	// Build builds the alert analytics query over the rule state history of the rules with the given ids.
+	Build(ctx context.Context, start, end uint64, requestType RequestType, query AlertAnalyticsQuery, ruleIDs []string, variables map[string]VariableItem) (*Statement, error)
+}
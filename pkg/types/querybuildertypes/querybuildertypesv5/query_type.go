@@ -7,12 +7,17 @@ type QueryType struct {
 }
 
 var (
-	QueryTypeUnknown       = QueryType{valuer.NewString("unknown")}
-	QueryTypeBuilder       = QueryType{valuer.NewString("builder_query")}
-	QueryTypeFormula       = QueryType{valuer.NewString("builder_formula")}
-	QueryTypeSubQuery      = QueryType{valuer.NewString("builder_sub_query")}
-	QueryTypeJoin          = QueryType{valuer.NewString("builder_join")}
-	QueryTypeTraceOperator = QueryType{valuer.NewString("builder_trace_operator")}
-	QueryTypeClickHouseSQL = QueryType{valuer.NewString("clickhouse_sql")}
-	QueryTypePromQL        = QueryType{valuer.NewString("promql")}
+	QueryTypeUnknown        = QueryType{valuer.NewString("unknown")}
+	QueryTypeBuilder        = QueryType{valuer.NewString("builder_query")}
+	QueryTypeFormula        = QueryType{valuer.NewString("builder_formula")}
+	QueryTypeSubQuery       = QueryType{valuer.NewString("builder_sub_query")}
+	QueryTypeJoin           = QueryType{valuer.NewString("builder_join")}
+	QueryTypeTraceOperator  = QueryType{valuer.NewString("builder_trace_operator")}
+	QueryTypeClickHouseSQL  = QueryType{valuer.NewString("clickhouse_sql")}
+	QueryTypePromQL         = QueryType{valuer.NewString("promql")}
+	QueryTypeAlertAnalytics = QueryType{valuer.NewString("alert_analytics")}
 )
+
+// SubQueryRefPrefix is the prefix of the value which references another query in the filter expression of a builder
+// query, as in `service.name IN @A`.
+const SubQueryRefPrefix = "@"
@@ -112,13 +112,20 @@ func (q *QueryEnvelope) UnmarshalJSON(data []byte) error {
 		}
 		q.Spec = spec
 
+	case QueryTypeAlertAnalytics:
+		var spec AlertAnalyticsQuery
+		if err := json.Unmarshal(shadow.Spec, &spec); err != nil {
+			return wrapUnmarshalError(err, "invalid alert analytics spec: %v", err)
+		}
+		q.Spec = spec
+
 	default:
 		return errors.NewInvalidInputf(
 			errors.CodeInvalidInput,
 			"unknown query type %q",
 			shadow.Type,
 		).WithAdditional(
-			"Valid query types are: builder_query, builder_sub_query, builder_formula, builder_join, builder_trace_operator, promql, clickhouse_sql",
+			"Valid query types are: builder_query, builder_sub_query, builder_formula, builder_join, builder_trace_operator, promql, clickhouse_sql, alert_analytics",
 		)
 	}
 
@@ -229,6 +236,8 @@ func (r *QueryRangeRequest) StepIntervalForQuery(name string) int64 {
 			stepsMap[spec.Name] = spec.StepInterval.Milliseconds()
 		case PromQuery:
 			stepsMap[spec.Name] = spec.Step.Milliseconds()
+		case AlertAnalyticsQuery:
+			stepsMap[spec.Name] = spec.StepInterval.Milliseconds()
 		}
 	}
 
@@ -275,6 +284,10 @@ func (r *QueryRangeRequest) NumAggregationForQuery(name string) int64 {
 			if spec.Name == name {
 				numAgg += 1
 			}
+		case AlertAnalyticsQuery:
+			if spec.Name == name {
+				numAgg += 1
+			}
 		}
 	}
 	return int64(numAgg)
@@ -325,28 +338,42 @@ func (r *QueryRangeRequest) FuncsForQuery(name string) []Function {
 			if spec.Name == name {
 				funcs = spec.Functions
 			}
+		case QueryBuilderTraceOperator:
+			if spec.Name == name {
+				funcs = spec.Functions
+			}
 		}
 	}
 	return funcs
 }
 
-func (r *QueryRangeRequest) IsAnomalyRequest() (*QueryBuilderQuery[MetricAggregation], bool) {
-	hasAnomaly := false
-	var q QueryBuilderQuery[MetricAggregation]
+// AnomalyFunctions returns the anomaly function of every builder query, formula and trace operator which uses it,
+// keyed by the name of the query
+func (r *QueryRangeRequest) AnomalyFunctions() map[string]Function {
+	anomalyFuncs := make(map[string]Function)
 	for _, query := range r.CompositeQuery.Queries {
+		var name string
+		var funcs []Function
 		switch spec := query.Spec.(type) {
-		// only metrics support anomaly right now
+		case QueryBuilderQuery[TraceAggregation]:
+			name, funcs = spec.Name, spec.Functions
+		case QueryBuilderQuery[LogAggregation]:
+			name, funcs = spec.Name, spec.Functions
 		case QueryBuilderQuery[MetricAggregation]:
-			for _, f := range spec.Functions {
-				if f.Name == FunctionNameAnomaly {
-					hasAnomaly = true
-					q = spec
-				}
+			name, funcs = spec.Name, spec.Functions
+		case QueryBuilderFormula:
+			name, funcs = spec.Name, spec.Functions
+		case QueryBuilderTraceOperator:
+			name, funcs = spec.Name, spec.Functions
+		}
+		for _, f := range funcs {
+			if f.Name == FunctionNameAnomaly {
+				anomalyFuncs[name] = f
+				break
 			}
 		}
 	}
-
-	return &q, hasAnomaly
+	return anomalyFuncs
 }
 
 // We do not support fill gaps for these queries. Maybe support in future?
@@ -1798,3 +1798,93 @@ func TestQueryRangeRequest_GetQueriesSupportingZeroDefault(t *testing.T) {
 		})
 	}
 }
+
+func TestQueryRangeRequest_AnomalyFunctions(t *testing.T) {
+	anomaly := Function{
+		Name: FunctionNameAnomaly,
+		Args: []FunctionArg{{Name: "seasonality", Value: "weekly"}},
+	}
+
+	tests := []struct {
+		name           string
+		CompositeQuery CompositeQuery
+		want           map[string]Function
+	}{
+		{
+			name: "no anomaly function",
+			CompositeQuery: CompositeQuery{
+				Queries: []QueryEnvelope{
+					{
+						Type: QueryTypeBuilder,
+						Spec: QueryBuilderQuery[MetricAggregation]{
+							Name:      "A",
+							Signal:    telemetrytypes.SignalMetrics,
+							Functions: []Function{{Name: FunctionNameEWMA3}},
+						},
+					},
+				},
+			},
+			want: map[string]Function{},
+		},
+		{
+			name: "anomaly function on every kind of query",
+			CompositeQuery: CompositeQuery{
+				Queries: []QueryEnvelope{
+					{
+						Type: QueryTypeBuilder,
+						Spec: QueryBuilderQuery[TraceAggregation]{
+							Name:      "A",
+							Signal:    telemetrytypes.SignalTraces,
+							Functions: []Function{anomaly},
+						},
+					},
+					{
+						Type: QueryTypeBuilder,
+						Spec: QueryBuilderQuery[LogAggregation]{
+							Name:      "B",
+							Signal:    telemetrytypes.SignalLogs,
+							Functions: []Function{{Name: FunctionNameAbsolute}, anomaly},
+						},
+					},
+					{
+						Type: QueryTypeBuilder,
+						Spec: QueryBuilderQuery[MetricAggregation]{
+							Name:   "C",
+							Signal: telemetrytypes.SignalMetrics,
+						},
+					},
+					{
+						Type: QueryTypeFormula,
+						Spec: QueryBuilderFormula{
+							Name:       "F1",
+							Expression: "A / B",
+							Functions:  []Function{anomaly},
+						},
+					},
+					{
+						Type: QueryTypeTraceOperator,
+						Spec: QueryBuilderTraceOperator{
+							Name:       "T1",
+							Expression: "A => B",
+							Functions:  []Function{anomaly},
+						},
+					},
+				},
+			},
+			want: map[string]Function{
+				"A":  anomaly,
+				"B":  anomaly,
+				"F1": anomaly,
+				"T1": anomaly,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &QueryRangeRequest{
+				CompositeQuery: tt.CompositeQuery,
+			}
+			assert.Equal(t, tt.want, r.AnomalyFunctions())
+		})
+	}
+}
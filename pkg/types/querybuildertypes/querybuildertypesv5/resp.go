@@ -186,6 +186,23 @@ type RawRow struct {
 	Data      map[string]any `json:"data"`
 }
 
+type DistributionData struct {
+	QueryName string                `json:"queryName"`
+	Series    []*DistributionSeries `json:"series"`
+}
+
+type DistributionSeries struct {
+	Labels  []*Label              `json:"labels,omitempty"`
+	Buckets []*DistributionBucket `json:"buckets"`
+}
+
+// DistributionBucket is the number of values in [Lower, Upper), the last bucket also includes Upper
+type DistributionBucket struct {
+	Lower float64 `json:"lower"`
+	Upper float64 `json:"upper"`
+	Count uint64  `json:"count"`
+}
+
 type RawStream struct {
 	Name  string
 	Logs  chan *RawRow
@@ -0,0 +1,55 @@
+package querybuildertypesv5
+
+import (
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type StreamFormat struct {
+	valuer.String
+}
+
+var (
+	// one JSON encoded event per line
+	StreamFormatNDJSON = StreamFormat{valuer.NewString("ndjson")}
+	// server-sent events, the type of the event is the name of the event
+	StreamFormatSSE = StreamFormat{valuer.NewString("sse")}
+)
+
+type StreamEventType struct {
+	valuer.String
+}
+
+var (
+	// a part of the result of a query, the parts of a time series result carry the points read since the previous part
+	// and must be merged by the index of the aggregation and the labels of the series, the parts of a scalar result
+	// carry the rows read since the previous part and the columns in the first part only
+	StreamEventTypePart = StreamEventType{valuer.NewString("part")}
+	// the whole result of a query which needs its full result before it can be sent, with the reason it is buffered
+	StreamEventTypeResult = StreamEventType{valuer.NewString("result")}
+	// the end of the stream, with the stats and the warnings of the request
+	StreamEventTypeEnd = StreamEventType{valuer.NewString("end")}
+	// the error which ended the stream
+	StreamEventTypeError = StreamEventType{valuer.NewString("error")}
+)
+
+// StreamEvent is an event of a streamed query range response.
+type StreamEvent struct {
+	Type      StreamEventType `json:"type"`
+	QueryName string          `json:"queryName,omitempty"`
+	// Result is a *TimeSeriesData or *ScalarData for the part events, and any result for the result events
+	Result any `json:"result,omitempty"`
+	// Buffered is the reason the result of the query was buffered instead of streamed
+	Buffered string `json:"buffered,omitempty"`
+
+	Meta    *ExecStats     `json:"meta,omitempty"`
+	Warning *QueryWarnData `json:"warning,omitempty"`
+	Error   *errors.JSON   `json:"error,omitempty"`
+
+	QBEvent *QBEvent `json:"-"`
+}
+
+// StreamWriter writes the events of a streamed query range response to the client.
+type StreamWriter interface {
+	Write(event *StreamEvent) error
+}
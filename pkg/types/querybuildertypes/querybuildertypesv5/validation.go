@@ -6,10 +6,25 @@ import (
 	"strings"
 
 	"github.com/SigNoz/signoz/pkg/errors"
+	grammar "github.com/SigNoz/signoz/pkg/parser/grammar"
 	"github.com/SigNoz/signoz/pkg/types/metrictypes"
 	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/antlr4-go/antlr/v4"
 )
 
+// builderQueryName returns the name of the builder query, or an empty string if the spec is not a builder query
+func builderQueryName(spec any) string {
+	switch s := spec.(type) {
+	case QueryBuilderQuery[TraceAggregation]:
+		return s.Name
+	case QueryBuilderQuery[LogAggregation]:
+		return s.Name
+	case QueryBuilderQuery[MetricAggregation]:
+		return s.Name
+	}
+	return ""
+}
+
 // getQueryIdentifier returns a friendly identifier for a query based on its type and name/content
 func getQueryIdentifier(envelope QueryEnvelope, index int) string {
 	switch envelope.Type {
@@ -56,6 +71,11 @@ func getQueryIdentifier(envelope QueryEnvelope, index int) string {
 			return fmt.Sprintf("ClickHouse query '%s'", spec.Name)
 		}
 		return fmt.Sprintf("ClickHouse query at position %d", index+1)
+	case QueryTypeAlertAnalytics:
+		if spec, ok := envelope.Spec.(AlertAnalyticsQuery); ok && spec.Name != "" {
+			return fmt.Sprintf("alert analytics query '%s'", spec.Name)
+		}
+		return fmt.Sprintf("alert analytics query at position %d", index+1)
 	}
 	return fmt.Sprintf("query at position %d", index+1)
 }
@@ -63,6 +83,11 @@ func getQueryIdentifier(envelope QueryEnvelope, index int) string {
 const (
 	// Maximum limit for query results
 	MaxQueryLimit = 10000
+
+	// Number of buckets of a distribution when neither boundaries nor bucket count are given
+	DefaultDistributionBucketCount = 20
+	// Maximum number of buckets of a distribution
+	MaxDistributionBucketCount = 1000
 )
 
 // Validate performs preliminary validation on QueryBuilderQuery
@@ -72,8 +97,15 @@ func (q *QueryBuilderQuery[T]) Validate(requestType RequestType) error {
 		return err
 	}
 
-	// Validate aggregations only for non-raw request types
-	if requestType != RequestTypeRaw && requestType != RequestTypeRawStream && requestType != RequestTypeTrace {
+	if requestType == RequestTypeDistribution {
+		if err := q.validateDistribution(); err != nil {
+			return err
+		}
+	}
+
+	// Validate aggregations only for non-raw request types, distributions of logs and traces count the values of the key
+	if requestType != RequestTypeRaw && requestType != RequestTypeRawStream && requestType != RequestTypeTrace &&
+		(requestType != RequestTypeDistribution || q.Signal == telemetrytypes.SignalMetrics) {
 		if err := q.validateAggregations(); err != nil {
 			return err
 		}
@@ -119,6 +151,66 @@ func (q *QueryBuilderQuery[T]) Validate(requestType RequestType) error {
 	return nil
 }
 
+func (q *QueryBuilderQuery[T]) validateDistribution() error {
+	if q.Distribution == nil {
+		return errors.NewInvalidInputf(
+			errors.CodeInvalidInput,
+			"distribution is required for distribution requests",
+		)
+	}
+
+	if q.Signal != telemetrytypes.SignalMetrics && q.Distribution.Key.Name == "" {
+		return errors.NewInvalidInputf(
+			errors.CodeInvalidInput,
+			"distribution key is required for %s",
+			q.Signal.StringValue(),
+		)
+	}
+
+	if q.Distribution.IsFixed() {
+		if q.Distribution.BucketCount != 0 {
+			return errors.NewInvalidInputf(
+				errors.CodeInvalidInput,
+				"distribution boundaries and bucket count are mutually exclusive",
+			)
+		}
+
+		if len(q.Distribution.Boundaries) < 2 {
+			return errors.NewInvalidInputf(
+				errors.CodeInvalidInput,
+				"distribution requires at least 2 boundaries, got %d",
+				len(q.Distribution.Boundaries),
+			)
+		}
+
+		for i := 1; i < len(q.Distribution.Boundaries); i++ {
+			if !(q.Distribution.Boundaries[i] > q.Distribution.Boundaries[i-1]) {
+				return errors.NewInvalidInputf(
+					errors.CodeInvalidInput,
+					"distribution boundaries must be strictly increasing",
+				)
+			}
+		}
+	}
+
+	if q.Distribution.BucketCount < 0 {
+		return errors.NewInvalidInputf(
+			errors.CodeInvalidInput,
+			"distribution bucket count must be positive",
+		)
+	}
+
+	if q.Distribution.NumBuckets() > MaxDistributionBucketCount {
+		return errors.NewInvalidInputf(
+			errors.CodeInvalidInput,
+			"distribution cannot have more than %d buckets",
+			MaxDistributionBucketCount,
+		)
+	}
+
+	return nil
+}
+
 func (q *QueryBuilderQuery[T]) validateSelectFields() error {
 	// isRoot and isEntryPoint are returned by the Metadata API, so if someone sends them, we have to reject the request.
 	for _, v := range q.SelectFields {
@@ -429,7 +521,7 @@ func (r *QueryRangeRequest) Validate() error {
 
 	// Validate request type
 	switch r.RequestType {
-	case RequestTypeRaw, RequestTypeRawStream, RequestTypeTimeSeries, RequestTypeScalar, RequestTypeTrace:
+	case RequestTypeRaw, RequestTypeRawStream, RequestTypeTimeSeries, RequestTypeScalar, RequestTypeTrace, RequestTypeDistribution:
 		// Valid request types
 	default:
 		return errors.NewInvalidInputf(
@@ -437,7 +529,7 @@ func (r *QueryRangeRequest) Validate() error {
 			"invalid request type: %s",
 			r.RequestType,
 		).WithAdditional(
-			"Valid request types are: raw, timeseries, scalar",
+			"Valid request types are: raw, timeseries, scalar, distribution",
 		)
 	}
 
@@ -459,7 +551,8 @@ func (r *QueryRangeRequest) validateAllQueriesNotDisabled() error {
 	allDisabled := true
 	for _, envelope := range r.CompositeQuery.Queries {
 		switch envelope.Type {
-		case QueryTypeBuilder, QueryTypeSubQuery:
+		// sub queries are not part of the response, only the queries referencing them are
+		case QueryTypeBuilder:
 			switch spec := envelope.Spec.(type) {
 			case QueryBuilderQuery[TraceAggregation]:
 				if !spec.Disabled {
@@ -494,6 +587,10 @@ func (r *QueryRangeRequest) validateAllQueriesNotDisabled() error {
 			if spec, ok := envelope.Spec.(ClickHouseQuery); ok && !spec.Disabled {
 				allDisabled = false
 			}
+		case QueryTypeAlertAnalytics:
+			if spec, ok := envelope.Spec.(AlertAnalyticsQuery); ok && !spec.Disabled {
+				allDisabled = false
+			}
 		}
 
 		// Early exit if we find at least one enabled query
@@ -524,14 +621,32 @@ func (r *QueryRangeRequest) validateCompositeQuery() error {
 	// Track query names for uniqueness (only for non-formula queries)
 	queryNames := make(map[string]bool)
 
+	// queries referenced by joins are run as scalar or raw queries depending on their aggregations
+	joinedQueries := make(map[string]bool)
+	for _, envelope := range r.CompositeQuery.Queries {
+		if spec, ok := envelope.Spec.(QueryBuilderJoin); ok && envelope.Type == QueryTypeJoin {
+			joinedQueries[spec.Left.Name] = true
+			joinedQueries[spec.Right.Name] = true
+		}
+	}
+
 	// Validate each query based on its type
 	for i, envelope := range r.CompositeQuery.Queries {
 		switch envelope.Type {
 		case QueryTypeBuilder, QueryTypeSubQuery:
+			// sub queries are always run as scalar queries
+			requestType := r.RequestType
+			if envelope.Type == QueryTypeSubQuery {
+				requestType = RequestTypeScalar
+			}
+			if joinedQueries[builderQueryName(envelope.Spec)] {
+				requestType = JoinedQueryRequestType(envelope.Spec)
+			}
+
 			// Validate based on the concrete type
 			switch spec := envelope.Spec.(type) {
 			case QueryBuilderQuery[TraceAggregation]:
-				if err := spec.Validate(r.RequestType); err != nil {
+				if err := spec.Validate(requestType); err != nil {
 					queryId := getQueryIdentifier(envelope, i)
 					return wrapValidationError(err, queryId, "invalid %s: %s")
 				}
@@ -547,7 +662,7 @@ func (r *QueryRangeRequest) validateCompositeQuery() error {
 					queryNames[spec.Name] = true
 				}
 			case QueryBuilderQuery[LogAggregation]:
-				if err := spec.Validate(r.RequestType); err != nil {
+				if err := spec.Validate(requestType); err != nil {
 					queryId := getQueryIdentifier(envelope, i)
 					return wrapValidationError(err, queryId, "invalid %s: %s")
 				}
@@ -563,7 +678,7 @@ func (r *QueryRangeRequest) validateCompositeQuery() error {
 					queryNames[spec.Name] = true
 				}
 			case QueryBuilderQuery[MetricAggregation]:
-				if err := spec.Validate(r.RequestType); err != nil {
+				if err := spec.Validate(requestType); err != nil {
 					queryId := getQueryIdentifier(envelope, i)
 					return wrapValidationError(err, queryId, "invalid %s: %s")
 				}
@@ -672,6 +787,28 @@ func (r *QueryRangeRequest) validateCompositeQuery() error {
 					queryId,
 				)
 			}
+		case QueryTypeAlertAnalytics:
+			spec, ok := envelope.Spec.(AlertAnalyticsQuery)
+			if !ok {
+				queryId := getQueryIdentifier(envelope, i)
+				return errors.NewInvalidInputf(
+					errors.CodeInvalidInput,
+					"invalid spec for %s",
+					queryId,
+				)
+			}
+			if err := spec.Validate(r.RequestType); err != nil {
+				queryId := getQueryIdentifier(envelope, i)
+				return wrapValidationError(err, queryId, "invalid %s: %s")
+			}
+			if queryNames[spec.Name] {
+				return errors.NewInvalidInputf(
+					errors.CodeInvalidInput,
+					"duplicate query name '%s'",
+					spec.Name,
+				)
+			}
+			queryNames[spec.Name] = true
 		default:
 			queryId := getQueryIdentifier(envelope, i)
 			return errors.NewInvalidInputf(
@@ -680,7 +817,336 @@ func (r *QueryRangeRequest) validateCompositeQuery() error {
 				envelope.Type,
 				queryId,
 			).WithAdditional(
-				"Valid query types are: builder_query, builder_formula, builder_join, promql, clickhouse_sql, trace_operator",
+				"Valid query types are: builder_query, builder_sub_query, builder_formula, builder_join, promql, clickhouse_sql, trace_operator, alert_analytics",
+			)
+		}
+	}
+
+	if err := r.validateSubQueries(); err != nil {
+		return err
+	}
+
+	if err := r.validateJoins(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SubQueryRefs returns the names of the queries referenced as `key IN @name` or `key NOT IN @name` in the filter
+// expression, in the order of their first reference.
+func SubQueryRefs(expression string) []string {
+	refs := []string{}
+	if expression == "" {
+		return refs
+	}
+
+	lexer := grammar.NewFilterQueryLexer(antlr.NewInputStream(expression))
+	prevIn := false
+	for {
+		tok := lexer.NextToken()
+		if tok.GetTokenType() == antlr.TokenEOF {
+			break
+		}
+
+		if prevIn && tok.GetTokenType() == grammar.FilterQueryLexerKEY {
+			if name, ok := strings.CutPrefix(tok.GetText(), SubQueryRefPrefix); ok && name != "" && !slices.Contains(refs, name) {
+				refs = append(refs, name)
+			}
+		}
+		prevIn = tok.GetTokenType() == grammar.FilterQueryLexerIN
+	}
+
+	return refs
+}
+
+// validateSubQueries validates that the builder queries only reference existing builder queries with a single group
+// by key, and that the references don't form a cycle.
+func (r *QueryRangeRequest) validateSubQueries() error {
+	refs := make(map[string][]string)
+	groupBys := make(map[string]int)
+	// the statements of the metric queries are not reduced when they are referenced, so their limit and order can't
+	// be applied
+	limitedMetrics := make(map[string]bool)
+
+	for _, envelope := range r.CompositeQuery.Queries {
+		if envelope.Type != QueryTypeBuilder && envelope.Type != QueryTypeSubQuery {
+			continue
+		}
+
+		var (
+			name    string
+			filter  *Filter
+			groupBy []GroupByKey
+		)
+		switch spec := envelope.Spec.(type) {
+		case QueryBuilderQuery[TraceAggregation]:
+			name, filter, groupBy = spec.Name, spec.Filter, spec.GroupBy
+		case QueryBuilderQuery[LogAggregation]:
+			name, filter, groupBy = spec.Name, spec.Filter, spec.GroupBy
+		case QueryBuilderQuery[MetricAggregation]:
+			name, filter, groupBy = spec.Name, spec.Filter, spec.GroupBy
+			limitedMetrics[name] = spec.Limit > 0 || len(spec.Order) > 0
+		}
+
+		if envelope.Type == QueryTypeSubQuery && name == "" {
+			return errors.NewInvalidInputf(
+				errors.CodeInvalidInput,
+				"name is required for sub queries",
+			)
+		}
+
+		groupBys[name] = len(groupBy)
+		if filter != nil {
+			refs[name] = SubQueryRefs(filter.Expression)
+		}
+	}
+
+	names := make([]string, 0, len(refs))
+	for name := range refs {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		for _, ref := range refs[name] {
+			groupBy, ok := groupBys[ref]
+			if !ok {
+				return errors.NewInvalidInputf(
+					errors.CodeInvalidInput,
+					"query '%s' references unknown query '%s'",
+					name,
+					ref,
+				).WithAdditional(
+					"Only builder queries and sub queries can be referenced with `IN @name`",
+				)
+			}
+
+			if groupBy != 1 {
+				return errors.NewInvalidInputf(
+					errors.CodeInvalidInput,
+					"query '%s' referenced by query '%s' must have exactly one group by key, got %d",
+					ref,
+					name,
+					groupBy,
+				)
+			}
+
+			if limitedMetrics[ref] {
+				return errors.NewInvalidInputf(
+					errors.CodeInvalidInput,
+					"metrics query '%s' referenced by query '%s' can't have a limit or an order",
+					ref,
+					name,
+				)
+			}
+		}
+	}
+
+	// detect cycles with a depth first search, a query is visiting while its references are being visited
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int)
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visiting:
+			return errors.NewInvalidInputf(
+				errors.CodeInvalidInput,
+				"query '%s' references itself through its sub queries",
+				name,
+			)
+		case visited:
+			return nil
+		}
+
+		state[name] = visiting
+		for _, ref := range refs[name] {
+			if err := visit(ref); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateJoins validates that the joins reference logs or traces builder queries which can be joined, and that the
+// request type matches the aggregations of the join.
+func (r *QueryRangeRequest) validateJoins() error {
+	specs := make(map[string]any)
+	for _, envelope := range r.CompositeQuery.Queries {
+		if envelope.Type == QueryTypeBuilder || envelope.Type == QueryTypeSubQuery {
+			specs[builderQueryName(envelope.Spec)] = envelope.Spec
+		}
+	}
+
+	for _, envelope := range r.CompositeQuery.Queries {
+		if envelope.Type != QueryTypeJoin {
+			continue
+		}
+
+		spec, ok := envelope.Spec.(QueryBuilderJoin)
+		if !ok {
+			continue
+		}
+
+		if spec.Name == "" {
+			return errors.NewInvalidInputf(
+				errors.CodeInvalidInput,
+				"name is required for join queries",
+			)
+		}
+
+		if spec.Left.Name == "" || spec.Right.Name == "" {
+			return errors.NewInvalidInputf(
+				errors.CodeInvalidInput,
+				"join '%s' must reference a left and a right query",
+				spec.Name,
+			)
+		}
+
+		if spec.Left.Name == spec.Right.Name {
+			return errors.NewInvalidInputf(
+				errors.CodeInvalidInput,
+				"join '%s' must reference two different queries, got '%s' twice",
+				spec.Name,
+				spec.Left.Name,
+			)
+		}
+
+		switch spec.Type {
+		case JoinTypeInner, JoinTypeLeft, JoinTypeRight, JoinTypeFull:
+			if strings.TrimSpace(spec.On) == "" {
+				return errors.NewInvalidInputf(
+					errors.CodeInvalidInput,
+					"on condition is required for %s join '%s'",
+					spec.Type.StringValue(),
+					spec.Name,
+				).WithAdditional(
+					"The on condition compares the columns of the joined queries, e.g. `A.trace_id = B.trace_id`",
+				)
+			}
+		case JoinTypeCross:
+			if strings.TrimSpace(spec.On) != "" {
+				return errors.NewInvalidInputf(
+					errors.CodeInvalidInput,
+					"cross join '%s' can't have an on condition",
+					spec.Name,
+				)
+			}
+		default:
+			return errors.NewInvalidInputf(
+				errors.CodeInvalidInput,
+				"invalid join type '%s' for join '%s'",
+				spec.Type.StringValue(),
+				spec.Name,
+			).WithAdditional(
+				"Valid join types are: inner, left, right, full, cross",
+			)
+		}
+
+		for _, ref := range []string{spec.Left.Name, spec.Right.Name} {
+			var (
+				aggregations int
+				selectFields int
+			)
+			switch refSpec := specs[ref].(type) {
+			case QueryBuilderQuery[TraceAggregation]:
+				aggregations, selectFields = len(refSpec.Aggregations), len(refSpec.SelectFields)
+			case QueryBuilderQuery[LogAggregation]:
+				aggregations, selectFields = len(refSpec.Aggregations), len(refSpec.SelectFields)
+			default:
+				return errors.NewInvalidInputf(
+					errors.CodeInvalidInput,
+					"query '%s' joined by '%s' must be a logs or traces builder query",
+					ref,
+					spec.Name,
+				)
+			}
+
+			if aggregations == 0 && selectFields == 0 {
+				return errors.NewInvalidInputf(
+					errors.CodeInvalidInput,
+					"query '%s' joined by '%s' must have select fields or aggregations",
+					ref,
+					spec.Name,
+				).WithAdditional(
+					"The select fields, or the group by keys and aggregations, are the columns available to the join",
+				)
+			}
+		}
+
+		aggregations, err := spec.JoinAggregations()
+		if err != nil {
+			return err
+		}
+
+		switch r.RequestType {
+		case RequestTypeRaw:
+			if len(aggregations) > 0 {
+				return errors.NewInvalidInputf(
+					errors.CodeInvalidInput,
+					"join '%s' with aggregations requires the scalar request type",
+					spec.Name,
+				)
+			}
+		case RequestTypeScalar:
+			if len(aggregations) == 0 {
+				return errors.NewInvalidInputf(
+					errors.CodeInvalidInput,
+					"at least one aggregation is required for join '%s'",
+					spec.Name,
+				)
+			}
+			for idx, aggregation := range aggregations {
+				if aggregation.Expression == "" {
+					return errors.NewInvalidInputf(
+						errors.CodeInvalidInput,
+						"expression is required for aggregation #%d of join '%s'",
+						idx+1,
+						spec.Name,
+					)
+				}
+			}
+		default:
+			return errors.NewInvalidInputf(
+				errors.CodeInvalidInput,
+				"join '%s' doesn't support the %s request type",
+				spec.Name,
+				r.RequestType.StringValue(),
+			).WithAdditional(
+				"Joins support the raw and scalar request types",
+			)
+		}
+
+		if spec.Limit < 0 || spec.Limit > MaxQueryLimit {
+			return errors.NewInvalidInputf(
+				errors.CodeInvalidInput,
+				"limit of join '%s' must be between 0 and %d, got %d",
+				spec.Name,
+				MaxQueryLimit,
+				spec.Limit,
+			)
+		}
+
+		if spec.Offset < 0 {
+			return errors.NewInvalidInputf(
+				errors.CodeInvalidInput,
+				"offset of join '%s' must be non-negative, got %d",
+				spec.Name,
+				spec.Offset,
 			)
 		}
 	}
@@ -711,6 +1177,10 @@ func (c *CompositeQuery) Validate(requestType RequestType) error {
 func validateQueryEnvelope(envelope QueryEnvelope, requestType RequestType) error {
 	switch envelope.Type {
 	case QueryTypeBuilder, QueryTypeSubQuery:
+		if envelope.Type == QueryTypeSubQuery {
+			requestType = RequestTypeScalar
+		}
+
 		switch spec := envelope.Spec.(type) {
 		case QueryBuilderQuery[TraceAggregation]:
 			return spec.Validate(requestType)
@@ -793,13 +1263,22 @@ func validateQueryEnvelope(envelope QueryEnvelope, requestType RequestType) erro
 			)
 		}
 		return nil
+	case QueryTypeAlertAnalytics:
+		spec, ok := envelope.Spec.(AlertAnalyticsQuery)
+		if !ok {
+			return errors.NewInvalidInputf(
+				errors.CodeInvalidInput,
+				"invalid alert analytics spec",
+			)
+		}
+		return spec.Validate(requestType)
 	default:
 		return errors.NewInvalidInputf(
 			errors.CodeInvalidInput,
 			"unknown query type: %s",
 			envelope.Type,
 		).WithAdditional(
-			"Valid query types are: builder_query, builder_sub_query, builder_formula, builder_join, promql, clickhouse_sql, trace_operator",
+			"Valid query types are: builder_query, builder_sub_query, builder_formula, builder_join, promql, clickhouse_sql, trace_operator, alert_analytics",
 		)
 	}
 }
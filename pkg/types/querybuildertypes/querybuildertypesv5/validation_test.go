@@ -1,10 +1,13 @@
 package querybuildertypesv5
 
 import (
+	"slices"
 	"strings"
 	"testing"
 
+	"github.com/SigNoz/signoz/pkg/types/metrictypes"
 	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
 )
 
 func contains(s, substr string) bool {
@@ -512,4 +515,517 @@ func TestQueryRangeRequest_ValidateOrderByForAggregation(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+func TestQueryBuilderQuery_ValidateDistribution(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   QueryBuilderQuery[LogAggregation]
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name: "fixed boundaries should pass",
+			query: QueryBuilderQuery[LogAggregation]{
+				Name:   "A",
+				Signal: telemetrytypes.SignalLogs,
+				Distribution: &Distribution{
+					Key:        telemetrytypes.TelemetryFieldKey{Name: "duration"},
+					Boundaries: []float64{0, 10, 100},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "bucket count should pass",
+			query: QueryBuilderQuery[LogAggregation]{
+				Name:   "A",
+				Signal: telemetrytypes.SignalLogs,
+				Distribution: &Distribution{
+					Key:         telemetrytypes.TelemetryFieldKey{Name: "duration"},
+					BucketCount: 10,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "missing distribution should fail",
+			query: QueryBuilderQuery[LogAggregation]{
+				Name:   "A",
+				Signal: telemetrytypes.SignalLogs,
+			},
+			wantErr: true,
+			errMsg:  "distribution is required",
+		},
+		{
+			name: "missing key should fail",
+			query: QueryBuilderQuery[LogAggregation]{
+				Name:         "A",
+				Signal:       telemetrytypes.SignalLogs,
+				Distribution: &Distribution{BucketCount: 10},
+			},
+			wantErr: true,
+			errMsg:  "distribution key is required",
+		},
+		{
+			name: "boundaries with bucket count should fail",
+			query: QueryBuilderQuery[LogAggregation]{
+				Name:   "A",
+				Signal: telemetrytypes.SignalLogs,
+				Distribution: &Distribution{
+					Key:         telemetrytypes.TelemetryFieldKey{Name: "duration"},
+					Boundaries:  []float64{0, 10},
+					BucketCount: 10,
+				},
+			},
+			wantErr: true,
+			errMsg:  "mutually exclusive",
+		},
+		{
+			name: "single boundary should fail",
+			query: QueryBuilderQuery[LogAggregation]{
+				Name:   "A",
+				Signal: telemetrytypes.SignalLogs,
+				Distribution: &Distribution{
+					Key:        telemetrytypes.TelemetryFieldKey{Name: "duration"},
+					Boundaries: []float64{10},
+				},
+			},
+			wantErr: true,
+			errMsg:  "at least 2 boundaries",
+		},
+		{
+			name: "unordered boundaries should fail",
+			query: QueryBuilderQuery[LogAggregation]{
+				Name:   "A",
+				Signal: telemetrytypes.SignalLogs,
+				Distribution: &Distribution{
+					Key:        telemetrytypes.TelemetryFieldKey{Name: "duration"},
+					Boundaries: []float64{0, 10, 10},
+				},
+			},
+			wantErr: true,
+			errMsg:  "strictly increasing",
+		},
+		{
+			name: "too many buckets should fail",
+			query: QueryBuilderQuery[LogAggregation]{
+				Name:   "A",
+				Signal: telemetrytypes.SignalLogs,
+				Distribution: &Distribution{
+					Key:         telemetrytypes.TelemetryFieldKey{Name: "duration"},
+					BucketCount: MaxDistributionBucketCount + 1,
+				},
+			},
+			wantErr: true,
+			errMsg:  "cannot have more than",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.query.Validate(RequestTypeDistribution)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("validateDistribution() expected error but got none")
+					return
+				}
+				if tt.errMsg != "" && !contains(err.Error(), tt.errMsg) {
+					t.Errorf("validateDistribution() error = %v, want to contain %v", err.Error(), tt.errMsg)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("validateDistribution() unexpected error = %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestSubQueryRefs(t *testing.T) {
+	tests := []struct {
+		expression string
+		want       []string
+	}{
+		{expression: "", want: []string{}},
+		{expression: "service.name = 'api'", want: []string{}},
+		{expression: "service.name IN @A", want: []string{"A"}},
+		{expression: "service.name NOT IN @B AND host.name IN @A OR k8s.pod.name IN @B", want: []string{"B", "A"}},
+		{expression: "service.name IN ('@A', 'b') AND email = '@C'", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			got := SubQueryRefs(tt.expression)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("SubQueryRefs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryRangeRequest_ValidateSubQueries(t *testing.T) {
+	logQuery := func(name string, expression string, groupBy ...string) QueryBuilderQuery[LogAggregation] {
+		query := QueryBuilderQuery[LogAggregation]{
+			Name:         name,
+			Signal:       telemetrytypes.SignalLogs,
+			Aggregations: []LogAggregation{{Expression: "count()"}},
+			Filter:       &Filter{Expression: expression},
+		}
+		for _, key := range groupBy {
+			query.GroupBy = append(query.GroupBy, GroupByKey{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: key}})
+		}
+		return query
+	}
+	metricQuery := func(name string, limit int, order []OrderBy) QueryBuilderQuery[MetricAggregation] {
+		return QueryBuilderQuery[MetricAggregation]{
+			Name:   name,
+			Signal: telemetrytypes.SignalMetrics,
+			Aggregations: []MetricAggregation{{
+				MetricName:       "http_requests_total",
+				Temporality:      metrictypes.Cumulative,
+				TimeAggregation:  metrictypes.TimeAggregationRate,
+				SpaceAggregation: metrictypes.SpaceAggregationSum,
+			}},
+			GroupBy: []GroupByKey{{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "service.name"}}},
+			Limit:   limit,
+			Order:   order,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		queries []QueryEnvelope
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name: "reference to a sub query should pass",
+			queries: []QueryEnvelope{
+				{Type: QueryTypeBuilder, Spec: logQuery("A", "service.name IN @B")},
+				{Type: QueryTypeSubQuery, Spec: logQuery("B", "severity_text = 'ERROR'", "service.name")},
+			},
+			wantErr: false,
+		},
+		{
+			name: "reference to a builder query should pass",
+			queries: []QueryEnvelope{
+				{Type: QueryTypeBuilder, Spec: logQuery("A", "service.name NOT IN @B")},
+				{Type: QueryTypeBuilder, Spec: logQuery("B", "", "service.name")},
+			},
+			wantErr: false,
+		},
+		{
+			name: "reference to an unknown query should fail",
+			queries: []QueryEnvelope{
+				{Type: QueryTypeBuilder, Spec: logQuery("A", "service.name IN @C")},
+			},
+			wantErr: true,
+			errMsg:  "query 'A' references unknown query 'C'",
+		},
+		{
+			name: "reference to a query without a single group by should fail",
+			queries: []QueryEnvelope{
+				{Type: QueryTypeBuilder, Spec: logQuery("A", "service.name IN @B")},
+				{Type: QueryTypeSubQuery, Spec: logQuery("B", "", "service.name", "host.name")},
+			},
+			wantErr: true,
+			errMsg:  "must have exactly one group by key, got 2",
+		},
+		{
+			name: "reference to a metrics query with a limit should fail",
+			queries: []QueryEnvelope{
+				{Type: QueryTypeBuilder, Spec: logQuery("A", "service.name IN @B")},
+				{Type: QueryTypeSubQuery, Spec: metricQuery("B", 5, nil)},
+			},
+			wantErr: true,
+			errMsg:  "metrics query 'B' referenced by query 'A' can't have a limit or an order",
+		},
+		{
+			name: "reference to a metrics query with an order should fail",
+			queries: []QueryEnvelope{
+				{Type: QueryTypeBuilder, Spec: logQuery("A", "service.name IN @B")},
+				{Type: QueryTypeSubQuery, Spec: metricQuery("B", 0, []OrderBy{{Key: OrderByKey{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "service.name"}}, Direction: OrderDirectionDesc}})},
+			},
+			wantErr: true,
+			errMsg:  "can't have a limit or an order",
+		},
+		{
+			name: "reference to a metrics query without a limit and an order should pass",
+			queries: []QueryEnvelope{
+				{Type: QueryTypeBuilder, Spec: logQuery("A", "service.name IN @B")},
+				{Type: QueryTypeSubQuery, Spec: metricQuery("B", 0, nil)},
+			},
+			wantErr: false,
+		},
+		{
+			name: "cyclic references should fail",
+			queries: []QueryEnvelope{
+				{Type: QueryTypeBuilder, Spec: logQuery("A", "service.name IN @B")},
+				{Type: QueryTypeSubQuery, Spec: logQuery("B", "service.name IN @C", "service.name")},
+				{Type: QueryTypeSubQuery, Spec: logQuery("C", "service.name IN @B", "service.name")},
+			},
+			wantErr: true,
+			errMsg:  "references itself through its sub queries",
+		},
+		{
+			name: "only sub queries should fail",
+			queries: []QueryEnvelope{
+				{Type: QueryTypeSubQuery, Spec: logQuery("B", "", "service.name")},
+			},
+			wantErr: true,
+			errMsg:  "all queries are disabled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := QueryRangeRequest{
+				Start:          1640995200000,
+				End:            1640998800000,
+				RequestType:    RequestTypeScalar,
+				CompositeQuery: CompositeQuery{Queries: tt.queries},
+			}
+
+			err := request.Validate()
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Validate() expected error but got none")
+					return
+				}
+				if tt.errMsg != "" && !contains(err.Error(), tt.errMsg) {
+					t.Errorf("Validate() error = %v, want to contain %v", err.Error(), tt.errMsg)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("Validate() unexpected error = %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestQueryRangeRequest_ValidateJoins(t *testing.T) {
+	logQuery := QueryBuilderQuery[LogAggregation]{
+		Name:         "A",
+		Signal:       telemetrytypes.SignalLogs,
+		SelectFields: []telemetrytypes.TelemetryFieldKey{{Name: "trace_id"}},
+	}
+	traceQuery := QueryBuilderQuery[TraceAggregation]{
+		Name:         "B",
+		Signal:       telemetrytypes.SignalTraces,
+		Aggregations: []TraceAggregation{{Expression: "count()"}},
+		GroupBy:      []GroupByKey{{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "trace_id"}}},
+	}
+	metricQuery := QueryBuilderQuery[MetricAggregation]{
+		Name:   "B",
+		Signal: telemetrytypes.SignalMetrics,
+		Aggregations: []MetricAggregation{{
+			MetricName:       "calls",
+			TimeAggregation:  metrictypes.TimeAggregationRate,
+			SpaceAggregation: metrictypes.SpaceAggregationSum,
+		}},
+	}
+
+	tests := []struct {
+		name        string
+		requestType RequestType
+		queries     []QueryEnvelope
+		wantErr     bool
+		errMsg      string
+	}{
+		{
+			name:        "list join of logs and traces should pass",
+			requestType: RequestTypeRaw,
+			queries: []QueryEnvelope{
+				{Type: QueryTypeBuilder, Spec: logQuery},
+				{Type: QueryTypeBuilder, Spec: traceQuery},
+				{Type: QueryTypeJoin, Spec: QueryBuilderJoin{Name: "C", Left: QueryRef{Name: "A"}, Right: QueryRef{Name: "B"}, Type: JoinTypeInner, On: "A.trace_id = B.trace_id"}},
+			},
+			wantErr: false,
+		},
+		{
+			name:        "scalar join with aggregations should pass",
+			requestType: RequestTypeScalar,
+			queries: []QueryEnvelope{
+				{Type: QueryTypeBuilder, Spec: logQuery},
+				{Type: QueryTypeBuilder, Spec: traceQuery},
+				{Type: QueryTypeJoin, Spec: QueryBuilderJoin{Name: "C", Left: QueryRef{Name: "A"}, Right: QueryRef{Name: "B"}, Type: JoinTypeLeft, On: "A.trace_id = B.trace_id", Aggregations: []any{JoinAggregation{Expression: "count()"}}}},
+			},
+			wantErr: false,
+		},
+		{
+			name:        "join without on condition should fail",
+			requestType: RequestTypeRaw,
+			queries: []QueryEnvelope{
+				{Type: QueryTypeBuilder, Spec: logQuery},
+				{Type: QueryTypeBuilder, Spec: traceQuery},
+				{Type: QueryTypeJoin, Spec: QueryBuilderJoin{Name: "C", Left: QueryRef{Name: "A"}, Right: QueryRef{Name: "B"}, Type: JoinTypeInner}},
+			},
+			wantErr: true,
+			errMsg:  "on condition is required for inner join 'C'",
+		},
+		{
+			name:        "cross join with on condition should fail",
+			requestType: RequestTypeRaw,
+			queries: []QueryEnvelope{
+				{Type: QueryTypeBuilder, Spec: logQuery},
+				{Type: QueryTypeBuilder, Spec: traceQuery},
+				{Type: QueryTypeJoin, Spec: QueryBuilderJoin{Name: "C", Left: QueryRef{Name: "A"}, Right: QueryRef{Name: "B"}, Type: JoinTypeCross, On: "A.trace_id = B.trace_id"}},
+			},
+			wantErr: true,
+			errMsg:  "cross join 'C' can't have an on condition",
+		},
+		{
+			name:        "join of the same query should fail",
+			requestType: RequestTypeRaw,
+			queries: []QueryEnvelope{
+				{Type: QueryTypeBuilder, Spec: logQuery},
+				{Type: QueryTypeJoin, Spec: QueryBuilderJoin{Name: "C", Left: QueryRef{Name: "A"}, Right: QueryRef{Name: "A"}, Type: JoinTypeInner, On: "A.trace_id = A.trace_id"}},
+			},
+			wantErr: true,
+			errMsg:  "must reference two different queries",
+		},
+		{
+			name:        "join of a metrics query should fail",
+			requestType: RequestTypeRaw,
+			queries: []QueryEnvelope{
+				{Type: QueryTypeBuilder, Spec: logQuery},
+				{Type: QueryTypeBuilder, Spec: metricQuery},
+				{Type: QueryTypeJoin, Spec: QueryBuilderJoin{Name: "C", Left: QueryRef{Name: "A"}, Right: QueryRef{Name: "B"}, Type: JoinTypeInner, On: "A.trace_id = B.trace_id"}},
+			},
+			wantErr: true,
+			errMsg:  "query 'B' joined by 'C' must be a logs or traces builder query",
+		},
+		{
+			name:        "list join with aggregations should fail",
+			requestType: RequestTypeRaw,
+			queries: []QueryEnvelope{
+				{Type: QueryTypeBuilder, Spec: logQuery},
+				{Type: QueryTypeBuilder, Spec: traceQuery},
+				{Type: QueryTypeJoin, Spec: QueryBuilderJoin{Name: "C", Left: QueryRef{Name: "A"}, Right: QueryRef{Name: "B"}, Type: JoinTypeInner, On: "A.trace_id = B.trace_id", Aggregations: []any{JoinAggregation{Expression: "count()"}}}},
+			},
+			wantErr: true,
+			errMsg:  "join 'C' with aggregations requires the scalar request type",
+		},
+		{
+			name:        "join with negative offset should fail",
+			requestType: RequestTypeRaw,
+			queries: []QueryEnvelope{
+				{Type: QueryTypeBuilder, Spec: logQuery},
+				{Type: QueryTypeBuilder, Spec: traceQuery},
+				{Type: QueryTypeJoin, Spec: QueryBuilderJoin{Name: "C", Left: QueryRef{Name: "A"}, Right: QueryRef{Name: "B"}, Type: JoinTypeInner, On: "A.trace_id = B.trace_id", Offset: -1}},
+			},
+			wantErr: true,
+			errMsg:  "offset of join 'C' must be non-negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := QueryRangeRequest{
+				Start:          1640995200000,
+				End:            1640998800000,
+				RequestType:    tt.requestType,
+				CompositeQuery: CompositeQuery{Queries: tt.queries},
+			}
+
+			err := request.Validate()
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Validate() expected error but got none")
+					return
+				}
+				if tt.errMsg != "" && !contains(err.Error(), tt.errMsg) {
+					t.Errorf("Validate() error = %v, want to contain %v", err.Error(), tt.errMsg)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("Validate() unexpected error = %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestQueryRangeRequest_ValidateAlertAnalytics(t *testing.T) {
+	triggers := []AlertAnalyticsAggregation{{Metric: AlertAnalyticsMetricTriggers}}
+	team := []GroupByKey{{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "team"}}}
+
+	tests := []struct {
+		name        string
+		requestType RequestType
+		query       AlertAnalyticsQuery
+		wantErr     bool
+		errMsg      string
+	}{
+		{
+			name:        "timeline should pass",
+			requestType: RequestTypeRaw,
+			query:       AlertAnalyticsQuery{Name: "A", Filter: &Filter{Expression: "rule_id = 'r1'"}, Limit: 10},
+		},
+		{
+			name:        "response times by team should pass",
+			requestType: RequestTypeScalar,
+			query:       AlertAnalyticsQuery{Name: "A", Aggregations: []AlertAnalyticsAggregation{{Metric: AlertAnalyticsMetricMTTR}, {Metric: AlertAnalyticsMetricMTTA}}, GroupBy: team},
+		},
+		{
+			name:        "triggers over time should pass",
+			requestType: RequestTypeTimeSeries,
+			query:       AlertAnalyticsQuery{Name: "A", Aggregations: triggers, GroupBy: team, Limit: 5},
+		},
+		{
+			name:        "aggregations in a timeline should fail",
+			requestType: RequestTypeRaw,
+			query:       AlertAnalyticsQuery{Name: "A", Aggregations: triggers},
+			wantErr:     true,
+			errMsg:      "aggregations, group by and having are not supported for the raw alert analytics queries",
+		},
+		{
+			name:        "no aggregation should fail",
+			requestType: RequestTypeScalar,
+			query:       AlertAnalyticsQuery{Name: "A", GroupBy: team},
+			wantErr:     true,
+			errMsg:      "at least one aggregation is required",
+		},
+		{
+			name:        "unknown metric should fail",
+			requestType: RequestTypeScalar,
+			query:       AlertAnalyticsQuery{Name: "A", Aggregations: []AlertAnalyticsAggregation{{Metric: AlertAnalyticsMetric{valuer.NewString("p99")}}}},
+			wantErr:     true,
+			errMsg:      "invalid metric \"p99\" for aggregation 1",
+		},
+		{
+			name:        "distribution should fail",
+			requestType: RequestTypeDistribution,
+			query:       AlertAnalyticsQuery{Name: "A", Aggregations: triggers},
+			wantErr:     true,
+			errMsg:      "request type distribution is not supported for the alert analytics queries",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := QueryRangeRequest{
+				Start:          1640995200000,
+				End:            1640998800000,
+				RequestType:    tt.requestType,
+				CompositeQuery: CompositeQuery{Queries: []QueryEnvelope{{Type: QueryTypeAlertAnalytics, Spec: tt.query}}},
+			}
+
+			err := request.Validate()
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Validate() expected error but got none")
+					return
+				}
+				if tt.errMsg != "" && !contains(err.Error(), tt.errMsg) {
+					t.Errorf("Validate() error = %v, want to contain %v", err.Error(), tt.errMsg)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("Validate() unexpected error = %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,277 @@
+package rawdataexporttypes
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/uptrace/bun"
+)
+
+var (
+	ErrCodeExportJobNotFound    = errors.MustNewCode("export_job_not_found")
+	ErrCodeExportJobInvalid     = errors.MustNewCode("export_job_invalid")
+	ErrCodeExportJobNotRunnable = errors.MustNewCode("export_job_not_runnable")
+)
+
+// Formats are the file formats in which the chunks of an export job can be written.
+var Formats = []string{"csv", "jsonl", "parquet", "arrow"}
+
+type JobStatus struct {
+	valuer.String
+}
+
+var (
+	// The job has been created and is waiting to be picked up by a worker.
+	JobStatusPending = JobStatus{valuer.NewString("pending")}
+	// The job is being exported. Running jobs are resumed from their cursor after a restart.
+	JobStatusRunning = JobStatus{valuer.NewString("running")}
+	// All the rows have been exported.
+	JobStatusSucceeded = JobStatus{valuer.NewString("succeeded")}
+	// The export failed, see the message of the job.
+	JobStatusFailed = JobStatus{valuer.NewString("failed")}
+	// The job was cancelled by a user.
+	JobStatusCancelled = JobStatus{valuer.NewString("cancelled")}
+)
+
+func (JobStatus) Enum() []any {
+	return []any{
+		JobStatusPending,
+		JobStatusRunning,
+		JobStatusSucceeded,
+		JobStatusFailed,
+		JobStatusCancelled,
+	}
+}
+
+// IsTerminal returns true if the job will not make any more progress.
+func (status JobStatus) IsTerminal() bool {
+	return status == JobStatusSucceeded || status == JobStatusFailed || status == JobStatusCancelled
+}
+
+type StorableJob struct {
+	bun.BaseModel `bun:"table:raw_data_export_job,alias:raw_data_export_job"`
+
+	types.Identifiable
+	types.TimeAuditable
+	types.UserAuditable
+	OrgID   valuer.UUID `bun:"org_id,type:text,notnull"`
+	Status  JobStatus   `bun:"status,type:text,notnull"`
+	Signal  string      `bun:"signal,type:text,notnull"`
+	Format  string      `bun:"format,type:text,notnull"`
+	Request string      `bun:"request,type:text,notnull"`
+	Cursor  int         `bun:"cursor,notnull"`
+	Chunks  int         `bun:"chunks,notnull"`
+	Bytes   int64       `bun:"bytes,notnull"`
+	Message string      `bun:"message,type:text"`
+}
+
+type Job struct {
+	types.Identifiable
+	types.TimeAuditable
+	types.UserAuditable
+
+	OrgID   valuer.UUID                `json:"orgId"`
+	Status  JobStatus                  `json:"status"`
+	Signal  telemetrytypes.Signal      `json:"signal"`
+	Format  string                     `json:"format"`
+	Request *qbtypes.QueryRangeRequest `json:"request"`
+	// Cursor is the number of rows which have been exported so far. The next chunk starts at this offset.
+	Cursor  int    `json:"cursor"`
+	Chunks  int    `json:"chunks"`
+	Bytes   int64  `json:"bytes"`
+	Message string `json:"message,omitempty"`
+}
+
+type PostableJob struct {
+	Format  string                    `json:"format"`
+	Request qbtypes.QueryRangeRequest `json:"request"`
+}
+
+// NewJob creates a pending export job for the request. A request without a limit exports up to maxRowCount rows.
+func NewJob(orgID valuer.UUID, createdBy string, format string, request *qbtypes.QueryRangeRequest, maxRowCount int) (*Job, error) {
+	if !slices.Contains(Formats, format) {
+		return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeExportJobInvalid, "invalid format %q, must be one of %s", format, strings.Join(Formats, ", "))
+	}
+
+	spec, err := GetJobQuerySpec(request)
+	if err != nil {
+		return nil, err
+	}
+
+	signal, limit := signalAndLimitOf(spec)
+	if limit < 0 {
+		return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeExportJobInvalid, "limit must not be negative")
+	}
+
+	if limit > maxRowCount {
+		return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeExportJobInvalid, "limit cannot be more than %d", maxRowCount)
+	}
+
+	if limit == 0 {
+		request = withLimitAndOffset(request, maxRowCount, 0)
+	}
+
+	now := time.Now()
+	return &Job{
+		Identifiable: types.Identifiable{
+			ID: valuer.GenerateUUID(),
+		},
+		TimeAuditable: types.TimeAuditable{
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		UserAuditable: types.UserAuditable{
+			CreatedBy: createdBy,
+			UpdatedBy: createdBy,
+		},
+		OrgID:   orgID,
+		Status:  JobStatusPending,
+		Signal:  signal,
+		Format:  format,
+		Request: request,
+	}, nil
+}
+
+// GetJobQuerySpec returns the single raw builder query of an export job request.
+func GetJobQuerySpec(request *qbtypes.QueryRangeRequest) (any, error) {
+	if request.RequestType != qbtypes.RequestTypeRaw {
+		return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeExportJobInvalid, "export jobs only support the %q request type", qbtypes.RequestTypeRaw.StringValue())
+	}
+
+	if len(request.CompositeQuery.Queries) != 1 {
+		return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeExportJobInvalid, "export jobs must have exactly one query, got %d", len(request.CompositeQuery.Queries))
+	}
+
+	envelope := request.CompositeQuery.Queries[0]
+	if envelope.Type != qbtypes.QueryTypeBuilder {
+		return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeExportJobInvalid, "export jobs only support builder queries")
+	}
+
+	switch spec := envelope.Spec.(type) {
+	case qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]:
+		return spec, nil
+	case qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]:
+		return spec, nil
+	default:
+		return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeExportJobInvalid, "export jobs only support logs and traces")
+	}
+}
+
+func signalAndLimitOf(spec any) (telemetrytypes.Signal, int) {
+	switch spec := spec.(type) {
+	case qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]:
+		return telemetrytypes.SignalLogs, spec.Limit
+	case qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]:
+		return telemetrytypes.SignalTraces, spec.Limit
+	default:
+		return telemetrytypes.SignalUnspecified, 0
+	}
+}
+
+func withLimitAndOffset(request *qbtypes.QueryRangeRequest, limit int, offset int) *qbtypes.QueryRangeRequest {
+	copied := *request
+	envelope := request.CompositeQuery.Queries[0]
+
+	switch spec := envelope.Spec.(type) {
+	case qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]:
+		spec.Limit, spec.Offset = limit, offset
+		envelope.Spec = spec
+	case qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]:
+		spec.Limit, spec.Offset = limit, offset
+		envelope.Spec = spec
+	}
+
+	copied.CompositeQuery.Queries = []qbtypes.QueryEnvelope{envelope}
+	return &copied
+}
+
+// Limit returns the total number of rows the job exports.
+func (job *Job) Limit() int {
+	spec, err := GetJobQuerySpec(job.Request)
+	if err != nil {
+		return 0
+	}
+
+	_, limit := signalAndLimitOf(spec)
+	return limit
+}
+
+// ChunkRequest returns a copy of the request of the job which queries at most limit rows starting at offset.
+func (job *Job) ChunkRequest(offset int, limit int) *qbtypes.QueryRangeRequest {
+	return withLimitAndOffset(job.Request, limit, offset)
+}
+
+// ChunkKey returns the object store key of the chunk at the zero based index.
+func (job *Job) ChunkKey(index int) string {
+	return fmt.Sprintf("%s%06d.%s", job.ChunkPrefix(), index, job.Format)
+}
+
+// ChunkPrefix returns the object store prefix under which all the chunks of the job are stored.
+func (job *Job) ChunkPrefix() string {
+	return fmt.Sprintf("rawdataexport/%s/", job.ID.StringValue())
+}
+
+func NewStorableJobFromJob(job *Job) (*StorableJob, error) {
+	request, err := json.Marshal(job.Request)
+	if err != nil {
+		return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to marshal export job request")
+	}
+
+	return &StorableJob{
+		Identifiable:  job.Identifiable,
+		TimeAuditable: job.TimeAuditable,
+		UserAuditable: job.UserAuditable,
+		OrgID:         job.OrgID,
+		Status:        job.Status,
+		Signal:        job.Signal.StringValue(),
+		Format:        job.Format,
+		Request:       string(request),
+		Cursor:        job.Cursor,
+		Chunks:        job.Chunks,
+		Bytes:         job.Bytes,
+		Message:       job.Message,
+	}, nil
+}
+
+func NewJobFromStorableJob(storable *StorableJob) (*Job, error) {
+	request := new(qbtypes.QueryRangeRequest)
+	if err := json.Unmarshal([]byte(storable.Request), request); err != nil {
+		return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to unmarshal request of export job %s", storable.ID.StringValue())
+	}
+
+	return &Job{
+		Identifiable:  storable.Identifiable,
+		TimeAuditable: storable.TimeAuditable,
+		UserAuditable: storable.UserAuditable,
+		OrgID:         storable.OrgID,
+		Status:        storable.Status,
+		Signal:        telemetrytypes.Signal{String: valuer.NewString(storable.Signal)},
+		Format:        storable.Format,
+		Request:       request,
+		Cursor:        storable.Cursor,
+		Chunks:        storable.Chunks,
+		Bytes:         storable.Bytes,
+		Message:       storable.Message,
+	}, nil
+}
+
+func NewJobsFromStorableJobs(storables []*StorableJob) ([]*Job, error) {
+	jobs := make([]*Job, 0, len(storables))
+	for _, storable := range storables {
+		job, err := NewJobFromStorableJob(storable)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
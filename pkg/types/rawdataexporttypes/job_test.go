@@ -0,0 +1,110 @@
+package rawdataexporttypes
+
+import (
+	"encoding/json"
+	"testing"
+
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRequest(spec any) *qbtypes.QueryRangeRequest {
+	return &qbtypes.QueryRangeRequest{
+		Start:       1693612800000,
+		End:         1693699199000,
+		RequestType: qbtypes.RequestTypeRaw,
+		CompositeQuery: qbtypes.CompositeQuery{
+			Queries: []qbtypes.QueryEnvelope{{Type: qbtypes.QueryTypeBuilder, Spec: spec}},
+		},
+	}
+}
+
+func TestNewJob(t *testing.T) {
+	testCases := []struct {
+		name          string
+		format        string
+		request       *qbtypes.QueryRangeRequest
+		expectedLimit int
+		expectedErr   bool
+	}{
+		{
+			name:          "DefaultLimit",
+			format:        "csv",
+			request:       newTestRequest(qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{Name: "A", Signal: telemetrytypes.SignalLogs}),
+			expectedLimit: 100,
+		},
+		{
+			name:          "Traces",
+			format:        "parquet",
+			request:       newTestRequest(qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]{Name: "A", Signal: telemetrytypes.SignalTraces, Limit: 10}),
+			expectedLimit: 10,
+		},
+		{
+			name:        "LimitTooLarge",
+			format:      "csv",
+			request:     newTestRequest(qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{Name: "A", Signal: telemetrytypes.SignalLogs, Limit: 101}),
+			expectedErr: true,
+		},
+		{
+			name:        "InvalidFormat",
+			format:      "xml",
+			request:     newTestRequest(qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{Name: "A", Signal: telemetrytypes.SignalLogs}),
+			expectedErr: true,
+		},
+		{
+			name:        "Metrics",
+			format:      "csv",
+			request:     newTestRequest(qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]{Name: "A", Signal: telemetrytypes.SignalMetrics}),
+			expectedErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			job, err := NewJob(valuer.GenerateUUID(), "user@signoz.io", testCase.format, testCase.request, 100)
+			if testCase.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, JobStatusPending, job.Status)
+			assert.Equal(t, testCase.expectedLimit, job.Limit())
+		})
+	}
+}
+
+func TestJobChunkRequest(t *testing.T) {
+	job, err := NewJob(valuer.GenerateUUID(), "user@signoz.io", "jsonl", newTestRequest(qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{Name: "A", Signal: telemetrytypes.SignalLogs, Limit: 50}), 100)
+	require.NoError(t, err)
+
+	request := job.ChunkRequest(20, 10)
+	spec := request.CompositeQuery.Queries[0].Spec.(qbtypes.QueryBuilderQuery[qbtypes.LogAggregation])
+	assert.Equal(t, 10, spec.Limit)
+	assert.Equal(t, 20, spec.Offset)
+
+	// the request of the job is left untouched
+	assert.Equal(t, 50, job.Limit())
+}
+
+func TestJobStorableRoundTrip(t *testing.T) {
+	job, err := NewJob(valuer.GenerateUUID(), "user@signoz.io", "arrow", newTestRequest(qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]{Name: "A", Signal: telemetrytypes.SignalTraces, Limit: 50}), 100)
+	require.NoError(t, err)
+	job.Cursor, job.Chunks, job.Bytes = 40, 2, 1024
+
+	storable, err := NewStorableJobFromJob(job)
+	require.NoError(t, err)
+
+	actual, err := NewJobFromStorableJob(storable)
+	require.NoError(t, err)
+
+	expected, err := json.Marshal(job)
+	require.NoError(t, err)
+	got, err := json.Marshal(actual)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expected), string(got))
+	assert.Equal(t, "rawdataexport/"+job.ID.StringValue()+"/000001.arrow", actual.ChunkKey(1))
+}
@@ -0,0 +1,30 @@
+package rawdataexporttypes
+
+import (
+	"context"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type Store interface {
+	Create(context.Context, *StorableJob) error
+
+	Get(context.Context, valuer.UUID, valuer.UUID) (*StorableJob, error)
+
+	List(context.Context, valuer.UUID) ([]*StorableJob, error)
+
+	ListByStatus(context.Context, valuer.UUID, ...JobStatus) ([]*StorableJob, error)
+
+	// ListFinishedBefore lists the jobs of the org which reached a terminal status before the given time.
+	ListFinishedBefore(context.Context, valuer.UUID, time.Time) ([]*StorableJob, error)
+
+	// UpdateProgress persists the cursor of a running job. It returns false if the job is no longer running.
+	UpdateProgress(context.Context, *StorableJob) (bool, error)
+
+	// UpdateStatus moves the job to the given status if it is currently in one of the from statuses.
+	// It returns false if the job was not in any of the from statuses.
+	UpdateStatus(ctx context.Context, orgID valuer.UUID, id valuer.UUID, from []JobStatus, to JobStatus, message string) (bool, error)
+
+	Delete(context.Context, valuer.UUID, valuer.UUID) error
+}
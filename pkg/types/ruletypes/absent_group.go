@@ -0,0 +1,77 @@
+package ruletypes
+
+import (
+	"context"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/uptrace/bun"
+)
+
+const (
+	// DefaultAbsentGroupsLookback is how long an absent group is tracked when the rule doesn't set a lookback
+	DefaultAbsentGroupsLookback = 24 * time.Hour
+)
+
+// AbsentGroupsCondition configures the detection of the groups of a rule that stop reporting data, e.g. a service
+// which sends no spans while the other services still do. Every absent group raises its own alert.
+type AbsentGroupsCondition struct {
+	Enabled bool `json:"enabled"`
+	// For is how long a group has to be absent before its alert fires
+	For valuer.TextDuration `json:"for"`
+	// Lookback is how long a group is remembered after it was last seen, the group is retired afterwards
+	Lookback valuer.TextDuration `json:"lookback,omitempty"`
+}
+
+// IsEnabled reports whether the absence of the groups is tracked.
+func (c *AbsentGroupsCondition) IsEnabled() bool {
+	return c != nil && c.Enabled
+}
+
+// GetLookback returns the lookback of the condition, or the default one when it isn't set.
+func (c *AbsentGroupsCondition) GetLookback() time.Duration {
+	if c == nil || c.Lookback.IsZero() {
+		return DefaultAbsentGroupsLookback
+	}
+	return c.Lookback.Duration()
+}
+
+func (c *AbsentGroupsCondition) Validate() error {
+	if !c.IsEnabled() {
+		return nil
+	}
+
+	if c.For.Duration() <= 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "absent groups duration must be positive")
+	}
+
+	if c.GetLookback() <= c.For.Duration() {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "absent groups lookback %s must be greater than the duration %s", c.GetLookback(), c.For.Duration())
+	}
+
+	return nil
+}
+
+// AbsentGroup is a group of a rule, identified by the fingerprint of its labels, and the last time it reported data.
+type AbsentGroup struct {
+	bun.BaseModel `bun:"table:rule_absent_group"`
+	types.Identifiable
+	OrgID       string            `bun:"org_id,type:text,notnull" json:"-"`
+	RuleID      string            `bun:"rule_id,type:text,notnull" json:"ruleId"`
+	Fingerprint string            `bun:"fingerprint,type:text,notnull" json:"fingerprint"`
+	Labels      map[string]string `bun:"labels,type:text,notnull" json:"labels"`
+	LastSeen    time.Time         `bun:"last_seen,notnull" json:"lastSeen"`
+}
+
+type AbsentGroupStore interface {
+	// ListAbsentGroups returns the groups tracked for the rule
+	ListAbsentGroups(ctx context.Context, ruleID string) ([]*AbsentGroup, error)
+
+	// UpsertAbsentGroups creates the groups or updates the labels and the last time they were seen
+	UpsertAbsentGroups(ctx context.Context, groups []*AbsentGroup) error
+
+	// DeleteAbsentGroups retires the groups of the rule with the given fingerprints, or all of them if none is given
+	DeleteAbsentGroups(ctx context.Context, ruleID string, fingerprints []string) error
+}
@@ -0,0 +1,75 @@
+package ruletypes
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAbsentGroupsCondition_Validate(t *testing.T) {
+	cases := []struct {
+		name      string
+		condition *AbsentGroupsCondition
+		wantErr   bool
+	}{
+		{name: "nil", condition: nil},
+		{name: "disabled", condition: &AbsentGroupsCondition{}},
+		{name: "default lookback", condition: &AbsentGroupsCondition{Enabled: true, For: valuer.MustParseTextDuration("10m")}},
+		{name: "missing duration", condition: &AbsentGroupsCondition{Enabled: true}, wantErr: true},
+		{name: "lookback shorter than duration", condition: &AbsentGroupsCondition{Enabled: true, For: valuer.MustParseTextDuration("2h"), Lookback: valuer.MustParseTextDuration("1h")}, wantErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.condition.Validate()
+			if c.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	assert.Equal(t, DefaultAbsentGroupsLookback, (&AbsentGroupsCondition{}).GetLookback())
+	assert.Equal(t, time.Hour, (&AbsentGroupsCondition{Lookback: valuer.MustParseTextDuration("1h")}).GetLookback())
+}
+
+func TestPostableRule_AbsentGroups(t *testing.T) {
+	content := `{
+		"alert": "service stopped reporting",
+		"condition": {
+			"compositeQuery": {
+				"queryType": "promql",
+				"promQueries": {"A": {"query": "sum by (service_name) (rate(calls_total[5m]))"}}
+			},
+			"op": "1",
+			"target": 0,
+			"matchType": "1",
+			"absentGroups": {"enabled": true, "for": "15m", "lookback": "6h"}
+		}
+	}`
+
+	var rule PostableRule
+	require.NoError(t, json.Unmarshal([]byte(content), &rule))
+	assert.True(t, rule.RuleCondition.AbsentGroups.IsEnabled())
+	assert.Equal(t, 6*time.Hour, rule.RuleCondition.AbsentGroups.GetLookback())
+
+	invalid := `{
+		"alert": "service stopped reporting",
+		"condition": {
+			"compositeQuery": {
+				"queryType": "promql",
+				"promQueries": {"A": {"query": "sum by (service_name) (rate(calls_total[5m]))"}}
+			},
+			"op": "1",
+			"target": 0,
+			"matchType": "1",
+			"absentGroups": {"enabled": true, "for": "15m", "lookback": "10m"}
+		}
+	}`
+	require.Error(t, json.Unmarshal([]byte(invalid), &rule))
+}
@@ -29,6 +29,9 @@ const (
 	RuleTypeThreshold = "threshold_rule"
 	RuleTypeProm      = "promql_rule"
 	RuleTypeAnomaly   = "anomaly_rule"
+	RuleTypeComposite = "composite_rule"
+	RuleTypeBurnRate  = "burn_rate_rule"
+	RuleTypeRecording = "recording_rule"
 )
 
 type RuleHealth string
@@ -106,19 +109,23 @@ const (
 )
 
 type RuleCondition struct {
-	CompositeQuery    *v3.CompositeQuery `json:"compositeQuery,omitempty"`
-	CompareOp         CompareOp          `json:"op,omitempty"`
-	Target            *float64           `json:"target,omitempty"`
-	AlertOnAbsent     bool               `json:"alertOnAbsent,omitempty"`
-	AbsentFor         uint64             `json:"absentFor,omitempty"`
-	MatchType         MatchType          `json:"matchType,omitempty"`
-	TargetUnit        string             `json:"targetUnit,omitempty"`
-	Algorithm         string             `json:"algorithm,omitempty"`
-	Seasonality       string             `json:"seasonality,omitempty"`
-	SelectedQuery     string             `json:"selectedQueryName,omitempty"`
-	RequireMinPoints  bool               `json:"requireMinPoints,omitempty"`
-	RequiredNumPoints int                `json:"requiredNumPoints,omitempty"`
-	Thresholds        *RuleThresholdData `json:"thresholds,omitempty"`
+	CompositeQuery    *v3.CompositeQuery     `json:"compositeQuery,omitempty"`
+	CompareOp         CompareOp              `json:"op,omitempty"`
+	Target            *float64               `json:"target,omitempty"`
+	AlertOnAbsent     bool                   `json:"alertOnAbsent,omitempty"`
+	AbsentFor         uint64                 `json:"absentFor,omitempty"`
+	MatchType         MatchType              `json:"matchType,omitempty"`
+	TargetUnit        string                 `json:"targetUnit,omitempty"`
+	Algorithm         string                 `json:"algorithm,omitempty"`
+	Seasonality       string                 `json:"seasonality,omitempty"`
+	SelectedQuery     string                 `json:"selectedQueryName,omitempty"`
+	RequireMinPoints  bool                   `json:"requireMinPoints,omitempty"`
+	RequiredNumPoints int                    `json:"requiredNumPoints,omitempty"`
+	Thresholds        *RuleThresholdData     `json:"thresholds,omitempty"`
+	Composite         *CompositeCondition    `json:"composite,omitempty"`
+	AbsentGroups      *AbsentGroupsCondition `json:"absentGroups,omitempty"`
+	BurnRate          *BurnRateCondition     `json:"burnRate,omitempty"`
+	Recording         *RecordingCondition    `json:"recording,omitempty"`
 }
 
 func (rc *RuleCondition) GetSelectedQueryName() string {
@@ -184,6 +191,18 @@ func (rc *RuleCondition) GetSelectedQueryName() string {
 
 func (rc *RuleCondition) IsValid() bool {
 
+	if rc.Composite != nil {
+		return rc.Composite.Validate() == nil && rc.Thresholds != nil
+	}
+
+	if rc.BurnRate != nil {
+		return rc.BurnRate.Validate() == nil && rc.Thresholds != nil
+	}
+
+	if rc.Recording != nil {
+		return rc.Recording.Validate() == nil && rc.Thresholds != nil
+	}
+
 	if rc.CompositeQuery == nil {
 		return false
 	}
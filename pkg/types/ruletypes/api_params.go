@@ -78,6 +78,8 @@ type Renotify struct {
 	Enabled          bool                `json:"enabled"`
 	ReNotifyInterval valuer.TextDuration `json:"interval,omitzero"`
 	AlertStates      []model.AlertState  `json:"alertStates,omitempty"`
+	// SuppressWhenAcknowledged stops the re-notifications of a group once someone acknowledges it
+	SuppressWhenAcknowledged bool `json:"suppressWhenAcknowledged,omitempty"`
 }
 
 func (ns *NotificationSettings) GetAlertManagerNotificationConfig() alertmanagertypes.NotificationConfig {
@@ -94,7 +96,9 @@ func (ns *NotificationSettings) GetAlertManagerNotificationConfig() alertmanager
 		renotifyInterval = 8760 * time.Hour //1 year for no renotify substitute
 		noDataRenotifyInterval = 8760 * time.Hour
 	}
-	return alertmanagertypes.NewNotificationConfig(ns.GroupBy, renotifyInterval, noDataRenotifyInterval, ns.UsePolicy)
+	config := alertmanagertypes.NewNotificationConfig(ns.GroupBy, renotifyInterval, noDataRenotifyInterval, ns.UsePolicy)
+	config.Renotify.SuppressWhenAcknowledged = ns.Renotify.SuppressWhenAcknowledged
+	return config
 }
 
 func (r *PostableRule) GetRuleRouteRequest(ruleId string) ([]*alertmanagertypes.PostableRoutePolicy, error) {
@@ -199,19 +203,27 @@ func (r *PostableRule) processRuleDefaults() {
 	}
 
 	if r.RuleCondition != nil {
-		switch r.RuleCondition.CompositeQuery.QueryType {
-		case v3.QueryTypeBuilder:
-			if r.RuleType == "" {
-				r.RuleType = RuleTypeThreshold
+		if r.RuleCondition.CompositeQuery != nil {
+			switch r.RuleCondition.CompositeQuery.QueryType {
+			case v3.QueryTypeBuilder:
+				if r.RuleType == "" {
+					r.RuleType = RuleTypeThreshold
+				}
+			case v3.QueryTypePromQL:
+				r.RuleType = RuleTypeProm
 			}
-		case v3.QueryTypePromQL:
-			r.RuleType = RuleTypeProm
-		}
 
-		for qLabel, q := range r.RuleCondition.CompositeQuery.BuilderQueries {
-			if q.AggregateAttribute.Key != "" && q.Expression == "" {
-				q.Expression = qLabel
+			for qLabel, q := range r.RuleCondition.CompositeQuery.BuilderQueries {
+				if q.AggregateAttribute.Key != "" && q.Expression == "" {
+					q.Expression = qLabel
+				}
 			}
+		} else if r.RuleCondition.Composite != nil && r.RuleType == "" {
+			r.RuleType = RuleTypeComposite
+		} else if r.RuleCondition.BurnRate != nil && r.RuleType == "" {
+			r.RuleType = RuleTypeBurnRate
+		} else if r.RuleCondition.Recording != nil && r.RuleType == "" {
+			r.RuleType = RuleTypeRecording
 		}
 
 		//added alerts v2 fields
@@ -254,6 +266,34 @@ func (r *PostableRule) processRuleDefaults() {
 				r.NotificationSettings.Renotify.AlertStates = append(r.NotificationSettings.Renotify.AlertStates, model.StateNoData)
 			}
 		}
+
+		// composite rules have no target, the threshold only names the alerts and routes them to the channels
+		if r.RuleType == RuleTypeComposite && r.RuleCondition.Thresholds == nil {
+			thresholdName := CriticalThresholdName
+			if severity, ok := r.Labels["severity"]; ok {
+				thresholdName = severity
+			}
+			r.RuleCondition.Thresholds = &RuleThresholdData{
+				Kind: BasicThresholdKind,
+				Spec: BasicRuleThresholds{{Name: thresholdName, Channels: r.PreferredChannels}},
+			}
+		}
+
+		// the windows of the burn rate rules name their alerts, every name routes to the channels
+		if r.RuleType == RuleTypeBurnRate && r.RuleCondition.BurnRate != nil && (r.RuleCondition.Thresholds == nil || r.SchemaVersion == DefaultSchemaVersion) {
+			thresholds := BasicRuleThresholds{}
+			for _, window := range r.RuleCondition.BurnRate.Windows {
+				if !slices.ContainsFunc(thresholds, func(threshold BasicRuleThreshold) bool { return threshold.Name == window.Name }) {
+					thresholds = append(thresholds, BasicRuleThreshold{Name: window.Name, Channels: r.PreferredChannels})
+				}
+			}
+			r.RuleCondition.Thresholds = &RuleThresholdData{Kind: BasicThresholdKind, Spec: thresholds}
+		}
+
+		// recording rules don't alert, they have no threshold
+		if r.RuleType == RuleTypeRecording {
+			r.RuleCondition.Thresholds = &RuleThresholdData{Kind: BasicThresholdKind, Spec: BasicRuleThresholds{}}
+		}
 	}
 }
 
@@ -351,7 +391,23 @@ func (r *PostableRule) validate() error {
 		// will get panic if we try to access CompositeQuery, so return here
 		return signozError.NewInvalidInputf(signozError.CodeInvalidInput, "rule condition is required")
 	}
-	if r.RuleCondition.CompositeQuery == nil {
+	if r.RuleType == RuleTypeComposite {
+		if err := r.RuleCondition.Composite.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	} else if r.RuleType == RuleTypeBurnRate {
+		if r.RuleCondition.BurnRate == nil {
+			errs = append(errs, signozError.NewInvalidInputf(signozError.CodeInvalidInput, "burn rate condition is required"))
+		} else if err := r.RuleCondition.BurnRate.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	} else if r.RuleType == RuleTypeRecording {
+		if r.RuleCondition.Recording == nil {
+			errs = append(errs, signozError.NewInvalidInputf(signozError.CodeInvalidInput, "recording condition is required"))
+		} else if err := r.RuleCondition.Recording.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	} else if r.RuleCondition.CompositeQuery == nil {
 		errs = append(errs, signozError.NewInvalidInputf(signozError.CodeInvalidInput, "composite query is required"))
 	}
 
@@ -359,6 +415,14 @@ func (r *PostableRule) validate() error {
 		errs = append(errs, signozError.NewInvalidInputf(signozError.CodeInvalidInput, "all queries are disabled in rule condition"))
 	}
 
+	if r.RuleCondition.AbsentGroups.IsEnabled() {
+		if r.RuleType == RuleTypeComposite || r.RuleType == RuleTypeBurnRate || r.RuleType == RuleTypeRecording {
+			errs = append(errs, signozError.NewInvalidInputf(signozError.CodeInvalidInput, "absent groups are not supported by %s rules", r.RuleType))
+		} else if err := r.RuleCondition.AbsentGroups.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
 	for k, v := range r.Labels {
 		if !isValidLabelName(k) {
 			errs = append(errs, signozError.NewInvalidInputf(signozError.CodeInvalidInput, "invalid label name: %s", k))
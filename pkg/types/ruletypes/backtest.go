@@ -0,0 +1,250 @@
+package ruletypes
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/query-service/model"
+	"github.com/SigNoz/signoz/pkg/query-service/utils/labels"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+const (
+	// MaxBacktestEvaluations is the maximum number of evaluations of a single backtest
+	MaxBacktestEvaluations = 50000
+
+	// MaxRunningBacktests is the maximum number of backtests an instance runs at once
+	MaxRunningBacktests = 2
+
+	// BacktestTimeout bounds the time a backtest runs for
+	BacktestTimeout = 30 * time.Minute
+
+	// BacktestRetention is how long a finished backtest is kept for its result to be fetched
+	BacktestRetention = time.Hour
+)
+
+var (
+	ErrCodeBacktestNotFound = errors.MustNewCode("backtest_not_found")
+	ErrCodeTooManyBacktests = errors.MustNewCode("too_many_backtests")
+)
+
+type BacktestStatus struct{ valuer.String }
+
+var (
+	BacktestStatusRunning   = BacktestStatus{valuer.NewString("running")}
+	BacktestStatusSucceeded = BacktestStatus{valuer.NewString("succeeded")}
+	BacktestStatusFailed    = BacktestStatus{valuer.NewString("failed")}
+)
+
+// PostableBacktest replays a rule over the range between start and end at the
+// frequency of its evaluation.
+type PostableBacktest struct {
+	Rule  PostableRule `json:"rule"`
+	Start time.Time    `json:"start"`
+	End   time.Time    `json:"end"`
+}
+
+// Frequency returns the frequency the rule of the backtest is evaluated at.
+func (b *PostableBacktest) Frequency() (valuer.TextDuration, error) {
+	if b.Rule.Evaluation == nil {
+		return b.Rule.Frequency, nil
+	}
+
+	evaluation, err := b.Rule.Evaluation.GetEvaluation()
+	if err != nil {
+		return valuer.TextDuration{}, err
+	}
+
+	return evaluation.GetFrequency(), nil
+}
+
+// Evaluations returns the number of evaluations of the rule within the range of the backtest.
+func (b *PostableBacktest) Evaluations() (int, error) {
+	frequency, err := b.Frequency()
+	if err != nil {
+		return 0, err
+	}
+
+	if !frequency.IsPositive() {
+		return 0, errors.NewInvalidInputf(errors.CodeInvalidInput, "frequency of the rule must be positive")
+	}
+
+	return int(b.End.Sub(b.Start)/frequency.Duration()) + 1, nil
+}
+
+func (b *PostableBacktest) Validate() error {
+	if b.Start.IsZero() || b.End.IsZero() {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "start and end of the backtest are required")
+	}
+
+	if !b.Start.Before(b.End) {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "start of the backtest must be before its end")
+	}
+
+	evaluations, err := b.Evaluations()
+	if err != nil {
+		return err
+	}
+
+	if evaluations > MaxBacktestEvaluations {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "backtest needs %d evaluations of the rule, at most %d are allowed, shorten the range or increase the frequency", evaluations, MaxBacktestEvaluations)
+	}
+
+	return nil
+}
+
+// BacktestInterval is a period during which an alert of the rule was firing.
+type BacktestInterval struct {
+	FiredAt time.Time `json:"firedAt"`
+	// ResolvedAt is zero when the alert was still firing at the end of the backtest
+	ResolvedAt time.Time `json:"resolvedAt,omitzero"`
+	NoData     bool      `json:"noData,omitempty"`
+}
+
+// BacktestSeries holds the intervals of the alert of a label set.
+type BacktestSeries struct {
+	Labels        map[string]string   `json:"labels"`
+	Intervals     []*BacktestInterval `json:"intervals"`
+	Notifications int                 `json:"notifications"`
+
+	key            string
+	firing         *BacktestInterval
+	lastNotifiedAt time.Time
+}
+
+type GettableBacktest struct {
+	Start         time.Time           `json:"start"`
+	End           time.Time           `json:"end"`
+	Frequency     valuer.TextDuration `json:"frequency"`
+	Evaluations   int                 `json:"evaluations"`
+	Notifications int                 `json:"notifications"`
+	Series        []*BacktestSeries   `json:"series"`
+}
+
+// GettableBacktestJob is a backtest run in the background, its result is set once it succeeds.
+type GettableBacktestJob struct {
+	ID        valuer.UUID       `json:"id"`
+	Status    BacktestStatus    `json:"status"`
+	Result    *GettableBacktest `json:"result,omitempty"`
+	Error     string            `json:"error,omitempty"`
+	CreatedAt time.Time         `json:"createdAt"`
+	UpdatedAt time.Time         `json:"updatedAt"`
+}
+
+// Backtest records the alerts of a rule after every evaluation of a backtest.
+// Every alert which starts firing or resolves counts as a notification, and so
+// does every re-notification of the rule while the alert keeps firing.
+type Backtest struct {
+	gettable               *GettableBacktest
+	series                 map[uint64]*BacktestSeries
+	renotifyInterval       time.Duration
+	noDataRenotifyInterval time.Duration
+}
+
+func NewBacktest(postable *PostableBacktest) (*Backtest, error) {
+	if err := postable.Validate(); err != nil {
+		return nil, err
+	}
+
+	frequency, err := postable.Frequency()
+	if err != nil {
+		return nil, err
+	}
+
+	backtest := &Backtest{
+		gettable: &GettableBacktest{
+			Start:     postable.Start,
+			End:       postable.End,
+			Frequency: frequency,
+			Series:    []*BacktestSeries{},
+		},
+		series: map[uint64]*BacktestSeries{},
+	}
+
+	if postable.Rule.NotificationSettings != nil {
+		config := postable.Rule.NotificationSettings.GetAlertManagerNotificationConfig()
+		backtest.renotifyInterval = config.Renotify.RenotifyInterval
+		backtest.noDataRenotifyInterval = config.Renotify.NoDataInterval
+	}
+
+	return backtest, nil
+}
+
+// Timestamps returns the timestamps the rule is evaluated at.
+func (b *Backtest) Timestamps() []time.Time {
+	timestamps := make([]time.Time, 0)
+	for ts := b.gettable.Start; !ts.After(b.gettable.End); ts = ts.Add(b.gettable.Frequency.Duration()) {
+		timestamps = append(timestamps, ts)
+	}
+
+	return timestamps
+}
+
+// Observe records the active alerts of the rule after its evaluation at ts.
+func (b *Backtest) Observe(ts time.Time, alerts []*Alert) {
+	b.gettable.Evaluations++
+
+	firing := make(map[uint64]struct{}, len(alerts))
+	for _, alert := range alerts {
+		if alert.State == model.StatePending || alert.State == model.StateInactive {
+			continue
+		}
+
+		fp := alert.Labels.Hash()
+		firing[fp] = struct{}{}
+
+		series, ok := b.series[fp]
+		if !ok {
+			lbls := alert.Labels.Map()
+			series = &BacktestSeries{Labels: lbls, Intervals: []*BacktestInterval{}, key: labels.FromMap(lbls).String()}
+			b.series[fp] = series
+			b.gettable.Series = append(b.gettable.Series, series)
+		}
+
+		if series.firing == nil {
+			firedAt := alert.FiredAt
+			if firedAt.IsZero() {
+				firedAt = ts
+			}
+			series.firing = &BacktestInterval{FiredAt: firedAt, NoData: alert.Missing}
+			series.Intervals = append(series.Intervals, series.firing)
+			b.notify(ts, series)
+			continue
+		}
+
+		interval := b.renotifyInterval
+		if alert.Missing {
+			interval = b.noDataRenotifyInterval
+		}
+		if interval > 0 && ts.Sub(series.lastNotifiedAt) >= interval {
+			b.notify(ts, series)
+		}
+	}
+
+	for fp, series := range b.series {
+		if _, ok := firing[fp]; ok || series.firing == nil {
+			continue
+		}
+
+		series.firing.ResolvedAt = ts
+		series.firing = nil
+		b.notify(ts, series)
+	}
+}
+
+func (b *Backtest) notify(ts time.Time, series *BacktestSeries) {
+	series.lastNotifiedAt = ts
+	series.Notifications++
+	b.gettable.Notifications++
+}
+
+// Gettable returns the result of the backtest, the series are sorted by their labels.
+func (b *Backtest) Gettable() *GettableBacktest {
+	slices.SortFunc(b.gettable.Series, func(a, b *BacktestSeries) int {
+		return strings.Compare(a.key, b.key)
+	})
+
+	return b.gettable
+}
@@ -0,0 +1,111 @@
+package ruletypes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/query-service/model"
+	"github.com/SigNoz/signoz/pkg/query-service/utils/labels"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBacktestRule(frequency string, renotify *Renotify) PostableRule {
+	rule := PostableRule{
+		Evaluation: &EvaluationEnvelope{Kind: RollingEvaluation, Spec: RollingWindow{
+			EvalWindow: valuer.MustParseTextDuration("5m"),
+			Frequency:  valuer.MustParseTextDuration(frequency),
+		}},
+		NotificationSettings: &NotificationSettings{},
+	}
+	if renotify != nil {
+		rule.NotificationSettings.Renotify = *renotify
+	}
+
+	return rule
+}
+
+func TestPostableBacktestValidate(t *testing.T) {
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		postable PostableBacktest
+		pass     bool
+	}{
+		{
+			name:     "Month",
+			postable: PostableBacktest{Rule: newBacktestRule("1m", nil), Start: start, End: start.Add(30 * 24 * time.Hour)},
+			pass:     true,
+		},
+		{
+			name:     "NoStart",
+			postable: PostableBacktest{Rule: newBacktestRule("1m", nil), End: start},
+			pass:     false,
+		},
+		{
+			name:     "EndBeforeStart",
+			postable: PostableBacktest{Rule: newBacktestRule("1m", nil), Start: start, End: start.Add(-time.Hour)},
+			pass:     false,
+		},
+		{
+			name:     "TooManyEvaluations",
+			postable: PostableBacktest{Rule: newBacktestRule("30s", nil), Start: start, End: start.Add(30 * 24 * time.Hour)},
+			pass:     false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.postable.Validate()
+			if tc.pass {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestBacktestObserve(t *testing.T) {
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	checkout := labels.FromMap(map[string]string{"service": "checkout"})
+	frontend := labels.FromMap(map[string]string{"service": "frontend"})
+
+	backtest, err := NewBacktest(&PostableBacktest{
+		Rule:  newBacktestRule("1m", &Renotify{Enabled: true, ReNotifyInterval: valuer.MustParseTextDuration("2m"), AlertStates: []model.AlertState{model.StateFiring}}),
+		Start: start,
+		End:   start.Add(5 * time.Minute),
+	})
+	require.NoError(t, err)
+
+	timestamps := backtest.Timestamps()
+	require.Len(t, timestamps, 6)
+
+	alert := func(lbls labels.Labels, state model.AlertState, firedAt time.Time) *Alert {
+		return &Alert{Labels: lbls, State: state, FiredAt: firedAt}
+	}
+
+	// checkout fires for three minutes and is re-notified once, frontend never leaves the pending state
+	backtest.Observe(timestamps[0], []*Alert{alert(checkout, model.StateFiring, timestamps[0]), alert(frontend, model.StatePending, time.Time{})})
+	backtest.Observe(timestamps[1], []*Alert{alert(checkout, model.StateFiring, timestamps[0])})
+	backtest.Observe(timestamps[2], []*Alert{alert(checkout, model.StateRecovering, timestamps[0])})
+	backtest.Observe(timestamps[3], []*Alert{})
+	// checkout fires again until the end of the backtest
+	backtest.Observe(timestamps[4], []*Alert{alert(checkout, model.StateFiring, timestamps[4])})
+	backtest.Observe(timestamps[5], []*Alert{alert(checkout, model.StateFiring, timestamps[4])})
+
+	gettable := backtest.Gettable()
+	assert.Equal(t, 6, gettable.Evaluations)
+	assert.Equal(t, 4, gettable.Notifications)
+	require.Len(t, gettable.Series, 1)
+
+	series := gettable.Series[0]
+	assert.Equal(t, map[string]string{"service": "checkout"}, series.Labels)
+	require.Len(t, series.Intervals, 2)
+	assert.Equal(t, timestamps[0], series.Intervals[0].FiredAt)
+	assert.Equal(t, timestamps[3], series.Intervals[0].ResolvedAt)
+	assert.Equal(t, timestamps[4], series.Intervals[1].FiredAt)
+	assert.True(t, series.Intervals[1].ResolvedAt.IsZero())
+}
@@ -0,0 +1,207 @@
+package ruletypes
+
+import (
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+const (
+	// SLIGoodQueryName and SLITotalQueryName name the queries of the good and the total events of an SLI
+	SLIGoodQueryName  = "good"
+	SLITotalQueryName = "total"
+)
+
+// BurnRateWindow fires when the error budget is consumed at least BurnRate times
+// faster than allowed over both the long and the short window. The short window
+// makes the alert resolve soon after the burn stops.
+type BurnRateWindow struct {
+	// Name is the threshold name of the alerts of the window, the receivers of the threshold are notified
+	Name     string              `json:"name"`
+	Long     valuer.TextDuration `json:"long"`
+	Short    valuer.TextDuration `json:"short"`
+	BurnRate float64             `json:"burnRate"`
+}
+
+// DefaultBurnRateWindows are the multi-window, multi-burn-rate alerts recommended
+// for a 30 day objective. The critical windows page for the fast burns which
+// consume 2% and 5% of the budget, the warning ones for the slow burns which
+// consume 10% of the budget.
+func DefaultBurnRateWindows() []BurnRateWindow {
+	return []BurnRateWindow{
+		{Name: CriticalThresholdName, Long: valuer.MustParseTextDuration("1h"), Short: valuer.MustParseTextDuration("5m"), BurnRate: 14.4},
+		{Name: CriticalThresholdName, Long: valuer.MustParseTextDuration("6h"), Short: valuer.MustParseTextDuration("30m"), BurnRate: 6},
+		{Name: WarningThresholdName, Long: valuer.MustParseTextDuration("24h"), Short: valuer.MustParseTextDuration("2h"), BurnRate: 3},
+		{Name: WarningThresholdName, Long: valuer.MustParseTextDuration("72h"), Short: valuer.MustParseTextDuration("6h"), BurnRate: 1},
+	}
+}
+
+// BurnRateCondition is the condition of the rules alerting on the error budget
+// burn rate of an SLI, the ratio of the good events to the total events.
+type BurnRateCondition struct {
+	Good  qbtypes.QueryEnvelope `json:"good"`
+	Total qbtypes.QueryEnvelope `json:"total"`
+	// Target is the objective of the SLI in percent, e.g. 99.9
+	Target  float64          `json:"target"`
+	Windows []BurnRateWindow `json:"windows"`
+}
+
+func (c *BurnRateCondition) Validate() error {
+	if err := ValidateSLIQueries(c.Good, c.Total); err != nil {
+		return err
+	}
+
+	if c.Target <= 0 || c.Target >= 100 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "target must be between 0 and 100 exclusive, got %v", c.Target)
+	}
+
+	if len(c.Windows) == 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "at least one burn rate window is required")
+	}
+
+	for _, window := range c.Windows {
+		if window.Name == "" {
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "burn rate window name is required")
+		}
+		if window.Short.Duration() <= 0 || window.Long.Duration() <= window.Short.Duration() {
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "burn rate window %s: the long window must be greater than the short window which must be positive", window.Name)
+		}
+		if window.BurnRate <= 0 {
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "burn rate window %s: burn rate must be positive", window.Name)
+		}
+	}
+
+	return nil
+}
+
+// Durations returns the sorted unique long and short durations of the windows.
+func (c *BurnRateCondition) Durations() []time.Duration {
+	durations := make([]time.Duration, 0, 2*len(c.Windows))
+	for _, window := range c.Windows {
+		durations = append(durations, window.Long.Duration(), window.Short.Duration())
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	return slices.Compact(durations)
+}
+
+// BurnRate returns how many times faster than allowed by the target the error
+// budget is consumed when the given ratio of the events are good.
+func (c *BurnRateCondition) BurnRate(sli float64) float64 {
+	return (1 - sli) / (1 - c.Target/100)
+}
+
+// ValidateSLIQueries checks that the good and the total queries of an SLI are builder queries.
+func ValidateSLIQueries(good qbtypes.QueryEnvelope, total qbtypes.QueryEnvelope) error {
+	for name, envelope := range map[string]qbtypes.QueryEnvelope{SLIGoodQueryName: good, SLITotalQueryName: total} {
+		if envelope.Type != qbtypes.QueryTypeBuilder {
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "%s query must be a builder query", name)
+		}
+
+		switch spec := envelope.Spec.(type) {
+		case qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]:
+			if len(spec.Aggregations) == 0 {
+				return errors.NewInvalidInputf(errors.CodeInvalidInput, "%s query must have an aggregation", name)
+			}
+		case qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]:
+			if len(spec.Aggregations) == 0 {
+				return errors.NewInvalidInputf(errors.CodeInvalidInput, "%s query must have an aggregation", name)
+			}
+		case qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]:
+			if len(spec.Aggregations) == 0 {
+				return errors.NewInvalidInputf(errors.CodeInvalidInput, "%s query must have an aggregation", name)
+			}
+		default:
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "%s query must be a traces, logs or metrics builder query", name)
+		}
+	}
+
+	return nil
+}
+
+// NewSLIQueryRangeRequest returns the scalar request of the good and the total events between start and end.
+func NewSLIQueryRangeRequest(good qbtypes.QueryEnvelope, total qbtypes.QueryEnvelope, start time.Time, end time.Time) *qbtypes.QueryRangeRequest {
+	return &qbtypes.QueryRangeRequest{
+		SchemaVersion: "v5",
+		Start:         uint64(start.UnixMilli()),
+		End:           uint64(end.UnixMilli()),
+		RequestType:   qbtypes.RequestTypeScalar,
+		CompositeQuery: qbtypes.CompositeQuery{
+			Queries: []qbtypes.QueryEnvelope{withQueryName(good, SLIGoodQueryName), withQueryName(total, SLITotalQueryName)},
+		},
+	}
+}
+
+func withQueryName(envelope qbtypes.QueryEnvelope, name string) qbtypes.QueryEnvelope {
+	switch spec := envelope.Spec.(type) {
+	case qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]:
+		spec.Name, spec.Disabled = name, false
+		envelope.Spec = spec
+	case qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]:
+		spec.Name, spec.Disabled = name, false
+		envelope.Spec = spec
+	case qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]:
+		spec.Name, spec.Disabled = name, false
+		envelope.Spec = spec
+	case qbtypes.PromQuery:
+		spec.Name, spec.Disabled = name, false
+		envelope.Spec = spec
+	}
+	return envelope
+}
+
+// SLIEventsFromResponse returns the number of good and total events of the
+// response to a request made with NewSLIQueryRangeRequest. The events of all
+// the groups of a query are summed up.
+func SLIEventsFromResponse(resp *qbtypes.QueryRangeResponse) (float64, float64, error) {
+	events := map[string]float64{}
+	for _, result := range resp.Data.Results {
+		data, ok := result.(*qbtypes.ScalarData)
+		if !ok || data == nil {
+			continue
+		}
+
+		column := -1
+		for i, c := range data.Columns {
+			if c.Type == qbtypes.ColumnTypeAggregation && c.AggregationIndex == 0 {
+				column = i
+				break
+			}
+		}
+		if column < 0 {
+			continue
+		}
+
+		for _, row := range data.Data {
+			value, err := toFloat64(row[column])
+			if err != nil {
+				return 0, 0, err
+			}
+			events[data.QueryName] += value
+		}
+	}
+
+	return events[SLIGoodQueryName], events[SLITotalQueryName], nil
+}
+
+func toFloat64(value any) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case uint64:
+		return float64(v), nil
+	case int:
+		return float64(v), nil
+	case nil:
+		return 0, nil
+	default:
+		return 0, errors.NewInternalf(errors.CodeInternal, "unexpected sli value %v of type %T", value, value)
+	}
+}
@@ -0,0 +1,84 @@
+package ruletypes
+
+import (
+	"encoding/json"
+	"testing"
+
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBurnRateRule = `{
+	"alert": "checkout error budget burn",
+	"alertType": "TRACES_BASED_ALERT",
+	"ruleType": "burn_rate_rule",
+	"preferredChannels": ["oncall"],
+	"condition": {
+		"burnRate": {
+			"good": {"type": "builder_query", "spec": {"name": "A", "signal": "traces", "aggregations": [{"expression": "count()"}], "filter": {"expression": "has_error = false"}}},
+			"total": {"type": "builder_query", "spec": {"name": "B", "signal": "traces", "aggregations": [{"expression": "count()"}]}},
+			"target": 99.9,
+			"windows": [
+				{"name": "critical", "long": "1h", "short": "5m", "burnRate": 14.4},
+				{"name": "warning", "long": "6h", "short": "30m", "burnRate": 6}
+			]
+		}
+	}
+}`
+
+func TestPostableRule_BurnRate(t *testing.T) {
+	rule := new(PostableRule)
+	require.NoError(t, json.Unmarshal([]byte(testBurnRateRule), rule))
+
+	assert.Equal(t, RuleType(RuleTypeBurnRate), rule.RuleType)
+	require.NotNil(t, rule.RuleCondition.BurnRate)
+	assert.Len(t, rule.RuleCondition.BurnRate.Windows, 2)
+
+	receivers := rule.RuleCondition.Thresholds.Spec.(BasicRuleThresholds).GetRuleReceivers()
+	require.Len(t, receivers, 2)
+	assert.Equal(t, CriticalThresholdName, receivers[0].Name)
+	assert.Equal(t, WarningThresholdName, receivers[1].Name)
+	assert.Equal(t, []string{"oncall"}, receivers[1].Channels)
+}
+
+func TestBurnRateCondition(t *testing.T) {
+	rule := new(PostableRule)
+	require.NoError(t, json.Unmarshal([]byte(testBurnRateRule), rule))
+	condition := rule.RuleCondition.BurnRate
+
+	assert.InDelta(t, 10, condition.BurnRate(0.99), 1e-9)
+	assert.InDelta(t, 0, condition.BurnRate(1), 1e-9)
+
+	durations := condition.Durations()
+	assert.Equal(t, []string{"5m0s", "30m0s", "1h0m0s", "6h0m0s"}, []string{durations[0].String(), durations[1].String(), durations[2].String(), durations[3].String()})
+
+	condition.Windows = append(condition.Windows, BurnRateWindow{Name: "info", Long: valuer.MustParseTextDuration("5m"), Short: valuer.MustParseTextDuration("5m"), BurnRate: 1})
+	assert.Error(t, condition.Validate())
+
+	condition.Windows = DefaultBurnRateWindows()
+	condition.Total = qbtypes.QueryEnvelope{Type: qbtypes.QueryTypePromQL}
+	assert.Error(t, condition.Validate())
+}
+
+func TestSLIEventsFromResponse(t *testing.T) {
+	columns := []*qbtypes.ColumnDescriptor{
+		{Type: qbtypes.ColumnTypeGroup},
+		{Type: qbtypes.ColumnTypeAggregation, AggregationIndex: 0},
+	}
+
+	resp := &qbtypes.QueryRangeResponse{
+		Data: qbtypes.QueryData{
+			Results: []any{
+				&qbtypes.ScalarData{QueryName: SLIGoodQueryName, Columns: columns, Data: [][]any{{"frontend", float64(90)}, {"checkout", uint64(5)}}},
+				&qbtypes.ScalarData{QueryName: SLITotalQueryName, Columns: columns, Data: [][]any{{"frontend", float64(100)}, {"checkout", uint64(10)}}},
+			},
+		},
+	}
+
+	good, total, err := SLIEventsFromResponse(resp)
+	require.NoError(t, err)
+	assert.Equal(t, float64(95), good)
+	assert.Equal(t, float64(110), total)
+}
@@ -0,0 +1,272 @@
+package ruletypes
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// CompositeCondition is the condition of a composite rule, the rule fires when the boolean expression over the
+// states of the referenced rules holds. A reference is true while the referenced rule is firing.
+type CompositeCondition struct {
+	// Expression combines the references with AND, OR, NOT and parentheses,
+	// e.g. "(high_latency AND high_error_rate) AND NOT deploy_in_progress"
+	Expression string `json:"expression"`
+	// Rules maps the references used in the expression to the ids of the rules
+	Rules map[string]string `json:"rules"`
+	// For is how long the expression has to hold before the rule fires
+	For valuer.TextDuration `json:"for,omitempty"`
+}
+
+// Validate checks that the expression parses and that every reference is mapped to a valid rule id.
+func (c *CompositeCondition) Validate() error {
+	if c == nil {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "composite condition is required")
+	}
+
+	expr, err := c.Parse()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, ref := range CompositeReferences(expr) {
+		id, ok := c.Rules[ref]
+		if !ok {
+			errs = append(errs, errors.NewInvalidInputf(errors.CodeInvalidInput, "reference %s is not mapped to a rule", ref))
+			continue
+		}
+		if _, err := valuer.NewUUID(id); err != nil {
+			errs = append(errs, errors.NewInvalidInputf(errors.CodeInvalidInput, "reference %s is mapped to an invalid rule id %s", ref, id))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Parse parses the expression of the condition.
+func (c *CompositeCondition) Parse() (CompositeExpr, error) {
+	return ParseCompositeExpression(c.Expression)
+}
+
+// RuleIDs returns the sorted ids of the rules the condition depends on.
+func (c *CompositeCondition) RuleIDs() []string {
+	if c == nil {
+		return nil
+	}
+
+	seen := make(map[string]struct{}, len(c.Rules))
+	ids := make([]string, 0, len(c.Rules))
+	for _, id := range c.Rules {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+// CompositeExpr is a parsed boolean expression of a composite condition.
+type CompositeExpr interface {
+	// Eval evaluates the expression, isTrue reports the value of a reference
+	Eval(isTrue func(ref string) bool) bool
+	String() string
+}
+
+type compositeRef struct {
+	name string
+}
+
+func (e compositeRef) Eval(isTrue func(string) bool) bool { return isTrue(e.name) }
+func (e compositeRef) String() string                     { return e.name }
+
+type compositeNot struct {
+	expr CompositeExpr
+}
+
+func (e compositeNot) Eval(isTrue func(string) bool) bool { return !e.expr.Eval(isTrue) }
+func (e compositeNot) String() string                     { return "NOT " + e.expr.String() }
+
+type compositeBinary struct {
+	op          string
+	left, right CompositeExpr
+}
+
+func (e compositeBinary) Eval(isTrue func(string) bool) bool {
+	if e.op == "AND" {
+		return e.left.Eval(isTrue) && e.right.Eval(isTrue)
+	}
+	return e.left.Eval(isTrue) || e.right.Eval(isTrue)
+}
+
+func (e compositeBinary) String() string {
+	return "(" + e.left.String() + " " + e.op + " " + e.right.String() + ")"
+}
+
+// CompositeReferences returns the sorted references used in the expression.
+func CompositeReferences(expr CompositeExpr) []string {
+	seen := map[string]struct{}{}
+	var walk func(CompositeExpr)
+	walk = func(e CompositeExpr) {
+		switch e := e.(type) {
+		case compositeRef:
+			seen[e.name] = struct{}{}
+		case compositeNot:
+			walk(e.expr)
+		case compositeBinary:
+			walk(e.left)
+			walk(e.right)
+		}
+	}
+	walk(expr)
+
+	refs := make([]string, 0, len(seen))
+	for ref := range seen {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+
+	return refs
+}
+
+// ParseCompositeExpression parses a boolean expression of references combined with AND, OR, NOT and parentheses.
+// The keywords are case insensitive, NOT binds tighter than AND, which binds tighter than OR.
+func ParseCompositeExpression(expression string) (CompositeExpr, error) {
+	tokens, err := tokenizeCompositeExpression(expression)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "composite expression is empty")
+	}
+
+	p := &compositeParser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "unexpected %q in composite expression", p.tokens[p.pos])
+	}
+
+	return expr, nil
+}
+
+func tokenizeCompositeExpression(expression string) ([]string, error) {
+	var tokens []string
+	runes := []rune(expression)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')':
+			tokens = append(tokens, string(r))
+			i++
+		case isCompositeRefRune(r):
+			start := i
+			for i < len(runes) && isCompositeRefRune(runes[i]) {
+				i++
+			}
+			token := string(runes[start:i])
+			if keyword := strings.ToUpper(token); keyword == "AND" || keyword == "OR" || keyword == "NOT" {
+				token = keyword
+			}
+			tokens = append(tokens, token)
+		default:
+			return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "unexpected character %q in composite expression", r)
+		}
+	}
+
+	return tokens, nil
+}
+
+func isCompositeRefRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-' || r == '.'
+}
+
+type compositeParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *compositeParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *compositeParser) parseOr() (CompositeExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "OR" {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = compositeBinary{op: "OR", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *compositeParser) parseAnd() (CompositeExpr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "AND" {
+		p.pos++
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = compositeBinary{op: "AND", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *compositeParser) parseNot() (CompositeExpr, error) {
+	if p.peek() == "NOT" {
+		p.pos++
+		expr, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return compositeNot{expr: expr}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *compositeParser) parsePrimary() (CompositeExpr, error) {
+	token := p.peek()
+	switch token {
+	case "":
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "unexpected end of composite expression")
+	case "(":
+		p.pos++
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "missing closing parenthesis in composite expression")
+		}
+		p.pos++
+		return expr, nil
+	case ")", "AND", "OR", "NOT":
+		return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "unexpected %q in composite expression", token)
+	}
+
+	p.pos++
+	return compositeRef{name: token}, nil
+}
@@ -0,0 +1,126 @@
+package ruletypes
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCompositeExpression(t *testing.T) {
+	cases := []struct {
+		name       string
+		expression string
+		firing     []string
+		want       bool
+		str        string
+	}{
+		{
+			name:       "and not",
+			expression: "(high_latency AND high_error_rate) AND NOT deploy_in_progress",
+			firing:     []string{"high_latency", "high_error_rate"},
+			want:       true,
+			str:        "((high_latency AND high_error_rate) AND NOT deploy_in_progress)",
+		},
+		{
+			name:       "suppressed by not",
+			expression: "(high_latency AND high_error_rate) AND NOT deploy_in_progress",
+			firing:     []string{"high_latency", "high_error_rate", "deploy_in_progress"},
+			want:       false,
+		},
+		{
+			name:       "and binds tighter than or",
+			expression: "a or b and c",
+			firing:     []string{"a"},
+			want:       true,
+			str:        "(a OR (b AND c))",
+		},
+		{
+			name:       "parentheses",
+			expression: "(a OR b) AND c",
+			firing:     []string{"a"},
+			want:       false,
+		},
+		{
+			name:       "double not",
+			expression: "NOT NOT a",
+			firing:     []string{"a"},
+			want:       true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			expr, err := ParseCompositeExpression(c.expression)
+			require.NoError(t, err)
+
+			firing := map[string]bool{}
+			for _, ref := range c.firing {
+				firing[ref] = true
+			}
+			assert.Equal(t, c.want, expr.Eval(func(ref string) bool { return firing[ref] }))
+			if c.str != "" {
+				assert.Equal(t, c.str, expr.String())
+			}
+		})
+	}
+}
+
+func TestParseCompositeExpression_Errors(t *testing.T) {
+	for _, expression := range []string{"", "a AND", "(a OR b", "a b", "a AND OR b", "a && b", ")"} {
+		t.Run(expression, func(t *testing.T) {
+			_, err := ParseCompositeExpression(expression)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestCompositeCondition_Validate(t *testing.T) {
+	condition := &CompositeCondition{
+		Expression: "a AND NOT b",
+		Rules: map[string]string{
+			"a": "0199d2b1-0f2a-7c3e-9a57-1c4f2b9e8d01",
+			"b": "0199d2b1-0f2a-7c3e-9a57-1c4f2b9e8d02",
+		},
+	}
+	require.NoError(t, condition.Validate())
+	assert.Equal(t, []string{"0199d2b1-0f2a-7c3e-9a57-1c4f2b9e8d01", "0199d2b1-0f2a-7c3e-9a57-1c4f2b9e8d02"}, condition.RuleIDs())
+
+	condition.Expression = "a AND c"
+	require.Error(t, condition.Validate())
+
+	condition.Expression = "a"
+	condition.Rules["a"] = "not-a-uuid"
+	require.Error(t, condition.Validate())
+}
+
+func TestPostableRule_Composite(t *testing.T) {
+	content := `{
+		"alert": "checkout degraded",
+		"labels": {"severity": "warning"},
+		"preferredChannels": ["oncall"],
+		"condition": {
+			"composite": {
+				"expression": "high_latency AND NOT deploy_in_progress",
+				"rules": {
+					"high_latency": "0199d2b1-0f2a-7c3e-9a57-1c4f2b9e8d01",
+					"deploy_in_progress": "0199d2b1-0f2a-7c3e-9a57-1c4f2b9e8d02"
+				},
+				"for": "5m"
+			}
+		}
+	}`
+
+	var rule PostableRule
+	require.NoError(t, json.Unmarshal([]byte(content), &rule))
+	assert.Equal(t, RuleType(RuleTypeComposite), rule.RuleType)
+	assert.True(t, rule.RuleCondition.IsValid())
+
+	threshold, err := rule.RuleCondition.Thresholds.GetRuleThreshold()
+	require.NoError(t, err)
+	assert.Equal(t, []RuleReceivers{{Name: "warning", Channels: []string{"oncall"}}}, threshold.GetRuleReceivers())
+
+	invalid := `{"alert": "invalid", "ruleType": "composite_rule", "condition": {"composite": {"expression": "a AND", "rules": {}}}}`
+	require.Error(t, json.Unmarshal([]byte(invalid), &rule))
+}
@@ -7,6 +7,7 @@ const (
 	InfoThresholdName     = "info"
 	LabelThresholdName    = "threshold.name"
 	LabelSeverityName     = "severity"
+	LabelBurnRateWindow   = "burn_rate_window"
 	LabelLastSeen         = "lastSeen"
 	LabelRuleId           = "ruleId"
 )
@@ -0,0 +1,158 @@
+package ruletypes
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/query-service/utils/labels"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+)
+
+const (
+	// RecordingQueryName names the query of a recording rule
+	RecordingQueryName = "A"
+)
+
+var (
+	recordingMetricRegex = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:.]*$`)
+)
+
+// RecordingCondition is the condition of the rules which record the result of
+// a query as a new metric. Every series of the result is written as a series
+// of the metric, labelled with the labels of the series and of the condition.
+type RecordingCondition struct {
+	Query qbtypes.QueryEnvelope `json:"query"`
+	// Metric is the name of the metric the result is recorded as
+	Metric string `json:"metric"`
+	// Labels are added to every recorded series, they override the labels of the series
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+func (c *RecordingCondition) Validate() error {
+	if !recordingMetricRegex.MatchString(c.Metric) {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid metric name %q, the name must match %s", c.Metric, recordingMetricRegex.String())
+	}
+
+	if strings.HasPrefix(c.Metric, "signoz_") {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "metric name %q is reserved, the prefix signoz_ is used by the metrics of signoz", c.Metric)
+	}
+
+	for name := range c.Labels {
+		if !isValidLabelName(name) || name == labels.MetricNameLabel {
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid label name: %s", name)
+		}
+	}
+
+	switch c.Query.Type {
+	case qbtypes.QueryTypeBuilder:
+		switch spec := c.Query.Spec.(type) {
+		case qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]:
+			if len(spec.Aggregations) == 0 {
+				return errors.NewInvalidInputf(errors.CodeInvalidInput, "recording query must have an aggregation")
+			}
+		case qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]:
+			if len(spec.Aggregations) == 0 {
+				return errors.NewInvalidInputf(errors.CodeInvalidInput, "recording query must have an aggregation")
+			}
+		case qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]:
+			if len(spec.Aggregations) == 0 {
+				return errors.NewInvalidInputf(errors.CodeInvalidInput, "recording query must have an aggregation")
+			}
+		default:
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "recording query must be a traces, logs or metrics builder query")
+		}
+	case qbtypes.QueryTypePromQL:
+		spec, ok := c.Query.Spec.(qbtypes.PromQuery)
+		if !ok || spec.Query == "" {
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "recording query must have a promql query")
+		}
+	default:
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "recording query must be a builder or a promql query")
+	}
+
+	return nil
+}
+
+// NewRecordingQueryRangeRequest returns the time series request of the query of the recording between start and end.
+func NewRecordingQueryRangeRequest(c *RecordingCondition, start time.Time, end time.Time) *qbtypes.QueryRangeRequest {
+	return &qbtypes.QueryRangeRequest{
+		SchemaVersion: "v5",
+		Start:         uint64(start.UnixMilli()),
+		End:           uint64(end.UnixMilli()),
+		RequestType:   qbtypes.RequestTypeTimeSeries,
+		CompositeQuery: qbtypes.CompositeQuery{
+			Queries: []qbtypes.QueryEnvelope{withQueryName(c.Query, RecordingQueryName)},
+		},
+		NoCache: true,
+	}
+}
+
+// RecordedSample is a sample of a series of a recorded metric, the labels hold the name of the metric.
+type RecordedSample struct {
+	Labels    labels.Labels
+	Value     float64
+	Timestamp time.Time
+}
+
+// RecordedSamplesFromResponse returns a sample for every series of the response
+// to a request made with NewRecordingQueryRangeRequest. The sample is the latest
+// value of the series which isn't partial, at the time of its bucket. The series
+// whose latest bucket is older than since are skipped as the bucket was already
+// recorded by an earlier evaluation.
+func RecordedSamplesFromResponse(c *RecordingCondition, resp *qbtypes.QueryRangeResponse, since time.Time) []*RecordedSample {
+	samples := make([]*RecordedSample, 0)
+	for _, result := range resp.Data.Results {
+		data, ok := result.(*qbtypes.TimeSeriesData)
+		if !ok || data == nil || data.QueryName != RecordingQueryName {
+			continue
+		}
+
+		for _, bucket := range data.Aggregations {
+			if bucket == nil || bucket.Index != 0 {
+				continue
+			}
+
+			for _, series := range bucket.Series {
+				if series == nil {
+					continue
+				}
+
+				// the partial buckets, such as the newest one while it is still being filled, are not recorded
+				var latest *qbtypes.TimeSeriesValue
+				for _, value := range series.Values {
+					if value == nil || value.Partial {
+						continue
+					}
+					if latest == nil || value.Timestamp > latest.Timestamp {
+						latest = value
+					}
+				}
+				if latest == nil || latest.Timestamp < since.UnixMilli() {
+					continue
+				}
+
+				lb := labels.NewBuilder(nil)
+				for _, label := range series.Labels {
+					lb.Set(label.Key.Name, fmt.Sprint(label.Value))
+				}
+				for name, value := range c.Labels {
+					lb.Set(name, value)
+				}
+				lb.Set(labels.MetricNameLabel, c.Metric)
+
+				samples = append(samples, &RecordedSample{Labels: lb.Labels(), Value: latest.Value, Timestamp: time.UnixMilli(latest.Timestamp)})
+			}
+		}
+	}
+
+	return samples
+}
+
+// RecordingStore writes the samples of the recorded metrics to the metrics tables.
+type RecordingStore interface {
+	Write(ctx context.Context, samples []*RecordedSample) error
+}
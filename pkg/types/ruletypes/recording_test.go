@@ -0,0 +1,190 @@
+package ruletypes
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/query-service/utils/labels"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRecordingRule = `{
+	"alert": "checkout error rate",
+	"alertType": "TRACES_BASED_ALERT",
+	"evalWindow": "5m",
+	"frequency": "1m",
+	"condition": {
+		"recording": {
+			"query": {"type": "builder_query", "spec": {"name": "errors", "signal": "traces", "aggregations": [{"expression": "count()"}], "filter": {"expression": "has_error = true"}, "groupBy": [{"name": "service.name"}]}},
+			"metric": "checkout:errors:count5m",
+			"labels": {"team": "payments"}
+		}
+	}
+}`
+
+func TestPostableRule_Recording(t *testing.T) {
+	rule := new(PostableRule)
+	require.NoError(t, json.Unmarshal([]byte(testRecordingRule), rule))
+
+	assert.Equal(t, RuleType(RuleTypeRecording), rule.RuleType)
+	require.NotNil(t, rule.RuleCondition.Recording)
+	assert.Equal(t, "checkout:errors:count5m", rule.RuleCondition.Recording.Metric)
+	assert.True(t, rule.RuleCondition.IsValid())
+	assert.Empty(t, rule.RuleCondition.Thresholds.Spec.(BasicRuleThresholds))
+
+	req := NewRecordingQueryRangeRequest(rule.RuleCondition.Recording, time.Unix(0, 0), time.Unix(300, 0))
+	require.Len(t, req.CompositeQuery.Queries, 1)
+	assert.Equal(t, RecordingQueryName, req.CompositeQuery.Queries[0].Spec.(qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]).Name)
+}
+
+func TestRecordingConditionValidate(t *testing.T) {
+	builder := qbtypes.QueryEnvelope{Type: qbtypes.QueryTypeBuilder, Spec: qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]{
+		Signal:       telemetrytypes.SignalMetrics,
+		Aggregations: []qbtypes.MetricAggregation{{MetricName: "http_requests_total"}},
+	}}
+	promql := qbtypes.QueryEnvelope{Type: qbtypes.QueryTypePromQL, Spec: qbtypes.PromQuery{Query: "sum(rate(http_requests_total[5m]))"}}
+
+	testCases := []struct {
+		name      string
+		condition RecordingCondition
+		pass      bool
+	}{
+		{name: "Builder", condition: RecordingCondition{Query: builder, Metric: "http:requests:rate5m"}, pass: true},
+		{name: "PromQL", condition: RecordingCondition{Query: promql, Metric: "http_requests_rate5m", Labels: map[string]string{"team": "web"}}, pass: true},
+		{name: "InvalidMetric", condition: RecordingCondition{Query: promql, Metric: "5xx-rate"}, pass: false},
+		{name: "ReservedMetric", condition: RecordingCondition{Query: promql, Metric: "signoz_calls_total"}, pass: false},
+		{name: "MetricNameLabel", condition: RecordingCondition{Query: promql, Metric: "rate", Labels: map[string]string{labels.MetricNameLabel: "other"}}, pass: false},
+		{name: "EmptyPromQL", condition: RecordingCondition{Query: qbtypes.QueryEnvelope{Type: qbtypes.QueryTypePromQL, Spec: qbtypes.PromQuery{}}, Metric: "rate"}, pass: false},
+		{name: "ClickHouseSQL", condition: RecordingCondition{Query: qbtypes.QueryEnvelope{Type: qbtypes.QueryTypeClickHouseSQL, Spec: qbtypes.ClickHouseQuery{Query: "SELECT 1"}}, Metric: "rate"}, pass: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.condition.Validate()
+			if tc.pass {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestRecordedSamplesFromResponse(t *testing.T) {
+	condition := &RecordingCondition{Metric: "http:requests:rate5m", Labels: map[string]string{"team": "web", "service": "override"}}
+
+	series := func(service string, values ...*qbtypes.TimeSeriesValue) *qbtypes.TimeSeries {
+		return &qbtypes.TimeSeries{
+			Labels: []*qbtypes.Label{
+				{Key: telemetrytypes.TelemetryFieldKey{Name: "service"}, Value: service},
+				{Key: telemetrytypes.TelemetryFieldKey{Name: "code"}, Value: 500},
+			},
+			Values: values,
+		}
+	}
+
+	resp := &qbtypes.QueryRangeResponse{
+		Data: qbtypes.QueryData{
+			Results: []any{
+				&qbtypes.TimeSeriesData{
+					QueryName: RecordingQueryName,
+					Aggregations: []*qbtypes.AggregationBucket{
+						{Index: 0, Series: []*qbtypes.TimeSeries{
+							series("checkout", &qbtypes.TimeSeriesValue{Timestamp: 240000, Value: 4}, &qbtypes.TimeSeriesValue{Timestamp: 180000, Value: 3}),
+							series("frontend"),
+						}},
+						{Index: 1, Series: []*qbtypes.TimeSeries{
+							series("checkout", &qbtypes.TimeSeriesValue{Timestamp: 240000, Value: 10}),
+						}},
+					},
+				},
+			},
+		},
+	}
+
+	samples := RecordedSamplesFromResponse(condition, resp, time.UnixMilli(0))
+	require.Len(t, samples, 1)
+	assert.Equal(t, float64(4), samples[0].Value)
+	assert.Equal(t, time.UnixMilli(240000), samples[0].Timestamp)
+	assert.Equal(t, map[string]string{
+		labels.MetricNameLabel: "http:requests:rate5m",
+		"service":              "override",
+		"code":                 "500",
+		"team":                 "web",
+	}, samples[0].Labels.Map())
+}
+
+func TestRecordedSamplesFromResponse_SkipsPartialValues(t *testing.T) {
+	condition := &RecordingCondition{Metric: "http:requests:rate5m"}
+
+	series := func(service string, values ...*qbtypes.TimeSeriesValue) *qbtypes.TimeSeries {
+		return &qbtypes.TimeSeries{
+			Labels: []*qbtypes.Label{{Key: telemetrytypes.TelemetryFieldKey{Name: "service"}, Value: service}},
+			Values: values,
+		}
+	}
+
+	resp := &qbtypes.QueryRangeResponse{
+		Data: qbtypes.QueryData{
+			Results: []any{
+				&qbtypes.TimeSeriesData{
+					QueryName: RecordingQueryName,
+					Aggregations: []*qbtypes.AggregationBucket{
+						{Index: 0, Series: []*qbtypes.TimeSeries{
+							series("checkout",
+								&qbtypes.TimeSeriesValue{Timestamp: 240000, Value: 4},
+								&qbtypes.TimeSeriesValue{Timestamp: 300000, Value: 1, Partial: true},
+							),
+							series("frontend", &qbtypes.TimeSeriesValue{Timestamp: 300000, Value: 1, Partial: true}),
+						}},
+					},
+				},
+			},
+		},
+	}
+
+	samples := RecordedSamplesFromResponse(condition, resp, time.UnixMilli(0))
+	require.Len(t, samples, 1)
+	assert.Equal(t, float64(4), samples[0].Value)
+	assert.Equal(t, "checkout", samples[0].Labels.Map()["service"])
+}
+
+func TestRecordedSamplesFromResponse_SkipsRecordedBuckets(t *testing.T) {
+	condition := &RecordingCondition{Metric: "http:requests:rate5m"}
+
+	series := func(service string, values ...*qbtypes.TimeSeriesValue) *qbtypes.TimeSeries {
+		return &qbtypes.TimeSeries{
+			Labels: []*qbtypes.Label{{Key: telemetrytypes.TelemetryFieldKey{Name: "service"}, Value: service}},
+			Values: values,
+		}
+	}
+
+	resp := &qbtypes.QueryRangeResponse{
+		Data: qbtypes.QueryData{
+			Results: []any{
+				&qbtypes.TimeSeriesData{
+					QueryName: RecordingQueryName,
+					Aggregations: []*qbtypes.AggregationBucket{
+						{Index: 0, Series: []*qbtypes.TimeSeries{
+							series("checkout", &qbtypes.TimeSeriesValue{Timestamp: 240000, Value: 4}),
+							// the latest complete bucket of frontend was recorded by the previous evaluation
+							series("frontend",
+								&qbtypes.TimeSeriesValue{Timestamp: 120000, Value: 2},
+								&qbtypes.TimeSeriesValue{Timestamp: 300000, Value: 1, Partial: true},
+							),
+						}},
+					},
+				},
+			},
+		},
+	}
+
+	samples := RecordedSamplesFromResponse(condition, resp, time.UnixMilli(180000))
+	require.Len(t, samples, 1)
+	assert.Equal(t, "checkout", samples[0].Labels.Map()["service"])
+	assert.Equal(t, time.UnixMilli(240000), samples[0].Timestamp)
+}
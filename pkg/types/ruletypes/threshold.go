@@ -179,7 +179,11 @@ func (r BasicRuleThresholds) Eval(series v3.Series, unit string, evalData EvalDa
 }
 
 func sortThresholds(thresholds []BasicRuleThreshold) {
-	sort.Slice(thresholds, func(i, j int) bool {
+	sort.SliceStable(thresholds, func(i, j int) bool {
+		// the thresholds of the composite and burn rate rules have no target, they keep their order
+		if thresholds[i].TargetValue == nil || thresholds[j].TargetValue == nil {
+			return false
+		}
 
 		compareOp := thresholds[i].getCompareOp()
 		targetI := thresholds[i].target(thresholds[i].TargetUnit) //for sorting we dont need rule unit
@@ -0,0 +1,359 @@
+package slotypes
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/uptrace/bun"
+)
+
+var (
+	ErrCodeSLONotFound = errors.MustNewCode("slo_not_found")
+	ErrCodeSLOInvalid  = errors.MustNewCode("slo_invalid")
+)
+
+const (
+	// LabelSLOID is set on the alerts of the burn rate rule of an SLO.
+	LabelSLOID = "slo_id"
+)
+
+type WindowKind struct {
+	valuer.String
+}
+
+var (
+	// The window spans the duration of the window up to now.
+	WindowKindRolling = WindowKind{valuer.NewString("rolling")}
+	// The window spans the current calendar period, the budget is reset at the start of every period.
+	WindowKindCalendar = WindowKind{valuer.NewString("calendar")}
+)
+
+func (WindowKind) Enum() []any {
+	return []any{
+		WindowKindRolling,
+		WindowKindCalendar,
+	}
+}
+
+type CalendarPeriod struct {
+	valuer.String
+}
+
+var (
+	CalendarPeriodWeek  = CalendarPeriod{valuer.NewString("week")}
+	CalendarPeriodMonth = CalendarPeriod{valuer.NewString("month")}
+)
+
+func (CalendarPeriod) Enum() []any {
+	return []any{
+		CalendarPeriodWeek,
+		CalendarPeriodMonth,
+	}
+}
+
+// Window is the compliance period over which the objective of an SLO is measured.
+type Window struct {
+	Kind WindowKind `json:"kind"`
+	// Duration of a rolling window, e.g. 720h for 30 days.
+	Duration valuer.TextDuration `json:"duration,omitzero"`
+	// Period of a calendar window. Weeks start on monday, the periods are in UTC.
+	Period CalendarPeriod `json:"period,omitzero"`
+}
+
+func (window Window) Validate() error {
+	switch window.Kind {
+	case WindowKindRolling:
+		if !window.Duration.IsPositive() {
+			return errors.Newf(errors.TypeInvalidInput, ErrCodeSLOInvalid, "duration of a rolling window must be positive")
+		}
+	case WindowKindCalendar:
+		if window.Period != CalendarPeriodWeek && window.Period != CalendarPeriodMonth {
+			return errors.Newf(errors.TypeInvalidInput, ErrCodeSLOInvalid, "period of a calendar window must be one of %s, %s", CalendarPeriodWeek.StringValue(), CalendarPeriodMonth.StringValue())
+		}
+	default:
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeSLOInvalid, "kind of the window must be one of %s, %s", WindowKindRolling.StringValue(), WindowKindCalendar.StringValue())
+	}
+
+	return nil
+}
+
+// Range returns the start and the end of the window at ts.
+func (window Window) Range(ts time.Time) (time.Time, time.Time) {
+	if window.Kind == WindowKindRolling {
+		return ts.Add(-window.Duration.Duration()), ts
+	}
+
+	ts = ts.UTC()
+	day := time.Date(ts.Year(), ts.Month(), ts.Day(), 0, 0, 0, 0, time.UTC)
+	if window.Period == CalendarPeriodWeek {
+		// time.Sunday is 0, the weeks start on monday
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7), ts
+	}
+
+	return day.AddDate(0, 0, 1-day.Day()), ts
+}
+
+// Alerting configures the burn rate alerts of an SLO.
+type Alerting struct {
+	Enabled  bool              `json:"enabled"`
+	Channels []string          `json:"channels,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	// Windows default to ruletypes.DefaultBurnRateWindows.
+	Windows []ruletypes.BurnRateWindow `json:"windows,omitempty"`
+}
+
+type PostableSLO struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Good and Total are the builder queries counting the good and all the events of the SLI.
+	Good  qbtypes.QueryEnvelope `json:"good"`
+	Total qbtypes.QueryEnvelope `json:"total"`
+	// Target is the objective of the SLI in percent, e.g. 99.9
+	Target   float64  `json:"target"`
+	Window   Window   `json:"window"`
+	Alerting Alerting `json:"alerting"`
+}
+
+func (postable *PostableSLO) Validate() error {
+	if strings.TrimSpace(postable.Name) == "" {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeSLOInvalid, "name is required")
+	}
+
+	if err := ruletypes.ValidateSLIQueries(postable.Good, postable.Total); err != nil {
+		return err
+	}
+
+	if postable.Target <= 0 || postable.Target >= 100 {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeSLOInvalid, "target must be between 0 and 100 exclusive, got %v", postable.Target)
+	}
+
+	if err := postable.Window.Validate(); err != nil {
+		return err
+	}
+
+	if postable.Alerting.Enabled {
+		if err := postable.burnRateCondition().Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (postable *PostableSLO) burnRateCondition() *ruletypes.BurnRateCondition {
+	windows := postable.Alerting.Windows
+	if len(windows) == 0 {
+		windows = ruletypes.DefaultBurnRateWindows()
+	}
+
+	return &ruletypes.BurnRateCondition{
+		Good:    postable.Good,
+		Total:   postable.Total,
+		Target:  postable.Target,
+		Windows: windows,
+	}
+}
+
+type SLO struct {
+	types.Identifiable
+	types.TimeAuditable
+	types.UserAuditable
+	PostableSLO
+	OrgID valuer.UUID `json:"orgId"`
+	// RuleID is the id of the burn rate rule of the SLO, it is empty when alerting is disabled.
+	RuleID string `json:"ruleId,omitempty"`
+}
+
+type StorableSLO struct {
+	bun.BaseModel `bun:"table:slo,alias:slo"`
+
+	types.Identifiable
+	types.TimeAuditable
+	types.UserAuditable
+	OrgID  valuer.UUID `bun:"org_id,type:text,notnull"`
+	Name   string      `bun:"name,type:text,notnull"`
+	Data   string      `bun:"data,type:text,notnull"`
+	RuleID string      `bun:"rule_id,type:text"`
+}
+
+func NewSLO(orgID valuer.UUID, createdBy string, postable *PostableSLO) (*SLO, error) {
+	if err := postable.Validate(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &SLO{
+		Identifiable: types.Identifiable{
+			ID: valuer.GenerateUUID(),
+		},
+		TimeAuditable: types.TimeAuditable{
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		UserAuditable: types.UserAuditable{
+			CreatedBy: createdBy,
+			UpdatedBy: createdBy,
+		},
+		PostableSLO: *postable,
+		OrgID:       orgID,
+	}, nil
+}
+
+// Update replaces the definition of the SLO.
+func (slo *SLO) Update(updatedBy string, postable *PostableSLO) error {
+	if err := postable.Validate(); err != nil {
+		return err
+	}
+
+	slo.PostableSLO = *postable
+	slo.UpdatedBy = updatedBy
+	slo.UpdatedAt = time.Now()
+	return nil
+}
+
+// NewPostableRule returns the burn rate rule alerting on the error budget of the SLO.
+func (slo *SLO) NewPostableRule() *ruletypes.PostableRule {
+	condition := slo.burnRateCondition()
+
+	var longest time.Duration
+	for _, window := range condition.Windows {
+		longest = max(longest, window.Long.Duration())
+	}
+
+	lbls := maps.Clone(slo.Alerting.Labels)
+	if lbls == nil {
+		lbls = map[string]string{}
+	}
+	lbls[LabelSLOID] = slo.ID.StringValue()
+
+	return &ruletypes.PostableRule{
+		AlertName:   fmt.Sprintf("%s error budget burn", slo.Name),
+		AlertType:   alertTypeOf(slo.Good),
+		Description: slo.Description,
+		RuleType:    ruletypes.RuleTypeBurnRate,
+		// the thresholds of the windows default to the preferred channels
+		RuleCondition: &ruletypes.RuleCondition{
+			BurnRate: condition,
+		},
+		Labels: lbls,
+		Annotations: map[string]string{
+			"summary":     fmt.Sprintf("The error budget of the SLO %s is burning too fast", slo.Name),
+			"description": fmt.Sprintf("The SLO %s targets %v%% of good events over its window.", slo.Name, slo.Target),
+		},
+		PreferredChannels: slo.Alerting.Channels,
+		Evaluation: &ruletypes.EvaluationEnvelope{
+			Kind: ruletypes.RollingEvaluation,
+			Spec: ruletypes.RollingWindow{
+				EvalWindow: valuer.MustParseTextDuration(longest.String()),
+				Frequency:  valuer.MustParseTextDuration("1m"),
+			},
+		},
+		SchemaVersion: "v2",
+	}
+}
+
+func alertTypeOf(envelope qbtypes.QueryEnvelope) ruletypes.AlertType {
+	switch envelope.Spec.(type) {
+	case qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]:
+		return ruletypes.AlertTypeTraces
+	case qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]:
+		return ruletypes.AlertTypeLogs
+	default:
+		return ruletypes.AlertTypeMetric
+	}
+}
+
+// Signal returns the signal of the good events of the SLO.
+func (slo *SLO) Signal() telemetrytypes.Signal {
+	switch alertTypeOf(slo.Good) {
+	case ruletypes.AlertTypeTraces:
+		return telemetrytypes.SignalTraces
+	case ruletypes.AlertTypeLogs:
+		return telemetrytypes.SignalLogs
+	default:
+		return telemetrytypes.SignalMetrics
+	}
+}
+
+func NewStorableSLOFromSLO(slo *SLO) (*StorableSLO, error) {
+	data, err := json.Marshal(slo.PostableSLO)
+	if err != nil {
+		return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to marshal slo")
+	}
+
+	return &StorableSLO{
+		Identifiable:  slo.Identifiable,
+		TimeAuditable: slo.TimeAuditable,
+		UserAuditable: slo.UserAuditable,
+		OrgID:         slo.OrgID,
+		Name:          slo.Name,
+		Data:          string(data),
+		RuleID:        slo.RuleID,
+	}, nil
+}
+
+func NewSLOFromStorableSLO(storable *StorableSLO) (*SLO, error) {
+	postable := PostableSLO{}
+	if err := json.Unmarshal([]byte(storable.Data), &postable); err != nil {
+		return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to unmarshal slo %s", storable.ID.StringValue())
+	}
+
+	return &SLO{
+		Identifiable:  storable.Identifiable,
+		TimeAuditable: storable.TimeAuditable,
+		UserAuditable: storable.UserAuditable,
+		PostableSLO:   postable,
+		OrgID:         storable.OrgID,
+		RuleID:        storable.RuleID,
+	}, nil
+}
+
+// Status is the compliance of an SLO over its current window.
+type Status struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	Good  float64   `json:"good"`
+	Total float64   `json:"total"`
+	// SLI is the percentage of good events, it is 100 without any event.
+	SLI    float64 `json:"sli"`
+	Target float64 `json:"target"`
+	// ErrorBudget is the number of bad events allowed by the target over the window so far.
+	ErrorBudget float64 `json:"errorBudget"`
+	// ErrorBudgetRemaining is the ratio of the error budget which is not consumed yet, it is negative once the
+	// objective is missed.
+	ErrorBudgetRemaining float64 `json:"errorBudgetRemaining"`
+}
+
+func NewStatus(target float64, start time.Time, end time.Time, good float64, total float64) *Status {
+	status := &Status{
+		Start:                start,
+		End:                  end,
+		Good:                 good,
+		Total:                total,
+		SLI:                  100,
+		Target:               target,
+		ErrorBudget:          total * (1 - target/100),
+		ErrorBudgetRemaining: 1,
+	}
+
+	if total > 0 {
+		status.SLI = 100 * good / total
+		status.ErrorBudgetRemaining = 1 - (total-good)/status.ErrorBudget
+	}
+
+	return status
+}
+
+// Met returns true if the objective is met over the window so far.
+func (status *Status) Met() bool {
+	return status.SLI >= status.Target
+}
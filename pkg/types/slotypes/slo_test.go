@@ -0,0 +1,153 @@
+package slotypes
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPostableSLO = `{
+	"name": "checkout availability",
+	"good": {"type": "builder_query", "spec": {"name": "A", "signal": "traces", "aggregations": [{"expression": "count()"}], "filter": {"expression": "service.name = 'checkout' AND has_error = false"}}},
+	"total": {"type": "builder_query", "spec": {"name": "B", "signal": "traces", "aggregations": [{"expression": "count()"}], "filter": {"expression": "service.name = 'checkout'"}}},
+	"target": 99.9,
+	"window": {"kind": "rolling", "duration": "720h"},
+	"alerting": {"enabled": true, "channels": ["oncall"], "labels": {"team": "payments"}}
+}`
+
+func newTestPostableSLO(t *testing.T) *PostableSLO {
+	t.Helper()
+
+	postable := new(PostableSLO)
+	require.NoError(t, json.Unmarshal([]byte(testPostableSLO), postable))
+	return postable
+}
+
+func TestPostableSLO_Validate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		mutate      func(*PostableSLO)
+		expectedErr bool
+	}{
+		{name: "Valid", mutate: func(*PostableSLO) {}},
+		{name: "MissingName", mutate: func(p *PostableSLO) { p.Name = " " }, expectedErr: true},
+		{name: "TargetOutOfRange", mutate: func(p *PostableSLO) { p.Target = 100 }, expectedErr: true},
+		{name: "MissingTotal", mutate: func(p *PostableSLO) { p.Total.Spec = nil }, expectedErr: true},
+		{name: "CalendarWithoutPeriod", mutate: func(p *PostableSLO) { p.Window = Window{Kind: WindowKindCalendar} }, expectedErr: true},
+		{name: "CalendarMonth", mutate: func(p *PostableSLO) { p.Window = Window{Kind: WindowKindCalendar, Period: CalendarPeriodMonth} }},
+		{
+			name: "InvalidBurnRateWindow",
+			mutate: func(p *PostableSLO) {
+				p.Alerting.Windows = []ruletypes.BurnRateWindow{{Name: "critical", Long: valuer.MustParseTextDuration("5m"), Short: valuer.MustParseTextDuration("1h"), BurnRate: 10}}
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			postable := newTestPostableSLO(t)
+			tc.mutate(postable)
+
+			err := postable.Validate()
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestWindow_Range(t *testing.T) {
+	// a thursday
+	ts := time.Date(2025, time.May, 15, 13, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		window        Window
+		expectedStart time.Time
+	}{
+		{name: "Rolling", window: Window{Kind: WindowKindRolling, Duration: valuer.MustParseTextDuration("168h")}, expectedStart: ts.Add(-7 * 24 * time.Hour)},
+		{name: "Week", window: Window{Kind: WindowKindCalendar, Period: CalendarPeriodWeek}, expectedStart: time.Date(2025, time.May, 12, 0, 0, 0, 0, time.UTC)},
+		{name: "Month", window: Window{Kind: WindowKindCalendar, Period: CalendarPeriodMonth}, expectedStart: time.Date(2025, time.May, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			start, end := tc.window.Range(ts)
+			assert.Equal(t, tc.expectedStart, start)
+			assert.Equal(t, ts, end)
+		})
+	}
+
+	// the week of a sunday starts on the previous monday
+	start, _ := Window{Kind: WindowKindCalendar, Period: CalendarPeriodWeek}.Range(time.Date(2025, time.May, 18, 10, 0, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2025, time.May, 12, 0, 0, 0, 0, time.UTC), start)
+}
+
+func TestNewStatus(t *testing.T) {
+	start, end := time.Unix(0, 0), time.Unix(3600, 0)
+
+	status := NewStatus(99, start, end, 9950, 10000)
+	assert.InDelta(t, 99.5, status.SLI, 1e-9)
+	assert.InDelta(t, 100, status.ErrorBudget, 1e-9)
+	assert.InDelta(t, 0.5, status.ErrorBudgetRemaining, 1e-9)
+	assert.True(t, status.Met())
+
+	status = NewStatus(99, start, end, 9800, 10000)
+	assert.InDelta(t, -1, status.ErrorBudgetRemaining, 1e-9)
+	assert.False(t, status.Met())
+
+	status = NewStatus(99, start, end, 0, 0)
+	assert.Equal(t, float64(100), status.SLI)
+	assert.Equal(t, float64(1), status.ErrorBudgetRemaining)
+}
+
+func TestSLO_NewPostableRule(t *testing.T) {
+	slo, err := NewSLO(valuer.GenerateUUID(), "admin@signoz.io", newTestPostableSLO(t))
+	require.NoError(t, err)
+
+	data, err := json.Marshal(slo.NewPostableRule())
+	require.NoError(t, err)
+
+	rule := new(ruletypes.PostableRule)
+	require.NoError(t, json.Unmarshal(data, rule))
+
+	assert.Equal(t, ruletypes.RuleType(ruletypes.RuleTypeBurnRate), rule.RuleType)
+	assert.Equal(t, ruletypes.AlertTypeTraces, rule.AlertType)
+	assert.Equal(t, slo.ID.StringValue(), rule.Labels[LabelSLOID])
+	assert.Equal(t, "payments", rule.Labels["team"])
+	require.NotNil(t, rule.RuleCondition.BurnRate)
+	assert.Equal(t, 99.9, rule.RuleCondition.BurnRate.Target)
+	assert.Equal(t, ruletypes.DefaultBurnRateWindows(), rule.RuleCondition.BurnRate.Windows)
+
+	receivers := rule.RuleCondition.Thresholds.Spec.(ruletypes.BasicRuleThresholds).GetRuleReceivers()
+	require.Len(t, receivers, 2)
+	for _, receiver := range receivers {
+		assert.Equal(t, []string{"oncall"}, receiver.Channels)
+	}
+}
+
+func TestSLO_StorableRoundTrip(t *testing.T) {
+	slo, err := NewSLO(valuer.GenerateUUID(), "admin@signoz.io", newTestPostableSLO(t))
+	require.NoError(t, err)
+	slo.RuleID = valuer.GenerateUUID().StringValue()
+
+	storable, err := NewStorableSLOFromSLO(slo)
+	require.NoError(t, err)
+
+	got, err := NewSLOFromStorableSLO(storable)
+	require.NoError(t, err)
+	assert.Equal(t, slo.ID, got.ID)
+	assert.Equal(t, slo.RuleID, got.RuleID)
+	assert.Equal(t, slo.Name, got.Name)
+	assert.Equal(t, slo.Window, got.Window)
+	assert.Equal(t, slo.Alerting, got.Alerting)
+	assert.Equal(t, slo.Good.Spec, got.Good.Spec)
+}
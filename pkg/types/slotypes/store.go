@@ -0,0 +1,19 @@
+package slotypes
+
+import (
+	"context"
+
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type Store interface {
+	Create(context.Context, *StorableSLO) error
+
+	Get(context.Context, valuer.UUID, valuer.UUID) (*StorableSLO, error)
+
+	List(context.Context, valuer.UUID) ([]*StorableSLO, error)
+
+	Update(context.Context, *StorableSLO) error
+
+	Delete(context.Context, valuer.UUID, valuer.UUID) error
+}